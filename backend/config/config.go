@@ -1,16 +1,39 @@
 package config
 
 import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/disgoorg/bot-template/backend/models"
 	"github.com/disgoorg/bot-template/bottemplate"
 )
 
+const (
+	// DefaultMaxUploadSize is the per-file upload limit used when none is configured
+	DefaultMaxUploadSize int64 = 10 * 1024 * 1024
+	// DefaultMaxRequestSize is the request body limit used when none is configured
+	DefaultMaxRequestSize int64 = 100 * 1024 * 1024
+)
+
+// DefaultDevOrigins are the local frontend origins allowed in debug mode when none are configured
+var DefaultDevOrigins = []string{"http://localhost:3000", "http://localhost:8080"}
+
 // WebAppConfig contains web-specific configuration
 type WebAppConfig struct {
-	Config      *bottemplate.Config
-	Debug       bool
-	Environment string
+	Config         *bottemplate.Config
+	Debug          bool
+	Environment    string
+	MaxUploadSize  int64 // Per-file upload limit in bytes
+	MaxRequestSize int64 // Request body limit in bytes
+	CardManagement CardManagementConfig
+	Metrics        bottemplate.MetricsConfig
+	// RolePermissions maps Discord role IDs to the web permissions they grant
+	RolePermissions map[string][]string
+	// AllowedOrigins are the CORS origins allowed to make credentialed requests
+	AllowedOrigins []string
 }
 
 // CardManagementConfig contains settings for card management operations
@@ -23,6 +46,8 @@ type CardManagementConfig struct {
 	EnablePreview     bool          `toml:"enable_preview"`
 	UploadTimeout     time.Duration `toml:"upload_timeout"`
 	ProcessingTimeout time.Duration `toml:"processing_timeout"`
+	WebPQuality       int           `toml:"webp_quality"`
+	CWebPPath         string        `toml:"cwebp_path"`
 }
 
 // DefaultCardManagementConfig returns default configuration for card management
@@ -36,6 +61,8 @@ func DefaultCardManagementConfig() CardManagementConfig {
 		EnablePreview:     true,
 		UploadTimeout:     30 * time.Minute,
 		ProcessingTimeout: 60 * time.Minute,
+		WebPQuality:       80,
+		CWebPPath:         "cwebp",
 	}
 }
 
@@ -46,11 +73,91 @@ func NewWebAppConfig(cfg *bottemplate.Config, debug bool) *WebAppConfig {
 		environment = "development"
 	}
 
+	maxUploadSize := DefaultMaxUploadSize
+	if cfg.Web.MaxUploadMB > 0 {
+		maxUploadSize = int64(cfg.Web.MaxUploadMB) * 1024 * 1024
+	}
+
+	maxRequestSize := DefaultMaxRequestSize
+	if cfg.Web.MaxRequestMB > 0 {
+		maxRequestSize = int64(cfg.Web.MaxRequestMB) * 1024 * 1024
+	}
+	if maxRequestSize < maxUploadSize {
+		maxRequestSize = maxUploadSize
+	}
+
+	cardManagement := DefaultCardManagementConfig()
+	if q := cfg.Web.Images.WebPQuality; q > 0 && q <= 100 {
+		cardManagement.WebPQuality = q
+	}
+	if cfg.Web.Images.CWebPPath != "" {
+		cardManagement.CWebPPath = cfg.Web.Images.CWebPPath
+	}
+
+	allowedOrigins := make([]string, 0, len(cfg.Web.AllowedOrigins))
+	for _, origin := range cfg.Web.AllowedOrigins {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			allowedOrigins = append(allowedOrigins, origin)
+		}
+	}
+	if len(allowedOrigins) == 0 && debug {
+		allowedOrigins = append(allowedOrigins, DefaultDevOrigins...)
+	}
+
 	return &WebAppConfig{
-		Config:      cfg,
-		Debug:       debug,
-		Environment: environment,
+		Config:          cfg,
+		Debug:           debug,
+		Environment:     environment,
+		MaxUploadSize:   maxUploadSize,
+		MaxRequestSize:  maxRequestSize,
+		CardManagement:  cardManagement,
+		Metrics:         cfg.Web.Metrics,
+		RolePermissions: resolveRolePermissions(cfg.Web.RolePermissions),
+		AllowedOrigins:  allowedOrigins,
+	}
+}
+
+// ValidateOrigins checks that every allowed origin is a bare http(s) origin.
+// Wildcards are rejected because the API is called with credentials.
+func (w *WebAppConfig) ValidateOrigins() error {
+	if len(w.AllowedOrigins) == 0 {
+		return fmt.Errorf("no allowed origins configured; set web.allowed_origins")
+	}
+
+	for _, origin := range w.AllowedOrigins {
+		if origin == "*" {
+			return fmt.Errorf("wildcard origin is not allowed with credentialed requests")
+		}
+		u, err := url.Parse(origin)
+		if err != nil {
+			return fmt.Errorf("invalid origin %q: %w", origin, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid origin %q: scheme must be http or https", origin)
+		}
+		if u.Host == "" || u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return fmt.Errorf("invalid origin %q: expected scheme://host[:port]", origin)
+		}
+	}
+	return nil
+}
+
+// resolveRolePermissions drops unknown permissions from the role mapping so a
+// typo in the config can't silently grant nothing or something unexpected
+func resolveRolePermissions(mapping map[string][]string) map[string][]string {
+	resolved := make(map[string][]string, len(mapping))
+	for roleID, permissions := range mapping {
+		for _, permission := range permissions {
+			if !models.IsKnownPermission(permission) {
+				slog.Warn("Ignoring unknown permission in role mapping",
+					slog.String("role_id", roleID),
+					slog.String("permission", permission))
+				continue
+			}
+			resolved[roleID] = append(resolved[roleID], permission)
+		}
 	}
+	return resolved
 }
 
 // GetDatabaseConfig returns the database configuration
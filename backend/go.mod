@@ -10,6 +10,7 @@ require (
 	github.com/disgoorg/bot-template v0.0.0-00010101000000-000000000000
 	github.com/gofiber/fiber/v2 v2.52.8
 	github.com/uptrace/bun v1.2.5
+	github.com/uptrace/bun/dialect/pgdialect v1.2.5
 )
 
 require (
@@ -62,7 +63,6 @@ require (
 	github.com/sahilm/fuzzy v0.1.1 // indirect
 	github.com/sasha-s/go-csync v0.0.0-20240107134140-fcbab37b09ad // indirect
 	github.com/tmthrgd/go-hex v0.0.0-20190904060850-447a3041c3bc // indirect
-	github.com/uptrace/bun/dialect/pgdialect v1.2.5 // indirect
 	github.com/uptrace/bun/driver/pgdriver v1.2.5 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasthttp v1.62.0 // indirect
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	webmodels "github.com/disgoorg/bot-template/backend/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+type fakeAuditRepo struct {
+	repositories.AdminAuditRepository
+	filter        repositories.AdminAuditFilter
+	offset, limit int
+}
+
+func (r *fakeAuditRepo) List(ctx context.Context, filter repositories.AdminAuditFilter, offset, limit int) ([]*models.AdminAudit, int, error) {
+	r.filter, r.offset, r.limit = filter, offset, limit
+	return []*models.AdminAudit{{ID: 7, ActorID: filter.ActorID, Command: "deletecard", Target: "card:42"}}, 61, nil
+}
+
+func TestAdminAuditListFilters(t *testing.T) {
+	repo := &fakeAuditRepo{}
+	webApp := &WebApp{Repos: &webmodels.Repositories{AdminAudit: repo}}
+
+	status, body := callHandler(t, AdminAuditList(webApp), "GET", "/audit",
+		"/audit?actor=admin1&command=deletecard&from=2026-03-01&to=2026-03-02T12:00:00Z&page=3&limit=20")
+	if status != 200 {
+		t.Fatalf("status = %d, body %v", status, body)
+	}
+
+	want := repositories.AdminAuditFilter{
+		ActorID: "admin1",
+		Command: "deletecard",
+		From:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC),
+	}
+	if repo.filter.ActorID != want.ActorID || repo.filter.Command != want.Command ||
+		!repo.filter.From.Equal(want.From) || !repo.filter.To.Equal(want.To) {
+		t.Errorf("filter = %+v, want %+v", repo.filter, want)
+	}
+	if repo.offset != 40 || repo.limit != 20 {
+		t.Errorf("offset %d limit %d, want 40 and 20", repo.offset, repo.limit)
+	}
+
+	data := body["data"].(map[string]interface{})
+	pagination := data["pagination"].(map[string]interface{})
+	if pagination["total_pages"] != 4.0 {
+		t.Errorf("pagination = %v, want 4 pages", pagination)
+	}
+	if entries := data["entries"].([]interface{}); len(entries) != 1 {
+		t.Errorf("entries = %v", entries)
+	}
+}
+
+func TestAdminAuditListRejectsBadDates(t *testing.T) {
+	webApp := &WebApp{Repos: &webmodels.Repositories{AdminAudit: &fakeAuditRepo{}}}
+
+	status, body := callHandler(t, AdminAuditList(webApp), "GET", "/audit", "/audit?from=last-week")
+	if status != 400 {
+		t.Fatalf("status = %d, want 400", status)
+	}
+	if code := body["error"].(map[string]interface{})["code"]; code != "INVALID_DATE" {
+		t.Errorf("code = %v, want INVALID_DATE", code)
+	}
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     []string
+		tags        []string
+		add         bool
+		want        []string
+		wantChanged bool
+	}{
+		{name: "add new", current: []string{"girlgroups"}, tags: []string{"summer", "winter"}, add: true, want: []string{"girlgroups", "summer", "winter"}, wantChanged: true},
+		{name: "add existing", current: []string{"girlgroups", "Summer"}, tags: []string{"summer", "girlgroups"}, add: true, want: []string{"girlgroups", "Summer"}},
+		{name: "add duplicates once", current: nil, tags: []string{"summer", "summer"}, add: true, want: []string{"summer"}, wantChanged: true},
+		{name: "remove", current: []string{"girlgroups", "Summer", "winter"}, tags: []string{"summer"}, want: []string{"girlgroups", "winter"}, wantChanged: true},
+		{name: "remove missing", current: []string{"girlgroups"}, tags: []string{"summer"}, want: []string{"girlgroups"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := editTags(tt.current, tt.tags, tt.add)
+			if !reflect.DeepEqual(got, tt.want) || changed != tt.wantChanged {
+				t.Errorf("editTags = %q, %t, want %q, %t", got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestEditTagsIdempotent(t *testing.T) {
+	for _, add := range []bool{true, false} {
+		once, _ := editTags([]string{"girlgroups", "summer"}, []string{"summer", "winter"}, add)
+		twice, changed := editTags(once, []string{"summer", "winter"}, add)
+		if changed || !reflect.DeepEqual(once, twice) {
+			t.Errorf("add=%t: second edit = %q, %t, want %q unchanged", add, twice, changed, once)
+		}
+	}
+}
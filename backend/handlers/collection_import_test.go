@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	webservices "github.com/disgoorg/bot-template/backend/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// batchImportForm builds a multipart body with the collections JSON and one PNG per file name
+func batchImportForm(t *testing.T, collections string, files ...string) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.WriteField("collections", collections); err != nil {
+		t.Fatalf("write collections: %v", err)
+	}
+	for _, name := range files {
+		part, err := w.CreateFormFile("files", name)
+		if err != nil {
+			t.Fatalf("create form file: %v", err)
+		}
+		if _, err := part.Write(pngBytes(t, 1)); err != nil {
+			t.Fatalf("write form file: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close multipart writer: %v", err)
+	}
+	return &body, w.FormDataContentType()
+}
+
+func readBatchForm(t *testing.T, collections string, files ...string) *multipart.Form {
+	t.Helper()
+	body, contentType := batchImportForm(t, collections, files...)
+	boundary := strings.TrimPrefix(contentType, "multipart/form-data; boundary=")
+	form, err := multipart.NewReader(body, boundary).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("read form: %v", err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+	return form
+}
+
+func TestParseBatchCollectionImport(t *testing.T) {
+	form := readBatchForm(t, `[
+		{"collection_id": "twice", "display_name": "Twice", "group_type": "girlgroups", "files": ["1_momo.png", "2_sana.png"]},
+		{"collection_id": "bts", "display_name": "BTS", "group_type": "boygroups", "files": ["1_jin.png"]}
+	]`, "1_momo.png", "2_sana.png", "1_jin.png")
+
+	reqs, err := parseBatchCollectionImport(form)
+	if err != nil {
+		t.Fatalf("parseBatchCollectionImport: %v", err)
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("got %d requests, want 2", len(reqs))
+	}
+	if reqs[0].CollectionID != "twice" || len(reqs[0].Files) != 2 || reqs[0].Files[1].Name != "2_sana.png" {
+		t.Errorf("twice = %+v", reqs[0])
+	}
+	if reqs[1].CollectionID != "bts" || len(reqs[1].Files) != 1 || reqs[1].Files[0].ContentType != "image/png" {
+		t.Errorf("bts = %+v", reqs[1])
+	}
+}
+
+func TestParseBatchCollectionImportRejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		collections string
+		files       []string
+		wantErr     string
+	}{
+		{name: "missing collections", wantErr: "collections data is required"},
+		{name: "empty list", collections: `[]`, wantErr: "no collections provided"},
+		{
+			name:        "file not uploaded",
+			collections: `[{"collection_id": "twice", "files": ["1_momo.png"]}]`,
+			wantErr:     "file 1_momo.png for collection twice was not uploaded",
+		},
+		{
+			name:        "collection listed twice",
+			collections: `[{"collection_id": "twice"}, {"collection_id": "twice"}]`,
+			wantErr:     "collection twice is listed more than once",
+		},
+		{
+			name:        "file claimed by two collections",
+			collections: `[{"collection_id": "twice", "files": ["1_momo.png"]}, {"collection_id": "bts", "files": ["1_momo.png"]}]`,
+			files:       []string{"1_momo.png"},
+			wantErr:     "file 1_momo.png is listed by both twice and bts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseBatchCollectionImport(readBatchForm(t, tt.collections, tt.files...))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCardsImportCollectionsRejectsBatchWithInvalidCollection(t *testing.T) {
+	// The valid collection must not be imported either, so no repositories are wired up
+	webApp := &WebApp{CollectionImportService: webservices.NewCollectionImportService(nil, nil, nil, nil)}
+	body, contentType := batchImportForm(t, `[
+		{"collection_id": "twice", "display_name": "Twice", "group_type": "girlgroups", "files": ["1_momo.png"]},
+		{"collection_id": "bts", "display_name": "BTS", "group_type": "kpop", "files": ["1_jin.png"]}
+	]`, "1_momo.png", "1_jin.png")
+
+	app := fiber.New()
+	app.Post("/collections/import/batch", CardsImportCollections(webApp))
+	req := httptest.NewRequest("POST", "/collections/import/batch", body)
+	req.Header.Set("Content-Type", contentType)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	var got struct {
+		Error struct {
+			Code    string            `json:"code"`
+			Details map[string]string `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("decode %q: %v", raw, err)
+	}
+
+	if resp.StatusCode != 400 || got.Error.Code != "INVALID_COLLECTIONS" {
+		t.Fatalf("status = %d, code = %q, want 400 INVALID_COLLECTIONS", resp.StatusCode, got.Error.Code)
+	}
+	if len(got.Error.Details) != 1 || !strings.Contains(got.Error.Details["bts"], "group type") {
+		t.Errorf("details = %v, want only bts reported", got.Error.Details)
+	}
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	webmodels "github.com/disgoorg/bot-template/backend/models"
+	"github.com/disgoorg/bot-template/backend/services/spacestest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+type fakeCollectionRepo struct {
+	repositories.CollectionRepository
+	collections map[string]*models.Collection
+	// stale makes UpdateIfUnchanged report a concurrent change
+	stale bool
+}
+
+func (r *fakeCollectionRepo) GetByID(ctx context.Context, id string) (*models.Collection, error) {
+	if collection, ok := r.collections[id]; ok {
+		return collection, nil
+	}
+	return nil, errors.New("collection not found")
+}
+
+// fakeCardSearchRepo returns every card in the filtered collection, ignoring the page
+type fakeCardSearchRepo struct {
+	repositories.CardRepository
+	cards []*models.Card
+}
+
+func (r *fakeCardSearchRepo) Search(ctx context.Context, filters repositories.SearchFilters, offset, limit int) ([]*models.Card, int, error) {
+	var cards []*models.Card
+	for _, card := range r.cards {
+		if card.ColID == filters.Collection {
+			cards = append(cards, card)
+		}
+	}
+	return cards, len(cards), nil
+}
+
+func collectionsApp(spaces *spacestest.Fake) *WebApp {
+	webApp := &WebApp{Repos: &webmodels.Repositories{
+		Collection: &fakeCollectionRepo{collections: map[string]*models.Collection{
+			"twice": {ID: "twice", Name: "Twice", Tags: []string{"girlgroups"}},
+		}},
+		Card: &fakeCardSearchRepo{cards: []*models.Card{
+			{ID: 1, Name: "Na Yeon", ColID: "twice", Level: 1},
+			{ID: 2, Name: "Momo", ColID: "twice", Level: 3, Animated: true},
+			{ID: 3, Name: "Jin", ColID: "bts", Level: 2},
+		}},
+	}}
+	if spaces != nil {
+		webApp.SpacesService = spaces
+	}
+	return webApp
+}
+
+func TestCollectionsDetailImageURLs(t *testing.T) {
+	spaces := spacestest.New("cards")
+	status, body := callHandler(t, CollectionsDetail(collectionsApp(spaces)), "GET", "/collections/:id", "/collections/twice")
+	if status != 200 {
+		t.Fatalf("status = %d, body %v", status, body)
+	}
+
+	cards := body["data"].(map[string]interface{})["cards"].([]interface{})
+	if len(cards) != 2 {
+		t.Fatalf("got %d cards, want 2", len(cards))
+	}
+	want := []string{
+		spaces.GetCardImageURLWithFormat("Na Yeon", "twice", 1, "girlgroups", false, ""),
+		spaces.GetCardImageURLWithFormat("Momo", "twice", 3, "girlgroups", true, ""),
+	}
+	for i, card := range cards {
+		if got := card.(map[string]interface{})["image_url"]; got != want[i] {
+			t.Errorf("card %d image_url = %v, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestCollectionsDetailWithoutStorage(t *testing.T) {
+	// A nil *Fake must not end up in the interface, or the handler would call through it
+	status, body := callHandler(t, CollectionsDetail(collectionsApp(nil)), "GET", "/collections/:id", "/collections/twice")
+	if status != 200 {
+		t.Fatalf("status = %d, body %v", status, body)
+	}
+	for i, card := range body["data"].(map[string]interface{})["cards"].([]interface{}) {
+		if got := card.(map[string]interface{})["image_url"]; got != "" {
+			t.Errorf("card %d image_url = %v, want none", i, got)
+		}
+	}
+}
+
+func TestCollectionsDetailUnknownCollection(t *testing.T) {
+	status, body := callHandler(t, CollectionsDetail(collectionsApp(nil)), "GET", "/collections/:id", "/collections/itzy")
+	if status != 404 {
+		t.Fatalf("status = %d, want 404", status)
+	}
+	if code := body["error"].(map[string]interface{})["code"]; code != "COLLECTION_NOT_FOUND" {
+		t.Errorf("code = %v, want COLLECTION_NOT_FOUND", code)
+	}
+}
+
+func (r *fakeCollectionRepo) Create(ctx context.Context, collection *models.Collection) error {
+	r.collections[collection.ID] = collection
+	return nil
+}
+
+func (r *fakeCollectionRepo) Update(ctx context.Context, collection *models.Collection) error {
+	r.collections[collection.ID] = collection
+	return nil
+}
+
+func (r *fakeCollectionRepo) UpdateIfUnchanged(ctx context.Context, collection *models.Collection, expectedUpdatedAt time.Time) error {
+	if r.stale {
+		return repositories.ErrStaleUpdate
+	}
+	return r.Update(ctx, collection)
+}
+
+func errorCode(body map[string]interface{}) interface{} {
+	if e, ok := body["error"].(map[string]interface{}); ok {
+		return e["code"]
+	}
+	return nil
+}
+
+func TestCollectionsCreateType(t *testing.T) {
+	webApp := collectionsApp(nil)
+	collections := webApp.Repos.Collection.(*fakeCollectionRepo).collections
+
+	status, body := callHandlerJSON(t, CollectionsCreate(webApp), "POST", "/collections", "/collections",
+		`{"id":"ive","name":"IVE","collection_type":"girls"}`)
+	if status != 400 || errorCode(body) != "INVALID_COLLECTION_TYPE" {
+		t.Errorf("invalid type: status %d, code %v, want 400 INVALID_COLLECTION_TYPE", status, errorCode(body))
+	}
+	if _, ok := collections["ive"]; ok {
+		t.Error("collection created with an invalid type")
+	}
+
+	status, _ = callHandlerJSON(t, CollectionsCreate(webApp), "POST", "/collections", "/collections",
+		`{"id":"ive","name":"IVE","collection_type":"girl_group"}`)
+	if status != 200 || collections["ive"] == nil || collections["ive"].Type != models.CollectionTypeGirlGroup {
+		t.Errorf("valid type: status %d, stored %+v", status, collections["ive"])
+	}
+}
+
+func TestCollectionsUpdateType(t *testing.T) {
+	webApp := collectionsApp(nil)
+	twice := webApp.Repos.Collection.(*fakeCollectionRepo).collections["twice"]
+
+	status, body := callHandlerJSON(t, CollectionsUpdate(webApp), "PUT", "/collections/:id", "/collections/twice",
+		`{"collection_type":""}`)
+	if status != 400 || errorCode(body) != "INVALID_COLLECTION_TYPE" {
+		t.Errorf("empty type: status %d, code %v, want 400 INVALID_COLLECTION_TYPE", status, errorCode(body))
+	}
+
+	status, _ = callHandlerJSON(t, CollectionsUpdate(webApp), "PUT", "/collections/:id", "/collections/twice",
+		`{"collection_type":"other"}`)
+	if status != 200 || twice.Type != models.CollectionTypeOther {
+		t.Errorf("status %d, type %q, want 200 and other", status, twice.Type)
+	}
+
+	// Leaving the type out keeps it
+	status, _ = callHandlerJSON(t, CollectionsUpdate(webApp), "PUT", "/collections/:id", "/collections/twice",
+		`{"name":"TWICE"}`)
+	if status != 200 || twice.Type != models.CollectionTypeOther || twice.Name != "TWICE" {
+		t.Errorf("status %d, collection %+v", status, twice)
+	}
+}
+
+func TestCollectionsDetailClampsLimit(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantLimit float64
+		wantPage  float64
+	}{
+		{query: "?limit=500", wantLimit: webmodels.MaxCardPageLimit, wantPage: 1},
+		{query: "?limit=100&page=0", wantLimit: 100, wantPage: 1},
+		{query: "?limit=0", wantLimit: webmodels.DefaultCardPageLimit, wantPage: 1},
+	}
+	for _, tt := range tests {
+		status, body := callHandler(t, CollectionsDetail(collectionsApp(nil)), "GET", "/collections/:id", "/collections/twice"+tt.query)
+		if status != 200 {
+			t.Fatalf("%s: status = %d, body %v", tt.query, status, body)
+		}
+		pagination := body["data"].(map[string]interface{})["pagination"].(map[string]interface{})
+		if pagination["limit"] != tt.wantLimit || pagination["page"] != tt.wantPage {
+			t.Errorf("%s: page %v, limit %v, want %v and %v", tt.query, pagination["page"], pagination["limit"], tt.wantPage, tt.wantLimit)
+		}
+	}
+}
+
+func TestCollectionsUpdateStale(t *testing.T) {
+	webApp := collectionsApp(nil)
+	repo := webApp.Repos.Collection.(*fakeCollectionRepo)
+	const body = `{"name":"TWICE","expected_updated_at":"2024-05-01T10:00:00.123Z"}`
+
+	repo.stale = true
+	status, resp := callHandlerJSON(t, CollectionsUpdate(webApp), "PUT", "/collections/:id", "/collections/twice", body)
+	if status != 409 || errorCode(resp) != "STALE_UPDATE" {
+		t.Errorf("stale update: status %d, code %v, want 409 STALE_UPDATE", status, errorCode(resp))
+	}
+
+	repo.stale = false
+	status, _ = callHandlerJSON(t, CollectionsUpdate(webApp), "PUT", "/collections/:id", "/collections/twice", body)
+	if status != 200 {
+		t.Errorf("fresh update: status %d, want 200", status)
+	}
+}
@@ -1,11 +1,19 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"mime/multipart"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -26,13 +34,16 @@ type WebApp struct {
 	Config                  *config.WebAppConfig
 	DB                      *database.DB
 	Repos                   *webmodels.Repositories
-	SpacesService           interface{} // Placeholder for now
+	SpacesService           webservices.SpacesClient
 	CardMgmtService         *webservices.CardManagementService
 	CardImportService       *webservices.CardImportService
 	SyncMgrService          *webservices.SyncManagerService
 	OAuthService            *webservices.OAuthService
 	SessionService          *webservices.SessionService
 	CollectionImportService *webservices.CollectionImportService
+	Health                  *webservices.HealthChecker
+	Metrics                 *webservices.MetricsRegistry // nil when metrics are disabled
+	VoteRewards             *services.VoteRewardService
 	Version                 string
 	Commit                  string
 }
@@ -109,67 +120,114 @@ func getDashboardStats(ctx context.Context, webApp *WebApp) (*webmodels.Dashboar
 	}, nil
 }
 
-// processUploadedFile processes a single uploaded file
+// processUploadedFile validates a single uploaded file and streams it to Spaces
 func processUploadedFile(ctx context.Context, webApp *WebApp, file *multipart.FileHeader) fiber.Map {
-	// Validate file size (max 10MB)
-	const maxFileSize = 10 * 1024 * 1024
+	// Validate file size against the configured limit
+	maxFileSize := webApp.Config.MaxUploadSize
 	if file.Size > maxFileSize {
 		return fiber.Map{
 			"filename": file.Filename,
 			"success":  false,
-			"error":    "File too large (max 10MB)",
+			"code":     "FILE_TOO_LARGE",
+			"error":    fmt.Sprintf("File too large (max %dMB)", maxFileSize/(1024*1024)),
 		}
 	}
 
-	// Validate file type
-	allowedTypes := map[string]bool{
-		"image/jpeg": true,
-		"image/jpg":  true,
-		"image/png":  true,
-		"image/webp": true,
-		"image/gif":  true,
+	// Open file
+	src, err := file.Open()
+	if err != nil {
+		return fiber.Map{
+			"filename": file.Filename,
+			"success":  false,
+			"code":     "FILE_ERROR",
+			"error":    "Failed to open file",
+		}
 	}
+	defer src.Close()
 
-	contentType := file.Header.Get("Content-Type")
-	if !allowedTypes[contentType] {
+	// Read just enough of the file to sniff its type
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
 		return fiber.Map{
 			"filename": file.Filename,
 			"success":  false,
-			"error":    "Invalid file type (only images allowed)",
+			"code":     "FILE_ERROR",
+			"error":    "Failed to read file",
 		}
 	}
 
-	// Open file
-	src, err := file.Open()
+	// Validate file type from its content rather than the client-sent header
+	contentType, err := utils.DetectImageContentType(file.Filename, head[:n])
 	if err != nil {
 		return fiber.Map{
 			"filename": file.Filename,
 			"success":  false,
-			"error":    "Failed to open file",
+			"code":     "INVALID_FILE_TYPE",
+			"error":    fmt.Sprintf("Invalid file type: %s", err.Error()),
 		}
 	}
-	defer src.Close()
 
-	// Read file data
-	fileData := make([]byte, file.Size)
-	_, err = src.Read(fileData)
-	if err != nil {
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
 		return fiber.Map{
 			"filename": file.Filename,
 			"success":  false,
+			"code":     "FILE_ERROR",
 			"error":    "Failed to read file",
 		}
 	}
 
-	// TODO: Upload to spaces service
-	// For now, just return success with placeholder URL
+	spacesService := webApp.SpacesService
+	if spacesService == nil {
+		return fiber.Map{
+			"filename": file.Filename,
+			"success":  false,
+			"code":     "STORAGE_UNAVAILABLE",
+			"error":    "Storage service is not configured",
+		}
+	}
+
+	// Uploads with the same filename must not replace each other, so every one gets its own prefix
+	path, err := uploadPath(file.Filename)
+	if err != nil {
+		slog.Error("Failed to generate upload path", slog.String("error", err.Error()))
+		return fiber.Map{
+			"filename": file.Filename,
+			"success":  false,
+			"code":     "UPLOAD_FAILED",
+			"error":    "Failed to upload file",
+		}
+	}
+
+	// Stream the file straight to Spaces instead of buffering it
+	if err := spacesService.UploadStream(ctx, src, file.Size, path, contentType); err != nil {
+		slog.Error("Failed to upload file",
+			slog.String("filename", file.Filename),
+			slog.String("error", err.Error()))
+		return fiber.Map{
+			"filename": file.Filename,
+			"success":  false,
+			"code":     "UPLOAD_FAILED",
+			"error":    "Failed to upload file",
+		}
+	}
+
 	return fiber.Map{
 		"filename": file.Filename,
 		"success":  true,
 		"size":     file.Size,
 		"type":     contentType,
-		"url":      fmt.Sprintf("/uploads/%s", file.Filename), // Placeholder
+		"url":      spacesService.GetObjectURL(path),
+	}
+}
+
+// uploadPath returns a new object key for an uploaded file, e.g. "uploads/9f86d081884c7d65/Na_Yeon.png"
+func uploadPath(filename string) (string, error) {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("failed to generate upload id: %w", err)
 	}
+	return fmt.Sprintf("uploads/%s/%s", hex.EncodeToString(id[:]), utils.SanitizeFilename(filename)), nil
 }
 
 // GetSession gets the current user session
@@ -200,14 +258,110 @@ func (w *WebApp) GetSession(c *fiber.Ctx) (*webmodels.UserSession, error) {
 
 func HealthCheck(webApp *WebApp) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		return utils.SendSuccess(c, fiber.Map{
-			"status":  "healthy",
-			"version": webApp.Version,
-			"commit":  webApp.Commit,
-		}, "Health check successful")
+		report := webApp.Health.Check(c.Context())
+		data := fiber.Map{
+			"status":     report.Status,
+			"components": report.Components,
+			"version":    webApp.Version,
+			"commit":     webApp.Commit,
+		}
+
+		if !report.Healthy() {
+			response := webmodels.NewErrorResponse("UNHEALTHY", "One or more critical dependencies are unavailable", nil)
+			response.Data = data
+			return utils.SendJSON(c, fiber.StatusServiceUnavailable, response)
+		}
+		return utils.SendSuccess(c, data, "Health check successful")
+	}
+}
+
+// MetricsEndpoint serves Prometheus metrics to scrapers sending the configured
+// bearer token. Without a token configured every scrape is refused.
+func MetricsEndpoint(webApp *WebApp) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token := webApp.Config.Metrics.Token
+		provided := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			return utils.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "Invalid metrics token", nil)
+		}
+
+		ctx, cancel := context.WithTimeout(c.Context(), webservices.DefaultHealthCheckTimeout)
+		defer cancel()
+
+		var buf bytes.Buffer
+		if err := webApp.Metrics.Write(&buf, collectMetricGauges(ctx, webApp)); err != nil {
+			return utils.SendInternalServerError(c, "Failed to render metrics")
+		}
+
+		c.Set(fiber.HeaderContentType, "text/plain; version=0.0.4; charset=utf-8")
+		return c.Send(buf.Bytes())
 	}
 }
 
+// collectMetricGauges samples connection pool stats and entity totals. Totals
+// that fail to load are skipped so a slow query doesn't fail the whole scrape.
+func collectMetricGauges(ctx context.Context, webApp *WebApp) []webservices.Gauge {
+	var gauges []webservices.Gauge
+
+	poolStat := webApp.DB.GetPool().Stat()
+	bunStat := webApp.DB.BunDB().Stats()
+	pools := []struct {
+		name                             string
+		open, inUse, idle, maxOpen, wait int64
+	}{
+		{"pgx", int64(poolStat.TotalConns()), int64(poolStat.AcquiredConns()), int64(poolStat.IdleConns()), int64(poolStat.MaxConns()), poolStat.EmptyAcquireCount()},
+		{"bun", int64(bunStat.OpenConnections), int64(bunStat.InUse), int64(bunStat.Idle), int64(bunStat.MaxOpenConnections), bunStat.WaitCount},
+	}
+	for _, p := range pools {
+		labels := map[string]string{"pool": p.name}
+		gauges = append(gauges,
+			webservices.Gauge{Name: "gohye_db_connections_open", Help: "Open database connections.", Labels: labels, Value: float64(p.open)},
+			webservices.Gauge{Name: "gohye_db_connections_in_use", Help: "Database connections currently in use.", Labels: labels, Value: float64(p.inUse)},
+			webservices.Gauge{Name: "gohye_db_connections_idle", Help: "Idle database connections.", Labels: labels, Value: float64(p.idle)},
+			webservices.Gauge{Name: "gohye_db_connections_max", Help: "Maximum database connections.", Labels: labels, Value: float64(p.maxOpen)},
+			webservices.Gauge{Name: "gohye_db_connection_waits", Help: "Connection requests that had to wait for a free connection.", Labels: labels, Value: float64(p.wait)},
+		)
+	}
+
+	totals := []struct {
+		entity string
+		count  func(context.Context) (int64, error)
+	}{
+		{"cards", webApp.Repos.Card.GetCardCount},
+		{"collections", webApp.Repos.Collection.GetCollectionCount},
+		{"users", webApp.Repos.User.GetUserCount},
+	}
+	for _, total := range totals {
+		count, err := total.count(ctx)
+		if err != nil {
+			slog.Warn("Failed to load metrics total",
+				slog.String("entity", total.entity),
+				slog.String("error", err.Error()))
+			continue
+		}
+		gauges = append(gauges, webservices.Gauge{
+			Name:   "gohye_entities_total",
+			Help:   "Number of stored cards, collections and users.",
+			Labels: map[string]string{"entity": total.entity},
+			Value:  float64(count),
+		})
+	}
+
+	return gauges
+}
+
+// recordOperation counts an import or bulk operation when metrics are enabled
+func recordOperation(webApp *WebApp, operation string, err error) {
+	if webApp.Metrics == nil {
+		return
+	}
+	result := webservices.OperationSuccess
+	if err != nil {
+		result = webservices.OperationError
+	}
+	webApp.Metrics.IncOperation(operation, result)
+}
+
 func DiscordOAuth(webApp *WebApp) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Generate state parameter
@@ -266,7 +420,7 @@ func OAuthCallback(webApp *WebApp) fiber.Handler {
 		}
 
 		// Exchange code for access token
-		accessToken, err := webApp.OAuthService.ExchangeCodeForToken(ctx, code)
+		token, err := webApp.OAuthService.ExchangeCodeForToken(ctx, code)
 		if err != nil {
 			slog.Error("OAuth callback: failed to exchange code for token",
 				slog.String("error", err.Error()))
@@ -274,7 +428,7 @@ func OAuthCallback(webApp *WebApp) fiber.Handler {
 		}
 
 		// Get user information
-		user, err := webApp.OAuthService.GetUserInfo(ctx, accessToken)
+		user, err := webApp.OAuthService.GetUserInfo(ctx, token.AccessToken)
 		if err != nil {
 			slog.Error("OAuth callback: failed to get user info",
 				slog.String("error", err.Error()))
@@ -282,7 +436,7 @@ func OAuthCallback(webApp *WebApp) fiber.Handler {
 		}
 
 		// Create user session
-		userSession, err := webApp.OAuthService.CreateUserSession(ctx, user, accessToken)
+		userSession, err := webApp.OAuthService.CreateUserSession(ctx, user, token)
 		if err != nil {
 			slog.Error("OAuth callback: failed to create user session",
 				slog.String("user_id", user.ID),
@@ -290,9 +444,9 @@ func OAuthCallback(webApp *WebApp) fiber.Handler {
 			return c.Redirect("http://localhost:3000/login?error=session_creation_failed")
 		}
 
-		// Check if user has admin access
-		if !userSession.IsAdmin {
-			slog.Warn("OAuth callback: user lacks admin privileges",
+		// Check if user has dashboard access
+		if !webApp.SessionService.HasPermission(userSession, webmodels.PermissionAdminRead) {
+			slog.Warn("OAuth callback: user lacks dashboard permissions",
 				slog.String("user_id", user.ID),
 				slog.String("username", user.Username))
 			return c.Redirect("http://localhost:3000/login?error=insufficient_permissions")
@@ -399,8 +553,12 @@ func CardsCreate(webApp *WebApp) fiber.Handler {
 			fileData, err := file.Open()
 			if err == nil {
 				defer fileData.Close()
-				imageData := make([]byte, file.Size)
-				fileData.Read(imageData)
+				imageData, err := io.ReadAll(fileData)
+				if err != nil {
+					return utils.SendError(c, 400, "FILE_ERROR", "Failed to read image", map[string]string{
+						"error": err.Error(),
+					})
+				}
 				req.ImageData = imageData
 				req.ImageName = file.Filename
 			}
@@ -451,8 +609,12 @@ func CardsUpdate(webApp *WebApp) fiber.Handler {
 			fileData, err := file.Open()
 			if err == nil {
 				defer fileData.Close()
-				imageData := make([]byte, file.Size)
-				fileData.Read(imageData)
+				imageData, err := io.ReadAll(fileData)
+				if err != nil {
+					return utils.SendError(c, 400, "FILE_ERROR", "Failed to read image", map[string]string{
+						"error": err.Error(),
+					})
+				}
 				req.ImageData = imageData
 				req.ImageName = file.Filename
 			}
@@ -460,6 +622,11 @@ func CardsUpdate(webApp *WebApp) fiber.Handler {
 
 		// Update card
 		card, err := webApp.CardMgmtService.UpdateCard(ctx, cardID, &req)
+		if errors.Is(err, repositories.ErrStaleUpdate) {
+			return utils.SendError(c, 409, "STALE_UPDATE", "Card was modified since it was loaded; reload and try again", map[string]string{
+				"card_id": cardIDStr,
+			})
+		}
 		if err != nil {
 			slog.Error("Failed to update card",
 				slog.Int64("card_id", cardID),
@@ -490,15 +657,33 @@ func CardsDelete(webApp *WebApp) fiber.Handler {
 			})
 		}
 
-		// Get card info before deletion for logging
-		card, err := webApp.CardMgmtService.GetCard(ctx, cardID)
-		if err != nil {
-			return utils.SendError(c, 404, "CARD_NOT_FOUND", "Card not found", nil)
+		// Cards are soft-deleted unless hard=true asks for permanent removal
+		if !c.QueryBool("hard") {
+			report, err := webApp.CardMgmtService.SoftDeleteCard(ctx, cardID)
+			if err != nil {
+				slog.Error("Failed to soft-delete card",
+					slog.Int64("card_id", cardID),
+					slog.String("error", err.Error()))
+				return utils.SendError(c, 400, "DELETION_FAILED", "Failed to delete card", map[string]string{
+					"error": err.Error(),
+				})
+			}
+			if !report.CardDeleted {
+				return utils.SendSuccess(c, report, "Card already deleted")
+			}
+			return utils.SendSuccess(c, report, "Card deleted successfully; it can be restored")
 		}
 
-		// Delete card
-		err = webApp.CardMgmtService.DeleteCard(ctx, cardID)
+		// Delete card; deleting an already removed card succeeds with an empty report
+		report, err := webApp.CardMgmtService.DeleteCard(ctx, cardID, c.QueryBool("force"))
 		if err != nil {
+			var owned *services.CardOwnedError
+			if errors.As(err, &owned) {
+				return utils.SendError(c, 409, "CARD_OWNED", "Card is still owned by users; pass force=true to delete it anyway", map[string]string{
+					"owners": strconv.Itoa(owned.Owners),
+					"copies": strconv.FormatInt(owned.Copies, 10),
+				})
+			}
 			slog.Error("Failed to delete card",
 				slog.Int64("card_id", cardID),
 				slog.String("error", err.Error()))
@@ -507,11 +692,44 @@ func CardsDelete(webApp *WebApp) fiber.Handler {
 			})
 		}
 
-		slog.Info("Card deleted successfully",
-			slog.Int64("card_id", cardID),
-			slog.String("name", card.Name))
+		if !report.CardDeleted {
+			return utils.SendSuccess(c, report, "Card already deleted")
+		}
+		return utils.SendSuccess(c, report, "Card deleted successfully")
+	}
+}
+
+// CardsRestore restores a soft-deleted card
+func CardsRestore(webApp *WebApp) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
 
-		return utils.SendSuccess(c, nil, "Card deleted successfully")
+		cardIDStr := c.Params("id")
+		cardID, err := parseInt64(cardIDStr)
+		if err != nil {
+			return utils.SendError(c, 400, "INVALID_CARD_ID", "Invalid card ID", map[string]string{
+				"card_id": cardIDStr,
+			})
+		}
+
+		restored, err := webApp.CardMgmtService.RestoreCard(ctx, cardID)
+		if err != nil {
+			slog.Error("Failed to restore card",
+				slog.Int64("card_id", cardID),
+				slog.String("error", err.Error()))
+			return utils.SendError(c, 500, "RESTORE_FAILED", "Failed to restore card", map[string]string{
+				"error": err.Error(),
+			})
+		}
+		if !restored {
+			return utils.SendError(c, 404, "CARD_NOT_DELETED", "No soft-deleted card with this ID", nil)
+		}
+
+		card, err := webApp.CardMgmtService.GetCard(ctx, cardID)
+		if err != nil {
+			return utils.SendSuccess(c, fiber.Map{"card_id": cardID}, "Card restored successfully")
+		}
+		return utils.SendSuccess(c, card, "Card restored successfully")
 	}
 }
 
@@ -533,8 +751,18 @@ func CardsBulkOperation(webApp *WebApp) fiber.Handler {
 			return utils.SendError(c, 400, "NO_CARDS_SELECTED", "No cards selected for bulk operation", nil)
 		}
 
+		// Deleting needs the stronger permission; every other operation edits cards
+		permission := webmodels.PermissionCardsUpdate
+		if req.Operation == "delete" {
+			permission = webmodels.PermissionCardsDelete
+		}
+		if session, ok := utils.ExtractUserSession(c); !ok || !webApp.SessionService.HasPermission(session, permission) {
+			return utils.SendForbidden(c, "Insufficient permissions")
+		}
+
 		// Perform bulk operation
 		err := webApp.CardMgmtService.BulkOperation(ctx, &req)
+		recordOperation(webApp, "card_bulk_"+req.Operation, err)
 		if err != nil {
 			slog.Error("Failed to perform bulk operation",
 				slog.String("operation", req.Operation),
@@ -569,19 +797,20 @@ func CollectionsDetail(webApp *WebApp) fiber.Handler {
 		}
 
 		// Get pagination parameters
-		page := c.QueryInt("page", 1)
-		limit := c.QueryInt("limit", 24) // 24 cards per page for good grid layout
-		search := c.Query("search", "")
-		levelFilter := c.QueryInt("level", 0)
-		typeFilter := c.Query("type", "")
-
-		// Ensure valid pagination
-		if page < 1 {
-			page = 1
+		searchReq := webmodels.CardSearchRequest{
+			Query:      c.Query("search", ""),
+			Collection: collectionID,
+			Level:      c.QueryInt("level", 0),
+			Page:       c.QueryInt("page", 1),
+			Limit:      c.QueryInt("limit", 24), // 24 cards per page for good grid layout
 		}
-		if limit < 1 || limit > 100 {
-			limit = 24
+		if err := searchReq.Validate(); err != nil {
+			return utils.SendError(c, 400, "INVALID_PARAMETERS", "Invalid search parameters", map[string]string{
+				"error": err.Error(),
+			})
 		}
+		page, limit := searchReq.Page, searchReq.Limit
+		typeFilter := c.Query("type", "")
 
 		// Get collection details
 		collection, err := webApp.Repos.Collection.GetByID(ctx, collectionID)
@@ -591,9 +820,9 @@ func CollectionsDetail(webApp *WebApp) fiber.Handler {
 
 		// Create search filters for collection cards
 		filters := repositories.SearchFilters{
-			Collection: collectionID,
-			Name:       search,
-			Level:      levelFilter,
+			Collection: searchReq.Collection,
+			Name:       searchReq.Query,
+			Level:      searchReq.Level,
 		}
 
 		// Handle type filter
@@ -614,7 +843,7 @@ func CollectionsDetail(webApp *WebApp) fiber.Handler {
 
 		// Convert cards to DTOs with image URLs if we have spaces service
 		cardDTOs := make([]*webmodels.CardDTO, 0, len(cards))
-		if spacesService, ok := webApp.SpacesService.(*services.SpacesService); ok && spacesService != nil {
+		if spacesService := webApp.SpacesService; spacesService != nil {
 			for _, card := range cards {
 				// Determine group type from collection tags
 				groupType := "girlgroups" // default
@@ -629,6 +858,7 @@ func CollectionsDetail(webApp *WebApp) fiber.Handler {
 					card.Level,
 					groupType,
 					card.Animated,
+					card.ImageFormat,
 				)
 
 				cardDTO := webmodels.ConvertCardToDTO(card, collection, imageURL)
@@ -668,6 +898,7 @@ func CollectionsCreate(webApp *WebApp) fiber.Handler {
 			Compressed bool     `json:"compressed"`
 			Fragments  bool     `json:"fragments"`
 			Tags       []string `json:"tags"`
+			Type       string   `json:"collection_type"`
 		}
 
 		// Parse JSON body
@@ -681,6 +912,9 @@ func CollectionsCreate(webApp *WebApp) fiber.Handler {
 		if req.ID == "" || req.Name == "" {
 			return utils.SendError(c, 400, "MISSING_FIELDS", "ID and Name are required", nil)
 		}
+		if req.Type != "" && !models.IsValidCollectionType(req.Type) {
+			return utils.SendError(c, 400, "INVALID_COLLECTION_TYPE", "collection_type must be girl_group, boy_group or other", nil)
+		}
 
 		// Create collection with proper defaults
 		collection := &models.Collection{
@@ -692,6 +926,7 @@ func CollectionsCreate(webApp *WebApp) fiber.Handler {
 			Compressed: req.Compressed,
 			Fragments:  req.Fragments,
 			Tags:       req.Tags,
+			Type:       req.Type, // inferred from tags and name when empty
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
 		}
@@ -739,6 +974,10 @@ func CollectionsUpdate(webApp *WebApp) fiber.Handler {
 			Compressed *bool    `json:"compressed"`
 			Fragments  *bool    `json:"fragments"`
 			Tags       []string `json:"tags"`
+			Type       *string  `json:"collection_type"`
+
+			// Rejects the update with 409 if the collection changed since this was read
+			ExpectedUpdatedAt *time.Time `json:"expected_updated_at"`
 		}
 
 		// Parse JSON body
@@ -748,6 +987,10 @@ func CollectionsUpdate(webApp *WebApp) fiber.Handler {
 			})
 		}
 
+		if req.Type != nil && !models.IsValidCollectionType(*req.Type) {
+			return utils.SendError(c, 400, "INVALID_COLLECTION_TYPE", "collection_type must be girl_group, boy_group or other", nil)
+		}
+
 		// Get existing collection
 		collection, err := webApp.Repos.Collection.GetByID(ctx, collectionID)
 		if err != nil {
@@ -776,9 +1019,21 @@ func CollectionsUpdate(webApp *WebApp) fiber.Handler {
 		if req.Tags != nil {
 			collection.Tags = req.Tags
 		}
+		if req.Type != nil {
+			collection.Type = *req.Type
+		}
 
 		// Update in database
-		err = webApp.Repos.Collection.Update(ctx, collection)
+		if req.ExpectedUpdatedAt != nil {
+			err = webApp.Repos.Collection.UpdateIfUnchanged(ctx, collection, *req.ExpectedUpdatedAt)
+		} else {
+			err = webApp.Repos.Collection.Update(ctx, collection)
+		}
+		if errors.Is(err, repositories.ErrStaleUpdate) {
+			return utils.SendError(c, 409, "STALE_UPDATE", "Collection was modified since it was loaded; reload and try again", map[string]string{
+				"collection_id": collectionID,
+			})
+		}
 		if err != nil {
 			slog.Error("Failed to update collection",
 				slog.String("collection_id", collectionID),
@@ -897,11 +1152,17 @@ func CollectionsImport(webApp *WebApp) fiber.Handler {
 					return utils.SendError(c, 400, "FILE_ERROR", fmt.Sprintf("Failed to read file %s: %s", fileHeader.Filename, err.Error()), nil)
 				}
 
+				// Sniff the real content type instead of trusting the header
+				contentType, err := utils.DetectImageContentType(fileHeader.Filename, fileData)
+				if err != nil {
+					return utils.SendError(c, 400, "INVALID_FILE_TYPE", fmt.Sprintf("Invalid file %s: %s", fileHeader.Filename, err.Error()), nil)
+				}
+
 				// Create FileUpload struct
 				fileUpload := &webmodels.FileUpload{
 					Name:        fileHeader.Filename,
 					Size:        fileHeader.Size,
-					ContentType: fileHeader.Header.Get("Content-Type"),
+					ContentType: contentType,
 					Data:        fileData,
 				}
 				files = append(files, fileUpload)
@@ -922,6 +1183,11 @@ func CollectionsImport(webApp *WebApp) fiber.Handler {
 		}
 
 		result, err := webApp.CollectionImportService.ProcessCollectionImport(ctx, req)
+		if err == nil && !result.Success {
+			recordOperation(webApp, "collection_import", errors.New(result.ErrorMessage))
+		} else {
+			recordOperation(webApp, "collection_import", err)
+		}
 		if err != nil {
 			return utils.SendError(c, 500, "IMPORT_FAILED", err.Error(), nil)
 		}
@@ -966,19 +1232,57 @@ func CardsAPI(webApp *WebApp) fiber.Handler {
 			})
 		}
 
-		// Calculate pagination info
-		totalPages := (total + int64(searchReq.Limit) - 1) / int64(searchReq.Limit)
-		hasMore := int64(searchReq.Page) < totalPages
+		pagination := webmodels.NewPaginationInfo(searchReq.Page, searchReq.Limit, total)
 
 		// Return API response with pagination info
 		return utils.SendSuccess(c, fiber.Map{
 			"cards":       cards,
-			"total":       total,
-			"page":        searchReq.Page,
-			"limit":       searchReq.Limit,
-			"total_pages": totalPages,
-			"has_more":    hasMore,
-			"has_prev":    searchReq.Page > 1,
+			"total":       pagination.Total,
+			"page":        pagination.Page,
+			"limit":       pagination.Limit,
+			"total_pages": pagination.TotalPages,
+			"has_more":    pagination.HasMore,
+			"has_prev":    pagination.HasPrev,
+			"pagination":  pagination,
+		}, "Cards retrieved successfully")
+	}
+}
+
+// CardQuerySearchAPI searches cards with the bot's query syntax, e.g. ?q=-3 #twice !animated
+func CardQuerySearchAPI(webApp *WebApp) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var searchReq webmodels.CardQuerySearchRequest
+		if err := c.QueryParser(&searchReq); err != nil {
+			return utils.SendError(c, 400, "INVALID_PARAMETERS", "Invalid search parameters", map[string]string{
+				"error": err.Error(),
+			})
+		}
+
+		cards, total, userFiltersIgnored, err := webApp.CardMgmtService.QuerySearchCards(ctx, &searchReq)
+		if err != nil {
+			slog.Error("Failed to run query search via API",
+				slog.String("query", searchReq.Query),
+				slog.String("error", err.Error()))
+			return utils.SendError(c, 500, "SEARCH_FAILED", "Failed to search cards", map[string]string{
+				"error": err.Error(),
+			})
+		}
+
+		pagination := webmodels.NewPaginationInfo(searchReq.Page, searchReq.Limit, total)
+
+		return utils.SendSuccess(c, fiber.Map{
+			"query":                searchReq.Query,
+			"cards":                cards,
+			"total":                pagination.Total,
+			"page":                 pagination.Page,
+			"limit":                pagination.Limit,
+			"total_pages":          pagination.TotalPages,
+			"has_more":             pagination.HasMore,
+			"has_prev":             pagination.HasPrev,
+			"pagination":           pagination,
+			"user_filters_ignored": userFiltersIgnored,
 		}, "Cards retrieved successfully")
 	}
 }
@@ -1082,8 +1386,20 @@ func SyncFix(webApp *WebApp) fiber.Handler {
 			return utils.SendError(c, 400, "MISSING_COLLECTION_ID", "Collection ID is required", nil)
 		}
 
-		// Fix sync issues for the collection
-		updatedStatus, err := webApp.SyncMgrService.FixSyncIssues(ctx, collectionID)
+		opts := webservices.SyncFixOptions{
+			Mode:  webmodels.SyncFixMode(c.Query("fix_mode", string(webmodels.SyncFixModeReport))),
+			Force: c.QueryBool("force", false),
+		}
+		switch opts.Mode {
+		case webmodels.SyncFixModeReport, webmodels.SyncFixModePlaceholder, webmodels.SyncFixModeDeleteRow:
+		default:
+			return utils.SendError(c, 400, "INVALID_FIX_MODE", "fix_mode must be report, placeholder or delete_row", map[string]string{
+				"fix_mode": string(opts.Mode),
+			})
+		}
+
+		// Check every card image in the collection and fix the missing ones
+		report, err := webApp.SyncMgrService.FixSyncIssues(ctx, collectionID, opts)
 		if err != nil {
 			slog.Error("Failed to fix sync issues",
 				slog.String("collection_id", collectionID),
@@ -1094,7 +1410,10 @@ func SyncFix(webApp *WebApp) fiber.Handler {
 			})
 		}
 
-		return utils.SendSuccess(c, updatedStatus, "Sync issues fixed successfully")
+		if opts.Mode == webmodels.SyncFixModeReport {
+			return utils.SendSuccess(c, report, fmt.Sprintf("Checked %d cards, %d missing images", report.CheckedCards, report.MissingCards))
+		}
+		return utils.SendSuccess(c, report, fmt.Sprintf("Fixed %d of %d missing images", report.FixedCards, report.MissingCards))
 	}
 }
 
@@ -1102,18 +1421,27 @@ func SyncCleanup(webApp *WebApp) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
 
-		// Cleanup orphaned files
-		cleanedCount, err := webApp.SyncMgrService.CleanupOrphans(ctx)
+		opts := webservices.CleanupOptions{
+			DryRun:       c.QueryBool("dry_run", false),
+			CollectionID: c.Query("collection_id"),
+		}
+
+		// Cleanup orphaned files (or just report them on a dry run)
+		report, err := webApp.SyncMgrService.CleanupOrphans(ctx, opts)
 		if err != nil {
-			slog.Error("Failed to cleanup orphans", slog.String("error", err.Error()))
+			slog.Error("Failed to cleanup orphans",
+				slog.String("collection_id", opts.CollectionID),
+				slog.String("error", err.Error()))
 			return utils.SendError(c, 500, "CLEANUP_FAILED", "Failed to cleanup orphaned files", map[string]string{
 				"error": err.Error(),
 			})
 		}
 
-		return utils.SendSuccess(c, fiber.Map{
-			"cleaned_count": cleanedCount,
-		}, fmt.Sprintf("Cleanup completed, removed %d orphaned files", cleanedCount))
+		if opts.DryRun {
+			return utils.SendSuccess(c, report, fmt.Sprintf("Dry run completed, %d orphaned files would be removed", len(report.OrphanKeys)))
+		}
+
+		return utils.SendSuccess(c, report, fmt.Sprintf("Cleanup completed, removed %d orphaned files", len(report.DeletedKeys)))
 	}
 }
 
@@ -1142,6 +1470,134 @@ func UsersDetail(webApp *WebApp) fiber.Handler {
 	}
 }
 
+// UsersQuests returns a user's unexpired quests grouped by daily, weekly and monthly
+func UsersQuests(webApp *WebApp) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		userID := c.Params("id")
+		if userID == "" {
+			return utils.SendError(c, 400, "MISSING_USER_ID", "User ID is required", nil)
+		}
+
+		quests, err := webApp.Repos.Quest.GetUserQuestOverview(ctx, userID)
+		if err != nil {
+			slog.Error("Failed to get user quests",
+				slog.String("user_id", userID),
+				slog.String("error", err.Error()))
+			return utils.SendError(c, 500, "QUESTS_FAILED", "Failed to retrieve quests", map[string]string{
+				"user_id": userID,
+			})
+		}
+
+		response := webmodels.UserQuestsResponse{
+			UserID:  userID,
+			Daily:   []webmodels.UserQuestDTO{},
+			Weekly:  []webmodels.UserQuestDTO{},
+			Monthly: []webmodels.UserQuestDTO{},
+		}
+		for _, quest := range quests {
+			dto := webmodels.NewUserQuestDTO(quest)
+			switch dto.Type {
+			case models.QuestTypeDaily:
+				response.Daily = append(response.Daily, dto)
+			case models.QuestTypeWeekly:
+				response.Weekly = append(response.Weekly, dto)
+			case models.QuestTypeMonthly:
+				response.Monthly = append(response.Monthly, dto)
+			}
+		}
+
+		return utils.SendSuccess(c, response, "User quests retrieved successfully")
+	}
+}
+
+// parseAuditTime accepts either RFC3339 timestamps or plain dates
+func parseAuditTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// AdminAuditList returns admin command audit entries filtered by actor, command and date range
+func AdminAuditList(webApp *WebApp) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		page := c.QueryInt("page", 1)
+		limit := c.QueryInt("limit", 50)
+		if page < 1 {
+			page = 1
+		}
+		if limit < 1 || limit > 200 {
+			limit = 50
+		}
+
+		filter := repositories.AdminAuditFilter{
+			ActorID: c.Query("actor"),
+			Command: c.Query("command"),
+		}
+		for param, dest := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+			value := c.Query(param)
+			if value == "" {
+				continue
+			}
+			t, err := parseAuditTime(value)
+			if err != nil {
+				return utils.SendError(c, 400, "INVALID_DATE", "Dates must be RFC3339 timestamps or YYYY-MM-DD", map[string]string{
+					param: value,
+				})
+			}
+			*dest = t
+		}
+
+		entries, total, err := webApp.Repos.AdminAudit.List(ctx, filter, (page-1)*limit, limit)
+		if err != nil {
+			slog.Error("Failed to list admin audit entries", slog.String("error", err.Error()))
+			return utils.SendError(c, 500, "AUDIT_FAILED", "Failed to retrieve audit log", nil)
+		}
+
+		return utils.SendSuccess(c, fiber.Map{
+			"entries":    entries,
+			"pagination": webmodels.NewPaginationInfo(page, limit, int64(total)),
+		}, "Audit log retrieved successfully")
+	}
+}
+
+// ProcessesList returns the background process state last published by the bot
+func ProcessesList(webApp *WebApp) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		processes, err := webApp.Repos.Process.List(c.Context())
+		if err != nil {
+			slog.Error("Failed to list background processes", slog.String("error", err.Error()))
+			return utils.SendError(c, 500, "PROCESSES_FAILED", "Failed to retrieve background processes", nil)
+		}
+
+		return utils.SendSuccess(c, processes, "Background processes retrieved successfully")
+	}
+}
+
+// ProcessesRestart flags a background process for restart; the bot applies it on its next sync
+func ProcessesRestart(webApp *WebApp) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		name := c.Params("name")
+		if err := webApp.Repos.Process.RequestRestart(c.Context(), name); err != nil {
+			if repositories.IsNotFound(err) {
+				return utils.SendError(c, 404, "PROCESS_NOT_FOUND", "Background process not found", map[string]string{
+					"name": name,
+				})
+			}
+			slog.Error("Failed to request process restart",
+				slog.String("process", name),
+				slog.String("error", err.Error()))
+			return utils.SendError(c, 500, "RESTART_FAILED", "Failed to request process restart", nil)
+		}
+
+		return utils.SendSuccess(c, fiber.Map{"name": name, "restart_requested": true}, "Restart requested")
+	}
+}
+
 func CollectionsAPI(webApp *WebApp) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
@@ -1158,14 +1614,11 @@ func CollectionsAPI(webApp *WebApp) fiber.Handler {
 		// Convert to DTOs
 		collectionDTOs := make([]webmodels.CollectionDTO, len(collectionsWithCounts))
 		for i, collectionWithCount := range collectionsWithCounts {
-			// Determine collection type based on tags or name
-			collectionType := determineCollectionType(collectionWithCount.Collection)
-
 			collectionDTOs[i] = webmodels.CollectionDTO{
 				ID:             collectionWithCount.ID,
 				Name:           collectionWithCount.Name,
 				Description:    "", // No description field in database model
-				CollectionType: collectionType,
+				CollectionType: collectionWithCount.ResolvedType(),
 				Origin:         collectionWithCount.Origin,
 				Aliases:        collectionWithCount.Aliases,
 				Promo:          collectionWithCount.Promo,
@@ -1182,32 +1635,6 @@ func CollectionsAPI(webApp *WebApp) fiber.Handler {
 	}
 }
 
-// determineCollectionType infers the collection type from available data
-func determineCollectionType(collection *models.Collection) string {
-	// Check tags first
-	for _, tag := range collection.Tags {
-		tag = strings.ToLower(tag)
-		if strings.Contains(tag, "girl") || strings.Contains(tag, "female") {
-			return "girl_group"
-		}
-		if strings.Contains(tag, "boy") || strings.Contains(tag, "male") {
-			return "boy_group"
-		}
-	}
-
-	// Check name as fallback
-	name := strings.ToLower(collection.Name)
-	if strings.Contains(name, "girl") || strings.Contains(name, "female") {
-		return "girl_group"
-	}
-	if strings.Contains(name, "boy") || strings.Contains(name, "male") {
-		return "boy_group"
-	}
-
-	// Default to "other"
-	return "other"
-}
-
 func CollectionCardsAPI(webApp *WebApp) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
@@ -1253,10 +1680,8 @@ func CollectionCardsAPI(webApp *WebApp) fiber.Handler {
 		for i, card := range cards {
 			imageURL := ""
 			if webApp.SpacesService != nil {
-				if spacesService, ok := webApp.SpacesService.(*services.SpacesService); ok {
-					// Use the correct method with proper parameters including group type
-					imageURL = spacesService.GetCardImageURLWithFormat(card.Name, card.ColID, card.Level, groupType, card.Animated)
-				}
+				// Use the correct method with proper parameters including group type
+				imageURL = webApp.SpacesService.GetCardImageURLWithFormat(card.Name, card.ColID, card.Level, groupType, card.Animated, card.ImageFormat)
 			}
 
 			cardDTOs[i] = webmodels.CardDTO{
@@ -1359,13 +1784,20 @@ func CardsImport(webApp *WebApp) fiber.Handler {
 
 		// Process import
 		result, err := webApp.CardImportService.ImportCards(ctx, req)
+		if err == nil && !result.Success {
+			recordOperation(webApp, "card_import", errors.New("import reported failure"))
+		} else {
+			recordOperation(webApp, "card_import", err)
+		}
 		if err != nil {
 			slog.Error("Failed to import cards",
 				slog.String("collection_id", req.CollectionID),
 				slog.String("error", err.Error()))
-			return utils.SendError(c, 500, "IMPORT_FAILED", "Import failed", map[string]string{
-				"error": err.Error(),
-			})
+			details := map[string]string{"error": err.Error()}
+			if result != nil && len(result.FilesRolledBack) > 0 {
+				details["files_rolled_back"] = strings.Join(result.FilesRolledBack, ",")
+			}
+			return utils.SendError(c, 500, "IMPORT_FAILED", "Import failed", details)
 		}
 
 		// Log successful import
@@ -1417,12 +1849,15 @@ func CardsBulkOperations(webApp *WebApp) fiber.Handler {
 			result, err = webApp.executeBulkToggleAnimated(ctx, &req)
 		case "export":
 			result, err = webApp.executeBulkExport(ctx, &req)
+		case "add_tags", "remove_tags":
+			result, err = webApp.executeBulkTagUpdate(ctx, &req)
 		default:
 			return utils.SendError(c, 400, "UNSUPPORTED_OPERATION", "Unsupported bulk operation", map[string]string{
 				"operation": req.Operation,
 			})
 		}
 
+		recordOperation(webApp, "card_batch_"+req.Operation, err)
 		if err != nil {
 			slog.Error("Failed to execute bulk operation",
 				slog.String("operation", req.Operation),
@@ -1445,9 +1880,13 @@ func CardsBulkOperations(webApp *WebApp) fiber.Handler {
 	}
 }
 
-// CardsImportCollections handles batch import of multiple collections
+// CardsImportCollections handles batch import of multiple collections. Every
+// entry is validated before anything is uploaded; after that each collection
+// is imported on its own and failures are reported per collection.
 func CardsImportCollections(webApp *WebApp) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
 		// Parse multipart form for batch collection import
 		form, err := c.MultipartForm()
 		if err != nil {
@@ -1456,24 +1895,43 @@ func CardsImportCollections(webApp *WebApp) fiber.Handler {
 			})
 		}
 
-		// Extract collections data (JSON format)
-		collectionsJSON := ""
-		if values, ok := form.Value["collections"]; ok && len(values) > 0 {
-			collectionsJSON = values[0]
+		reqs, err := parseBatchCollectionImport(form)
+		if err != nil {
+			return utils.SendError(c, 400, "INVALID_REQUEST", err.Error(), nil)
 		}
 
-		if collectionsJSON == "" {
-			return utils.SendError(c, 400, "MISSING_COLLECTIONS", "Collections data is required", nil)
+		// Reject the whole batch if any entry is invalid
+		invalid := make(map[string]string)
+		for _, req := range reqs {
+			if err := webApp.CollectionImportService.ValidateImportRequest(req); err != nil {
+				invalid[req.CollectionID] = err.Error()
+			}
+		}
+		if len(invalid) > 0 {
+			return utils.SendError(c, 400, "INVALID_COLLECTIONS", "One or more collections are invalid", invalid)
 		}
 
-		// TODO: Implement batch collection import
-		// For now, return placeholder
-		result := fiber.Map{
-			"message":           "Batch collection import not yet implemented",
-			"use_single_import": "/api/cards/import",
+		result := webApp.CollectionImportService.ProcessBatchImport(ctx, reqs)
+		for _, collectionResult := range result.Results {
+			var importErr error
+			if !collectionResult.Success {
+				importErr = errors.New(collectionResult.ErrorMessage)
+			}
+			recordOperation(webApp, "collection_import", importErr)
 		}
 
-		return utils.SendSuccess(c, result, "Batch import placeholder")
+		slog.Info("Batch collection import completed",
+			slog.Int("total", result.Total),
+			slog.Int("succeeded", result.Succeeded),
+			slog.Int("failed", result.Failed))
+
+		message := fmt.Sprintf("Imported %d of %d collections", result.Succeeded, result.Total)
+		if result.Succeeded == 0 {
+			response := webmodels.NewErrorResponse("IMPORT_FAILED", message, nil)
+			response.Data = result
+			return utils.SendJSON(c, fiber.StatusUnprocessableEntity, response)
+		}
+		return utils.SendSuccess(c, result, message)
 	}
 }
 
@@ -1481,6 +1939,97 @@ func CardsImportCollections(webApp *WebApp) fiber.Handler {
 // HELPER FUNCTIONS FOR CARD IMPORT
 // =============================================================================
 
+// parseBatchCollectionImport pairs the entries in the "collections" JSON field
+// with the uploaded files they name
+func parseBatchCollectionImport(form *multipart.Form) ([]*webmodels.CollectionImportRequest, error) {
+	collectionsJSON := ""
+	if values, ok := form.Value["collections"]; ok && len(values) > 0 {
+		collectionsJSON = values[0]
+	}
+	if collectionsJSON == "" {
+		return nil, fmt.Errorf("collections data is required")
+	}
+
+	var entries []webmodels.BatchCollectionEntry
+	if err := json.Unmarshal([]byte(collectionsJSON), &entries); err != nil {
+		return nil, fmt.Errorf("invalid collections data: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no collections provided")
+	}
+
+	uploads := make(map[string]*multipart.FileHeader)
+	for _, fileHeader := range form.File["files"] {
+		uploads[fileHeader.Filename] = fileHeader
+	}
+
+	seenCollections := make(map[string]bool, len(entries))
+	claimedFiles := make(map[string]string)
+	reqs := make([]*webmodels.CollectionImportRequest, 0, len(entries))
+	for i, entry := range entries {
+		if entry.CollectionID == "" {
+			return nil, fmt.Errorf("collection %d is missing collection_id", i+1)
+		}
+		if seenCollections[entry.CollectionID] {
+			return nil, fmt.Errorf("collection %s is listed more than once", entry.CollectionID)
+		}
+		seenCollections[entry.CollectionID] = true
+
+		req := &webmodels.CollectionImportRequest{
+			CollectionID: entry.CollectionID,
+			DisplayName:  entry.DisplayName,
+			GroupType:    entry.GroupType,
+			IsPromo:      entry.IsPromo,
+			Files:        make([]*webmodels.FileUpload, 0, len(entry.Files)),
+		}
+		for _, name := range entry.Files {
+			if owner, ok := claimedFiles[name]; ok {
+				return nil, fmt.Errorf("file %s is listed by both %s and %s", name, owner, entry.CollectionID)
+			}
+			claimedFiles[name] = entry.CollectionID
+
+			fileHeader, ok := uploads[name]
+			if !ok {
+				return nil, fmt.Errorf("file %s for collection %s was not uploaded", name, entry.CollectionID)
+			}
+			upload, err := readFileUpload(fileHeader)
+			if err != nil {
+				return nil, err
+			}
+			req.Files = append(req.Files, upload)
+		}
+		reqs = append(reqs, req)
+	}
+
+	return reqs, nil
+}
+
+// readFileUpload reads an uploaded image and sniffs its real content type
+func readFileUpload(fileHeader *multipart.FileHeader) (*webmodels.FileUpload, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
+	}
+	defer file.Close()
+
+	fileData, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", fileHeader.Filename, err)
+	}
+
+	contentType, err := utils.DetectImageContentType(fileHeader.Filename, fileData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file %s: %w", fileHeader.Filename, err)
+	}
+
+	return &webmodels.FileUpload{
+		Name:        fileHeader.Filename,
+		Size:        fileHeader.Size,
+		ContentType: contentType,
+		Data:        fileData,
+	}, nil
+}
+
 // parseCardImportRequest parses multipart form data into CardImportRequest
 func parseCardImportRequest(form *multipart.Form) (*webmodels.CardImportRequest, error) {
 	// Extract form fields
@@ -1538,11 +2087,17 @@ func parseCardImportRequest(form *multipart.Form) (*webmodels.CardImportRequest,
 				return nil, fmt.Errorf("failed to read file %s: %w", fileHeader.Filename, err)
 			}
 
+			// Sniff the real content type instead of trusting the header
+			contentType, err := utils.DetectImageContentType(fileHeader.Filename, fileData)
+			if err != nil {
+				return nil, fmt.Errorf("invalid file %s: %w", fileHeader.Filename, err)
+			}
+
 			// Create FileUpload struct
 			fileUpload := &webmodels.FileUpload{
 				Name:        fileHeader.Filename,
 				Size:        fileHeader.Size,
-				ContentType: fileHeader.Header.Get("Content-Type"),
+				ContentType: contentType,
 				Data:        fileData,
 			}
 			files = append(files, fileUpload)
@@ -1600,10 +2155,15 @@ func (w *WebApp) executeBulkDelete(ctx context.Context, req *webmodels.CardBatch
 			}
 		} else {
 			// Actually delete the card
-			if err := w.CardMgmtService.DeleteCard(ctx, cardID); err != nil {
+			if _, err := w.CardMgmtService.DeleteCard(ctx, cardID, req.Force); err != nil {
+				errorType := "delete_failed"
+				var owned *services.CardOwnedError
+				if errors.As(err, &owned) {
+					errorType = "card_owned"
+				}
 				result.Errors = append(result.Errors, webmodels.CardOperationError{
 					CardID:      cardID,
-					ErrorType:   "delete_failed",
+					ErrorType:   errorType,
 					Description: err.Error(),
 				})
 				result.FailedCards++
@@ -1770,6 +2330,93 @@ func (w *WebApp) executeBulkToggleAnimated(ctx context.Context, req *webmodels.C
 	return result, nil
 }
 
+// executeBulkTagUpdate adds or removes tags on every card. Cards whose tags would not
+// change are counted as processed without being written, so repeating an edit is harmless.
+func (w *WebApp) executeBulkTagUpdate(ctx context.Context, req *webmodels.CardBatchOperation) (*webmodels.CardBatchResult, error) {
+	result := &webmodels.CardBatchResult{
+		Operation:  req.Operation,
+		TotalCards: len(req.CardIDs),
+		DryRun:     req.DryRun,
+		Errors:     make([]webmodels.CardOperationError, 0),
+	}
+	add := req.Operation == "add_tags"
+
+	for _, cardID := range req.CardIDs {
+		card, err := w.CardMgmtService.GetCard(ctx, cardID)
+		if err != nil {
+			result.Errors = append(result.Errors, webmodels.CardOperationError{
+				CardID:      cardID,
+				ErrorType:   "not_found",
+				Description: "Card not found",
+			})
+			result.FailedCards++
+			continue
+		}
+
+		newTags, changed := editTags(card.Tags, req.Tags, add)
+
+		if req.DryRun {
+			result.PreviewResults = append(result.PreviewResults, webmodels.CardPreview{
+				CardID:   cardID,
+				CardName: card.Name,
+				Changes: map[string]interface{}{
+					"tags":    newTags,
+					"changed": changed,
+				},
+			})
+			result.ProcessedCards++
+			continue
+		}
+
+		if changed {
+			if _, err := w.CardMgmtService.UpdateCard(ctx, cardID, &webmodels.CardUpdateRequest{Tags: newTags}); err != nil {
+				result.Errors = append(result.Errors, webmodels.CardOperationError{
+					CardID:      cardID,
+					CardName:    card.Name,
+					ErrorType:   "tag_update_failed",
+					Description: err.Error(),
+				})
+				result.FailedCards++
+				continue
+			}
+		}
+		result.ProcessedCards++
+	}
+
+	result.Success = result.FailedCards == 0
+	return result, nil
+}
+
+// editTags returns the union (add) or difference (remove) of current and tags, keeping
+// the existing order, and whether anything changed. Tags compare case-insensitively.
+func editTags(current, tags []string, add bool) ([]string, bool) {
+	edit := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		edit[strings.ToLower(tag)] = true
+	}
+
+	newTags := make([]string, 0, len(current)+len(tags))
+	present := make(map[string]bool, len(current))
+	for _, tag := range current {
+		key := strings.ToLower(tag)
+		if !add && edit[key] {
+			continue
+		}
+		present[key] = true
+		newTags = append(newTags, tag)
+	}
+	if add {
+		for _, tag := range tags {
+			if key := strings.ToLower(tag); !present[key] {
+				present[key] = true
+				newTags = append(newTags, tag)
+			}
+		}
+	}
+
+	return newTags, len(newTags) != len(current)
+}
+
 // executeBulkExport executes bulk export operation
 func (w *WebApp) executeBulkExport(ctx context.Context, req *webmodels.CardBatchOperation) (*webmodels.CardBatchResult, error) {
 	result := &webmodels.CardBatchResult{
@@ -1786,3 +2433,174 @@ func (w *WebApp) executeBulkExport(ctx context.Context, req *webmodels.CardBatch
 
 	return result, nil
 }
+
+// VoteWebhook pays the vote reward when the bot list reports a vote. The request must
+// carry the configured webhook secret in its Authorization header. Votes within the
+// cooldown are acknowledged without paying, so the list does not retry them.
+func VoteWebhook(webApp *WebApp) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		secret := webApp.Config.Config.Vote.WebhookSecret
+		if secret == "" || webApp.VoteRewards == nil {
+			return utils.SendNotFound(c, "Vote webhook is not enabled")
+		}
+		if subtle.ConstantTimeCompare([]byte(c.Get(fiber.HeaderAuthorization)), []byte(secret)) != 1 {
+			slog.Warn("Rejected vote webhook with invalid authorization", slog.String("ip", c.IP()))
+			return utils.SendUnauthorized(c, "Invalid webhook authorization")
+		}
+
+		var payload webmodels.VoteWebhookPayload
+		if err := c.BodyParser(&payload); err != nil {
+			return utils.SendBadRequest(c, "Invalid vote payload", nil)
+		}
+		if _, err := parseInt64(payload.User); err != nil {
+			return utils.SendBadRequest(c, "Invalid voter ID", map[string]string{"user": payload.User})
+		}
+		if payload.Type == "test" {
+			slog.Info("Received test vote", slog.String("user_id", payload.User))
+			return utils.SendSuccess(c, fiber.Map{"granted": 0}, "Test vote received")
+		}
+
+		granted, err := webApp.VoteRewards.Grant(c.Context(), payload.User, time.Now())
+		recordOperation(webApp, "vote_reward", err)
+		switch {
+		case errors.Is(err, services.ErrVoteCooldown):
+			return utils.SendSuccess(c, fiber.Map{"granted": 0}, "Vote already rewarded within the cooldown")
+		case errors.Is(err, repositories.ErrUserNotFound):
+			return utils.SendNotFound(c, "User not found")
+		case err != nil:
+			slog.Error("Failed to grant vote reward",
+				slog.String("user_id", payload.User),
+				slog.String("error", err.Error()))
+			return utils.SendInternalServerError(c, "Failed to grant vote reward")
+		}
+
+		slog.Info("Granted vote reward",
+			slog.String("user_id", payload.User),
+			slog.Int64("flakes", granted))
+		return utils.SendSuccess(c, fiber.Map{"granted": granted}, "Vote reward granted")
+	}
+}
+
+// discordIDPattern finds a Discord user ID written in a Ko-fi message
+var discordIDPattern = regexp.MustCompile(`\b\d{17,20}\b`)
+
+// kofiDonorID maps a Ko-fi supporter to a Discord user: the account they linked on
+// Ko-fi, otherwise a Discord user ID they wrote in their message
+func kofiDonorID(payload *webmodels.KofiWebhookPayload) string {
+	if _, err := parseInt64(payload.DiscordUserID); err == nil {
+		return payload.DiscordUserID
+	}
+	return discordIDPattern.FindString(payload.Message)
+}
+
+// KofiWebhook rewards Ko-fi payments. The payload must carry the configured
+// verification token, and each Ko-fi message ID is only rewarded once, so replayed
+// or redelivered webhooks are acknowledged without paying again. Payments that
+// can't be rewarded are stored as unmatched or pending instead of being dropped.
+func KofiWebhook(webApp *WebApp) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		kofi := webApp.Config.Config.Kofi
+		if kofi.VerificationToken == "" || webApp.Repos.KofiDonation == nil {
+			return utils.SendNotFound(c, "Ko-fi webhook is not enabled")
+		}
+
+		var payload webmodels.KofiWebhookPayload
+		if err := json.Unmarshal([]byte(c.FormValue("data")), &payload); err != nil {
+			return utils.SendBadRequest(c, "Invalid Ko-fi payload", nil)
+		}
+		if subtle.ConstantTimeCompare([]byte(payload.VerificationToken), []byte(kofi.VerificationToken)) != 1 {
+			slog.Warn("Rejected Ko-fi webhook with invalid verification token", slog.String("ip", c.IP()))
+			return utils.SendUnauthorized(c, "Invalid verification token")
+		}
+		if payload.MessageID == "" {
+			return utils.SendBadRequest(c, "Missing message ID", nil)
+		}
+
+		tier := ""
+		if payload.IsSubscriptionPayment {
+			tier = payload.TierName
+		}
+		donation := &models.KofiDonation{
+			MessageID:     payload.MessageID,
+			TransactionID: payload.KofiTransactionID,
+			UserID:        kofiDonorID(&payload),
+			Type:          payload.Type,
+			TierName:      tier,
+			Amount:        payload.Amount,
+			Currency:      payload.Currency,
+			ReceivedAt:    time.Now(),
+		}
+		if donation.UserID == "" {
+			slog.Warn("Ko-fi payment is not linked to a Discord user",
+				slog.String("message_id", payload.MessageID),
+				slog.String("from_name", payload.FromName))
+			return recordUnclaimedKofi(c, webApp, donation, models.KofiDonationUnmatched, "Payment is not linked to a Discord user")
+		}
+
+		amount, err := strconv.ParseFloat(payload.Amount, 64)
+		if err != nil || amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+			slog.Warn("Ko-fi payment has an unreadable amount",
+				slog.String("message_id", payload.MessageID),
+				slog.String("amount", payload.Amount))
+			return recordUnclaimedKofi(c, webApp, donation, models.KofiDonationPending, "Payment amount could not be read")
+		}
+		reward := kofi.Rewards.For(tier)
+		flakes, err := kofi.Rewards.FlakesFor(reward, amount, payload.Currency)
+		if err != nil {
+			slog.Warn("Ko-fi payment is in an unsupported currency",
+				slog.String("message_id", payload.MessageID),
+				slog.String("currency", payload.Currency))
+			return recordUnclaimedKofi(c, webApp, donation, models.KofiDonationPending, "Unsupported currency")
+		}
+		donation.Flakes = flakes
+		donation.PremiumDays = reward.PremiumDays
+
+		claimed, err := webApp.Repos.KofiDonation.Claim(c.Context(), donation)
+		recordOperation(webApp, "kofi_claim", err)
+		switch {
+		case errors.Is(err, repositories.ErrUserNotFound):
+			slog.Warn("Ko-fi payment names a Discord user without an account",
+				slog.String("message_id", payload.MessageID),
+				slog.String("user_id", donation.UserID))
+			return recordUnclaimedKofi(c, webApp, donation, models.KofiDonationUnmatched, "User not found")
+		case err != nil:
+			slog.Error("Failed to claim Ko-fi payment",
+				slog.String("message_id", payload.MessageID),
+				slog.String("user_id", donation.UserID),
+				slog.String("error", err.Error()))
+			return utils.SendInternalServerError(c, "Failed to claim Ko-fi payment")
+		case !claimed:
+			slog.Info("Ignored replayed Ko-fi payment", slog.String("message_id", payload.MessageID))
+			return utils.SendSuccess(c, fiber.Map{"claimed": false}, "Payment was already claimed")
+		}
+
+		slog.Info("Claimed Ko-fi payment",
+			slog.String("message_id", payload.MessageID),
+			slog.String("user_id", donation.UserID),
+			slog.String("tier", tier),
+			slog.Int64("flakes", donation.Flakes),
+			slog.Int("premium_days", donation.PremiumDays))
+		return utils.SendSuccess(c, fiber.Map{
+			"claimed":      true,
+			"flakes":       donation.Flakes,
+			"premium_days": donation.PremiumDays,
+		}, "Ko-fi payment claimed")
+	}
+}
+
+// recordUnclaimedKofi stores a Ko-fi payment that couldn't be rewarded with the given
+// status and acknowledges it, so Ko-fi stops redelivering and the payment can be
+// settled by hand. A redelivery once the problem is fixed still claims it.
+func recordUnclaimedKofi(c *fiber.Ctx, webApp *WebApp, donation *models.KofiDonation, status models.KofiDonationStatus, reason string) error {
+	donation.Status = status
+	_, err := webApp.Repos.KofiDonation.Record(c.Context(), donation)
+	recordOperation(webApp, "kofi_record", err)
+	if err != nil {
+		slog.Error("Failed to record Ko-fi payment",
+			slog.String("message_id", donation.MessageID),
+			slog.String("status", string(status)),
+			slog.String("error", err.Error()))
+		return utils.SendInternalServerError(c, "Failed to record Ko-fi payment")
+	}
+	return utils.SendSuccess(c, fiber.Map{"claimed": false, "status": status}, reason)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// callHandler mounts handler on route, sends method to target and decodes the JSON body
+func callHandler(t *testing.T, handler fiber.Handler, method, route, target string) (int, map[string]interface{}) {
+	t.Helper()
+	return callHandlerJSON(t, handler, method, route, target, "")
+}
+
+// callHandlerJSON is callHandler with a JSON request body
+func callHandlerJSON(t *testing.T, handler fiber.Handler, method, route, target, body string) (int, map[string]interface{}) {
+	t.Helper()
+	app := fiber.New()
+	app.Add(method, route, handler)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, target, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	var decoded map[string]interface{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			t.Fatalf("decode %q: %v", raw, err)
+		}
+	}
+	return resp.StatusCode, decoded
+}
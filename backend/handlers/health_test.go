@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	webservices "github.com/disgoorg/bot-template/backend/services"
+)
+
+type unreachable struct{}
+
+func (unreachable) Ping(ctx context.Context) error { return errors.New("connection refused") }
+
+type reachable struct{}
+
+func (reachable) Ping(ctx context.Context) error { return nil }
+
+func TestHealthCheckStatusCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		deps       []webservices.HealthDependency
+		wantStatus int
+		wantHealth string
+	}{
+		{
+			name:       "healthy",
+			deps:       []webservices.HealthDependency{{Name: "database", Pinger: reachable{}, Critical: true}},
+			wantStatus: 200,
+			wantHealth: webservices.HealthStatusHealthy,
+		},
+		{
+			name: "spaces down only degrades",
+			deps: []webservices.HealthDependency{
+				{Name: "database", Pinger: reachable{}, Critical: true},
+				{Name: "spaces", Pinger: unreachable{}},
+			},
+			wantStatus: 200,
+			wantHealth: webservices.HealthStatusDegraded,
+		},
+		{
+			name:       "database down",
+			deps:       []webservices.HealthDependency{{Name: "database", Pinger: unreachable{}, Critical: true}},
+			wantStatus: 503,
+			wantHealth: webservices.HealthStatusUnhealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webApp := &WebApp{Health: webservices.NewHealthChecker(time.Second, tt.deps...), Version: "test"}
+			status, body := callHandler(t, HealthCheck(webApp), "GET", "/health", "/health")
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%v)", status, tt.wantStatus, body)
+			}
+			data, _ := body["data"].(map[string]interface{})
+			if data["status"] != tt.wantHealth {
+				t.Errorf("health = %v, want %s", data["status"], tt.wantHealth)
+			}
+			if tt.wantStatus == 503 {
+				if code := body["error"].(map[string]interface{})["code"]; code != "UNHEALTHY" {
+					t.Errorf("error code = %v, want UNHEALTHY", code)
+				}
+			}
+		})
+	}
+}
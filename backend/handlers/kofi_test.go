@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/backend/config"
+	webmodels "github.com/disgoorg/bot-template/backend/models"
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// missingKofiUser has no account, so claims for it fail like in the real repository
+const missingKofiUser = "999999999999999999"
+
+// fakeKofiDonationRepo claims each message ID once and keeps what was claimed and
+// what was only recorded
+type fakeKofiDonationRepo struct {
+	repositories.KofiDonationRepository
+	claimed  map[string]*models.KofiDonation
+	recorded map[string]*models.KofiDonation
+}
+
+func (r *fakeKofiDonationRepo) Claim(ctx context.Context, donation *models.KofiDonation) (bool, error) {
+	if donation.UserID == missingKofiUser {
+		return false, fmt.Errorf("%w: %s", repositories.ErrUserNotFound, donation.UserID)
+	}
+	if _, ok := r.claimed[donation.MessageID]; ok {
+		return false, nil
+	}
+	r.claimed[donation.MessageID] = donation
+	return true, nil
+}
+
+func (r *fakeKofiDonationRepo) Record(ctx context.Context, donation *models.KofiDonation) (bool, error) {
+	if _, ok := r.recorded[donation.MessageID]; ok {
+		return false, nil
+	}
+	r.recorded[donation.MessageID] = donation
+	return true, nil
+}
+
+func kofiApp() (*WebApp, *fakeKofiDonationRepo) {
+	cfg := &bottemplate.Config{}
+	cfg.Kofi.VerificationToken = "kofi-token"
+	cfg.Kofi.Rewards = services.KofiRewards{
+		Default:       services.KofiReward{FlakesPerUnit: 100},
+		Tiers:         map[string]services.KofiReward{"Gold": {Flakes: 1000, PremiumDays: 30}},
+		CurrencyRates: map[string]float64{"USD": 1},
+	}
+	repo := &fakeKofiDonationRepo{
+		claimed:  make(map[string]*models.KofiDonation),
+		recorded: make(map[string]*models.KofiDonation),
+	}
+	return &WebApp{
+		Config: config.NewWebAppConfig(cfg, false),
+		Repos:  &webmodels.Repositories{KofiDonation: repo},
+	}, repo
+}
+
+// postKofi sends payload the way Ko-fi does, as JSON in the data form field
+func postKofi(t *testing.T, webApp *WebApp, payload webmodels.KofiWebhookPayload) int {
+	t.Helper()
+	app := fiber.New()
+	app.Post("/webhooks/kofi", KofiWebhook(webApp))
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	form := url.Values{"data": {string(data)}}
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/kofi", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("POST /webhooks/kofi: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestKofiWebhookClaimsOnce(t *testing.T) {
+	webApp, repo := kofiApp()
+	payload := webmodels.KofiWebhookPayload{
+		VerificationToken: "kofi-token",
+		MessageID:         "msg-1",
+		Type:              "Donation",
+		Message:           "for 123456789012345678, thanks!",
+		Amount:            "3.00",
+		Currency:          "USD",
+	}
+
+	if status := postKofi(t, webApp, payload); status != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	donation := repo.claimed["msg-1"]
+	if donation == nil || donation.UserID != "123456789012345678" || donation.Flakes != 300 {
+		t.Fatalf("claimed %+v, want 300 flakes for the ID in the message", donation)
+	}
+
+	// Redelivery is acknowledged without claiming again
+	payload.Amount = "50.00"
+	if status := postKofi(t, webApp, payload); status != fiber.StatusOK || repo.claimed["msg-1"].Flakes != 300 {
+		t.Errorf("replay status = %d with %d flakes, want 200 and the first claim kept", status, repo.claimed["msg-1"].Flakes)
+	}
+
+	// Subscriptions use their tier's reward and the linked Discord account
+	sub := webmodels.KofiWebhookPayload{
+		VerificationToken:     "kofi-token",
+		MessageID:             "msg-2",
+		Type:                  "Subscription",
+		Amount:                "5.00",
+		Currency:              "USD",
+		IsSubscriptionPayment: true,
+		TierName:              "Gold",
+		DiscordUserID:         "223456789012345678",
+	}
+	if status := postKofi(t, webApp, sub); status != fiber.StatusOK {
+		t.Fatalf("subscription status = %d", status)
+	}
+	if d := repo.claimed["msg-2"]; d.UserID != "223456789012345678" || d.Flakes != 1000 || d.PremiumDays != 30 || d.TierName != "Gold" {
+		t.Errorf("subscription claimed %+v", d)
+	}
+}
+
+func TestKofiWebhookRejects(t *testing.T) {
+	valid := webmodels.KofiWebhookPayload{VerificationToken: "kofi-token", MessageID: "msg-1", Type: "Donation", DiscordUserID: "123456789012345678", Amount: "3.00", Currency: "USD"}
+	tests := []struct {
+		name   string
+		modify func(*webmodels.KofiWebhookPayload)
+		want   int
+	}{
+		{name: "bad token", modify: func(p *webmodels.KofiWebhookPayload) { p.VerificationToken = "guess" }, want: fiber.StatusUnauthorized},
+		{name: "no message ID", modify: func(p *webmodels.KofiWebhookPayload) { p.MessageID = "" }, want: fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webApp, repo := kofiApp()
+			payload := valid
+			tt.modify(&payload)
+			if status := postKofi(t, webApp, payload); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+			if len(repo.claimed) != 0 || len(repo.recorded) != 0 {
+				t.Errorf("claimed %v, recorded %v", repo.claimed, repo.recorded)
+			}
+		})
+	}
+}
+
+func TestKofiWebhookRecordsUnclaimedPayments(t *testing.T) {
+	valid := webmodels.KofiWebhookPayload{VerificationToken: "kofi-token", MessageID: "msg-1", Type: "Donation", DiscordUserID: "123456789012345678", Amount: "3.00", Currency: "USD"}
+	tests := []struct {
+		name   string
+		modify func(*webmodels.KofiWebhookPayload)
+		want   models.KofiDonationStatus
+	}{
+		{name: "unlinked", modify: func(p *webmodels.KofiWebhookPayload) { p.DiscordUserID = "" }, want: models.KofiDonationUnmatched},
+		{name: "unknown user", modify: func(p *webmodels.KofiWebhookPayload) { p.DiscordUserID = missingKofiUser }, want: models.KofiDonationUnmatched},
+		// A malformed amount must not be priced as zero and still grant premium days
+		{name: "malformed amount", modify: func(p *webmodels.KofiWebhookPayload) { p.Amount = "3,00" }, want: models.KofiDonationPending},
+		{name: "zero amount", modify: func(p *webmodels.KofiWebhookPayload) { p.Amount = "0" }, want: models.KofiDonationPending},
+		{name: "unknown currency", modify: func(p *webmodels.KofiWebhookPayload) { p.Currency = "JPY" }, want: models.KofiDonationPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webApp, repo := kofiApp()
+			payload := valid
+			tt.modify(&payload)
+			if status := postKofi(t, webApp, payload); status != fiber.StatusOK {
+				t.Errorf("status = %d, want 200", status)
+			}
+			if len(repo.claimed) != 0 {
+				t.Errorf("claimed %v", repo.claimed)
+			}
+			donation := repo.recorded["msg-1"]
+			if donation == nil || donation.Status != tt.want || donation.Amount != payload.Amount || donation.Currency != payload.Currency {
+				t.Errorf("recorded %+v, want the payment kept as %s", donation, tt.want)
+			}
+		})
+	}
+}
+
+func TestKofiDonorID(t *testing.T) {
+	tests := []struct {
+		payload webmodels.KofiWebhookPayload
+		want    string
+	}{
+		{webmodels.KofiWebhookPayload{DiscordUserID: "123456789012345678", Message: "223456789012345678"}, "123456789012345678"},
+		{webmodels.KofiWebhookPayload{Message: "my id is 223456789012345678"}, "223456789012345678"},
+		{webmodels.KofiWebhookPayload{DiscordUserID: "not-an-id", Message: "no id here 12345"}, ""},
+	}
+	for _, tt := range tests {
+		if got := kofiDonorID(&tt.payload); got != tt.want {
+			t.Errorf("kofiDonorID(%+v) = %q, want %q", tt.payload, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/disgoorg/bot-template/backend/config"
+	webservices "github.com/disgoorg/bot-template/backend/services"
+	"github.com/disgoorg/bot-template/bottemplate"
+)
+
+func TestMetricsEndpointRequiresToken(t *testing.T) {
+	webApp := &WebApp{
+		Config:  &config.WebAppConfig{Metrics: bottemplate.MetricsConfig{Enabled: true, Token: "scrape-secret"}},
+		Metrics: webservices.NewMetricsRegistry(),
+	}
+
+	status, body := callHandler(t, MetricsEndpoint(webApp), "GET", "/metrics", "/metrics")
+	if status != 401 {
+		t.Fatalf("status = %d, want 401", status)
+	}
+	if code := body["error"].(map[string]interface{})["code"]; code != "UNAUTHORIZED" {
+		t.Errorf("error code = %v", code)
+	}
+}
+
+func TestMetricsEndpointRefusesWithoutConfiguredToken(t *testing.T) {
+	webApp := &WebApp{
+		Config:  &config.WebAppConfig{Metrics: bottemplate.MetricsConfig{Enabled: true}},
+		Metrics: webservices.NewMetricsRegistry(),
+	}
+
+	if status, _ := callHandler(t, MetricsEndpoint(webApp), "GET", "/metrics", "/metrics"); status != 401 {
+		t.Errorf("status = %d, want 401", status)
+	}
+}
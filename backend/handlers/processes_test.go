@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	webmodels "github.com/disgoorg/bot-template/backend/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// fakeProcessRepo knows a fixed set of processes and records restart requests
+type fakeProcessRepo struct {
+	repositories.BackgroundProcessRepository
+	processes []*models.BackgroundProcess
+	requested []string
+}
+
+func (r *fakeProcessRepo) List(ctx context.Context) ([]*models.BackgroundProcess, error) {
+	return r.processes, nil
+}
+
+func (r *fakeProcessRepo) RequestRestart(ctx context.Context, name string) error {
+	for _, process := range r.processes {
+		if process.Name == name {
+			r.requested = append(r.requested, name)
+			return nil
+		}
+	}
+	return &repositories.NotFoundError{Entity: "background_process", ID: name}
+}
+
+func TestProcessesRestart(t *testing.T) {
+	repo := &fakeProcessRepo{processes: []*models.BackgroundProcess{{Name: "effect-expiry", Status: "running"}}}
+	webApp := &WebApp{Repos: &webmodels.Repositories{Process: repo}}
+
+	status, body := callHandler(t, ProcessesList(webApp), "GET", "/processes", "/processes")
+	if status != 200 || len(body["data"].([]interface{})) != 1 {
+		t.Errorf("list = %d %v, want one process", status, body)
+	}
+
+	status, body = callHandler(t, ProcessesRestart(webApp), "POST", "/processes/:name/restart", "/processes/effect-expiry/restart")
+	if status != 200 || len(repo.requested) != 1 || repo.requested[0] != "effect-expiry" {
+		t.Errorf("restart = %d %v, requested %v", status, body, repo.requested)
+	}
+
+	status, body = callHandler(t, ProcessesRestart(webApp), "POST", "/processes/:name/restart", "/processes/missing/restart")
+	if status != 404 {
+		t.Fatalf("unknown process status = %d, want 404", status)
+	}
+	if code := body["error"].(map[string]interface{})["code"]; code != "PROCESS_NOT_FOUND" {
+		t.Errorf("error code = %v", code)
+	}
+}
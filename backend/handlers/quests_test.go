@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	webmodels "github.com/disgoorg/bot-template/backend/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+type fakeQuestRepo struct {
+	repositories.QuestRepository
+	progress []*models.UserQuestProgress
+	err      error
+}
+
+func (r *fakeQuestRepo) GetUserQuestOverview(ctx context.Context, userID string) ([]*models.UserQuestProgress, error) {
+	return r.progress, r.err
+}
+
+func questProgress(questID, questType string, current, required int) *models.UserQuestProgress {
+	return &models.UserQuestProgress{
+		QuestID:         questID,
+		CurrentProgress: current,
+		Completed:       current >= required,
+		QuestDefinition: &models.QuestDefinition{QuestID: questID, Name: questID, Type: questType, RequirementCount: required},
+	}
+}
+
+func TestUsersQuestsGroupsByType(t *testing.T) {
+	webApp := &WebApp{Repos: &webmodels.Repositories{Quest: &fakeQuestRepo{progress: []*models.UserQuestProgress{
+		questProgress("daily_claims", models.QuestTypeDaily, 3, 4),
+		questProgress("daily_work", models.QuestTypeDaily, 5, 5),
+		questProgress("monthly_levels", models.QuestTypeMonthly, 1, 10),
+	}}}}
+
+	status, body := callHandler(t, UsersQuests(webApp), "GET", "/users/:id/quests", "/users/42/quests")
+	if status != 200 {
+		t.Fatalf("status = %d, body %v", status, body)
+	}
+
+	data := body["data"].(map[string]interface{})
+	if data["user_id"] != "42" {
+		t.Errorf("user_id = %v, want 42", data["user_id"])
+	}
+	daily := data["daily"].([]interface{})
+	weekly := data["weekly"].([]interface{})
+	monthly := data["monthly"].([]interface{})
+	if len(daily) != 2 || len(weekly) != 0 || len(monthly) != 1 {
+		t.Fatalf("got %d daily, %d weekly, %d monthly quests, want 2, 0, 1", len(daily), len(weekly), len(monthly))
+	}
+
+	first := daily[0].(map[string]interface{})
+	if first["quest_id"] != "daily_claims" || first["percentage"] != 75.0 || first["completed"] != false {
+		t.Errorf("daily[0] = %v", first)
+	}
+	if monthly[0].(map[string]interface{})["requirement_count"] != 10.0 {
+		t.Errorf("monthly[0] = %v", monthly[0])
+	}
+}
+
+func TestUsersQuestsRepositoryError(t *testing.T) {
+	webApp := &WebApp{Repos: &webmodels.Repositories{Quest: &fakeQuestRepo{err: errors.New("connection reset")}}}
+
+	status, body := callHandler(t, UsersQuests(webApp), "GET", "/users/:id/quests", "/users/42/quests")
+	if status != 500 {
+		t.Fatalf("status = %d, want 500", status)
+	}
+	if code := body["error"].(map[string]interface{})["code"]; code != "QUESTS_FAILED" {
+		t.Errorf("code = %v, want QUESTS_FAILED", code)
+	}
+}
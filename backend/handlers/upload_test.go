@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/backend/config"
+	"github.com/disgoorg/bot-template/backend/services/spacestest"
+	"github.com/disgoorg/bot-template/bottemplate"
+)
+
+// newFileHeader builds a multipart file header the way fiber hands one to a handler
+func newFileHeader(t *testing.T, filename string, data []byte) *multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("files", filename)
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		t.Fatalf("write form file: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close multipart writer: %v", err)
+	}
+
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(int64(len(data)) + 1024)
+	if err != nil {
+		t.Fatalf("read form: %v", err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+	return form.File["files"][0]
+}
+
+func pngBytes(t *testing.T, size int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, size, size))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func uploadApp(maxUploadMB int) (*WebApp, *spacestest.Fake) {
+	spaces := spacestest.New("cards")
+	cfg := &bottemplate.Config{}
+	cfg.Web.MaxUploadMB = maxUploadMB
+	return &WebApp{Config: config.NewWebAppConfig(cfg, false), SpacesService: spaces}, spaces
+}
+
+func TestProcessUploadedFileRejectsOversizedFiles(t *testing.T) {
+	webApp, spaces := uploadApp(1)
+	data := append(pngBytes(t, 1), make([]byte, 1024*1024)...)
+
+	result := processUploadedFile(context.Background(), webApp, newFileHeader(t, "big.png", data))
+
+	if result["success"] != false || result["code"] != "FILE_TOO_LARGE" {
+		t.Fatalf("result = %v, want FILE_TOO_LARGE", result)
+	}
+	if result["error"] != "File too large (max 1MB)" {
+		t.Errorf("error = %q", result["error"])
+	}
+	if keys := spaces.Keys(); len(keys) != 0 {
+		t.Errorf("oversized file was uploaded: %v", keys)
+	}
+}
+
+func TestProcessUploadedFileUsesDefaultLimit(t *testing.T) {
+	webApp, _ := uploadApp(0)
+	if webApp.Config.MaxUploadSize != config.DefaultMaxUploadSize {
+		t.Fatalf("MaxUploadSize = %d, want default %d", webApp.Config.MaxUploadSize, config.DefaultMaxUploadSize)
+	}
+
+	data := append(pngBytes(t, 1), make([]byte, config.DefaultMaxUploadSize)...)
+	result := processUploadedFile(context.Background(), webApp, newFileHeader(t, "big.png", data))
+	if result["code"] != "FILE_TOO_LARGE" {
+		t.Errorf("result = %v, want FILE_TOO_LARGE", result)
+	}
+}
+
+func TestProcessUploadedFileStreamsToSpaces(t *testing.T) {
+	webApp, spaces := uploadApp(1)
+	data := pngBytes(t, 16)
+
+	result := processUploadedFile(context.Background(), webApp, newFileHeader(t, "Na Yeon.png", data))
+
+	if result["success"] != true {
+		t.Fatalf("result = %v, want success", result)
+	}
+	if result["type"] != "image/png" {
+		t.Errorf("type = %v, want image/png", result["type"])
+	}
+	keys := spaces.Keys()
+	if len(keys) != 1 {
+		t.Fatalf("uploaded keys = %v, want one", keys)
+	}
+	if result["url"] != spaces.GetObjectURL(keys[0]) {
+		t.Errorf("url = %v, want %s", result["url"], spaces.GetObjectURL(keys[0]))
+	}
+	if stored, _ := spaces.Object(keys[0]); !bytes.Equal(stored, data) {
+		t.Errorf("stored %d bytes, want the %d uploaded", len(stored), len(data))
+	}
+}
+
+func TestProcessUploadedFileKeepsSameNamedUploads(t *testing.T) {
+	webApp, spaces := uploadApp(1)
+	first, second := pngBytes(t, 8), pngBytes(t, 16)
+
+	firstResult := processUploadedFile(context.Background(), webApp, newFileHeader(t, "card.png", first))
+	secondResult := processUploadedFile(context.Background(), webApp, newFileHeader(t, "card.png", second))
+	if firstResult["success"] != true || secondResult["success"] != true {
+		t.Fatalf("results = %v, %v, want both uploaded", firstResult, secondResult)
+	}
+	if firstResult["url"] == secondResult["url"] {
+		t.Fatalf("both uploads got %v", firstResult["url"])
+	}
+
+	for _, upload := range []struct {
+		url  any
+		data []byte
+	}{{firstResult["url"], first}, {secondResult["url"], second}} {
+		key := strings.TrimPrefix(upload.url.(string), spaces.GetObjectURL(""))
+		if !strings.HasPrefix(key, "uploads/") || !strings.HasSuffix(key, "/card.png") {
+			t.Errorf("key = %q, want the filename below its own uploads prefix", key)
+		}
+		if stored, _ := spaces.Object(key); !bytes.Equal(stored, upload.data) {
+			t.Errorf("%s holds %d bytes, want the %d uploaded there", key, len(stored), len(upload.data))
+		}
+	}
+}
+
+func TestProcessUploadedFileSniffsShortFiles(t *testing.T) {
+	webApp, spaces := uploadApp(1)
+
+	// Shorter than the sniff buffer: the partial read is validated, not treated as a read error
+	result := processUploadedFile(context.Background(), webApp, newFileHeader(t, "tiny.jpg", pngBytes(t, 1)))
+
+	if result["code"] != "INVALID_FILE_TYPE" {
+		t.Fatalf("result = %v, want INVALID_FILE_TYPE", result)
+	}
+	if keys := spaces.Keys(); len(keys) != 0 {
+		t.Errorf("rejected file was uploaded: %v", keys)
+	}
+}
+
+func TestNewWebAppConfigUploadLimits(t *testing.T) {
+	cfg := &bottemplate.Config{}
+	cfg.Web.MaxUploadMB = 200
+	cfg.Web.MaxRequestMB = 50
+
+	webCfg := config.NewWebAppConfig(cfg, false)
+	if webCfg.MaxUploadSize != 200*1024*1024 {
+		t.Errorf("MaxUploadSize = %d, want 200MB", webCfg.MaxUploadSize)
+	}
+	// The request limit never undercuts a single file
+	if webCfg.MaxRequestSize != webCfg.MaxUploadSize {
+		t.Errorf("MaxRequestSize = %d, want raised to %d", webCfg.MaxRequestSize, webCfg.MaxUploadSize)
+	}
+}
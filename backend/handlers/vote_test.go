@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/backend/config"
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func voteApp(secret string) *WebApp {
+	cfg := &bottemplate.Config{}
+	cfg.Vote.WebhookSecret = secret
+	return &WebApp{
+		Config:      config.NewWebAppConfig(cfg, false),
+		VoteRewards: services.NewVoteRewardService(nil, nil, services.VoteReward{Flakes: 500}),
+	}
+}
+
+// postVote sends a vote webhook with the given Authorization header and returns the status
+func postVote(t *testing.T, webApp *WebApp, authorization, body string) int {
+	t.Helper()
+	app := fiber.New()
+	app.Post("/webhooks/vote", VoteWebhook(webApp))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/vote", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("POST /webhooks/vote: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestVoteWebhookRejectsBadRequests(t *testing.T) {
+	const upvote = `{"user": "123456789012345678", "type": "upvote"}`
+	tests := []struct {
+		name          string
+		webApp        *WebApp
+		authorization string
+		body          string
+		want          int
+	}{
+		{name: "disabled", webApp: voteApp(""), authorization: "", body: upvote, want: fiber.StatusNotFound},
+		{name: "missing secret", webApp: voteApp("s3cret"), authorization: "", body: upvote, want: fiber.StatusUnauthorized},
+		{name: "wrong secret", webApp: voteApp("s3cret"), authorization: "guess", body: upvote, want: fiber.StatusUnauthorized},
+		{name: "bad voter", webApp: voteApp("s3cret"), authorization: "s3cret", body: `{"user": "someone", "type": "upvote"}`, want: fiber.StatusBadRequest},
+		// The list's test button is acknowledged without paying anything
+		{name: "test vote", webApp: voteApp("s3cret"), authorization: "s3cret", body: `{"user": "123456789012345678", "type": "test"}`, want: fiber.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := postVote(t, tt.webApp, tt.authorization, tt.body); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -41,7 +41,7 @@ func main() {
 
 	// Initialize logger first
 	customHandler := logger.NewHandler("GoHYE-Backend")
-	slog.SetDefault(slog.New(customHandler))
+	slog.SetDefault(slog.New(logger.NewContextHandler(customHandler)))
 
 	slog.Info("Starting GoHYE Backend API",
 		slog.String("version", version),
@@ -54,9 +54,18 @@ func main() {
 		slog.Error("Failed to load config", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
+	if err := cfg.ValidateWeb(); err != nil {
+		slog.Error("Config is invalid", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
 
 	// Create web app configuration
-	webCfg := config.NewWebAppConfig(cfg, true) // debug mode for development
+	webCfg := config.NewWebAppConfig(cfg, cfg.Web.Debug)
+	if err := webCfg.ValidateOrigins(); err != nil {
+		slog.Error("Invalid CORS configuration", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	slog.Info("CORS origins configured", slog.Any("origins", webCfg.AllowedOrigins))
 
 	// Initialize database
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -70,6 +79,8 @@ func main() {
 		Password: cfg.DB.Password,
 		Database: cfg.DB.Database,
 		PoolSize: cfg.DB.PoolSize,
+
+		SlowQueryThresholdMs: cfg.DB.SlowQueryThresholdMs,
 	}
 	db, err := database.New(ctx, dbConfig)
 	if err != nil {
@@ -89,6 +100,10 @@ func main() {
 		repositories.NewEffectRepository(db.BunDB()),
 		repositories.NewWishlistRepository(db.BunDB()),
 		repositories.NewEconomyStatsRepository(db.BunDB()),
+		repositories.NewQuestRepository(db.BunDB()),
+		repositories.NewAdminAuditRepository(db.BunDB()),
+		repositories.NewBackgroundProcessRepository(db.BunDB()),
+		repositories.NewKofiDonationRepository(db.BunDB()),
 	)
 
 	// Initialize services
@@ -99,22 +114,33 @@ func main() {
 		cfg.Spaces.Bucket,
 		cfg.Spaces.CardRoot,
 	)
+	if cfg.Spaces.Private {
+		spacesService.UseSignedURLs(time.Duration(cfg.Spaces.SignedURLTTLSeconds) * time.Second)
+	}
 
 	// Initialize transaction manager
 	txManager := economyutils.NewEconomicTransactionManager(db.BunDB())
 
 	// Initialize web services
-	cardMgmtService := webservices.NewCardManagementService(repos, spacesService)
+	cardMgmtService := webservices.NewCardManagementService(repos, spacesService, webCfg.CardManagement)
 	syncMgrService := webservices.NewSyncManagerService(repos, spacesService)
 	collectionImportService := webservices.NewCollectionImportService(repos.Card, repos.Collection, spacesService, txManager)
+	cardImportService := webservices.NewCardImportService(repos, spacesService, cardMgmtService, db.BunDB())
 	oauthService := webservices.NewOAuthService(webCfg)
 	sessionService := webservices.NewSessionService(webCfg)
 
+	// Dependencies pinged by /health; Spaces only degrades the status since the API works without images
+	healthChecker := webservices.NewHealthChecker(webservices.DefaultHealthCheckTimeout,
+		webservices.HealthDependency{Name: "database", Pinger: db, Critical: true},
+		webservices.HealthDependency{Name: "spaces", Pinger: spacesService},
+	)
+
 	// Initialize Fiber as API-only backend
 	app := fiber.New(fiber.Config{
 		AppName:      "GoHYE Backend API",
 		ServerHeader: "GoHYE-Backend",
 		ErrorHandler: middleware.CustomErrorHandler,
+		BodyLimit:    int(webCfg.MaxRequestSize),
 	})
 
 	// Global middleware
@@ -123,14 +149,16 @@ func main() {
 	app.Use(compress.New(compress.Config{
 		Level: compress.LevelBestSpeed, // Fast compression for better performance
 	}))
-	app.Use(cors.New(cors.Config{
-		AllowOrigins:     "http://localhost:3000,http://localhost:8080",
-		AllowMethods:     "GET,POST,PUT,DELETE,PATCH,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Requested-With,Cookie",
-		AllowCredentials: true,
-	}))
+	app.Use(cors.New(middleware.CORSConfig(webCfg)))
 	app.Use(middleware.LoggingMiddleware())
 
+	// Prometheus metrics are opt-in; the registry stays nil when disabled
+	var metrics *webservices.MetricsRegistry
+	if webCfg.Metrics.Enabled {
+		metrics = webservices.NewMetricsRegistry()
+		app.Use(middleware.MetricsMiddleware(metrics))
+	}
+
 	// Create web app instance
 	webApp := &handlers.WebApp{
 		Config:                  webCfg,
@@ -140,12 +168,19 @@ func main() {
 		CardMgmtService:         cardMgmtService,
 		SyncMgrService:          syncMgrService,
 		CollectionImportService: collectionImportService,
+		CardImportService:       cardImportService,
 		OAuthService:            oauthService,
 		SessionService:          sessionService,
+		Health:                  healthChecker,
+		Metrics:                 metrics,
+		VoteRewards:             services.NewVoteRewardService(db.BunDB(), repos.User, cfg.Vote.Reward),
 		Version:                 version,
 		Commit:                  commit,
 	}
 
+	// Renew near-expiry sessions before any route reads them
+	app.Use(middleware.SessionRenewal(webApp))
+
 	// Setup routes
 	setupRoutes(app, webApp)
 
@@ -184,6 +219,11 @@ func setupRoutes(app *fiber.App, webApp *handlers.WebApp) {
 	// Health check endpoint
 	app.Get("/health", handlers.HealthCheck(webApp))
 
+	// Prometheus scrape endpoint, only registered when metrics are enabled
+	if webApp.Metrics != nil {
+		app.Get("/metrics", handlers.MetricsEndpoint(webApp))
+	}
+
 	// Authentication routes
 	auth := app.Group("/auth")
 	auth.Get("/discord", handlers.DiscordOAuth(webApp))
@@ -212,40 +252,60 @@ func setupRoutes(app *fiber.App, webApp *handlers.WebApp) {
 	// Card management routes (API)
 	cards := admin.Group("/cards")
 	cards.Get("/:id", handlers.CardsDetail(webApp))
-	cards.Post("/", handlers.CardsCreate(webApp))
-	cards.Put("/:id", handlers.CardsUpdate(webApp))
-	cards.Delete("/:id", handlers.CardsDelete(webApp))
-	cards.Post("/bulk", handlers.CardsBulkOperation(webApp))
+	cards.Post("/", middleware.PermissionRequired(webmodels.PermissionCardsCreate), handlers.CardsCreate(webApp))
+	cards.Put("/:id", middleware.PermissionRequired(webmodels.PermissionCardsUpdate), handlers.CardsUpdate(webApp))
+	cards.Delete("/:id", middleware.PermissionRequired(webmodels.PermissionCardsDelete), handlers.CardsDelete(webApp))
+	cards.Post("/:id/restore", middleware.PermissionRequired(webmodels.PermissionCardsDelete), handlers.CardsRestore(webApp))
+	cards.Post("/bulk", handlers.CardsBulkOperation(webApp)) // checks per-operation permissions itself
+	cards.Post("/import", middleware.PermissionRequired(webmodels.PermissionCollectionsImport), handlers.CardsImport(webApp))
+	cards.Post("/import/validate", middleware.PermissionRequired(webmodels.PermissionCollectionsImport), handlers.CardsImportValidate(webApp))
 
 	// Collection management routes (API)
 	collections := admin.Group("/collections")
 	collections.Get("/import", handlers.CollectionsImportPage(webApp))
 	collections.Get("/:id", handlers.CollectionsDetail(webApp))
-	collections.Post("/", handlers.CollectionsCreate(webApp))
-	collections.Post("/import", handlers.CollectionsImport(webApp))
-	collections.Put("/:id", handlers.CollectionsUpdate(webApp))
-	collections.Delete("/:id", handlers.CollectionsDelete(webApp))
+	collections.Post("/", middleware.PermissionRequired(webmodels.PermissionCollectionsManage), handlers.CollectionsCreate(webApp))
+	collections.Post("/import", middleware.PermissionRequired(webmodels.PermissionCollectionsImport), handlers.CollectionsImport(webApp))
+	collections.Post("/import/batch", middleware.PermissionRequired(webmodels.PermissionCollectionsImport), handlers.CardsImportCollections(webApp))
+	collections.Put("/:id", middleware.PermissionRequired(webmodels.PermissionCollectionsManage), handlers.CollectionsUpdate(webApp))
+	collections.Delete("/:id", middleware.PermissionRequired(webmodels.PermissionCollectionsManage), handlers.CollectionsDelete(webApp))
 
 	// Sync management routes (API)
-	sync := admin.Group("/sync")
+	sync := admin.Group("/sync", middleware.PermissionRequired(webmodels.PermissionSyncManage))
 	sync.Get("/status", handlers.SyncStatus(webApp))
 	sync.Post("/fix", handlers.SyncFix(webApp))
 	sync.Post("/cleanup", handlers.SyncCleanup(webApp))
 
 	// User management routes (API)
-	users := admin.Group("/users")
+	users := admin.Group("/users", middleware.PermissionRequired(webmodels.PermissionUsersView))
 	users.Get("/:id", handlers.UsersDetail(webApp))
+	users.Get("/:id/quests", handlers.UsersQuests(webApp))
+
+	// Admin command audit log (API)
+	admin.Get("/audit", middleware.PermissionRequired(webmodels.PermissionAuditView), handlers.AdminAuditList(webApp))
+
+	// Background processes reported by the bot
+	processes := admin.Group("/processes", middleware.PermissionRequired(webmodels.PermissionProcessesManage))
+	processes.Get("", handlers.ProcessesList(webApp))
+	processes.Post("/:name/restart", handlers.ProcessesRestart(webApp))
 
 	// API routes for Next.js frontend
 	api := admin.Group("/api")
 	api.Get("/cards", handlers.CardsAPI(webApp))
+	api.Get("/search", handlers.CardQuerySearchAPI(webApp))
 	api.Get("/collections", handlers.CollectionsAPI(webApp))
 	api.Get("/collections/:id/cards", handlers.CollectionCardsAPI(webApp))
-	api.Post("/upload", handlers.UploadAPI(webApp))
+	api.Post("/upload", middleware.PermissionRequired(webmodels.PermissionCardsCreate), handlers.UploadAPI(webApp))
 	api.Get("/progress/:id", handlers.ProgressAPI(webApp))
 	api.Get("/dashboard/stats", handlers.DashboardStatsAPI(webApp))
 	api.Get("/activity", handlers.ActivityAPI(webApp))
 
+	// Bot list vote webhook; authenticated by the shared webhook secret
+	app.Post("/webhooks/vote", handlers.VoteWebhook(webApp))
+
+	// Ko-fi payment webhook; authenticated by the verification token in the payload
+	app.Post("/webhooks/kofi", handlers.KofiWebhook(webApp))
+
 	// Session validation endpoint for Next.js frontend
 	app.Get("/api/auth/validate", handlers.ValidateSession(webApp))
 
@@ -37,7 +37,66 @@ func AuthRequired(webApp *handlers.WebApp) fiber.Handler {
 	}
 }
 
-// AdminRequired middleware ensures the user has admin privileges
+// SessionRenewal transparently renews sessions that are close to expiry using
+// the stored Discord refresh token. Admin status is re-derived from the fresh
+// token, and failures leave the current session to expire on its own.
+func SessionRenewal(webApp *handlers.WebApp) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		session, err := webApp.SessionService.GetSession(c)
+		if err != nil || !webApp.SessionService.NeedsRenewal(session) {
+			return c.Next()
+		}
+
+		ctx := c.Context()
+		token, err := webApp.OAuthService.RefreshToken(ctx, session.RefreshToken)
+		if err != nil {
+			slog.Warn("Session renewal: failed to refresh token",
+				slog.String("discord_id", session.DiscordID),
+				slog.String("error", err.Error()))
+			return c.Next()
+		}
+
+		user, err := webApp.OAuthService.GetUserInfo(ctx, token.AccessToken)
+		if err != nil {
+			slog.Warn("Session renewal: failed to get user info",
+				slog.String("discord_id", session.DiscordID),
+				slog.String("error", err.Error()))
+			return c.Next()
+		}
+
+		renewed, err := webApp.OAuthService.CreateUserSession(ctx, user, token)
+		if err != nil {
+			slog.Warn("Session renewal: failed to create session",
+				slog.String("discord_id", session.DiscordID),
+				slog.String("error", err.Error()))
+			return c.Next()
+		}
+
+		// Lost dashboard access since login; end the session instead of renewing it
+		if !webApp.SessionService.HasPermission(renewed, models.PermissionAdminRead) {
+			slog.Warn("Session renewal: user no longer has dashboard permissions",
+				slog.String("discord_id", session.DiscordID))
+			webApp.SessionService.DestroySession(c)
+			return c.Next()
+		}
+
+		if err := webApp.SessionService.CreateSession(c, renewed); err != nil {
+			slog.Warn("Session renewal: failed to write session",
+				slog.String("discord_id", session.DiscordID),
+				slog.String("error", err.Error()))
+			return c.Next()
+		}
+
+		slog.Debug("Session renewed",
+			slog.String("discord_id", renewed.DiscordID),
+			slog.Time("expires_at", renewed.ExpiresAt))
+
+		return c.Next()
+	}
+}
+
+// AdminRequired middleware ensures the user can access the admin dashboard.
+// Routes that change data add a PermissionRequired guard on top.
 func AdminRequired(webApp *handlers.WebApp) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Get user from context (should be set by AuthRequired middleware)
@@ -53,9 +112,9 @@ func AdminRequired(webApp *handlers.WebApp) fiber.Handler {
 			return utils.SendForbidden(c, "Access denied")
 		}
 
-		// Check if user has admin privileges
-		if !session.IsAdmin {
-			slog.Warn("Admin required: user lacks admin privileges",
+		// Check if user has dashboard access
+		if !webApp.SessionService.HasPermission(session, models.PermissionAdminRead) {
+			slog.Warn("Admin required: user lacks dashboard permissions",
 				slog.String("discord_id", session.DiscordID),
 				slog.String("username", session.Username))
 			return utils.SendForbidden(c, "Admin access required")
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/backend/config"
+	"github.com/disgoorg/bot-template/backend/handlers"
+	"github.com/disgoorg/bot-template/backend/models"
+	webservices "github.com/disgoorg/bot-template/backend/services"
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeDiscord answers the OAuth token and user endpoints. OAuthService uses the default
+// transport, so it is swapped for the duration of the test.
+type fakeDiscord struct {
+	refreshes int
+}
+
+func (d *fakeDiscord) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := `{"id":"u1","username":"nayeon"}`
+	if strings.HasSuffix(req.URL.Path, "/oauth2/token") {
+		d.refreshes++
+		body = `{"access_token":"access-2","refresh_token":"refresh-2","expires_in":604800}`
+	}
+	return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func newRenewalApp(t *testing.T, adminUsers []string) (*fiber.App, *fakeDiscord) {
+	t.Helper()
+	discord := &fakeDiscord{}
+	original := http.DefaultTransport
+	http.DefaultTransport = discord
+	t.Cleanup(func() { http.DefaultTransport = original })
+
+	cfg := &config.WebAppConfig{Config: &bottemplate.Config{Web: bottemplate.WebConfig{
+		SessionKey: "test-session-key",
+		AdminUsers: adminUsers,
+	}}}
+	webApp := &handlers.WebApp{
+		SessionService: webservices.NewSessionService(cfg),
+		OAuthService:   webservices.NewOAuthService(cfg),
+	}
+
+	app := fiber.New()
+	app.Get("/login", func(c *fiber.Ctx) error {
+		expiresIn, _ := time.ParseDuration(c.Query("expires_in"))
+		return webApp.SessionService.CreateSession(c, &models.UserSession{
+			DiscordID: "u1", IsAdmin: true, RefreshToken: "refresh-1", ExpiresAt: time.Now().Add(expiresIn),
+		})
+	})
+	app.Use(SessionRenewal(webApp))
+	app.Get("/me", func(c *fiber.Ctx) error {
+		session, err := webApp.SessionService.GetSession(c)
+		if err != nil {
+			return c.Status(401).SendString("signed out")
+		}
+		return c.SendString(session.RefreshToken)
+	})
+	return app, discord
+}
+
+func requestWithSession(t *testing.T, app *fiber.App, expiresIn time.Duration) (string, *http.Response) {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest("GET", "/login?expires_in="+expiresIn.String(), nil), -1)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	req := httptest.NewRequest("GET", "/me", nil)
+	for _, cookie := range resp.Cookies() {
+		req.AddCookie(cookie)
+	}
+	resp, err = app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("me: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	return string(body), resp
+}
+
+func TestSessionRenewalInsideWindow(t *testing.T) {
+	app, discord := newRenewalApp(t, []string{"u1"})
+
+	body, resp := requestWithSession(t, app, time.Hour)
+	if body != "refresh-2" || discord.refreshes != 1 {
+		t.Errorf("session refresh token %q after %d refreshes, want the rotated token", body, discord.refreshes)
+	}
+	var renewed bool
+	for _, cookie := range resp.Cookies() {
+		renewed = renewed || (cookie.Name == webservices.SessionCookieName && cookie.Value != "")
+	}
+	if !renewed {
+		t.Error("renewed session cookie was not sent")
+	}
+}
+
+func TestSessionRenewalOutsideWindow(t *testing.T) {
+	app, discord := newRenewalApp(t, []string{"u1"})
+
+	if body, _ := requestWithSession(t, app, 20*time.Hour); body != "refresh-1" || discord.refreshes != 0 {
+		t.Errorf("session refresh token %q after %d refreshes, want it untouched", body, discord.refreshes)
+	}
+}
+
+func TestSessionRenewalDropsRevokedAdmin(t *testing.T) {
+	app, _ := newRenewalApp(t, nil)
+
+	if body, _ := requestWithSession(t, app, time.Hour); body != "signed out" {
+		t.Errorf("got %q, want the session ended once admin access is gone", body)
+	}
+}
@@ -2,10 +2,23 @@ package middleware
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/disgoorg/bot-template/backend/config"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
+// CORSConfig builds the CORS settings from the configured allowed origins
+func CORSConfig(webCfg *config.WebAppConfig) cors.Config {
+	return cors.Config{
+		AllowOrigins:     strings.Join(webCfg.AllowedOrigins, ","),
+		AllowMethods:     "GET,POST,PUT,DELETE,PATCH,OPTIONS",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Requested-With,Cookie",
+		AllowCredentials: true,
+	}
+}
+
 // CustomErrorHandler handles application errors
 func CustomErrorHandler(c *fiber.Ctx, err error) error {
 	// Default to 500 server error
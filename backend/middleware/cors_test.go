@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/disgoorg/bot-template/backend/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+func TestCORSConfigAllowsConfiguredOrigins(t *testing.T) {
+	app := fiber.New()
+	app.Use(cors.New(CORSConfig(&config.WebAppConfig{
+		AllowedOrigins: []string{"https://admin.gohye.app", "http://localhost:3000"},
+	})))
+	app.Get("/api/cards", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	tests := []struct {
+		origin string
+		want   string
+	}{
+		{"https://admin.gohye.app", "https://admin.gohye.app"},
+		{"http://localhost:3000", "http://localhost:3000"},
+		{"https://evil.example", ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/api/cards", nil)
+		req.Header.Set("Origin", tt.origin)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("GET from %s: %v", tt.origin, err)
+		}
+		resp.Body.Close()
+
+		if got := resp.Header.Get(fiber.HeaderAccessControlAllowOrigin); got != tt.want {
+			t.Errorf("origin %s: Access-Control-Allow-Origin = %q, want %q", tt.origin, got, tt.want)
+		}
+		if tt.want != "" && resp.Header.Get(fiber.HeaderAccessControlAllowCredentials) != "true" {
+			t.Errorf("origin %s: credentials not allowed", tt.origin)
+		}
+	}
+}
@@ -5,14 +5,28 @@ import (
 	"time"
 
 	"github.com/disgoorg/bot-template/backend/utils"
+	"github.com/disgoorg/bot-template/bottemplate/logger"
 	"github.com/gofiber/fiber/v2"
 )
 
-// LoggingMiddleware logs HTTP requests in a structured format
+// requestIDHeader carries the correlation ID in and out of the API
+const requestIDHeader = "X-Request-ID"
+
+// LoggingMiddleware logs HTTP requests in a structured format. It also tags the
+// request's user context with a request ID (taken from X-Request-ID when the
+// client sends one) so logs made further down the request can be correlated.
 func LoggingMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
 
+		requestID := c.Get(requestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = logger.NewRequestID()
+		}
+		ctx := logger.WithRequestID(c.UserContext(), requestID)
+		c.SetUserContext(ctx)
+		c.Set(requestIDHeader, requestID)
+
 		// Process request
 		err := c.Next()
 
@@ -37,7 +51,7 @@ func LoggingMiddleware() fiber.Handler {
 		}
 
 		// Create log entry
-		logger := slog.With(
+		requestLogger := slog.With(
 			slog.String("method", c.Method()),
 			slog.String("path", c.Path()),
 			slog.String("query", c.Request().URI().QueryArgs().String()),
@@ -50,7 +64,7 @@ func LoggingMiddleware() fiber.Handler {
 
 		// Add user information if available
 		if userID != "" {
-			logger = logger.With(
+			requestLogger = requestLogger.With(
 				slog.String("user_id", userID),
 				slog.String("username", username),
 			)
@@ -58,17 +72,17 @@ func LoggingMiddleware() fiber.Handler {
 
 		// Add error information if present
 		if err != nil {
-			logger = logger.With(slog.String("error", err.Error()))
+			requestLogger = requestLogger.With(slog.String("error", err.Error()))
 		}
 
 		// Add referer if present
 		if referer := c.Get("Referer"); referer != "" {
-			logger = logger.With(slog.String("referer", referer))
+			requestLogger = requestLogger.With(slog.String("referer", referer))
 		}
 
 		// Add HTMX information if present
 		if c.Get("HX-Request") != "" {
-			logger = logger.With(
+			requestLogger = requestLogger.With(
 				slog.Bool("htmx_request", true),
 				slog.String("htmx_target", c.Get("HX-Target")),
 				slog.String("htmx_trigger", c.Get("HX-Trigger")),
@@ -80,7 +94,7 @@ func LoggingMiddleware() fiber.Handler {
 		if err != nil {
 			message = "HTTP request failed"
 			// Add more detailed error information
-			slog.Error("HTTP request error details",
+			slog.ErrorContext(ctx, "HTTP request error details",
 				slog.String("method", c.Method()),
 				slog.String("path", c.Path()),
 				slog.Int("status", statusCode),
@@ -89,7 +103,7 @@ func LoggingMiddleware() fiber.Handler {
 			)
 		}
 
-		logger.Log(c.Context(), logLevel, message)
+		requestLogger.Log(ctx, logLevel, message)
 
 		return err
 	}
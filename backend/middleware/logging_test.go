@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLoggingMiddlewareRequestID(t *testing.T) {
+	app := fiber.New()
+	app.Use(LoggingMiddleware())
+	app.Get("/api/cards", func(c *fiber.Ctx) error {
+		// Handlers see the same ID the client gets back
+		return c.SendString(logger.RequestID(c.UserContext()))
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{name: "client ID", header: "abc-123", keep: true},
+		{name: "no ID", header: ""},
+		{name: "overlong ID", header: strings.Repeat("x", 65)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/cards", nil)
+			if tt.header != "" {
+				req.Header.Set(requestIDHeader, tt.header)
+			}
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("GET: %v", err)
+			}
+			defer resp.Body.Close()
+
+			got := resp.Header.Get(requestIDHeader)
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+			if got == "" || string(body) != got {
+				t.Errorf("response ID %q, handler saw %q, want the same non-empty ID", got, body)
+			}
+			if tt.keep != (got == tt.header) {
+				t.Errorf("response ID = %q for header %q, keep = %t", got, tt.header, tt.keep)
+			}
+		})
+	}
+}
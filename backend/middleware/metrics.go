@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	webservices "github.com/disgoorg/bot-template/backend/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MetricsMiddleware records request counts and latency by route. Requests that
+// match no route are grouped under a single label so probes of random paths
+// can't blow up the series count.
+func MetricsMiddleware(metrics *webservices.MetricsRegistry) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		// Errors are turned into responses later by the error handler, so
+		// record the status it will send rather than the current one
+		statusCode := c.Response().StatusCode()
+		if err != nil {
+			statusCode = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				statusCode = fiberErr.Code
+			}
+		}
+
+		route := c.Route().Path
+		if statusCode == fiber.StatusNotFound && route == "/" && c.Path() != "/" {
+			route = "unmatched"
+		}
+		metrics.ObserveRequest(c.Method(), route, statusCode, time.Since(start))
+
+		return err
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	webservices "github.com/disgoorg/bot-template/backend/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMetricsMiddlewareLabelsRoutes(t *testing.T) {
+	metrics := webservices.NewMetricsRegistry()
+	app := fiber.New()
+	app.Use(MetricsMiddleware(metrics))
+	app.Get("/cards/:id", func(c *fiber.Ctx) error { return c.SendString(c.Params("id")) })
+	app.Get("/broken", func(c *fiber.Ctx) error { return fiber.NewError(fiber.StatusBadGateway, "upstream down") })
+
+	for _, target := range []string{"/cards/1", "/cards/2", "/broken", "/wp-login.php", "/.env"} {
+		resp, err := app.Test(httptest.NewRequest("GET", target, nil), -1)
+		if err != nil {
+			t.Fatalf("GET %s: %v", target, err)
+		}
+		resp.Body.Close()
+	}
+
+	var out strings.Builder
+	if err := metrics.Write(&out, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{
+		`gohye_http_requests_total{method="GET",route="/cards/:id",status="200"} 2`,
+		`gohye_http_requests_total{method="GET",route="/broken",status="502"} 1`,
+		`gohye_http_requests_total{method="GET",route="unmatched",status="404"} 2`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output is missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "wp-login") {
+		t.Error("raw path of an unmatched request leaked into the labels")
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+// Permissions granted to web sessions. Admin users and admin roles receive all
+// of them; other Discord roles receive whatever the role mapping grants.
+const (
+	PermissionAdminRead         = "admin.read"
+	PermissionCardsCreate       = "cards.create"
+	PermissionCardsUpdate       = "cards.update"
+	PermissionCardsDelete       = "cards.delete"
+	PermissionCollectionsImport = "collections.import"
+	PermissionCollectionsManage = "collections.manage"
+	PermissionSyncManage        = "sync.manage"
+	PermissionUsersView         = "users.view"
+	PermissionProcessesManage   = "processes.manage"
+	PermissionAuditView         = "audit.view"
+)
+
+// AllPermissions lists every known permission in display order
+var AllPermissions = []string{
+	PermissionAdminRead,
+	PermissionCardsCreate,
+	PermissionCardsUpdate,
+	PermissionCardsDelete,
+	PermissionCollectionsImport,
+	PermissionCollectionsManage,
+	PermissionSyncManage,
+	PermissionUsersView,
+	PermissionProcessesManage,
+	PermissionAuditView,
+}
+
+// IsKnownPermission reports whether permission is one the backend checks
+func IsKnownPermission(permission string) bool {
+	for _, known := range AllPermissions {
+		if known == permission {
+			return true
+		}
+	}
+	return false
+}
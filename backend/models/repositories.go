@@ -15,6 +15,10 @@ type Repositories struct {
 	Effect       repositories.EffectRepository
 	Wishlist     repositories.WishlistRepository
 	EconomyStats repositories.EconomyStatsRepository
+	Quest        repositories.QuestRepository
+	AdminAudit   repositories.AdminAuditRepository
+	Process      repositories.BackgroundProcessRepository
+	KofiDonation repositories.KofiDonationRepository
 }
 
 // NewRepositories creates a new repositories group from individual repositories
@@ -28,6 +32,10 @@ func NewRepositories(
 	effect repositories.EffectRepository,
 	wishlist repositories.WishlistRepository,
 	economyStats repositories.EconomyStatsRepository,
+	quest repositories.QuestRepository,
+	adminAudit repositories.AdminAuditRepository,
+	process repositories.BackgroundProcessRepository,
+	kofiDonation repositories.KofiDonationRepository,
 ) *Repositories {
 	return &Repositories{
 		User:         user,
@@ -39,5 +47,9 @@ func NewRepositories(
 		Effect:       effect,
 		Wishlist:     wishlist,
 		EconomyStats: economyStats,
+		Quest:        quest,
+		AdminAudit:   adminAudit,
+		Process:      process,
+		KofiDonation: kofiDonation,
 	}
 }
@@ -32,7 +32,8 @@ type PaginationInfo struct {
 	Limit      int   `json:"limit"`
 	Total      int64 `json:"total"`
 	TotalPages int   `json:"total_pages"`
-	HasNext    bool  `json:"has_next"`
+	HasMore    bool  `json:"has_more"`
+	HasNext    bool  `json:"has_next"` // Deprecated: same as HasMore, kept for older clients
 	HasPrev    bool  `json:"has_prev"`
 }
 
@@ -114,15 +115,32 @@ func NewPaginatedResponse(data interface{}, pagination *PaginationInfo, message
 	}
 }
 
-// NewPaginationInfo creates pagination info
+// NewPaginationInfo creates pagination info. An empty result has zero pages,
+// and page/limit below 1 are treated as 1 so the math never divides by zero.
 func NewPaginationInfo(page, limit int, total int64) *PaginationInfo {
-	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	if limit < 1 {
+		limit = 1
+	}
+	if page < 1 {
+		page = 1
+	}
+	if total < 0 {
+		total = 0
+	}
+
+	totalPages := int(total / int64(limit))
+	if total%int64(limit) != 0 {
+		totalPages++
+	}
+
+	hasMore := page < totalPages
 	return &PaginationInfo{
 		Page:       page,
 		Limit:      limit,
 		Total:      total,
 		TotalPages: totalPages,
-		HasNext:    page < totalPages,
+		HasMore:    hasMore,
+		HasNext:    hasMore,
 		HasPrev:    page > 1,
 	}
 }
@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestNewPaginationInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		page, limit int
+		total       int64
+		want        PaginationInfo
+	}{
+		{name: "empty", page: 1, limit: 20, total: 0, want: PaginationInfo{Page: 1, Limit: 20, TotalPages: 0}},
+		{name: "exact multiple", page: 2, limit: 20, total: 40, want: PaginationInfo{Page: 2, Limit: 20, Total: 40, TotalPages: 2, HasPrev: true}},
+		{name: "exact multiple first page", page: 1, limit: 20, total: 40, want: PaginationInfo{Page: 1, Limit: 20, Total: 40, TotalPages: 2, HasMore: true, HasNext: true}},
+		{name: "partial last page", page: 1, limit: 20, total: 41, want: PaginationInfo{Page: 1, Limit: 20, Total: 41, TotalPages: 3, HasMore: true, HasNext: true}},
+		{name: "zero limit", page: 0, limit: 0, total: 3, want: PaginationInfo{Page: 1, Limit: 1, Total: 3, TotalPages: 3, HasMore: true, HasNext: true}},
+		{name: "negative total", page: 1, limit: 20, total: -5, want: PaginationInfo{Page: 1, Limit: 20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPaginationInfo(tt.page, tt.limit, tt.total); *got != tt.want {
+				t.Errorf("NewPaginationInfo(%d, %d, %d) = %+v, want %+v", tt.page, tt.limit, tt.total, *got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
@@ -17,6 +19,9 @@ type UserSession struct {
 	Permissions []string  `json:"permissions"`
 	ExpiresAt   time.Time `json:"expires_at"`
 	IsAdmin     bool      `json:"is_admin"`
+	// RefreshToken is the Discord refresh token used to renew the session. It
+	// only travels in the signed cookie; it is useless without the client secret.
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 // CardDTO represents a card data transfer object for web UI
@@ -33,6 +38,51 @@ type CardDTO struct {
 	UpdatedAt      time.Time `json:"updated_at"`
 }
 
+// UserQuestDTO represents a user's progress on a single quest
+type UserQuestDTO struct {
+	QuestID          string    `json:"quest_id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	Type             string    `json:"type"`
+	Tier             int       `json:"tier"`
+	Category         string    `json:"category"`
+	CurrentProgress  int       `json:"current_progress"`
+	RequirementCount int       `json:"requirement_count"`
+	Percentage       float64   `json:"percentage"`
+	Completed        bool      `json:"completed"`
+	Claimed          bool      `json:"claimed"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// UserQuestsResponse groups a user's active quests by quest type
+type UserQuestsResponse struct {
+	UserID  string         `json:"user_id"`
+	Daily   []UserQuestDTO `json:"daily"`
+	Weekly  []UserQuestDTO `json:"weekly"`
+	Monthly []UserQuestDTO `json:"monthly"`
+}
+
+// NewUserQuestDTO converts quest progress with a loaded definition into a DTO
+func NewUserQuestDTO(progress *models.UserQuestProgress) UserQuestDTO {
+	dto := UserQuestDTO{
+		QuestID:         progress.QuestID,
+		CurrentProgress: progress.CurrentProgress,
+		Percentage:      progress.GetProgressPercentage(),
+		Completed:       progress.Completed,
+		Claimed:         progress.Claimed,
+		ExpiresAt:       progress.ExpiresAt,
+	}
+	if def := progress.QuestDefinition; def != nil {
+		dto.Name = def.Name
+		dto.Description = def.Description
+		dto.Type = def.Type
+		dto.Tier = def.Tier
+		dto.Category = def.Category
+		dto.RequirementCount = def.RequirementCount
+	}
+	return dto
+}
+
 // CollectionDTO represents a collection data transfer object
 type CollectionDTO struct {
 	ID             string    `json:"id"`
@@ -63,6 +113,14 @@ type CardSearchRequest struct {
 	SortOrder  string   `json:"sort_order" form:"sort_order"`
 }
 
+// CardQuerySearchRequest represents a search using the bot's query syntax,
+// e.g. "-3 #twice !animated"
+type CardQuerySearchRequest struct {
+	Query string `json:"q" form:"q"`
+	Page  int    `json:"page" form:"page"`
+	Limit int    `json:"limit" form:"limit"`
+}
+
 // CardCreateRequest represents a request to create a new card
 type CardCreateRequest struct {
 	Name      string   `json:"name" validate:"required,min=1,max=100"`
@@ -72,6 +130,10 @@ type CardCreateRequest struct {
 	Tags      []string `json:"tags"`
 	ImageData []byte   `json:"image_data,omitempty"`
 	ImageName string   `json:"image_name,omitempty"`
+
+	// Image conversion options
+	ConvertToWebP bool `json:"convert_to_webp" form:"convert_to_webp"`
+	KeepOriginal  bool `json:"keep_original" form:"keep_original"`
 }
 
 // CardUpdateRequest represents a request to update a card
@@ -83,6 +145,14 @@ type CardUpdateRequest struct {
 	Tags      []string `json:"tags,omitempty"`
 	ImageData []byte   `json:"image_data,omitempty"`
 	ImageName string   `json:"image_name,omitempty"`
+
+	// Image conversion options
+	ConvertToWebP bool `json:"convert_to_webp" form:"convert_to_webp"`
+	KeepOriginal  bool `json:"keep_original" form:"keep_original"`
+
+	// ExpectedUpdatedAt is the updated_at the client last read; when set, the update is
+	// rejected if the card changed since
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at,omitempty"`
 }
 
 // CardBulkOperation represents a bulk operation request
@@ -91,6 +161,7 @@ type CardBulkOperation struct {
 	CardIDs          []int64            `json:"card_ids" validate:"required,min=1"`
 	Updates          *CardUpdateRequest `json:"updates,omitempty"`
 	TargetCollection string             `json:"target_collection,omitempty"`
+	Force            bool               `json:"force"` // Delete cards even if users own copies
 }
 
 // CollectionImportRequest represents a collection import request
@@ -121,6 +192,24 @@ type CollectionImportResult struct {
 	ErrorMessage  string   `json:"error_message,omitempty"`
 }
 
+// BatchCollectionEntry describes one collection in a batch import. Files name
+// the uploaded files that belong to the collection.
+type BatchCollectionEntry struct {
+	CollectionID string   `json:"collection_id"`
+	DisplayName  string   `json:"display_name"`
+	GroupType    string   `json:"group_type"`
+	IsPromo      bool     `json:"is_promo"`
+	Files        []string `json:"files"`
+}
+
+// BatchCollectionImportResult aggregates the results of a batch import
+type BatchCollectionImportResult struct {
+	Total     int                       `json:"total"`
+	Succeeded int                       `json:"succeeded"`
+	Failed    int                       `json:"failed"`
+	Results   []*CollectionImportResult `json:"results"`
+}
+
 // ParsedFilename represents a parsed filename
 type ParsedFilename struct {
 	Level      int    `json:"level"`
@@ -130,7 +219,20 @@ type ParsedFilename struct {
 	Original   string `json:"original"`
 	Normalized string `json:"normalized"`
 	Valid      bool   `json:"valid"`
+	ErrorType  string `json:"error_type,omitempty"` // filename_format, invalid_level, empty_name
 	ErrorMsg   string `json:"error_msg,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// CardImportPreview is the card metadata a file would be imported as
+type CardImportPreview struct {
+	FileName  string `json:"file_name"`
+	Name      string `json:"name,omitempty"`
+	Level     int    `json:"level,omitempty"`
+	Animated  bool   `json:"animated"`
+	GroupType string `json:"group_type"`
+	Valid     bool   `json:"valid"`
+	Error     string `json:"error,omitempty"`
 }
 
 // ValidationError represents a file validation error
@@ -150,28 +252,58 @@ type CardImportRequest struct {
 	IsPromo          bool          `json:"is_promo"`
 	Files            []*FileUpload `json:"files" validate:"required,min=1"`
 	ValidateOnly     bool          `json:"validate_only"`
-	OverwriteMode    string        `json:"overwrite_mode" validate:"oneof=skip overwrite update"` // skip, overwrite, update
-	CreateCollection bool          `json:"create_collection"`                                     // Auto-create collection if not exists
+	OverwriteMode    string        `json:"overwrite_mode" validate:"oneof=skip overwrite rename update"` // skip, overwrite, rename, update
+	CreateCollection bool          `json:"create_collection"`                                            // Auto-create collection if not exists
 }
 
 // CardImportResult represents enhanced import results
 type CardImportResult struct {
-	CollectionID      string            `json:"collection_id"`
-	CollectionCreated bool              `json:"collection_created"`
-	CardsCreated      int               `json:"cards_created"`
-	CardsSkipped      int               `json:"cards_skipped"`
-	CardsUpdated      int               `json:"cards_updated"`
-	FirstCardID       int64             `json:"first_card_id"`
-	LastCardID        int64             `json:"last_card_id"`
-	FilesUploaded     []string          `json:"files_uploaded"`
-	FilesSkipped      []string          `json:"files_skipped"`
-	ValidationErrors  []ValidationError `json:"validation_errors"`
-	ProcessingErrors  []ProcessingError `json:"processing_errors"`
-	Success           bool              `json:"success"`
-	PartialSuccess    bool              `json:"partial_success"`
-	ErrorMessage      string            `json:"error_message,omitempty"`
-	ProcessingTimeMs  int64             `json:"processing_time_ms"`
-	ImportSummary     *ImportSummary    `json:"import_summary,omitempty"`
+	CollectionID      string               `json:"collection_id"`
+	CollectionCreated bool                 `json:"collection_created"`
+	CardsCreated      int                  `json:"cards_created"`
+	CardsSkipped      int                  `json:"cards_skipped"`
+	CardsUpdated      int                  `json:"cards_updated"`
+	FirstCardID       int64                `json:"first_card_id"`
+	LastCardID        int64                `json:"last_card_id"`
+	FilesUploaded     []string             `json:"files_uploaded"`
+	FilesSkipped      []string             `json:"files_skipped"`
+	FilesRolledBack   []string             `json:"files_rolled_back,omitempty"` // Uploads deleted after the database write failed
+	FileActions       []*FileImportAction  `json:"file_actions"`
+	Preview           []*CardImportPreview `json:"preview,omitempty"` // Parsed metadata per file, returned when validating
+	ValidationErrors  []ValidationError    `json:"validation_errors"`
+	ProcessingErrors  []ProcessingError    `json:"processing_errors"`
+	Success           bool                 `json:"success"`
+	PartialSuccess    bool                 `json:"partial_success"`
+	ErrorMessage      string               `json:"error_message,omitempty"`
+	ProcessingTimeMs  int64                `json:"processing_time_ms"`
+	ImportSummary     *ImportSummary       `json:"import_summary,omitempty"`
+}
+
+// Overwrite modes for cards that already exist in the target collection
+const (
+	OverwriteModeSkip      = "skip"      // leave the existing card alone
+	OverwriteModeOverwrite = "overwrite" // replace the existing card's image and metadata
+	OverwriteModeRename    = "rename"    // import as a new card with a numbered suffix
+	OverwriteModeUpdate    = "update"    // update the existing card's metadata only
+)
+
+// Actions reported per file by a card import
+const (
+	ImportActionCreated     = "created"
+	ImportActionSkipped     = "skipped"
+	ImportActionOverwritten = "overwritten"
+	ImportActionRenamed     = "renamed"
+	ImportActionUpdated     = "updated"
+	ImportActionFailed      = "failed"
+)
+
+// FileImportAction records what a card import did with one file
+type FileImportAction struct {
+	FileName string `json:"file_name"`
+	Action   string `json:"action"`
+	CardID   int64  `json:"card_id,omitempty"`
+	CardName string `json:"card_name,omitempty"`
+	Reason   string `json:"reason,omitempty"`
 }
 
 // ProcessingError represents an error during processing
@@ -198,12 +330,14 @@ type ImportSummary struct {
 
 // CardBatchOperation represents enhanced bulk operations
 type CardBatchOperation struct {
-	Operation        string             `json:"operation" validate:"required,oneof=delete update move export level_update toggle_animated"`
+	Operation        string             `json:"operation" validate:"required,oneof=delete update move export level_update toggle_animated add_tags remove_tags"`
 	CardIDs          []int64            `json:"card_ids" validate:"required,min=1"`
 	Updates          *CardUpdateRequest `json:"updates,omitempty"`
 	TargetCollection string             `json:"target_collection,omitempty"`
 	NewLevel         *int               `json:"new_level,omitempty" validate:"omitempty,min=1,max=5"`
-	DryRun           bool               `json:"dry_run"` // Preview operation without executing
+	Tags             []string           `json:"tags,omitempty"` // Tags to add or remove for add_tags/remove_tags
+	DryRun           bool               `json:"dry_run"`        // Preview operation without executing
+	Force            bool               `json:"force"`          // Delete cards even if users own copies
 }
 
 // CardBatchResult represents the result of batch operations
@@ -270,6 +404,55 @@ type SyncIssue struct {
 	Severity    string `json:"severity"` // low, medium, high, critical
 }
 
+// OrphanCleanupReport summarizes an orphaned-file cleanup run
+type OrphanCleanupReport struct {
+	DryRun       bool              `json:"dry_run"`
+	CollectionID string            `json:"collection_id,omitempty"`
+	ScannedFiles int               `json:"scanned_files"`
+	OrphanKeys   []string          `json:"orphan_keys"`
+	DeletedKeys  []string          `json:"deleted_keys"`
+	FailedKeys   map[string]string `json:"failed_keys,omitempty"`
+}
+
+// SyncFixMode selects what a sync fix does with cards whose image is missing
+type SyncFixMode string
+
+const (
+	SyncFixModeReport      SyncFixMode = "report"      // only report missing images
+	SyncFixModePlaceholder SyncFixMode = "placeholder" // upload a placeholder image
+	SyncFixModeDeleteRow   SyncFixMode = "delete_row"  // delete the card from the database
+)
+
+// Per-card outcomes of a sync fix
+const (
+	CardSyncPresent            = "present"
+	CardSyncMissing            = "missing"
+	CardSyncPlaceholderCreated = "placeholder_created"
+	CardSyncRowDeleted         = "row_deleted"
+	CardSyncFailed             = "failed"
+)
+
+// CardSyncResult is the image status of a single card after a sync fix
+type CardSyncResult struct {
+	CardID   int64  `json:"card_id"`
+	Name     string `json:"name"`
+	Level    int    `json:"level"`
+	ImageKey string `json:"image_key"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SyncFixReport summarizes a per-collection sync fix
+type SyncFixReport struct {
+	CollectionID string           `json:"collection_id"`
+	Mode         SyncFixMode      `json:"mode"`
+	CheckedCards int              `json:"checked_cards"`
+	MissingCards int              `json:"missing_cards"`
+	FixedCards   int              `json:"fixed_cards"`
+	FailedCards  int              `json:"failed_cards"`
+	Cards        []CardSyncResult `json:"cards"`
+}
+
 // DashboardStats represents dashboard statistics
 type DashboardStats struct {
 	TotalCards       int64          `json:"total_cards"`
@@ -315,7 +498,7 @@ func ConvertCollectionToDTO(collection *models.Collection, cardCount int) *Colle
 		ID:             collection.ID,
 		Name:           collection.Name,
 		Description:    "",
-		CollectionType: "other", // Default value, should be determined by logic
+		CollectionType: collection.ResolvedType(),
 		Origin:         collection.Origin,
 		Aliases:        collection.Aliases,
 		Promo:          collection.Promo,
@@ -328,20 +511,56 @@ func ConvertCollectionToDTO(collection *models.Collection, cardCount int) *Colle
 	}
 }
 
-// Validate validates the card search request
-func (r *CardSearchRequest) Validate() error {
-	if r.Page < 1 {
-		r.Page = 1
+// Card search pagination bounds shared by every card listing endpoint
+const (
+	DefaultCardPageLimit = 20
+	MaxCardPageLimit     = 100
+)
+
+// cardSortFields are the columns a card search may be sorted by
+var cardSortFields = []string{"id", "name", "level", "col_id", "created_at", "updated_at"}
+
+// clampPage normalizes pagination: page is at least 1 and limit falls back to
+// DefaultCardPageLimit when unset and is capped at MaxCardPageLimit
+func clampPage(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultCardPageLimit
 	}
-	if r.Limit < 1 || r.Limit > 100 {
-		r.Limit = 20
+	if limit > MaxCardPageLimit {
+		limit = MaxCardPageLimit
 	}
+	return page, limit
+}
+
+// Validate clamps pagination, sets sort defaults and rejects unknown sort fields or orders
+func (r *CardSearchRequest) Validate() error {
+	r.Page, r.Limit = clampPage(r.Page, r.Limit)
+
+	r.SortBy = strings.ToLower(strings.TrimSpace(r.SortBy))
 	if r.SortBy == "" {
 		r.SortBy = "id"
 	}
+	if !slices.Contains(cardSortFields, r.SortBy) {
+		return fmt.Errorf("sort_by %q is not supported, use one of: %s", r.SortBy, strings.Join(cardSortFields, ", "))
+	}
+
+	r.SortOrder = strings.ToLower(strings.TrimSpace(r.SortOrder))
 	if r.SortOrder == "" {
 		r.SortOrder = "asc"
 	}
+	if r.SortOrder != "asc" && r.SortOrder != "desc" {
+		return fmt.Errorf("sort_order %q is not supported, use asc or desc", r.SortOrder)
+	}
+	return nil
+}
+
+// Validate validates the query search request and sets defaults
+func (r *CardQuerySearchRequest) Validate() error {
+	r.Query = strings.TrimSpace(r.Query)
+	r.Page, r.Limit = clampPage(r.Page, r.Limit)
 	return nil
 }
 
@@ -359,11 +578,12 @@ func (r *CardImportRequest) Validate() error {
 	if len(r.Files) == 0 {
 		return fmt.Errorf("at least one file is required")
 	}
-	if r.OverwriteMode != "" && r.OverwriteMode != "skip" && r.OverwriteMode != "overwrite" && r.OverwriteMode != "update" {
-		return fmt.Errorf("overwrite_mode must be 'skip', 'overwrite', or 'update'")
-	}
-	if r.OverwriteMode == "" {
-		r.OverwriteMode = "skip" // Default
+	switch r.OverwriteMode {
+	case "":
+		r.OverwriteMode = OverwriteModeSkip // Default
+	case OverwriteModeSkip, OverwriteModeOverwrite, OverwriteModeRename, OverwriteModeUpdate:
+	default:
+		return fmt.Errorf("overwrite_mode must be 'skip', 'overwrite', 'rename', or 'update'")
 	}
 	return nil
 }
@@ -394,7 +614,7 @@ func (f *FileUpload) Validate() error {
 
 // Validate validates the card batch operation
 func (r *CardBatchOperation) Validate() error {
-	validOps := []string{"delete", "update", "move", "export", "level_update", "toggle_animated"}
+	validOps := []string{"delete", "update", "move", "export", "level_update", "toggle_animated", "add_tags", "remove_tags"}
 	validOp := false
 	for _, op := range validOps {
 		if r.Operation == op {
@@ -427,6 +647,17 @@ func (r *CardBatchOperation) Validate() error {
 		if r.Updates == nil {
 			return fmt.Errorf("updates are required for update operation")
 		}
+	case "add_tags", "remove_tags":
+		tags := make([]string, 0, len(r.Tags))
+		for _, tag := range r.Tags {
+			if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		if len(tags) == 0 {
+			return fmt.Errorf("tags are required for %s operation", r.Operation)
+		}
+		r.Tags = tags
 	}
 
 	return nil
@@ -509,3 +740,29 @@ func CreateProcessingError(fileName, stage, errorType, description string, recov
 		Recoverable: recoverable,
 	}
 }
+
+// VoteWebhookPayload is the body a bot list posts when a user votes for the bot
+type VoteWebhookPayload struct {
+	Bot       string `json:"bot"`
+	User      string `json:"user"` // Discord ID of the voter
+	Type      string `json:"type"` // "upvote", or "test" for the list's test button
+	IsWeekend bool   `json:"isWeekend"`
+}
+
+// KofiWebhookPayload is the JSON Ko-fi sends in the data form field of its webhook
+type KofiWebhookPayload struct {
+	VerificationToken          string `json:"verification_token"`
+	MessageID                  string `json:"message_id"`
+	Timestamp                  string `json:"timestamp"`
+	Type                       string `json:"type"` // Donation, Subscription, Commission or Shop Order
+	FromName                   string `json:"from_name"`
+	Message                    string `json:"message"`
+	Amount                     string `json:"amount"`
+	Currency                   string `json:"currency"`
+	IsSubscriptionPayment      bool   `json:"is_subscription_payment"`
+	IsFirstSubscriptionPayment bool   `json:"is_first_subscription_payment"`
+	KofiTransactionID          string `json:"kofi_transaction_id"`
+	TierName                   string `json:"tier_name"`
+	DiscordUsername            string `json:"discord_username"`
+	DiscordUserID              string `json:"discord_userid"`
+}
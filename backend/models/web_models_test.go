@@ -0,0 +1,58 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCardBatchOperationTags(t *testing.T) {
+	op := &CardBatchOperation{Operation: "add_tags", CardIDs: []int64{1}, Tags: []string{" Summer ", "", "WINTER"}}
+	if err := op.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if want := []string{"summer", "winter"}; !reflect.DeepEqual(op.Tags, want) {
+		t.Errorf("tags = %q, want %q", op.Tags, want)
+	}
+
+	for _, tags := range [][]string{nil, {" ", ""}} {
+		op := &CardBatchOperation{Operation: "remove_tags", CardIDs: []int64{1}, Tags: tags}
+		if err := op.Validate(); err == nil {
+			t.Errorf("remove_tags with tags %q accepted", tags)
+		}
+	}
+}
+
+func TestCardSearchRequestBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       CardSearchRequest
+		wantPage  int
+		wantLimit int
+		wantSort  string
+		wantOrder string
+	}{
+		{name: "defaults", req: CardSearchRequest{}, wantPage: 1, wantLimit: DefaultCardPageLimit, wantSort: "id", wantOrder: "asc"},
+		{name: "negative", req: CardSearchRequest{Page: -3, Limit: -1}, wantPage: 1, wantLimit: DefaultCardPageLimit, wantSort: "id", wantOrder: "asc"},
+		{name: "at max", req: CardSearchRequest{Page: 2, Limit: MaxCardPageLimit}, wantPage: 2, wantLimit: MaxCardPageLimit, wantSort: "id", wantOrder: "asc"},
+		{name: "over max", req: CardSearchRequest{Limit: MaxCardPageLimit + 1}, wantPage: 1, wantLimit: MaxCardPageLimit, wantSort: "id", wantOrder: "asc"},
+		{name: "normalized sort", req: CardSearchRequest{SortBy: " Level ", SortOrder: "DESC"}, wantPage: 1, wantLimit: DefaultCardPageLimit, wantSort: "level", wantOrder: "desc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			if err := req.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+			if req.Page != tt.wantPage || req.Limit != tt.wantLimit || req.SortBy != tt.wantSort || req.SortOrder != tt.wantOrder {
+				t.Errorf("got page %d, limit %d, sort %s %s, want %d, %d, %s %s",
+					req.Page, req.Limit, req.SortBy, req.SortOrder, tt.wantPage, tt.wantLimit, tt.wantSort, tt.wantOrder)
+			}
+		})
+	}
+
+	for _, req := range []CardSearchRequest{{SortBy: "password"}, {SortBy: "name; DROP TABLE cards"}, {SortOrder: "sideways"}} {
+		if err := req.Validate(); err == nil {
+			t.Errorf("Validate accepted sort %q %q", req.SortBy, req.SortOrder)
+		}
+	}
+}
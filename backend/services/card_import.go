@@ -4,34 +4,45 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"mime"
-	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	webmodels "github.com/disgoorg/bot-template/backend/models"
+	webutils "github.com/disgoorg/bot-template/backend/utils"
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	economyutils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
 	"github.com/disgoorg/bot-template/bottemplate/services"
 	"github.com/uptrace/bun"
 )
 
+// CardImportStorage is the storage the import service uploads card images to.
+// Uploads are deleted again when the card write fails.
+type CardImportStorage interface {
+	ManageCardImage(ctx context.Context, operation services.ImageOperation, cardID int64, imageData []byte, card *models.Card) (*services.ImageManagementResult, error)
+	DeleteObject(ctx context.Context, path string) error
+}
+
+var _ CardImportStorage = (*services.SpacesService)(nil)
+
 // CardImportService provides card import operations with comprehensive validation
 type CardImportService struct {
 	repos         *webmodels.Repositories
-	spacesService *services.SpacesService
+	spacesService CardImportStorage
 	cardService   *CardManagementService
 	db            *bun.DB
+	txManager     *economyutils.EconomicTransactionManager
 }
 
 // NewCardImportService creates a new card import service
-func NewCardImportService(repos *webmodels.Repositories, spacesService *services.SpacesService, cardService *CardManagementService, db *bun.DB) *CardImportService {
+func NewCardImportService(repos *webmodels.Repositories, spacesService CardImportStorage, cardService *CardManagementService, db *bun.DB) *CardImportService {
 	return &CardImportService{
 		repos:         repos,
 		spacesService: spacesService,
 		cardService:   cardService,
 		db:            db,
+		txManager:     economyutils.NewEconomicTransactionManager(db),
 	}
 }
 
@@ -56,6 +67,7 @@ func (cis *CardImportService) ImportCards(ctx context.Context, req *webmodels.Ca
 		ProcessingErrors: make([]webmodels.ProcessingError, 0),
 		FilesUploaded:    make([]string, 0),
 		FilesSkipped:     make([]string, 0),
+		FileActions:      make([]*webmodels.FileImportAction, 0, len(req.Files)),
 		ImportSummary: &webmodels.ImportSummary{
 			TotalFiles:    len(req.Files),
 			LevelStats:    make(map[int]int),
@@ -74,6 +86,7 @@ func (cis *CardImportService) ImportCards(ctx context.Context, req *webmodels.Ca
 
 	// Stop if validation only or critical errors found
 	if req.ValidateOnly {
+		result.Preview = cis.PreviewFiles(req.GroupType, req.Files)
 		result.Success = len(validationErrors) == 0
 		result.ProcessingTimeMs = time.Since(startTime).Milliseconds()
 		return result, nil
@@ -135,8 +148,10 @@ func (cis *CardImportService) ValidateFiles(files []*webmodels.FileUpload) []web
 		// Filename format validation
 		parsed, err := cis.ParseFilename(file.Name)
 		if err != nil {
-			errors = append(errors, webmodels.CreateValidationError(
-				file.Name, "filename_format", err.Error(), "critical"))
+			validationErr := webmodels.CreateValidationError(
+				file.Name, parsed.ErrorType, parsed.ErrorMsg, "critical")
+			validationErr.Suggestion = parsed.Suggestion
+			errors = append(errors, validationErr)
 			continue
 		}
 
@@ -168,6 +183,26 @@ func (cis *CardImportService) ValidateFiles(files []*webmodels.FileUpload) []web
 	return errors
 }
 
+// PreviewFiles parses every filename into the card metadata it would be
+// imported as, without touching Spaces or the database
+func (cis *CardImportService) PreviewFiles(groupType string, files []*webmodels.FileUpload) []*webmodels.CardImportPreview {
+	preview := make([]*webmodels.CardImportPreview, 0, len(files))
+	for _, file := range files {
+		entry := &webmodels.CardImportPreview{FileName: file.Name, GroupType: groupType}
+		parsed, err := cis.ParseFilename(file.Name)
+		if err != nil {
+			entry.Error = parsed.ErrorMsg
+		} else {
+			entry.Name = parsed.Name
+			entry.Level = parsed.Level
+			entry.Animated = parsed.IsAnimated
+			entry.Valid = true
+		}
+		preview = append(preview, entry)
+	}
+	return preview
+}
+
 // ParseFilename parses and validates filename according to the required format
 func (cis *CardImportService) ParseFilename(filename string) (*webmodels.ParsedFilename, error) {
 	// Pattern: level_name(_additional_names).ext
@@ -177,18 +212,22 @@ func (cis *CardImportService) ParseFilename(filename string) (*webmodels.ParsedF
 	matches := pattern.FindStringSubmatch(strings.ToLower(filename))
 	if len(matches) != 4 {
 		return &webmodels.ParsedFilename{
-			Original: filename,
-			Valid:    false,
-			ErrorMsg: "Filename must follow format: level_name.ext (e.g., 1_hello.jpg)",
+			Original:   filename,
+			Valid:      false,
+			ErrorType:  "filename_format",
+			ErrorMsg:   "Filename must follow format: level_name.ext (e.g., 1_hello.jpg)",
+			Suggestion: "Prefix the name with its level and an underscore, and use a jpg, jpeg, png or gif extension",
 		}, fmt.Errorf("invalid filename format")
 	}
 
 	level, err := strconv.Atoi(matches[1])
 	if err != nil || level < 1 || level > 5 {
 		return &webmodels.ParsedFilename{
-			Original: filename,
-			Valid:    false,
-			ErrorMsg: "Level must be between 1 and 5",
+			Original:   filename,
+			Valid:      false,
+			ErrorType:  "invalid_level",
+			ErrorMsg:   "Level must be between 1 and 5",
+			Suggestion: "Rename the file so it starts with a level from 1 to 5",
 		}, fmt.Errorf("invalid level: %s", matches[1])
 	}
 
@@ -200,9 +239,11 @@ func (cis *CardImportService) ParseFilename(filename string) (*webmodels.ParsedF
 	name = strings.TrimSpace(regexp.MustCompile(`\s+`).ReplaceAllString(name, " "))
 	if name == "" {
 		return &webmodels.ParsedFilename{
-			Original: filename,
-			Valid:    false,
-			ErrorMsg: "Card name cannot be empty",
+			Original:   filename,
+			Valid:      false,
+			ErrorType:  "empty_name",
+			ErrorMsg:   "Card name cannot be empty",
+			Suggestion: "Add the card name after the level, e.g. 1_hello.jpg",
 		}, fmt.Errorf("empty card name")
 	}
 
@@ -219,35 +260,15 @@ func (cis *CardImportService) ParseFilename(filename string) (*webmodels.ParsedF
 	}, nil
 }
 
-// validateMimeType validates the MIME type of uploaded files
+// validateMimeType validates the MIME type of uploaded files by sniffing their content
 func (cis *CardImportService) validateMimeType(file *webmodels.FileUpload) error {
-	// Check declared content type
-	expectedTypes := []string{
-		"image/jpeg", "image/jpg", "image/png", "image/gif",
-	}
-
-	validType := false
-	for _, expected := range expectedTypes {
-		if file.ContentType == expected {
-			validType = true
-			break
-		}
-	}
-
-	if !validType {
-		return fmt.Errorf("invalid content type %s, expected image type", file.ContentType)
-	}
-
-	// Check file extension matches content type
-	ext := strings.ToLower(filepath.Ext(file.Name))
-	expectedMime := mime.TypeByExtension(ext)
-	if expectedMime != "" && expectedMime != file.ContentType {
-		// Allow some common mismatches
-		if !(ext == ".jpg" && file.ContentType == "image/jpeg") {
-			return fmt.Errorf("content type %s doesn't match file extension %s", file.ContentType, ext)
-		}
+	contentType, err := webutils.DetectImageContentType(file.Name, file.Data)
+	if err != nil {
+		return err
 	}
 
+	// Store the sniffed type so uploads never use the client-sent header
+	file.ContentType = contentType
 	return nil
 }
 
@@ -292,19 +313,44 @@ func (cis *CardImportService) ensureCollection(ctx context.Context, req *webmode
 	return nil
 }
 
-// processFilesWithTransaction processes files within a database transaction
+// importPlan collects the database changes an import makes so they can be
+// applied in one transaction once every image is uploaded
+type importPlan struct {
+	updates       []*models.Card
+	creates       []*models.Card
+	createActions []*webmodels.FileImportAction // parallel to creates, filled with IDs on commit
+	uploads       []uploadedImage
+}
+
+// uploadedImage is an image written to Spaces during this import
+type uploadedImage struct {
+	fileName string
+	key      string
+	action   *webmodels.FileImportAction
+}
+
+// processFilesWithTransaction uploads the valid files and then applies all
+// card changes in a single transaction. If the transaction fails, the images
+// uploaded for this import are deleted again so no orphans are left behind.
 func (cis *CardImportService) processFilesWithTransaction(ctx context.Context, req *webmodels.CardImportRequest, result *webmodels.CardImportResult) error {
-	tx, err := cis.db.BeginTx(ctx, nil)
+	// Existing cards are matched by name within the target collection only
+	existing, err := cis.repos.Card.GetByCollectionID(ctx, req.CollectionID)
 	if err != nil {
-		return fmt.Errorf("failed to start transaction: %w", err)
+		return fmt.Errorf("failed to load existing cards: %w", err)
+	}
+	taken := make(map[string][]*models.Card, len(existing))
+	for _, card := range existing {
+		name := strings.ToLower(card.Name)
+		taken[name] = append(taken[name], card)
 	}
-	defer tx.Rollback()
 
-	var uploadedFiles []string
-	var cardsToCreate []*models.Card
+	plan := &importPlan{}
 
 	// Process each valid file
 	for _, file := range req.Files {
+		action := &webmodels.FileImportAction{FileName: file.Name}
+		result.FileActions = append(result.FileActions, action)
+
 		// Skip files with validation errors
 		hasError := false
 		for _, valError := range result.ValidationErrors {
@@ -314,144 +360,226 @@ func (cis *CardImportService) processFilesWithTransaction(ctx context.Context, r
 			}
 		}
 		if hasError {
+			action.Action = webmodels.ImportActionSkipped
+			action.Reason = "failed validation"
 			result.FilesSkipped = append(result.FilesSkipped, file.Name)
 			continue
 		}
 
 		parsed, err := cis.ParseFilename(file.Name)
 		if err != nil {
+			action.Action = webmodels.ImportActionFailed
+			action.Reason = err.Error()
 			result.ProcessingErrors = append(result.ProcessingErrors,
 				webmodels.CreateProcessingError(file.Name, "parsing", "parse_error", err.Error(), false))
 			continue
 		}
 
-		// Check for existing card
-		existingCards, err := cis.repos.Card.GetByName(ctx, parsed.Name)
-		if err == nil && len(existingCards) > 0 {
-			// Handle existing card based on overwrite mode
-			handled := cis.handleExistingCard(ctx, req, parsed, existingCards, result)
-			if !handled {
-				result.FilesSkipped = append(result.FilesSkipped, file.Name)
+		// Resolve a name clash according to the overwrite mode
+		var target *models.Card
+		if matches := taken[strings.ToLower(parsed.Name)]; len(matches) > 0 {
+			var proceed bool
+			target, proceed = cis.handleExistingCard(req, matches, parsed, taken, plan, action, result)
+			if !proceed {
+				if action.Action == webmodels.ImportActionSkipped {
+					result.FilesSkipped = append(result.FilesSkipped, file.Name)
+				}
 				continue
 			}
 		}
 
 		// Upload file to DigitalOcean Spaces
-		if err := cis.uploadFileToSpaces(ctx, file, parsed, req.GroupType, req.CollectionID); err != nil {
+		key, err := cis.uploadFileToSpaces(ctx, file, parsed, req.GroupType, req.CollectionID)
+		if err != nil {
+			action.Action = webmodels.ImportActionFailed
+			action.Reason = err.Error()
 			result.ProcessingErrors = append(result.ProcessingErrors,
 				webmodels.CreateProcessingError(file.Name, "upload", "spaces_error", err.Error(), true))
 			continue
 		}
-
-		uploadedFiles = append(uploadedFiles, file.Name)
-		result.FilesUploaded = append(result.FilesUploaded, file.Name)
-
-		// Prepare card for database creation
-		card := &models.Card{
-			Name:      parsed.Name,
-			Level:     parsed.Level,
-			Animated:  parsed.IsAnimated,
-			ColID:     req.CollectionID,
-			Tags:      []string{req.GroupType},
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+		plan.uploads = append(plan.uploads, uploadedImage{fileName: file.Name, key: key, action: action})
+
+		if target != nil {
+			// Overwrite keeps the card's ID so owned copies stay valid. An image
+			// left at the card's old level is removed by the sync cleanup.
+			target.Level = parsed.Level
+			target.Animated = parsed.IsAnimated
+			target.Tags = []string{req.GroupType}
+			target.UpdatedAt = time.Now()
+			plan.updates = append(plan.updates, target)
+			action.Action = webmodels.ImportActionOverwritten
+			action.CardID = target.ID
+			action.CardName = target.Name
+		} else {
+			if action.Action == "" {
+				action.Action = webmodels.ImportActionCreated
+			}
+			action.CardName = parsed.Name
+			taken[strings.ToLower(parsed.Name)] = append(taken[strings.ToLower(parsed.Name)], nil)
+			plan.creates = append(plan.creates, &models.Card{
+				Name:      parsed.Name,
+				Level:     parsed.Level,
+				Animated:  parsed.IsAnimated,
+				ColID:     req.CollectionID,
+				Tags:      []string{req.GroupType},
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			})
+			plan.createActions = append(plan.createActions, action)
 		}
 
-		cardsToCreate = append(cardsToCreate, card)
-
 		// Update statistics
 		result.ImportSummary.LevelStats[parsed.Level]++
 		result.ImportSummary.FileTypeStats[parsed.Extension]++
 	}
 
-	// Create cards in database with transaction
-	if len(cardsToCreate) > 0 {
-		// Get next card ID
-		lastID, err := cis.repos.Card.GetLastCardID(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get last card ID: %w", err)
+	err = cis.txManager.WithTransaction(ctx, economyutils.StandardTransactionOptions(), func(ctx context.Context, tx bun.Tx) error {
+		return cis.applyImportPlan(ctx, tx, plan)
+	})
+	if err != nil {
+		cis.rollbackUploads(ctx, plan.uploads, result)
+		for _, upload := range plan.uploads {
+			upload.action.Action = webmodels.ImportActionFailed
+			upload.action.Reason = "rolled back after database failure"
 		}
+		return fmt.Errorf("failed to write imported cards: %w", err)
+	}
 
-		nextID := lastID + 1
-		for i, card := range cardsToCreate {
-			card.ID = nextID + int64(i)
+	for _, upload := range plan.uploads {
+		result.FilesUploaded = append(result.FilesUploaded, upload.fileName)
+	}
+	result.CardsUpdated += len(plan.updates)
+	if len(plan.creates) > 0 {
+		for i, card := range plan.creates {
+			plan.createActions[i].CardID = card.ID
 		}
+		result.CardsCreated = len(plan.creates)
+		result.FirstCardID = plan.creates[0].ID
+		result.LastCardID = plan.creates[len(plan.creates)-1].ID
+	}
 
-		// Batch create cards
-		if err := cis.repos.Card.BatchCreateWithTransaction(ctx, tx, cardsToCreate); err != nil {
-			// Cleanup uploaded files on database failure
-			cis.cleanupUploadedFiles(ctx, uploadedFiles, req.GroupType, req.CollectionID)
-			return fmt.Errorf("failed to create cards in database: %w", err)
-		}
+	result.ImportSummary.ProcessedFiles = len(plan.uploads)
+	result.ImportSummary.FailedFiles = result.ImportSummary.TotalFiles - result.ImportSummary.ProcessedFiles - len(result.FilesSkipped)
 
-		result.CardsCreated = len(cardsToCreate)
-		result.FirstCardID = cardsToCreate[0].ID
-		result.LastCardID = cardsToCreate[len(cardsToCreate)-1].ID
+	return nil
+}
+
+// applyImportPlan writes the planned card changes on tx
+func (cis *CardImportService) applyImportPlan(ctx context.Context, tx bun.Tx, plan *importPlan) error {
+	for _, card := range plan.updates {
+		if _, err := tx.NewUpdate().
+			Model(card).
+			Column("level", "animated", "tags", "updated_at").
+			WherePK().
+			Exec(ctx); err != nil {
+			return fmt.Errorf("failed to update card %d: %w", card.ID, err)
+		}
 	}
 
-	result.ImportSummary.ProcessedFiles = len(uploadedFiles)
-	result.ImportSummary.FailedFiles = result.ImportSummary.TotalFiles - result.ImportSummary.ProcessedFiles - len(result.FilesSkipped)
+	if len(plan.creates) == 0 {
+		return nil
+	}
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		// Cleanup uploaded files on commit failure
-		cis.cleanupUploadedFiles(ctx, uploadedFiles, req.GroupType, req.CollectionID)
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	// Allocate IDs inside the transaction so they line up with what is inserted
+	var lastID int64
+	if err := tx.NewSelect().
+		Model((*models.Card)(nil)).
+		ColumnExpr("COALESCE(MAX(id), 0)").
+		Scan(ctx, &lastID); err != nil {
+		return fmt.Errorf("failed to get last card ID: %w", err)
+	}
+	for i, card := range plan.creates {
+		card.ID = lastID + 1 + int64(i)
 	}
 
+	if err := cis.repos.Card.BatchCreateWithTransaction(ctx, tx, plan.creates); err != nil {
+		return fmt.Errorf("failed to create cards in database: %w", err)
+	}
 	return nil
 }
 
-// handleExistingCard handles existing cards based on overwrite mode
-func (cis *CardImportService) handleExistingCard(ctx context.Context, req *webmodels.CardImportRequest, parsed *webmodels.ParsedFilename, existingCards []*models.Card, result *webmodels.CardImportResult) bool {
+// handleExistingCard applies the overwrite mode to a file whose card name is
+// already used in the collection. It returns the card to overwrite, if any, and
+// whether the file should still be uploaded. Rename changes parsed.Name to a
+// free name; update records metadata changes in the plan without an upload.
+func (cis *CardImportService) handleExistingCard(req *webmodels.CardImportRequest, matches []*models.Card, parsed *webmodels.ParsedFilename, taken map[string][]*models.Card, plan *importPlan, action *webmodels.FileImportAction, result *webmodels.CardImportResult) (*models.Card, bool) {
+	existing := pickExistingCard(matches, parsed.Level)
+
 	switch req.OverwriteMode {
-	case "skip":
-		result.CardsSkipped++
-		return false
-	case "overwrite":
-		// Delete existing card(s) - will be recreated
-		for _, existing := range existingCards {
-			if err := cis.repos.Card.Delete(ctx, existing.ID); err != nil {
-				result.ProcessingErrors = append(result.ProcessingErrors,
-					webmodels.CreateProcessingError(parsed.Original, "database", "delete_error",
-						fmt.Sprintf("Failed to delete existing card %d: %v", existing.ID, err), true))
-				return false
-			}
+	case webmodels.OverwriteModeOverwrite:
+		if existing == nil {
+			// Only clashes with another file in this import, which can't be overwritten
+			action.Action = webmodels.ImportActionSkipped
+			action.Reason = "duplicate card name in import"
+			result.CardsSkipped++
+			return nil, false
 		}
-		return true
-	case "update":
-		// Update existing card properties if needed
-		for _, existing := range existingCards {
-			updated := false
-			if existing.Level != parsed.Level {
-				existing.Level = parsed.Level
-				updated = true
-			}
-			if existing.Animated != parsed.IsAnimated {
-				existing.Animated = parsed.IsAnimated
-				updated = true
-			}
-			if updated {
-				existing.UpdatedAt = time.Now()
-				if err := cis.repos.Card.Update(ctx, existing); err != nil {
-					result.ProcessingErrors = append(result.ProcessingErrors,
-						webmodels.CreateProcessingError(parsed.Original, "database", "update_error",
-							fmt.Sprintf("Failed to update existing card %d: %v", existing.ID, err), true))
-					return false
-				}
-				result.CardsUpdated++
-			} else {
-				result.CardsSkipped++
+		// Claim the card so a second file with the same name can't overwrite it too
+		for i, card := range matches {
+			if card == existing {
+				matches[i] = nil
 			}
 		}
-		return false // Don't create new card
+		return existing, true
+	case webmodels.OverwriteModeRename:
+		original := parsed.Name
+		parsed.Name = uniqueCardName(original, taken)
+		action.Action = webmodels.ImportActionRenamed
+		action.Reason = fmt.Sprintf("%s already exists", original)
+		return nil, true
+	case webmodels.OverwriteModeUpdate:
+		if existing == nil || (existing.Level == parsed.Level && existing.Animated == parsed.IsAnimated) {
+			action.Action = webmodels.ImportActionSkipped
+			action.Reason = "card already up to date"
+			result.CardsSkipped++
+			return nil, false
+		}
+		existing.Level = parsed.Level
+		existing.Animated = parsed.IsAnimated
+		existing.UpdatedAt = time.Now()
+		plan.updates = append(plan.updates, existing)
+		action.Action = webmodels.ImportActionUpdated
+		action.CardID = existing.ID
+		action.CardName = existing.Name
+		return nil, false
 	default:
-		return false
+		action.Action = webmodels.ImportActionSkipped
+		action.Reason = "card already exists"
+		result.CardsSkipped++
+		return nil, false
 	}
 }
 
-// uploadFileToSpaces uploads a file to DigitalOcean Spaces
-func (cis *CardImportService) uploadFileToSpaces(ctx context.Context, file *webmodels.FileUpload, parsed *webmodels.ParsedFilename, groupType, collectionID string) error {
+// pickExistingCard prefers the stored card at the same level. Nil entries mark
+// names claimed earlier in the same import.
+func pickExistingCard(matches []*models.Card, level int) *models.Card {
+	var fallback *models.Card
+	for _, card := range matches {
+		if card == nil {
+			continue
+		}
+		if card.Level == level {
+			return card
+		}
+		if fallback == nil {
+			fallback = card
+		}
+	}
+	return fallback
+}
+
+// uniqueCardName appends the first free numeric suffix to name
+func uniqueCardName(name string, taken map[string][]*models.Card) string {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s_%d", name, i)
+		if len(taken[strings.ToLower(candidate)]) == 0 {
+			return candidate
+		}
+	}
+}
+
+// uploadFileToSpaces uploads a file to DigitalOcean Spaces and returns its object key
+func (cis *CardImportService) uploadFileToSpaces(ctx context.Context, file *webmodels.FileUpload, parsed *webmodels.ParsedFilename, groupType, collectionID string) (string, error) {
 	// Create a temporary card for spaces service
 	tempCard := &models.Card{
 		Name:     parsed.Name,
@@ -464,40 +592,41 @@ func (cis *CardImportService) uploadFileToSpaces(ctx context.Context, file *webm
 	// Use the existing spaces service to upload
 	result, err := cis.spacesService.ManageCardImage(ctx, services.ImageOperationUpload, 0, file.Data, tempCard)
 	if err != nil {
-		return fmt.Errorf("spaces upload failed: %w", err)
+		return "", fmt.Errorf("spaces upload failed: %w", err)
 	}
 
 	if !result.Success {
-		return fmt.Errorf("spaces upload failed: %s", result.ErrorMessage)
+		return "", fmt.Errorf("spaces upload failed: %s", result.ErrorMessage)
 	}
 
-	return nil
+	return result.Key, nil
 }
 
-// cleanupUploadedFiles removes uploaded files if database operation fails
-func (cis *CardImportService) cleanupUploadedFiles(ctx context.Context, uploadedFiles []string, groupType, collectionID string) {
-	for _, fileName := range uploadedFiles {
-		parsed, err := cis.ParseFilename(fileName)
-		if err != nil {
-			continue
-		}
-
-		tempCard := &models.Card{
-			Name:     parsed.Name,
-			Level:    parsed.Level,
-			Animated: parsed.IsAnimated,
-			ColID:    collectionID,
-			Tags:     []string{groupType},
-		}
-
-		// Attempt to delete the uploaded file
-		_, err = cis.spacesService.ManageCardImage(ctx, services.DeleteOperation, 0, nil, tempCard)
-		if err != nil {
-			slog.Error("Failed to cleanup uploaded file",
-				slog.String("file_name", fileName),
+// rollbackUploads deletes the images uploaded by a failed import. Deleted files
+// are reported in FilesRolledBack; ones that couldn't be deleted are reported
+// as processing errors so they can be cleaned up by hand.
+func (cis *CardImportService) rollbackUploads(ctx context.Context, uploads []uploadedImage, result *webmodels.CardImportResult) {
+	// The request context may be what failed, so cleanup gets its own
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+	defer cancel()
+
+	for _, upload := range uploads {
+		if err := cis.spacesService.DeleteObject(cleanupCtx, upload.key); err != nil {
+			slog.Error("Failed to roll back uploaded file",
+				slog.String("file_name", upload.fileName),
+				slog.String("key", upload.key),
 				slog.String("error", err.Error()))
+			result.ProcessingErrors = append(result.ProcessingErrors,
+				webmodels.CreateProcessingError(upload.fileName, "rollback", "cleanup_error",
+					fmt.Sprintf("Failed to delete %s: %v", upload.key, err), true))
+			continue
 		}
+		result.FilesRolledBack = append(result.FilesRolledBack, upload.fileName)
 	}
+
+	slog.Warn("Rolled back card import uploads",
+		slog.Int("uploaded", len(uploads)),
+		slog.Int("rolled_back", len(result.FilesRolledBack)))
 }
 
 // ValidateImportRequest validates import request without processing
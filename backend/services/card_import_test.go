@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	webmodels "github.com/disgoorg/bot-template/backend/models"
+	"github.com/disgoorg/bot-template/backend/services/spacestest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+var errDatabaseDown = errors.New("database down")
+
+// downConnector fails every connection attempt, so any transaction fails to start
+type downConnector struct{}
+
+func (downConnector) Connect(ctx context.Context) (driver.Conn, error) { return nil, errDatabaseDown }
+func (downConnector) Driver() driver.Driver                            { return downDriver{} }
+
+type downDriver struct{}
+
+func (downDriver) Open(name string) (driver.Conn, error) { return nil, errDatabaseDown }
+
+// fakeCollectionRepo serves a fixed set of collections; methods it doesn't override panic
+type fakeCollectionRepo struct {
+	repositories.CollectionRepository
+	collections map[string]*models.Collection
+}
+
+func (r *fakeCollectionRepo) GetByID(ctx context.Context, id string) (*models.Collection, error) {
+	if collection, ok := r.collections[id]; ok {
+		return collection, nil
+	}
+	return nil, errors.New("collection not found")
+}
+
+func importFiles(t *testing.T, names ...string) []*webmodels.FileUpload {
+	t.Helper()
+	data := smallPNG(t)
+	files := make([]*webmodels.FileUpload, 0, len(names))
+	for _, name := range names {
+		files = append(files, &webmodels.FileUpload{Name: name, Size: int64(len(data)), ContentType: "image/png", Data: data})
+	}
+	return files
+}
+
+func TestImportCardsRollsBackUploadsWhenDatabaseFails(t *testing.T) {
+	db := bun.NewDB(sql.OpenDB(downConnector{}), pgdialect.New())
+	t.Cleanup(func() { db.Close() })
+
+	spaces := spacestest.New("cards")
+	repos := &webmodels.Repositories{
+		Card:       &fakeCardRepo{},
+		Collection: &fakeCollectionRepo{collections: map[string]*models.Collection{"twice": {ID: "twice"}}},
+	}
+	cis := NewCardImportService(repos, spaces, nil, db)
+
+	result, err := cis.ImportCards(context.Background(), &webmodels.CardImportRequest{
+		CollectionID: "twice",
+		DisplayName:  "Twice",
+		GroupType:    "girlgroups",
+		Files:        importFiles(t, "1_momo.png", "2_sana.png"),
+	})
+	if !errors.Is(err, errDatabaseDown) {
+		t.Fatalf("ImportCards error = %v, want the database failure", err)
+	}
+	if result.Success || result.CardsCreated != 0 {
+		t.Errorf("result = success %t with %d cards created, want a failure", result.Success, result.CardsCreated)
+	}
+
+	// Both images were uploaded, then deleted again
+	wantKeys := []string{"cards/girlgroups/twice/1_momo.jpg", "cards/girlgroups/twice/2_sana.jpg"}
+	if !reflect.DeepEqual(spaces.Deleted, wantKeys) {
+		t.Errorf("deleted = %v, want %v", spaces.Deleted, wantKeys)
+	}
+	if keys := spaces.Keys(); len(keys) != 0 {
+		t.Errorf("left in storage: %v", keys)
+	}
+	if want := []string{"1_momo.png", "2_sana.png"}; !reflect.DeepEqual(result.FilesRolledBack, want) {
+		t.Errorf("rolled back = %v, want %v", result.FilesRolledBack, want)
+	}
+	if len(result.FilesUploaded) != 0 {
+		t.Errorf("uploaded = %v, want none reported", result.FilesUploaded)
+	}
+	for _, action := range result.FileActions {
+		if action.Action != webmodels.ImportActionFailed {
+			t.Errorf("%s: action = %s, want failed", action.FileName, action.Action)
+		}
+	}
+}
+
+func TestHandleExistingCardModes(t *testing.T) {
+	tests := []struct {
+		mode       string
+		wantAction string
+		wantTarget bool
+		wantUpload bool
+		wantName   string
+		wantUpdate bool
+	}{
+		{mode: webmodels.OverwriteModeSkip, wantAction: webmodels.ImportActionSkipped, wantName: "momo"},
+		{mode: webmodels.OverwriteModeOverwrite, wantTarget: true, wantUpload: true, wantName: "momo"},
+		{mode: webmodels.OverwriteModeRename, wantAction: webmodels.ImportActionRenamed, wantUpload: true, wantName: "momo_3"},
+		{mode: webmodels.OverwriteModeUpdate, wantAction: webmodels.ImportActionUpdated, wantName: "momo", wantUpdate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			existing := &models.Card{ID: 7, Name: "momo", ColID: "twice", Level: 1}
+			taken := map[string][]*models.Card{
+				"momo":   {existing},
+				"momo_2": {{ID: 8, Name: "momo_2", ColID: "twice", Level: 1}},
+			}
+			parsed := &webmodels.ParsedFilename{Name: "momo", Level: 2}
+			plan := &importPlan{}
+			action := &webmodels.FileImportAction{FileName: "2_momo.png"}
+			result := &webmodels.CardImportResult{}
+
+			req := &webmodels.CardImportRequest{OverwriteMode: tt.mode}
+			target, upload := (&CardImportService{}).handleExistingCard(req, taken["momo"], parsed, taken, plan, action, result)
+
+			if (target == existing) != tt.wantTarget || upload != tt.wantUpload {
+				t.Errorf("target = %v, upload = %t, want target %t, upload %t", target, upload, tt.wantTarget, tt.wantUpload)
+			}
+			if action.Action != tt.wantAction {
+				t.Errorf("action = %q, want %q", action.Action, tt.wantAction)
+			}
+			if parsed.Name != tt.wantName {
+				t.Errorf("name = %q, want %q", parsed.Name, tt.wantName)
+			}
+			if updated := len(plan.updates) == 1 && plan.updates[0].Level == 2; updated != tt.wantUpdate {
+				t.Errorf("updates = %v, want update %t", plan.updates, tt.wantUpdate)
+			}
+		})
+	}
+}
+
+func TestHandleExistingCardOverwritesOnce(t *testing.T) {
+	existing := &models.Card{ID: 7, Name: "momo", ColID: "twice", Level: 1}
+	matches := []*models.Card{existing}
+	req := &webmodels.CardImportRequest{OverwriteMode: webmodels.OverwriteModeOverwrite}
+	cis := &CardImportService{}
+	result := &webmodels.CardImportResult{}
+
+	first, _ := cis.handleExistingCard(req, matches, &webmodels.ParsedFilename{Name: "momo", Level: 1}, nil, &importPlan{}, &webmodels.FileImportAction{}, result)
+	action := &webmodels.FileImportAction{}
+	second, upload := cis.handleExistingCard(req, matches, &webmodels.ParsedFilename{Name: "momo", Level: 1}, nil, &importPlan{}, action, result)
+
+	if first != existing {
+		t.Fatalf("first file target = %v, want card 7", first)
+	}
+	if second != nil || upload || action.Action != webmodels.ImportActionSkipped {
+		t.Errorf("second file: target = %v, upload = %t, action = %q, want skipped", second, upload, action.Action)
+	}
+}
+
+func TestParseFilename(t *testing.T) {
+	tests := []struct {
+		filename     string
+		wantName     string
+		wantLevel    int
+		wantAnimated bool
+		wantErrType  string
+	}{
+		{filename: "1_hello.jpg", wantName: "hello", wantLevel: 1},
+		{filename: "3_Na_Yeon.PNG", wantName: "na yeon", wantLevel: 3},
+		{filename: "2_momo__sana.gif", wantName: "momo sana", wantLevel: 2, wantAnimated: true},
+		{filename: "hello.jpg", wantErrType: "filename_format"},
+		{filename: "1_hello.webp", wantErrType: "filename_format"},
+		{filename: "6_hello.jpg", wantErrType: "invalid_level"},
+		{filename: "0_hello.jpg", wantErrType: "invalid_level"},
+		{filename: "1___.png", wantErrType: "empty_name"},
+	}
+
+	cis := &CardImportService{}
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			parsed, err := cis.ParseFilename(tt.filename)
+			if tt.wantErrType != "" {
+				if err == nil || parsed.Valid || parsed.ErrorType != tt.wantErrType {
+					t.Fatalf("parsed = %+v, err = %v, want %s error", parsed, err, tt.wantErrType)
+				}
+				if parsed.Suggestion == "" {
+					t.Errorf("no suggestion for %s", tt.wantErrType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFilename: %v", err)
+			}
+			if parsed.Name != tt.wantName || parsed.Level != tt.wantLevel || parsed.IsAnimated != tt.wantAnimated {
+				t.Errorf("parsed = %q level %d animated %t, want %q level %d animated %t",
+					parsed.Name, parsed.Level, parsed.IsAnimated, tt.wantName, tt.wantLevel, tt.wantAnimated)
+			}
+		})
+	}
+}
+
+func TestValidateImportRequestPreview(t *testing.T) {
+	cis := &CardImportService{}
+	result, err := cis.ValidateImportRequest(context.Background(), &webmodels.CardImportRequest{
+		CollectionID: "twice",
+		DisplayName:  "Twice",
+		GroupType:    "girlgroups",
+		Files:        importFiles(t, "1_momo.png", "momo.png"),
+	})
+	if err != nil {
+		t.Fatalf("ValidateImportRequest: %v", err)
+	}
+	if result.Success {
+		t.Error("success with an invalid filename")
+	}
+
+	if len(result.Preview) != 2 {
+		t.Fatalf("preview = %v, want 2 entries", result.Preview)
+	}
+	valid, invalid := result.Preview[0], result.Preview[1]
+	if !valid.Valid || valid.Name != "momo" || valid.Level != 1 || valid.GroupType != "girlgroups" {
+		t.Errorf("preview[0] = %+v", valid)
+	}
+	if invalid.Valid || invalid.Error == "" {
+		t.Errorf("preview[1] = %+v, want an error", invalid)
+	}
+
+	if len(result.ValidationErrors) != 1 {
+		t.Fatalf("validation errors = %+v, want 1", result.ValidationErrors)
+	}
+	if got := result.ValidationErrors[0]; got.FileName != "momo.png" || got.ErrorType != "filename_format" || got.Suggestion == "" {
+		t.Errorf("validation error = %+v", got)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"time"
 
+	"github.com/disgoorg/bot-template/backend/config"
 	webmodels "github.com/disgoorg/bot-template/backend/models"
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
 	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
@@ -16,14 +18,18 @@ import (
 // CardManagementService provides card management operations for the web interface
 type CardManagementService struct {
 	repos         *webmodels.Repositories
-	spacesService *services.SpacesService
+	spacesService CardImageStorage
+	transcoder    ImageTranscoder
+	webpQuality   int
 }
 
 // NewCardManagementService creates a new card management service
-func NewCardManagementService(repos *webmodels.Repositories, spacesService *services.SpacesService) *CardManagementService {
+func NewCardManagementService(repos *webmodels.Repositories, spacesService CardImageStorage, cfg config.CardManagementConfig) *CardManagementService {
 	return &CardManagementService{
 		repos:         repos,
 		spacesService: spacesService,
+		transcoder:    NewCWebPTranscoder(cfg.CWebPPath),
+		webpQuality:   cfg.WebPQuality,
 	}
 }
 
@@ -55,7 +61,41 @@ func (cms *CardManagementService) SearchCards(ctx context.Context, req *webmodel
 		return nil, 0, fmt.Errorf("failed to search cards: %w", err)
 	}
 
-	// Optimize: Batch fetch collections to avoid N+1 query problem
+	return cms.toCardDTOs(ctx, cards), int64(total), nil
+}
+
+// QuerySearchCards searches all cards with the bot's query syntax (ParseSearchQuery and
+// WeightedSearch). Only card-level filters apply; the second return value reports whether
+// the query contained user-specific filters, which are ignored without a user context.
+func (cms *CardManagementService) QuerySearchCards(ctx context.Context, req *webmodels.CardQuerySearchRequest) ([]*webmodels.CardDTO, int64, bool, error) {
+	if err := req.Validate(); err != nil {
+		return nil, 0, false, fmt.Errorf("invalid search request: %w", err)
+	}
+
+	// Promo and excluded collection filters read the collection cache, which only the bot fills
+	collections, err := cms.repos.Collection.GetAll(ctx)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to get collections: %w", err)
+	}
+	utils.RefreshCollectionCache(collections)
+
+	cards, err := cms.repos.Card.GetAll(ctx)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to get cards: %w", err)
+	}
+
+	filters := utils.ParseSearchQuery(req.Query)
+	results := utils.WeightedSearch(cards, filters)
+
+	total := len(results)
+	start := min((req.Page-1)*req.Limit, total)
+	end := min(start+req.Limit, total)
+
+	return cms.toCardDTOs(ctx, results[start:end]), int64(total), filters.UserQuery, nil
+}
+
+// toCardDTOs converts cards to DTOs, batch fetching their collections to avoid N+1 queries
+func (cms *CardManagementService) toCardDTOs(ctx context.Context, cards []*models.Card) []*webmodels.CardDTO {
 	collectionIDs := make([]string, 0, len(cards))
 	collectionMap := make(map[string]*models.Collection)
 
@@ -92,7 +132,7 @@ func (cms *CardManagementService) SearchCards(ctx context.Context, req *webmodel
 		cardDTOs[i] = webmodels.ConvertCardToDTO(card, collection, imageURL)
 	}
 
-	return cardDTOs, int64(total), nil
+	return cardDTOs
 }
 
 // GetCard retrieves a single card by ID
@@ -122,7 +162,7 @@ func (cms *CardManagementService) GetCard(ctx context.Context, cardID int64) (*w
 // getOptimizedImageURL generates the optimized image URL with correct format
 func (cms *CardManagementService) getOptimizedImageURL(card *models.Card, groupType string) string {
 	// Use the new method that supports both JPG and GIF based on animated flag
-	return cms.spacesService.GetCardImageURLWithFormat(card.Name, card.ColID, card.Level, groupType, card.Animated)
+	return cms.spacesService.GetCardImageURLWithFormat(card.Name, card.ColID, card.Level, groupType, card.Animated, card.ImageFormat)
 }
 
 // CreateCard creates a new card
@@ -152,7 +192,10 @@ func (cms *CardManagementService) CreateCard(ctx context.Context, req *webmodels
 
 	// Handle image upload if provided
 	if len(req.ImageData) > 0 {
-		err = cms.uploadCardImage(ctx, card, req.ImageData, req.ImageName)
+		err = cms.uploadCardImage(ctx, card, req.ImageData, req.ImageName, imageUploadOptions{
+			convertToWebP: req.ConvertToWebP,
+			keepOriginal:  req.KeepOriginal,
+		})
 		if err != nil {
 			slog.Error("Failed to upload card image",
 				slog.Int64("card_id", card.ID),
@@ -205,15 +248,22 @@ func (cms *CardManagementService) UpdateCard(ctx context.Context, cardID int64,
 
 	card.UpdatedAt = time.Now()
 
-	// Update card in database
-	err = cms.repos.Card.Update(ctx, card)
+	// Update card in database, rejecting the write if someone else changed the card since the client read it
+	if req.ExpectedUpdatedAt != nil {
+		err = cms.repos.Card.UpdateIfUnchanged(ctx, card, *req.ExpectedUpdatedAt)
+	} else {
+		err = cms.repos.Card.Update(ctx, card)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to update card: %w", err)
 	}
 
 	// Handle image update if provided
 	if len(req.ImageData) > 0 {
-		err = cms.uploadCardImage(ctx, card, req.ImageData, req.ImageName)
+		err = cms.uploadCardImage(ctx, card, req.ImageData, req.ImageName, imageUploadOptions{
+			convertToWebP: req.ConvertToWebP,
+			keepOriginal:  req.KeepOriginal,
+		})
 		if err != nil {
 			slog.Error("Failed to update card image",
 				slog.Int64("card_id", card.ID),
@@ -243,41 +293,27 @@ func (cms *CardManagementService) UpdateCard(ctx context.Context, cardID int64,
 	return webmodels.ConvertCardToDTO(card, collection, imageURL), nil
 }
 
-// DeleteCard deletes a card and its associated image
-func (cms *CardManagementService) DeleteCard(ctx context.Context, cardID int64) error {
-	// Get card to delete
-	card, err := cms.repos.Card.GetByID(ctx, cardID)
-	if err != nil {
-		return fmt.Errorf("card not found: %w", err)
-	}
-
-	// Delete image from storage
-	err = cms.spacesService.DeleteCardImage(ctx, card.ColID, card.Name, card.Level, card.Tags)
-	if err != nil {
-		slog.Warn("Failed to delete card image",
-			slog.Int64("card_id", card.ID),
-			slog.String("error", err.Error()))
-		// Continue with database deletion even if image deletion fails
-	}
-
-	// Delete card from database
-	err = cms.repos.Card.Delete(ctx, cardID)
-	if err != nil {
-		return fmt.Errorf("failed to delete card: %w", err)
-	}
+// DeleteCard deletes a card together with its owned copies, wishlist entries and image.
+// Cards still owned by users are refused unless force is set.
+func (cms *CardManagementService) DeleteCard(ctx context.Context, cardID int64, force bool) (*models.DeletionReport, error) {
+	return services.DeleteCard(ctx, cms.repos.Card, cms.spacesService, cardID, force)
+}
 
-	slog.Info("Card deleted successfully",
-		slog.Int64("card_id", card.ID),
-		slog.String("name", card.Name))
+// SoftDeleteCard hides a card from search and listings; it can be restored with RestoreCard
+func (cms *CardManagementService) SoftDeleteCard(ctx context.Context, cardID int64) (*models.DeletionReport, error) {
+	return services.SoftDeleteCard(ctx, cms.repos.Card, cardID)
+}
 
-	return nil
+// RestoreCard restores a soft-deleted card
+func (cms *CardManagementService) RestoreCard(ctx context.Context, cardID int64) (bool, error) {
+	return services.RestoreCard(ctx, cms.repos.Card, cardID)
 }
 
 // BulkOperation performs a bulk operation on multiple cards
 func (cms *CardManagementService) BulkOperation(ctx context.Context, req *webmodels.CardBulkOperation) error {
 	switch req.Operation {
 	case "delete":
-		return cms.bulkDelete(ctx, req.CardIDs)
+		return cms.bulkDelete(ctx, req.CardIDs, req.Force)
 	case "update":
 		return cms.bulkUpdate(ctx, req.CardIDs, req.Updates)
 	case "move":
@@ -287,25 +323,79 @@ func (cms *CardManagementService) BulkOperation(ctx context.Context, req *webmod
 	}
 }
 
-// uploadCardImage uploads an image for a card
-func (cms *CardManagementService) uploadCardImage(ctx context.Context, card *models.Card, imageData []byte, imageName string) error {
-	// Use the existing SpacesService to manage the image
-	result, err := cms.spacesService.ManageCardImage(ctx, services.ImageOperationUpdate, card.ID, imageData, card)
+// imageUploadOptions controls how an uploaded card image is stored
+type imageUploadOptions struct {
+	convertToWebP bool
+	keepOriginal  bool
+}
+
+// uploadCardImage uploads an image for a card, optionally transcoding it to WebP
+func (cms *CardManagementService) uploadCardImage(ctx context.Context, card *models.Card, imageData []byte, imageName string, opts imageUploadOptions) error {
+	contentType := http.DetectContentType(imageData)
+
+	// Animated GIFs are stored as-is; only still PNG/JPEG images are transcoded
+	convert := opts.convertToWebP && (contentType == "image/png" || contentType == "image/jpeg")
+	if !convert {
+		// Use the existing SpacesService to manage the image
+		result, err := cms.spacesService.ManageCardImage(ctx, services.ImageOperationUpdate, card.ID, imageData, card)
+		if err != nil {
+			return fmt.Errorf("failed to upload image: %w", err)
+		}
+
+		if !result.Success {
+			return fmt.Errorf("image upload failed: %s", result.ErrorMessage)
+		}
+
+		return cms.setImageFormat(ctx, card, "")
+	}
+
+	webpData, err := cms.transcoder.ToWebP(ctx, imageData, cms.webpQuality)
 	if err != nil {
+		return fmt.Errorf("failed to convert image to webp: %w", err)
+	}
+
+	if _, err := cms.spacesService.UploadCardImage(ctx, card, webpData, "webp", "image/webp"); err != nil {
 		return fmt.Errorf("failed to upload image: %w", err)
 	}
 
-	if !result.Success {
-		return fmt.Errorf("image upload failed: %s", result.ErrorMessage)
+	if opts.keepOriginal {
+		extension := "jpg"
+		if contentType == "image/png" {
+			extension = "png"
+		}
+		if _, err := cms.spacesService.UploadCardImage(ctx, card, imageData, extension, contentType); err != nil {
+			slog.Warn("Failed to keep original card image",
+				slog.Int64("card_id", card.ID),
+				slog.String("image_name", imageName),
+				slog.String("error", err.Error()))
+		}
 	}
 
+	slog.Info("Card image converted to webp",
+		slog.Int64("card_id", card.ID),
+		slog.Int("original_size", len(imageData)),
+		slog.Int("webp_size", len(webpData)))
+
+	return cms.setImageFormat(ctx, card, "webp")
+}
+
+// setImageFormat records the stored image format of a card
+func (cms *CardManagementService) setImageFormat(ctx context.Context, card *models.Card, format string) error {
+	if card.ImageFormat == format {
+		return nil
+	}
+
+	card.ImageFormat = format
+	if err := cms.repos.Card.Update(ctx, card); err != nil {
+		return fmt.Errorf("failed to store image format: %w", err)
+	}
 	return nil
 }
 
 // bulkDelete deletes multiple cards
-func (cms *CardManagementService) bulkDelete(ctx context.Context, cardIDs []int64) error {
+func (cms *CardManagementService) bulkDelete(ctx context.Context, cardIDs []int64, force bool) error {
 	for _, cardID := range cardIDs {
-		err := cms.DeleteCard(ctx, cardID)
+		_, err := cms.DeleteCard(ctx, cardID, force)
 		if err != nil {
 			slog.Error("Failed to delete card in bulk operation",
 				slog.Int64("card_id", cardID),
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/backend/config"
+	webmodels "github.com/disgoorg/bot-template/backend/models"
+	"github.com/disgoorg/bot-template/backend/services/spacestest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+var _ CardImageStorage = (*spacestest.Fake)(nil)
+
+func (r *fakeCardRepo) Update(ctx context.Context, card *models.Card) error {
+	r.updates++
+	return nil
+}
+
+// fakeTranscoder returns a minimal WebP header instead of running cwebp
+type fakeTranscoder struct {
+	err   error
+	calls int
+}
+
+func (t *fakeTranscoder) ToWebP(ctx context.Context, data []byte, quality int) ([]byte, error) {
+	t.calls++
+	if t.err != nil {
+		return nil, t.err
+	}
+	return []byte("RIFF\x1a\x00\x00\x00WEBPVP8 \x0e\x00\x00\x00fake-webp-data"), nil
+}
+
+func smallPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func webpFixture(transcoder ImageTranscoder) (*CardManagementService, *fakeCardRepo, *spacestest.Fake) {
+	cards := &fakeCardRepo{}
+	spaces := spacestest.New("cards")
+	cms := NewCardManagementService(&webmodels.Repositories{Card: cards}, spaces, config.CardManagementConfig{WebPQuality: 80})
+	cms.transcoder = transcoder
+	return cms, cards, spaces
+}
+
+func webpCard() *models.Card {
+	return &models.Card{ID: 1, Name: "Na Yeon", ColID: "twice", Level: 1, Tags: []string{"girlgroups"}}
+}
+
+func TestUploadCardImageConvertsToWebP(t *testing.T) {
+	cms, cards, spaces := webpFixture(&fakeTranscoder{})
+	card := webpCard()
+
+	err := cms.uploadCardImage(context.Background(), card, smallPNG(t), "nayeon.png", imageUploadOptions{convertToWebP: true})
+	if err != nil {
+		t.Fatalf("uploadCardImage: %v", err)
+	}
+
+	const key = "cards/girlgroups/twice/1_na_yeon.webp"
+	data, ok := spaces.Object(key)
+	if !ok {
+		t.Fatalf("no webp object stored, keys = %v", spaces.Keys())
+	}
+	if got := http.DetectContentType(data); got != "image/webp" {
+		t.Errorf("stored content is %s, want image/webp", got)
+	}
+	if len(spaces.Keys()) != 1 {
+		t.Errorf("keys = %v, want only the webp", spaces.Keys())
+	}
+	if card.ImageFormat != "webp" || cards.updates != 1 {
+		t.Errorf("image format = %q after %d updates, want webp stored once", card.ImageFormat, cards.updates)
+	}
+	if url := cms.getOptimizedImageURL(card, "girlgroups"); url != spaces.GetObjectURL(key) {
+		t.Errorf("image URL = %s, want %s", url, spaces.GetObjectURL(key))
+	}
+}
+
+func TestUploadCardImageKeepsOriginal(t *testing.T) {
+	cms, _, spaces := webpFixture(&fakeTranscoder{})
+	original := smallPNG(t)
+
+	err := cms.uploadCardImage(context.Background(), webpCard(), original, "nayeon.png", imageUploadOptions{convertToWebP: true, keepOriginal: true})
+	if err != nil {
+		t.Fatalf("uploadCardImage: %v", err)
+	}
+
+	if _, ok := spaces.Object("cards/girlgroups/twice/1_na_yeon.webp"); !ok {
+		t.Errorf("webp missing, keys = %v", spaces.Keys())
+	}
+	if data, ok := spaces.Object("cards/girlgroups/twice/1_na_yeon.png"); !ok || !bytes.Equal(data, original) {
+		t.Errorf("original png missing or changed, keys = %v", spaces.Keys())
+	}
+}
+
+func TestUploadCardImageWithoutConversion(t *testing.T) {
+	transcoder := &fakeTranscoder{}
+	cms, cards, spaces := webpFixture(transcoder)
+	card := webpCard()
+
+	if err := cms.uploadCardImage(context.Background(), card, smallPNG(t), "nayeon.png", imageUploadOptions{}); err != nil {
+		t.Fatalf("uploadCardImage: %v", err)
+	}
+
+	if transcoder.calls != 0 {
+		t.Errorf("transcoder ran %d times without convert_to_webp", transcoder.calls)
+	}
+	if _, ok := spaces.Object("cards/girlgroups/twice/1_na_yeon.jpg"); !ok {
+		t.Errorf("default image missing, keys = %v", spaces.Keys())
+	}
+	if card.ImageFormat != "" || cards.updates != 0 {
+		t.Errorf("image format = %q after %d updates, want unchanged", card.ImageFormat, cards.updates)
+	}
+	if url := cms.getOptimizedImageURL(card, "girlgroups"); !strings.HasSuffix(url, ".jpg") {
+		t.Errorf("image URL = %s, want a jpg", url)
+	}
+}
+
+func TestUploadCardImageTranscodeFailure(t *testing.T) {
+	cms, cards, spaces := webpFixture(&fakeTranscoder{err: errors.New("cwebp: not found")})
+	card := webpCard()
+
+	err := cms.uploadCardImage(context.Background(), card, smallPNG(t), "nayeon.png", imageUploadOptions{convertToWebP: true})
+	if err == nil || !strings.Contains(err.Error(), "convert image to webp") {
+		t.Fatalf("err = %v, want a conversion error", err)
+	}
+	if len(spaces.Keys()) != 0 || card.ImageFormat != "" || cards.updates != 0 {
+		t.Errorf("failed conversion stored %v with format %q", spaces.Keys(), card.ImageFormat)
+	}
+}
+
+func TestCWebPTranscoder(t *testing.T) {
+	path, err := exec.LookPath("cwebp")
+	if err != nil {
+		t.Skip("cwebp is not installed")
+	}
+
+	data, err := NewCWebPTranscoder(path).ToWebP(context.Background(), smallPNG(t), 80)
+	if err != nil {
+		t.Fatalf("ToWebP: %v", err)
+	}
+	if got := http.DetectContentType(data); got != "image/webp" {
+		t.Errorf("output is %s, want image/webp", got)
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/bot-template/backend/config"
+	webmodels "github.com/disgoorg/bot-template/backend/models"
+	"github.com/disgoorg/bot-template/backend/services/spacestest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+func (r *fakeCollectionRepo) GetAll(ctx context.Context) ([]*models.Collection, error) {
+	collections := make([]*models.Collection, 0, len(r.collections))
+	for _, collection := range r.collections {
+		collections = append(collections, collection)
+	}
+	return collections, nil
+}
+
+func (r *fakeCollectionRepo) GetByIDs(ctx context.Context, ids []string) ([]*models.Collection, error) {
+	var collections []*models.Collection
+	for _, id := range ids {
+		if collection, ok := r.collections[id]; ok {
+			collections = append(collections, collection)
+		}
+	}
+	return collections, nil
+}
+
+func querySearchService() *CardManagementService {
+	repos := &webmodels.Repositories{
+		Card: &fakeCardRepo{cards: []*models.Card{
+			{ID: 1, Name: "nayeon", ColID: "twice", Level: 3},
+			{ID: 2, Name: "momo", ColID: "twice", Level: 3},
+			{ID: 3, Name: "sana", ColID: "twice", Level: 1},
+			{ID: 4, Name: "karina", ColID: "aespa", Level: 3, Animated: true},
+			{ID: 5, Name: "winter", ColID: "aespa", Level: 2},
+		}},
+		Collection: &fakeCollectionRepo{collections: map[string]*models.Collection{
+			"twice": {ID: "twice", Name: "Twice"},
+			"aespa": {ID: "aespa", Name: "aespa"},
+		}},
+	}
+	return NewCardManagementService(repos, spacestest.New("cards"), config.CardManagementConfig{})
+}
+
+func TestQuerySearchCardsCompoundQuery(t *testing.T) {
+	t.Cleanup(func() { utils.RefreshCollectionCache(nil) })
+	cms := querySearchService()
+
+	tests := []struct {
+		query       string
+		want        []int64
+		userIgnored bool
+	}{
+		{query: "-3 -twice", want: []int64{2, 1}},
+		{query: "-3 !animated", want: []int64{2, 1}},
+		{query: "-3 animated", want: []int64{4}},
+		// Amount filters need a user, so only the card filters apply
+		{query: "-aespa >amount=1", want: []int64{4, 5}, userIgnored: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			cards, total, userIgnored, err := cms.QuerySearchCards(context.Background(), &webmodels.CardQuerySearchRequest{Query: tt.query})
+			if err != nil {
+				t.Fatalf("QuerySearchCards: %v", err)
+			}
+			var got []int64
+			for _, card := range cards {
+				got = append(got, card.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) || total != int64(len(tt.want)) {
+				t.Errorf("cards = %v (total %d), want %v", got, total, tt.want)
+			}
+			if userIgnored != tt.userIgnored {
+				t.Errorf("user filters ignored = %t, want %t", userIgnored, tt.userIgnored)
+			}
+		})
+	}
+}
+
+func TestQuerySearchCardsPages(t *testing.T) {
+	t.Cleanup(func() { utils.RefreshCollectionCache(nil) })
+	cms := querySearchService()
+	ctx := context.Background()
+
+	req := &webmodels.CardQuerySearchRequest{Query: "  ", Page: 3, Limit: 2}
+	cards, total, _, err := cms.QuerySearchCards(ctx, req)
+	if err != nil {
+		t.Fatalf("QuerySearchCards: %v", err)
+	}
+	if total != 5 || len(cards) != 1 || req.Query != "" {
+		t.Errorf("page 3 = %d cards of %d for query %q, want the last 1 of 5", len(cards), total, req.Query)
+	}
+
+	// Past the end is empty rather than an error; the limit is clamped
+	req = &webmodels.CardQuerySearchRequest{Page: 9, Limit: 500}
+	cards, total, _, err = cms.QuerySearchCards(ctx, req)
+	if err != nil || len(cards) != 0 || total != 5 || req.Limit != webmodels.MaxCardPageLimit {
+		t.Errorf("page 9 = %d cards of %d, limit %d, %v", len(cards), total, req.Limit, err)
+	}
+}
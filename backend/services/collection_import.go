@@ -9,6 +9,7 @@ import (
 	"time"
 
 	webmodels "github.com/disgoorg/bot-template/backend/models"
+	webutils "github.com/disgoorg/bot-template/backend/utils"
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
 	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
 	"github.com/disgoorg/bot-template/bottemplate/economy/utils"
@@ -93,6 +94,16 @@ func (cis *CollectionImportService) ProcessCollectionImport(ctx context.Context,
 				ErrorMessage: fmt.Sprintf("Invalid file %s: %s", file.Name, err.Error()),
 			}, nil
 		}
+
+		// Never trust the client-sent content type
+		contentType, err := webutils.DetectImageContentType(file.Name, file.Data)
+		if err != nil {
+			return &webmodels.CollectionImportResult{
+				Success:      false,
+				ErrorMessage: fmt.Sprintf("Invalid file %s: %s", file.Name, err.Error()),
+			}, nil
+		}
+		file.ContentType = contentType
 		validatedFiles = append(validatedFiles, parsed)
 	}
 
@@ -187,6 +198,60 @@ func (cis *CollectionImportService) ProcessCollectionImport(ctx context.Context,
 	}, nil
 }
 
+// ValidateImportRequest checks a collection import up front so a batch can be
+// rejected before anything is uploaded
+func (cis *CollectionImportService) ValidateImportRequest(req *webmodels.CollectionImportRequest) error {
+	if req.CollectionID == "" || req.DisplayName == "" || req.GroupType == "" {
+		return fmt.Errorf("collection_id, display_name and group_type are required")
+	}
+	if req.GroupType != "girlgroups" && req.GroupType != "boygroups" {
+		return fmt.Errorf("group type must be 'girlgroups' or 'boygroups'")
+	}
+	if len(req.Files) == 0 {
+		return fmt.Errorf("no files provided")
+	}
+
+	names := make(map[string]bool, len(req.Files))
+	for _, file := range req.Files {
+		parsed, err := cis.ValidateAndNormalizeFilename(file.Name)
+		if err != nil {
+			return fmt.Errorf("invalid file %s: %w", file.Name, err)
+		}
+		if names[parsed.Name] {
+			return fmt.Errorf("duplicate card name found: %s", parsed.Name)
+		}
+		names[parsed.Name] = true
+	}
+	return nil
+}
+
+// ProcessBatchImport imports each collection in turn. A failed collection is
+// recorded in its result and doesn't stop the rest of the batch, since each
+// import cleans up after itself.
+func (cis *CollectionImportService) ProcessBatchImport(ctx context.Context, reqs []*webmodels.CollectionImportRequest) *webmodels.BatchCollectionImportResult {
+	batch := &webmodels.BatchCollectionImportResult{
+		Total:   len(reqs),
+		Results: make([]*webmodels.CollectionImportResult, 0, len(reqs)),
+	}
+
+	for _, req := range reqs {
+		result, err := cis.ProcessCollectionImport(ctx, req)
+		if err != nil {
+			result = &webmodels.CollectionImportResult{ErrorMessage: err.Error()}
+		}
+		result.CollectionID = req.CollectionID
+
+		if result.Success {
+			batch.Succeeded++
+		} else {
+			batch.Failed++
+		}
+		batch.Results = append(batch.Results, result)
+	}
+
+	return batch
+}
+
 func (cis *CollectionImportService) ensureCollectionExists(ctx context.Context, collectionID, displayName, groupType string, isPromo bool) error {
 	// Check if collection already exists
 	existing, err := cis.collectionRepo.GetByID(ctx, collectionID)
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	webmodels "github.com/disgoorg/bot-template/backend/models"
+)
+
+func importRequest(id, groupType string, files ...string) *webmodels.CollectionImportRequest {
+	req := &webmodels.CollectionImportRequest{CollectionID: id, DisplayName: id, GroupType: groupType}
+	for _, name := range files {
+		req.Files = append(req.Files, &webmodels.FileUpload{Name: name})
+	}
+	return req
+}
+
+func TestValidateImportRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *webmodels.CollectionImportRequest
+		wantErr string
+	}{
+		{name: "valid", req: importRequest("twice", "girlgroups", "1_momo.png", "2_sana.gif")},
+		{name: "missing display name", req: &webmodels.CollectionImportRequest{CollectionID: "twice", GroupType: "girlgroups"}, wantErr: "required"},
+		{name: "unknown group type", req: importRequest("twice", "kpop", "1_momo.png"), wantErr: "group type"},
+		{name: "no files", req: importRequest("twice", "girlgroups"), wantErr: "no files provided"},
+		{name: "bad filename", req: importRequest("twice", "girlgroups", "momo.png"), wantErr: "invalid file momo.png"},
+		{name: "level out of range", req: importRequest("twice", "girlgroups", "6_momo.png"), wantErr: "level must be between 1 and 5"},
+		{name: "duplicate after normalizing", req: importRequest("twice", "girlgroups", "1_Momo Hirai.png", "2_momo_hirai.jpg"), wantErr: "duplicate card name found: momo_hirai"},
+	}
+
+	cis := NewCollectionImportService(nil, nil, nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := cis.ValidateImportRequest(tt.req)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateImportRequest: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProcessBatchImportReportsEachCollection(t *testing.T) {
+	cis := NewCollectionImportService(nil, nil, nil, nil)
+	// Both fail file validation before touching storage or the database
+	reqs := []*webmodels.CollectionImportRequest{
+		importRequest("twice", "girlgroups", "momo.png"),
+		importRequest("bts", "boygroups", "9_jin.png"),
+	}
+
+	batch := cis.ProcessBatchImport(context.Background(), reqs)
+	if batch.Total != 2 || batch.Succeeded != 0 || batch.Failed != 2 {
+		t.Fatalf("batch = %+v, want 2 failed of 2", batch)
+	}
+	for i, want := range []string{"twice", "bts"} {
+		if batch.Results[i].CollectionID != want || batch.Results[i].ErrorMessage == "" {
+			t.Errorf("result %d = %+v, want a failure for %s", i, batch.Results[i], want)
+		}
+	}
+}
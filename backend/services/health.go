@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Health statuses reported for components and overall
+const (
+	HealthStatusHealthy   = "healthy"
+	HealthStatusDegraded  = "degraded"
+	HealthStatusUnhealthy = "unhealthy"
+)
+
+// DefaultHealthCheckTimeout bounds each dependency check so a hung dependency can't hang /health
+const DefaultHealthCheckTimeout = 3 * time.Second
+
+// Pinger is a dependency that can report whether it is reachable
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// HealthDependency is a named dependency checked by the health endpoint.
+// Failures of non-critical dependencies only degrade the overall status.
+type HealthDependency struct {
+	Name     string
+	Pinger   Pinger
+	Critical bool
+}
+
+// ComponentHealth is the result of checking a single dependency
+type ComponentHealth struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// HealthReport is the combined result of all dependency checks
+type HealthReport struct {
+	Status     string            `json:"status"`
+	Components []ComponentHealth `json:"components"`
+}
+
+// Healthy reports whether every critical dependency is reachable
+func (r *HealthReport) Healthy() bool {
+	return r.Status != HealthStatusUnhealthy
+}
+
+// HealthChecker pings the backend's dependencies concurrently
+type HealthChecker struct {
+	dependencies []HealthDependency
+	timeout      time.Duration
+}
+
+// NewHealthChecker creates a checker for the given dependencies
+func NewHealthChecker(timeout time.Duration, dependencies ...HealthDependency) *HealthChecker {
+	if timeout <= 0 {
+		timeout = DefaultHealthCheckTimeout
+	}
+	return &HealthChecker{
+		dependencies: dependencies,
+		timeout:      timeout,
+	}
+}
+
+// Check pings every dependency, each under its own timeout
+func (h *HealthChecker) Check(ctx context.Context) *HealthReport {
+	report := &HealthReport{
+		Status:     HealthStatusHealthy,
+		Components: make([]ComponentHealth, len(h.dependencies)),
+	}
+
+	var wg sync.WaitGroup
+	for i, dep := range h.dependencies {
+		wg.Add(1)
+		go func(i int, dep HealthDependency) {
+			defer wg.Done()
+			report.Components[i] = h.checkOne(ctx, dep)
+		}(i, dep)
+	}
+	wg.Wait()
+
+	for _, component := range report.Components {
+		if component.Status == HealthStatusHealthy {
+			continue
+		}
+		if component.Critical {
+			report.Status = HealthStatusUnhealthy
+		} else if report.Status == HealthStatusHealthy {
+			report.Status = HealthStatusDegraded
+		}
+	}
+	return report
+}
+
+func (h *HealthChecker) checkOne(ctx context.Context, dep HealthDependency) ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	// Run the ping separately so a dependency that ignores ctx still can't block past the timeout
+	result := make(chan error, 1)
+	start := time.Now()
+	go func() {
+		result <- dep.Pinger.Ping(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	component := ComponentHealth{
+		Name:      dep.Name,
+		Status:    HealthStatusHealthy,
+		Critical:  dep.Critical,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		component.Status = HealthStatusUnhealthy
+		component.Error = err.Error()
+	}
+	return component
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type pingFunc func(ctx context.Context) error
+
+func (f pingFunc) Ping(ctx context.Context) error { return f(ctx) }
+
+var (
+	pingOK   = pingFunc(func(ctx context.Context) error { return nil })
+	pingDown = pingFunc(func(ctx context.Context) error { return errors.New("connection refused") })
+	// pingHung ignores ctx entirely, like a client without deadlines
+	pingHung = pingFunc(func(ctx context.Context) error { time.Sleep(time.Second); return nil })
+)
+
+func TestHealthCheckerStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		deps []HealthDependency
+		want string
+	}{
+		{
+			name: "all reachable",
+			deps: []HealthDependency{{Name: "database", Pinger: pingOK, Critical: true}, {Name: "spaces", Pinger: pingOK}},
+			want: HealthStatusHealthy,
+		},
+		{
+			name: "optional dependency down",
+			deps: []HealthDependency{{Name: "database", Pinger: pingOK, Critical: true}, {Name: "spaces", Pinger: pingDown}},
+			want: HealthStatusDegraded,
+		},
+		{
+			name: "critical dependency down",
+			deps: []HealthDependency{{Name: "database", Pinger: pingDown, Critical: true}, {Name: "spaces", Pinger: pingDown}},
+			want: HealthStatusUnhealthy,
+		},
+		{
+			name: "no dependencies",
+			want: HealthStatusHealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewHealthChecker(time.Second, tt.deps...).Check(context.Background())
+			if report.Status != tt.want {
+				t.Errorf("status = %s, want %s", report.Status, tt.want)
+			}
+			if report.Healthy() != (tt.want != HealthStatusUnhealthy) {
+				t.Errorf("Healthy() = %v for %s", report.Healthy(), report.Status)
+			}
+			if len(report.Components) != len(tt.deps) {
+				t.Fatalf("got %d components, want %d", len(report.Components), len(tt.deps))
+			}
+			for i, component := range report.Components {
+				if component.Name != tt.deps[i].Name || component.Critical != tt.deps[i].Critical {
+					t.Errorf("component %d = %+v, want %s", i, component, tt.deps[i].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthCheckerTimesOutHungDependency(t *testing.T) {
+	checker := NewHealthChecker(20*time.Millisecond, HealthDependency{Name: "database", Pinger: pingHung, Critical: true})
+
+	start := time.Now()
+	report := checker.Check(context.Background())
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Check took %s, want it bounded by the timeout", elapsed)
+	}
+	if report.Status != HealthStatusUnhealthy || report.Components[0].Error == "" {
+		t.Errorf("report = %+v, want the hung database reported unhealthy", report)
+	}
+}
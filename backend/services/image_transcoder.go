@@ -0,0 +1,61 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// ImageTranscoder converts uploaded card images into a storage format
+type ImageTranscoder interface {
+	// ToWebP converts PNG or JPEG data to WebP at the given quality (0-100)
+	ToWebP(ctx context.Context, data []byte, quality int) ([]byte, error)
+}
+
+// CWebPTranscoder transcodes images by running libwebp's cwebp tool
+type CWebPTranscoder struct {
+	binaryPath string
+}
+
+// NewCWebPTranscoder creates a transcoder that runs the cwebp binary at binaryPath
+func NewCWebPTranscoder(binaryPath string) *CWebPTranscoder {
+	return &CWebPTranscoder{binaryPath: binaryPath}
+}
+
+// ToWebP converts PNG or JPEG data to WebP
+func (t *CWebPTranscoder) ToWebP(ctx context.Context, data []byte, quality int) ([]byte, error) {
+	// cwebp can't read from stdin, so stage the input in a temp file
+	input, err := os.CreateTemp("", "card-*.img")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(input.Name())
+
+	if _, err := input.Write(data); err != nil {
+		input.Close()
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := input.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.binaryPath, "-quiet", "-q", strconv.Itoa(quality), "-o", "-", "--", input.Name())
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("cwebp failed: %w: %s", err, stderr.String())
+	}
+
+	output := stdout.Bytes()
+	if http.DetectContentType(output) != "image/webp" {
+		return nil, fmt.Errorf("cwebp produced invalid WebP output")
+	}
+
+	return output, nil
+}
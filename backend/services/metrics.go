@@ -0,0 +1,199 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Operation results recorded by IncOperation
+const (
+	OperationSuccess = "success"
+	OperationError   = "error"
+)
+
+// requestLatencyBuckets are the upper bounds, in seconds, of the request latency histogram
+var requestLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type requestKey struct {
+	Method string
+	Route  string
+	Status string
+}
+
+type routeKey struct {
+	Method string
+	Route  string
+}
+
+type operationKey struct {
+	Operation string
+	Result    string
+}
+
+type latencyHistogram struct {
+	buckets []uint64
+	sum     float64
+	count   uint64
+}
+
+// Gauge is a point-in-time value rendered alongside the collected metrics
+type Gauge struct {
+	Name   string
+	Help   string
+	Labels map[string]string
+	Value  float64
+}
+
+// MetricsRegistry collects HTTP and operation metrics for the Prometheus
+// /metrics endpoint. It renders the text exposition format itself so the
+// backend doesn't need the full client library for a handful of series.
+type MetricsRegistry struct {
+	mu         sync.Mutex
+	requests   map[requestKey]uint64
+	latencies  map[routeKey]*latencyHistogram
+	operations map[operationKey]uint64
+}
+
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{
+		requests:   make(map[requestKey]uint64),
+		latencies:  make(map[routeKey]*latencyHistogram),
+		operations: make(map[operationKey]uint64),
+	}
+}
+
+// ObserveRequest records a handled request. Route should be the registered
+// route pattern rather than the raw path to keep label cardinality bounded.
+func (m *MetricsRegistry) ObserveRequest(method, route string, status int, duration time.Duration) {
+	seconds := duration.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{Method: method, Route: route, Status: strconv.Itoa(status)}]++
+
+	key := routeKey{Method: method, Route: route}
+	hist, ok := m.latencies[key]
+	if !ok {
+		hist = &latencyHistogram{buckets: make([]uint64, len(requestLatencyBuckets))}
+		m.latencies[key] = hist
+	}
+	for i, bound := range requestLatencyBuckets {
+		if seconds <= bound {
+			hist.buckets[i]++
+		}
+	}
+	hist.sum += seconds
+	hist.count++
+}
+
+// IncOperation counts a completed import or bulk operation
+func (m *MetricsRegistry) IncOperation(operation, result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operations[operationKey{Operation: operation, Result: result}]++
+}
+
+// Write renders the collected metrics followed by the given gauges in the
+// Prometheus text exposition format
+func (m *MetricsRegistry) Write(w io.Writer, gauges []Gauge) error {
+	var b strings.Builder
+
+	m.mu.Lock()
+	requestKeys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		requestKeys = append(requestKeys, key)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		a, c := requestKeys[i], requestKeys[j]
+		if a.Route != c.Route {
+			return a.Route < c.Route
+		}
+		if a.Method != c.Method {
+			return a.Method < c.Method
+		}
+		return a.Status < c.Status
+	})
+	b.WriteString("# HELP gohye_http_requests_total Total HTTP requests by route and status.\n")
+	b.WriteString("# TYPE gohye_http_requests_total counter\n")
+	for _, key := range requestKeys {
+		fmt.Fprintf(&b, "gohye_http_requests_total{method=%q,route=%q,status=%q} %d\n",
+			key.Method, key.Route, key.Status, m.requests[key])
+	}
+
+	routeKeys := make([]routeKey, 0, len(m.latencies))
+	for key := range m.latencies {
+		routeKeys = append(routeKeys, key)
+	}
+	sort.Slice(routeKeys, func(i, j int) bool {
+		if routeKeys[i].Route != routeKeys[j].Route {
+			return routeKeys[i].Route < routeKeys[j].Route
+		}
+		return routeKeys[i].Method < routeKeys[j].Method
+	})
+	b.WriteString("# HELP gohye_http_request_duration_seconds HTTP request latency by route.\n")
+	b.WriteString("# TYPE gohye_http_request_duration_seconds histogram\n")
+	for _, key := range routeKeys {
+		hist := m.latencies[key]
+		for i, bound := range requestLatencyBuckets {
+			fmt.Fprintf(&b, "gohye_http_request_duration_seconds_bucket{method=%q,route=%q,le=%q} %d\n",
+				key.Method, key.Route, strconv.FormatFloat(bound, 'g', -1, 64), hist.buckets[i])
+		}
+		fmt.Fprintf(&b, "gohye_http_request_duration_seconds_bucket{method=%q,route=%q,le=\"+Inf\"} %d\n",
+			key.Method, key.Route, hist.count)
+		fmt.Fprintf(&b, "gohye_http_request_duration_seconds_sum{method=%q,route=%q} %s\n",
+			key.Method, key.Route, strconv.FormatFloat(hist.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "gohye_http_request_duration_seconds_count{method=%q,route=%q} %d\n",
+			key.Method, key.Route, hist.count)
+	}
+
+	operationKeys := make([]operationKey, 0, len(m.operations))
+	for key := range m.operations {
+		operationKeys = append(operationKeys, key)
+	}
+	sort.Slice(operationKeys, func(i, j int) bool {
+		if operationKeys[i].Operation != operationKeys[j].Operation {
+			return operationKeys[i].Operation < operationKeys[j].Operation
+		}
+		return operationKeys[i].Result < operationKeys[j].Result
+	})
+	b.WriteString("# HELP gohye_operations_total Import and bulk operations by result.\n")
+	b.WriteString("# TYPE gohye_operations_total counter\n")
+	for _, key := range operationKeys {
+		fmt.Fprintf(&b, "gohye_operations_total{operation=%q,result=%q} %d\n",
+			key.Operation, key.Result, m.operations[key])
+	}
+	m.mu.Unlock()
+
+	// Gauges sharing a name are grouped under a single HELP/TYPE header
+	written := make(map[string]bool)
+	for _, gauge := range gauges {
+		if !written[gauge.Name] {
+			fmt.Fprintf(&b, "# HELP %s %s\n", gauge.Name, gauge.Help)
+			fmt.Fprintf(&b, "# TYPE %s gauge\n", gauge.Name)
+			written[gauge.Name] = true
+		}
+		b.WriteString(gauge.Name)
+		if len(gauge.Labels) > 0 {
+			names := make([]string, 0, len(gauge.Labels))
+			for name := range gauge.Labels {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			pairs := make([]string, len(names))
+			for i, name := range names {
+				pairs[i] = fmt.Sprintf("%s=%q", name, gauge.Labels[name])
+			}
+			b.WriteString("{" + strings.Join(pairs, ",") + "}")
+		}
+		b.WriteString(" " + strconv.FormatFloat(gauge.Value, 'g', -1, 64) + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
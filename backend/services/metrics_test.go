@@ -0,0 +1,52 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsRegistryWrite(t *testing.T) {
+	m := NewMetricsRegistry()
+	m.ObserveRequest("GET", "/admin/api/cards", 200, 30*time.Millisecond)
+	m.ObserveRequest("GET", "/admin/api/cards", 200, 2*time.Second)
+	m.ObserveRequest("POST", "/admin/cards/import", 500, time.Millisecond)
+	m.IncOperation("card_import", OperationSuccess)
+	m.IncOperation("card_import", OperationError)
+	m.IncOperation("card_import", OperationError)
+
+	var out strings.Builder
+	err := m.Write(&out, []Gauge{
+		{Name: "gohye_entities_total", Help: "Number of stored cards, collections and users.", Labels: map[string]string{"entity": "cards"}, Value: 1200},
+		{Name: "gohye_entities_total", Help: "Number of stored cards, collections and users.", Labels: map[string]string{"entity": "users"}, Value: 35},
+		{Name: "gohye_db_connections_open", Help: "Open database connections.", Labels: map[string]string{"pool": "pgx"}, Value: 4},
+	})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	text := out.String()
+
+	for _, want := range []string{
+		"# TYPE gohye_http_requests_total counter\n",
+		`gohye_http_requests_total{method="GET",route="/admin/api/cards",status="200"} 2` + "\n",
+		`gohye_http_requests_total{method="POST",route="/admin/cards/import",status="500"} 1` + "\n",
+		"# TYPE gohye_http_request_duration_seconds histogram\n",
+		`gohye_http_request_duration_seconds_bucket{method="GET",route="/admin/api/cards",le="0.05"} 1` + "\n",
+		`gohye_http_request_duration_seconds_bucket{method="GET",route="/admin/api/cards",le="2.5"} 2` + "\n",
+		`gohye_http_request_duration_seconds_bucket{method="GET",route="/admin/api/cards",le="+Inf"} 2` + "\n",
+		`gohye_http_request_duration_seconds_count{method="GET",route="/admin/api/cards"} 2` + "\n",
+		`gohye_operations_total{operation="card_import",result="error"} 2` + "\n",
+		`gohye_operations_total{operation="card_import",result="success"} 1` + "\n",
+		`gohye_entities_total{entity="cards"} 1200` + "\n",
+		`gohye_db_connections_open{pool="pgx"} 4` + "\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output is missing %q", want)
+		}
+	}
+
+	// Gauges sharing a name get one header
+	if n := strings.Count(text, "# TYPE gohye_entities_total gauge"); n != 1 {
+		t.Errorf("gohye_entities_total has %d TYPE lines, want 1", n)
+	}
+}
@@ -34,6 +34,13 @@ type DiscordGuildMember struct {
 	Nick  string      `json:"nick"`
 }
 
+// OAuthToken is the token set returned by Discord's token endpoint
+type OAuthToken struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
 // OAuthService handles Discord OAuth2 authentication
 type OAuthService struct {
 	config     *config.WebAppConfig
@@ -63,45 +70,78 @@ func (o *OAuthService) GenerateAuthURL(state string) string {
 }
 
 // ExchangeCodeForToken exchanges an authorization code for an access token
-func (o *OAuthService) ExchangeCodeForToken(ctx context.Context, code string) (string, error) {
+func (o *OAuthService) ExchangeCodeForToken(ctx context.Context, code string) (*OAuthToken, error) {
 	data := url.Values{}
-	data.Set("client_id", o.config.Config.Web.OAuth.ClientID)
-	data.Set("client_secret", o.config.Config.Web.OAuth.ClientSecret)
 	data.Set("grant_type", "authorization_code")
 	data.Set("code", code)
 	data.Set("redirect_uri", o.config.Config.Web.OAuth.RedirectURL)
 
+	token, err := o.requestToken(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code for token: %w", err)
+	}
+	return token, nil
+}
+
+// RefreshToken trades a refresh token for a new token set. Discord rotates
+// refresh tokens, so the returned one replaces the one passed in.
+func (o *OAuthService) RefreshToken(ctx context.Context, refreshToken string) (*OAuthToken, error) {
+	if refreshToken == "" {
+		return nil, fmt.Errorf("no refresh token available")
+	}
+
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", refreshToken)
+
+	token, err := o.requestToken(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	return token, nil
+}
+
+// requestToken posts a grant to Discord's token endpoint with the client credentials
+func (o *OAuthService) requestToken(ctx context.Context, data url.Values) (*OAuthToken, error) {
+	data.Set("client_id", o.config.Config.Web.OAuth.ClientID)
+	data.Set("client_secret", o.config.Config.Web.OAuth.ClientSecret)
+
 	req, err := http.NewRequestWithContext(ctx, "POST", "https://discord.com/api/oauth2/token",
 		strings.NewReader(data.Encode()))
 	if err != nil {
-		return "", fmt.Errorf("failed to create token request: %w", err)
+		return nil, fmt.Errorf("failed to create token request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := o.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to exchange code for token: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("discord API error: %s", string(body))
+		return nil, fmt.Errorf("discord API error: %s", string(body))
 	}
 
 	var tokenResp struct {
-		AccessToken string `json:"access_token"`
-		TokenType   string `json:"token_type"`
-		ExpiresIn   int    `json:"expires_in"`
-		Scope       string `json:"scope"`
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int    `json:"expires_in"`
+		Scope        string `json:"scope"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return "", fmt.Errorf("failed to decode token response: %w", err)
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
 	}
 
-	return tokenResp.AccessToken, nil
+	return &OAuthToken{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
+	}, nil
 }
 
 // GetUserInfo gets Discord user information using an access token
@@ -169,51 +209,35 @@ func (o *OAuthService) GetUserGuildMember(ctx context.Context, accessToken strin
 	return &member, nil
 }
 
-// CreateUserSession creates a user session from Discord user info
-func (o *OAuthService) CreateUserSession(ctx context.Context, user *DiscordUser, accessToken string) (*models.UserSession, error) {
+// CreateUserSession creates a user session from Discord user info. It is also
+// used on renewal so admin status is re-derived from the user's current roles.
+func (o *OAuthService) CreateUserSession(ctx context.Context, user *DiscordUser, token *OAuthToken) (*models.UserSession, error) {
 	session := &models.UserSession{
-		DiscordID:   user.ID,
-		Username:    user.Username,
-		Avatar:      user.Avatar,
-		Email:       user.Email,
-		Permissions: []string{},
-		Roles:       []string{},
-		IsAdmin:     false,
-		ExpiresAt:   time.Now().Add(24 * time.Hour), // 24 hour session
+		DiscordID:    user.ID,
+		Username:     user.Username,
+		Avatar:       user.Avatar,
+		Email:        user.Email,
+		Permissions:  []string{},
+		Roles:        []string{},
+		IsAdmin:      false,
+		ExpiresAt:    time.Now().Add(SessionDuration),
+		RefreshToken: token.RefreshToken,
 	}
 
-	// Check if user is an admin by user ID
-	session.IsAdmin = o.isAdminUser(user.ID)
-	slog.Info("Admin check by user ID",
-		slog.String("user_id", user.ID),
-		slog.Bool("is_admin_by_id", session.IsAdmin))
-
-	// If not admin by user ID, check roles if guild is configured
-	if !session.IsAdmin && o.config.Config.Web.AdminGuildID != "" {
-		member, err := o.GetUserGuildMember(ctx, accessToken)
+	// Roles only matter when the admin guild is configured and the user isn't
+	// already an admin by ID
+	if !o.isAdminUser(user.ID) && o.config.Config.Web.AdminGuildID != "" {
+		member, err := o.GetUserGuildMember(ctx, token.AccessToken)
 		if err != nil {
-			slog.Warn("Failed to get guild member info for admin check",
+			slog.Warn("Failed to get guild member info for permission check",
 				slog.String("user_id", user.ID),
 				slog.String("error", err.Error()))
 		} else {
 			session.Roles = member.Roles
-			session.IsAdmin = o.isAdminRole(member.Roles)
 		}
 	}
 
-	// Set permissions based on admin status
-	if session.IsAdmin {
-		session.Permissions = []string{
-			"admin.read",
-			"admin.write",
-			"cards.create",
-			"cards.update",
-			"cards.delete",
-			"collections.import",
-			"sync.manage",
-			"users.view",
-		}
-	}
+	session.Permissions, session.IsAdmin = o.ResolvePermissions(user.ID, session.Roles)
 
 	slog.Info("User session created",
 		slog.String("user_id", session.DiscordID),
@@ -224,22 +248,42 @@ func (o *OAuthService) CreateUserSession(ctx context.Context, user *DiscordUser,
 	return session, nil
 }
 
+// ResolvePermissions works out a user's web permissions. Admin users and admin
+// roles get every permission; otherwise the role mapping is applied, and any
+// mapped permission also grants read access to the dashboard.
+func (o *OAuthService) ResolvePermissions(userID string, roles []string) ([]string, bool) {
+	if o.isAdminUser(userID) || o.isAdminRole(roles) {
+		return append([]string(nil), models.AllPermissions...), true
+	}
+
+	granted := make(map[string]bool)
+	for _, roleID := range roles {
+		for _, permission := range o.config.RolePermissions[roleID] {
+			granted[permission] = true
+		}
+	}
+	if len(granted) == 0 {
+		return []string{}, false
+	}
+	granted[models.PermissionAdminRead] = true
+
+	// Keep a stable order so sessions compare and display predictably
+	permissions := make([]string, 0, len(granted))
+	for _, permission := range models.AllPermissions {
+		if granted[permission] {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions, false
+}
+
 // isAdminUser checks if a user ID is in the admin users list
 func (o *OAuthService) isAdminUser(userID string) bool {
-	slog.Info("Checking admin user",
-		slog.String("user_id", userID),
-		slog.Any("admin_users", o.config.Config.Web.AdminUsers))
-
 	for _, adminID := range o.config.Config.Web.AdminUsers {
-		slog.Info("Comparing admin IDs",
-			slog.String("user_id", userID),
-			slog.String("admin_id", adminID))
 		if adminID == userID {
-			slog.Info("Admin user match found", slog.String("user_id", userID))
 			return true
 		}
 	}
-	slog.Info("No admin user match found", slog.String("user_id", userID))
 	return false
 }
 
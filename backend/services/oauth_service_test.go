@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/backend/models"
+)
+
+// roundTripFunc answers requests without touching the network
+type roundTripFunc func(req *http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	o := NewOAuthService(testWebConfig())
+	var form url.Values
+	o.httpClient.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		raw, _ := io.ReadAll(req.Body)
+		form, _ = url.ParseQuery(string(raw))
+		return jsonResponse(200, `{"access_token":"access-2","refresh_token":"refresh-2","token_type":"Bearer","expires_in":604800}`)
+	})
+
+	token, err := o.RefreshToken(context.Background(), "refresh-1")
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+	if form.Get("grant_type") != "refresh_token" || form.Get("refresh_token") != "refresh-1" ||
+		form.Get("client_id") != "client" || form.Get("client_secret") != "secret" {
+		t.Errorf("token request form = %v", form)
+	}
+	if token.AccessToken != "access-2" || token.RefreshToken != "refresh-2" {
+		t.Errorf("token = %+v, want the rotated pair", token)
+	}
+	if until := time.Until(token.ExpiresAt); until < 6*24*time.Hour || until > 7*24*time.Hour {
+		t.Errorf("token expires in %s, want about 7 days", until)
+	}
+
+	if _, err := o.RefreshToken(context.Background(), ""); err == nil {
+		t.Error("refreshed without a refresh token")
+	}
+
+	o.httpClient.Transport = roundTripFunc(func(req *http.Request) *http.Response {
+		return jsonResponse(400, `{"error":"invalid_grant"}`)
+	})
+	if _, err := o.RefreshToken(context.Background(), "revoked"); err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("revoked refresh token error = %v", err)
+	}
+}
+
+func TestResolvePermissions(t *testing.T) {
+	cfg := testWebConfig()
+	cfg.Config.Web.AdminUsers = []string{"owner"}
+	cfg.Config.Web.AdminRoles = []string{"role-admin"}
+	cfg.RolePermissions = map[string][]string{
+		"role-editor":    {models.PermissionCardsCreate, models.PermissionCardsUpdate},
+		"role-importer":  {models.PermissionCollectionsImport, models.PermissionCardsUpdate},
+		"role-moderator": {models.PermissionUsersView},
+		"role-auditor":   {models.PermissionAuditView},
+	}
+	o := NewOAuthService(cfg)
+
+	tests := []struct {
+		name      string
+		userID    string
+		roles     []string
+		want      []string
+		wantAdmin bool
+	}{
+		{name: "admin user", userID: "owner", want: models.AllPermissions, wantAdmin: true},
+		{name: "admin role", userID: "u1", roles: []string{"role-admin"}, want: models.AllPermissions, wantAdmin: true},
+		{
+			name:   "one mapped role also grants dashboard access",
+			userID: "u1",
+			roles:  []string{"role-moderator"},
+			want:   []string{models.PermissionAdminRead, models.PermissionUsersView},
+		},
+		{
+			name:   "roles are merged in display order without duplicates",
+			userID: "u1",
+			roles:  []string{"role-importer", "role-editor"},
+			want: []string{models.PermissionAdminRead, models.PermissionCardsCreate, models.PermissionCardsUpdate,
+				models.PermissionCollectionsImport},
+		},
+		// The audit log and process list are not covered by dashboard read access
+		{
+			name:   "audit access is its own permission",
+			userID: "u1",
+			roles:  []string{"role-auditor", "role-editor"},
+			want: []string{models.PermissionAdminRead, models.PermissionCardsCreate, models.PermissionCardsUpdate,
+				models.PermissionAuditView},
+		},
+		{name: "unmapped role", userID: "u1", roles: []string{"role-member"}, want: []string{}},
+		{name: "no roles", userID: "u1", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, admin := o.ResolvePermissions(tt.userID, tt.roles)
+			if admin != tt.wantAdmin || strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ResolvePermissions = %v, %v; want %v, %v", got, admin, tt.want, tt.wantAdmin)
+			}
+		})
+	}
+
+	// Callers get their own copy of the full permission list
+	got, _ := o.ResolvePermissions("owner", nil)
+	got[0] = "tampered"
+	if models.AllPermissions[0] == "tampered" {
+		t.Error("ResolvePermissions returned AllPermissions itself")
+	}
+}
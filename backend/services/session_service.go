@@ -17,6 +17,15 @@ import (
 const (
 	SessionCookieName = "gohye_session"
 	StateCookieName   = "oauth_state"
+
+	// SessionDuration is how long a session lasts from login or renewal
+	SessionDuration = 24 * time.Hour
+	// SessionRenewalWindow is how close to expiry a session is renewed from its refresh token
+	SessionRenewalWindow = 2 * time.Hour
+
+	// sessionLocalsKey holds the session written during the current request so
+	// later handlers see a renewed session rather than the stale cookie
+	sessionLocalsKey = "session"
 )
 
 // SessionService handles user session management
@@ -50,11 +59,12 @@ func (s *SessionService) CreateSession(c *fiber.Ctx, userSession *models.UserSes
 		Name:     SessionCookieName,
 		Value:    signedSession,
 		Path:     "/",
-		MaxAge:   int(24 * time.Hour / time.Second), // 24 hours
+		MaxAge:   int(SessionDuration / time.Second),
 		Secure:   s.config.Environment == "production",
 		HTTPOnly: true,
 		SameSite: "Lax",
 	})
+	c.Locals(sessionLocalsKey, userSession)
 
 	slog.Info("Session created for user",
 		slog.String("user_id", userSession.DiscordID),
@@ -66,6 +76,14 @@ func (s *SessionService) CreateSession(c *fiber.Ctx, userSession *models.UserSes
 
 // GetSession retrieves and validates the user session from the request
 func (s *SessionService) GetSession(c *fiber.Ctx) (*models.UserSession, error) {
+	// Prefer a session renewed or destroyed earlier in this request
+	if current, ok := c.Locals(sessionLocalsKey).(*models.UserSession); ok {
+		if current == nil {
+			return nil, fmt.Errorf("session destroyed")
+		}
+		return current, nil
+	}
+
 	// Get session cookie
 	sessionCookie := c.Cookies(SessionCookieName)
 	if sessionCookie == "" {
@@ -95,6 +113,7 @@ func (s *SessionService) GetSession(c *fiber.Ctx) (*models.UserSession, error) {
 
 // DestroySession removes the session cookie and invalidates the session
 func (s *SessionService) DestroySession(c *fiber.Ctx) {
+	c.Locals(sessionLocalsKey, (*models.UserSession)(nil))
 	c.Cookie(&fiber.Cookie{
 		Name:     SessionCookieName,
 		Value:    "",
@@ -163,12 +182,21 @@ func (s *SessionService) GetAndClearState(c *fiber.Ctx) (string, error) {
 // RefreshSession extends the session expiration time
 func (s *SessionService) RefreshSession(c *fiber.Ctx, userSession *models.UserSession) error {
 	// Update expiration time
-	userSession.ExpiresAt = time.Now().Add(24 * time.Hour)
+	userSession.ExpiresAt = time.Now().Add(SessionDuration)
 
 	// Create new session cookie
 	return s.CreateSession(c, userSession)
 }
 
+// NeedsRenewal reports whether a session is close enough to expiry to be
+// renewed and still has a refresh token to renew it with
+func (s *SessionService) NeedsRenewal(userSession *models.UserSession) bool {
+	if userSession == nil || userSession.RefreshToken == "" {
+		return false
+	}
+	return time.Until(userSession.ExpiresAt) <= SessionRenewalWindow
+}
+
 // signData signs data using HMAC-SHA256
 func (s *SessionService) signData(data []byte) (string, error) {
 	if s.config.Config.Web.SessionKey == "" {
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/backend/config"
+	"github.com/disgoorg/bot-template/backend/models"
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/gofiber/fiber/v2"
+)
+
+func testWebConfig() *config.WebAppConfig {
+	return &config.WebAppConfig{Config: &bottemplate.Config{Web: bottemplate.WebConfig{
+		SessionKey: "test-session-key",
+		OAuth:      bottemplate.OAuthConfig{ClientID: "client", ClientSecret: "secret"},
+	}}}
+}
+
+func TestNeedsRenewal(t *testing.T) {
+	s := NewSessionService(testWebConfig())
+	tests := []struct {
+		name    string
+		session *models.UserSession
+		want    bool
+	}{
+		{"fresh session", &models.UserSession{RefreshToken: "r", ExpiresAt: time.Now().Add(SessionDuration)}, false},
+		{"just outside the window", &models.UserSession{RefreshToken: "r", ExpiresAt: time.Now().Add(SessionRenewalWindow + time.Minute)}, false},
+		{"inside the window", &models.UserSession{RefreshToken: "r", ExpiresAt: time.Now().Add(SessionRenewalWindow - time.Minute)}, true},
+		{"already expired", &models.UserSession{RefreshToken: "r", ExpiresAt: time.Now().Add(-time.Minute)}, true},
+		{"no refresh token", &models.UserSession{ExpiresAt: time.Now().Add(time.Minute)}, false},
+		{"no session", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.NeedsRenewal(tt.session); got != tt.want {
+				t.Errorf("NeedsRenewal = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionCookieKeepsRefreshToken(t *testing.T) {
+	s := NewSessionService(testWebConfig())
+	app := fiber.New()
+	app.Get("/login", func(c *fiber.Ctx) error {
+		return s.CreateSession(c, &models.UserSession{DiscordID: "u1", RefreshToken: "refresh-1", ExpiresAt: time.Now().Add(SessionDuration)})
+	})
+	app.Get("/me", func(c *fiber.Ctx) error {
+		session, err := s.GetSession(c)
+		if err != nil {
+			return c.Status(401).SendString(err.Error())
+		}
+		return c.SendString(session.RefreshToken)
+	})
+	// Within one request, a renewed or destroyed session wins over the cookie sent
+	app.Get("/renew", func(c *fiber.Ctx) error {
+		s.CreateSession(c, &models.UserSession{DiscordID: "u1", RefreshToken: "refresh-2", ExpiresAt: time.Now().Add(SessionDuration)})
+		session, err := s.GetSession(c)
+		if err != nil {
+			return err
+		}
+		return c.SendString(session.RefreshToken)
+	})
+	app.Get("/logout", func(c *fiber.Ctx) error {
+		s.DestroySession(c)
+		if _, err := s.GetSession(c); err == nil {
+			return c.Status(500).SendString("session survived DestroySession")
+		}
+		return c.SendStatus(204)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/login", nil), -1)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	var cookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == SessionCookieName {
+			cookie = c
+		}
+	}
+	if cookie == nil {
+		t.Fatal("no session cookie set")
+	}
+
+	for path, want := range map[string]string{"/me": "refresh-1", "/renew": "refresh-2", "/logout": ""} {
+		req := httptest.NewRequest("GET", path, nil)
+		req.AddCookie(cookie)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		body := make([]byte, 64)
+		n, _ := resp.Body.Read(body)
+		resp.Body.Close()
+		if resp.StatusCode >= 300 || string(body[:n]) != want {
+			t.Errorf("%s = %d %q, want %q", path, resp.StatusCode, body[:n], want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/services"
+)
+
+// SpacesClient is the object storage used by the web handlers. The bot's
+// SpacesService implements it; handlers depend on the interface so they can
+// run against a fake.
+type SpacesClient interface {
+	UploadStream(ctx context.Context, r io.Reader, size int64, path string, contentType string) error
+	DeleteFile(ctx context.Context, path string) error
+	ListObjectKeys(ctx context.Context, prefix string) ([]string, error)
+	GetObjectURL(path string) string
+	GetCardRoot() string
+	GetCardStorageKey(cardName string, colID string, level int, groupType string, animated bool, format string) string
+	GetCardStorageKeys(cardName string, colID string, level int, groupType string, animated bool, format string) []string
+	ObjectExists(ctx context.Context, key string) (bool, error)
+	GetCardImageURLWithFormat(cardName string, colID string, level int, groupType string, animated bool, format string) string
+	GetSignedCardImageURL(key string, ttl time.Duration) (string, error)
+}
+
+var _ SpacesClient = (*services.SpacesService)(nil)
+
+// CardImageStorage is the storage the card management service writes card images to
+type CardImageStorage interface {
+	services.CardImageStore
+	GetCardImageURLWithFormat(cardName string, colID string, level int, groupType string, animated bool, format string) string
+	ManageCardImage(ctx context.Context, operation services.ImageOperation, cardID int64, imageData []byte, card *models.Card) (*services.ImageManagementResult, error)
+	UploadCardImage(ctx context.Context, card *models.Card, imageData []byte, extension string, contentType string) (string, error)
+}
+
+var _ CardImageStorage = (*services.SpacesService)(nil)
@@ -0,0 +1,218 @@
+// Package spacestest provides an in-memory SpacesClient for tests
+package spacestest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/services"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// Fake stores objects in memory. Card images are resolved like the Spaces path cache:
+// the cards directory before promo and the slugified name before the legacy one. Images
+// that aren't stored resolve to the cards directory.
+type Fake struct {
+	CardRoot string
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	// Deleted records every key passed to DeleteFile, in order
+	Deleted []string
+	// Heads counts ObjectExists calls
+	Heads int
+}
+
+// New returns a fake holding the given keys with empty contents
+func New(cardRoot string, keys ...string) *Fake {
+	f := &Fake{
+		CardRoot: cardRoot,
+		objects:  make(map[string][]byte),
+	}
+	for _, key := range keys {
+		f.objects[key] = nil
+	}
+	return f
+}
+
+// Keys returns the stored keys in sorted order
+func (f *Fake) Keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.objects))
+	for key := range f.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Object returns the contents stored at key
+func (f *Fake) Object(key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, ok := f.objects[key]
+	return data, ok
+}
+
+func (f *Fake) UploadStream(ctx context.Context, r io.Reader, size int64, path string, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != size {
+		return fmt.Errorf("read %d bytes, expected %d", len(data), size)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[path] = data
+	return nil
+}
+
+func (f *Fake) put(key string, data []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[key] = data
+}
+
+func (f *Fake) DeleteFile(ctx context.Context, path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Deleted = append(f.Deleted, path)
+	delete(f.objects, path)
+	return nil
+}
+
+func (f *Fake) ListObjectKeys(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for _, key := range f.Keys() {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (f *Fake) GetObjectURL(path string) string {
+	return "https://fake.spaces/" + path
+}
+
+func (f *Fake) GetCardRoot() string {
+	return f.CardRoot
+}
+
+func (f *Fake) GetCardKey(cardName string, colID string, level int, groupType string, animated bool, format string) string {
+	ref, pathType := f.resolve(cardName, colID, level, groupType, animated, format)
+	ref.BaseDir = string(pathType)
+	return services.BuildCardKey(ref)
+}
+
+func (f *Fake) GetCardStorageKey(cardName string, colID string, level int, groupType string, animated bool, format string) string {
+	return f.storageKey(f.resolve(cardName, colID, level, groupType, animated, format))
+}
+
+func (f *Fake) GetCardStorageKeys(cardName string, colID string, level int, groupType string, animated bool, format string) []string {
+	ref, pathType := f.resolve(cardName, colID, level, groupType, animated, format)
+	keys := []string{f.storageKey(ref, pathType)}
+	for _, format := range services.CardImageFormats {
+		variant := ref
+		variant.Animated = false
+		variant.Format = format
+		if key := f.storageKey(variant, pathType); key != keys[0] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// resolve finds the name form and directory a card image is stored under
+func (f *Fake) resolve(cardName string, colID string, level int, groupType string, animated bool, format string) (services.CardImageRef, services.PathType) {
+	ref := services.CardImageRef{
+		GroupType: groupType,
+		ColID:     colID,
+		Name:      cardName,
+		Level:     level,
+		Animated:  animated,
+		Format:    format,
+	}
+	for _, pathType := range []services.PathType{services.PathTypeCards, services.PathTypePromo} {
+		for _, legacy := range []bool{false, true} {
+			ref.LegacyName = legacy
+			if _, ok := f.Object(f.storageKey(ref, pathType)); ok {
+				return ref, pathType
+			}
+		}
+	}
+	ref.LegacyName = false
+	return ref, services.PathTypeCards
+}
+
+// storageKey returns the object key of ref below the card root
+func (f *Fake) storageKey(ref services.CardImageRef, pathType services.PathType) string {
+	ref.BaseDir = f.CardRoot
+	if pathType == services.PathTypePromo {
+		ref.BaseDir = f.CardRoot + "/promo"
+	}
+	return services.BuildCardKey(ref)
+}
+
+func (f *Fake) ObjectExists(ctx context.Context, key string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Heads++
+	_, ok := f.objects[key]
+	return ok, nil
+}
+
+func (f *Fake) GetCardImageURLWithFormat(cardName string, colID string, level int, groupType string, animated bool, format string) string {
+	return f.GetObjectURL(f.GetCardKey(cardName, colID, level, groupType, animated, format))
+}
+
+func (f *Fake) GetSignedCardImageURL(key string, ttl time.Duration) (string, error) {
+	return fmt.Sprintf("%s?ttl=%d", f.GetObjectURL(key), int64(ttl.Seconds())), nil
+}
+
+func (f *Fake) DeleteObject(ctx context.Context, path string) error {
+	return f.DeleteFile(ctx, path)
+}
+
+// ManageCardImage stores uploads and updates at the card's default key
+func (f *Fake) ManageCardImage(ctx context.Context, operation services.ImageOperation, cardID int64, imageData []byte, card *models.Card) (*services.ImageManagementResult, error) {
+	key := f.uploadKey(card, card.Animated, "")
+	if operation == services.ImageOperationUpload || operation == services.ImageOperationUpdate {
+		f.put(key, imageData)
+	}
+	return &services.ImageManagementResult{
+		Operation:    operation,
+		Success:      true,
+		CardName:     card.Name,
+		CollectionID: card.ColID,
+		Level:        card.Level,
+		URL:          f.GetObjectURL(key),
+		Key:          key,
+	}, nil
+}
+
+func (f *Fake) UploadCardImage(ctx context.Context, card *models.Card, imageData []byte, extension string, contentType string) (string, error) {
+	key := f.uploadKey(card, false, extension)
+	f.put(key, imageData)
+	return f.GetObjectURL(key), nil
+}
+
+// uploadKey returns the key card images are uploaded to, the cards directory with the slugified name
+func (f *Fake) uploadKey(card *models.Card, animated bool, format string) string {
+	return f.storageKey(services.CardImageRef{
+		GroupType: utils.GetGroupType(card.Tags),
+		ColID:     card.ColID,
+		Name:      card.Name,
+		Level:     card.Level,
+		Animated:  animated,
+		Format:    format,
+	}, services.PathTypeCards)
+}
@@ -1,25 +1,35 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
 	"log/slog"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 
 	webmodels "github.com/disgoorg/bot-template/backend/models"
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
 	"github.com/disgoorg/bot-template/bottemplate/services"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
 )
 
 // SyncManagerService manages synchronization between database and storage
 type SyncManagerService struct {
 	repos         *webmodels.Repositories
-	spacesService *services.SpacesService
+	spacesService SpacesClient
 }
 
 // NewSyncManagerService creates a new sync manager service
-func NewSyncManagerService(repos *webmodels.Repositories, spacesService *services.SpacesService) *SyncManagerService {
+func NewSyncManagerService(repos *webmodels.Repositories, spacesService SpacesClient) *SyncManagerService {
 	return &SyncManagerService{
 		repos:         repos,
 		spacesService: spacesService,
@@ -79,23 +89,24 @@ func (sms *SyncManagerService) getCollectionSyncStatus(ctx context.Context, coll
 		return nil, fmt.Errorf("failed to get cards for collection: %w", err)
 	}
 
-	// TODO: Implement storage file checking when SpacesService has the necessary methods
-	// For now, we'll create a basic sync status
+	issues := sms.detectSyncIssues(ctx, cards)
+
+	missing := 0
+	for _, issue := range issues {
+		if issue.Type == "missing_file" {
+			missing++
+		}
+	}
 
 	status := &webmodels.SyncStatus{
 		CollectionID:   collectionID,
 		CollectionName: collection.Name,
 		DatabaseCards:  len(cards),
-		StorageFiles:   len(cards), // Placeholder - should check actual storage
-		Status:         "synced",   // Placeholder - should determine actual status
-		Issues:         []webmodels.SyncIssue{},
+		StorageFiles:   len(cards) - missing,
+		Issues:         issues,
 		LastChecked:    time.Now(),
 	}
 
-	// Check for potential issues
-	issues := sms.detectSyncIssues(ctx, cards)
-	status.Issues = issues
-
 	// Determine overall status
 	if len(issues) == 0 {
 		status.Status = "synced"
@@ -119,123 +130,404 @@ func (sms *SyncManagerService) getCollectionSyncStatus(ctx context.Context, coll
 
 // detectSyncIssues detects potential synchronization issues
 func (sms *SyncManagerService) detectSyncIssues(ctx context.Context, cards []*models.Card) []webmodels.SyncIssue {
-	var issues []webmodels.SyncIssue
+	issues := []webmodels.SyncIssue{}
 
-	// TODO: Implement actual sync issue detection
-	// This would involve:
-	// 1. Checking if files exist for each card
-	// 2. Checking for orphaned files
-	// 3. Validating naming conventions
-	// 4. Checking file sizes and formats
+	// Present keys are cached by the Spaces service, so dashboard refreshes
+	// only go back to storage for images that were missing last time
+	for _, card := range cards {
+		key := sms.cardImageKey(card)
+
+		exists, err := sms.spacesService.ObjectExists(ctx, key)
+		if err != nil {
+			slog.Warn("Failed to check card image",
+				slog.Int64("card_id", card.ID),
+				slog.String("key", key),
+				slog.String("error", err.Error()))
+			continue
+		}
+		if !exists {
+			cardID := card.ID
+			issues = append(issues, webmodels.SyncIssue{
+				Type:        "missing_file",
+				Description: fmt.Sprintf("Image for card %s (level %d) is missing from storage", card.Name, card.Level),
+				CardID:      &cardID,
+				FilePath:    key,
+				Severity:    "critical",
+			})
+		}
+	}
 
-	// For now, return empty issues
 	return issues
 }
 
-// FixSyncIssues attempts to fix synchronization issues
-func (sms *SyncManagerService) FixSyncIssues(ctx context.Context, collectionID string) (*webmodels.SyncStatus, error) {
-	slog.Info("Starting sync fix for collection", slog.String("collection_id", collectionID))
+// SyncFixOptions controls how FixSyncIssues treats cards with a missing image
+type SyncFixOptions struct {
+	Mode webmodels.SyncFixMode
+	// Force lets delete_row remove cards that are still owned by users
+	Force bool
+}
+
+// cardImageKey returns the storage key a card's image is expected at, below the card root
+func (sms *SyncManagerService) cardImageKey(card *models.Card) string {
+	groupType := utils.GetGroupType(card.Tags)
+	return sms.spacesService.GetCardStorageKey(card.Name, card.ColID, card.Level, groupType, card.Animated, card.ImageFormat)
+}
+
+// FixSyncIssues checks the image of every card in a collection and handles the
+// missing ones according to the fix mode, reporting the outcome per card
+func (sms *SyncManagerService) FixSyncIssues(ctx context.Context, collectionID string, opts SyncFixOptions) (*webmodels.SyncFixReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = webmodels.SyncFixModeReport
+	}
+
+	slog.Info("Starting sync fix for collection",
+		slog.String("collection_id", collectionID),
+		slog.String("mode", string(opts.Mode)))
+
+	if _, err := sms.repos.Collection.GetByID(ctx, collectionID); err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	cards, err := sms.repos.Card.GetByCollectionID(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards for collection: %w", err)
+	}
+
+	report := &webmodels.SyncFixReport{
+		CollectionID: collectionID,
+		Mode:         opts.Mode,
+		Cards:        make([]webmodels.CardSyncResult, 0, len(cards)),
+	}
+
+	for _, card := range cards {
+		result := sms.fixCardImage(ctx, card, opts)
+		report.CheckedCards++
+		switch result.Status {
+		case webmodels.CardSyncPresent:
+		case webmodels.CardSyncMissing:
+			report.MissingCards++
+		case webmodels.CardSyncFailed:
+			report.FailedCards++
+		default:
+			report.MissingCards++
+			report.FixedCards++
+		}
+		report.Cards = append(report.Cards, result)
+	}
+
+	slog.Info("Sync fix completed",
+		slog.String("collection_id", collectionID),
+		slog.Int("checked_cards", report.CheckedCards),
+		slog.Int("missing_cards", report.MissingCards),
+		slog.Int("fixed_cards", report.FixedCards),
+		slog.Int("failed_cards", report.FailedCards))
+
+	return report, nil
+}
+
+// fixCardImage checks a single card's image and, when it is missing, applies the fix mode
+func (sms *SyncManagerService) fixCardImage(ctx context.Context, card *models.Card, opts SyncFixOptions) webmodels.CardSyncResult {
+	key := sms.cardImageKey(card)
+	result := webmodels.CardSyncResult{
+		CardID:   card.ID,
+		Name:     card.Name,
+		Level:    card.Level,
+		ImageKey: key,
+	}
 
-	// Get current status
-	status, err := sms.getCollectionSyncStatus(ctx, collectionID)
+	exists, err := sms.spacesService.ObjectExists(ctx, key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get sync status: %w", err)
+		result.Status = webmodels.CardSyncFailed
+		result.Error = err.Error()
+		return result
+	}
+	if exists {
+		result.Status = webmodels.CardSyncPresent
+		return result
 	}
 
-	// Process each issue
-	fixedIssues := 0
-	remainingIssues := []webmodels.SyncIssue{}
+	switch opts.Mode {
+	case webmodels.SyncFixModePlaceholder:
+		placeholderKey, err := sms.uploadPlaceholder(ctx, card, key)
+		if err != nil {
+			result.Status = webmodels.CardSyncFailed
+			result.Error = fmt.Sprintf("failed to upload placeholder: %s", err.Error())
+			return result
+		}
+		result.ImageKey = placeholderKey
+		result.Status = webmodels.CardSyncPlaceholderCreated
+	case webmodels.SyncFixModeDeleteRow:
+		// Deleting the row can't be undone, so look for the image again in every format first
+		found, err := sms.findStoredImage(ctx, card)
+		if err != nil {
+			result.Status = webmodels.CardSyncFailed
+			result.Error = err.Error()
+			return result
+		}
+		if found != "" {
+			result.Status = webmodels.CardSyncFailed
+			result.Error = fmt.Sprintf("image found at %s, card was not deleted", found)
+			return result
+		}
 
-	for _, issue := range status.Issues {
-		fixed, err := sms.fixSyncIssue(ctx, issue)
+		// The card has no image, so there is nothing for DeleteCard to remove from storage
+		deletion, err := services.DeleteCard(ctx, sms.repos.Card, nil, card.ID, opts.Force)
 		if err != nil {
-			slog.Error("Failed to fix sync issue",
-				slog.String("issue_type", issue.Type),
-				slog.String("description", issue.Description),
-				slog.String("error", err.Error()))
-			remainingIssues = append(remainingIssues, issue)
-		} else if fixed {
-			fixedIssues++
-			slog.Info("Fixed sync issue",
-				slog.String("issue_type", issue.Type),
-				slog.String("description", issue.Description))
-		} else {
-			remainingIssues = append(remainingIssues, issue)
+			result.Status = webmodels.CardSyncFailed
+			result.Error = err.Error()
+			return result
+		}
+		if !deletion.CardDeleted {
+			result.Status = webmodels.CardSyncFailed
+			result.Error = "card was not deleted"
+			return result
+		}
+		result.Status = webmodels.CardSyncRowDeleted
+	default:
+		result.Status = webmodels.CardSyncMissing
+	}
+
+	return result
+}
+
+// findStoredImage returns the storage key of any stored image of card, checking every
+// format it may be stored in, or "" when there is none
+func (sms *SyncManagerService) findStoredImage(ctx context.Context, card *models.Card) (string, error) {
+	groupType := utils.GetGroupType(card.Tags)
+	for _, key := range sms.spacesService.GetCardStorageKeys(card.Name, card.ColID, card.Level, groupType, card.Animated, card.ImageFormat) {
+		exists, err := sms.spacesService.ObjectExists(ctx, key)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return key, nil
 		}
 	}
+	return "", nil
+}
 
-	// Get updated status
-	updatedStatus, err := sms.getCollectionSyncStatus(ctx, collectionID)
+// uploadPlaceholder stores a placeholder for card at its storage key and returns the key.
+// Formats no placeholder can be encoded in, such as webp, get a jpg placeholder and the
+// card is switched to jpg so it is read from there.
+func (sms *SyncManagerService) uploadPlaceholder(ctx context.Context, card *models.Card, key string) (string, error) {
+	data, contentType, err := placeholderImage(services.CardImageExtension(card.Animated, card.ImageFormat))
+	fallback := errors.Is(err, errPlaceholderFormat)
+	if fallback {
+		key = sms.spacesService.GetCardStorageKey(card.Name, card.ColID, card.Level, utils.GetGroupType(card.Tags), false, "")
+		// A kept original may already be stored as jpg and must not be overwritten
+		exists, existsErr := sms.spacesService.ObjectExists(ctx, key)
+		if existsErr != nil {
+			return "", existsErr
+		}
+		if exists {
+			return "", fmt.Errorf("%s images can't be generated and %s already exists", card.ImageFormat, key)
+		}
+		data, contentType, err = placeholderImage("jpg")
+	}
 	if err != nil {
-		return status, fmt.Errorf("failed to get updated sync status: %w", err)
+		return "", err
 	}
 
-	slog.Info("Sync fix completed",
-		slog.String("collection_id", collectionID),
-		slog.Int("fixed_issues", fixedIssues),
-		slog.Int("remaining_issues", len(remainingIssues)))
+	if err := sms.spacesService.UploadStream(ctx, bytes.NewReader(data), int64(len(data)), key, contentType); err != nil {
+		return "", err
+	}
 
-	return updatedStatus, nil
+	if fallback {
+		card.ImageFormat = ""
+		if err := sms.repos.Card.Update(ctx, card); err != nil {
+			return "", fmt.Errorf("failed to store jpg image format: %w", err)
+		}
+	}
+	return key, nil
 }
 
-// fixSyncIssue attempts to fix a specific sync issue
-func (sms *SyncManagerService) fixSyncIssue(ctx context.Context, issue webmodels.SyncIssue) (bool, error) {
-	switch issue.Type {
-	case "missing_file":
-		return sms.fixMissingFile(ctx, issue)
-	case "orphan_file":
-		return sms.fixOrphanFile(ctx, issue)
-	case "naming_mismatch":
-		return sms.fixNamingMismatch(ctx, issue)
+// Placeholder dimensions match the card art aspect ratio
+const (
+	placeholderWidth  = 300
+	placeholderHeight = 420
+)
+
+// errPlaceholderFormat is returned for formats placeholderImage can't encode
+var errPlaceholderFormat = errors.New("placeholder images are not supported")
+
+// placeholderImage renders a flat grey card-sized image in the given format
+func placeholderImage(ext string) ([]byte, string, error) {
+	img := image.NewPaletted(image.Rect(0, 0, placeholderWidth, placeholderHeight), color.Palette{color.Gray{Y: 0x80}})
+
+	var buf bytes.Buffer
+	var contentType string
+	var err error
+	switch ext {
+	case "jpg", "jpeg":
+		contentType = "image/jpeg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 80})
+	case "png":
+		contentType = "image/png"
+		err = png.Encode(&buf, img)
+	case "gif":
+		contentType = "image/gif"
+		err = gif.Encode(&buf, img, nil)
 	default:
-		return false, fmt.Errorf("unknown issue type: %s", issue.Type)
+		return nil, "", fmt.Errorf("%w: %s", errPlaceholderFormat, ext)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode placeholder: %w", err)
+	}
+	return buf.Bytes(), contentType, nil
+}
+
+// CleanupOptions controls the scope and behavior of CleanupOrphans
+type CleanupOptions struct {
+	// DryRun reports the orphaned keys without deleting them
+	DryRun bool
+	// CollectionID limits the scan to a single collection when set
+	CollectionID string
+}
+
+// CleanupOrphans removes image files from storage that no longer belong to a card
+func (sms *SyncManagerService) CleanupOrphans(ctx context.Context, opts CleanupOptions) (*webmodels.OrphanCleanupReport, error) {
+	slog.Info("Starting orphan cleanup",
+		slog.Bool("dry_run", opts.DryRun),
+		slog.String("collection_id", opts.CollectionID))
+
+	keys, err := sms.listCardImageKeys(ctx, opts.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage files: %w", err)
+	}
+
+	expected, err := sms.expectedImageNames(ctx, opts.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cards: %w", err)
+	}
+
+	report := &webmodels.OrphanCleanupReport{
+		DryRun:       opts.DryRun,
+		CollectionID: opts.CollectionID,
+		ScannedFiles: len(keys),
+		OrphanKeys:   findOrphanKeys(keys, expected),
+		DeletedKeys:  []string{},
+	}
+
+	if opts.DryRun {
+		slog.Info("Orphan cleanup dry run completed",
+			slog.Int("scanned_files", report.ScannedFiles),
+			slog.Int("orphan_count", len(report.OrphanKeys)))
+		return report, nil
+	}
+
+	for _, key := range report.OrphanKeys {
+		if err := sms.spacesService.DeleteFile(ctx, key); err != nil {
+			slog.Error("Failed to delete orphaned file",
+				slog.String("key", key),
+				slog.String("error", err.Error()))
+			if report.FailedKeys == nil {
+				report.FailedKeys = make(map[string]string)
+			}
+			report.FailedKeys[key] = err.Error()
+			continue
+		}
+		report.DeletedKeys = append(report.DeletedKeys, key)
 	}
+
+	slog.Info("Orphan cleanup completed",
+		slog.Int("scanned_files", report.ScannedFiles),
+		slog.Int("cleaned_count", len(report.DeletedKeys)),
+		slog.Int("failed_count", len(report.FailedKeys)))
+	return report, nil
 }
 
-// fixMissingFile attempts to fix missing file issues
-func (sms *SyncManagerService) fixMissingFile(ctx context.Context, issue webmodels.SyncIssue) (bool, error) {
-	// TODO: Implement missing file fix
-	// This could involve:
-	// 1. Generating a placeholder image
-	// 2. Copying from a template
-	// 3. Marking the card as having missing image
-	return false, fmt.Errorf("missing file fix not implemented")
+// listCardImageKeys lists card image keys in storage, optionally scoped to one collection
+func (sms *SyncManagerService) listCardImageKeys(ctx context.Context, collectionID string) ([]string, error) {
+	root := strings.TrimSuffix(sms.spacesService.GetCardRoot(), "/")
+
+	prefixes := []string{root + "/"}
+	if collectionID != "" {
+		prefixes = prefixes[:0]
+		for _, baseDir := range []string{"", "promo/"} {
+			for _, groupType := range []string{"girlgroups", "boygroups"} {
+				prefixes = append(prefixes, fmt.Sprintf("%s/%s%s/%s/", root, baseDir, groupType, collectionID))
+			}
+		}
+	}
+
+	var keys []string
+	for _, prefix := range prefixes {
+		found, err := sms.spacesService.ListObjectKeys(ctx, prefix)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, found...)
+	}
+
+	return keys, nil
 }
 
-// fixOrphanFile attempts to fix orphan file issues
-func (sms *SyncManagerService) fixOrphanFile(ctx context.Context, issue webmodels.SyncIssue) (bool, error) {
-	// TODO: Implement orphan file fix
-	// This could involve:
-	// 1. Deleting the orphan file
-	// 2. Attempting to match it to a card
-	// 3. Moving it to a quarantine folder
-	return false, fmt.Errorf("orphan file fix not implemented")
+// expectedImageNames returns the image base names ("{level}_{name}") each collection should have.
+// Soft-deleted cards count too, so a restored card still has its image.
+func (sms *SyncManagerService) expectedImageNames(ctx context.Context, collectionID string) (map[string]map[string]bool, error) {
+	var cards []*models.Card
+	var err error
+	if collectionID != "" {
+		cards, err = sms.repos.Card.GetByCollectionIDWithDeleted(ctx, collectionID)
+	} else {
+		cards, err = sms.repos.Card.GetAllWithDeleted(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return expectedNamesForCards(cards), nil
+}
+
+// expectedNamesForCards maps each collection to the image base names its cards may be
+// stored under: the slugified name new uploads use and the raw name of older uploads
+func expectedNamesForCards(cards []*models.Card) map[string]map[string]bool {
+	expected := make(map[string]map[string]bool)
+	for _, card := range cards {
+		if expected[card.ColID] == nil {
+			expected[card.ColID] = make(map[string]bool)
+		}
+		expected[card.ColID][fmt.Sprintf("%d_%s", card.Level, services.SlugifyCardName(card.Name))] = true
+		expected[card.ColID][fmt.Sprintf("%d_%s", card.Level, card.Name)] = true
+	}
+	return expected
 }
 
-// fixNamingMismatch attempts to fix naming mismatch issues
-func (sms *SyncManagerService) fixNamingMismatch(ctx context.Context, issue webmodels.SyncIssue) (bool, error) {
-	// TODO: Implement naming mismatch fix
-	// This could involve:
-	// 1. Renaming the file to match the expected pattern
-	// 2. Updating the database to match the file name
-	return false, fmt.Errorf("naming mismatch fix not implemented")
+// findOrphanKeys returns the card image keys that do not match any expected card.
+// Keys that don't follow the "{collection}/{level}_{name}.{ext}" layout are never
+// reported, so unrelated files stored alongside the cards are left untouched.
+func findOrphanKeys(keys []string, expected map[string]map[string]bool) []string {
+	orphans := []string{}
+	for _, key := range keys {
+		colID, baseName, ok := parseCardImageKey(key)
+		if !ok {
+			continue
+		}
+		if !expected[colID][baseName] {
+			orphans = append(orphans, key)
+		}
+	}
+	return orphans
 }
 
-// CleanupOrphans removes orphaned files from storage
-func (sms *SyncManagerService) CleanupOrphans(ctx context.Context) (int, error) {
-	slog.Info("Starting orphan cleanup")
+// parseCardImageKey extracts the collection ID and "{level}_{name}" base name from a storage key
+func parseCardImageKey(key string) (string, string, bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) < 3 {
+		return "", "", false
+	}
 
-	// TODO: Implement orphan cleanup
-	// This would involve:
-	// 1. Listing all files in storage
-	// 2. Checking which files have corresponding database entries
-	// 3. Deleting files that don't have matches
-	// 4. Being careful not to delete legitimate files
+	fileName := parts[len(parts)-1]
+	baseName := strings.TrimSuffix(fileName, path.Ext(fileName))
 
-	cleanedCount := 0
+	idx := strings.Index(baseName, "_")
+	if idx <= 0 || idx == len(baseName)-1 {
+		return "", "", false
+	}
+	if _, err := strconv.Atoi(baseName[:idx]); err != nil {
+		return "", "", false
+	}
 
-	slog.Info("Orphan cleanup completed", slog.Int("cleaned_count", cleanedCount))
-	return cleanedCount, nil
+	return parts[len(parts)-2], baseName, true
 }
 
 // ValidateNamingConventions checks if files follow naming conventions
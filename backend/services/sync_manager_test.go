@@ -0,0 +1,436 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"image"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	webmodels "github.com/disgoorg/bot-template/backend/models"
+	"github.com/disgoorg/bot-template/backend/services/spacestest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// fakeCardRepo serves a fixed card set; methods it doesn't override panic
+type fakeCardRepo struct {
+	repositories.CardRepository
+	cards   []*models.Card
+	updates int
+	owners  map[int64]int // owners per card ID
+	deleted []int64
+}
+
+// GetAll and GetByCollectionID hide soft-deleted cards like the real repository
+func (r *fakeCardRepo) GetAll(ctx context.Context) ([]*models.Card, error) {
+	return r.filter("", false), nil
+}
+
+func (r *fakeCardRepo) GetByCollectionID(ctx context.Context, colID string) ([]*models.Card, error) {
+	return r.filter(colID, false), nil
+}
+
+func (r *fakeCardRepo) GetAllWithDeleted(ctx context.Context) ([]*models.Card, error) {
+	return r.filter("", true), nil
+}
+
+func (r *fakeCardRepo) GetByCollectionIDWithDeleted(ctx context.Context, colID string) ([]*models.Card, error) {
+	return r.filter(colID, true), nil
+}
+
+func (r *fakeCardRepo) filter(colID string, withDeleted bool) []*models.Card {
+	var cards []*models.Card
+	for _, card := range r.cards {
+		if (colID == "" || card.ColID == colID) && (withDeleted || card.DeletedAt.IsZero()) {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+func (r *fakeCardRepo) GetByIDWithDeleted(ctx context.Context, id int64) (*models.Card, error) {
+	for _, card := range r.cards {
+		if card.ID == id {
+			return card, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeCardRepo) CountCopies(ctx context.Context, cardID int64) (int, int64, error) {
+	return r.owners[cardID], int64(r.owners[cardID]), nil
+}
+
+func (r *fakeCardRepo) SafeDelete(ctx context.Context, cardID int64) (*models.DeletionReport, error) {
+	r.deleted = append(r.deleted, cardID)
+	return &models.DeletionReport{CardID: cardID, CardDeleted: true}, nil
+}
+
+func orphanFixture() (*SyncManagerService, *spacestest.Fake) {
+	cards := &fakeCardRepo{cards: []*models.Card{
+		{ID: 1, Name: "Na'Yeon", ColID: "twice", Level: 1, Tags: []string{"girlgroups"}},
+		{ID: 2, Name: "Jeong Yeon", ColID: "twice", Level: 2, Tags: []string{"girlgroups"}},
+		{ID: 3, Name: "Jung Kook", ColID: "bts", Level: 3, Tags: []string{"boygroups"}},
+	}}
+	spaces := spacestest.New("cards",
+		// Slugified key of a punctuated name
+		"cards/girlgroups/twice/1_nayeon.jpg",
+		// Legacy raw-name key
+		"cards/girlgroups/twice/2_Jeong Yeon.jpg",
+		"cards/boygroups/bts/3_jung_kook.webp",
+		// Orphans: a deleted card and a wrong level
+		"cards/girlgroups/twice/1_tzuyu.jpg",
+		"cards/boygroups/bts/5_jung_kook.jpg",
+		// Files outside the card layout are never touched
+		"cards/girlgroups/twice/cover.png",
+	)
+	sms := NewSyncManagerService(&webmodels.Repositories{Card: cards}, spaces)
+	return sms, spaces
+}
+
+func TestCleanupOrphansDryRun(t *testing.T) {
+	sms, spaces := orphanFixture()
+
+	report, err := sms.CleanupOrphans(context.Background(), CleanupOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("CleanupOrphans: %v", err)
+	}
+
+	want := []string{"cards/boygroups/bts/5_jung_kook.jpg", "cards/girlgroups/twice/1_tzuyu.jpg"}
+	got := append([]string(nil), report.OrphanKeys...)
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("orphans = %v, want %v", got, want)
+	}
+	if report.ScannedFiles != 6 {
+		t.Errorf("scanned %d files, want 6", report.ScannedFiles)
+	}
+	if len(report.DeletedKeys) != 0 || len(spaces.Deleted) != 0 {
+		t.Errorf("dry run deleted %v", spaces.Deleted)
+	}
+}
+
+func TestCleanupOrphansDeletes(t *testing.T) {
+	sms, spaces := orphanFixture()
+
+	report, err := sms.CleanupOrphans(context.Background(), CleanupOptions{})
+	if err != nil {
+		t.Fatalf("CleanupOrphans: %v", err)
+	}
+	if len(report.DeletedKeys) != 2 {
+		t.Errorf("deleted %v, want the 2 orphans", report.DeletedKeys)
+	}
+
+	want := []string{
+		"cards/boygroups/bts/3_jung_kook.webp",
+		"cards/girlgroups/twice/1_nayeon.jpg",
+		"cards/girlgroups/twice/2_Jeong Yeon.jpg",
+		"cards/girlgroups/twice/cover.png",
+	}
+	if got := spaces.Keys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("remaining keys = %v, want %v", got, want)
+	}
+}
+
+func TestCleanupOrphansCollectionScope(t *testing.T) {
+	sms, spaces := orphanFixture()
+
+	report, err := sms.CleanupOrphans(context.Background(), CleanupOptions{CollectionID: "bts"})
+	if err != nil {
+		t.Fatalf("CleanupOrphans: %v", err)
+	}
+	if !reflect.DeepEqual(report.DeletedKeys, []string{"cards/boygroups/bts/5_jung_kook.jpg"}) {
+		t.Errorf("deleted %v, want only the bts orphan", report.DeletedKeys)
+	}
+	if _, ok := spaces.Object("cards/girlgroups/twice/1_tzuyu.jpg"); !ok {
+		t.Error("orphan outside the scoped collection was deleted")
+	}
+}
+
+func TestFindOrphanKeys(t *testing.T) {
+	cards := []*models.Card{
+		{ID: 1, Name: "Na'Yeon", ColID: "twice", Level: 1},
+		{ID: 2, Name: "Jeong Yeon", ColID: "twice", Level: 2},
+		{ID: 3, Name: "Jung Kook", ColID: "bts", Level: 3},
+	}
+	// A Spaces listing holding every card's image plus known orphans
+	keys := []string{
+		// Slugified key of a punctuated name
+		"cards/girlgroups/twice/1_nayeon.jpg",
+		// Legacy raw-name key
+		"cards/girlgroups/twice/2_Jeong Yeon.jpg",
+		"cards/boygroups/bts/3_jung_kook.webp",
+		// Orphans: a deleted card and a wrong level
+		"cards/girlgroups/twice/1_tzuyu.jpg",
+		"cards/boygroups/bts/5_jung_kook.jpg",
+		// Files outside the card layout are never reported
+		"cards/girlgroups/twice/cover.png",
+	}
+
+	got := findOrphanKeys(keys, expectedNamesForCards(cards))
+	want := []string{"cards/girlgroups/twice/1_tzuyu.jpg", "cards/boygroups/bts/5_jung_kook.jpg"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("orphans = %v, want %v", got, want)
+	}
+}
+
+func TestExpectedImageNamesKeepSoftDeletedCards(t *testing.T) {
+	cards := &fakeCardRepo{cards: []*models.Card{
+		{ID: 1, Name: "Na'Yeon", ColID: "twice", Level: 1, Tags: []string{"girlgroups"}},
+		{ID: 2, Name: "Mina", ColID: "twice", Level: 2, Tags: []string{"girlgroups"}, DeletedAt: time.Now()},
+	}}
+	sms := NewSyncManagerService(&webmodels.Repositories{Card: cards}, nil)
+	keys := []string{"cards/girlgroups/twice/1_nayeon.jpg", "cards/girlgroups/twice/2_mina.jpg"}
+
+	// The soft-deleted card can still be restored, so its image is not an orphan
+	for _, colID := range []string{"", "twice"} {
+		expected, err := sms.expectedImageNames(context.Background(), colID)
+		if err != nil {
+			t.Fatalf("expectedImageNames(%q): %v", colID, err)
+		}
+		if orphans := findOrphanKeys(keys, expected); len(orphans) != 0 {
+			t.Errorf("expectedImageNames(%q) left orphans %v", colID, orphans)
+		}
+	}
+}
+
+func TestGetCollectionSyncStatusReportsMissingImages(t *testing.T) {
+	cards := &fakeCardRepo{cards: []*models.Card{
+		{ID: 1, Name: "Na'Yeon", ColID: "twice", Level: 1, Tags: []string{"girlgroups"}},
+		{ID: 2, Name: "Momo", ColID: "twice", Level: 3, Tags: []string{"girlgroups"}},
+	}}
+	spaces := spacestest.New("cards", "cards/girlgroups/twice/1_nayeon.jpg")
+	sms := NewSyncManagerService(&webmodels.Repositories{
+		Card:       cards,
+		Collection: &fakeCollectionRepo{collections: map[string]*models.Collection{"twice": {ID: "twice", Name: "Twice"}}},
+	}, spaces)
+
+	status, err := sms.getCollectionSyncStatus(context.Background(), "twice")
+	if err != nil {
+		t.Fatalf("getCollectionSyncStatus: %v", err)
+	}
+	if status.Status != "inconsistent" || status.DatabaseCards != 2 || status.StorageFiles != 1 {
+		t.Errorf("status = %s with %d cards and %d files, want inconsistent, 2, 1", status.Status, status.DatabaseCards, status.StorageFiles)
+	}
+	if len(status.Issues) != 1 {
+		t.Fatalf("issues = %+v, want 1", status.Issues)
+	}
+	issue := status.Issues[0]
+	if issue.Type != "missing_file" || issue.CardID == nil || *issue.CardID != 2 || issue.FilePath != "cards/girlgroups/twice/3_momo.jpg" {
+		t.Errorf("issue = %+v, want card 2 missing", issue)
+	}
+	if spaces.Heads != 2 {
+		t.Errorf("%d existence checks, want one per card", spaces.Heads)
+	}
+
+	spaces.UploadStream(context.Background(), strings.NewReader(""), 0, "cards/girlgroups/twice/3_momo.jpg", "image/jpeg")
+	if status, _ := sms.getCollectionSyncStatus(context.Background(), "twice"); status.Status != "synced" || len(status.Issues) != 0 {
+		t.Errorf("after upload: status = %s with issues %+v, want synced", status.Status, status.Issues)
+	}
+}
+
+func TestGetCollectionSyncStatusFindsPromoImages(t *testing.T) {
+	cards := &fakeCardRepo{cards: []*models.Card{
+		{ID: 1, Name: "Momo", ColID: "xmas", Level: 5, Tags: []string{"girlgroups"}},
+	}}
+	// Promo images are stored below the card root, not at the promo URL key
+	spaces := spacestest.New("cards", "cards/promo/girlgroups/xmas/5_momo.jpg", "promo/girlgroups/xmas/6_momo.jpg")
+	sms := NewSyncManagerService(&webmodels.Repositories{
+		Card:       cards,
+		Collection: &fakeCollectionRepo{collections: map[string]*models.Collection{"xmas": {ID: "xmas", Name: "Xmas"}}},
+	}, spaces)
+
+	status, err := sms.getCollectionSyncStatus(context.Background(), "xmas")
+	if err != nil {
+		t.Fatalf("getCollectionSyncStatus: %v", err)
+	}
+	if status.Status != "synced" || len(status.Issues) != 0 {
+		t.Errorf("status = %s with issues %+v, want synced", status.Status, status.Issues)
+	}
+
+	cards.cards = append(cards.cards, &models.Card{ID: 2, Name: "Momo", ColID: "xmas", Level: 6, Tags: []string{"girlgroups"}})
+	status, err = sms.getCollectionSyncStatus(context.Background(), "xmas")
+	if err != nil {
+		t.Fatalf("getCollectionSyncStatus: %v", err)
+	}
+	if len(status.Issues) != 1 || *status.Issues[0].CardID != 2 {
+		t.Errorf("issues = %+v, want card 2 missing despite the object at its URL key", status.Issues)
+	}
+}
+
+func TestFixSyncIssuesModes(t *testing.T) {
+	const momoKey = "cards/girlgroups/twice/3_momo.jpg"
+	tests := []struct {
+		mode        webmodels.SyncFixMode
+		force       bool
+		wantStatus  string
+		wantFixed   int
+		wantFailed  int
+		wantUpload  bool
+		wantDeleted []int64
+	}{
+		{mode: "", wantStatus: webmodels.CardSyncMissing},
+		{mode: webmodels.SyncFixModeReport, wantStatus: webmodels.CardSyncMissing},
+		{mode: webmodels.SyncFixModePlaceholder, wantStatus: webmodels.CardSyncPlaceholderCreated, wantFixed: 1, wantUpload: true},
+		{mode: webmodels.SyncFixModeDeleteRow, wantStatus: webmodels.CardSyncFailed, wantFailed: 1},
+		{mode: webmodels.SyncFixModeDeleteRow, force: true, wantStatus: webmodels.CardSyncRowDeleted, wantFixed: 1, wantDeleted: []int64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s force=%t", tt.mode, tt.force), func(t *testing.T) {
+			cards := &fakeCardRepo{
+				cards: []*models.Card{
+					{ID: 1, Name: "Na'Yeon", ColID: "twice", Level: 1, Tags: []string{"girlgroups"}},
+					{ID: 2, Name: "Momo", ColID: "twice", Level: 3, Tags: []string{"girlgroups"}},
+				},
+				owners: map[int64]int{2: 1},
+			}
+			spaces := spacestest.New("cards", "cards/girlgroups/twice/1_nayeon.jpg")
+			sms := NewSyncManagerService(&webmodels.Repositories{
+				Card:       cards,
+				Collection: &fakeCollectionRepo{collections: map[string]*models.Collection{"twice": {ID: "twice"}}},
+			}, spaces)
+
+			report, err := sms.FixSyncIssues(context.Background(), "twice", SyncFixOptions{Mode: tt.mode, Force: tt.force})
+			if err != nil {
+				t.Fatalf("FixSyncIssues: %v", err)
+			}
+			if report.CheckedCards != 2 || report.FixedCards != tt.wantFixed || report.FailedCards != tt.wantFailed {
+				t.Errorf("checked %d, fixed %d, failed %d, want 2, %d, %d",
+					report.CheckedCards, report.FixedCards, report.FailedCards, tt.wantFixed, tt.wantFailed)
+			}
+			if got := report.Cards[0].Status; got != webmodels.CardSyncPresent {
+				t.Errorf("card 1 status = %s, want present", got)
+			}
+			if got := report.Cards[1]; got.Status != tt.wantStatus || got.ImageKey != momoKey {
+				t.Errorf("card 2 = %+v, want %s at %s", got, tt.wantStatus, momoKey)
+			}
+
+			data, uploaded := spaces.Object(momoKey)
+			if uploaded != tt.wantUpload {
+				t.Errorf("placeholder uploaded = %t, want %t", uploaded, tt.wantUpload)
+			}
+			if uploaded {
+				if _, format, err := image.DecodeConfig(bytes.NewReader(data)); err != nil || format != "jpeg" {
+					t.Errorf("placeholder is %s, %v, want a jpeg", format, err)
+				}
+			}
+			if !reflect.DeepEqual(cards.deleted, tt.wantDeleted) {
+				t.Errorf("deleted rows = %v, want %v", cards.deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestFixSyncIssuesPromoImages(t *testing.T) {
+	cards := &fakeCardRepo{
+		cards: []*models.Card{
+			{ID: 1, Name: "Momo", ColID: "xmas", Level: 5, Tags: []string{"girlgroups"}},
+			{ID: 2, Name: "Sana", ColID: "xmas", Level: 5, Tags: []string{"girlgroups"}},
+		},
+	}
+	spaces := spacestest.New("cards", "cards/promo/girlgroups/xmas/5_momo.jpg")
+	sms := NewSyncManagerService(&webmodels.Repositories{
+		Card:       cards,
+		Collection: &fakeCollectionRepo{collections: map[string]*models.Collection{"xmas": {ID: "xmas"}}},
+	}, spaces)
+
+	report, err := sms.FixSyncIssues(context.Background(), "xmas", SyncFixOptions{Mode: webmodels.SyncFixModeDeleteRow, Force: true})
+	if err != nil {
+		t.Fatalf("FixSyncIssues: %v", err)
+	}
+	if got := report.Cards[0]; got.Status != webmodels.CardSyncPresent || got.ImageKey != "cards/promo/girlgroups/xmas/5_momo.jpg" {
+		t.Errorf("promo card = %+v, want present at its storage key", got)
+	}
+	if !reflect.DeepEqual(cards.deleted, []int64{2}) {
+		t.Errorf("deleted rows = %v, want only the card without an image", cards.deleted)
+	}
+}
+
+func TestFixSyncIssuesDeleteRowKeepsOtherFormats(t *testing.T) {
+	cards := &fakeCardRepo{
+		cards: []*models.Card{{ID: 1, Name: "Mina", ColID: "twice", Level: 4, Tags: []string{"girlgroups"}, ImageFormat: "webp"}},
+	}
+	// The webp image is gone but the original kept next to it is not
+	spaces := spacestest.New("cards", "cards/girlgroups/twice/4_mina.png")
+	sms := NewSyncManagerService(&webmodels.Repositories{
+		Card:       cards,
+		Collection: &fakeCollectionRepo{collections: map[string]*models.Collection{"twice": {ID: "twice"}}},
+	}, spaces)
+
+	report, err := sms.FixSyncIssues(context.Background(), "twice", SyncFixOptions{Mode: webmodels.SyncFixModeDeleteRow, Force: true})
+	if err != nil {
+		t.Fatalf("FixSyncIssues: %v", err)
+	}
+	if got := report.Cards[0]; got.Status != webmodels.CardSyncFailed || !strings.Contains(got.Error, "4_mina.png") {
+		t.Errorf("card = %+v, want failed pointing at the stored original", got)
+	}
+	if len(cards.deleted) != 0 {
+		t.Errorf("deleted rows = %v, want none", cards.deleted)
+	}
+}
+
+func TestFixSyncIssuesWebPPlaceholder(t *testing.T) {
+	newFixture := func() (*SyncManagerService, *fakeCardRepo, *spacestest.Fake) {
+		cards := &fakeCardRepo{
+			cards: []*models.Card{{ID: 1, Name: "Mina", ColID: "twice", Level: 4, Tags: []string{"girlgroups"}, ImageFormat: "webp"}},
+		}
+		spaces := spacestest.New("cards")
+		sms := NewSyncManagerService(&webmodels.Repositories{
+			Card:       cards,
+			Collection: &fakeCollectionRepo{collections: map[string]*models.Collection{"twice": {ID: "twice"}}},
+		}, spaces)
+		return sms, cards, spaces
+	}
+
+	t.Run("falls back to jpg", func(t *testing.T) {
+		sms, cards, spaces := newFixture()
+
+		report, err := sms.FixSyncIssues(context.Background(), "twice", SyncFixOptions{Mode: webmodels.SyncFixModePlaceholder})
+		if err != nil {
+			t.Fatalf("FixSyncIssues: %v", err)
+		}
+		const jpgKey = "cards/girlgroups/twice/4_mina.jpg"
+		if got := report.Cards[0]; got.Status != webmodels.CardSyncPlaceholderCreated || got.ImageKey != jpgKey {
+			t.Errorf("card = %+v, want a placeholder at %s", got, jpgKey)
+		}
+		if !reflect.DeepEqual(spaces.Keys(), []string{jpgKey}) {
+			t.Errorf("stored keys = %v, want only the jpg placeholder", spaces.Keys())
+		}
+		if card := cards.cards[0]; card.ImageFormat != "" || cards.updates != 1 {
+			t.Errorf("image format = %q after %d updates, want jpg stored once", card.ImageFormat, cards.updates)
+		}
+	})
+
+	t.Run("never overwrites a stored jpg", func(t *testing.T) {
+		sms, cards, spaces := newFixture()
+		spaces.UploadStream(context.Background(), strings.NewReader("original"), 8, "cards/girlgroups/twice/4_mina.jpg", "image/jpeg")
+
+		report, err := sms.FixSyncIssues(context.Background(), "twice", SyncFixOptions{Mode: webmodels.SyncFixModePlaceholder})
+		if err != nil {
+			t.Fatalf("FixSyncIssues: %v", err)
+		}
+		if got := report.Cards[0].Status; got != webmodels.CardSyncFailed {
+			t.Errorf("status = %s, want failed", got)
+		}
+		if data, _ := spaces.Object("cards/girlgroups/twice/4_mina.jpg"); string(data) != "original" {
+			t.Errorf("stored jpg = %q, want the original", data)
+		}
+		if cards.updates != 0 {
+			t.Errorf("%d card updates, want none", cards.updates)
+		}
+	})
+}
+
+func TestFixSyncIssuesUnknownCollection(t *testing.T) {
+	sms := NewSyncManagerService(&webmodels.Repositories{Collection: &fakeCollectionRepo{}}, spacestest.New("cards"))
+	if _, err := sms.FixSyncIssues(context.Background(), "itzy", SyncFixOptions{}); err == nil {
+		t.Error("no error for an unknown collection")
+	}
+}
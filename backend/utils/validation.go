@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"mime/multipart"
+	"net/http"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -22,8 +23,57 @@ var (
 
 	// ValidCollectionIDRegex validates collection IDs
 	ValidCollectionIDRegex = regexp.MustCompile(`^[a-zA-Z0-9\-_]+$`)
+
+	// ImageContentTypesByExtension maps allowed image extensions to their sniffed content type
+	ImageContentTypesByExtension = map[string]string{
+		".jpg":  "image/jpeg",
+		".jpeg": "image/jpeg",
+		".png":  "image/png",
+		".gif":  "image/gif",
+		".webp": "image/webp",
+	}
 )
 
+// sniffLen is the number of leading bytes http.DetectContentType considers
+const sniffLen = 512
+
+// DetectImageContentType sniffs the real content type of an uploaded image from its
+// leading bytes instead of trusting the client-sent header. It returns an error if the
+// content is not an allowed image type or doesn't match the file extension.
+func DetectImageContentType(filename string, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", fmt.Errorf("file is empty")
+	}
+
+	head := data
+	if len(head) > sniffLen {
+		head = head[:sniffLen]
+	}
+	detected := http.DetectContentType(head)
+
+	allowed := false
+	for _, contentType := range ImageContentTypesByExtension {
+		if detected == contentType {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", fmt.Errorf("file content is %s, only images are allowed", detected)
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	expected, ok := ImageContentTypesByExtension[ext]
+	if !ok {
+		return "", fmt.Errorf("unsupported file extension %q", ext)
+	}
+	if expected != detected {
+		return "", fmt.Errorf("file content is %s but extension %s expects %s", detected, ext, expected)
+	}
+
+	return detected, nil
+}
+
 // ValidateCardCreateRequest validates a card creation request
 func ValidateCardCreateRequest(req *models.CardCreateRequest) []models.ValidationError {
 	var errors []models.ValidationError
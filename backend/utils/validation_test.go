@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	return img
+}
+
+func encodePNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage()); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(), nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeGIF(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := gif.Encode(&buf, testImage(), nil); err != nil {
+		t.Fatalf("encode gif: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetectImageContentType(t *testing.T) {
+	pngData := encodePNG(t)
+	jpegData := encodeJPEG(t)
+	gifData := encodeGIF(t)
+
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		want     string
+		wantErr  string
+	}{
+		{name: "png", filename: "nayeon.png", data: pngData, want: "image/png"},
+		{name: "jpg", filename: "nayeon.jpg", data: jpegData, want: "image/jpeg"},
+		{name: "jpeg extension", filename: "nayeon.JPEG", data: jpegData, want: "image/jpeg"},
+		{name: "gif", filename: "nayeon.gif", data: gifData, want: "image/gif"},
+		{name: "png renamed to jpg", filename: "nayeon.jpg", data: pngData, wantErr: "extension .jpg expects image/jpeg"},
+		{name: "jpeg renamed to png", filename: "nayeon.png", data: jpegData, wantErr: "extension .png expects image/png"},
+		{name: "html disguised as an image", filename: "nayeon.jpg", data: []byte("<html><script>alert(1)</script></html>"), wantErr: "only images are allowed"},
+		{name: "unsupported extension", filename: "nayeon.bmp", data: pngData, wantErr: "unsupported file extension"},
+		{name: "empty file", filename: "nayeon.png", data: nil, wantErr: "file is empty"},
+		// A read cut short after the signature still sniffs as the real type
+		{name: "truncated after signature", filename: "nayeon.png", data: pngData[:8], want: "image/png"},
+		{name: "truncated png renamed to jpg", filename: "nayeon.jpg", data: pngData[:8], wantErr: "expects image/jpeg"},
+		// A read cut short inside the signature has nothing left to identify
+		{name: "truncated inside signature", filename: "nayeon.png", data: pngData[:4], wantErr: "only images are allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectImageContentType(tt.filename, tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DetectImageContentType(%q) error = %v, want it to contain %q", tt.filename, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DetectImageContentType(%q): %v", tt.filename, err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectImageContentType(%q) = %q, want %q", tt.filename, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectImageContentTypeOnlySniffsTheHead(t *testing.T) {
+	// Trailing bytes past the sniffed head must not change the verdict
+	data := append(encodePNG(t), bytes.Repeat([]byte("<html>"), 200)...)
+	got, err := DetectImageContentType("nayeon.png", data)
+	if err != nil {
+		t.Fatalf("DetectImageContentType: %v", err)
+	}
+	if got != "image/png" {
+		t.Errorf("DetectImageContentType = %q, want image/png", got)
+	}
+}
@@ -2,6 +2,7 @@ package bottemplate
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/disgoorg/bot-template/bottemplate/economy/auction"
 	"github.com/disgoorg/bot-template/bottemplate/economy/claim"
 	"github.com/disgoorg/bot-template/bottemplate/economy/effects"
+	"github.com/disgoorg/bot-template/bottemplate/economy/vials"
 	"github.com/disgoorg/bot-template/bottemplate/services"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo"
@@ -53,6 +55,7 @@ type Bot struct {
 	PriceCalculator          *economy.PriceCalculator
 	AuctionManager           *auction.Manager
 	ClaimManager             *claim.Manager
+	LiquefyUndo              *vials.UndoStore
 	ClaimRepository          repositories.ClaimRepository
 	EconomyStatsRepository   repositories.EconomyStatsRepository
 	StartTime                time.Time
@@ -64,6 +67,18 @@ type Bot struct {
 	QuestRepository          repositories.QuestRepository
 	QuestService             *services.QuestService
 	QuestTracker             *services.QuestTracker
+	GuildCommandGate         *services.GuildCommandGate
+	AdminAuditRepository     repositories.AdminAuditRepository
+	CollectionProgressRepo   repositories.CollectionProgressRepository
+	CompletionRewardRepo     repositories.CompletionRewardRepository
+	CardSupplyRepository     repositories.CardSupplyRepository
+	CurrencyLedgerRepository repositories.CurrencyLedgerRepository
+	TransferCounterRepo      repositories.TransferCounterRepository
+	CardNameIndex            *services.CardNameIndex
+	UserService              *services.UserService
+	BanChecker               *services.BanChecker
+	CardOwnership            *services.CardOwnershipCache
+	VoteRewards              *services.VoteRewardService
 }
 
 // GetQuestTracker returns the quest tracker instance
@@ -111,12 +126,24 @@ func (b *Bot) OnReady(event *events.Ready) {
 	}
 }
 
+// ReloadCollections refetches every collection and swaps it into the shared
+// collection cache used by the claim, forge, liquefy and promo checks, so
+// collections added or edited elsewhere apply without a restart
+func (b *Bot) ReloadCollections(ctx context.Context) (int, error) {
+	collections, err := b.CollectionRepository.GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load collections: %w", err)
+	}
+	utils.RefreshCollectionCache(collections)
+	return len(collections), nil
+}
+
 // Shutdown gracefully shuts down the bot and all background processes
 func (b *Bot) Shutdown(ctx context.Context) error {
 	slog.Info("Initiating bot shutdown...")
 
-	// Stop all background processes first
-	if err := b.BackgroundProcessManager.Shutdown(10 * time.Second); err != nil {
+	// Stop all background processes first, waiting for them within the shutdown deadline
+	if err := b.BackgroundProcessManager.Shutdown(ctx); err != nil {
 		slog.Error("Failed to shutdown background processes", slog.Any("error", err))
 	}
 
@@ -0,0 +1,59 @@
+package bottemplate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+type reloadCollectionRepo struct {
+	repositories.CollectionRepository
+	collections []*models.Collection
+	err         error
+}
+
+func (r *reloadCollectionRepo) GetAll(ctx context.Context) ([]*models.Collection, error) {
+	return r.collections, r.err
+}
+
+func TestReloadCollectionsRefreshesPromoFlags(t *testing.T) {
+	t.Cleanup(func() { utils.RefreshCollectionCache(nil) })
+	utils.RefreshCollectionCache([]*models.Collection{
+		{ID: "twice"},
+		{ID: "retired"},
+	})
+
+	repo := &reloadCollectionRepo{collections: []*models.Collection{
+		{ID: "twice", Promo: true},
+		{ID: "aespa", Fragments: true},
+	}}
+	b := &Bot{CollectionRepository: repo}
+	ctx := context.Background()
+
+	loaded, err := b.ReloadCollections(ctx)
+	if err != nil || loaded != 2 {
+		t.Fatalf("ReloadCollections = %d, %v, want 2", loaded, err)
+	}
+	if info, ok := utils.GetCollectionInfo("twice"); !ok || !info.IsPromo {
+		t.Errorf("twice = %+v, %t, want it marked as promo", info, ok)
+	}
+	if info, ok := utils.GetCollectionInfo("aespa"); !ok || !info.IsFragments {
+		t.Errorf("aespa = %+v, %t, want the new fragment collection", info, ok)
+	}
+	if _, ok := utils.GetCollectionInfo("retired"); ok {
+		t.Error("removed collection is still cached")
+	}
+
+	// A failed load keeps the cache it had
+	repo.err = errors.New("database down")
+	if _, err := b.ReloadCollections(ctx); err == nil {
+		t.Fatal("ReloadCollections succeeded without collections")
+	}
+	if n := utils.GetCollectionCacheSize(); n != 2 {
+		t.Errorf("cache size after a failed reload = %d, want 2", n)
+	}
+}
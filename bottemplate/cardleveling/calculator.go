@@ -14,6 +14,9 @@ func NewCalculator(config *Config) *Calculator {
 }
 
 func (c *Calculator) CalculateExpRequirement(level int) int64 {
+	if level >= 1 && level <= len(c.config.ExpCurve) {
+		return c.config.ExpCurve[level-1]
+	}
 	baseExp := c.config.BaseExpRequirements[level]
 	return int64(float64(baseExp) * math.Pow(1.5, float64(level-1)))
 }
@@ -54,13 +57,7 @@ func (c *Calculator) CalculateExpGain(level int, stats *CardLevelingStats) *ExpG
 }
 
 func (c *Calculator) CalculateFinalExp(config *ExpGainConfig) int64 {
-	baseExp := float64(config.BaseGain)
-
-	// Apply all multipliers
-	finalExp := baseExp *
-		config.LevelPenalty *
-		config.ActivityBonus *
-		config.TimeBonus
+	finalExp := c.expBeforeCritical(config)
 
 	// Critical exp chance
 	if rand.Float64() < c.config.CriticalExpChance {
@@ -69,3 +66,15 @@ func (c *Calculator) CalculateFinalExp(config *ExpGainConfig) int64 {
 
 	return int64(math.Max(1, finalExp))
 }
+
+// CalculateExpectedExp is the EXP a gain yields without a critical hit
+func (c *Calculator) CalculateExpectedExp(config *ExpGainConfig) int64 {
+	return int64(math.Max(1, c.expBeforeCritical(config)))
+}
+
+func (c *Calculator) expBeforeCritical(config *ExpGainConfig) float64 {
+	return float64(config.BaseGain) *
+		config.LevelPenalty *
+		config.ActivityBonus *
+		config.TimeBonus
+}
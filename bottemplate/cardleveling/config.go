@@ -1,11 +1,21 @@
 package cardleveling
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+// MaxLevel is the highest level a card can be leveled to
+const MaxLevel = 5
 
 type Config struct {
 	// Base EXP requirements per level
 	BaseExpRequirements map[int]int64
 
+	// ExpCurve, when set, is the exact EXP needed to leave each level: index 0
+	// is level 1 -> 2. It replaces BaseExpRequirements and their scaling.
+	ExpCurve []int64
+
 	// EXP gain multipliers
 	ExpMultipliers struct {
 		Level1 float64
@@ -52,3 +62,26 @@ func NewDefaultConfig() *Config {
 		ComboBonus:         0.05, // Reduced from 0.1
 	}
 }
+
+// DefaultExpCurve returns the EXP needed to leave each level under the default config
+func DefaultExpCurve() []int64 {
+	calc := NewCalculator(NewDefaultConfig())
+	curve := make([]int64, MaxLevel-1)
+	for level := 1; level < MaxLevel; level++ {
+		curve[level-1] = calc.CalculateExpRequirement(level)
+	}
+	return curve
+}
+
+// ValidateExpCurve checks that a configured curve has a positive requirement for every level below MaxLevel
+func ValidateExpCurve(curve []int64) error {
+	if len(curve) != MaxLevel-1 {
+		return fmt.Errorf("exp curve needs %d entries, one per level below %d, got %d", MaxLevel-1, MaxLevel, len(curve))
+	}
+	for i, exp := range curve {
+		if exp <= 0 {
+			return fmt.Errorf("exp curve entry for level %d must be positive, got %d", i+1, exp)
+		}
+	}
+	return nil
+}
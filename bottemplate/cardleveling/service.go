@@ -95,6 +95,36 @@ func (s *Service) GainExpWithModifier(ctx context.Context, userCard *models.User
 	return result, nil
 }
 
+// Preview estimates the EXP and number of level ups a card needs for its next
+// level. modifyExp is applied to the expected gain, so active EXP boosts count.
+func (s *Service) Preview(userCard *models.UserCard, modifyExp func(int64) int64) (*LevelPreview, error) {
+	if userCard == nil || userCard.UserID == "" || userCard.CardID == 0 {
+		return nil, errors.New("card not found")
+	}
+
+	preview := &LevelPreview{
+		Level:      userCard.Level,
+		CurrentExp: userCard.Exp,
+	}
+	if userCard.Level >= MaxLevel {
+		preview.MaxLevel = true
+		return preview, nil
+	}
+
+	preview.RequiredExp = s.calculator.CalculateExpRequirement(userCard.Level)
+	preview.RemainingExp = max(preview.RequiredExp-userCard.Exp, 0)
+
+	stats := s.getCardStats(userCard.UserID, userCard.CardID)
+	expPerLevelUp := s.calculator.CalculateExpectedExp(s.calculator.CalculateExpGain(userCard.Level, stats))
+	if modifyExp != nil {
+		expPerLevelUp = modifyExp(expPerLevelUp)
+	}
+	preview.ExpPerLevelUp = max(expPerLevelUp, 1)
+	preview.LevelUpsNeeded = (preview.RemainingExp + preview.ExpPerLevelUp - 1) / preview.ExpPerLevelUp
+
+	return preview, nil
+}
+
 func (s *Service) CombineCards(ctx context.Context, mainCard, fodderCard *models.UserCard) (*LevelingResult, error) {
 	if mainCard == nil || fodderCard == nil {
 		return nil, errors.New("card not found")
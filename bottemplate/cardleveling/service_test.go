@@ -0,0 +1,131 @@
+package cardleveling
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// levelCardRepo serves one card and records the user card updates
+type levelCardRepo struct {
+	repositories.CardRepository
+	card    *models.Card
+	updated []models.UserCard
+}
+
+func (r *levelCardRepo) GetByID(ctx context.Context, id int64) (*models.Card, error) {
+	return r.card, nil
+}
+
+func (r *levelCardRepo) UpdateUserCard(ctx context.Context, userCard *models.UserCard) error {
+	r.updated = append(r.updated, *userCard)
+	return nil
+}
+
+func curveService(curve []int64) (*Service, *levelCardRepo) {
+	cfg := NewDefaultConfig()
+	cfg.ExpCurve = curve
+	repo := &levelCardRepo{card: &models.Card{ID: 7, Name: "momo", ColID: "twice", Level: 2}}
+	return NewService(cfg, repo), repo
+}
+
+// fixedExp replaces the random gain so a test controls exactly where the card lands
+func fixedExp(exp int64) func(int64) int64 {
+	return func(int64) int64 { return exp }
+}
+
+func TestGainExpAtLevelBoundary(t *testing.T) {
+	tests := []struct {
+		name      string
+		startExp  int64
+		gain      int64
+		wantLevel int
+		wantExp   int64
+	}{
+		{name: "one short", startExp: 148, gain: 1, wantLevel: 2, wantExp: 149},
+		{name: "exactly the requirement", startExp: 149, gain: 1, wantLevel: 3, wantExp: 0},
+		{name: "overshoot resets", startExp: 100, gain: 500, wantLevel: 3, wantExp: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo := curveService([]int64{100, 150, 300, 600})
+			userCard := &models.UserCard{UserID: "u1", CardID: 7, Level: 2, Amount: 1, Exp: tt.startExp}
+
+			result, err := s.GainExpWithModifier(context.Background(), userCard, fixedExp(tt.gain))
+			if err != nil {
+				t.Fatalf("GainExpWithModifier: %v", err)
+			}
+			if result.NewLevel != tt.wantLevel || userCard.Exp != tt.wantExp {
+				t.Errorf("level %d with %d exp, want level %d with %d", result.NewLevel, userCard.Exp, tt.wantLevel, tt.wantExp)
+			}
+			wantRequired := int64(150)
+			if tt.wantLevel == 3 {
+				wantRequired = 300
+			}
+			if result.RequiredExp != wantRequired {
+				t.Errorf("RequiredExp = %d, want %d from the curve", result.RequiredExp, wantRequired)
+			}
+			if len(repo.updated) != 1 || repo.updated[0].Level != tt.wantLevel {
+				t.Errorf("updates = %+v", repo.updated)
+			}
+		})
+	}
+}
+
+func TestGainExpRejectsMaxLevel(t *testing.T) {
+	s, repo := curveService(nil)
+	_, err := s.GainExpWithModifier(context.Background(), &models.UserCard{UserID: "u1", CardID: 7, Level: MaxLevel, Amount: 1}, fixedExp(1))
+	if err == nil || len(repo.updated) != 0 {
+		t.Errorf("max level gain = %v with %d updates, want an error and no update", err, len(repo.updated))
+	}
+}
+
+func TestPreviewLevelUpsNeeded(t *testing.T) {
+	s, _ := curveService([]int64{100, 150, 300, 600})
+
+	preview, err := s.Preview(&models.UserCard{UserID: "u1", CardID: 7, Level: 2, Exp: 40}, fixedExp(25))
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+	want := &LevelPreview{Level: 2, CurrentExp: 40, RequiredExp: 150, RemainingExp: 110, ExpPerLevelUp: 25, LevelUpsNeeded: 5}
+	if !reflect.DeepEqual(preview, want) {
+		t.Errorf("preview = %+v, want %+v", preview, want)
+	}
+
+	// Exp past the requirement needs nothing more
+	preview, _ = s.Preview(&models.UserCard{UserID: "u1", CardID: 7, Level: 2, Exp: 200}, fixedExp(25))
+	if preview.RemainingExp != 0 || preview.LevelUpsNeeded != 0 {
+		t.Errorf("overfull preview = %+v", preview)
+	}
+
+	preview, _ = s.Preview(&models.UserCard{UserID: "u1", CardID: 7, Level: MaxLevel}, nil)
+	if !preview.MaxLevel || preview.RequiredExp != 0 {
+		t.Errorf("max level preview = %+v", preview)
+	}
+}
+
+func TestExpCurve(t *testing.T) {
+	calc := NewCalculator(NewDefaultConfig())
+	curve := DefaultExpCurve()
+	if len(curve) != MaxLevel-1 {
+		t.Fatalf("default curve has %d entries, want %d", len(curve), MaxLevel-1)
+	}
+	for level := 1; level < MaxLevel; level++ {
+		if curve[level-1] != calc.CalculateExpRequirement(level) {
+			t.Errorf("level %d: curve %d, calculator %d", level, curve[level-1], calc.CalculateExpRequirement(level))
+		}
+	}
+	if err := ValidateExpCurve(curve); err != nil {
+		t.Errorf("default curve invalid: %v", err)
+	}
+
+	for _, bad := range [][]int64{{100, 200, 300}, {100, 0, 300, 400}, {100, 200, 300, 400, 500}} {
+		if err := ValidateExpCurve(bad); err == nil || !strings.Contains(err.Error(), "exp curve") {
+			t.Errorf("ValidateExpCurve(%v) = %v, want an error", bad, err)
+		}
+	}
+}
@@ -12,6 +12,17 @@ type LevelingResult struct {
 	CombinedCard bool
 }
 
+// LevelPreview estimates what a card still needs to reach its next level
+type LevelPreview struct {
+	Level          int
+	MaxLevel       bool
+	CurrentExp     int64
+	RequiredExp    int64
+	RemainingExp   int64
+	ExpPerLevelUp  int64 // expected EXP from one level up, without a critical hit
+	LevelUpsNeeded int64
+}
+
 type ExpGainConfig struct {
 	BaseGain      int64
 	LevelPenalty  float64
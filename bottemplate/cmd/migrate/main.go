@@ -2,21 +2,36 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate/database"
 	"github.com/disgoorg/bot-template/bottemplate/migration"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func main() {
-	ctx := context.Background()
+	// Ctrl-C/SIGTERM cancels ctx: the batch in flight finishes, a checkpoint is written and
+	// deferred cleanup runs before exiting with 130
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	interruptedExit := false
+	defer func() {
+		if interruptedExit {
+			os.Exit(130)
+		}
+	}()
+	defer stop()
 
 	// Command line flags
 	var (
@@ -32,13 +47,27 @@ func main() {
 		batchSize            = flag.Int("batch-size", 1000, "Batch size for inserts (lower for poolers, e.g., 200)")
 		resetOnError         = flag.Bool("reset-on-error", false, "If set, truncates app tables on migration error (Postgres only)")
 		resetBefore          = flag.Bool("reset-before", false, "If set, truncates app tables before migration (Postgres only)")
+		resetTables          = flag.String("reset-tables", "", "Comma-separated tables to truncate before migrating, e.g. user_cards; also limits --reset-on-error to them")
 		sleepMS              = flag.Int("sleep-ms", 0, "Optional sleep in ms between batches/statements (helps poolers)")
 		insertMode           = flag.String("insert-mode", "batch", "Insert mode: batch (default) or single (pooler-friendly)")
 		mongoCardsColl       = flag.String("mongo-cards-coll", "", "Override Mongo cards collection name (default: cards)")
 		mongoCollectionsColl = flag.String("mongo-collections-coll", "", "Override Mongo collections collection name (default: collections)")
 		autoCreateMissing    = flag.Bool("auto-create-missing-cards", false, "Auto-create placeholder cards for missing IDs referenced by usercards (default false; JSON backfill used instead)")
 		useCopy              = flag.Bool("use-copy", false, "Use pgx COPY for fastest bulk inserts (recommended for millions of rows)")
+		skippedLog           = flag.String("skipped-log", "skipped_cards.log", "Path of the log recording user cards that could not be imported")
+		skippedLogJSON       = flag.Bool("skipped-log-json", false, "Write the skipped cards log as JSON lines instead of CSV")
+		deadLetter           = flag.String("dead-letter", "", "Append rows that fail to insert to this JSON lines file and continue instead of aborting")
+		userCardGapsOnly     = flag.Bool("user-card-gaps-only", false, "Only report user cards referencing missing cards (backfilled, auto-created or skipped) and exit without migrating")
 	)
+	mongoFilters := map[string]bson.D{}
+	flag.Func("mongo-filter", "Only migrate matching Mongo documents, as kind={extended JSON filter} (repeatable), e.g. cards={\"col\":\"twice\"}", func(v string) error {
+		kind, filter, err := parseMongoFilter(v)
+		if err != nil {
+			return err
+		}
+		mongoFilters[kind] = filter
+		return nil
+	})
 	flag.Parse()
 
 	// Immediate debug output to see if we get this far
@@ -82,11 +111,23 @@ func main() {
 	defer db.Close()
 	fmt.Println("=== DATABASE CONNECTED ===")
 
+	// --reset-tables narrows every reset to the listed tables
+	var targetTables []string
+	if *resetTables != "" {
+		targetTables = strings.Split(*resetTables, ",")
+	}
+	reset := func() error {
+		if len(targetTables) > 0 {
+			return db.ResetTables(ctx, targetTables...)
+		}
+		return db.ResetAppTables(ctx)
+	}
+
 	// Optionally reset tables before starting
-	if *resetBefore {
-		slog.Warn("Resetting PostgreSQL app tables before migration")
-		if err := db.ResetAppTables(ctx); err != nil {
-			slog.Error("Failed to reset app tables", "error", err)
+	if *resetBefore || len(targetTables) > 0 {
+		slog.Warn("Resetting PostgreSQL tables before migration", "tables", describeResetTables(targetTables))
+		if err := reset(); err != nil {
+			slog.Error("Failed to reset tables", "error", err)
 			os.Exit(1)
 		}
 	}
@@ -127,14 +168,31 @@ func main() {
 		if *mongoCollectionsColl != "" {
 			migrator.SetMongoCollectionName("collections", *mongoCollectionsColl)
 		}
+		for kind, filter := range mongoFilters {
+			migrator.SetMongoFilter(kind, filter)
+		}
 		migrator.SetAutoCreateMissingCards(*autoCreateMissing)
 		migrator.SetUseCopy(*useCopy)
+		migrator.SetSkippedLogPath(*skippedLog)
+		migrator.SetSkippedLogJSON(*skippedLogJSON)
+		migrator.SetDeadLetterPath(*deadLetter)
+		migrator.SetCheckpointPath(filepath.Join(*logDir, "migration_checkpoint.json"))
+
+		if *userCardGapsOnly {
+			reportUserCardGaps(ctx, migrator)
+			return
+		}
 
 		if err := migrator.MigrateAllFromMongo(ctx); err != nil {
+			if errors.Is(err, migration.ErrInterrupted) {
+				slog.Warn("Mongo migration interrupted; see the checkpoint for completed steps", "error", err)
+				interruptedExit = true
+				return
+			}
 			slog.Error("Mongo migration failed", "error", err)
 			if *resetOnError {
-				slog.Warn("Reset-on-error enabled: truncating app tables")
-				_ = db.ResetAppTables(ctx)
+				slog.Warn("Reset-on-error enabled: truncating tables", "tables", describeResetTables(targetTables))
+				_ = reset()
 			}
 			os.Exit(1)
 		}
@@ -150,12 +208,26 @@ func main() {
 		migrator.SetInsertMode(*insertMode)
 		migrator.SetAutoCreateMissingCards(*autoCreateMissing)
 		migrator.SetUseCopy(*useCopy)
+		migrator.SetSkippedLogPath(*skippedLog)
+		migrator.SetSkippedLogJSON(*skippedLogJSON)
+		migrator.SetDeadLetterPath(*deadLetter)
+		migrator.SetCheckpointPath(filepath.Join(*logDir, "migration_checkpoint.json"))
+
+		if *userCardGapsOnly {
+			reportUserCardGaps(ctx, migrator)
+			return
+		}
 
 		if err := migrator.MigrateAll(ctx); err != nil {
+			if errors.Is(err, migration.ErrInterrupted) {
+				slog.Warn("BSON migration interrupted; see the checkpoint for completed steps", "error", err)
+				interruptedExit = true
+				return
+			}
 			slog.Error("BSON migration failed", "error", err)
 			if *resetOnError {
-				slog.Warn("Reset-on-error enabled: truncating app tables")
-				_ = db.ResetAppTables(ctx)
+				slog.Warn("Reset-on-error enabled: truncating tables", "tables", describeResetTables(targetTables))
+				_ = reset()
 			}
 			os.Exit(1)
 		}
@@ -164,6 +236,38 @@ func main() {
 	slog.Info("Migration completed successfully!")
 }
 
+// describeResetTables describes which tables a reset will truncate
+func describeResetTables(tables []string) string {
+	if len(tables) == 0 {
+		return "all app tables"
+	}
+	return strings.Join(tables, ",")
+}
+
+// reportUserCardGaps prints the user card gap report as JSON, exiting on failure
+func reportUserCardGaps(ctx context.Context, migrator *migration.Migrator) {
+	report, err := migrator.AnalyzeUserCardGaps(ctx)
+	if err != nil {
+		slog.Error("User card gap analysis failed", "error", err)
+		os.Exit(1)
+	}
+	out, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(out))
+}
+
+// parseMongoFilter splits a --mongo-filter value into its kind and decoded filter document
+func parseMongoFilter(v string) (string, bson.D, error) {
+	kind, raw, ok := strings.Cut(v, "=")
+	if !ok || kind == "" {
+		return "", nil, fmt.Errorf("expected kind={filter}, got %q", v)
+	}
+	var filter bson.D
+	if err := bson.UnmarshalExtJSON([]byte(raw), false, &filter); err != nil {
+		return "", nil, fmt.Errorf("invalid filter for %s: %w", kind, err)
+	}
+	return kind, filter, nil
+}
+
 // setupFileLogging configures slog to write to both console and file
 func setupFileLogging(logFile string) error {
 	file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
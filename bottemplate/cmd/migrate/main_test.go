@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParseMongoFilter(t *testing.T) {
+	kind, filter, err := parseMongoFilter(`cards={"col":"twice","level":{"$gte":3}}`)
+	if err != nil {
+		t.Fatalf("parseMongoFilter: %v", err)
+	}
+	want := bson.D{{Key: "col", Value: "twice"}, {Key: "level", Value: bson.D{{Key: "$gte", Value: int32(3)}}}}
+	if kind != "cards" || !reflect.DeepEqual(filter, want) {
+		t.Errorf("parseMongoFilter = %s %v, want cards %v", kind, filter, want)
+	}
+
+	for _, v := range []string{`{"col":"twice"}`, `={"col":"twice"}`, `cards={col}`, `cards=`} {
+		if _, _, err := parseMongoFilter(v); err == nil {
+			t.Errorf("parseMongoFilter(%q) accepted", v)
+		}
+	}
+}
+
+func TestDescribeResetTables(t *testing.T) {
+	if got := describeResetTables(nil); got != "all app tables" {
+		t.Errorf("describeResetTables(nil) = %q", got)
+	}
+	if got := describeResetTables([]string{"user_cards", "claims"}); got != "user_cards,claims" {
+		t.Errorf("describeResetTables = %q, want user_cards,claims", got)
+	}
+}
@@ -1,7 +1,9 @@
 package admin
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"math"
@@ -41,37 +43,25 @@ func AnalyzeEconomyHandler(b *bottemplate.Bot) handler.CommandHandler {
 			ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
 			defer cancel()
 
-			// Get latest stats
-			stats, err := b.EconomyStatsRepository.GetLatest(ctx)
+			// Every run collects fresh stats and persists them so trends and inflation have history
+			stats, err := collectEconomyReport(ctx, b)
 			if err != nil {
-				slog.Info("No existing economic stats found, initializing...")
-				monitor := economy.NewEconomyMonitor(b.EconomyStatsRepository, b.PriceCalculator, b.UserRepository)
-				stats, err = monitor.CollectStats(ctx)
-				if err != nil {
-					slog.Error("Failed to collect economic statistics", slog.String("error", err.Error()))
-					_, _ = event.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
-						Title:       "Error",
-						Description: "Failed to initialize economic statistics. Please try again later.",
-						Color:       utils.ErrorColor,
-					}}})
-					return
-				}
-				if err := b.EconomyStatsRepository.Create(ctx, stats); err != nil {
-					slog.Error("Failed to store economic statistics", slog.String("error", err.Error()))
-					_, _ = event.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
-						Title:       "Error",
-						Description: "Failed to store economic statistics. Please try again later.",
-						Color:       utils.ErrorColor,
-					}}})
-					return
-				}
+				slog.Error("Failed to collect economic statistics", slog.String("error", err.Error()))
+				_, _ = event.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
+					Title:       "Error",
+					Description: "Failed to collect economic statistics. Please try again later.",
+					Color:       utils.ErrorColor,
+				}}})
+				return
 			}
 
 			trends := map[string]float64{"wealth_change": 0, "activity_change": 0, "market_volume_change": 0, "inequality_change": 0}
-			if historicalTrends, err := b.EconomyStatsRepository.GetTrends(ctx); err == nil {
+			if historicalTrends, err := b.EconomyStatsRepository.GetTrends(ctx); err == nil && historicalTrends != nil {
 				trends = historicalTrends
 			}
 
+			thresholds := b.Cfg.Economy.Thresholds.Evaluate(stats)
+
 			var healthStatus string
 			switch {
 			case stats.EconomicHealth >= 90:
@@ -90,21 +80,94 @@ func AnalyzeEconomyHandler(b *bottemplate.Bot) handler.CommandHandler {
 			embed := discord.NewEmbedBuilder().
 				SetTitle("📊 Economic Analysis Report").
 				AddField("Economic Health", fmt.Sprintf("```\nScore: %.1f/100\nStatus: %s\nNeeds Correction: %v\n```", stats.EconomicHealth, healthStatus, stats.NeedsCorrection), false).
-				AddField("Wealth Statistics", fmt.Sprintf("```\nTotal Wealth: %s\nAverage Wealth: %s\nMedian Wealth: %s\nGini Coefficient: %.3f\n```", utils.FormatNumber(stats.TotalWealth), utils.FormatNumber(stats.AverageWealth), utils.FormatNumber(stats.MedianWealth), stats.GiniCoefficient), false).
+				AddField("Health Thresholds", formatThresholds(thresholds), false).
+				AddField("Wealth Statistics", fmt.Sprintf("```\nTotal Wealth: %s\nAverage Wealth: %s\nMedian Wealth: %s\nGini Coefficient: %.3f\nCard Ownership Gini: %.3f\nInflation (24h): %.2f%%\n```", utils.FormatNumber(stats.TotalWealth), utils.FormatNumber(stats.AverageWealth), utils.FormatNumber(stats.MedianWealth), stats.GiniCoefficient, stats.CardOwnershipGini, stats.InflationRate), false).
 				AddField("User Activity", fmt.Sprintf("```\nTotal Users: %d\nActive Users: %d\nDaily Transactions: %d\nMarket Volume: %s\n```", stats.TotalUsers, stats.ActiveUsers, stats.DailyTransactions, utils.FormatNumber(stats.MarketVolume)), false).
 				AddField("Wealth Distribution", distribution, false).
 				AddField("30-Day Trends", trendAnalysis, false).
 				SetColor(getHealthColor(stats.EconomicHealth)).
 				SetTimestamp(time.Now()).
-				SetFooter("Last updated", "")
+				SetFooter("Full report attached as JSON", "")
+
+			update := discord.MessageUpdate{Embeds: &[]discord.Embed{embed.Build()}}
+			report, err := json.MarshalIndent(economyReport{
+				GeneratedAt: time.Now().UTC(),
+				Stats:       stats,
+				Trends:      trends,
+				Thresholds:  thresholds,
+			}, "", "  ")
+			if err != nil {
+				slog.Error("Failed to encode economy report", slog.String("error", err.Error()))
+			} else {
+				update.Files = []*discord.File{{
+					Name:   fmt.Sprintf("economy_report_%d.json", time.Now().Unix()),
+					Reader: bytes.NewReader(report),
+				}}
+			}
 
-			_, _ = event.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{embed.Build()}})
+			_, _ = event.UpdateInteractionResponse(update)
 		}()
 
 		return nil
 	}
 }
 
+// economyReport is the JSON attachment sent with /analyze-economy
+type economyReport struct {
+	GeneratedAt time.Time                 `json:"generated_at"`
+	Stats       *models.EconomyStats      `json:"stats"`
+	Trends      map[string]float64        `json:"trends"`
+	Thresholds  []economy.ThresholdResult `json:"thresholds"`
+}
+
+// collectEconomyReport gathers current stats including card ownership inequality and the
+// 24h inflation rate, stores them and returns the stored row with its health score
+func collectEconomyReport(ctx context.Context, b *bottemplate.Bot) (*models.EconomyStats, error) {
+	monitor := economy.NewEconomyMonitor(b.EconomyStatsRepository, b.PriceCalculator, b.UserRepository)
+	stats, err := monitor.CollectStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect stats: %w", err)
+	}
+
+	if gini, err := b.EconomyStatsRepository.CardOwnershipGini(ctx); err != nil {
+		slog.Warn("Failed to compute card ownership gini", slog.String("error", err.Error()))
+	} else {
+		stats.CardOwnershipGini = gini
+	}
+
+	if inflation, err := b.EconomyStatsRepository.InflationSince(ctx, stats.TotalWealth, time.Now().Add(-24*time.Hour)); err != nil {
+		slog.Warn("Failed to compute inflation rate", slog.String("error", err.Error()))
+	} else {
+		stats.InflationRate = inflation
+	}
+
+	if err := b.EconomyStatsRepository.Create(ctx, stats); err != nil {
+		return nil, fmt.Errorf("failed to store stats: %w", err)
+	}
+	if err := b.EconomyStatsRepository.UpdateEconomicHealth(ctx); err != nil {
+		return nil, fmt.Errorf("failed to update economic health: %w", err)
+	}
+
+	return b.EconomyStatsRepository.GetLatest(ctx)
+}
+
+func formatThresholds(results []economy.ThresholdResult) string {
+	var sb strings.Builder
+	sb.WriteString("```\n")
+	for _, r := range results {
+		icon := "🟢"
+		switch r.Status {
+		case economy.ThresholdWarn:
+			icon = "🟡"
+		case economy.ThresholdFail:
+			icon = "🔴"
+		}
+		sb.WriteString(fmt.Sprintf("%s %-4s %s: %.3f\n", icon, strings.ToUpper(string(r.Status)), r.Metric, r.Value))
+	}
+	sb.WriteString("```")
+	return sb.String()
+}
+
 func createDistributionGraph(stats *models.EconomyStats) string {
 	var sb strings.Builder
 	sb.WriteString("```\n")
@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// recordAudit stores an audit entry for the admin command behind e. It runs in the
+// background and only logs failures, so auditing can never block or fail the command.
+func recordAudit(b *bottemplate.Bot, e *handler.CommandEvent, target string, params map[string]interface{}) {
+	entry := &models.AdminAudit{
+		ActorID:    e.User().ID.String(),
+		Command:    e.Data.CommandName(),
+		Target:     target,
+		Parameters: params,
+		CreatedAt:  time.Now(),
+	}
+	if guildID := e.GuildID(); guildID != nil {
+		entry.GuildID = guildID.String()
+	}
+	storeAudit(b, entry)
+}
+
+// recordComponentAudit is recordAudit for actions confirmed through a component,
+// such as a confirmation button, where the command name isn't on the event
+func recordComponentAudit(b *bottemplate.Bot, e *handler.ComponentEvent, command, target string, params map[string]interface{}) {
+	entry := &models.AdminAudit{
+		ActorID:    e.User().ID.String(),
+		Command:    command,
+		Target:     target,
+		Parameters: params,
+		CreatedAt:  time.Now(),
+	}
+	if guildID := e.GuildID(); guildID != nil {
+		entry.GuildID = guildID.String()
+	}
+	storeAudit(b, entry)
+}
+
+func storeAudit(b *bottemplate.Bot, entry *models.AdminAudit) {
+	if b.AdminAuditRepository == nil {
+		return
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.Error("Panic while recording admin audit entry", slog.Any("panic", r))
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := b.AdminAuditRepository.Create(ctx, entry); err != nil {
+			slog.Error("Failed to record admin audit entry",
+				slog.String("actor_id", entry.ActorID),
+				slog.String("command", entry.Command),
+				slog.String("target", entry.Target),
+				slog.Any("error", err))
+		}
+	}()
+}
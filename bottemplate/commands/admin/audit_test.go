@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// chanAuditRepo hands every created entry to a channel
+type chanAuditRepo struct {
+	repositories.AdminAuditRepository
+	created chan *models.AdminAudit
+	err     error
+}
+
+func (r *chanAuditRepo) Create(ctx context.Context, entry *models.AdminAudit) error {
+	r.created <- entry
+	return r.err
+}
+
+func TestStoreAuditRecordsCardDeletion(t *testing.T) {
+	repo := &chanAuditRepo{created: make(chan *models.AdminAudit, 1)}
+	b := &bottemplate.Bot{AdminAuditRepository: repo}
+
+	storeAudit(b, &models.AdminAudit{
+		ActorID:    "admin1",
+		Command:    "deletecard",
+		Target:     "card:42",
+		Parameters: map[string]interface{}{"card_name": "nayeon", "user_cards_deleted": 3},
+	})
+
+	select {
+	case entry := <-repo.created:
+		if entry.Command != "deletecard" || entry.Target != "card:42" || entry.ActorID != "admin1" {
+			t.Errorf("entry = %+v", entry)
+		}
+		if entry.Parameters["user_cards_deleted"] != 3 {
+			t.Errorf("parameters = %v", entry.Parameters)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no audit entry was stored")
+	}
+}
+
+func TestStoreAuditFailureDoesNotPropagate(t *testing.T) {
+	repo := &chanAuditRepo{created: make(chan *models.AdminAudit, 1), err: errors.New("insert failed")}
+	b := &bottemplate.Bot{AdminAuditRepository: repo}
+
+	storeAudit(b, &models.AdminAudit{ActorID: "admin1", Command: "gift"})
+
+	select {
+	case <-repo.created:
+	case <-time.After(time.Second):
+		t.Fatal("audit entry was never attempted")
+	}
+}
+
+func TestStoreAuditWithoutRepository(t *testing.T) {
+	// Bots started without auditing simply skip it
+	storeAudit(&bottemplate.Bot{}, &models.AdminAudit{ActorID: "admin1", Command: "gift"})
+}
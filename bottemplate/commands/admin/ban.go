@@ -0,0 +1,151 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var Ban = discord.SlashCommandCreate{
+	Name:        "ban",
+	Description: "Ban a user from using the bot",
+	Options: []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionUser{
+			Name:        "user",
+			Description: "The user to ban",
+			Required:    true,
+		},
+		discord.ApplicationCommandOptionString{
+			Name:        "reason",
+			Description: "Shown to the user when they try to use a command",
+			Required:    false,
+			MaxLength:   utils.Ptr(200),
+		},
+		discord.ApplicationCommandOptionInt{
+			Name:        "days",
+			Description: "Lift the ban automatically after this many days; leave empty for a permanent ban",
+			Required:    false,
+			MinValue:    utils.Ptr(1),
+		},
+	},
+}
+
+var Unban = discord.SlashCommandCreate{
+	Name:        "unban",
+	Description: "Lift a user's ban",
+	Options: []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionUser{
+			Name:        "user",
+			Description: "The user to unban",
+			Required:    true,
+		},
+	},
+}
+
+func BanHandler(b *bottemplate.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+		defer cancel()
+
+		data := e.SlashCommandInteractionData()
+		target := data.User("user")
+		if target.ID == e.User().ID {
+			return utils.EH.CreateErrorEmbed(e, "You can't ban yourself.")
+		}
+
+		user, err := b.UserRepository.GetByDiscordID(ctx, target.ID.String())
+		if err != nil {
+			return utils.EH.CreateErrorEmbed(e, fmt.Sprintf("%s hasn't started playing yet.", target.Username))
+		}
+
+		ban := user.Ban
+		ban.Full = true
+		ban.Reason = strings.TrimSpace(data.String("reason"))
+		ban.BannedBy = e.User().ID.String()
+		ban.Until = time.Time{}
+		if days, ok := data.OptInt("days"); ok {
+			ban.Until = time.Now().Add(time.Duration(days) * 24 * time.Hour)
+		}
+
+		if err := b.BanChecker.SetBan(ctx, user.DiscordID, ban); err != nil {
+			slog.Error("Failed to ban user",
+				slog.String("type", "cmd"),
+				slog.String("target_user_id", user.DiscordID),
+				slog.Any("error", err))
+			return utils.EH.CreateErrorEmbed(e, "Failed to ban the user. Please try again later.")
+		}
+
+		params := map[string]interface{}{"reason": ban.Reason}
+		if !ban.Until.IsZero() {
+			params["until"] = ban.Until
+		}
+		recordAudit(b, e, "user:"+user.DiscordID, params)
+
+		description := fmt.Sprintf("%s can no longer use the bot.", target.Mention())
+		if ban.Reason != "" {
+			description += fmt.Sprintf("\n**Reason:** %s", ban.Reason)
+		}
+		if ban.Until.IsZero() {
+			description += "\n**Expires:** never"
+		} else {
+			description += fmt.Sprintf("\n**Expires:** <t:%d:R>", ban.Until.Unix())
+		}
+
+		return e.CreateMessage(discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title:       "🔨 User Banned",
+				Description: description,
+				Color:       config.ErrorColor,
+			}},
+			Flags: discord.MessageFlagEphemeral,
+		})
+	}
+}
+
+func UnbanHandler(b *bottemplate.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+		defer cancel()
+
+		target := e.SlashCommandInteractionData().User("user")
+		user, err := b.UserRepository.GetByDiscordID(ctx, target.ID.String())
+		if err != nil {
+			return utils.EH.CreateErrorEmbed(e, fmt.Sprintf("%s hasn't started playing yet.", target.Username))
+		}
+		if !user.Ban.Active(time.Now()) {
+			return utils.EH.CreateErrorEmbed(e, fmt.Sprintf("%s isn't banned.", target.Username))
+		}
+
+		ban := user.Ban
+		ban.Full = false
+		ban.Reason = ""
+		ban.Until = time.Time{}
+		ban.BannedBy = ""
+		if err := b.BanChecker.SetBan(ctx, user.DiscordID, ban); err != nil {
+			slog.Error("Failed to unban user",
+				slog.String("type", "cmd"),
+				slog.String("target_user_id", user.DiscordID),
+				slog.Any("error", err))
+			return utils.EH.CreateErrorEmbed(e, "Failed to unban the user. Please try again later.")
+		}
+
+		recordAudit(b, e, "user:"+user.DiscordID, nil)
+
+		return e.CreateMessage(discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title:       "✅ User Unbanned",
+				Description: fmt.Sprintf("%s can use the bot again.", target.Mention()),
+				Color:       config.SuccessColor,
+			}},
+			Flags: discord.MessageFlagEphemeral,
+		})
+	}
+}
@@ -12,4 +12,10 @@ var Commands = []discord.ApplicationCommandCreate{
 	AnalyzeUsers,
 	Gift,
 	ResetDaily,
+	GuildConfig,
+	Processes,
+	ReloadCollections,
+	EconomyConfig,
+	Ban,
+	Unban,
 }
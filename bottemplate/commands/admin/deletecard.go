@@ -2,12 +2,14 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate"
 	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/services"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
@@ -27,6 +29,11 @@ var DeleteCard = discord.SlashCommandCreate{
 			Description: "Confirm that you want to delete this card",
 			Required:    true,
 		},
+		discord.ApplicationCommandOptionBool{
+			Name:        "force",
+			Description: "Delete the card even if users still own copies",
+			Required:    false,
+		},
 	},
 }
 
@@ -46,6 +53,7 @@ func DeleteCardHandler(b *bottemplate.Bot) handler.CommandHandler {
 		}
 		cardID := int64(e.SlashCommandInteractionData().Int("card_id"))
 		confirm := e.SlashCommandInteractionData().Bool("confirm")
+		force := e.SlashCommandInteractionData().Bool("force")
 
 		if !confirm {
 			_, err := e.UpdateInteractionResponse(discord.MessageUpdate{Content: utils.Ptr("⚠️ You must confirm the deletion by setting the confirm option to true.")})
@@ -65,8 +73,17 @@ func DeleteCardHandler(b *bottemplate.Bot) handler.CommandHandler {
 			return updErr
 		}
 
-		report, err := b.CardRepository.SafeDelete(ctx, cardID)
+		report, err := services.DeleteCard(ctx, b.CardRepository, b.SpacesService, cardID, force)
 		if err != nil {
+			var owned *services.CardOwnedError
+			if errors.As(err, &owned) {
+				_, updErr := e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
+					Title:       "⚠️ Card Still Owned",
+					Description: fmt.Sprintf("**%s** is owned by **%d** users (**%d** copies).\nRun the command again with `force: true` to delete it anyway.", card.Name, owned.Owners, owned.Copies),
+					Color:       config.ErrorColor,
+				}}})
+				return updErr
+			}
 			_, updErr := e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
 				Title:       "❌ Error",
 				Description: "An error occurred while deleting the card. Please try again later.",
@@ -75,6 +92,21 @@ func DeleteCardHandler(b *bottemplate.Bot) handler.CommandHandler {
 			return updErr
 		}
 
+		if b.CardNameIndex != nil {
+			b.CardNameIndex.Invalidate()
+		}
+
+		recordAudit(b, e, fmt.Sprintf("card:%d", cardID), map[string]interface{}{
+			"card_name":          card.Name,
+			"collection":         card.ColID,
+			"user_cards_deleted": report.UserCardsDeleted,
+			"copies_deleted":     report.CopiesDeleted,
+			"wishlists_deleted":  report.WishlistsDeleted,
+			"card_deleted":       report.CardDeleted,
+			"image_deleted":      report.ImageDeleted,
+			"force":              force,
+		})
+
 		// Create inline value for field booleans
 		inlineTrue := true
 
@@ -93,18 +125,11 @@ func DeleteCardHandler(b *bottemplate.Bot) handler.CommandHandler {
 				Inline: &inlineTrue,
 			}, {
 				Name:   "Database Changes",
-				Value:  fmt.Sprintf("• Removed from **%d** user inventories\n• Card entry deleted: **%v**", report.UserCardsDeleted, report.CardDeleted),
+				Value:  fmt.Sprintf("• Removed from **%d** user inventories (**%d** copies)\n• Removed from **%d** wishlists\n• Card entry deleted: **%v**", report.UserCardsDeleted, report.CopiesDeleted, report.WishlistsDeleted, report.CardDeleted),
 				Inline: &inlineTrue,
 			}, {
-				Name: "Storage Cleanup",
-				Value: fmt.Sprintf("Attempted to delete:\n• `%s/%s/%s/%d_%s.jpg`\n• `%s/%s.jpg`",
-					b.SpacesService.CardRoot,
-					getGroupType(card.Tags),
-					card.ColID,
-					card.Level,
-					card.Name,
-					card.ColID,
-					card.Name),
+				Name:   "Storage Cleanup",
+				Value:  fmt.Sprintf("• Image deleted: **%v**", report.ImageDeleted),
 				Inline: &inlineTrue,
 			}},
 			Footer: &discord.EmbedFooter{Text: "Card Deletion System"},
@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/economy"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var EconomyConfig = discord.SlashCommandCreate{
+	Name:        "economy-config",
+	Description: "Show the daily and work reward values currently in effect",
+}
+
+func EconomyConfigHandler(b *bottemplate.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		rewards := b.Cfg.Economy.Rewards
+
+		var daily strings.Builder
+		daily.WriteString(fmt.Sprintf("Reward: **%d** flakes\n", rewards.Daily.Amount))
+		daily.WriteString(fmt.Sprintf("Cooldown: **%s** before effects", time.Duration(rewards.Daily.CooldownMinutes)*time.Minute))
+
+		var work strings.Builder
+		work.WriteString(fmt.Sprintf("Cooldown: **%s**\n", rewards.Work.Cooldown()))
+		work.WriteString("❌ Failed: " + formatWorkPayout(rewards.Work.Failed) + "\n")
+		for i, payout := range rewards.Work.Stars {
+			work.WriteString(fmt.Sprintf("%s: %s\n", strings.Repeat("⭐", i+1), formatWorkPayout(payout)))
+		}
+
+		embed := discord.NewEmbedBuilder().
+			SetTitle("💰 Economy Config").
+			SetDescription("Effective values after defaults; ranges roll from min up to but excluding max.").
+			AddField("/daily", daily.String(), false).
+			AddField("/work", work.String(), false).
+			SetColor(config.SuccessColor).
+			SetTimestamp(time.Now()).
+			Build()
+
+		return e.CreateMessage(discord.MessageCreate{
+			Embeds: []discord.Embed{embed},
+			Flags:  discord.MessageFlagEphemeral,
+		})
+	}
+}
+
+func formatWorkPayout(p economy.WorkPayout) string {
+	return fmt.Sprintf("%s flakes • %s vials • %s XP", formatRewardRange(p.Flakes), formatRewardRange(p.Vials), formatRewardRange(p.XP))
+}
+
+func formatRewardRange(r economy.RewardRange) string {
+	if r.Max <= r.Min {
+		return fmt.Sprintf("%d", r.Min)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max-1)
+}
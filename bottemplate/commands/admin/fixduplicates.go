@@ -4,12 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"sync"
-	"sync/atomic"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate"
-	"github.com/disgoorg/bot-template/bottemplate/database/models"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
@@ -20,6 +18,9 @@ var FixDuplicates = discord.SlashCommandCreate{
 	Description: "🛠️ Fix duplicate cards in all collections",
 }
 
+// maxMergeLines caps how many per-user merge counts are listed in the result embed
+const maxMergeLines = 15
+
 func FixDuplicatesHandler(b *bottemplate.Bot) handler.CommandHandler {
 	return func(e *handler.CommandEvent) error {
 		if err := e.DeferCreateMessage(false); err != nil {
@@ -30,59 +31,54 @@ func FixDuplicatesHandler(b *bottemplate.Bot) handler.CommandHandler {
 			ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
 			defer cancel()
 
-			var userIDs []string
-			err := b.DB.BunDB().NewSelect().
-				ColumnExpr("DISTINCT user_id").
-				TableExpr("user_cards").
-				Scan(ctx, &userIDs)
-
+			merges, err := b.UserCardRepository.MergeDuplicates(ctx)
 			if err != nil {
-				log.Printf("[ERROR] Failed to fetch user IDs: %v", err)
-				_, _ = e.CreateFollowupMessage(discord.MessageCreate{Content: "❌ Failed to fetch users"})
+				log.Printf("[ERROR] Failed to merge duplicate cards: %v", err)
+				_, _ = e.CreateFollowupMessage(discord.MessageCreate{Content: "❌ Failed to merge duplicate cards"})
 				return
 			}
 
-			var totalUsersFixed atomic.Int64
-			var totalCardsFixed atomic.Int64
-
-			const maxWorkers = 10
-			userChan := make(chan string, len(userIDs))
-			var wg sync.WaitGroup
-
-			for i := 0; i < maxWorkers; i++ {
-				wg.Add(1)
-				go func() {
-					defer wg.Done()
-					for userID := range userChan {
-						if fixed, cards := processUser(ctx, b, userID); fixed {
-							totalUsersFixed.Add(1)
-							totalCardsFixed.Add(cards)
-						}
-					}
-				}()
+			var totalCardsMerged, totalRowsRemoved int
+			var perUser strings.Builder
+			for i, m := range merges {
+				totalCardsMerged += m.CardsMerged
+				totalRowsRemoved += m.RowsRemoved
+				if i < maxMergeLines {
+					perUser.WriteString(fmt.Sprintf("<@%s> • %d cards, %d rows removed\n", m.UserID, m.CardsMerged, m.RowsRemoved))
+				}
 			}
-
-			for _, userID := range userIDs {
-				userChan <- userID
+			if len(merges) > maxMergeLines {
+				perUser.WriteString(fmt.Sprintf("…and %d more users", len(merges)-maxMergeLines))
+			}
+			if perUser.Len() == 0 {
+				perUser.WriteString("No duplicates found")
 			}
-			close(userChan)
 
-			wg.Wait()
+			log.Printf("[INFO] Merged %d duplicate cards (%d rows) across %d users", totalCardsMerged, totalRowsRemoved, len(merges))
+
+			recordAudit(b, e, "all_users", map[string]interface{}{
+				"users_fixed":  len(merges),
+				"cards_merged": totalCardsMerged,
+				"rows_removed": totalRowsRemoved,
+			})
 
 			now := time.Now()
 			_, _ = e.CreateFollowupMessage(discord.MessageCreate{Embeds: []discord.Embed{{
 				Title:       "🛠️ Global Duplicate Fix Results",
-				Description: fmt.Sprintf("Fixed %d duplicate card entries across %d users!", totalCardsFixed.Load(), totalUsersFixed.Load()),
+				Description: fmt.Sprintf("Merged %d duplicate card entries across %d users!", totalCardsMerged, len(merges)),
 				Color:       utils.SuccessColor,
 				Fields: []discord.EmbedField{{
-					Name:  "Total Users Processed",
-					Value: fmt.Sprintf("%d", len(userIDs)),
-				}, {
 					Name:  "Users With Fixes",
-					Value: fmt.Sprintf("%d", totalUsersFixed.Load()),
+					Value: fmt.Sprintf("%d", len(merges)),
 				}, {
-					Name:  "Total Cards Fixed",
-					Value: fmt.Sprintf("%d", totalCardsFixed.Load()),
+					Name:  "Total Cards Merged",
+					Value: fmt.Sprintf("%d", totalCardsMerged),
+				}, {
+					Name:  "Duplicate Rows Removed",
+					Value: fmt.Sprintf("%d", totalRowsRemoved),
+				}, {
+					Name:  "Per User",
+					Value: perUser.String(),
 				}},
 				Footer:    &discord.EmbedFooter{Text: "All collections have been cleaned up!"},
 				Timestamp: &now,
@@ -92,90 +88,3 @@ func FixDuplicatesHandler(b *bottemplate.Bot) handler.CommandHandler {
 		return nil
 	}
 }
-
-func processUser(ctx context.Context, b *bottemplate.Bot, userID string) (bool, int64) {
-	tx, err := b.DB.BunDB().BeginTx(ctx, nil)
-	if err != nil {
-		log.Printf("[ERROR] Failed to start transaction for user %s: %v", userID, err)
-		return false, 0
-	}
-	defer tx.Rollback()
-
-	var duplicates []struct {
-		CardID int64 `bun:"card_id"`
-		Count  int64 `bun:"count"`
-	}
-
-	err = tx.NewSelect().
-		TableExpr("user_cards").
-		Column("card_id").
-		ColumnExpr("COUNT(*) as count").
-		Where("user_id = ?", userID).
-		Group("card_id").
-		Having("COUNT(*) > 1").
-		Scan(ctx, &duplicates)
-
-	if err != nil {
-		log.Printf("[ERROR] Failed to find duplicates for user %s: %v", userID, err)
-		return false, 0
-	}
-
-	if len(duplicates) == 0 {
-		return false, 0
-	}
-
-	var cardsFixed int64
-	for _, dup := range duplicates {
-		_, err := tx.NewRaw(`
-            WITH duplicates AS (
-                SELECT MIN(id) as keep_id,
-                       SUM(amount) as total_amount,
-                       MAX(exp) as max_exp,
-                       bool_or(favorite) as is_favorite,
-                       bool_or(locked) as is_locked,
-                       MIN(obtained) as first_obtained,
-                       MAX(rating) as max_rating,
-                       string_agg(DISTINCT mark, ', ') as combined_marks
-                FROM user_cards
-                WHERE user_id = ? AND card_id = ?
-            )
-            UPDATE user_cards
-            SET amount = duplicates.total_amount,
-                exp = duplicates.max_exp,
-                favorite = duplicates.is_favorite,
-                locked = duplicates.is_locked,
-                obtained = duplicates.first_obtained,
-                rating = duplicates.max_rating,
-                mark = duplicates.combined_marks,
-                updated_at = ?
-            FROM duplicates
-            WHERE user_cards.id = duplicates.keep_id
-        `, userID, dup.CardID, time.Now()).Exec(ctx)
-
-		if err != nil {
-			log.Printf("[ERROR] Failed to update card %d for user %s: %v", dup.CardID, userID, err)
-			continue
-		}
-
-		_, err = tx.NewDelete().
-			Model((*models.UserCard)(nil)).
-			Where("user_id = ? AND card_id = ? AND id != (SELECT MIN(id) FROM user_cards WHERE user_id = ? AND card_id = ?)",
-				userID, dup.CardID, userID, dup.CardID).
-			Exec(ctx)
-
-		if err != nil {
-			log.Printf("[ERROR] Failed to delete duplicates for card %d user %s: %v", dup.CardID, userID, err)
-			continue
-		}
-
-		cardsFixed++
-		log.Printf("[INFO] Fixed card %d for user %s", dup.CardID, userID)
-	}
-
-	if err := tx.Commit(); err != nil {
-		log.Printf("[ERROR] Failed to commit transaction for user %s: %v", userID, err)
-		return false, 0
-	}
-
-	return true, cardsFixed
-}
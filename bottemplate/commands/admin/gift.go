@@ -188,6 +188,21 @@ func GiftHandler(b *bottemplate.Bot) handler.CommandHandler {
 			return updErr
 		}
 
+		auditParams := map[string]interface{}{"balance": balance}
+		if card != nil {
+			auditParams["card_id"] = card.ID
+			auditParams["card_amount"] = cardAmount
+		}
+		if itemID != "" {
+			auditParams["item_id"] = itemID
+			auditParams["item_quantity"] = itemQuantity
+		}
+		recordAudit(b, e, "user:"+targetUserID, auditParams)
+
+		if card != nil {
+			go b.CompletionChecker.CheckCompletionForCards(context.Background(), targetUserID, []int64{card.ID})
+		}
+
 		// Create success message
 		successMessage := fmt.Sprintf("🎁 **Gifts sent to %s:**\n%s",
 			targetUser.Username,
@@ -217,36 +232,23 @@ func addBalanceToUser(ctx context.Context, tx bun.Tx, userID string, amount int6
 
 // addCardToUser adds cards to a user (following updateUserCard pattern from claim.go)
 func addCardToUser(ctx context.Context, tx bun.Tx, userID string, cardID int64, amount int64) error {
-	// Try to update existing user card
-	result, err := tx.NewUpdate().
-		Model((*models.UserCard)(nil)).
-		Set("amount = amount + ?", amount).
-		Set("updated_at = ?", time.Now()).
-		Where("user_id = ? AND card_id = ?", userID, cardID).
-		Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to update user card: %w", err)
+	now := time.Now()
+	userCard := &models.UserCard{
+		UserID:    userID,
+		CardID:    cardID,
+		Amount:    amount,
+		Obtained:  now,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
-
-	rowsAffected, err := result.RowsAffected()
+	_, err := tx.NewInsert().
+		Model(userCard).
+		On("CONFLICT (user_id, card_id) DO UPDATE").
+		Set("amount = uc.amount + EXCLUDED.amount").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to check rows affected: %w", err)
-	}
-
-	// If no rows affected, create new user card
-	if rowsAffected == 0 {
-		userCard := &models.UserCard{
-			UserID:    userID,
-			CardID:    cardID,
-			Amount:    amount,
-			Obtained:  time.Now(),
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		}
-		_, err = tx.NewInsert().Model(userCard).Exec(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to create user card: %w", err)
-		}
+		return fmt.Errorf("failed to upsert user card: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,117 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// guildToggleableCategories lists the command categories a guild may switch off
+var guildToggleableCategories = []string{"cards", "economy", "social"}
+
+var GuildConfig = discord.SlashCommandCreate{
+	Name:        "guild-config",
+	Description: "⚙️ Enable or disable command categories in this server",
+	Options: []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionString{
+			Name:        "category",
+			Description: "Command category to toggle (omit to view the current settings)",
+			Required:    false,
+			Choices: []discord.ApplicationCommandOptionChoiceString{
+				{Name: "Cards", Value: "cards"},
+				{Name: "Economy", Value: "economy"},
+				{Name: "Social", Value: "social"},
+			},
+		},
+		discord.ApplicationCommandOptionBool{
+			Name:        "enabled",
+			Description: "Whether the category's commands can be used here",
+			Required:    false,
+		},
+	},
+}
+
+func GuildConfigHandler(b *bottemplate.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		guildID := e.GuildID()
+		if guildID == nil {
+			return utils.EH.CreateErrorEmbed(e, "This command can only be used in a server.")
+		}
+		if member := e.Member(); member == nil || !member.Permissions.Has(discord.PermissionManageGuild) {
+			return utils.EH.CreateErrorEmbed(e, "You need the **Manage Server** permission to change server settings.")
+		}
+		if b.GuildCommandGate == nil {
+			return utils.EH.CreateErrorEmbed(e, "Server settings are not available right now.")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		data := e.SlashCommandInteractionData()
+		category, hasCategory := data.OptString("category")
+		enabled, hasEnabled := data.OptBool("enabled")
+
+		if hasCategory != hasEnabled {
+			return utils.EH.CreateErrorEmbed(e, "Provide both `category` and `enabled` to change a setting, or neither to view them.")
+		}
+
+		var (
+			settings *models.GuildSettings
+			err      error
+		)
+		if hasCategory {
+			settings, err = b.GuildCommandGate.SetCategoryEnabled(ctx, guildID.String(), category, enabled, e.User().ID.String())
+			if err == nil {
+				recordAudit(b, e, "guild:"+guildID.String(), map[string]interface{}{
+					"category": category,
+					"enabled":  enabled,
+				})
+				slog.Info("Guild command category toggled",
+					slog.String("guild_id", guildID.String()),
+					slog.String("category", category),
+					slog.Bool("enabled", enabled),
+					slog.String("admin_id", e.User().ID.String()))
+			}
+		} else {
+			settings, err = b.GuildCommandGate.Settings(ctx, guildID.String())
+		}
+		if err != nil {
+			slog.Error("Failed to update guild settings",
+				slog.String("guild_id", guildID.String()),
+				slog.Any("error", err))
+			return utils.EH.CreateErrorEmbed(e, "Failed to load server settings. Please try again.")
+		}
+
+		return e.CreateMessage(discord.MessageCreate{
+			Embeds: []discord.Embed{buildGuildConfigEmbed(settings)},
+			Flags:  discord.MessageFlagEphemeral,
+		})
+	}
+}
+
+func buildGuildConfigEmbed(settings *models.GuildSettings) discord.Embed {
+	var sb strings.Builder
+	for _, category := range guildToggleableCategories {
+		status := "✅ Enabled"
+		if !settings.IsCategoryEnabled(category) {
+			status = "🚫 Disabled"
+		}
+		sb.WriteString(fmt.Sprintf("**%s%s** — %s\n", strings.ToUpper(category[:1]), category[1:], status))
+	}
+	sb.WriteString("\nAdmin and system commands are always available.")
+
+	return discord.NewEmbedBuilder().
+		SetTitle("⚙️ Server Command Settings").
+		SetDescription(sb.String()).
+		SetColor(config.InfoColor).
+		Build()
+}
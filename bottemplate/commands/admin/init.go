@@ -4,17 +4,32 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate"
 	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/database"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
 )
 
 var Init = discord.SlashCommandCreate{
 	Name:        "init",
-	Description: "Initialize database tables if they don't exist",
+	Description: "Initialize database tables and default data; safe to re-run",
+	Options: []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionString{
+			Name:        "part",
+			Description: "Only run part of the initialization",
+			Required:    false,
+			Choices: []discord.ApplicationCommandOptionChoiceString{
+				{Name: "Everything", Value: string(database.InitAll)},
+				{Name: "Tables, migrations and indexes", Value: string(database.InitSchema)},
+				{Name: "Default items", Value: string(database.InitItems)},
+				{Name: "Quest definitions", Value: string(database.InitQuests)},
+			},
+		},
+	},
 }
 
 func InitHandler(b *bottemplate.Bot) handler.CommandHandler {
@@ -28,6 +43,11 @@ func InitHandler(b *bottemplate.Bot) handler.CommandHandler {
 			)
 		}()
 
+		part := database.InitAll
+		if value, ok := e.SlashCommandInteractionData().OptString("part"); ok {
+			part = database.InitPart(value)
+		}
+
 		// First, defer the response to let us process longer than 3 seconds
 		if err := e.DeferCreateMessage(false); err != nil {
 			return fmt.Errorf("failed to defer message: %w", err)
@@ -36,7 +56,8 @@ func InitHandler(b *bottemplate.Bot) handler.CommandHandler {
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
 			defer cancel()
-			if err := b.DB.InitializeSchema(ctx); err != nil {
+			summary, err := b.DB.Initialize(ctx, part)
+			if err != nil {
 				_, _ = e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
 					Title:       "❌ Database Initialization Failed",
 					Description: fmt.Sprintf("```diff\n- Error: %s\n```", err.Error()),
@@ -44,25 +65,69 @@ func InitHandler(b *bottemplate.Bot) handler.CommandHandler {
 				}}})
 				return
 			}
-			timestamp := fmt.Sprintf("<t:%d:F>", time.Now().Unix())
-			inlineTrue := true
-			_, _ = e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
-				Title:       "✅ Database Initialized",
-				Description: "All database tables have been successfully initialized.",
-				Color:       config.SuccessColor,
-				Fields: []discord.EmbedField{{
-					Name: "Tables Created",
-					Value: "• collections\n• cards\n• user_cards\n• user_quests\n" +
-						"• user_slots\n• user_stats\n• users\n• user_effects\n• claims",
-					Inline: &inlineTrue,
-				}, {
-					Name:   "Initialized At",
-					Value:  timestamp,
-					Inline: &inlineTrue,
-				}},
-				Footer: &discord.EmbedFooter{Text: "Database Initialization System"},
-			}}})
+
+			recordAudit(b, e, "database", map[string]interface{}{
+				"part":           string(part),
+				"tables_created": len(summary.TablesCreated),
+				"items_created":  summary.Items.Created,
+				"quests_created": summary.Quests.Created,
+			})
+
+			_, _ = e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{createInitSummaryEmbed(summary)}})
 		}()
 		return nil
 	}
 }
+
+// createInitSummaryEmbed lists what an init run created vs found already present
+func createInitSummaryEmbed(summary *database.InitSummary) discord.Embed {
+	inlineTrue := true
+	description := fmt.Sprintf("Ran the **%s** initialization. Re-running only creates what is missing.", summary.Part)
+	if summary.Skipped {
+		description = "Schema is already up to date (`DB_FAST_INIT`), nothing was changed."
+	}
+
+	var fields []discord.EmbedField
+	if summary.Part == database.InitAll || summary.Part == database.InitSchema {
+		tables := fmt.Sprintf("Created: **%d**\nAlready present: **%d**", len(summary.TablesCreated), summary.TablesExisting)
+		if len(summary.TablesCreated) > 0 {
+			tables += "\n• " + strings.Join(summary.TablesCreated, "\n• ")
+		}
+		fields = append(fields, discord.EmbedField{Name: "Tables", Value: tables, Inline: &inlineTrue})
+	}
+	if summary.Part == database.InitAll || summary.Part == database.InitItems {
+		fields = append(fields, discord.EmbedField{
+			Name:   "Items",
+			Value:  fmt.Sprintf("Created: **%d**\nAlready present: **%d**", summary.Items.Created, summary.Items.Existing),
+			Inline: &inlineTrue,
+		})
+	}
+	if summary.Part == database.InitAll || summary.Part == database.InitQuests {
+		fields = append(fields, discord.EmbedField{
+			Name: "Quests",
+			Value: fmt.Sprintf("Created: **%d**\nUpdated: **%d**\nRemoved: **%d**",
+				summary.Quests.Created, summary.Quests.Existing, summary.QuestsRemoved),
+			Inline: &inlineTrue,
+		})
+	}
+	fields = append(fields,
+		discord.EmbedField{
+			Name:   "Catalog",
+			Value:  fmt.Sprintf("Collections: **%d**\nCards: **%d**", summary.Collections, summary.Cards),
+			Inline: &inlineTrue,
+		},
+		discord.EmbedField{
+			Name:   "Initialized At",
+			Value:  fmt.Sprintf("<t:%d:F>", time.Now().Unix()),
+			Inline: &inlineTrue,
+		},
+	)
+
+	return discord.Embed{
+		Title:       "✅ Database Initialized",
+		Description: description,
+		Color:       config.SuccessColor,
+		Fields:      fields,
+		Footer:      &discord.EmbedFooter{Text: "Database Initialization System"},
+	}
+}
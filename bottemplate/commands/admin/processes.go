@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var Processes = discord.SlashCommandCreate{
+	Name:        "processes",
+	Description: "Inspect background processes and restart one",
+	Options: []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionString{
+			Name:         "restart",
+			Description:  "Name of the process to restart",
+			Required:     false,
+			Autocomplete: true,
+		},
+	},
+}
+
+func ProcessesHandler(b *bottemplate.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		if err := e.DeferCreateMessage(true); err != nil {
+			return err
+		}
+
+		notice := ""
+		if name := strings.TrimSpace(e.SlashCommandInteractionData().String("restart")); name != "" {
+			if err := b.BackgroundProcessManager.RestartProcess(name); err != nil {
+				if errors.Is(err, utils.ErrProcessNotFound) {
+					return utils.EH.UpdateInteractionResponse(e, "Unknown Process", fmt.Sprintf("No background process named `%s`", name))
+				}
+				return utils.EH.UpdateInteractionResponse(e, "Restart Failed", err.Error())
+			}
+			recordAudit(b, e, "process:"+name, map[string]interface{}{"action": "restart"})
+			notice = fmt.Sprintf("🔄 Restarted `%s`", name)
+		}
+
+		processes := b.BackgroundProcessManager.ListProcesses()
+		var description strings.Builder
+		if notice != "" {
+			description.WriteString(notice + "\n\n")
+		}
+		if len(processes) == 0 {
+			description.WriteString("No background processes registered")
+		}
+		for _, process := range processes {
+			description.WriteString(fmt.Sprintf("%s **%s** `%s`\n", processStatusEmoji(process.Status), process.Name, process.Status))
+			description.WriteString(fmt.Sprintf("> %s\n", process.Description))
+			description.WriteString(fmt.Sprintf("> Started %s", discordTime(&process.StartedAt)))
+			if process.Restarts > 0 {
+				description.WriteString(fmt.Sprintf(" • %d restarts", process.Restarts))
+			}
+			description.WriteString("\n")
+			if process.LastRun != nil {
+				description.WriteString(fmt.Sprintf("> Last run %s • last success %s\n", discordTime(process.LastRun), discordTime(process.LastSuccess)))
+			}
+			if process.LastError != "" {
+				description.WriteString(fmt.Sprintf("> ⚠️ %s: `%s`\n", discordTime(process.LastErrorAt), process.LastError))
+			}
+			description.WriteString("\n")
+		}
+
+		embed := discord.NewEmbedBuilder().
+			SetTitle("⚙️ Background Processes").
+			SetDescription(description.String()).
+			SetColor(config.SuccessColor).
+			SetTimestamp(time.Now()).
+			Build()
+
+		_, err := e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{embed}})
+		return err
+	}
+}
+
+func ProcessesAutocomplete(b *bottemplate.Bot) handler.AutocompleteHandler {
+	return func(e *handler.AutocompleteEvent) error {
+		query := strings.ToLower(e.Data.String("restart"))
+
+		var choices []discord.AutocompleteChoice
+		for _, process := range b.BackgroundProcessManager.ListProcesses() {
+			if query != "" && !strings.Contains(process.Name, query) {
+				continue
+			}
+			choices = append(choices, discord.AutocompleteChoiceString{
+				Name:  fmt.Sprintf("%s (%s)", process.Name, process.Status),
+				Value: process.Name,
+			})
+			if len(choices) == 25 {
+				break
+			}
+		}
+		return e.AutocompleteResult(choices)
+	}
+}
+
+func processStatusEmoji(status string) string {
+	switch status {
+	case utils.ProcessStatusRunning:
+		return "🟢"
+	case utils.ProcessStatusStopped:
+		return "⚪"
+	case utils.ProcessStatusCrashed:
+		return "🔴"
+	default:
+		return "🟡"
+	}
+}
+
+// discordTime renders t as a relative Discord timestamp
+func discordTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "never"
+	}
+	return fmt.Sprintf("<t:%d:R>", t.Unix())
+}
@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var ReloadCollections = discord.SlashCommandCreate{
+	Name:        "reload-collections",
+	Description: "Reload the collection cache after collections were added or edited",
+}
+
+func ReloadCollectionsHandler(b *bottemplate.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		if err := e.DeferCreateMessage(true); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+		defer cancel()
+
+		before := utils.GetCollectionCacheSize()
+		loaded, err := b.ReloadCollections(ctx)
+		if err != nil {
+			slog.Error("Failed to reload collection cache",
+				slog.String("type", "cmd"),
+				slog.Any("error", err))
+			return utils.EH.UpdateInteractionResponse(e, "Reload Failed", "Failed to load collections. The previous cache is still in use.")
+		}
+
+		recordAudit(b, e, "collections", map[string]interface{}{
+			"before": before,
+			"loaded": loaded,
+		})
+
+		embed := discord.NewEmbedBuilder().
+			SetTitle("🔄 Collection Cache Reloaded").
+			SetDescription(fmt.Sprintf("Loaded **%d** collections (previously **%d**).\nPromo, fragment and forge flags now reflect the database.", loaded, before)).
+			SetColor(config.SuccessColor).
+			SetTimestamp(time.Now()).
+			Build()
+
+		_, err = e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{embed}})
+		return err
+	}
+}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate"
-	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/config"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
@@ -20,11 +21,19 @@ var ResetDaily = discord.SlashCommandCreate{
 		discord.ApplicationCommandOptionUser{
 			Name:        "user",
 			Description: "The user whose daily cooldown to reset",
-			Required:    true,
+			Required:    false,
+		},
+		discord.ApplicationCommandOptionBool{
+			Name:        "all",
+			Description: "Reset the daily cooldown of every user (asks for confirmation)",
+			Required:    false,
 		},
 	},
 }
 
+// resetDailyConfirmWindow is how long the global reset confirmation buttons stay valid
+const resetDailyConfirmWindow = 2 * time.Minute
+
 func ResetDailyHandler(b *bottemplate.Bot) handler.CommandHandler {
 	return func(e *handler.CommandEvent) error {
 		start := time.Now()
@@ -41,9 +50,13 @@ func ResetDailyHandler(b *bottemplate.Bot) handler.CommandHandler {
 
 		// Get the target user from command options
 		data := e.SlashCommandInteractionData()
-		targetUser := data.User("user")
-		if targetUser.ID == 0 {
-			return utils.EH.CreateErrorEmbed(e, "Invalid user specified.")
+		if data.Bool("all") {
+			return promptGlobalDailyReset(e)
+		}
+
+		targetUser, ok := data.OptUser("user")
+		if !ok || targetUser.ID == 0 {
+			return utils.EH.CreateErrorEmbed(e, "Specify a user, or set `all` to reset everyone's daily.")
 		}
 
 		slog.Info("Resetting daily for user",
@@ -85,15 +98,8 @@ func ResetDailyHandler(b *bottemplate.Bot) handler.CommandHandler {
 			return utils.EH.CreateErrorEmbed(e, "Failed to reset daily claims. Please try again later.")
 		}
 
-		// Reset last daily timestamp to epoch (allows immediate daily)
-		epochTime := time.Unix(0, 0)
-		_, err = tx.NewUpdate().
-			Model((*models.User)(nil)).
-			Set("last_daily = ?", epochTime).
-			Set("updated_at = ?", time.Now()).
-			Where("discord_id = ?", user.DiscordID).
-			Exec(ctx)
-		if err != nil {
+		// Reset last daily timestamp (allows immediate daily)
+		if err := b.UserRepository.ResetDaily(ctx, tx, user.DiscordID); err != nil {
 			slog.Error("Failed to reset last daily timestamp",
 				slog.String("type", "db"),
 				slog.String("target_user_id", user.DiscordID),
@@ -111,6 +117,8 @@ func ResetDailyHandler(b *bottemplate.Bot) handler.CommandHandler {
 			return utils.EH.CreateErrorEmbed(e, "Failed to reset daily. Please try again later.")
 		}
 
+		recordAudit(b, e, "user:"+user.DiscordID, nil)
+
 		slog.Info("Daily reset successful",
 			slog.String("type", "cmd"),
 			slog.String("admin_id", e.User().ID.String()),
@@ -132,3 +140,123 @@ func ResetDailyHandler(b *bottemplate.Bot) handler.CommandHandler {
 		})
 	}
 }
+
+// promptGlobalDailyReset asks the admin to confirm resetting every user's daily
+func promptGlobalDailyReset(e *handler.CommandEvent) error {
+	adminID := e.User().ID.String()
+	return e.CreateMessage(discord.MessageCreate{
+		Embeds: []discord.Embed{
+			{
+				Title: "⚠️ Reset Everyone's Daily?",
+				Description: fmt.Sprintf("This resets the daily cooldown and daily claims of **every user**.\n\n"+
+					"Confirm within %s to continue.", resetDailyConfirmWindow),
+				Color: config.WarningColor,
+			},
+		},
+		Components: []discord.ContainerComponent{
+			discord.NewActionRow(
+				discord.NewDangerButton("Reset All", "/reset-daily/confirm/"+adminID),
+				discord.NewSecondaryButton("Cancel", "/reset-daily/cancel/"+adminID),
+			),
+		},
+		Flags: discord.MessageFlagEphemeral,
+	})
+}
+
+// ResetDailyComponentHandler handles the confirmation buttons of a global daily reset
+func ResetDailyComponentHandler(b *bottemplate.Bot) handler.ComponentHandler {
+	return func(e *handler.ComponentEvent) error {
+		// Custom ID format: /reset-daily/{action}/{adminID}
+		parts := strings.Split(e.Data.CustomID(), "/")
+		if len(parts) != 4 {
+			return nil
+		}
+		action, adminID := parts[2], parts[3]
+
+		if e.User().ID.String() != adminID {
+			return e.CreateMessage(discord.MessageCreate{
+				Content: "Only the admin who started this reset can confirm it.",
+				Flags:   discord.MessageFlagEphemeral,
+			})
+		}
+
+		if action == "cancel" {
+			return updateResetDailyMessage(e, "Daily Reset Cancelled", "No dailies were reset.", config.InfoColor)
+		}
+		if action != "confirm" {
+			return nil
+		}
+
+		if time.Since(e.Message.CreatedAt) > resetDailyConfirmWindow {
+			return updateResetDailyMessage(e, "Confirmation Expired", "Run `/reset-daily all:true` again to reset everyone's daily.", config.ErrorColor)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		tx, err := b.DB.BunDB().BeginTx(ctx, nil)
+		if err != nil {
+			slog.Error("Failed to start transaction",
+				slog.String("type", "db"),
+				slog.Any("error", err),
+			)
+			return updateResetDailyMessage(e, "Daily Reset Failed", "Failed to reset dailies. Please try again later.", config.ErrorColor)
+		}
+		defer tx.Rollback()
+
+		if err := b.ClaimRepository.ResetAllDailyClaims(ctx, tx); err != nil {
+			slog.Error("Failed to reset all daily claims",
+				slog.String("type", "db"),
+				slog.Any("error", err),
+			)
+			return updateResetDailyMessage(e, "Daily Reset Failed", "Failed to reset daily claims. Please try again later.", config.ErrorColor)
+		}
+
+		resetCount, err := b.UserRepository.ResetAllDaily(ctx, tx)
+		if err != nil {
+			slog.Error("Failed to reset all daily timestamps",
+				slog.String("type", "db"),
+				slog.Any("error", err),
+			)
+			return updateResetDailyMessage(e, "Daily Reset Failed", "Failed to reset daily timestamps. Please try again later.", config.ErrorColor)
+		}
+
+		if err := tx.Commit(); err != nil {
+			slog.Error("Failed to commit transaction",
+				slog.String("type", "db"),
+				slog.Any("error", err),
+			)
+			return updateResetDailyMessage(e, "Daily Reset Failed", "Failed to reset dailies. Please try again later.", config.ErrorColor)
+		}
+
+		recordComponentAudit(b, e, ResetDaily.Name, "all", map[string]interface{}{
+			"users_reset": resetCount,
+		})
+
+		slog.Info("Global daily reset successful",
+			slog.String("type", "cmd"),
+			slog.String("admin_id", adminID),
+			slog.Int64("users_reset", resetCount),
+		)
+
+		return updateResetDailyMessage(e, "✅ Daily Reset Complete",
+			fmt.Sprintf("Reset the daily cooldown of **%d** users.\n\nEveryone can now use `/daily` immediately.", resetCount),
+			config.SuccessColor)
+	}
+}
+
+func updateResetDailyMessage(e *handler.ComponentEvent, title, description string, color int) error {
+	return e.UpdateMessage(discord.MessageUpdate{
+		Embeds: &[]discord.Embed{
+			{
+				Title:       title,
+				Description: description,
+				Color:       color,
+				Footer: &discord.EmbedFooter{
+					Text: "Admin Testing Command",
+				},
+			},
+		},
+		Components: &[]discord.ContainerComponent{},
+	})
+}
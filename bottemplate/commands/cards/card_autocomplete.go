@@ -0,0 +1,80 @@
+package cards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/services"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// maxChoiceLength is Discord's limit for an autocomplete choice name and value
+const maxChoiceLength = 100
+
+// CardNameAutocomplete suggests cards for whichever card query option is focused.
+// The chosen value is a search query pinned to the exact card, so commands keep
+// parsing it like anything the user could have typed. In a comma-separated list
+// only the last entry is completed.
+func CardNameAutocomplete(b *bottemplate.Bot) handler.AutocompleteHandler {
+	return func(e *handler.AutocompleteEvent) error {
+		focused := e.Data.Focused()
+
+		searchTerm := ""
+		if focused.Value != nil {
+			var s string
+			if err := json.Unmarshal(focused.Value, &s); err != nil {
+				return e.AutocompleteResult([]discord.AutocompleteChoice{})
+			}
+			searchTerm = strings.TrimSpace(s)
+		}
+
+		listPrefix := ""
+		if i := strings.LastIndex(searchTerm, ","); i >= 0 {
+			listPrefix = searchTerm[:i+1] + " "
+			searchTerm = strings.TrimSpace(searchTerm[i+1:])
+		}
+
+		// Nothing typed yet, or a query that already uses the search syntax
+		if searchTerm == "" || b.CardNameIndex == nil || strings.ContainsAny(searchTerm[:1], "#!-<>=") {
+			return e.AutocompleteResult([]discord.AutocompleteChoice{})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		cards, err := b.CardNameIndex.Suggest(ctx, searchTerm, services.MaxAutocompleteChoices)
+		if err != nil {
+			slog.Error("Failed to suggest card names",
+				slog.String("error", err.Error()),
+				slog.String("search_term", searchTerm))
+			return e.AutocompleteResult([]discord.AutocompleteChoice{})
+		}
+
+		choices := make([]discord.AutocompleteChoice, 0, len(cards))
+		for _, card := range cards {
+			value := listPrefix + fmt.Sprintf("%s %d collection=%s", card.Name, card.Level, card.ColID)
+			if len(value) > maxChoiceLength {
+				continue
+			}
+
+			name := fmt.Sprintf("%s %s [%s]", utils.FormatCardName(card.Name), strings.Repeat("★", card.Level), card.ColID)
+			if runes := []rune(name); len(runes) > maxChoiceLength {
+				name = string(runes[:maxChoiceLength])
+			}
+
+			choices = append(choices, discord.AutocompleteChoiceString{
+				Name:  name,
+				Value: value,
+			})
+		}
+
+		return e.AutocompleteResult(choices)
+	}
+}
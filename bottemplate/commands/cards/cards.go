@@ -27,54 +27,10 @@ var Cards = discord.SlashCommandCreate{
 
 func CardsHandler(b *bottemplate.Bot) handler.CommandHandler {
 	cardDisplayService := services.NewCardDisplayService(b.CardRepository, b.SpacesService)
-	cardOperationsService := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository)
+	cardOperationsService := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository).
+		WithPriceLookup(b.PriceCalculator.GetLastPrices)
 
-	paginationHandler := &utils.PaginationHandler{
-		Config: utils.PaginationConfig{
-			ItemsPerPage: config.CardsPerPage,
-			Prefix:       "cards",
-		},
-		FormatItems: func(items []interface{}, page, totalPages int, userID, query string) (discord.Embed, error) {
-			displayItems := make([]services.CardDisplayItem, len(items))
-			for i, item := range items {
-				displayItems[i] = item.(services.CardDisplayItem)
-			}
-
-			// Calculate total items from pagination data
-			itemsPerPage := config.CardsPerPage
-			totalItems := totalPages * itemsPerPage
-			if page == totalPages-1 {
-				// Last page might have fewer items
-				totalItems = (totalPages-1)*itemsPerPage + len(items)
-			}
-
-			return cardDisplayService.CreateCardsEmbed(
-				context.Background(),
-				"My Collection",
-				displayItems,
-				page,
-				totalPages,
-				totalItems,
-				query,
-				config.BackgroundColor,
-			)
-		},
-		FormatCopy: func(items []interface{}) string {
-			displayItems := make([]services.CardDisplayItem, len(items))
-			for i, item := range items {
-				displayItems[i] = item.(services.CardDisplayItem)
-			}
-
-			copyText, err := cardDisplayService.FormatCopyText(context.Background(), displayItems, "My Collection")
-			if err != nil {
-				return "Error formatting copy text"
-			}
-			return copyText
-		},
-		ValidateUser: func(eventUserID, targetUserID string) bool {
-			return eventUserID == targetUserID
-		},
-	}
+	factory := newCardsPaginationFactory(b, cardDisplayService, cardOperationsService)
 
 	return func(event *handler.CommandEvent) error {
 		query := strings.TrimSpace(event.SlashCommandInteractionData().String("query"))
@@ -117,7 +73,10 @@ func CardsHandler(b *bottemplate.Bot) handler.CommandHandler {
 			items[i] = item
 		}
 
-		embed, components, err := paginationHandler.CreateInitialPaginationEmbed(items, event.User().ID.String(), query)
+		embed, components, err := factory.CreateInitialPaginationEmbedFromItems(items, utils.PaginationParams{
+			UserID: event.User().ID.String(),
+			Query:  query,
+		})
 		if err != nil {
 			return utils.EH.UpdateInteractionResponse(event, "Cards", "Failed to create card display")
 		}
@@ -133,8 +92,14 @@ func CardsHandler(b *bottemplate.Bot) handler.CommandHandler {
 // CardsComponentHandler handles pagination for cards using the new unified factory
 func CardsComponentHandler(b *bottemplate.Bot) handler.ComponentHandler {
 	cardDisplayService := services.NewCardDisplayService(b.CardRepository, b.SpacesService)
-	cardOperationsService := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository)
+	cardOperationsService := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository).
+		WithPriceLookup(b.PriceCalculator.GetLastPrices)
+
+	return newCardsPaginationFactory(b, cardDisplayService, cardOperationsService).CreateHandler()
+}
 
+// newCardsPaginationFactory builds the pagination shared by /cards and its buttons
+func newCardsPaginationFactory(b *bottemplate.Bot, cardDisplayService *services.CardDisplayService, cardOperationsService *services.CardOperationsService) *utils.PaginationFactory {
 	// Create data fetcher
 	fetcher := &CardsDataFetcher{
 		bot:                   b,
@@ -160,9 +125,7 @@ func CardsComponentHandler(b *bottemplate.Bot) handler.ComponentHandler {
 		Validator:    validator,
 	}
 
-	// Create factory and return handler
-	factory := utils.NewPaginationFactory(factoryConfig)
-	return factory.CreateHandler()
+	return utils.NewPaginationFactory(factoryConfig)
 }
 
 // CardsDataFetcher implements DataFetcher for cards pagination
@@ -217,21 +180,13 @@ func (cf *CardsFormatter) FormatItems(items []interface{}, page, totalPages int,
 		displayItems[i] = item.(services.CardDisplayItem)
 	}
 
-	// Calculate total items from pagination data
-	itemsPerPage := config.CardsPerPage
-	totalItems := totalPages * itemsPerPage
-	if page == totalPages-1 {
-		// Last page might have fewer items
-		totalItems = (totalPages-1)*itemsPerPage + len(items)
-	}
-
 	return cf.cardDisplayService.CreateCardsEmbed(
 		context.Background(),
 		"My Collection",
 		displayItems,
 		page,
 		totalPages,
-		totalItems,
+		params.TotalItems,
 		params.Query,
 		config.BackgroundColor,
 	)
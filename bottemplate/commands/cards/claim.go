@@ -3,6 +3,7 @@ package cards
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/rand"
@@ -15,6 +16,7 @@ import (
 	"github.com/disgoorg/bot-template/bottemplate/cardleveling"
 	"github.com/disgoorg/bot-template/bottemplate/config"
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
@@ -41,6 +43,11 @@ var Claim = discord.SlashCommandCreate{
 				{Name: "Boy Groups", Value: "boygroups"},
 			},
 		},
+		discord.ApplicationCommandOptionBool{
+			Name:        "pick",
+			Description: "Browse several cards and keep the one you like (costs one claim)",
+			Required:    false,
+		},
 	},
 }
 
@@ -79,13 +86,18 @@ func NewClaimHandler(b *bottemplate.Bot) *ClaimHandler {
 }
 
 func (h *ClaimHandler) HandleCommand(e *handler.CommandEvent) error {
-	ctx := context.Background()
+	ctx := e.Ctx
 	// Defer immediately to avoid 3s Discord timeout (prevents Unknown interaction 10062)
 	if err := e.DeferCreateMessage(false); err != nil {
 		return err
 	}
 	userID := e.User().ID.String()
 
+	if pick, ok := e.SlashCommandInteractionData().OptBool("pick"); ok && pick {
+		groupType := strings.TrimSpace(e.SlashCommandInteractionData().String("group_type"))
+		return h.handleOfferCommand(e, groupType)
+	}
+
 	// Get current claim info
 	claimInfo, err := h.bot.ClaimRepository.GetClaimInfo(ctx, userID)
 	if err != nil {
@@ -142,20 +154,11 @@ func (h *ClaimHandler) HandleCommand(e *handler.CommandEvent) error {
 	}
 	defer tx.Rollback()
 
-	// Retrieve all cards and filter out promo cards
-	allCards, err := h.bot.CardRepository.GetAll(ctx)
+	cards, supply, err := claimPool(ctx, h.bot)
 	if err != nil {
 		return utils.EH.UpdateInteractionResponse(e, "Error", "Failed to fetch cards")
 	}
 
-	// Filter out cards that should never enter the normal claim pool.
-	var cards []*models.Card
-	for _, card := range allCards {
-		if utils.IsCardClaimEligible(card) {
-			cards = append(cards, card)
-		}
-	}
-
 	// Randomly pick cards (with effect modifications)
 	type cardWithEXP struct {
 		card *models.Card
@@ -167,6 +170,14 @@ func (h *ClaimHandler) HandleCommand(e *handler.CommandEvent) error {
 		isFirstClaim := currentDailyClaims == 0 && i == 0
 		card := selectRandomCard(cards, h.bot, userID, isFirstClaim, groupType)
 		if card != nil {
+			// Stop offering a capped card once this batch would exhaust it
+			if status, capped := supply[card.ID]; capped {
+				status.Minted++
+				if status.Remaining() <= 0 {
+					cards = withoutCard(cards, card.ID)
+				}
+			}
+
 			// Calculate initial EXP for non-promo, non-fragment cards
 			var exp int64
 			if colInfo, exists := utils.GetCollectionInfo(card.ColID); exists && !colInfo.IsPromo && !colInfo.IsFragments {
@@ -186,6 +197,12 @@ func (h *ClaimHandler) HandleCommand(e *handler.CommandEvent) error {
 	})
 
 	// Build the new-cards listing text
+	batchCopies := make(map[int64]int64)
+	for _, cardWithExp := range selectedCardsWithEXP {
+		batchCopies[cardWithExp.card.ID]++
+	}
+	claimedInBatch := make(map[int64]int64)
+
 	var cardList strings.Builder
 	cardList.WriteString("**✨ New Cards**\n\n")
 	for _, cardWithExp := range selectedCardsWithEXP {
@@ -220,6 +237,14 @@ func (h *ClaimHandler) HandleCommand(e *handler.CommandEvent) error {
 		}
 		// For promo cards, fragments, and level 5 cards, expDisplay remains empty
 
+		// Capped cards show the supply left after this claim
+		supplyDisplay := ""
+		if status, capped := supply[card.ID]; capped {
+			claimedInBatch[card.ID]++
+			left := status.MaxCopies - (status.Minted - batchCopies[card.ID] + claimedInBatch[card.ID])
+			supplyDisplay = fmt.Sprintf(" `%d/%d left`", left, status.MaxCopies)
+		}
+
 		// Format: stars [name](url) [collection] exp% #amount
 		cardName := utils.FormatCardName(card.Name)
 		amountDisplay := ""
@@ -235,6 +260,7 @@ func (h *ClaimHandler) HandleCommand(e *handler.CommandEvent) error {
 		if amountDisplay != "" {
 			line += amountDisplay
 		}
+		line += supplyDisplay
 		cardList.WriteString(line + "\n")
 	}
 	cardList.WriteString("\n\n")
@@ -248,6 +274,10 @@ func (h *ClaimHandler) HandleCommand(e *handler.CommandEvent) error {
 	// Deduct balance & update claim stats per card
 	for i, cardWithExp := range selectedCardsWithEXP {
 		if err := claimCardTx(ctx, tx, h.bot, cardWithExp.card.ID, userID, claimCosts[i], cardWithExp.exp); err != nil {
+			if errors.Is(err, repositories.ErrCardSoldOut) {
+				return utils.EH.UpdateInteractionResponse(e, "Sold Out",
+					fmt.Sprintf("%s just ran out of copies. Nothing was charged, please claim again.", utils.FormatCardName(cardWithExp.card.Name)))
+			}
 			return fmt.Errorf("failed to claim card: %w", err)
 		}
 	}
@@ -381,6 +411,24 @@ func (h *ClaimHandler) HandleComponent(e *handler.ComponentEvent) error {
 	action := parts[2]
 	claimerID := parts[3]
 
+	// Multi-card offers: /claim/pick/{user}/{page} and /claim/{next|prev}/{user}/{page}/offer
+	if action == "pick" || (len(parts) == 6 && parts[5] == "offer") {
+		if e.User().ID.String() != claimerID {
+			return e.CreateMessage(discord.MessageCreate{
+				Content: "Only the user who started this claim can pick from it.",
+				Flags:   discord.MessageFlagEphemeral,
+			})
+		}
+		page, err := strconv.Atoi(parts[4])
+		if err != nil {
+			return nil
+		}
+		if action == "pick" {
+			return h.handleOfferPick(e, claimerID, page)
+		}
+		return h.handleOfferNavigate(e, claimerID, action, page)
+	}
+
 	// Handle favorite button
 	if action == "favorite" {
 		if len(parts) != 6 {
@@ -404,7 +452,7 @@ func (h *ClaimHandler) HandleComponent(e *handler.ComponentEvent) error {
 		}
 
 		// Toggle favorite status
-		ctx := context.Background()
+		ctx := e.Ctx
 		isFavorited, err := h.bot.UserCardRepository.ToggleFavorite(ctx, claimerID, cardID)
 		if err != nil {
 			slog.Error("Failed to toggle favorite",
@@ -529,7 +577,7 @@ func (h *ClaimHandler) HandleComponent(e *handler.ComponentEvent) error {
 	}
 
 	// Check if current card is favorited
-	ctx := context.Background()
+	ctx := e.Ctx
 	favoriteEmoji := "🤍"
 	if currentCardID > 0 {
 		userCard, err := h.bot.UserCardRepository.GetUserCard(ctx, claimerID, currentCardID)
@@ -619,6 +667,42 @@ func calculateInitialEXP(cardLevel int) int64 {
 	return exp
 }
 
+// claimPool returns the cards that may be claimed right now, leaving out
+// capped cards whose supply is exhausted, along with the supply of the rest
+func claimPool(ctx context.Context, b *bottemplate.Bot) ([]*models.Card, map[int64]*repositories.SupplyStatus, error) {
+	allCards, err := b.CardRepository.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	supply, err := b.CardSupplyRepository.GetStatuses(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pool []*models.Card
+	for _, card := range allCards {
+		if !utils.IsCardClaimEligible(card) {
+			continue
+		}
+		if status, capped := supply[card.ID]; capped && status.Remaining() <= 0 {
+			continue
+		}
+		pool = append(pool, card)
+	}
+	return pool, supply, nil
+}
+
+// withoutCard returns cards minus the card with the given ID
+func withoutCard(cards []*models.Card, cardID int64) []*models.Card {
+	filtered := make([]*models.Card, 0, len(cards))
+	for _, card := range cards {
+		if card.ID != cardID {
+			filtered = append(filtered, card)
+		}
+	}
+	return filtered
+}
+
 func selectRandomCard(cards []*models.Card, bot *bottemplate.Bot, userID string, isFirstClaim bool, groupType string) *models.Card {
 	// Weighted rarities
 	weights := map[int]int{
@@ -719,6 +803,7 @@ func claimCardTx(ctx context.Context, tx bun.Tx, b *bottemplate.Bot, cardID int6
 		Model((*models.User)(nil)).
 		Set("balance = balance - ?", claimCost).
 		Where("discord_id = ?", userID).
+		Where("balance >= ?", claimCost).
 		Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to update user balance: %w", err)
@@ -727,6 +812,11 @@ func claimCardTx(ctx context.Context, tx bun.Tx, b *bottemplate.Bot, cardID int6
 		return fmt.Errorf("failed to update user balance - user not found or insufficient balance")
 	}
 
+	// Count the copy against the card's supply cap, if it has one
+	if _, err := b.CardSupplyRepository.Reserve(ctx, tx, cardID, 1); err != nil {
+		return fmt.Errorf("failed to reserve card supply: %w", err)
+	}
+
 	// Upsert the user_card
 	if err := updateUserCard(ctx, tx, userID, cardID, initialExp); err != nil {
 		return fmt.Errorf("failed to handle user card: %w", err)
@@ -736,36 +826,25 @@ func claimCardTx(ctx context.Context, tx bun.Tx, b *bottemplate.Bot, cardID int6
 }
 
 func updateUserCard(ctx context.Context, tx bun.Tx, userID string, cardID int64, initialExp int64) error {
-	result, err := tx.NewUpdate().
-		Model((*models.UserCard)(nil)).
-		Set("amount = amount + 1").
-		Set("updated_at = ?", time.Now()).
-		Where("user_id = ? AND card_id = ?", userID, cardID).
+	// Upsert on (user_id, card_id): new cards get the initial EXP, existing ones just gain a copy
+	now := time.Now()
+	userCard := &models.UserCard{
+		UserID:    userID,
+		CardID:    cardID,
+		Amount:    1,
+		Exp:       initialExp,
+		Obtained:  now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	_, err := tx.NewInsert().
+		Model(userCard).
+		On("CONFLICT (user_id, card_id) DO UPDATE").
+		Set("amount = uc.amount + EXCLUDED.amount").
+		Set("updated_at = EXCLUDED.updated_at").
 		Exec(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to update card: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to check rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		// New card - set initial EXP
-		userCard := &models.UserCard{
-			UserID:    userID,
-			CardID:    cardID,
-			Amount:    1,
-			Exp:       initialExp,
-			Obtained:  time.Now(),
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		}
-		_, err = tx.NewInsert().Model(userCard).Exec(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to create user card: %w", err)
-		}
+		return fmt.Errorf("failed to upsert user card: %w", err)
 	}
 
 	return nil
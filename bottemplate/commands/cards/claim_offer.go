@@ -0,0 +1,239 @@
+package cards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/economy/claim"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+const (
+	defaultClaimOfferSize = 3
+	maxClaimOfferSize     = 5
+)
+
+func (h *ClaimHandler) offerSize() int {
+	size := h.bot.Cfg.Claim.OfferSize
+	if size <= 1 {
+		return defaultClaimOfferSize
+	}
+	if size > maxClaimOfferSize {
+		return maxClaimOfferSize
+	}
+	return size
+}
+
+// handleOfferCommand presents several cards for the price of one claim and lets the user pick
+// one. Nothing is charged or granted until the pick, so the other cards stay in the pool.
+func (h *ClaimHandler) handleOfferCommand(e *handler.CommandEvent, groupType string) error {
+	ctx := e.Ctx
+	userID := e.User().ID.String()
+
+	if h.bot.ClaimManager == nil {
+		return utils.EH.UpdateInteractionResponse(e, "Error", "Claim offers are not available right now")
+	}
+
+	claimInfo, err := h.bot.ClaimRepository.GetClaimInfo(ctx, userID)
+	if err != nil {
+		return utils.EH.UpdateInteractionResponse(e, "Error", "Failed to get claim info")
+	}
+
+	currentDailyClaims, err := h.bot.ClaimRepository.GetUserClaimsInPeriod(ctx, userID, time.Now().Add(-config.DailyPeriod))
+	if err != nil {
+		return utils.EH.UpdateInteractionResponse(e, "Error", "Failed to get claim count")
+	}
+	cost := h.bot.ClaimRepository.GetBasePrice() * int64(currentDailyClaims+1)
+	if claimInfo.Balance < cost {
+		return utils.EH.UpdateInteractionResponse(e, "Error",
+			fmt.Sprintf("Insufficient balance. You need %d ❄ for this claim", cost))
+	}
+
+	if ok, wait := h.bot.ClaimManager.CanClaim(userID); !ok {
+		return utils.EH.UpdateInteractionResponse(e, "Error",
+			fmt.Sprintf("Please wait %d seconds before claiming again", int(wait.Seconds())+1))
+	}
+	if !h.bot.ClaimManager.LockClaim(userID) {
+		return utils.EH.UpdateInteractionResponse(e, "Error", "You already have a claim offer open. Pick a card or wait for it to expire.")
+	}
+
+	pool, _, err := claimPool(ctx, h.bot)
+	if err != nil {
+		h.bot.ClaimManager.ReleaseClaim(userID)
+		return utils.EH.UpdateInteractionResponse(e, "Error", "Failed to fetch cards")
+	}
+
+	size := h.offerSize()
+	isFirstClaim := currentDailyClaims == 0
+	seen := make(map[int64]bool, size)
+	var offered []*models.Card
+	var exps []int64
+	for attempts := 0; len(offered) < size && attempts < size*10; attempts++ {
+		card := selectRandomCard(pool, h.bot, userID, isFirstClaim && len(offered) == 0, groupType)
+		if card == nil {
+			break
+		}
+		if seen[card.ID] {
+			continue
+		}
+		seen[card.ID] = true
+
+		var exp int64
+		if colInfo, exists := utils.GetCollectionInfo(card.ColID); exists && !colInfo.IsPromo && !colInfo.IsFragments {
+			exp = calculateInitialEXP(card.Level)
+		}
+		offered = append(offered, card)
+		exps = append(exps, exp)
+	}
+
+	if len(offered) == 0 {
+		h.bot.ClaimManager.ReleaseClaim(userID)
+		return utils.EH.UpdateInteractionResponse(e, "Error", "No cards available")
+	}
+
+	offer := h.bot.ClaimManager.CreateOffer(userID, offered, exps, cost)
+	embed, components := h.renderOffer(offer, 1, e.User().Username)
+
+	_, updErr := e.UpdateInteractionResponse(discord.MessageUpdate{
+		Embeds:     &[]discord.Embed{embed},
+		Components: &components,
+	})
+	return updErr
+}
+
+func (h *ClaimHandler) renderOffer(offer *claim.Offer, page int, username string) (discord.Embed, []discord.ContainerComponent) {
+	var list strings.Builder
+	list.WriteString("**🎴 Pick one card**\n\n")
+	for i, card := range offer.Cards {
+		marker := "▫️"
+		if i == page-1 {
+			marker = "▶️"
+		}
+		stars := utils.GetPromoRarityDisplay(card.ColID, card.Level)
+		list.WriteString(fmt.Sprintf("%s %s **[%s](%s)** `[%s]`\n",
+			marker, stars, utils.FormatCardName(card.Name), getCardImageURL(card, h.bot), strings.ToUpper(card.ColID)))
+	}
+	list.WriteString(fmt.Sprintf("\nCost: **%d** ❄ • Offer expires <t:%d:R>", offer.Cost, offer.ExpiresAt.Unix()))
+
+	embed := discord.NewEmbedBuilder().
+		SetDescription(list.String()).
+		SetColor(utils.SuccessColor).
+		SetImage(getCardImageURL(offer.Cards[page-1], h.bot)).
+		SetFooter(fmt.Sprintf("Card %d/%d • Offer for %s", page, len(offer.Cards), username), "").
+		Build()
+
+	components := []discord.ContainerComponent{
+		discord.NewActionRow(
+			discord.NewSecondaryButton("◀ Previous", fmt.Sprintf("/claim/prev/%s/%d/offer", offer.UserID, page)),
+			discord.NewSuccessButton("Pick this card", fmt.Sprintf("/claim/pick/%s/%d", offer.UserID, page)),
+			discord.NewSecondaryButton("Next ▶", fmt.Sprintf("/claim/next/%s/%d/offer", offer.UserID, page)),
+		),
+	}
+	return embed, components
+}
+
+// handleOfferNavigate moves through the cards of a pending offer
+func (h *ClaimHandler) handleOfferNavigate(e *handler.ComponentEvent, claimerID string, action string, page int) error {
+	offer, err := h.bot.ClaimManager.GetOffer(claimerID)
+	if err != nil {
+		return h.closeOffer(e, err)
+	}
+
+	total := len(offer.Cards)
+	if page < 1 || page > total {
+		page = 1
+	}
+	switch action {
+	case "next":
+		page = page%total + 1
+	case "prev":
+		page = (page-2+total)%total + 1
+	}
+
+	embed, components := h.renderOffer(offer, page, e.User().Username)
+	return e.UpdateMessage(discord.MessageUpdate{
+		Embeds:     &[]discord.Embed{embed},
+		Components: &components,
+	})
+}
+
+// handleOfferPick grants the selected card, charges the claim and closes the offer
+func (h *ClaimHandler) handleOfferPick(e *handler.ComponentEvent, claimerID string, page int) error {
+	ctx := e.Ctx
+
+	card, exp, cost, err := h.bot.ClaimManager.TakeOffer(claimerID, page-1)
+	if err != nil {
+		if errors.Is(err, claim.ErrInvalidPick) {
+			return utils.EH.CreateEphemeralError(e, "That card is not part of your offer.")
+		}
+		return h.closeOffer(e, err)
+	}
+	// The offer is consumed either way; end the session so the cooldown applies
+	defer h.bot.ClaimManager.ReleaseClaim(claimerID)
+
+	if err := claimCard(ctx, h.bot, card.ID, claimerID, cost, exp); err != nil {
+		slog.Error("Failed to claim offered card",
+			slog.String("user_id", claimerID),
+			slog.Int64("card_id", card.ID),
+			slog.Any("error", err))
+		return h.closeOffer(e, fmt.Errorf("claim failed: %w", err))
+	}
+
+	go h.bot.CompletionChecker.CheckCompletionForCards(context.Background(), claimerID, []int64{card.ID})
+	if h.bot.EffectManager != nil {
+		go h.bot.EffectManager.UpdateEffectProgress(context.Background(), claimerID, "cakeday", 1)
+	}
+	if h.bot.QuestTracker != nil {
+		go h.bot.QuestTracker.TrackCardClaim(context.Background(), claimerID, 1)
+	}
+
+	stars := utils.GetPromoRarityDisplay(card.ColID, card.Level)
+	embed := discord.NewEmbedBuilder().
+		SetDescription(fmt.Sprintf("**✨ You picked**\n\n%s **[%s](%s)** `[%s]`\n\nSpent: **%d** ❄",
+			stars, utils.FormatCardName(card.Name), getCardImageURL(card, h.bot), strings.ToUpper(card.ColID), cost)).
+		SetColor(utils.SuccessColor).
+		SetImage(getCardImageURL(card, h.bot)).
+		SetFooter(fmt.Sprintf("Claimed by %s", e.User().Username), "").
+		Build()
+
+	return e.UpdateMessage(discord.MessageUpdate{
+		Embeds:     &[]discord.Embed{embed},
+		Components: &[]discord.ContainerComponent{},
+	})
+}
+
+// closeOffer replaces an offer message that can no longer be picked from
+func (h *ClaimHandler) closeOffer(e *handler.ComponentEvent, cause error) error {
+	description := "This claim offer is no longer available."
+	switch {
+	case errors.Is(cause, claim.ErrOfferExpired):
+		description = "This claim offer expired. Nothing was charged."
+	case errors.Is(cause, claim.ErrNoOffer):
+		description = "This claim offer was already picked or has expired."
+	case errors.Is(cause, repositories.ErrCardSoldOut):
+		description = "That card sold out before you picked it. Nothing was charged."
+	case cause != nil && strings.Contains(cause.Error(), "insufficient balance"):
+		description = "You no longer have enough ❄ for this claim. Nothing was charged."
+	case cause != nil:
+		description = "Failed to claim the card. Nothing was charged, please try again."
+	}
+
+	return e.UpdateMessage(discord.MessageUpdate{
+		Embeds: &[]discord.Embed{
+			discord.NewEmbedBuilder().
+				SetDescription(description).
+				SetColor(utils.ErrorColor).
+				Build(),
+		},
+		Components: &[]discord.ContainerComponent{},
+	})
+}
@@ -28,7 +28,6 @@ var CollectionProgress = discord.SlashCommandCreate{
 }
 
 func CollectionProgressHandler(b *bottemplate.Bot) handler.CommandHandler {
-	collectionService := services.NewCollectionService(b.CollectionRepository, b.CardRepository, b.UserCardRepository)
 	imageService := services.NewLeaderboardImageService()
 
 	return func(event *handler.CommandEvent) error {
@@ -80,7 +79,7 @@ func CollectionProgressHandler(b *bottemplate.Bot) handler.CommandHandler {
 				return
 			}
 			collection := collections[0]
-			progressResults, err := collectionService.GetCollectionLeaderboard(ctx, collection.ID, limit)
+			progressResults, err := b.CollectionService.GetCollectionLeaderboard(ctx, collection.ID, limit)
 			if err != nil {
 				slog.Error("Failed to get collection progress",
 					slog.String("type", "cmd"),
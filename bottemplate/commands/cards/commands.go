@@ -10,6 +10,7 @@ var Commands = []discord.ApplicationCommandCreate{
 	Claim,
 	LevelUp,
 	Forge,
+	Hero,
 	LimitedCards,
 	LimitedStats,
 	CollectionList,
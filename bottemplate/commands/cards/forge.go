@@ -3,6 +3,7 @@ package cards
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -24,14 +25,16 @@ var Forge = discord.SlashCommandCreate{
 	Description: "✨ Forge two cards into a new one",
 	Options: []discord.ApplicationCommandOption{
 		discord.ApplicationCommandOptionString{
-			Name:        "card_query_1",
-			Description: "First card to forge (ID or name)",
-			Required:    true,
+			Name:         "card_query_1",
+			Description:  "First card to forge (ID or name)",
+			Required:     true,
+			Autocomplete: true,
 		},
 		discord.ApplicationCommandOptionString{
-			Name:        "card_query_2",
-			Description: "Second card to forge (ID or name)",
-			Required:    false,
+			Name:         "card_query_2",
+			Description:  "Second card to forge (ID or name)",
+			Required:     false,
+			Autocomplete: true,
 		},
 	},
 }
@@ -54,7 +57,7 @@ func (h *ForgeHandler) HandleForge(e *handler.CommandEvent) error {
 		return err
 	}
 
-	fm := forge.NewForgeManager(h.bot.DB, h.bot.PriceCalculator)
+	fm := forge.NewForgeManager(h.bot.DB, h.bot.PriceCalculator).WithConfig(h.bot.Cfg.Forge)
 	query1 := strings.TrimSpace(e.SlashCommandInteractionData().String("card_query_1"))
 	query2 := strings.TrimSpace(e.SlashCommandInteractionData().String("card_query_2"))
 	ctx := context.Background()
@@ -81,16 +84,21 @@ func (h *ForgeHandler) HandleForge(e *handler.CommandEvent) error {
 		return utils.EH.UpdateInteractionResponse(e, "Forge", "You must use two different cards to forge")
 	}
 
+	recipe, ok := fm.Recipe(card1.Level)
+	if !ok {
+		return utils.EH.UpdateInteractionResponse(e, "Forge", fmt.Sprintf("Level %d cards cannot be forged", card1.Level))
+	}
+
 	// Calculate forge cost with effect discounts
 	cost, err := fm.CalculateForgeCostWithEffects(ctx, card1, card2, userID, h.bot.EffectIntegrator)
 	if err != nil {
 		return utils.EH.UpdateInteractionResponse(e, "Forge", fmt.Sprintf("Error calculating forge cost: %v", err))
 	}
 
-	return h.showForgeConfirmation(e, card1, card2, cost)
+	return h.showForgeConfirmation(e, card1, card2, cost, recipe)
 }
 
-func (h *ForgeHandler) showForgeConfirmation(e *handler.CommandEvent, card1, card2 *models.Card, cost int64) error {
+func (h *ForgeHandler) showForgeConfirmation(e *handler.CommandEvent, card1, card2 *models.Card, cost int64, recipe forge.Recipe) error {
 	// Get group type from card tags
 	groupType := "girlgroups" // default
 	for _, tag := range card1.Tags {
@@ -122,13 +130,13 @@ func (h *ForgeHandler) showForgeConfirmation(e *handler.CommandEvent, card1, car
 			"## Forge Information\n"+
 			"• Level: %s\n"+
 			"• Cost: %d 💰\n"+
-			"• Result: Random %s card%s\n\n"+
+			"• Result: %s%s\n\n"+
 			"⚠️ **Warning:** This action cannot be undone!",
 			card1Display.FormattedName, card1Display.ImageURL, card1Display.FormattedCollection,
 			card2Display.FormattedName, card2Display.ImageURL, card2Display.FormattedCollection,
 			utils.GetPromoRarityPlainText(card1.ColID, card1.Level),
 			cost,
-			utils.GetPromoRarityPlainText(card1.ColID, card1.Level),
+			describeForgeResult(recipe, card1.ColID),
 			getSameCollectionBonus(card1, card2))).
 		SetImage(card1Display.ImageURL).
 		SetTimestamp(time.Now()).
@@ -147,13 +155,35 @@ func (h *ForgeHandler) showForgeConfirmation(e *handler.CommandEvent, card1, car
 	return err
 }
 
+// describeForgeResult names the possible result levels of a recipe with their chances
+func describeForgeResult(recipe forge.Recipe, colID string) string {
+	chances := recipe.Chances()
+	if len(chances) == 1 {
+		for level := range chances {
+			return fmt.Sprintf("Random %s card", utils.GetPromoRarityPlainText(colID, level))
+		}
+	}
+
+	levels := make([]int, 0, len(chances))
+	for level := range chances {
+		levels = append(levels, level)
+	}
+	sort.Ints(levels)
+
+	parts := make([]string, len(levels))
+	for i, level := range levels {
+		parts[i] = fmt.Sprintf("%s (%.0f%%)", utils.GetPromoRarityPlainText(colID, level), chances[level]*100)
+	}
+	return "Random card: " + strings.Join(parts, ", ")
+}
+
 func (h *ForgeHandler) HandleComponent(e *handler.ComponentEvent) error {
 	// Defer immediately to acknowledge interaction, then update/ follow up
 	if err := e.DeferUpdateMessage(); err != nil {
 		return err
 	}
 
-	fm := forge.NewForgeManager(h.bot.DB, h.bot.PriceCalculator)
+	fm := forge.NewForgeManager(h.bot.DB, h.bot.PriceCalculator).WithConfig(h.bot.Cfg.Forge)
 	userID := int64(e.User().ID)
 	ctx := context.Background()
 
@@ -194,7 +224,7 @@ func (h *ForgeHandler) HandleComponent(e *handler.ComponentEvent) error {
 			go h.bot.EffectManager.UpdateEffectProgress(context.Background(), userIDStr, "cherrybloss", 1)
 		}
 		userIDStr := strconv.FormatInt(userID, 10)
-		go h.bot.CompletionChecker.CheckCompletionForCards(context.Background(), userIDStr, []int64{newCard.ID})
+		go h.bot.CompletionChecker.CheckCompletionForCards(context.Background(), userIDStr, []int64{newCard.ID, card1ID, card2ID})
 
 		// Get group type from card tags
 		groupType := "girlgroups" // default
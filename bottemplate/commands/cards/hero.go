@@ -0,0 +1,220 @@
+package cards
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/economy/effects"
+	"github.com/disgoorg/bot-template/bottemplate/services"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var Hero = discord.SlashCommandCreate{
+	Name:        "hero",
+	Description: "Set the cards in your hero slots to boost your /work rewards",
+	Options: []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionSubCommand{
+			Name:        "set",
+			Description: "Put one of your cards in a hero slot",
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionString{
+					Name:         "card_name",
+					Description:  "The name or ID of the card",
+					Required:     true,
+					Autocomplete: true,
+				},
+				discord.ApplicationCommandOptionInt{
+					Name:        "slot",
+					Description: "The hero slot to use, defaults to the first free one",
+					Required:    false,
+					MinValue:    utils.Ptr(1),
+					MaxValue:    utils.Ptr(effects.HeroBaseSlots + effects.HeroPremiumSlots),
+				},
+			},
+		},
+		discord.ApplicationCommandOptionSubCommand{
+			Name:        "view",
+			Description: "Show your hero slots and the bonus they give",
+		},
+	},
+}
+
+func HeroHandler(b *bottemplate.Bot) handler.CommandHandler {
+	cardOperationsService := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository)
+
+	return func(e *handler.CommandEvent) error {
+		ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+		defer cancel()
+
+		userID := e.User().ID.String()
+		user, err := b.UserRepository.GetByDiscordID(ctx, userID)
+		if err != nil {
+			return utils.EH.CreateSystemError(e, "Failed to fetch your profile")
+		}
+
+		data := e.SlashCommandInteractionData()
+		switch *data.SubCommandName {
+		case "set":
+			return handleHeroSet(ctx, b, e, cardOperationsService, user)
+		case "view":
+			return handleHeroView(ctx, b, e, user)
+		default:
+			return utils.EH.CreateUserError(e, "Invalid subcommand")
+		}
+	}
+}
+
+func handleHeroSet(ctx context.Context, b *bottemplate.Bot, e *handler.CommandEvent, cardOperationsService *services.CardOperationsService, user *models.User) error {
+	data := e.SlashCommandInteractionData()
+	query := strings.TrimSpace(data.String("card_name"))
+	now := time.Now()
+
+	card, err := findOwnedHeroCard(ctx, b, cardOperationsService, user.DiscordID, query)
+	if err != nil {
+		return utils.EH.CreateSystemError(e, "Failed to fetch your collection")
+	}
+	if card == nil {
+		return utils.EH.CreateUserError(e, fmt.Sprintf("You don't own a card matching '%s'", query))
+	}
+
+	slot := defaultHeroSlot(user, now)
+	if s, ok := data.OptInt("slot"); ok {
+		slot = s - 1
+	}
+
+	previousChange := user.HeroChanged
+	if err := effects.AssignHero(user, slot, card.ID, now); err != nil {
+		return utils.EH.CreateUserError(e, err.Error())
+	}
+	saved, err := b.UserRepository.UpdateHero(ctx, user, previousChange)
+	if err != nil {
+		return utils.EH.CreateSystemError(e, "Failed to save your hero")
+	}
+	if !saved {
+		return utils.EH.CreateUserError(e, "Your heroes were changed in the meantime, please try again")
+	}
+
+	return e.CreateMessage(discord.MessageCreate{
+		Embeds: []discord.Embed{{
+			Title: "Hero Set",
+			Description: fmt.Sprintf("%s %s is now your hero in slot %d.\nIt adds **+%.0f%%** to your /work rewards. This slot can be changed again <t:%d:R>.",
+				utils.GetPromoRarityPlainText(card.ColID, card.Level),
+				utils.FormatCardName(card.Name),
+				slot+1,
+				float64(card.Level)*effects.HeroWorkBonusPerStar*100,
+				now.Add(effects.HeroChangeCooldown).Unix()),
+			Color: config.SuccessColor,
+		}},
+	})
+}
+
+func handleHeroView(ctx context.Context, b *bottemplate.Bot, e *handler.CommandEvent, user *models.User) error {
+	now := time.Now()
+	slots := effects.HeroSlotCount(user, now)
+
+	ids := effects.ActiveHeroCardIDs(user, now)
+	cardsByID := make(map[int64]*models.Card, len(ids))
+	if len(ids) > 0 {
+		heroCards, err := b.CardRepository.GetByIDs(ctx, ids)
+		if err != nil {
+			return utils.EH.CreateSystemError(e, "Failed to fetch your hero cards")
+		}
+		for _, card := range heroCards {
+			cardsByID[card.ID] = card
+		}
+	}
+
+	var description strings.Builder
+	for slot := 0; slot < slots; slot++ {
+		description.WriteString(fmt.Sprintf("**Slot %d:** ", slot+1))
+		if card, ok := cardsByID[effects.HeroCardID(user, slot)]; ok {
+			description.WriteString(fmt.Sprintf("%s %s", utils.GetPromoRarityPlainText(card.ColID, card.Level), utils.FormatCardName(card.Name)))
+		} else {
+			description.WriteString("*empty*")
+		}
+		if readyAt := effects.HeroSlotReadyAt(user, slot); readyAt.After(now) {
+			description.WriteString(fmt.Sprintf(" · changeable <t:%d:R>", readyAt.Unix()))
+		}
+		description.WriteString("\n")
+	}
+
+	bonus := 0.0
+	if b.EffectIntegrator != nil {
+		bonus = b.EffectIntegrator.HeroWorkBonus(ctx, user.DiscordID)
+	}
+	description.WriteString(fmt.Sprintf("\n/work bonus: **+%.0f%%**", bonus*100))
+
+	return e.CreateMessage(discord.MessageCreate{
+		Embeds: []discord.Embed{{
+			Title:       "Your Heroes",
+			Description: description.String(),
+			Color:       config.InfoColor,
+			Footer: &discord.EmbedFooter{
+				Text: fmt.Sprintf("Each hero adds %.0f%% per star · premium users get %d extra slot", effects.HeroWorkBonusPerStar*100, effects.HeroPremiumSlots),
+			},
+		}},
+	})
+}
+
+// findOwnedHeroCard returns the best match for query among the cards the user owns,
+// or nil when none match. Ownership is re-checked since the collection search is cached.
+func findOwnedHeroCard(ctx context.Context, b *bottemplate.Bot, cardOperationsService *services.CardOperationsService, userID, query string) (*models.Card, error) {
+	userCards, cards, err := cardOperationsService.GetUserCardsWithDetails(ctx, userID, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(userCards) == 0 || len(cards) == 0 {
+		return nil, nil
+	}
+
+	_, cardMap := cardOperationsService.BuildCardMappings(userCards, cards)
+	var ownedCards []*models.Card
+	for _, userCard := range userCards {
+		if userCard.Amount > 0 {
+			if card, exists := cardMap[userCard.CardID]; exists {
+				ownedCards = append(ownedCards, card)
+			}
+		}
+	}
+	if len(ownedCards) == 0 {
+		return nil, nil
+	}
+
+	card, err := services.NewUnifiedSearchService(cardOperationsService).SearchSingleCard(ctx, ownedCards, query)
+	if err != nil || card == nil {
+		card = ownedCards[0]
+	}
+
+	userCard, err := b.UserCardRepository.GetByUserIDAndCardID(ctx, userID, card.ID)
+	if err != nil {
+		return nil, err
+	}
+	if userCard == nil || userCard.Amount <= 0 {
+		return nil, nil
+	}
+	return card, nil
+}
+
+// defaultHeroSlot picks the first empty slot, then the first slot off cooldown,
+// falling back to the first slot
+func defaultHeroSlot(user *models.User, now time.Time) int {
+	slots := effects.HeroSlotCount(user, now)
+	for slot := 0; slot < slots; slot++ {
+		if effects.HeroCardID(user, slot) == 0 {
+			return slot
+		}
+	}
+	for slot := 0; slot < slots; slot++ {
+		if !effects.HeroSlotReadyAt(user, slot).After(now) {
+			return slot
+		}
+	}
+	return 0
+}
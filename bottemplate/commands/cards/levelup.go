@@ -31,6 +31,11 @@ var LevelUp = discord.SlashCommandCreate{
 			Description: "Optional: Name or ID of another card to combine with",
 			Required:    false,
 		},
+		&discord.ApplicationCommandOptionBool{
+			Name:        "preview",
+			Description: "Show what the card needs for its next level without leveling it",
+			Required:    false,
+		},
 	},
 }
 
@@ -78,17 +83,23 @@ func (c *LevelUpCommand) Handle(event *handler.CommandEvent) error {
 		return createErrorEmbed(event, "Card Not Eligible", "This card cannot be leveled up because it is from a restricted collection, locked, or already max level.")
 	}
 
+	applyExpBoosts := func(baseXP int64) int64 {
+		if c.bot != nil && c.bot.EffectIntegrator != nil {
+			return c.bot.EffectIntegrator.ApplyCardLevelupXP(ctx, event.User().ID.String(), card.ColID, baseXP)
+		}
+		return baseXP
+	}
+
+	if preview, ok := event.SlashCommandInteractionData().OptBool("preview"); ok && preview {
+		return c.handlePreview(event, card, userCard, applyExpBoosts)
+	}
+
 	if combineWith := event.SlashCommandInteractionData().String("combine_with"); combineWith != "" {
 		return c.handleCombine(event, userCard, combineWith)
 	}
 
 	oldLevel := userCard.Level
-	result, err := c.levelingService.GainExpWithModifier(ctx, userCard, func(baseXP int64) int64 {
-		if c.bot != nil && c.bot.EffectIntegrator != nil {
-			return c.bot.EffectIntegrator.ApplyLevelupXP(ctx, event.User().ID.String(), baseXP)
-		}
-		return baseXP
-	})
+	result, err := c.levelingService.GainExpWithModifier(ctx, userCard, applyExpBoosts)
 	if err != nil {
 		if err.Error() == "exp gain on cooldown" {
 			return createCooldownEmbed(event, userCard)
@@ -305,6 +316,51 @@ func (c *LevelUpCommand) handleCombine(event *handler.CommandEvent, mainCard *mo
 	return nil
 }
 
+// handlePreview shows the EXP and level ups a card needs for its next level,
+// counting active EXP boosts, without changing the card
+func (c *LevelUpCommand) handlePreview(event *handler.CommandEvent, card *models.Card, userCard *models.UserCard, applyExpBoosts func(int64) int64) error {
+	preview, err := c.levelingService.Preview(userCard, applyExpBoosts)
+	if err != nil {
+		return createErrorEmbed(event, "Preview Failed", err.Error())
+	}
+
+	var description string
+	if preview.MaxLevel {
+		description = fmt.Sprintf("**%s** is already at the maximum level.", utils.FormatCardName(card.Name))
+	} else {
+		description = fmt.Sprintf("**%s**\n"+
+			"``%s``\n\n"+
+			"```ansi\n"+
+			"\x1b[1;33mLevel %d → %d\x1b[0m\n"+
+			"\x1b[0;37mProgress Bar:\x1b[0m %s\n"+
+			"\x1b[1;36mCurrent EXP:\x1b[0m %d/%d\n"+
+			"\x1b[1;36mEXP Needed:\x1b[0m %d\n\n"+
+			"\x1b[1;36mEXP per Level Up:\x1b[0m ~%d\n"+
+			"\x1b[1;32mLevel Ups Needed:\x1b[0m ~%d\n"+
+			"```\n"+
+			"Estimates include active EXP boosts; critical gains can finish sooner.",
+			utils.FormatCardName(card.Name),
+			utils.FormatCollectionName(card.ColID),
+			preview.Level,
+			preview.Level+1,
+			createExpBar(preview.CurrentExp, preview.RequiredExp),
+			preview.CurrentExp,
+			preview.RequiredExp,
+			preview.RemainingExp,
+			preview.ExpPerLevelUp,
+			preview.LevelUpsNeeded)
+	}
+
+	_, err = event.CreateFollowupMessage(discord.MessageCreate{
+		Embeds: []discord.Embed{{
+			Title:       "Level Preview",
+			Description: description,
+			Color:       config.BackgroundColor,
+		}},
+	})
+	return err
+}
+
 func createErrorEmbed(event *handler.CommandEvent, title, description string) error {
 	_, err := event.CreateFollowupMessage(discord.MessageCreate{
 		Embeds: []discord.Embed{{
@@ -421,8 +477,11 @@ func (c *LevelUpCommand) findCard(ctx context.Context, userID, query string) (*m
 }
 
 func LevelUpHandler(b *bottemplate.Bot) handler.CommandHandler {
+	levelingConfig := cardleveling.NewDefaultConfig()
+	levelingConfig.ExpCurve = b.Cfg.Leveling.ExpCurve
+
 	levelingService := cardleveling.NewService(
-		cardleveling.NewDefaultConfig(),
+		levelingConfig,
 		b.CardRepository,
 	)
 
@@ -28,12 +28,18 @@ var SearchCards = discord.SlashCommandCreate{
 			Description: "Search query (supports level=3, !promo, #girlgroups, etc.)",
 			Required:    false,
 		},
+		discord.ApplicationCommandOptionBool{
+			Name:        "prices",
+			Description: "Show the current market price of each card",
+			Required:    false,
+		},
 	},
 }
 
 type cacheEntry struct {
 	results    []*models.Card
 	totalCount int
+	prices     map[int64]int64 // looked up the first time the page is shown with prices
 	timestamp  time.Time
 }
 
@@ -81,10 +87,21 @@ func (sc *searchCache) set(key string, cards []*models.Card, totalCount int) {
 		time.Now().Add(utils.CacheExpiration))
 }
 
+// setPrices remembers the prices looked up for a cached page
+func (sc *searchCache) setPrices(key string, prices map[int64]int64) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if entry, exists := sc.cache[key]; exists {
+		entry.prices = prices
+	}
+}
+
 func SearchCardsHandler(b *bottemplate.Bot) handler.CommandHandler {
 	return func(event *handler.CommandEvent) error {
 		// Get single query parameter
 		query := strings.TrimSpace(event.SlashCommandInteractionData().String("query"))
+		withPrices := event.SlashCommandInteractionData().Bool("prices")
 
 		// Use enhanced search filters for parsing
 		var repoFilters repositories.SearchFilters
@@ -109,7 +126,7 @@ func SearchCardsHandler(b *bottemplate.Bot) handler.CommandHandler {
 
 		// Try to get results from cache first
 		if entry, exists := cardSearchCache.get(cacheKey); exists {
-			return createPaginator(b, event, entry.results, entry.totalCount, repoFilters)
+			return createPaginator(b, event, entry.results, entry.totalCount, repoFilters, withPrices)
 		}
 
 		// Set timeout context
@@ -146,7 +163,7 @@ func SearchCardsHandler(b *bottemplate.Bot) handler.CommandHandler {
 			// Cache the results
 			cardSearchCache.set(cacheKey, result.cards, result.count)
 
-			return createPaginator(b, event, result.cards, result.count, repoFilters)
+			return createPaginator(b, event, result.cards, result.count, repoFilters, withPrices)
 
 		case <-ctx.Done():
 			return utils.EH.UpdateInteractionResponse(event, "Search Timeout", "Search took too long to complete")
@@ -181,7 +198,7 @@ func generateCacheKey(filters repositories.SearchFilters) string {
 	)
 }
 
-func createPaginator(b *bottemplate.Bot, e *handler.CommandEvent, initialCards []*models.Card, totalCount int, filters repositories.SearchFilters) error {
+func createPaginator(b *bottemplate.Bot, e *handler.CommandEvent, initialCards []*models.Card, totalCount int, filters repositories.SearchFilters, withPrices bool) error {
 	// Ensure totalCount is at least the length of initial cards
 	if totalCount < len(initialCards) {
 		totalCount = len(initialCards)
@@ -202,12 +219,17 @@ func createPaginator(b *bottemplate.Bot, e *handler.CommandEvent, initialCards [
 			// Try to get page from cache first
 			cacheKey := fmt.Sprintf("%s:page:%d", generateCacheKey(filters), page)
 			if entry, exists := cardSearchCache.get(cacheKey); exists {
-				description := buildSearchDescription(entry.results, filters, page+1, totalCount, totalPages)
+				prices := entry.prices
+				if withPrices && prices == nil {
+					prices = lookupCardPrices(b, entry.results)
+					cardSearchCache.setPrices(cacheKey, prices)
+				}
+				description := buildSearchDescription(entry.results, filters, prices, page+1, totalCount, totalPages)
 				embed.
 					SetTitle("🔍 Card Search Results").
 					SetDescription(description).
 					SetColor(0x000000).
-					SetFooter(fmt.Sprintf("Page %d/%d • Total: %d", page+1, totalPages, totalCount), "")
+					SetFooter(utils.FormatPageFooter(page, totalPages, utils.CardsPerPage, totalCount), "")
 				return
 			}
 
@@ -218,19 +240,50 @@ func createPaginator(b *bottemplate.Bot, e *handler.CommandEvent, initialCards [
 			// Cache the page results
 			cardSearchCache.set(cacheKey, pageCards, totalCount)
 
-			description := buildSearchDescription(pageCards, filters, page+1, totalCount, totalPages)
+			var prices map[int64]int64
+			if withPrices {
+				prices = lookupCardPrices(b, pageCards)
+				cardSearchCache.setPrices(cacheKey, prices)
+			}
+
+			description := buildSearchDescription(pageCards, filters, prices, page+1, totalCount, totalPages)
 			embed.
 				SetTitle("🔍 Card Search Results").
 				SetDescription(description).
 				SetColor(0x000000).
-				SetFooter(fmt.Sprintf("Page %d/%d • Total: %d", page+1, totalPages, totalCount), "")
+				SetFooter(utils.FormatPageFooter(page, totalPages, utils.CardsPerPage, totalCount), "")
 		},
 		Pages:      totalPages,
 		ExpireMode: paginator.ExpireModeAfterLastUsage,
 	}, false)
 }
 
-func buildSearchDescription(cards []*models.Card, filters repositories.SearchFilters, _, _, _ int) string {
+// lookupCardPrices returns the current market price of each card on a page. A failed
+// lookup is logged and the page is shown without prices.
+func lookupCardPrices(b *bottemplate.Bot, cards []*models.Card) map[int64]int64 {
+	if b.PriceCalculator == nil || len(cards) == 0 {
+		return nil
+	}
+
+	cardIDs := make([]int64, len(cards))
+	for i, card := range cards {
+		cardIDs[i] = card.ID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), utils.SearchTimeout)
+	defer cancel()
+
+	prices, err := b.PriceCalculator.GetLastPrices(ctx, cardIDs)
+	if err != nil {
+		log.Printf("Failed to look up card prices: %v", err)
+		return nil
+	}
+	return prices
+}
+
+// buildSearchDescription renders a page of results; prices is nil unless the
+// prices option was set, and cards without a known price show none
+func buildSearchDescription(cards []*models.Card, filters repositories.SearchFilters, prices map[int64]int64, _, _, _ int) string {
 	var description strings.Builder
 	description.WriteString("```md\n")
 
@@ -268,11 +321,17 @@ func buildSearchDescription(cards []*models.Card, filters repositories.SearchFil
 				animatedIcon = "✨"
 			}
 
-			description.WriteString(fmt.Sprintf("* %s %s%s [%s]\n",
+			priceText := ""
+			if price, ok := prices[card.ID]; ok {
+				priceText = fmt.Sprintf(" • %d ❄", price)
+			}
+
+			description.WriteString(fmt.Sprintf("* %s %s%s [%s]%s\n",
 				utils.GetPromoRarityPlainText(card.ColID, card.Level),
 				utils.FormatCardName(card.Name),
 				animatedIcon,
 				strings.Trim(utils.FormatCollectionName(card.ColID), "[]"),
+				priceText,
 			))
 		}
 	}
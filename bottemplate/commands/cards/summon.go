@@ -3,6 +3,7 @@ package cards
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -54,6 +55,14 @@ func SummonHandler(b *bottemplate.Bot) handler.CommandHandler {
 		ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
 		defer cancel()
 
+		user, err := b.UserRepository.GetByDiscordID(ctx, userID)
+		if err != nil {
+			return utils.EH.CreateSystemError(e, "Failed to fetch your profile")
+		}
+		if cooldown := utils.CheckCooldown(user.LastSummon, config.SummonCooldown); cooldown.Active() {
+			return utils.EH.UpdateInteractionResponse(e, "Summon Cooldown", fmt.Sprintf("Summoning is free, but you can summon again %s.", cooldown.RelativeTimestamp()))
+		}
+
 		// Use CardOperationsService to get user cards with search applied
 		userCards, cards, err := cardOperationsService.GetUserCardsWithDetails(ctx, userID, cardName)
 		if err != nil {
@@ -97,6 +106,11 @@ func SummonHandler(b *bottemplate.Bot) handler.CommandHandler {
 		if err := displayCard(e, matchedCard, b, cardDisplayService); err != nil {
 			return err
 		}
+		if err := b.UserRepository.UpdateLastSummon(ctx, userID); err != nil {
+			slog.Warn("Failed to record summon cooldown",
+				slog.String("user_id", userID),
+				slog.Any("error", err))
+		}
 		if b.QuestTracker != nil {
 			go b.QuestTracker.TrackCardDrawWithCardID(context.Background(), userID, matchedCard.ID)
 		}
@@ -107,13 +121,13 @@ func SummonHandler(b *bottemplate.Bot) handler.CommandHandler {
 // displayCard handles the card display logic
 func displayCard(e *handler.CommandEvent, card *models.Card, b *bottemplate.Bot, _ *services.CardDisplayService) error {
 	// Use the existing CardDisplayService pattern
-	config := b.SpacesService.GetSpacesConfig()
+	spacesConfig := b.SpacesService.GetSpacesConfig()
 	cardInfo := utils.GetCardDisplayInfo(
 		card.Name,
 		card.ColID,
 		card.Level,
 		utils.GetGroupType(card.Tags),
-		config,
+		spacesConfig,
 	)
 
 	timestamp := fmt.Sprintf("<t:%d:R>", time.Now().Unix())
@@ -129,12 +143,14 @@ func displayCard(e *handler.CommandEvent, card *models.Card, b *bottemplate.Bot,
 			"%s\n"+
 			"```\n"+
 			"> %s\n\n"+
+			"Cost: **Free** • Cooldown: **%s**\n"+
 			"Use `/inventory` to view your collection",
 			cardInfo.FormattedCollection,
 			strings.Repeat("⭐", card.Level),
 			card.ID,
 			utils.GetAnimatedTag(card.Animated),
-			getCardQuote(card.Level)),
+			getCardQuote(card.Level),
+			config.SummonCooldown),
 		Image: &discord.EmbedResource{
 			URL: cardInfo.ImageURL,
 		},
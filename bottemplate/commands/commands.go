@@ -18,3 +18,29 @@ func init() {
 	Commands = append(Commands, social.Commands...)
 	Commands = append(Commands, system.Commands...)
 }
+
+// Command categories used for per-guild enablement
+const (
+	CategoryAdmin   = "admin"
+	CategoryCards   = "cards"
+	CategoryEconomy = "economy"
+	CategorySocial  = "social"
+	CategorySystem  = "system"
+)
+
+// CommandCategories maps every command name to its category
+func CommandCategories() map[string]string {
+	categories := make(map[string]string, len(Commands))
+	for category, cmds := range map[string][]discord.ApplicationCommandCreate{
+		CategoryAdmin:   admin.Commands,
+		CategoryCards:   cards.Commands,
+		CategoryEconomy: economy.Commands,
+		CategorySocial:  social.Commands,
+		CategorySystem:  system.Commands,
+	} {
+		for _, cmd := range cmds {
+			categories[cmd.CommandName()] = category
+		}
+	}
+	return categories
+}
@@ -0,0 +1,20 @@
+package commands
+
+import "testing"
+
+func TestCommandCategories(t *testing.T) {
+	categories := CommandCategories()
+	if len(categories) != len(Commands) {
+		t.Errorf("%d categorized commands, want all %d", len(categories), len(Commands))
+	}
+
+	for command, want := range map[string]string{
+		"guild-config":      CategoryAdmin,
+		"claim":             CategoryCards,
+		"quest-leaderboard": CategorySystem,
+	} {
+		if got := categories[command]; got != want {
+			t.Errorf("category of %s = %q, want %q", command, got, want)
+		}
+	}
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/disgoorg/bot-template/bottemplate/config"
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
 	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/economy"
 	"github.com/disgoorg/bot-template/bottemplate/economy/auction"
+	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
@@ -69,6 +71,18 @@ var AuctionCommand = discord.SlashCommandCreate{
 	},
 }
 
+var AuctionsCommand = discord.SlashCommandCreate{
+	Name:        "auctions",
+	Description: "Browse active auctions, ending soonest first",
+	Options: []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionString{
+			Name:        "query",
+			Description: "Card search query (e.g. name, -3, -collection)",
+			Required:    false,
+		},
+	},
+}
+
 type AuctionHandler struct {
 	bot      *bottemplate.Bot
 	manager  *auction.Manager
@@ -91,13 +105,17 @@ func (h *AuctionHandler) Register(r handler.Router) {
 		r.Command("/bid", h.HandleBid)
 		r.Command("/list", h.HandleList)
 	})
+	r.Command("/auctions", h.HandleSearch)
 
 	// Component patterns must start with /
 	r.Component("/auction/confirm", h.HandleConfirmation)
 	r.Component("/auction/cancel", h.HandleCancel)
+	r.Component("/auction/watch", h.HandleWatch)
+	r.Component("/auction/unwatch", h.HandleUnwatch)
 
 	// Register auction list pagination components
 	r.Component("/auction-list/", h.CreateAuctionListComponentHandler())
+	r.Component("/auction-search/", h.newAuctionSearchFactory().CreateHandler())
 }
 
 func (h *AuctionHandler) HandleCreate(event *handler.CommandEvent) error {
@@ -135,7 +153,7 @@ func (h *AuctionHandler) HandleCreate(event *handler.CommandEvent) error {
 	}
 
 	// Create confirmation embed
-	embed := discord.NewEmbedBuilder().
+	builder := discord.NewEmbedBuilder().
 		SetTitle("🏛️ Confirm Auction Creation").
 		SetDescription(fmt.Sprintf("Please confirm that you want to create an auction for **%s**", utils.FormatCardName(card.Name))).
 		AddField("Card", fmt.Sprintf("%s %s", utils.GetPromoRarityPlainText(card.ColID, card.Level), utils.FormatCardName(card.Name)), false).
@@ -143,8 +161,9 @@ func (h *AuctionHandler) HandleCreate(event *handler.CommandEvent) error {
 		AddField("Duration", formatDuration(duration), true).
 		AddField("Collection", strings.ToUpper(card.ColID), true).
 		SetColor(config.BackgroundColor).
-		SetFooter("This auction will be visible to all users", "").
-		Build()
+		SetFooter("This auction will be visible to all users", "")
+	addAuctionFeeFields(builder, h.manager.Fees(), startPrice)
+	embed := builder.Build()
 
 	// Create confirmation buttons (restrict to command user)
 	ownerID := event.User().ID.String()
@@ -215,16 +234,96 @@ func (h *AuctionHandler) HandleBid(event *handler.CommandEvent) error {
 		go h.bot.QuestTracker.TrackAuctionBid(context.Background(), event.User().ID.String())
 	}
 
+	auction.CurrentPrice = amount
+	return event.CreateMessage(discord.MessageCreate{
+		Content: fmt.Sprintf("Successfully placed bid of %d 💰 on auction %s. The next minimum bid is %d 💰.",
+			amount, auction.AuctionID, h.manager.MinimumBid(auction)),
+		Components: watchComponents(auction.ID),
+		Flags:      discord.MessageFlagEphemeral,
+	})
+}
+
+// HandleWatch subscribes the user to DMs about an auction: /auction/watch/{auctionID}
+func (h *AuctionHandler) HandleWatch(event *handler.ComponentEvent) error {
+	auctionID, err := parseWatchAuctionID(event.Data.CustomID())
+	if err != nil {
+		return utils.EH.CreateEphemeralError(event, "Invalid auction.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+	defer cancel()
+
+	added, err := h.manager.Watch(ctx, auctionID, event.User().ID.String())
+	if err != nil {
+		return utils.EH.CreateEphemeralError(event, fmt.Sprintf("Failed to watch auction: %s", err))
+	}
+
+	content := "👀 You're now watching this auction. You'll get a DM on new bids and when it's about to end."
+	if !added {
+		content = "You're already watching this auction."
+	}
+	return event.CreateMessage(discord.MessageCreate{
+		Content: content,
+		Flags:   discord.MessageFlagEphemeral,
+	})
+}
+
+// HandleUnwatch stops the user's DMs about an auction: /auction/unwatch/{auctionID}
+func (h *AuctionHandler) HandleUnwatch(event *handler.ComponentEvent) error {
+	auctionID, err := parseWatchAuctionID(event.Data.CustomID())
+	if err != nil {
+		return utils.EH.CreateEphemeralError(event, "Invalid auction.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+	defer cancel()
+
+	removed, err := h.manager.Unwatch(ctx, auctionID, event.User().ID.String())
+	if err != nil {
+		return utils.EH.CreateEphemeralError(event, fmt.Sprintf("Failed to unwatch auction: %s", err))
+	}
+
+	content := "You're no longer watching this auction."
+	if !removed {
+		content = "You weren't watching this auction."
+	}
 	return event.CreateMessage(discord.MessageCreate{
-		Content: fmt.Sprintf("Successfully placed bid of %d 💰 on auction %s", amount, auction.AuctionID),
+		Content: content,
 		Flags:   discord.MessageFlagEphemeral,
 	})
 }
 
+// watchComponents offers the Watch and Unwatch buttons for an auction
+func watchComponents(auctionID int64) []discord.ContainerComponent {
+	return []discord.ContainerComponent{auction.WatchActionRow(auctionID)}
+}
+
+func parseWatchAuctionID(customID string) (int64, error) {
+	parts := strings.Split(customID, "/")
+	if len(parts) != 4 { // /auction/{watch|unwatch}/{auctionID}
+		return 0, fmt.Errorf("invalid watch ID format")
+	}
+	return strconv.ParseInt(parts[3], 10, 64)
+}
+
 func intPtr(v int) *int {
 	return &v
 }
 
+// addAuctionFeeFields previews the listing fee and what the seller receives if the
+// auction sells at its start price. Nothing is added when no fees are configured.
+func addAuctionFeeFields(builder *discord.EmbedBuilder, fees economy.AuctionFees, startPrice int64) {
+	listingFee := fees.Listing(startPrice)
+	bps := fees.CommissionBps()
+	if listingFee == 0 && bps == 0 {
+		return
+	}
+	commission := economicUtils.CommissionFor(startPrice, bps)
+	builder.AddField("Listing Fee", fmt.Sprintf("%d 💰 (charged now, not refunded)", listingFee), true)
+	builder.AddField("Commission", fmt.Sprintf("%.2f%% of the final price", float64(bps)/100), true)
+	builder.AddField("Net Payout at Start Price", fmt.Sprintf("%d 💰", startPrice-commission-listingFee), true)
+}
+
 func formatDuration(d time.Duration) string {
 	hours := int(d.Hours())
 	minutes := int(d.Minutes()) % 60
@@ -313,6 +412,76 @@ func (h *AuctionHandler) HandleCancel(event *handler.ComponentEvent) error {
 	})
 }
 
+// HandleSearch lists active auctions whose card matches the query, ending soonest first
+func (h *AuctionHandler) HandleSearch(event *handler.CommandEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+	defer cancel()
+
+	params := utils.PaginationParams{
+		UserID: event.User().ID.String(),
+		Page:   0,
+		Query:  strings.TrimSpace(event.SlashCommandInteractionData().String("query")),
+	}
+
+	embed, components, err := h.newAuctionSearchFactory().CreateInitialPaginationEmbed(ctx, params)
+	if err != nil {
+		if err.Error() == "no items found" {
+			content := "No active auctions found."
+			if params.Query != "" {
+				content = fmt.Sprintf("No active auctions match `%s`.", params.Query)
+			}
+			return event.CreateMessage(discord.MessageCreate{
+				Content: content,
+				Flags:   discord.MessageFlagEphemeral,
+			})
+		}
+		return event.CreateMessage(discord.MessageCreate{
+			Content: fmt.Sprintf("Failed to search auctions: %s", err),
+			Flags:   discord.MessageFlagEphemeral,
+		})
+	}
+
+	return event.CreateMessage(discord.MessageCreate{
+		Embeds:     []discord.Embed{embed},
+		Components: components,
+	})
+}
+
+func (h *AuctionHandler) newAuctionSearchFactory() *utils.PaginationFactory {
+	return utils.NewPaginationFactory(utils.PaginationFactoryConfig{
+		ItemsPerPage: 10,
+		Prefix:       "auction-search",
+		Parser:       utils.NewRegularParser("auction-search"),
+		Fetcher:      &AuctionSearchDataFetcher{manager: h.manager},
+		Formatter:    &AuctionListFormatter{},
+		Validator:    &AuctionListValidator{},
+	})
+}
+
+// AuctionSearchDataFetcher implements DataFetcher for /auctions, using the query from the params
+type AuctionSearchDataFetcher struct {
+	manager *auction.Manager
+}
+
+func (f *AuctionSearchDataFetcher) FetchData(ctx context.Context, params utils.PaginationParams) ([]interface{}, error) {
+	auctions, err := f.manager.SearchActiveAuctions(ctx, params.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, 0, len(auctions))
+	for _, auc := range auctions {
+		if auc.Card == nil {
+			continue
+		}
+		items = append(items, AuctionListItem{
+			Auction: auc,
+			Card:    auc.Card,
+		})
+	}
+	return items, nil
+}
+
 // CreateAuctionListComponentHandler creates component handler for auction list pagination
 func (h *AuctionHandler) CreateAuctionListComponentHandler() handler.ComponentHandler {
 	// Create data fetcher
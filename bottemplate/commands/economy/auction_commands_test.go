@@ -0,0 +1,44 @@
+package economy
+
+import (
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/economy"
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestParseWatchAuctionID(t *testing.T) {
+	if id, err := parseWatchAuctionID("/auction/watch/42"); err != nil || id != 42 {
+		t.Errorf("watch = %d, %v, want 42", id, err)
+	}
+	if id, err := parseWatchAuctionID("/auction/unwatch/7"); err != nil || id != 7 {
+		t.Errorf("unwatch = %d, %v, want 7", id, err)
+	}
+	for _, customID := range []string{"/auction/watch", "/auction/watch/abc", "/auction/watch/1/2"} {
+		if _, err := parseWatchAuctionID(customID); err == nil {
+			t.Errorf("parseWatchAuctionID(%q) succeeded", customID)
+		}
+	}
+}
+
+func TestAddAuctionFeeFields(t *testing.T) {
+	builder := discord.NewEmbedBuilder()
+	addAuctionFeeFields(builder, economy.AuctionFees{ListingFee: 20, CommissionPercent: 5}, 1000)
+	fields := builder.Build().Fields
+	if len(fields) != 3 {
+		t.Fatalf("got %d fields, want 3", len(fields))
+	}
+	if fields[0].Value != "20 💰 (charged now, not refunded)" || fields[1].Value != "5.00% of the final price" {
+		t.Errorf("fee fields = %q, %q", fields[0].Value, fields[1].Value)
+	}
+	// 1000 less 50 commission and the 20 listing fee
+	if fields[2].Value != "930 💰" {
+		t.Errorf("net payout = %q, want 930", fields[2].Value)
+	}
+
+	builder = discord.NewEmbedBuilder()
+	addAuctionFeeFields(builder, economy.AuctionFees{}, 1000)
+	if fields := builder.Build().Fields; len(fields) != 0 {
+		t.Errorf("fee fields without fees: %v", fields)
+	}
+}
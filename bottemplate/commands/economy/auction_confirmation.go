@@ -73,16 +73,18 @@ func (h *AuctionHandler) HandleConfirmation(event *handler.ComponentEvent) error
 	}
 
 	// Success message
+	builder := discord.NewEmbedBuilder().
+		SetTitle("✅ Auction Created").
+		SetDescription(fmt.Sprintf("Successfully created auction #%s for **%s**", auction.AuctionID, cardName)).
+		AddField("Start Price", fmt.Sprintf("%d 💰", startPrice), true).
+		AddField("Minimum Bid", fmt.Sprintf("%d 💰", h.manager.MinimumBid(auction)), true).
+		AddField("Duration", formatDuration(duration), true).
+		SetColor(config.SuccessColor)
+	if auction.ListingFee > 0 {
+		builder.AddField("Listing Fee Paid", fmt.Sprintf("%d 💰", auction.ListingFee), true)
+	}
 	return event.UpdateMessage(discord.MessageUpdate{
-		Embeds: &[]discord.Embed{
-			discord.NewEmbedBuilder().
-				SetTitle("✅ Auction Created").
-				SetDescription(fmt.Sprintf("Successfully created auction #%s for **%s**", auction.AuctionID, cardName)).
-				AddField("Start Price", fmt.Sprintf("%d 💰", startPrice), true).
-				AddField("Duration", formatDuration(duration), true).
-				SetColor(config.SuccessColor).
-				Build(),
-		},
+		Embeds:     &[]discord.Embed{builder.Build()},
 		Components: &[]discord.ContainerComponent{},
 	})
 }
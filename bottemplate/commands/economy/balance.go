@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
@@ -18,6 +19,24 @@ var Balance = discord.SlashCommandCreate{
 	Description: "💰 View your current balance and earnings",
 }
 
+// balanceHistoryLimit is how many recent currency changes /balance lists
+const balanceHistoryLimit = 5
+
+// ledgerReasonLabels names ledger reasons in the balance history
+var ledgerReasonLabels = map[string]string{
+	models.LedgerReasonDaily:             "Daily reward",
+	models.LedgerReasonWork:              "Work",
+	models.LedgerReasonAuctionBid:        "Auction bid",
+	models.LedgerReasonAuctionRefund:     "Auction refund",
+	models.LedgerReasonAuctionSale:       "Auction sale",
+	models.LedgerReasonAuctionBonus:      "Auction bonus",
+	models.LedgerReasonAuctionListingFee: "Auction listing fee",
+	models.LedgerReasonAuctionCommission: "Auction commission",
+	models.LedgerReasonShop:              "Shop purchase",
+	models.LedgerReasonVote:              "Vote reward",
+	models.LedgerReasonKofi:              "Ko-fi support",
+}
+
 func BalanceHandler(b *bottemplate.Bot) handler.CommandHandler {
 	return func(e *handler.CommandEvent) error {
 		start := time.Now()
@@ -59,12 +78,27 @@ func BalanceHandler(b *bottemplate.Bot) handler.CommandHandler {
 			vialBar,
 		)
 
+		var fields []discord.EmbedField
+		history, err := b.CurrencyLedgerRepository.GetRecent(ctx, user.DiscordID, balanceHistoryLimit)
+		if err != nil {
+			// The balance itself is still worth showing without its history
+			slog.Error("Failed to fetch currency history",
+				slog.String("user_id", user.DiscordID),
+				slog.Any("error", err))
+		} else if len(history) > 0 {
+			fields = append(fields, discord.EmbedField{
+				Name:  "Recent Activity",
+				Value: formatCurrencyHistory(history),
+			})
+		}
+
 		now := time.Now()
 		return e.CreateMessage(discord.MessageCreate{
 			Embeds: []discord.Embed{{
 				Title:       "💰 Balance",
 				Description: description,
 				Color:       utils.SuccessColor,
+				Fields:      fields,
 				Footer: &discord.EmbedFooter{
 					Text: fmt.Sprintf("Requested by %s", e.User().Username),
 				},
@@ -74,6 +108,27 @@ func BalanceHandler(b *bottemplate.Bot) handler.CommandHandler {
 	}
 }
 
+// formatCurrencyHistory renders ledger entries one per line, newest first
+func formatCurrencyHistory(entries []*models.CurrencyLedgerEntry) string {
+	var b strings.Builder
+	for _, entry := range entries {
+		label, ok := ledgerReasonLabels[entry.Reason]
+		if !ok {
+			label = entry.Reason
+		}
+
+		sign := "+"
+		amount := entry.Amount
+		if amount < 0 {
+			sign = "-"
+			amount = -amount
+		}
+
+		fmt.Fprintf(&b, "`%s%s` %s • <t:%d:R>\n", sign, utils.FormatNumber(amount), label, entry.CreatedAt.Unix())
+	}
+	return b.String()
+}
+
 func createBalanceBar(balance int64) string {
 	const barLength = 10
 
@@ -0,0 +1,26 @@
+package economy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestFormatCurrencyHistory(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	got := formatCurrencyHistory([]*models.CurrencyLedgerEntry{
+		{Amount: 1500, Reason: models.LedgerReasonDaily, CreatedAt: at},
+		{Amount: -200, Reason: models.LedgerReasonShop, CreatedAt: at},
+		{Amount: 30, Reason: "gift", CreatedAt: at},
+	})
+	want := "`+1,500` Daily reward • <t:1700000000:R>\n" +
+		"`-200` Shop purchase • <t:1700000000:R>\n" +
+		"`+30` gift • <t:1700000000:R>\n"
+	if got != want {
+		t.Errorf("formatCurrencyHistory =\n%s\nwant\n%s", got, want)
+	}
+	if got := formatCurrencyHistory(nil); got != "" {
+		t.Errorf("empty history = %q", got)
+	}
+}
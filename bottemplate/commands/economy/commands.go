@@ -9,8 +9,11 @@ var Commands = []discord.ApplicationCommandCreate{
 	Shop,
 	Liquefy,
 	AuctionCommand,
+	AuctionsCommand,
 	PriceStats,
 	Fuse,
 	TradeCommand,
 	InboxCommand,
+	GiftItem,
+	Vote,
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
@@ -37,17 +38,20 @@ func DailyHandler(b *bottemplate.Bot) handler.CommandHandler {
 		}
 
 		// Get dynamic daily cooldown in minutes (affected by Ruler Jeanne).
-		cooldownMinutes := b.EffectIntegrator.GetDailyCooldown(ctx, e.User().ID.String())
+		rewardCfg := b.Cfg.Economy.Rewards.Daily
+		cooldownMinutes := b.EffectIntegrator.GetDailyCooldown(ctx, e.User().ID.String(), rewardCfg.CooldownMinutes)
 		cooldownDuration := time.Duration(cooldownMinutes) * time.Minute
 
 		// Check cooldown
-		if time.Since(user.LastDaily) < cooldownDuration {
-			remaining := time.Until(user.LastDaily.Add(cooldownDuration)).Round(time.Second)
-			return utils.EH.UpdateInteractionResponse(e, "Daily Cooldown", fmt.Sprintf("You can claim your daily reward again in %s.", remaining))
+		if cooldown := utils.CheckCooldown(user.LastDaily, cooldownDuration); cooldown.Active() {
+			return utils.EH.UpdateInteractionResponse(e, "Daily Cooldown", fmt.Sprintf("You can claim your daily reward again %s.", cooldown.RelativeTimestamp()))
 		}
 
 		// Calculate reward (consider streaks, bonuses, etc.)
-		baseReward := int64(1000) // Basic reward
+		baseReward := rewardCfg.Amount
+		if b.UserService != nil {
+			baseReward = b.UserService.DailyReward(user, baseReward)
+		}
 
 		// Apply passive effects with feedback
 		effectResult := b.EffectIntegrator.ApplyDailyEffectsWithFeedback(ctx, e.User().ID.String(), int(baseReward))
@@ -83,6 +87,19 @@ func DailyHandler(b *bottemplate.Bot) handler.CommandHandler {
 			return utils.EH.UpdateInteractionResponse(e, "Error", "Failed to claim daily reward. Please try again later.")
 		}
 
+		if err := b.CurrencyLedgerRepository.Record(ctx, tx, &models.CurrencyLedgerEntry{
+			UserID: user.DiscordID,
+			Amount: reward,
+			Reason: models.LedgerReasonDaily,
+		}); err != nil {
+			slog.Error("Failed to record daily reward",
+				slog.String("type", "db"),
+				slog.String("discord_id", user.DiscordID),
+				slog.Any("error", err),
+			)
+			return utils.EH.UpdateInteractionResponse(e, "Error", "Failed to claim daily reward. Please try again later.")
+		}
+
 		if b.QuestTracker != nil {
 			go b.QuestTracker.TrackSnowflakesEarned(context.Background(), user.DiscordID, reward, "daily")
 		}
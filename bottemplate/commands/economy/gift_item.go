@@ -0,0 +1,125 @@
+package economy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var GiftItem = discord.SlashCommandCreate{
+	Name:        "gift-item",
+	Description: "🎁 Give some of your items to another user",
+	Options: []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionUser{
+			Name:        "user",
+			Description: "The user to give items to",
+			Required:    true,
+		},
+		discord.ApplicationCommandOptionString{
+			Name:        "item",
+			Description: "The item to give",
+			Required:    true,
+			Choices: []discord.ApplicationCommandOptionChoiceString{
+				{Name: "Broken Disc", Value: models.ItemBrokenDisc},
+				{Name: "Microphone", Value: models.ItemMicrophone},
+				{Name: "Forgotten Song", Value: models.ItemForgottenSong},
+			},
+		},
+		discord.ApplicationCommandOptionInt{
+			Name:        "amount",
+			Description: "How many to give",
+			Required:    true,
+			MinValue:    &[]int{1}[0],
+			MaxValue:    &[]int{999}[0],
+		},
+	},
+}
+
+func GiftItemHandler(b *bottemplate.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		data := e.SlashCommandInteractionData()
+		target := data.User("user")
+		itemID := data.String("item")
+		amount := data.Int("amount")
+		senderID := e.User().ID.String()
+
+		if target.Bot {
+			return utils.EH.CreateErrorEmbed(e, "You can't gift items to bots.")
+		}
+		if target.ID == e.User().ID {
+			return utils.EH.CreateErrorEmbed(e, "You can't gift items to yourself.")
+		}
+
+		if _, err := b.UserRepository.GetByDiscordID(ctx, target.ID.String()); err != nil {
+			return utils.EH.CreateErrorEmbed(e, fmt.Sprintf("%s hasn't started playing yet.", target.Username))
+		}
+
+		sender, err := b.UserRepository.GetByDiscordID(ctx, senderID)
+		if err != nil {
+			return utils.EH.CreateErrorEmbed(e, "Failed to get your user data. Please try again later.")
+		}
+
+		item, err := b.ItemRepository.GetByID(ctx, itemID)
+		if err != nil {
+			return utils.EH.CreateErrorEmbed(e, "That item doesn't exist.")
+		}
+
+		if err := consumeTransferLimit(ctx, b, sender, models.TransferKindGift, int64(amount)); err != nil {
+			var limitErr *transferLimitError
+			if errors.As(err, &limitErr) {
+				return utils.EH.CreateErrorEmbed(e, limitErr.Error())
+			}
+			slog.Error("Failed to check gift limit",
+				slog.String("from", senderID),
+				slog.Any("error", err))
+			return utils.EH.CreateErrorEmbed(e, "Failed to gift the item. Please try again later.")
+		}
+
+		if err := b.ItemRepository.TransferUserItem(ctx, senderID, target.ID.String(), itemID, amount); err != nil {
+			releaseTransferLimit(ctx, b, senderID, models.TransferKindGift, int64(amount))
+			switch {
+			case errors.Is(err, repositories.ErrItemNotTradeable):
+				return utils.EH.CreateErrorEmbed(e, fmt.Sprintf("%s can't be gifted.", item.Name))
+			case errors.Is(err, repositories.ErrInsufficientItems):
+				return utils.EH.CreateErrorEmbed(e, fmt.Sprintf("You don't have %d× %s.", amount, item.Name))
+			case errors.Is(err, repositories.ErrItemStackFull):
+				return utils.EH.CreateErrorEmbed(e, fmt.Sprintf("%s can't hold that many %s (max %d).", target.Username, item.Name, item.MaxStack))
+			default:
+				slog.Error("Failed to gift item",
+					slog.String("from", senderID),
+					slog.String("to", target.ID.String()),
+					slog.String("item_id", itemID),
+					slog.Int("amount", amount),
+					slog.Any("error", err))
+				return utils.EH.CreateErrorEmbed(e, "Failed to gift the item. Please try again later.")
+			}
+		}
+
+		slog.Info("Item gifted",
+			slog.String("from", senderID),
+			slog.String("to", target.ID.String()),
+			slog.String("item_id", itemID),
+			slog.Int("amount", amount))
+
+		return e.CreateMessage(discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title:       "🎁 Gift Sent",
+				Description: fmt.Sprintf("You gave %s **%d× %s %s**!", target.Mention(), amount, item.Emoji, item.Name),
+				Color:       config.SuccessColor,
+			}},
+		})
+	}
+}
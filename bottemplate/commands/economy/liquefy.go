@@ -21,15 +21,19 @@ import (
 // Add this constant at the top of the file
 const LiquefyCustomIDPrefix = "/liquefy/"
 
+// liquefyUndoSeconds is the undo window shown to users
+var liquefyUndoSeconds = int(vials.LiquefyUndoWindow.Seconds())
+
 // Add to commands list
 var Liquefy = discord.SlashCommandCreate{
 	Name:        "liquefy",
 	Description: "Convert a card into vials",
 	Options: []discord.ApplicationCommandOption{
 		discord.ApplicationCommandOptionString{
-			Name:        "query",
-			Description: "Card ID or name to liquefy",
-			Required:    true,
+			Name:         "query",
+			Description:  "Card ID or name to liquefy",
+			Required:     true,
+			Autocomplete: true,
 		},
 	},
 }
@@ -71,7 +75,7 @@ func (h *LiquefyHandler) HandleLiquefy(e *handler.CommandEvent) error {
 func (h *LiquefyHandler) showLiquefyConfirmation(e *handler.CommandEvent, card *models.Card, vm *vials.VialManager) error {
 	// Calculate vial yield with effect bonuses
 	userID := strconv.FormatInt(int64(e.User().ID), 10)
-	vials, err := vm.CalculateVialYieldWithEffects(context.Background(), card, userID, h.bot.EffectIntegrator)
+	vialYield, err := vm.CalculateVialYieldWithEffects(context.Background(), card, userID, h.bot.EffectIntegrator)
 	if err != nil {
 		return updateLiquefyCommandContent(e, "❌ Failed to calculate vial yield: "+err.Error())
 	}
@@ -79,11 +83,12 @@ func (h *LiquefyHandler) showLiquefyConfirmation(e *handler.CommandEvent, card *
 	embed := discord.NewEmbedBuilder().
 		SetTitle("🍷 Confirm Liquefication").
 		SetColor(config.BackgroundColor).
-		SetDescription(fmt.Sprintf("```md\n## Card Details\n* Name: %s\n* Collection: %s\n* Level: %s\n* Vial Yield: %d 🍷\n```\n⚠️ Warning: This action cannot be undone!",
+		SetDescription(fmt.Sprintf("```md\n## Card Details\n* Name: %s\n* Collection: %s\n* Level: %s\n* Vial Yield: %d 🍷\n```\n⚠️ Warning: You only have %d seconds to undo this!",
 			utils.FormatCardName(card.Name),
 			card.ColID,
 			utils.GetPromoRarityPlainText(card.ColID, card.Level),
-			vials)).
+			vialYield,
+			liquefyUndoSeconds)).
 		SetTimestamp(time.Now()).
 		Build()
 
@@ -126,6 +131,11 @@ func (h *LiquefyHandler) HandleComponent(e *handler.ComponentEvent) error {
 		return utils.EH.CreateEphemeralError(e, "Only the command user can use these buttons.")
 	}
 
+	// The card may be gone from the inventory entirely, so undo skips the ownership checks below
+	if parts[2] == "undo" {
+		return h.handleUndo(ctx, e, vm, parts[4])
+	}
+
 	cardID, err := strconv.ParseInt(parts[4], 10, 64)
 	if err != nil {
 		_, err := e.UpdateInteractionResponse(discord.MessageUpdate{
@@ -171,7 +181,7 @@ func (h *LiquefyHandler) HandleComponent(e *handler.ComponentEvent) error {
 			return err
 		}
 
-		vials, err := vm.LiquefyCardWithEffects(context.Background(), userID, cardID, h.bot.EffectIntegrator)
+		vialYield, err := vm.LiquefyCardWithEffects(context.Background(), userID, cardID, h.bot.EffectIntegrator)
 		if err != nil {
 			_, err := e.UpdateInteractionResponse(discord.MessageUpdate{
 				Content:    utils.Ptr(fmt.Sprintf("❌ Failed to liquefy card: %s", err.Error())),
@@ -186,16 +196,29 @@ func (h *LiquefyHandler) HandleComponent(e *handler.ComponentEvent) error {
 			SetDescription(fmt.Sprintf("```md\n## Result\n* Card: %s\n* Collection: %s\n* Vials Received: %d 🍷\n```",
 				card.Name,
 				card.ColID,
-				vials))
+				vialYield))
+
+		go h.bot.CompletionChecker.CheckCompletionForCards(context.Background(), e.User().ID.String(), []int64{cardID})
 
 		// Track effect progress for Holy Grail
 		if h.bot.EffectManager != nil {
 			go h.bot.EffectManager.UpdateEffectProgress(context.Background(), e.User().ID.String(), "holygrail", 1)
 		}
 
+		components := []discord.ContainerComponent{}
+		if h.bot.LiquefyUndo != nil {
+			token := h.bot.LiquefyUndo.Remember(e.User().ID.String(), card.ID, card.Level, vialYield)
+			components = append(components, discord.NewActionRow(
+				discord.NewSecondaryButton(
+					"↩ Undo",
+					fmt.Sprintf("/liquefy/undo/%s/%s", e.User().ID.String(), token)),
+			))
+			embed.SetFooter(fmt.Sprintf("You can undo this within %d seconds", liquefyUndoSeconds), "")
+		}
+
 		_, err = e.UpdateInteractionResponse(discord.MessageUpdate{
 			Embeds:     &[]discord.Embed{embed.Build()},
-			Components: &[]discord.ContainerComponent{},
+			Components: &components,
 		})
 		return err
 
@@ -215,6 +238,45 @@ func (h *LiquefyHandler) HandleComponent(e *handler.ComponentEvent) error {
 	}
 }
 
+// handleUndo reverses a recent liquefy if its undo window is still open
+func (h *LiquefyHandler) handleUndo(ctx context.Context, e *handler.ComponentEvent, vm *vials.VialManager, token string) error {
+	if h.bot.LiquefyUndo == nil {
+		return utils.EH.CreateEphemeralError(e, "Undo is not available right now.")
+	}
+
+	record, err := h.bot.LiquefyUndo.Take(token, e.User().ID.String())
+	if err != nil {
+		_, err := e.UpdateInteractionResponse(discord.MessageUpdate{
+			Components: &[]discord.ContainerComponent{},
+		})
+		if err != nil {
+			return err
+		}
+		return utils.EH.CreateEphemeralError(e, "❌ "+err.Error())
+	}
+
+	if err := vm.UndoLiquefy(ctx, record); err != nil {
+		// Nothing was changed, so keep the button usable for a retry
+		h.bot.LiquefyUndo.Restore(token, record)
+		return utils.EH.CreateEphemeralError(e, fmt.Sprintf("❌ Failed to undo liquefy: %s", err.Error()))
+	}
+
+	go h.bot.CompletionChecker.CheckCompletionForCards(context.Background(), record.UserID, []int64{record.CardID})
+
+	embed := discord.NewEmbedBuilder().
+		SetTitle("↩ Liquefy Undone").
+		SetColor(config.BackgroundColor).
+		SetDescription(fmt.Sprintf("The card is back in your inventory and the **%d** 🍷 it gave you were taken back.", record.Vials)).
+		SetTimestamp(time.Now()).
+		Build()
+
+	_, err = e.UpdateInteractionResponse(discord.MessageUpdate{
+		Embeds:     &[]discord.Embed{embed},
+		Components: &[]discord.ContainerComponent{},
+	})
+	return err
+}
+
 func updateLiquefyCommandContent(e *handler.CommandEvent, content string) error {
 	_, err := e.UpdateInteractionResponse(discord.MessageUpdate{
 		Content:    utils.Ptr(content),
@@ -10,7 +10,9 @@ import (
 
 	"github.com/disgoorg/bot-template/bottemplate"
 	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
 	"github.com/disgoorg/bot-template/bottemplate/economy"
+	"github.com/disgoorg/bot-template/bottemplate/economy/auction"
 	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/discord"
@@ -104,6 +106,25 @@ func handleCardByID(b *bottemplate.Bot, event *handler.CommandEvent, cardID int6
 	// Generate star display based on card level
 	stars := utils.GetPromoRarityPlainText(card.ColID, card.Level)
 
+	// Closed auctions give a second, player-driven price signal
+	var sales auction.SalesSummary
+	if b.AuctionManager != nil {
+		sales, err = b.AuctionManager.GetSalesSummary(ctx, cardID)
+		if err != nil {
+			slog.Warn("Failed to fetch auction sales",
+				slog.String("type", "cmd"),
+				slog.String("name", "price-stats"),
+				slog.Int64("card_id", cardID),
+				slog.String("error", err.Error()))
+		}
+	}
+	lookbackDays := int(economicUtils.AuctionSalesLookback.Hours() / 24)
+	auctionMarket := fmt.Sprintf("* No sales in the last %d days\n", lookbackDays)
+	if sales.AveragedOver > 0 {
+		auctionMarket = fmt.Sprintf("* Market Price: %d 💰 (last %d sales)\n* Sales (%dd): %d\n",
+			sales.MarketPrice, sales.AveragedOver, lookbackDays, len(sales.Sales))
+	}
+
 	timestamp := fmt.Sprintf("<t:%d:R>", time.Now().Unix())
 
 	description := fmt.Sprintf("```md\n"+
@@ -121,6 +142,9 @@ func handleCardByID(b *bottemplate.Bot, event *handler.CommandEvent, cardID int6
 		"* Maximum: %d 💰\n"+
 		"* Average: %.0f 💰\n"+
 		"\n"+
+		"# Auction Sales\n"+
+		"%s"+
+		"\n"+
 		"# Vial Information\n"+
 		"* Current Vial Value: %d 🧪\n"+
 		"* Vial Rate: %.0f%%\n"+
@@ -135,14 +159,24 @@ func handleCardByID(b *bottemplate.Bot, event *handler.CommandEvent, cardID int6
 		marketStats.MinPrice24h,
 		marketStats.MaxPrice24h,
 		marketStats.AvgPrice24h,
+		auctionMarket,
 		calculateVialValue(price, card.Level),
 		getVialRate(card.Level)*100,
 	)
 
+	var fields []discord.EmbedField
+	if len(sales.Sales) > 0 {
+		fields = append(fields, discord.EmbedField{
+			Name:  "Recent Auction Sales",
+			Value: formatRecentSales(sales.Sales, 5),
+		})
+	}
+
 	return event.CreateMessage(discord.MessageCreate{
 		Embeds: []discord.Embed{{
 			Title:       fmt.Sprintf("%s %s", cardInfo.Stars, cardInfo.FormattedName),
 			Description: description,
+			Fields:      fields,
 			Color:       utils.GetColorByLevel(card.Level),
 			Thumbnail: &discord.EmbedResource{
 				URL: cardInfo.ImageURL,
@@ -160,6 +194,18 @@ func handleCardByID(b *bottemplate.Bot, event *handler.CommandEvent, cardID int6
 	})
 }
 
+// formatRecentSales lists up to limit sales, newest first, one per line
+func formatRecentSales(sales []repositories.AuctionSale, limit int) string {
+	if len(sales) > limit {
+		sales = sales[:limit]
+	}
+	lines := make([]string, len(sales))
+	for i, sale := range sales {
+		lines[i] = fmt.Sprintf("`%s` • %d 💰 • <t:%d:R>", sale.AuctionID, sale.FinalPrice, sale.SoldAt.Unix())
+	}
+	return strings.Join(lines, "\n")
+}
+
 // Component handler for the details button
 func PriceDetailsHandler(b *bottemplate.Bot) handler.ComponentHandler {
 	return func(event *handler.ComponentEvent) error {
@@ -0,0 +1,21 @@
+package economy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestFormatRecentSales(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	sales := []repositories.AuctionSale{
+		{AuctionID: "A1", FinalPrice: 500, SoldAt: at},
+		{AuctionID: "A2", FinalPrice: 450, SoldAt: at},
+		{AuctionID: "A3", FinalPrice: 400, SoldAt: at},
+	}
+	want := "`A1` • 500 💰 • <t:1700000000:R>\n`A2` • 450 💰 • <t:1700000000:R>"
+	if got := formatRecentSales(sales, 2); got != want {
+		t.Errorf("formatRecentSales =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package economy
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/disgoorg/bot-template/bottemplate"
@@ -87,6 +88,8 @@ func (h *ShopHandler) handleList(event *handler.CommandEvent) error {
 		})
 	}
 
+	stock := h.getShopStock(ctx)
+
 	actives, _, passives := groupItems(items)
 	currentItems := actives
 	title := "Shop - Items"
@@ -98,7 +101,7 @@ func (h *ShopHandler) handleList(event *handler.CommandEvent) error {
 
 	components := []discord.ContainerComponent{
 		createShopComponents("active", ownerID)[0],
-		createItemSelectMenu(currentItems, "active", ownerID),
+		createItemSelectMenu(currentItems, "active", ownerID, stock),
 	}
 
 	// Handle empty shop case with appropriate messaging
@@ -115,7 +118,7 @@ func (h *ShopHandler) handleList(event *handler.CommandEvent) error {
 	} else {
 		embed = discord.Embed{
 			Title:       title,
-			Description: "Select an item to view details",
+			Description: "Select an item to view details" + formatNextRestock(currentItems, stock),
 			Color:       getColorByType(currentItems[0].Type),
 			Footer: &discord.EmbedFooter{
 				Text: "Prices update hourly",
@@ -323,6 +326,8 @@ func (h *ShopHandler) handleCategorySelect(event *handler.ComponentEvent) error
 		})
 	}
 
+	stock := h.getShopStock(ctx)
+
 	actives, _, passives := groupItems(items)
 	var currentItems []*models.EffectItem
 	var title string
@@ -338,7 +343,7 @@ func (h *ShopHandler) handleCategorySelect(event *handler.ComponentEvent) error
 
 	components := []discord.ContainerComponent{
 		createShopComponents(selectedValue, ownerID)[0],
-		createItemSelectMenu(currentItems, selectedValue, ownerID),
+		createItemSelectMenu(currentItems, selectedValue, ownerID, stock),
 	}
 
 	// Handle empty category case with appropriate messaging
@@ -355,7 +360,7 @@ func (h *ShopHandler) handleCategorySelect(event *handler.ComponentEvent) error
 	} else {
 		embed = discord.Embed{
 			Title:       title,
-			Description: "Select an item to view details",
+			Description: "Select an item to view details" + formatNextRestock(currentItems, stock),
 			Color:       getColorByType(currentItems[0].Type),
 			Footer: &discord.EmbedFooter{
 				Text: "💡 Tip: Prices update hourly",
@@ -392,7 +397,7 @@ func createShopComponents(selectedValue string, ownerID string) []discord.Contai
 	}
 }
 
-func createItemSelectMenu(items []*models.EffectItem, _ string, ownerID string) discord.ContainerComponent {
+func createItemSelectMenu(items []*models.EffectItem, _ string, ownerID string, stock map[string]*models.ShopStock) discord.ContainerComponent {
 	// Handle empty items array to prevent Discord API error
 	if len(items) == 0 {
 		// Return a disabled placeholder select menu
@@ -417,10 +422,14 @@ func createItemSelectMenu(items []*models.EffectItem, _ string, ownerID string)
 		} else {
 			durationSnippet = fmt.Sprintf("%d uses", item.Duration)
 		}
+		description := fmt.Sprintf("%d %s • %s", item.Price, getCurrencyEmoji(item.Currency), durationSnippet)
+		if itemStock, ok := stock[item.ID]; ok {
+			description += " • " + formatStockLevel(itemStock)
+		}
 		options = append(options, discord.StringSelectMenuOption{
 			Label:       item.Name,
 			Value:       "item_" + item.ID,
-			Description: fmt.Sprintf("%d %s • %s", item.Price, getCurrencyEmoji(item.Currency), durationSnippet),
+			Description: description,
 			Emoji:       &discord.ComponentEmoji{Name: getTypeEmoji(item.Type)},
 		})
 	}
@@ -486,21 +495,35 @@ func (h *ShopHandler) handleItemSelect(event *handler.ComponentEvent) error {
 		durationText = fmt.Sprintf("%d uses", item.Duration)
 	}
 
+	// Limited items show their remaining stock and can't be bought when sold out
+	stockText := ""
+	soldOut := false
+	if itemStock, ok := h.getShopStock(ctx)[item.ID]; ok {
+		stockText = fmt.Sprintf("**Stock:** %s • restocks <t:%d:R>\n", formatStockLevel(itemStock), itemStock.NextRestockAt.Unix())
+		soldOut = itemStock.Stock <= 0
+	}
+
 	embed := discord.NewEmbedBuilder().
 		SetTitle(fmt.Sprintf("%s %s", getTypeEmoji(item.Type), item.Name)).
 		SetColor(getColorByType(item.Type)).
-		SetDescription(fmt.Sprintf("%s\n\n**Price:** %d %s\n**Duration:** %s\n%s",
+		SetDescription(fmt.Sprintf("%s\n\n**Price:** %d %s\n**Duration:** %s\n%s%s",
 			h.formatItemDescription(item),
 			item.Price,
 			getCurrencyEmoji(item.Currency),
 			durationText,
+			stockText,
 			recipeText)).
 		SetFooter("💡 Tip: Prices update hourly", "").
 		Build()
 
+	buyButton := discord.NewSuccessButton("Buy 🛍️", fmt.Sprintf("/shop_buy/%s/%s", ownerID, item.ID))
+	if soldOut {
+		buyButton = discord.NewSecondaryButton("Sold Out", fmt.Sprintf("/shop_buy/%s/%s", ownerID, item.ID)).AsDisabled()
+	}
+
 	// Simplify component structure similar to liquefy
 	actionRow := discord.NewActionRow(
-		discord.NewSuccessButton("Buy 🛍️", fmt.Sprintf("/shop_buy/%s/%s", ownerID, item.ID)),
+		buyButton,
 		discord.NewSecondaryButton("Back ↩️", "/shop_category/"+ownerID),
 	)
 
@@ -509,3 +532,34 @@ func (h *ShopHandler) handleItemSelect(event *handler.ComponentEvent) error {
 		Components: &[]discord.ContainerComponent{actionRow},
 	})
 }
+
+// getShopStock returns limited item stock keyed by item ID; items missing from it are unlimited
+func (h *ShopHandler) getShopStock(ctx context.Context) map[string]*models.ShopStock {
+	stock, err := h.effectManager.GetShopStock(ctx)
+	if err != nil {
+		slog.Warn("Failed to fetch shop stock", slog.Any("error", err))
+		return nil
+	}
+	return stock
+}
+
+func formatStockLevel(stock *models.ShopStock) string {
+	if stock.Stock <= 0 {
+		return "Sold out"
+	}
+	return fmt.Sprintf("%d/%d left", stock.Stock, stock.MaxStock)
+}
+
+// formatNextRestock returns a line with the earliest restock among the limited items listed
+func formatNextRestock(items []*models.EffectItem, stock map[string]*models.ShopStock) string {
+	var next *models.ShopStock
+	for _, item := range items {
+		if itemStock, ok := stock[item.ID]; ok && (next == nil || itemStock.NextRestockAt.Before(next.NextRestockAt)) {
+			next = itemStock
+		}
+	}
+	if next == nil {
+		return ""
+	}
+	return fmt.Sprintf("\n\n⏳ Limited items restock <t:%d:R>", next.NextRestockAt.Unix())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -146,9 +147,25 @@ func (h *TradeHandler) HandleTrade(event *handler.CommandEvent) error {
 		return updErr
 	}
 
+	offerer, err := h.userRepo.GetByDiscordID(ctx, offererID)
+	if err != nil {
+		_, updErr := event.UpdateInteractionResponse(discord.MessageUpdate{Content: utils.Ptr("❌ Failed to get your user data.")})
+		return updErr
+	}
+	if err := consumeTransferLimit(ctx, h.bot, offerer, models.TransferKindTrade, 1); err != nil {
+		var limitErr *transferLimitError
+		if errors.As(err, &limitErr) {
+			_, updErr := event.UpdateInteractionResponse(discord.MessageUpdate{Content: utils.Ptr("❌ " + limitErr.Error())})
+			return updErr
+		}
+		_, updErr := event.UpdateInteractionResponse(discord.MessageUpdate{Content: utils.Ptr("❌ Failed to create trade offer.")})
+		return updErr
+	}
+
 	// Generate unique trade ID
 	tradeID, err := h.generateTradeID(ctx, offererCardDetails)
 	if err != nil {
+		releaseTransferLimit(ctx, h.bot, offererID, models.TransferKindTrade, 1)
 		_, updErr := event.UpdateInteractionResponse(discord.MessageUpdate{Content: utils.Ptr("❌ Failed to generate trade ID.")})
 		return updErr
 	}
@@ -164,6 +181,7 @@ func (h *TradeHandler) HandleTrade(event *handler.CommandEvent) error {
 
 	err = h.tradeRepo.Create(ctx, trade)
 	if err != nil {
+		releaseTransferLimit(ctx, h.bot, offererID, models.TransferKindTrade, 1)
 		_, updErr := event.UpdateInteractionResponse(discord.MessageUpdate{Content: utils.Ptr("❌ Failed to create trade offer.")})
 		return updErr
 	}
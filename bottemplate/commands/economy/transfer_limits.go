@@ -0,0 +1,72 @@
+package economy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// transferLimitError is returned by consumeTransferLimit when the user has used up
+// their allowance; its message is meant for the user
+type transferLimitError struct {
+	message string
+}
+
+func (e *transferLimitError) Error() string {
+	return e.message
+}
+
+// transferWindow returns how long each kind of transfer counter runs before it resets
+func transferWindow(kind string) time.Duration {
+	if kind == models.TransferKindTrade {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// consumeTransferLimit counts amount against the user's configured gift or trade limit.
+// It returns a *transferLimitError once the limit is reached.
+func consumeTransferLimit(ctx context.Context, b *bottemplate.Bot, user *models.User, kind string, amount int64) error {
+	if b.TransferCounterRepo == nil {
+		return nil
+	}
+
+	limits := b.Cfg.Economy.Transfers
+	premium := user.PremiumActive(time.Now())
+	limit, unit := limits.GiftLimit(premium), "items gifted today"
+	if kind == models.TransferKindTrade {
+		limit, unit = limits.TradeLimit(premium), "trade offers this hour"
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	window := transferWindow(kind)
+	_, err := b.TransferCounterRepo.Consume(ctx, user.DiscordID, kind, window, amount, limit)
+	if !errors.Is(err, repositories.ErrTransferLimitReached) {
+		return err
+	}
+
+	used, usedErr := b.TransferCounterRepo.Used(ctx, user.DiscordID, kind, window)
+	if usedErr != nil {
+		used = limit
+	}
+	resetsAt := repositories.TransferWindowEnd(time.Now(), window)
+	return &transferLimitError{message: fmt.Sprintf(
+		"Limit reached: you've used **%d/%d** %s. It resets <t:%d:R>.",
+		used, limit, unit, resetsAt.Unix(),
+	)}
+}
+
+// releaseTransferLimit hands back an allowance consumed for a transfer that then failed
+func releaseTransferLimit(ctx context.Context, b *bottemplate.Bot, userID, kind string, amount int64) {
+	if b.TransferCounterRepo == nil {
+		return
+	}
+	_ = b.TransferCounterRepo.Release(ctx, userID, kind, transferWindow(kind), amount)
+}
@@ -0,0 +1,70 @@
+package economy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/economy"
+)
+
+// fakeTransferCounters keeps one in-memory total per user and kind
+type fakeTransferCounters struct {
+	repositories.TransferCounterRepository
+	used      map[string]int64
+	lastLimit int64
+}
+
+func (c *fakeTransferCounters) Consume(ctx context.Context, userID, kind string, window time.Duration, amount, limit int64) (int64, error) {
+	c.lastLimit = limit
+	key := userID + "/" + kind
+	if limit > 0 && c.used[key]+amount > limit {
+		return 0, repositories.ErrTransferLimitReached
+	}
+	c.used[key] += amount
+	return c.used[key], nil
+}
+
+func (c *fakeTransferCounters) Used(ctx context.Context, userID, kind string, window time.Duration) (int64, error) {
+	return c.used[userID+"/"+kind], nil
+}
+
+func TestConsumeTransferLimit(t *testing.T) {
+	counters := &fakeTransferCounters{used: make(map[string]int64)}
+	b := &bottemplate.Bot{TransferCounterRepo: counters}
+	b.Cfg.Economy.Transfers = economy.TransferLimits{GiftsPerDay: 3, PremiumGiftsPerDay: 10}
+	ctx := context.Background()
+
+	regular := &models.User{DiscordID: "u1"}
+	if err := consumeTransferLimit(ctx, b, regular, models.TransferKindGift, 3); err != nil {
+		t.Fatalf("consume up to the cap: %v", err)
+	}
+	err := consumeTransferLimit(ctx, b, regular, models.TransferKindGift, 1)
+	var limitErr *transferLimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("consume past the cap = %v, want a transferLimitError", err)
+	}
+	if !strings.Contains(limitErr.Error(), "**3/3** items gifted today") {
+		t.Errorf("message = %q", limitErr.Error())
+	}
+
+	// Expired premium gets the regular cap, active premium the premium one
+	expired := &models.User{DiscordID: "u2", Premium: true, PremiumExpires: time.Now().Add(-time.Hour)}
+	if err := consumeTransferLimit(ctx, b, expired, models.TransferKindGift, 1); err != nil || counters.lastLimit != 3 {
+		t.Errorf("expired premium = %v with limit %d, want 3", err, counters.lastLimit)
+	}
+	active := &models.User{DiscordID: "u3", Premium: true, PremiumExpires: time.Now().Add(time.Hour)}
+	if err := consumeTransferLimit(ctx, b, active, models.TransferKindGift, 5); err != nil || counters.lastLimit != 10 {
+		t.Errorf("active premium = %v with limit %d, want 10", err, counters.lastLimit)
+	}
+
+	// No trade cap configured means trades are never counted
+	if err := consumeTransferLimit(ctx, b, regular, models.TransferKindTrade, 100); err != nil || counters.used["u1/trade"] != 0 {
+		t.Errorf("uncapped trade = %v, counted %d", err, counters.used["u1/trade"])
+	}
+}
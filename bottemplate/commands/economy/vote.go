@@ -0,0 +1,60 @@
+package economy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var Vote = discord.SlashCommandCreate{
+	Name:        "vote",
+	Description: "Vote for the bot and see when your next vote reward is ready",
+}
+
+// VoteHandler links the vote page and shows the reward and the user's vote cooldown.
+// The reward itself is paid by the backend when the bot list reports the vote.
+func VoteHandler(b *bottemplate.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		if b.Cfg.Vote.URL == "" || b.VoteRewards == nil {
+			return utils.EH.CreateErrorEmbed(e, "Voting is not set up on this bot.")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		user, err := b.UserRepository.GetByDiscordID(ctx, e.User().ID.String())
+		if err != nil {
+			slog.Error("Failed to get user",
+				slog.String("type", "db"),
+				slog.String("discord_id", e.User().ID.String()),
+				slog.Any("error", err),
+			)
+			return utils.EH.CreateErrorEmbed(e, "Failed to get user data. Please try again later.")
+		}
+
+		reward := b.VoteRewards.Reward()
+		status := "✅ Your next vote will be rewarded."
+		if cooldown := b.VoteRewards.Cooldown(user); cooldown.Active() {
+			status = fmt.Sprintf("⏳ You can be rewarded again %s.", cooldown.RelativeTimestamp())
+		}
+
+		description := fmt.Sprintf("[Vote for the bot here](%s)\n\n%s", b.Cfg.Vote.URL, status)
+		if reward.Flakes > 0 {
+			description += fmt.Sprintf("\nEach vote pays **%d** ❄️ flakes, once every %s.", reward.Flakes, reward.Cooldown())
+		}
+
+		return e.CreateMessage(discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title:       "🗳️ Vote",
+				Description: description,
+				Color:       utils.SuccessColor,
+			}},
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/disgoorg/bot-template/bottemplate"
 	"github.com/disgoorg/bot-template/bottemplate/config"
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/economy"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
@@ -32,8 +33,6 @@ func NewWorkHandler(b *bottemplate.Bot) *WorkHandler {
 	return &WorkHandler{bot: b}
 }
 
-const workCooldown = config.WorkMinCooldown
-
 type JobRarity int
 
 const (
@@ -115,7 +114,7 @@ func (h *WorkHandler) HandleWork(e *handler.CommandEvent) error {
 		return uerr
 	}
 
-	if remaining, onCooldown := remainingWorkCooldown(user.LastWork); onCooldown {
+	if remaining, onCooldown := remainingWorkCooldown(user.LastWork, h.rewards().Cooldown()); onCooldown {
 		_, uerr := e.UpdateInteractionResponse(discord.MessageUpdate{
 			Content: utils.Ptr(fmt.Sprintf("⏰ You need to rest for %s before working again!", remaining)),
 		})
@@ -655,7 +654,14 @@ func (h *WorkHandler) HandleWorkAnswer(e *handler.ComponentEvent, correctIdx, ch
 	cardBonus := h.calculateCardBonusWithCollection(ctx, scenario, userCards, allCards)
 
 	// Calculate rewards with card bonus
-	rewards := calculateRewardsWithBonus(rarity, success, cardBonus)
+	rewards := calculateRewardsWithBonus(h.rewards(), rarity, success, cardBonus)
+	if h.bot.UserService != nil {
+		if user, err := h.bot.UserRepository.GetByDiscordID(ctx, userID); err == nil {
+			rewards.Flakes = h.bot.UserService.WorkReward(user, rewards.Flakes)
+			rewards.Vials = h.bot.UserService.WorkReward(user, rewards.Vials)
+			rewards.XP = h.bot.UserService.WorkReward(user, rewards.XP)
+		}
+	}
 	if h.bot.EffectIntegrator != nil {
 		rewards.Flakes = h.bot.EffectIntegrator.ApplyWorkReward(ctx, userID, rewards.Flakes)
 		rewards.Vials = h.bot.EffectIntegrator.ApplyWorkReward(ctx, userID, rewards.Vials)
@@ -698,21 +704,14 @@ func (h *WorkHandler) HandleWorkAnswer(e *handler.ComponentEvent, correctIdx, ch
 	return uerr
 }
 
-func remainingWorkCooldown(lastWork time.Time) (time.Duration, bool) {
-	if lastWork.IsZero() {
-		return 0, false
-	}
-
-	remaining := time.Until(lastWork.Add(workCooldown))
-	if remaining <= 0 {
-		return 0, false
-	}
+// rewards returns the configured /work payouts and cooldown
+func (h *WorkHandler) rewards() economy.WorkRewardConfig {
+	return h.bot.Cfg.Economy.Rewards.Work
+}
 
-	rounded := remaining.Round(time.Second)
-	if rounded < time.Second {
-		rounded = time.Second
-	}
-	return rounded, true
+func remainingWorkCooldown(lastWork time.Time, workCooldown time.Duration) (time.Duration, bool) {
+	cooldown := utils.CheckCooldown(lastWork, workCooldown)
+	return cooldown.Remaining, cooldown.Active()
 }
 
 func (h *WorkHandler) applyWorkRewardsTx(ctx context.Context, userID string, rewards WorkRewards) error {
@@ -731,7 +730,7 @@ func (h *WorkHandler) applyWorkRewardsTx(ctx context.Context, userID string, rew
 		return fmt.Errorf("failed to lock user for work reward: %w", err)
 	}
 
-	if remaining, onCooldown := remainingWorkCooldown(user.LastWork); onCooldown {
+	if remaining, onCooldown := remainingWorkCooldown(user.LastWork, h.rewards().Cooldown()); onCooldown {
 		return &workCooldownError{remaining: remaining}
 	}
 
@@ -761,6 +760,17 @@ func (h *WorkHandler) applyWorkRewardsTx(ctx context.Context, userID string, rew
 		return err
 	}
 
+	if rewards.Flakes != 0 && h.bot.CurrencyLedgerRepository != nil {
+		if err := h.bot.CurrencyLedgerRepository.Record(ctx, tx, &models.CurrencyLedgerEntry{
+			UserID:    userID,
+			Amount:    rewards.Flakes,
+			Reason:    models.LedgerReasonWork,
+			CreatedAt: now,
+		}); err != nil {
+			return err
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit work reward transaction: %w", err)
 	}
@@ -805,50 +815,18 @@ func addWorkItemDropsTx(ctx context.Context, tx bun.Tx, userID string, itemIDs [
 	return nil
 }
 
-func calculateRewards(rarity JobRarity, success bool) WorkRewards {
-	if !success {
-		// Failed jobs give minimal rewards
-		return WorkRewards{
-			Flakes: int64(5 + rand.Intn(10)),
-			Vials:  int64(2 + rand.Intn(5)),
-			XP:     int64(2 + rand.Intn(5)),
-		}
-	}
-
-	// Base rewards based on rarity (lowered for better economy balance)
-	var baseFlakes, baseVials, baseXP int64
-	switch rarity {
-	case JobRarity1Star:
-		baseFlakes = 30
-		baseVials = 15
-		baseXP = 10
-	case JobRarity2Star:
-		baseFlakes = 60
-		baseVials = 30
-		baseXP = 20
-	case JobRarity3Star:
-		baseFlakes = 120
-		baseVials = 60
-		baseXP = 35
-	case JobRarity4Star:
-		baseFlakes = 250
-		baseVials = 125
-		baseXP = 60
-	case JobRarity5Star:
-		baseFlakes = 500
-		baseVials = 250
-		baseXP = 100
-	}
-
-	// Add some variance
+func calculateRewards(cfg economy.WorkRewardConfig, rarity JobRarity, success bool) WorkRewards {
+	payout := cfg.Payout(int(rarity), success)
 	rewards := WorkRewards{
-		Flakes: baseFlakes + int64(rand.Intn(int(baseFlakes/2))),
-		Vials:  baseVials + int64(rand.Intn(int(baseVials/2))),
-		XP:     baseXP + int64(rand.Intn(int(baseXP/2))),
+		Flakes: payout.Flakes.Roll(),
+		Vials:  payout.Vials.Roll(),
+		XP:     payout.XP.Roll(),
 	}
 
-	// Item drops
-	rewards.ItemDrops = calculateItemDrops(rarity)
+	// Failed jobs give minimal rewards and no items
+	if success {
+		rewards.ItemDrops = calculateItemDrops(rarity)
+	}
 
 	return rewards
 }
@@ -888,8 +866,8 @@ func calculateItemDrops(rarity JobRarity) []string {
 }
 
 // Calculate rewards with card bonus applied
-func calculateRewardsWithBonus(rarity JobRarity, success bool, cardBonus CardBonus) WorkRewards {
-	rewards := calculateRewards(rarity, success)
+func calculateRewardsWithBonus(cfg economy.WorkRewardConfig, rarity JobRarity, success bool, cardBonus CardBonus) WorkRewards {
+	rewards := calculateRewards(cfg, rarity, success)
 
 	if cardBonus.CombinedMultiplier > 1.0 {
 		// Apply combined multiplier to base rewards
@@ -0,0 +1,33 @@
+package economy
+
+import (
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/economy"
+)
+
+func TestCalculateRewardsUsesConfig(t *testing.T) {
+	fixed := func(n int64) economy.RewardRange { return economy.RewardRange{Min: n, Max: n} }
+	cfg := economy.RewardConfig{Work: economy.WorkRewardConfig{
+		Failed: economy.WorkPayout{Flakes: fixed(1), Vials: fixed(2), XP: fixed(3)},
+		Stars: []economy.WorkPayout{
+			{Flakes: fixed(10), Vials: fixed(20), XP: fixed(30)},
+			{}, {},
+			{Flakes: fixed(40), Vials: fixed(50), XP: fixed(60)},
+		},
+	}}.WithDefaults().Work
+
+	got := calculateRewards(cfg, JobRarity1Star, true)
+	if got.Flakes != 10 || got.Vials != 20 || got.XP != 30 {
+		t.Errorf("1-star = %+v, want 10/20/30", got)
+	}
+	got = calculateRewards(cfg, JobRarity4Star, true)
+	if got.Flakes != 40 || len(got.ItemDrops) == 0 {
+		t.Errorf("4-star = %+v, want 40 flakes and a guaranteed item", got)
+	}
+	// A wrong answer pays the failed payout and never drops items
+	got = calculateRewards(cfg, JobRarity5Star, false)
+	if got.Flakes != 1 || got.Vials != 2 || got.XP != 3 || len(got.ItemDrops) != 0 {
+		t.Errorf("failed 5-star = %+v, want 1/2/3 and no items", got)
+	}
+}
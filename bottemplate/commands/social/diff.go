@@ -52,6 +52,17 @@ var Diff = discord.SlashCommandCreate{
 				},
 			},
 		},
+		discord.ApplicationCommandOptionSubCommand{
+			Name:        "summary",
+			Description: "Summarize how your collection compares with another user's",
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionUser{
+					Name:        "user",
+					Description: "User to compare with",
+					Required:    true,
+				},
+			},
+		},
 	},
 }
 
@@ -73,6 +84,10 @@ func DiffHandler(b *bottemplate.Bot) handler.CommandHandler {
 		targetUser := data.User("user")
 		query := strings.TrimSpace(data.String("query"))
 
+		if subCmd == "summary" {
+			return handleDiffSummary(ctx, e, cardOperationsService, targetUser)
+		}
+
 		// Create factory pieces shared with component handler
 		fetcher := &DiffDataFetcher{bot: b, cardOperationsService: cardOperationsService}
 		formatter := &DiffFormatter{bot: b}
@@ -113,6 +128,37 @@ func DiffHandler(b *bottemplate.Bot) handler.CommandHandler {
 	}
 }
 
+// handleDiffSummary reports both directions of the diff plus the overlap, with buttons that
+// open each list through DiffComponentHandler
+func handleDiffSummary(ctx context.Context, e *handler.CommandEvent, cardOperationsService *services.CardOperationsService, targetUser discord.User) error {
+	userID := e.User().ID.String()
+	diff, err := cardOperationsService.CompareCollections(ctx, userID, targetUser.ID.String())
+	if err != nil {
+		return utils.EH.UpdateInteractionResponse(e, "Diff", "Failed to compare collections")
+	}
+
+	embed := discord.NewEmbedBuilder().
+		SetTitle(fmt.Sprintf("Collection comparison with %s", targetUser.Username)).
+		SetDescription(fmt.Sprintf("You have **%d** cards they don't, they have **%d** you don't, **%d** shared.",
+			len(diff.OnlyUser), len(diff.OnlyTarget), diff.Shared)).
+		SetColor(config.BackgroundColor).
+		Build()
+
+	parser := utils.NewDiffParser()
+	forID := parser.BuildComponentID("diff", "open", utils.PaginationParams{UserID: userID, SubCommand: "for", TargetUserID: targetUser.ID.String()})
+	fromID := parser.BuildComponentID("diff", "open", utils.PaginationParams{UserID: userID, SubCommand: "from", TargetUserID: targetUser.ID.String()})
+
+	components := []discord.ContainerComponent{
+		discord.NewActionRow(
+			discord.NewSecondaryButton(fmt.Sprintf("Only you (%d)", len(diff.OnlyUser)), forID).WithDisabled(len(diff.OnlyUser) == 0),
+			discord.NewSecondaryButton(fmt.Sprintf("Only them (%d)", len(diff.OnlyTarget)), fromID).WithDisabled(len(diff.OnlyTarget) == 0),
+		),
+	}
+
+	_, err = e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{embed}, Components: &components})
+	return err
+}
+
 // DiffComponentHandler handles diff command pagination using the new unified factory
 func DiffComponentHandler(b *bottemplate.Bot) handler.ComponentHandler {
 	cardOperationsService := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository)
@@ -223,20 +269,13 @@ func (df *DiffFormatter) FormatItems(items []interface{}, page, totalPages int,
 
 	cardDisplayService := services.NewCardDisplayService(df.bot.CardRepository, df.bot.SpacesService)
 
-	// Compute totalItems from totalPages and current page size
-	itemsPerPage := config.CardsPerPage
-	totalItems := totalPages * itemsPerPage
-	if page == totalPages-1 { // last page may be partial
-		totalItems = (totalPages-1)*itemsPerPage + len(displayItems)
-	}
-
 	return cardDisplayService.CreateCardsEmbed(
 		context.Background(),
 		title,
 		displayItems,
 		page,
 		totalPages,
-		totalItems,
+		params.TotalItems,
 		params.Query,
 		config.BackgroundColor,
 	)
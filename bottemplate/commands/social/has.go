@@ -16,7 +16,7 @@ import (
 
 var Has = discord.SlashCommandCreate{
 	Name:        "has",
-	Description: "Check if a user has a specific card",
+	Description: "Check if a user has a card, or several separated by commas",
 	Options: []discord.ApplicationCommandOption{
 		discord.ApplicationCommandOptionUser{
 			Name:        "user",
@@ -24,13 +24,24 @@ var Has = discord.SlashCommandCreate{
 			Required:    true,
 		},
 		discord.ApplicationCommandOptionString{
-			Name:        "card_query",
-			Description: "Card to check (name or ID)",
-			Required:    true,
+			Name:         "card_query",
+			Description:  "Card to check (name or ID), a comma-separated list, or a search query",
+			Required:     true,
+			Autocomplete: true,
 		},
 	},
 }
 
+// hasMaxCards caps how many cards one /has check reports
+const hasMaxCards = 20
+
+// hasResult is the ownership of one resolved card, or a term that matched nothing
+type hasResult struct {
+	term   string
+	card   *models.Card
+	amount int64
+}
+
 func HasHandler(b *bottemplate.Bot) handler.CommandHandler {
 	cardOperationsService := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository)
 
@@ -45,44 +56,178 @@ func HasHandler(b *bottemplate.Bot) handler.CommandHandler {
 		targetUser := e.SlashCommandInteractionData().User("user")
 		query := e.SlashCommandInteractionData().String("card_query")
 
-		// Try direct query first (optimized approach)
-		var card *models.Card
-		var err error
+		terms := splitHasTerms(query)
+		if len(terms) == 0 {
+			return utils.EH.UpdateInteractionResponse(e, "Not Found", "Please provide a card to check")
+		}
 
-		// First try GetByQuery for exact matches
-		if directCard, queryErr := b.CardRepository.GetByQuery(ctx, query); queryErr == nil {
-			card = directCard
-		} else {
-			// Fallback to comprehensive search for fuzzy matches
-			cards, getAllErr := b.CardRepository.GetAll(ctx)
-			if getAllErr != nil {
+		// A single card keeps the detailed embed with the card image
+		if len(terms) == 1 && utils.ParseSearchQuery(terms[0]).Name != "" {
+			card, err := resolveHasCard(ctx, b, cardOperationsService, terms[0], nil)
+			if err != nil {
 				return utils.EH.UpdateInteractionResponse(e, "Error", "Failed to search for cards")
 			}
+			if card == nil {
+				return utils.EH.UpdateInteractionResponse(e, "Not Found", fmt.Sprintf("No cards found matching '%s'", terms[0]))
+			}
 
-			// Use enhanced search filters
-			filters := utils.ParseSearchQuery(query)
-			filters.SortBy = utils.SortByLevel
-			filters.SortDesc = true
-
-			searchResults := cardOperationsService.SearchCardsInCollection(ctx, cards, filters)
-			if len(searchResults) == 0 {
-				return utils.EH.UpdateInteractionResponse(e, "Not Found", fmt.Sprintf("No cards found matching '%s'", query))
+			// Check if user has the card
+			userCard, err := b.UserCardRepository.GetUserCard(ctx, targetUser.ID.String(), card.ID)
+			var hasEmbed discord.Embed
+			if err != nil {
+				hasEmbed = createHasEmbed(targetUser, card, 0, false, b)
+			} else {
+				hasEmbed = createHasEmbed(targetUser, card, userCard.Amount, true, b)
 			}
+			_, updErr := e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{hasEmbed}})
+			return updErr
+		}
 
-			card = searchResults[0]
+		results, err := checkHasCards(ctx, b, cardOperationsService, targetUser.ID.String(), terms)
+		if err != nil {
+			return utils.EH.UpdateInteractionResponse(e, "Error", "Failed to search for cards")
 		}
 
-		// Check if user has the card
-		userCard, err := b.UserCardRepository.GetUserCard(ctx, targetUser.ID.String(), card.ID)
-		var hasEmbed discord.Embed
+		matrixEmbed := createHasMatrixEmbed(targetUser, query, results)
+		_, updErr := e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{matrixEmbed}})
+		return updErr
+	}
+}
+
+// splitHasTerms splits a comma-separated card list into trimmed, non-empty terms
+func splitHasTerms(query string) []string {
+	var terms []string
+	for _, term := range strings.Split(query, ",") {
+		if term = strings.TrimSpace(term); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// resolveHasCard resolves a term to its best matching card, or nil when nothing
+// matches. allCards is loaded on first use and shared between terms.
+func resolveHasCard(ctx context.Context, b *bottemplate.Bot, ops *services.CardOperationsService, term string, allCards *[]*models.Card) (*models.Card, error) {
+	// First try GetByQuery for exact matches
+	if directCard, err := b.CardRepository.GetByQuery(ctx, term); err == nil {
+		return directCard, nil
+	}
+
+	matches, err := searchHasCards(ctx, b, ops, term, allCards)
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+	return matches[0], nil
+}
+
+// searchHasCards runs a search query over every card, best matches first
+func searchHasCards(ctx context.Context, b *bottemplate.Bot, ops *services.CardOperationsService, term string, allCards *[]*models.Card) ([]*models.Card, error) {
+	var cards []*models.Card
+	if allCards != nil && *allCards != nil {
+		cards = *allCards
+	} else {
+		loaded, err := b.CardRepository.GetAll(ctx)
 		if err != nil {
-			hasEmbed = createHasEmbed(targetUser, card, 0, false, b)
+			return nil, err
+		}
+		cards = loaded
+		if allCards != nil {
+			*allCards = loaded
+		}
+	}
+
+	// Use enhanced search filters
+	filters := utils.ParseSearchQuery(term)
+	filters.SortBy = utils.SortByLevel
+	filters.SortDesc = true
+
+	return ops.SearchCardsInCollection(ctx, cards, filters), nil
+}
+
+// checkHasCards resolves each term to one card and looks up how many copies the
+// user owns. A single term without a card name is treated as a search query
+// and every card it matches is checked, up to hasMaxCards.
+func checkHasCards(ctx context.Context, b *bottemplate.Bot, ops *services.CardOperationsService, userID string, terms []string) ([]hasResult, error) {
+	var allCards []*models.Card
+	var results []hasResult
+
+	if len(terms) == 1 {
+		matches, err := searchHasCards(ctx, b, ops, terms[0], &allCards)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			results = append(results, hasResult{term: terms[0]})
+		}
+		for _, card := range matches {
+			if len(results) == hasMaxCards {
+				break
+			}
+			results = append(results, hasResult{term: terms[0], card: card})
+		}
+	} else {
+		for _, term := range terms {
+			if len(results) == hasMaxCards {
+				break
+			}
+			card, err := resolveHasCard(ctx, b, ops, term, &allCards)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, hasResult{term: term, card: card})
+		}
+	}
+
+	userCards, err := b.UserCardRepository.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[int64]int64, len(userCards))
+	for _, uc := range userCards {
+		owned[uc.CardID] += uc.Amount
+	}
+
+	for i := range results {
+		if results[i].card != nil {
+			results[i].amount = owned[results[i].card.ID]
+		}
+	}
+	return results, nil
+}
+
+// createHasMatrixEmbed renders one line per checked card: owned count or a cross
+func createHasMatrixEmbed(user discord.User, query string, results []hasResult) discord.Embed {
+	var description strings.Builder
+	description.WriteString(fmt.Sprintf("🔎`%s`\n", query))
+	description.WriteString("```ansi\n")
+
+	ownedCount, resolvedCount := 0, 0
+	for _, result := range results {
+		if result.card == nil {
+			description.WriteString(fmt.Sprintf("❓ \x1b[90m%s\x1b[0m - no matching card\n", result.term))
+			continue
+		}
+
+		resolvedCount++
+		if result.amount > 0 {
+			ownedCount++
+			description.WriteString(fmt.Sprintf("✅ \x1b[33mx%d\x1b[0m ", result.amount))
 		} else {
-			hasEmbed = createHasEmbed(targetUser, card, userCard.Amount, true, b)
+			description.WriteString("❌ \x1b[31mx0\x1b[0m ")
 		}
-		_, updErr := e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{hasEmbed}})
-		return updErr
+		description.WriteString(fmt.Sprintf("%s %s %s\n",
+			utils.FormatCardName(result.card.Name),
+			strings.Repeat("⭐", result.card.Level),
+			utils.FormatCollectionName(result.card.ColID)))
 	}
+	description.WriteString("```")
+
+	return discord.NewEmbedBuilder().
+		SetTitle("Card Ownership Check").
+		SetDescription(description.String()).
+		SetColor(config.EmbedDefaultColor).
+		SetFooter(fmt.Sprintf("%s owns %d of %d cards", user.Username, ownedCount, resolvedCount), "").
+		Build()
 }
 
 func createHasEmbed(user discord.User, card *models.Card, amount int64, hasCard bool, b *bottemplate.Bot) discord.Embed {
@@ -0,0 +1,131 @@
+package social
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/services"
+	"github.com/disgoorg/disgo/discord"
+)
+
+// hasCardRepo resolves exact names through GetByQuery and counts full card loads
+type hasCardRepo struct {
+	repositories.CardRepository
+	cards []*models.Card
+	loads int
+}
+
+func (r *hasCardRepo) GetByQuery(ctx context.Context, query string) (*models.Card, error) {
+	for _, card := range r.cards {
+		if card.Name == query {
+			return card, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *hasCardRepo) GetAll(ctx context.Context) ([]*models.Card, error) {
+	r.loads++
+	return r.cards, nil
+}
+
+type hasUserCardRepo struct {
+	repositories.UserCardRepository
+	userCards []*models.UserCard
+}
+
+func (r *hasUserCardRepo) GetAllByUserID(ctx context.Context, userID string) ([]*models.UserCard, error) {
+	var owned []*models.UserCard
+	for _, userCard := range r.userCards {
+		if userCard.UserID == userID {
+			owned = append(owned, userCard)
+		}
+	}
+	return owned, nil
+}
+
+func hasBot() (*bottemplate.Bot, *hasCardRepo) {
+	cards := &hasCardRepo{cards: []*models.Card{
+		{ID: 1, Name: "nayeon", ColID: "twice", Level: 1},
+		{ID: 2, Name: "momo", ColID: "twice", Level: 3},
+		{ID: 3, Name: "sana", ColID: "twice", Level: 3},
+	}}
+	userCards := &hasUserCardRepo{userCards: []*models.UserCard{
+		{UserID: "u1", CardID: 1, Amount: 2},
+		{UserID: "u1", CardID: 3, Amount: 1},
+		{UserID: "u2", CardID: 2, Amount: 1},
+	}}
+	return &bottemplate.Bot{CardRepository: cards, UserCardRepository: userCards}, cards
+}
+
+func TestSplitHasTerms(t *testing.T) {
+	got := splitHasTerms(" nayeon, ,momo 3 ,, sana ")
+	if want := []string{"nayeon", "momo 3", "sana"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("splitHasTerms = %q, want %q", got, want)
+	}
+	if got := splitHasTerms(" , "); len(got) != 0 {
+		t.Errorf("splitHasTerms of only commas = %q", got)
+	}
+}
+
+func TestCheckHasCardsMixedOwnership(t *testing.T) {
+	b, cards := hasBot()
+	ops := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository)
+
+	results, err := checkHasCards(context.Background(), b, ops, "u1", []string{"nayeon", "mom", "tzuyu"})
+	if err != nil {
+		t.Fatalf("checkHasCards: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	if r := results[0]; r.card == nil || r.card.ID != 1 || r.amount != 2 {
+		t.Errorf("nayeon = %+v, want card 1 owned twice", r)
+	}
+	// momo is owned by someone else only
+	if r := results[1]; r.card == nil || r.card.ID != 2 || r.amount != 0 {
+		t.Errorf("mom = %+v, want card 2 not owned", r)
+	}
+	if r := results[2]; r.card != nil || r.term != "tzuyu" {
+		t.Errorf("tzuyu = %+v, want no card", r)
+	}
+	if cards.loads != 1 {
+		t.Errorf("cards loaded %d times, want once for all fuzzy terms", cards.loads)
+	}
+
+	embed := createHasMatrixEmbed(discord.User{Username: "hyejoo"}, "nayeon, mom, tzuyu", results)
+	if embed.Footer == nil || embed.Footer.Text != "hyejoo owns 1 of 2 cards" {
+		t.Errorf("footer = %+v, want 1 of 2 owned", embed.Footer)
+	}
+	if !strings.Contains(embed.Description, "tzuyu") || !strings.Contains(embed.Description, "no matching card") {
+		t.Errorf("unmatched term missing from %q", embed.Description)
+	}
+}
+
+func TestCheckHasCardsSearchQuery(t *testing.T) {
+	b, _ := hasBot()
+	ops := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository)
+
+	// One term without a name checks every card it matches
+	results, err := checkHasCards(context.Background(), b, ops, "u1", []string{"-3"})
+	if err != nil {
+		t.Fatalf("checkHasCards: %v", err)
+	}
+	owned := make(map[int64]int64)
+	for _, r := range results {
+		if r.card == nil {
+			t.Fatalf("unexpected unmatched result %+v", r)
+		}
+		owned[r.card.ID] = r.amount
+	}
+	if want := map[int64]int64{2: 0, 3: 1}; !reflect.DeepEqual(owned, want) {
+		t.Errorf("owned = %v, want %v", owned, want)
+	}
+}
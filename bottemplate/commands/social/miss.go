@@ -13,6 +13,9 @@ import (
 	"github.com/disgoorg/disgo/handler"
 )
 
+// missWishlistExportLimit caps how many missing cards one wishlist export adds
+const missWishlistExportLimit = 100
+
 var Miss = discord.SlashCommandCreate{
 	Name:        "miss",
 	Description: "View missing cards from your collection",
@@ -30,62 +33,7 @@ func MissHandler(b *bottemplate.Bot) handler.CommandHandler {
 	cardDisplayService := services.NewCardDisplayService(b.CardRepository, b.SpacesService)
 	cardOperationsService := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository)
 
-	// Create pagination handler with standard items per page
-	paginationHandler := &utils.PaginationHandler{
-		Config: utils.PaginationConfig{
-			ItemsPerPage: config.CardsPerPage,
-			Prefix:       "miss",
-		},
-		FormatItems: func(items []interface{}, page, totalPages int, userID, query string) (discord.Embed, error) {
-			// Convert back to CardDisplayItem slice
-			displayItems := make([]services.CardDisplayItem, len(items))
-			for i, item := range items {
-				displayItems[i] = item.(services.CardDisplayItem)
-			}
-
-			description, err := cardDisplayService.FormatCardDisplayItems(context.Background(), displayItems)
-			if err != nil {
-				return discord.Embed{}, fmt.Errorf("failed to format card display: %w", err)
-			}
-
-			// Calculate total items from pagination data
-			itemsPerPage := config.CardsPerPage
-			totalItems := totalPages * itemsPerPage
-			if page == totalPages-1 {
-				// Last page might have fewer items
-				totalItems = (totalPages-1)*itemsPerPage + len(items)
-			}
-
-			embed := discord.NewEmbedBuilder().
-				SetTitle("Missing Cards").
-				SetDescription(description).
-				SetColor(config.BackgroundColor).
-				SetFooter(fmt.Sprintf("Page %d/%d • Total Missing: %d", page+1, totalPages, totalItems), "")
-
-			// Add search query to description if provided
-			if query != "" {
-				embed.SetDescription(fmt.Sprintf("🔍`%s`\n\n%s", query, description))
-			}
-
-			return embed.Build(), nil
-		},
-		FormatCopy: func(items []interface{}) string {
-			// Convert back to CardDisplayItem slice
-			displayItems := make([]services.CardDisplayItem, len(items))
-			for i, item := range items {
-				displayItems[i] = item.(services.CardDisplayItem)
-			}
-
-			copyText, err := cardDisplayService.FormatCopyText(context.Background(), displayItems, "Missing Cards")
-			if err != nil {
-				return "Error formatting copy text"
-			}
-			return copyText
-		},
-		ValidateUser: func(eventUserID, targetUserID string) bool {
-			return eventUserID == targetUserID
-		},
-	}
+	factory := newMissPaginationFactory(b, cardOperationsService)
 
 	return func(e *handler.CommandEvent) error {
 		// Defer immediately to avoid 3s timeout -> prevents Unknown interaction (10062)
@@ -136,7 +84,10 @@ func MissHandler(b *bottemplate.Bot) handler.CommandHandler {
 				items[i] = item
 			}
 
-			embed, components, err := paginationHandler.CreateInitialPaginationEmbed(items, e.User().ID.String(), query)
+			embed, components, err := factory.CreateInitialPaginationEmbedFromItems(items, utils.PaginationParams{
+				UserID: e.User().ID.String(),
+				Query:  query,
+			})
 			if err != nil {
 				_, _ = e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
 					Title:       "Error",
@@ -156,7 +107,23 @@ func MissHandler(b *bottemplate.Bot) handler.CommandHandler {
 // MissComponentHandler handles pagination for missing cards using the new unified factory
 func MissComponentHandler(b *bottemplate.Bot) handler.ComponentHandler {
 	cardOperationsService := services.NewCardOperationsService(b.CardRepository, b.UserCardRepository)
+	paginate := newMissPaginationFactory(b, cardOperationsService).CreateHandler()
+	parser := utils.NewRegularParser("miss")
+
+	return func(e *handler.ComponentEvent) error {
+		if strings.HasPrefix(e.Data.CustomID(), "/miss/wish/") {
+			params, err := parser.Parse(e.Data.CustomID())
+			if err != nil {
+				return nil // Invalid component ID, ignore
+			}
+			return handleMissWishlistExport(b, cardOperationsService, e, params)
+		}
+		return paginate(e)
+	}
+}
 
+// newMissPaginationFactory builds the pagination shared by /miss and its buttons
+func newMissPaginationFactory(b *bottemplate.Bot, cardOperationsService *services.CardOperationsService) *utils.PaginationFactory {
 	// Create data fetcher
 	fetcher := &MissDataFetcher{
 		bot:                   b,
@@ -179,11 +146,87 @@ func MissComponentHandler(b *bottemplate.Bot) handler.ComponentHandler {
 		Fetcher:      fetcher,
 		Formatter:    formatter,
 		Validator:    validator,
+		ExtraButtons: missWishlistButtons,
 	}
 
-	// Create factory and return handler
-	factory := utils.NewPaginationFactory(factoryConfig)
-	return factory.CreateHandler()
+	return utils.NewPaginationFactory(factoryConfig)
+}
+
+// missWishlistButtons adds the wishlist export button below the miss pagination
+func missWishlistButtons(params utils.PaginationParams) []discord.InteractiveComponent {
+	params.Page = 0
+	return []discord.InteractiveComponent{
+		discord.NewPrimaryButton("⭐ Add to Wishlist", utils.NewRegularParser("miss").BuildComponentID("miss", "wish", params)),
+	}
+}
+
+// handleMissWishlistExport adds the cards matching the miss query to the user's
+// wishlist, up to missWishlistExportLimit, skipping cards already on it
+func handleMissWishlistExport(b *bottemplate.Bot, cardOperationsService *services.CardOperationsService, e *handler.ComponentEvent, params utils.PaginationParams) error {
+	if e.User().ID.String() != params.UserID {
+		return e.CreateMessage(discord.MessageCreate{
+			Content: "Only the command user can add these cards to their wishlist.",
+			Flags:   discord.MessageFlagEphemeral,
+		})
+	}
+
+	if err := e.DeferCreateMessage(true); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+	defer cancel()
+
+	missingCards, err := cardOperationsService.GetMissingCards(ctx, params.UserID, params.Query)
+	if err != nil {
+		_, err = e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
+			Title:       "Error",
+			Description: "Failed to fetch missing cards",
+			Color:       config.ErrorColor,
+		}}})
+		return err
+	}
+
+	if len(missingCards) == 0 {
+		_, err = e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
+			Title:       "Wishlist",
+			Description: "No missing cards to add.",
+			Color:       config.BackgroundColor,
+		}}})
+		return err
+	}
+
+	exported := missingCards
+	if len(exported) > missWishlistExportLimit {
+		exported = exported[:missWishlistExportLimit]
+	}
+	cardIDs := make([]int64, len(exported))
+	for i, card := range exported {
+		cardIDs[i] = card.ID
+	}
+
+	added, existing, err := b.WishlistRepository.BulkAdd(ctx, params.UserID, cardIDs)
+	if err != nil {
+		_, err = e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
+			Title:       "Error",
+			Description: "Failed to add cards to your wishlist",
+			Color:       config.ErrorColor,
+		}}})
+		return err
+	}
+
+	description := fmt.Sprintf("Added **%d** cards to your wishlist.\n**%d** were already on it.", added, existing)
+	if len(missingCards) > len(exported) {
+		description += fmt.Sprintf("\n\nOnly the first %d of %d missing cards were exported; narrow your query to add the rest.",
+			len(exported), len(missingCards))
+	}
+
+	_, err = e.UpdateInteractionResponse(discord.MessageUpdate{Embeds: &[]discord.Embed{{
+		Title:       "Wishlist",
+		Description: description,
+		Color:       config.SuccessColor,
+	}}})
+	return err
 }
 
 // MissDataFetcher implements DataFetcher for miss pagination
@@ -236,19 +279,11 @@ func (mf *MissFormatter) FormatItems(items []interface{}, page, totalPages int,
 		return discord.Embed{}, fmt.Errorf("failed to format card display: %w", err)
 	}
 
-	// Calculate total items from pagination data
-	itemsPerPage := config.CardsPerPage
-	totalItems := totalPages * itemsPerPage
-	if page == totalPages-1 {
-		// Last page might have fewer items
-		totalItems = (totalPages-1)*itemsPerPage + len(items)
-	}
-
 	embed := discord.NewEmbedBuilder().
 		SetTitle("Missing Cards").
 		SetDescription(description).
 		SetColor(config.BackgroundColor).
-		SetFooter(fmt.Sprintf("Page %d/%d • Total Missing: %d", page+1, totalPages, totalItems), "")
+		SetFooter(utils.FormatPageFooter(page, totalPages, config.CardsPerPage, params.TotalItems), "")
 
 	// Add search query to description if provided
 	if params.Query != "" {
@@ -0,0 +1,29 @@
+package social
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestMissWishlistButtonRoundTrip(t *testing.T) {
+	buttons := missWishlistButtons(utils.PaginationParams{UserID: "u1", Page: 3, Query: "twice -3"})
+	if len(buttons) != 1 {
+		t.Fatalf("got %d buttons, want 1", len(buttons))
+	}
+	customID := buttons[0].(discord.ButtonComponent).CustomID
+	if !strings.HasPrefix(customID, "/miss/wish/") {
+		t.Fatalf("custom ID %q isn't routed to the wishlist export", customID)
+	}
+
+	// The export always covers the whole query, not the page it was clicked on
+	params, err := utils.NewRegularParser("miss").Parse(customID)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", customID, err)
+	}
+	if params.UserID != "u1" || params.Page != 0 || params.Query != "twice -3" {
+		t.Errorf("params = %+v", params)
+	}
+}
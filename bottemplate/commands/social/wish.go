@@ -39,9 +39,10 @@ var Wish = discord.SlashCommandCreate{
 			Description: "Add cards to your wishlist",
 			Options: []discord.ApplicationCommandOption{
 				discord.ApplicationCommandOptionString{
-					Name:        "card_query",
-					Description: "Cards to add to wishlist (name or ID)",
-					Required:    true,
+					Name:         "card_query",
+					Description:  "Cards to add to wishlist (name or ID)",
+					Required:     true,
+					Autocomplete: true,
 				},
 				discord.ApplicationCommandOptionBool{
 					Name:        "exact",
@@ -55,9 +56,10 @@ var Wish = discord.SlashCommandCreate{
 			Description: "Remove cards from your wishlist",
 			Options: []discord.ApplicationCommandOption{
 				discord.ApplicationCommandOptionString{
-					Name:        "card_query",
-					Description: "Cards to remove from wishlist",
-					Required:    true,
+					Name:         "card_query",
+					Description:  "Cards to remove from wishlist",
+					Required:     true,
+					Autocomplete: true,
 				},
 			},
 		},
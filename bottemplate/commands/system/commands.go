@@ -10,8 +10,10 @@ var Commands = []discord.ApplicationCommandCreate{
 	CraftEffect,
 	Help,
 	Profile,
+	Preferences,
 	QuestsCommand,
 	QuestClaimCommand,
+	QuestLeaderboardCommand,
 	Effects,
 	EffectInfo,
 }
@@ -319,11 +319,17 @@ func (h *HelpHandler) getCommandCategories() map[string]CategoryInfo {
 			Commands: []CommandInfo{
 				{Name: "analyze-economy", Description: "📊 Analyze the current economic state"},
 				{Name: "analyzeusers", Description: "📊 Analyze MongoDB users data for migration"},
+				{Name: "ban", Description: "🔨 Ban a user from the bot, optionally for a number of days"},
 				{Name: "dbtest", Description: "Test database connectivity and operations"},
+				{Name: "economy-config", Description: "💰 Show the daily and work reward values in effect"},
 				{Name: "deletecard", Description: "Permanently delete a card and remove it from all users"},
+				{Name: "guild-config", Description: "⚙️ Enable or disable command categories in this server"},
 				{Name: "fixduplicates", Description: "🛠️ Fix duplicate cards in all collections"},
-				{Name: "init", Description: "Initialize database tables if they don't exist"},
+				{Name: "init", Description: "Initialize database tables and default data; safe to re-run"},
 				{Name: "manage-images", Description: "🖼️ Manage card images", Subcommands: []string{"update", "verify", "delete"}},
+				{Name: "processes", Description: "⚙️ Inspect and restart background processes"},
+				{Name: "reload-collections", Description: "🔄 Reload the collection cache after collections change"},
+				{Name: "unban", Description: "✅ Lift a user's ban"},
 			},
 		},
 		"cards": {
@@ -335,6 +341,7 @@ func (h *HelpHandler) getCommandCategories() map[string]CategoryInfo {
 				{Name: "cards", Description: "View your card collection"},
 				{Name: "claim", Description: "✨ Claim cards from the collection!"},
 				{Name: "forge", Description: "✨ Forge two cards into a new one"},
+				{Name: "hero", Description: "🦸 Set hero cards that boost your /work rewards", Subcommands: []string{"set", "view"}},
 				{Name: "levelup", Description: "Level up or combine your cards"},
 				{Name: "limitedcards", Description: "🎴 List all unowned cards from limited collection"},
 				{Name: "limitedstats", Description: "📊 View ownership statistics for limited collection cards"},
@@ -349,11 +356,14 @@ func (h *HelpHandler) getCommandCategories() map[string]CategoryInfo {
 			Emoji:       "💰",
 			Commands: []CommandInfo{
 				{Name: "auction", Description: "Auction related commands", Subcommands: []string{"create", "list", "bid", "cancel"}},
+				{Name: "auctions", Description: "Browse active auctions by card, ending soonest first"},
 				{Name: "balance", Description: "💰 View your current balance and earnings"},
 				{Name: "daily", Description: "Claim your daily reward!"},
+				{Name: "gift-item", Description: "🎁 Give some of your items to another user"},
 				{Name: "liquefy", Description: "Convert a card into vials"},
 				{Name: "price-stats", Description: "📊 View detailed price statistics for a card"},
 				{Name: "shop", Description: "Browse and purchase items from the shop"},
+				{Name: "vote", Description: "🗳️ Vote for the bot and see when your next vote reward is ready"},
 				{Name: "work", Description: "💼 Work in the K-pop industry to earn rewards"},
 			},
 		},
@@ -363,7 +373,7 @@ func (h *HelpHandler) getCommandCategories() map[string]CategoryInfo {
 			Color:       0xA8E6CF,
 			Emoji:       "👥",
 			Commands: []CommandInfo{
-				{Name: "diff", Description: "Compare card collections between users", Subcommands: []string{"for", "missing"}},
+				{Name: "diff", Description: "Compare card collections between users", Subcommands: []string{"for", "from", "summary"}},
 				{Name: "has", Description: "Check if a user has a specific card"},
 				{Name: "miss", Description: "View missing cards from your collection"},
 				{Name: "wish", Description: "Manage your card wishlist", Subcommands: []string{"list", "add", "remove"}},
@@ -379,6 +389,8 @@ func (h *HelpHandler) getCommandCategories() map[string]CategoryInfo {
 				{Name: "help", Description: "📖 Display all available commands and their descriptions"},
 				{Name: "inventory", Description: "View your inventory of items"},
 				{Name: "metrics", Description: "📊 View bot performance metrics and statistics"},
+				{Name: "preferences", Description: "🔔 Choose which DM notifications you get"},
+				{Name: "quest-leaderboard", Description: "🏆 See who completed the most quests this period"},
 				{Name: "use-effect", Description: "Use an active effect from your inventory"},
 				{Name: "version", Description: "Display bot version and commit information"},
 			},
@@ -18,10 +18,26 @@ import (
 var Inventory = discord.SlashCommandCreate{
 	Name:        "inventory",
 	Description: "View your inventory of items",
+	Options: []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionString{
+			Name:        "sort",
+			Description: "How to order your items",
+			Required:    false,
+			Choices: []discord.ApplicationCommandOptionChoiceString{
+				{Name: "Rarity", Value: string(inventorySortRarity)},
+				{Name: "Name", Value: string(inventorySortName)},
+				{Name: "Quantity", Value: string(inventorySortQuantity)},
+			},
+		},
+		discord.ApplicationCommandOptionString{
+			Name:        "filter",
+			Description: "Only show items whose name or description contains this text",
+			Required:    false,
+			MaxLength:   utils.Ptr(maxInventoryFilterLen),
+		},
+	},
 }
 
-const maxInventorySelectOptions = 25
-
 type InventoryHandler struct {
 	bot           *bottemplate.Bot
 	effectManager *effects.Manager
@@ -44,6 +60,8 @@ func (h *InventoryHandler) Handle(event *handler.CommandEvent) error {
 func (h *InventoryHandler) handleList(event *handler.CommandEvent) error {
 	ctx := context.Background()
 	userID := event.User().ID.String()
+	data := event.SlashCommandInteractionData()
+	view := newInventoryView(data.String("sort"), data.String("filter"))
 
 	// Get effects
 	items, err := h.effectManager.ListUserEffects(ctx, userID)
@@ -71,63 +89,117 @@ func (h *InventoryHandler) handleList(event *handler.CommandEvent) error {
 		return err
 	}
 
+	filteredItems := sortAndFilterEffectItems(items, view)
+	filteredMaterials := sortAndFilterUserItems(userItems, view)
+	if len(filteredItems) == 0 && len(filteredMaterials) == 0 {
+		return utils.EH.UpdateInteractionResponse(event, "Inventory", fmt.Sprintf("No items match %q", view.Filter))
+	}
+
+	// Default to materials first if any exist
+	selectedCategory := "materials"
+	if len(filteredMaterials) == 0 {
+		actives, recipes, _ := groupItems(filteredItems)
+		switch {
+		case len(recipes) > 0:
+			selectedCategory = "recipe"
+		case len(actives) > 0:
+			selectedCategory = "active"
+		default:
+			selectedCategory = "passive"
+		}
+	}
+
+	embed, components, ok := renderInventoryCategory(filteredItems, filteredMaterials, len(items), selectedCategory, userID, view)
+	if !ok {
+		_, err := event.UpdateInteractionResponse(discord.MessageUpdate{
+			Content: utils.Ptr("No items in your inventory"),
+		})
+		return err
+	}
+
+	_, err = event.UpdateInteractionResponse(discord.MessageUpdate{
+		Embeds:     &[]discord.Embed{embed},
+		Components: &components,
+	})
+	return err
+}
+
+// renderInventoryCategory builds the inventory view for a category from already sorted and
+// filtered items. It returns false if the category has nothing to show.
+func renderInventoryCategory(items []*models.EffectItem, userItems []*models.UserItem, totalEffects int, category, ownerID string, view inventoryView) (discord.Embed, []discord.ContainerComponent, bool) {
+	if category == "materials" {
+		embed, components := createMaterialsEmbed(userItems, totalEffects, ownerID, view)
+		return embed, components, true
+	}
+
 	actives, recipes, passives := groupItems(items)
 	var currentItems []*models.EffectItem
 	var title string
 
-	// Default to materials first if any exist
-	if len(userItems) > 0 {
-		// Show materials view
-		return h.showMaterialsView(event, userItems, len(items))
-	} else if len(recipes) > 0 {
+	switch category {
+	case "recipe":
 		currentItems = recipes
 		title = "📦 Your Inventory - Recipes"
-	} else if len(actives) > 0 {
+	case "active":
 		currentItems = actives
 		title = "📦 Your Inventory - Items"
-	} else {
+	case "passive":
 		currentItems = passives
 		title = "📦 Your Inventory - Effects"
 	}
 
 	if len(currentItems) == 0 {
-		_, err := event.UpdateInteractionResponse(discord.MessageUpdate{
-			Content: utils.Ptr("No items in your inventory"),
-		})
-		return err
+		return discord.Embed{}, nil, false
 	}
 
-	selectedCategory := "recipe"
-	if len(recipes) == 0 {
-		if len(actives) > 0 {
-			selectedCategory = "active"
-		} else {
-			selectedCategory = "passive"
-		}
+	start, end, totalPages := inventoryPageBounds(len(currentItems), view.Page)
+	view.Page = start / inventoryPageSize
+	pageItems := currentItems[start:end]
+
+	var description strings.Builder
+	description.WriteString("Select an item to view details\n\n")
+	for _, item := range pageItems {
+		description.WriteString(fmt.Sprintf("%s **%s**\n", getTypeEmoji(item.Type), item.Name))
 	}
 
 	components := []discord.ContainerComponent{
-		createInventoryCategories(selectedCategory, userID),
-		createInventoryItems(currentItems, selectedCategory, userID),
+		createInventoryCategories(category, ownerID, view),
+		createInventoryItems(pageItems, category, ownerID, view),
+	}
+	if totalPages > 1 {
+		components = append(components, createInventoryPageButtons(category, ownerID, view, totalPages))
 	}
 
-	_, err = event.UpdateInteractionResponse(discord.MessageUpdate{
-		Embeds: &[]discord.Embed{{
-			Title:       title,
-			Description: "Select an item to view details",
-			Color:       getColorByType(currentItems[0].Type),
-			Footer: &discord.EmbedFooter{
-				Text: fmt.Sprintf("Total Items: %d", len(items)),
-			},
-		}},
-		Components: &components,
-	})
-	return err
+	embed := discord.Embed{
+		Title:       title,
+		Description: description.String(),
+		Color:       getColorByType(currentItems[0].Type),
+		Footer: &discord.EmbedFooter{
+			Text: fmt.Sprintf("Total Items: %d • %s", totalEffects, view.footer(totalPages)),
+		},
+	}
+	return embed, components, true
+}
+
+func createInventoryPageButtons(category, ownerID string, view inventoryView, totalPages int) discord.ContainerComponent {
+	pageID := func(page int) string {
+		return fmt.Sprintf("/inventory_page/%s/%s/%d/%s", ownerID, category, page, view.idSuffix())
+	}
+
+	prev := discord.NewSecondaryButton("◀ Previous", pageID(view.Page-1))
+	if view.Page == 0 {
+		prev = prev.AsDisabled()
+	}
+	next := discord.NewSecondaryButton("Next ▶", pageID(view.Page+1))
+	if view.Page >= totalPages-1 {
+		next = next.AsDisabled()
+	}
+	return discord.NewActionRow(prev, next)
 }
 
-func createInventoryCategories(selectedValue string, ownerID string) discord.ContainerComponent {
+func createInventoryCategories(selectedValue string, ownerID string, view inventoryView) discord.ContainerComponent {
 	return discord.NewActionRow(
-		discord.NewStringSelectMenu("/inventory_category/"+ownerID, "Select Category",
+		discord.NewStringSelectMenu("/inventory_category/"+ownerID+"/"+view.idSuffix(), "Select Category",
 			discord.StringSelectMenuOption{
 				Label:       "Materials",
 				Value:       "materials",
@@ -160,8 +232,8 @@ func createInventoryCategories(selectedValue string, ownerID string) discord.Con
 	)
 }
 
-func createInventoryItems(items []*models.EffectItem, _ string, ownerID string) discord.ContainerComponent {
-	options := make([]discord.StringSelectMenuOption, 0, maxInventorySelectOptions)
+func createInventoryItems(items []*models.EffectItem, _ string, ownerID string, view inventoryView) discord.ContainerComponent {
+	options := make([]discord.StringSelectMenuOption, 0, inventoryPageSize)
 	seen := make(map[string]struct{}, len(items))
 	for _, item := range items {
 		if item == nil || item.ID == "" {
@@ -171,7 +243,7 @@ func createInventoryItems(items []*models.EffectItem, _ string, ownerID string)
 			continue
 		}
 		seen[item.ID] = struct{}{}
-		if len(options) >= maxInventorySelectOptions {
+		if len(options) >= inventoryPageSize {
 			break
 		}
 
@@ -191,7 +263,7 @@ func createInventoryItems(items []*models.EffectItem, _ string, ownerID string)
 	}
 
 	return discord.NewActionRow(
-		discord.NewStringSelectMenu("/inventory_item/"+ownerID, "Select Item", options...).
+		discord.NewStringSelectMenu("/inventory_item/"+ownerID+"/"+view.idSuffix(), "Select Item", options...).
 			WithMinValues(1).
 			WithMaxValues(1),
 	)
@@ -278,16 +350,15 @@ func (h *InventoryHandler) handleItemSelect(event *handler.ComponentEvent) error
 	embed.SetDescription(description.String())
 	embed.SetFooter("💡 Use /shop to purchase more items", "")
 
-	// ownerID encoded in the custom ID of the select that triggered this view
+	// ownerID, sort and filter are encoded in the custom ID of the select that triggered this view
 	ownerID := event.User().ID.String()
-	if idd, ok := event.Data.(interface{ CustomID() string }); ok {
-		parts := strings.Split(idd.CustomID(), "/")
-		if len(parts) >= 3 && parts[2] != "" {
-			ownerID = parts[2]
-		}
+	parts := strings.Split(data.CustomID(), "/")
+	if len(parts) >= 3 && parts[2] != "" {
+		ownerID = parts[2]
 	}
+	view := parseInventoryView(parts[min(len(parts), 3):])
 	actionRow := discord.NewActionRow(
-		discord.NewSecondaryButton("Back to Inventory ↩️", "/inventory_category/"+ownerID),
+		discord.NewSecondaryButton("Back to Inventory ↩️", "/inventory_category/"+ownerID+"/"+view.idSuffix()),
 	)
 
 	return event.UpdateMessage(discord.MessageUpdate{
@@ -312,6 +383,12 @@ func (h *InventoryHandler) HandleComponent(event *handler.ComponentEvent) error
 			return utils.EH.CreateEphemeralError(event, "Only the command user can navigate this inventory.")
 		}
 		return h.handleItemSelect(event)
+	case strings.HasPrefix(customID, "/inventory_page/"):
+		parts := strings.Split(customID, "/")
+		if len(parts) < 3 || parts[2] == "" || parts[2] != event.User().ID.String() {
+			return utils.EH.CreateEphemeralError(event, "Only the command user can navigate this inventory.")
+		}
+		return h.handlePageSelect(event, parts)
 	default:
 		return nil
 	}
@@ -319,7 +396,6 @@ func (h *InventoryHandler) HandleComponent(event *handler.ComponentEvent) error
 
 func (h *InventoryHandler) handleCategorySelect(event *handler.ComponentEvent) error {
 	var selectedValue string
-	ownerID := ""
 
 	// Handle both button and select menu interactions
 	switch data := event.Data.(type) {
@@ -331,16 +407,8 @@ func (h *InventoryHandler) handleCategorySelect(event *handler.ComponentEvent) e
 			})
 		}
 		selectedValue = data.Values[0]
-		parts := strings.Split(data.CustomID(), "/")
-		if len(parts) >= 3 {
-			ownerID = parts[2]
-		}
 	case discord.ButtonInteractionData:
 		selectedValue = "recipe" // Default to recipes when coming from back button
-		parts := strings.Split(data.CustomID(), "/")
-		if len(parts) >= 3 {
-			ownerID = parts[2]
-		}
 	default:
 		return event.CreateMessage(discord.MessageCreate{
 			Content: "Invalid interaction data",
@@ -348,10 +416,30 @@ func (h *InventoryHandler) handleCategorySelect(event *handler.ComponentEvent) e
 		})
 	}
 
+	parts := strings.Split(event.Data.CustomID(), "/")
+	ownerID := ""
+	if len(parts) >= 3 {
+		ownerID = parts[2]
+	}
 	if ownerID == "" || ownerID != event.User().ID.String() {
 		return utils.EH.CreateEphemeralError(event, "Only the command user can navigate this inventory.")
 	}
 
+	return h.updateCategoryView(event, selectedValue, ownerID, parseInventoryView(parts[min(len(parts), 3):]))
+}
+
+// handlePageSelect handles /inventory_page/{owner}/{category}/{page}/{sort}/{filter}
+func (h *InventoryHandler) handlePageSelect(event *handler.ComponentEvent, parts []string) error {
+	if len(parts) < 5 {
+		return utils.EH.CreateEphemeralError(event, "Invalid page selection")
+	}
+
+	view := parseInventoryView(parts[5:])
+	view.Page = parseInventoryPage(parts[4])
+	return h.updateCategoryView(event, parts[3], parts[2], view)
+}
+
+func (h *InventoryHandler) updateCategoryView(event *handler.ComponentEvent, category, ownerID string, view inventoryView) error {
 	ctx := context.Background()
 	userID := event.User().ID.String()
 
@@ -360,50 +448,29 @@ func (h *InventoryHandler) handleCategorySelect(event *handler.ComponentEvent) e
 		return utils.EH.CreateEphemeralError(event, fmt.Sprintf("Failed to fetch inventory: %v", err))
 	}
 
-	actives, recipes, passives := groupItems(items)
-	var currentItems []*models.EffectItem
-	var title string
-
-	switch selectedValue {
-	case "materials":
+	var userItems []*models.UserItem
+	if category == "materials" {
 		// Get material items
-		userItems, err := h.bot.ItemRepository.GetUserItems(ctx, userID)
+		userItems, err = h.bot.ItemRepository.GetUserItems(ctx, userID)
 		if err != nil {
 			return utils.EH.CreateEphemeralError(event, fmt.Sprintf("Failed to fetch items: %v", err))
 		}
-		return h.updateMaterialsView(event, userItems, len(items))
-	case "recipe":
-		currentItems = recipes
-		title = "📦 Your Inventory - Recipes"
-	case "active":
-		currentItems = actives
-		title = "📦 Your Inventory - Items"
-	case "passive":
-		currentItems = passives
-		title = "📦 Your Inventory - Effects"
 	}
 
-	if len(currentItems) == 0 {
+	embed, components, ok := renderInventoryCategory(
+		sortAndFilterEffectItems(items, view),
+		sortAndFilterUserItems(userItems, view),
+		len(items), category, ownerID, view,
+	)
+	if !ok {
 		return event.CreateMessage(discord.MessageCreate{
 			Content: "No items in this category",
 			Flags:   discord.MessageFlagEphemeral,
 		})
 	}
 
-	components := []discord.ContainerComponent{
-		createInventoryCategories(selectedValue, ownerID),
-		createInventoryItems(currentItems, selectedValue, ownerID),
-	}
-
 	return event.UpdateMessage(discord.MessageUpdate{
-		Embeds: &[]discord.Embed{{
-			Title:       title,
-			Description: "Select an item to view details",
-			Color:       getColorByType(currentItems[0].Type),
-			Footer: &discord.EmbedFooter{
-				Text: fmt.Sprintf("Total Items: %d", len(items)),
-			},
-		}},
+		Embeds:     &[]discord.Embed{embed},
 		Components: &components,
 	})
 }
@@ -455,49 +522,28 @@ func groupItems(items []*models.EffectItem) (actives, recipes, passives []*model
 	return
 }
 
-func (h *InventoryHandler) showMaterialsView(event *handler.CommandEvent, userItems []*models.UserItem, totalEffects int) error {
-	embed, components := h.createMaterialsEmbed(userItems, totalEffects, event.User().ID.String())
-	_, err := event.UpdateInteractionResponse(discord.MessageUpdate{
-		Embeds:     &[]discord.Embed{embed},
-		Components: &components,
-	})
-	return err
-}
+func createMaterialsEmbed(userItems []*models.UserItem, totalEffects int, ownerID string, view inventoryView) (discord.Embed, []discord.ContainerComponent) {
+	start, end, totalPages := inventoryPageBounds(len(userItems), view.Page)
+	view.Page = start / inventoryPageSize
 
-func (h *InventoryHandler) updateMaterialsView(event *handler.ComponentEvent, userItems []*models.UserItem, totalEffects int) error {
-	ownerID := event.User().ID.String()
-	if idd, ok := event.Data.(interface{ CustomID() string }); ok {
-		parts := strings.Split(idd.CustomID(), "/")
-		if len(parts) >= 3 && parts[2] != "" {
-			ownerID = parts[2]
-		}
-	}
-	embed, components := h.createMaterialsEmbed(userItems, totalEffects, ownerID)
-	return event.UpdateMessage(discord.MessageUpdate{
-		Embeds:     &[]discord.Embed{embed},
-		Components: &components,
-	})
-}
-
-func (h *InventoryHandler) createMaterialsEmbed(userItems []*models.UserItem, totalEffects int, ownerID string) (discord.Embed, []discord.ContainerComponent) {
 	var description strings.Builder
 	description.WriteString("```ansi\n")
 	description.WriteString("\u001b[1;36m🎁 Crafting Materials\u001b[0m\n\n")
 
-	hasItems := false
-	for _, userItem := range userItems {
-		if userItem.Item != nil && userItem.Quantity > 0 {
-			hasItems = true
-			description.WriteString(fmt.Sprintf("%s %s x%d\n",
-				userItem.Item.Emoji,
-				userItem.Item.Name,
-				userItem.Quantity))
-		}
+	for _, userItem := range userItems[start:end] {
+		description.WriteString(fmt.Sprintf("%s %s x%d\n",
+			userItem.Item.Emoji,
+			userItem.Item.Name,
+			userItem.Quantity))
 	}
 
-	if !hasItems {
-		description.WriteString("\u001b[1;33mNo materials yet!\u001b[0m\n")
-		description.WriteString("Earn materials by working with /work\n")
+	if len(userItems) == 0 {
+		if view.Filter != "" {
+			description.WriteString("\u001b[1;33mNo materials match your filter\u001b[0m\n")
+		} else {
+			description.WriteString("\u001b[1;33mNo materials yet!\u001b[0m\n")
+			description.WriteString("Earn materials by working with /work\n")
+		}
 	}
 
 	description.WriteString("\n\u001b[1;32m💡 Tip:\u001b[0m Collect 1 of each material to /fuse them into an album card!")
@@ -507,11 +553,14 @@ func (h *InventoryHandler) createMaterialsEmbed(userItems []*models.UserItem, to
 		SetTitle("📦 Your Inventory - Materials").
 		SetDescription(description.String()).
 		SetColor(config.InfoColor).
-		SetFooter(fmt.Sprintf("Total Items: %d materials, %d effects", len(userItems), totalEffects), "").
+		SetFooter(fmt.Sprintf("Total Items: %d materials, %d effects • %s", len(userItems), totalEffects, view.footer(totalPages)), "").
 		Build()
 
 	components := []discord.ContainerComponent{
-		createInventoryCategories("materials", ownerID),
+		createInventoryCategories("materials", ownerID, view),
+	}
+	if totalPages > 1 {
+		components = append(components, createInventoryPageButtons("materials", ownerID, view, totalPages))
 	}
 
 	return embed, components
@@ -0,0 +1,188 @@
+package system
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// inventorySort is the order inventory entries are listed in
+type inventorySort string
+
+const (
+	inventorySortRarity   inventorySort = "rarity"
+	inventorySortName     inventorySort = "name"
+	inventorySortQuantity inventorySort = "quantity"
+
+	inventoryPageSize     = 25 // Matches Discord's select option and embed field limits
+	maxInventoryFilterLen = 32
+)
+
+func parseInventorySort(value string) inventorySort {
+	switch inventorySort(value) {
+	case inventorySortName, inventorySortQuantity:
+		return inventorySort(value)
+	default:
+		return inventorySortRarity
+	}
+}
+
+// inventoryView is the sort, filter and page state carried in inventory component IDs
+type inventoryView struct {
+	Sort   inventorySort
+	Filter string
+	Page   int
+}
+
+func newInventoryView(sortValue, filter string) inventoryView {
+	// Slashes would break custom ID parsing, and IDs are capped at 100 characters
+	filter = strings.TrimSpace(strings.ReplaceAll(filter, "/", ""))
+	if runes := []rune(filter); len(runes) > maxInventoryFilterLen {
+		filter = string(runes[:maxInventoryFilterLen])
+	}
+	return inventoryView{Sort: parseInventorySort(sortValue), Filter: filter}
+}
+
+// parseInventoryView reads "<sort>/<filter>" from the custom ID parts following the owner ID
+func parseInventoryView(parts []string) inventoryView {
+	var sortValue, filter string
+	if len(parts) > 0 {
+		sortValue = parts[0]
+	}
+	if len(parts) > 1 {
+		filter = parts[1]
+	}
+	return newInventoryView(sortValue, filter)
+}
+
+// idSuffix encodes the sort and filter for a component custom ID
+func (v inventoryView) idSuffix() string {
+	return string(v.Sort) + "/" + v.Filter
+}
+
+// footer describes the active sort, filter and page for the embed footer
+func (v inventoryView) footer(totalPages int) string {
+	parts := []string{"Sorted by " + string(v.Sort)}
+	if v.Filter != "" {
+		parts = append(parts, fmt.Sprintf("Filter: %q", v.Filter))
+	}
+	if totalPages > 1 {
+		parts = append(parts, fmt.Sprintf("Page %d/%d", v.Page+1, totalPages))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// inventoryEntry holds the fields inventory entries are sorted by
+type inventoryEntry struct {
+	Name     string
+	Rarity   int
+	Quantity int
+}
+
+// compareInventoryEntries orders two entries by the given sort, highest rarity and
+// quantity first, falling back to name so the order is stable across pages.
+func compareInventoryEntries(a, b inventoryEntry, by inventorySort) int {
+	switch by {
+	case inventorySortRarity:
+		if a.Rarity != b.Rarity {
+			return b.Rarity - a.Rarity
+		}
+	case inventorySortQuantity:
+		if a.Quantity != b.Quantity {
+			return b.Quantity - a.Quantity
+		}
+	}
+	return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+}
+
+// matchesInventoryFilter reports whether any of the fields contain the filter, ignoring case
+func matchesInventoryFilter(filter string, fields ...string) bool {
+	if filter == "" {
+		return true
+	}
+	filter = strings.ToLower(filter)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// effectItemEntry maps an effect item to sort fields; rarity is the total recipe stars
+func effectItemEntry(item *models.EffectItem) inventoryEntry {
+	rarity := 0
+	for _, stars := range item.Recipe {
+		rarity += int(stars)
+	}
+	return inventoryEntry{Name: item.Name, Rarity: rarity, Quantity: item.Duration}
+}
+
+func userItemEntry(userItem *models.UserItem) inventoryEntry {
+	entry := inventoryEntry{Name: userItem.ItemID, Quantity: userItem.Quantity}
+	if userItem.Item != nil {
+		entry.Name = userItem.Item.Name
+		entry.Rarity = userItem.Item.Rarity
+	}
+	return entry
+}
+
+func sortAndFilterEffectItems(items []*models.EffectItem, view inventoryView) []*models.EffectItem {
+	result := make([]*models.EffectItem, 0, len(items))
+	for _, item := range items {
+		if item != nil && matchesInventoryFilter(view.Filter, item.Name, item.Description) {
+			result = append(result, item)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return compareInventoryEntries(effectItemEntry(result[i]), effectItemEntry(result[j]), view.Sort) < 0
+	})
+	return result
+}
+
+func sortAndFilterUserItems(userItems []*models.UserItem, view inventoryView) []*models.UserItem {
+	result := make([]*models.UserItem, 0, len(userItems))
+	for _, userItem := range userItems {
+		if userItem == nil || userItem.Item == nil || userItem.Quantity <= 0 {
+			continue
+		}
+		if matchesInventoryFilter(view.Filter, userItem.Item.Name, userItem.Item.Description) {
+			result = append(result, userItem)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return compareInventoryEntries(userItemEntry(result[i]), userItemEntry(result[j]), view.Sort) < 0
+	})
+	return result
+}
+
+// inventoryPageBounds clamps the page and returns the slice bounds and page count for n entries
+func inventoryPageBounds(n, page int) (start, end, totalPages int) {
+	totalPages = (n + inventoryPageSize - 1) / inventoryPageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+	start = page * inventoryPageSize
+	end = start + inventoryPageSize
+	if end > n {
+		end = n
+	}
+	return start, end, totalPages
+}
+
+func parseInventoryPage(value string) int {
+	page, err := strconv.Atoi(value)
+	if err != nil || page < 0 {
+		return 0
+	}
+	return page
+}
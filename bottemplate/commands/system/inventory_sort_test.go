@@ -0,0 +1,135 @@
+package system
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func mixedUserItems() []*models.UserItem {
+	item := func(id, name string, rarity, quantity int) *models.UserItem {
+		return &models.UserItem{ItemID: id, Quantity: quantity, Item: &models.Item{ID: id, Name: name, Description: name + " material", Rarity: rarity}}
+	}
+	return []*models.UserItem{
+		item("broken_disc", "broken disc", 1, 12),
+		item("album", "Album", 4, 1),
+		item("photocard", "photocard", 2, 7),
+		item("lightstick", "Lightstick", 4, 3),
+		item("poster", "Poster", 2, 7),
+		item("ticket", "Ticket", 3, 0), // used up, never listed
+		nil,
+		{ItemID: "unknown", Quantity: 2}, // item definition missing
+	}
+}
+
+func userItemNames(items []*models.UserItem) []string {
+	names := make([]string, 0, len(items))
+	for _, userItem := range items {
+		names = append(names, userItem.Item.Name)
+	}
+	return names
+}
+
+func TestSortAndFilterUserItems(t *testing.T) {
+	tests := []struct {
+		name string
+		view inventoryView
+		want []string
+	}{
+		{
+			name: "rarity first, then name ignoring case",
+			view: newInventoryView("rarity", ""),
+			want: []string{"Album", "Lightstick", "photocard", "Poster", "broken disc"},
+		},
+		{
+			name: "quantity first, ties by name",
+			view: newInventoryView("quantity", ""),
+			want: []string{"broken disc", "photocard", "Poster", "Lightstick", "Album"},
+		},
+		{
+			name: "name",
+			view: newInventoryView("name", ""),
+			want: []string{"Album", "broken disc", "Lightstick", "photocard", "Poster"},
+		},
+		{
+			name: "unknown sort falls back to rarity",
+			view: newInventoryView("price", ""),
+			want: []string{"Album", "Lightstick", "photocard", "Poster", "broken disc"},
+		},
+		{
+			name: "filter ignores case",
+			view: newInventoryView("rarity", "O"),
+			want: []string{"photocard", "Poster", "broken disc"},
+		},
+		{
+			name: "filter matches the description",
+			view: newInventoryView("name", "material"),
+			want: []string{"Album", "broken disc", "Lightstick", "photocard", "Poster"},
+		},
+		{
+			name: "filter narrows the list",
+			view: newInventoryView("quantity", "card"),
+			want: []string{"photocard"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userItemNames(sortAndFilterUserItems(mixedUserItems(), tt.view))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortEffectItemsByRecipeStars(t *testing.T) {
+	items := []*models.EffectItem{
+		{Name: "Tohru Gift", Recipe: []int64{1, 2}, Duration: 3},
+		{Name: "Cake Day", Recipe: []int64{3, 3, 3}, Duration: 1},
+		{Name: "Holy Grail", Recipe: []int64{1, 1}, Duration: 5},
+		{Name: "Fandom Fever", Recipe: []int64{1, 1, 1}, Duration: 6},
+	}
+
+	got := sortAndFilterEffectItems(items, newInventoryView("rarity", ""))
+	var names []string
+	for _, item := range got {
+		names = append(names, item.Name)
+	}
+	if want := []string{"Cake Day", "Fandom Fever", "Tohru Gift", "Holy Grail"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %v, want %v", names, want)
+	}
+}
+
+func TestInventoryViewRoundTrip(t *testing.T) {
+	view := newInventoryView("quantity", " a/b "+strings.Repeat("나", 40))
+	parsed := parseInventoryView([]string{string(view.Sort), view.Filter})
+	if parsed != view {
+		t.Errorf("parsed %+v, want %+v", parsed, view)
+	}
+	if len([]rune(view.Filter)) > maxInventoryFilterLen {
+		t.Errorf("filter is %d runes, want at most %d", len([]rune(view.Filter)), maxInventoryFilterLen)
+	}
+}
+
+func TestInventoryPageBounds(t *testing.T) {
+	tests := []struct {
+		n, page                      int
+		wantStart, wantEnd, wantPage int
+	}{
+		{0, 0, 0, 0, 1},
+		{25, 0, 0, 25, 1},
+		{26, 1, 25, 26, 2},
+		{26, 9, 25, 26, 2}, // past the end clamps to the last page
+		{10, -1, 0, 10, 1},
+	}
+	for _, tt := range tests {
+		start, end, pages := inventoryPageBounds(tt.n, tt.page)
+		if start != tt.wantStart || end != tt.wantEnd || pages != tt.wantPage {
+			t.Errorf("inventoryPageBounds(%d, %d) = %d, %d, %d, want %d, %d, %d",
+				tt.n, tt.page, start, end, pages, tt.wantStart, tt.wantEnd, tt.wantPage)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package system
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"runtime"
 	"time"
 
@@ -16,6 +18,9 @@ var Metrics = discord.SlashCommandCreate{
 	Description: "📊 View bot performance metrics and statistics",
 }
 
+// effectExpiryWindow is how soon an active effect must expire to count as expiring
+const effectExpiryWindow = time.Hour
+
 func MetricsHandler(b *bottemplate.Bot) handler.CommandHandler {
 	return func(e *handler.CommandEvent) error {
 		if err := e.DeferCreateMessage(false); err != nil {
@@ -71,6 +76,27 @@ func MetricsHandler(b *bottemplate.Bot) handler.CommandHandler {
 			int(uptime.Minutes())%60,
 		)
 
+		effectsField := "```\nEffect manager not initialized\n```"
+		if b.EffectManager != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+			stats, err := b.EffectManager.Stats(ctx, effectExpiryWindow)
+			cancel()
+			if err != nil {
+				slog.Warn("Failed to collect effect stats", slog.Any("error", err))
+			}
+			effectsField = fmt.Sprintf("```\n"+
+				"Registered Passive: %d\n"+
+				"Registered Active: %d\n"+
+				"Active User Effects: %d\n"+
+				"Expiring Within 1h: %d\n"+
+				"```",
+				stats.RegisteredPassive,
+				stats.RegisteredActive,
+				stats.ActiveUserEffects,
+				stats.ExpiringSoon,
+			)
+		}
+
 		// Create and send embed
 		embed := discord.NewEmbedBuilder().
 			SetTitle("🔧 Bot Performance Metrics").
@@ -78,6 +104,7 @@ func MetricsHandler(b *bottemplate.Bot) handler.CommandHandler {
 			AddField("💾 Memory Usage", memoryField, false).
 			AddField("⚡ Latency", latencyField, false).
 			AddField("⏰ Uptime", uptimeField, false).
+			AddField("✨ Effects", effectsField, false).
 			SetColor(config.SuccessColor).
 			SetTimestamp(time.Now()).
 			SetFooter("Requested by "+e.User().Username, e.User().EffectiveAvatarURL())
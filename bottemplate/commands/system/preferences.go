@@ -0,0 +1,118 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var Preferences = discord.SlashCommandCreate{
+	Name:        "preferences",
+	Description: "🔔 Choose which DM notifications you get",
+}
+
+func PreferencesHandler(b *bottemplate.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+		defer cancel()
+
+		userID := e.User().ID.String()
+		user, err := b.UserRepository.GetByDiscordID(ctx, userID)
+		if err != nil {
+			return utils.EH.CreateSystemError(e, "Failed to fetch your profile")
+		}
+
+		settings := user.NotificationSettings()
+		return e.CreateMessage(discord.MessageCreate{
+			Embeds:     []discord.Embed{preferencesEmbed(settings)},
+			Components: preferencesComponents(settings, userID),
+			Flags:      discord.MessageFlagEphemeral,
+		})
+	}
+}
+
+// PreferencesComponentHandler handles the toggle buttons: /preferences/toggle/{key}/{userID}
+func PreferencesComponentHandler(b *bottemplate.Bot) handler.ComponentHandler {
+	return func(e *handler.ComponentEvent) error {
+		parts := strings.Split(e.Data.CustomID(), "/")
+		if len(parts) < 5 || parts[2] != "toggle" {
+			return utils.EH.CreateEphemeralError(e, "Invalid preferences component")
+		}
+		toggle, ok := models.FindNotificationToggle(parts[3])
+		if !ok {
+			return utils.EH.CreateEphemeralError(e, "Unknown preference")
+		}
+		userID := parts[4]
+		if e.User().ID.String() != userID {
+			return utils.EH.CreateEphemeralError(e, "You can only change your own preferences!")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), config.DefaultQueryTimeout)
+		defer cancel()
+
+		user, err := b.UserRepository.GetByDiscordID(ctx, userID)
+		if err != nil {
+			return utils.EH.CreateEphemeralError(e, "Failed to fetch your profile")
+		}
+		settings := user.NotificationSettings()
+		enabled := !*toggle.Field(&settings)
+		if err := b.UserRepository.SetNotificationPreference(ctx, userID, toggle.Key, enabled); err != nil {
+			return utils.EH.CreateEphemeralError(e, "Failed to save your preferences")
+		}
+		*toggle.Field(&settings) = enabled
+
+		components := preferencesComponents(settings, userID)
+		return e.UpdateMessage(discord.MessageUpdate{
+			Embeds:     &[]discord.Embed{preferencesEmbed(settings)},
+			Components: &components,
+		})
+	}
+}
+
+func preferencesEmbed(settings models.NotificationPreferences) discord.Embed {
+	var description strings.Builder
+	for _, toggle := range models.NotificationToggles {
+		status := "❌"
+		if *toggle.Field(&settings) {
+			status = "✅"
+		}
+		description.WriteString(fmt.Sprintf("%s **%s**\n%s\n", status, toggle.Label, toggle.Description))
+	}
+
+	return discord.Embed{
+		Title:       "🔔 Notification Preferences",
+		Description: description.String(),
+		Color:       config.InfoColor,
+		Footer: &discord.EmbedFooter{
+			Text: "Press a button to turn that DM on or off",
+		},
+	}
+}
+
+func preferencesComponents(settings models.NotificationPreferences, userID string) []discord.ContainerComponent {
+	var buttons []discord.InteractiveComponent
+	for _, toggle := range models.NotificationToggles {
+		customID := fmt.Sprintf("/preferences/toggle/%s/%s", toggle.Key, userID)
+		if *toggle.Field(&settings) {
+			buttons = append(buttons, discord.NewSuccessButton(toggle.Label, customID))
+		} else {
+			buttons = append(buttons, discord.NewSecondaryButton(toggle.Label, customID))
+		}
+	}
+
+	// Discord allows five buttons per row
+	var rows []discord.ContainerComponent
+	for len(buttons) > 0 {
+		n := min(len(buttons), 5)
+		rows = append(rows, discord.NewActionRow(buttons[:n]...))
+		buttons = buttons[n:]
+	}
+	return rows
+}
@@ -0,0 +1,79 @@
+package system
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestNotificationTogglesMatchJSONKeys(t *testing.T) {
+	for _, toggle := range models.NotificationToggles {
+		settings := models.DefaultPreferences().Notifications
+		*toggle.Field(&settings) = false
+
+		raw, err := json.Marshal(settings)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var decoded map[string]bool
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		// The repository updates the stored JSON by key, so the key must name the field
+		if enabled, ok := decoded[toggle.Key]; !ok || enabled {
+			t.Errorf("toggle %q does not match the JSON key of its field: %s", toggle.Key, raw)
+		}
+		if found, ok := models.FindNotificationToggle(toggle.Key); !ok || found.Label != toggle.Label {
+			t.Errorf("FindNotificationToggle(%q) = %+v, %t", toggle.Key, found, ok)
+		}
+	}
+	if _, ok := models.FindNotificationToggle("nope"); ok {
+		t.Error("found an unknown toggle")
+	}
+}
+
+func TestNotificationSettingsDefaults(t *testing.T) {
+	if got := (&models.User{}).NotificationSettings(); got != models.DefaultPreferences().Notifications {
+		t.Errorf("settings without saved preferences = %+v, want the defaults", got)
+	}
+	saved := models.DefaultPreferences()
+	saved.Notifications.AucEnd = false
+	if got := (&models.User{Preferences: saved}).NotificationSettings(); got.AucEnd {
+		t.Error("saved preference ignored")
+	}
+}
+
+func TestPreferencesComponents(t *testing.T) {
+	settings := models.DefaultPreferences().Notifications
+	settings.AucOutBid = false
+
+	rows := preferencesComponents(settings, "u1")
+	var buttons []discord.ButtonComponent
+	for _, row := range rows {
+		actionRow := row.(discord.ActionRowComponent)
+		components := actionRow.Components()
+		if len(components) > 5 {
+			t.Errorf("row has %d buttons, Discord allows 5", len(components))
+		}
+		for _, c := range components {
+			buttons = append(buttons, c.(discord.ButtonComponent))
+		}
+	}
+	if len(buttons) != len(models.NotificationToggles) {
+		t.Fatalf("got %d buttons, want one per toggle", len(buttons))
+	}
+	if buttons[0].CustomID != "/preferences/toggle/aucoutbid/u1" || buttons[0].Style != discord.ButtonStyleSecondary {
+		t.Errorf("outbid button = %+v, want a grey toggle", buttons[0])
+	}
+	if buttons[1].Style != discord.ButtonStyleSuccess {
+		t.Errorf("enabled button style = %v, want success", buttons[1].Style)
+	}
+
+	embed := preferencesEmbed(settings)
+	if !strings.HasPrefix(embed.Description, "❌ **") || strings.Count(embed.Description, "✅") != len(models.NotificationToggles)-1 {
+		t.Errorf("embed marks the wrong toggles off: %q", embed.Description)
+	}
+}
@@ -5,9 +5,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
 	"github.com/disgoorg/bot-template/bottemplate/services"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/discord"
@@ -55,15 +59,31 @@ func ProfileHandler(b *bottemplate.Bot) handler.CommandHandler {
 			return utils.EH.UpdateInteractionResponse(event, "Error", "Failed to get your profile data. Please try again later.")
 		}
 
-		// Get actual card count from user_cards table
-		userCards, err := b.UserCardRepository.GetAllByUserID(ctx, userID)
+		// Aggregate card stats in the database instead of loading every user card
+		stats, err := b.UserCardRepository.GetCardStats(ctx, userID)
 		if err != nil {
-			slog.Error("Failed to get user cards",
+			slog.Error("Failed to get card stats",
 				slog.String("user_id", userID),
 				slog.String("error", err.Error()))
 			return utils.EH.UpdateInteractionResponse(event, "Error", "Failed to get your card data. Please try again later.")
 		}
-		cardCount := len(userCards)
+		cardCount := stats.DistinctCards
+
+		levelCounts, err := b.UserCardRepository.GetLevelCounts(ctx, userID)
+		if err != nil {
+			slog.Error("Failed to get level counts",
+				slog.String("user_id", userID),
+				slog.String("error", err.Error()))
+			return utils.EH.UpdateInteractionResponse(event, "Error", "Failed to get your card data. Please try again later.")
+		}
+
+		completions, err := b.UserCardRepository.GetCollectionCompletion(ctx, userID)
+		if err != nil {
+			slog.Error("Failed to get collection completion",
+				slog.String("user_id", userID),
+				slog.String("error", err.Error()))
+			return utils.EH.UpdateInteractionResponse(event, "Error", "Failed to get your collection data. Please try again later.")
+		}
 
 		// Calculate daily streak (placeholder - using 0 for now since streak tracking needs investigation)
 		dailyStreak := 0
@@ -110,12 +130,61 @@ func ProfileHandler(b *bottemplate.Bot) handler.CommandHandler {
 
 		_, err = event.UpdateInteractionResponse(discord.MessageUpdate{
 			Content: utils.Ptr(fmt.Sprintf("🎯 **%s's Profile**", user.Username)),
+			Embeds:  &[]discord.Embed{createProfileStatsEmbed(user, stats, levelCounts, completions)},
 			Files:   []*discord.File{&file},
 		})
 		return err
 	}
 }
 
+// profileTopCollections is how many of the most complete collections the profile lists
+const profileTopCollections = 3
+
+// createProfileStatsEmbed renders the card totals, level breakdown and
+// collection progress shown under the profile card
+func createProfileStatsEmbed(user *models.User, stats *repositories.UserCardStats, levelCounts []repositories.LevelCount, completions []repositories.CollectionCompletion) discord.Embed {
+	embed := discord.NewEmbedBuilder().
+		SetColor(config.EmbedDefaultColor).
+		AddField("Cards", fmt.Sprintf("**%s** total\n**%s** distinct",
+			utils.FormatNumber(stats.TotalCards), utils.FormatNumber(int64(stats.DistinctCards))), true)
+
+	if len(levelCounts) > 0 {
+		var levels strings.Builder
+		for _, count := range levelCounts {
+			fmt.Fprintf(&levels, "%s `%s` (%s distinct)\n", strings.Repeat("★", count.Level),
+				utils.FormatNumber(count.Copies), utils.FormatNumber(int64(count.DistinctCards)))
+		}
+		embed.AddField("By Level", levels.String(), true)
+	}
+
+	completed := 0
+	for _, completion := range completions {
+		if completion.IsCompleted() {
+			completed++
+		}
+	}
+	embed.AddField("Completed Collections", fmt.Sprintf("**%d**", completed), true)
+
+	if user.PromoExp > 0 {
+		promoExp := fmt.Sprintf("**%s**", utils.FormatNumber(user.PromoExp))
+		if !user.PromoExpExpiresAt.IsZero() {
+			promoExp += fmt.Sprintf("\nexpires <t:%d:R>", user.PromoExpExpiresAt.Unix())
+		}
+		embed.AddField("Promo EXP", promoExp, true)
+	}
+
+	if len(completions) > 0 {
+		var top strings.Builder
+		for i, completion := range completions[:min(profileTopCollections, len(completions))] {
+			fmt.Fprintf(&top, "%d. **%s** `%.1f%%` (%d/%d)\n", i+1, completion.Name,
+				completion.Percentage(), completion.OwnedCards, completion.TotalCards)
+		}
+		embed.AddField("Top Collections", top.String(), false)
+	}
+
+	return embed.Build()
+}
+
 func calculateUserRank(cardCount int) string {
 	switch {
 	case cardCount >= 1000:
@@ -0,0 +1,65 @@
+package system
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestCreateProfileStatsEmbed(t *testing.T) {
+	stats := &repositories.UserCardStats{TotalCards: 1234, DistinctCards: 6}
+	levels := []repositories.LevelCount{
+		{Level: 1, DistinctCards: 3, Copies: 4},
+		{Level: 3, DistinctCards: 2, Copies: 4},
+	}
+	completions := []repositories.CollectionCompletion{
+		{CollectionID: "twice", Name: "twice", TotalCards: 3, OwnedCards: 3},
+		{CollectionID: "aespa", Name: "aespa", TotalCards: 1, OwnedCards: 1},
+		{CollectionID: "ive", Name: "ive", TotalCards: 4, OwnedCards: 1},
+		{CollectionID: "itzy", Name: "itzy", TotalCards: 5, OwnedCards: 1},
+	}
+
+	embed := createProfileStatsEmbed(&models.User{}, stats, levels, completions)
+	fields := make(map[string]string)
+	for _, field := range embed.Fields {
+		fields[field.Name] = field.Value
+	}
+
+	if got := fields["Completed Collections"]; got != "**2**" {
+		t.Errorf("completed = %q, want 2", got)
+	}
+	if got := fields["By Level"]; !strings.HasPrefix(got, "★ `4` (3 distinct)\n★★★ `4`") {
+		t.Errorf("levels = %q", got)
+	}
+	top := fields["Top Collections"]
+	if strings.Count(top, "\n") != profileTopCollections || strings.Contains(top, "itzy") {
+		t.Errorf("top collections = %q, want only the first %d", top, profileTopCollections)
+	}
+	if !strings.Contains(top, "3. **ive** `25.0%` (1/4)") {
+		t.Errorf("top collections = %q, want ive at 25%%", top)
+	}
+
+	// Users without cards get no level or top collection fields
+	embed = createProfileStatsEmbed(&models.User{}, &repositories.UserCardStats{}, nil, nil)
+	if len(embed.Fields) != 2 {
+		t.Errorf("got %d fields for an empty profile, want cards and completed only", len(embed.Fields))
+	}
+}
+
+func TestCreateProfileStatsEmbedPromoExp(t *testing.T) {
+	user := &models.User{PromoExp: 1500, PromoExpExpiresAt: time.Unix(1700000000, 0)}
+	embed := createProfileStatsEmbed(user, &repositories.UserCardStats{}, nil, nil)
+
+	for _, field := range embed.Fields {
+		if field.Name == "Promo EXP" {
+			if want := "**1,500**\nexpires <t:1700000000:R>"; field.Value != want {
+				t.Errorf("promo exp = %q, want %q", field.Value, want)
+			}
+			return
+		}
+	}
+	t.Error("no promo EXP field for a user holding promo EXP")
+}
@@ -0,0 +1,119 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+const questLeaderboardPageSize = 10
+
+var QuestLeaderboardCommand = discord.SlashCommandCreate{
+	Name:        "quest-leaderboard",
+	Description: "🏆 See who completed the most quests this period",
+	Options: []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionString{
+			Name:        "period",
+			Description: "Leaderboard period (default: weekly)",
+			Required:    false,
+			Choices: []discord.ApplicationCommandOptionChoiceString{
+				{Name: "Daily", Value: models.QuestTypeDaily},
+				{Name: "Weekly", Value: models.QuestTypeWeekly},
+				{Name: "Monthly", Value: models.QuestTypeMonthly},
+			},
+		},
+		discord.ApplicationCommandOptionInt{
+			Name:        "page",
+			Description: "Page number",
+			Required:    false,
+			MinValue:    utils.Ptr(1),
+		},
+	},
+}
+
+func QuestLeaderboardHandler(b *bottemplate.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		data := e.SlashCommandInteractionData()
+
+		period := models.QuestTypeWeekly
+		if p, ok := data.OptString("period"); ok {
+			period = p
+		}
+		page := 1
+		if p, ok := data.OptInt("page"); ok && p > 0 {
+			page = p
+		}
+
+		questService := b.QuestService
+		if questService == nil {
+			return utils.EH.CreateErrorEmbed(e, "Quest system is not available right now. Please try again later.")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		entries, total, periodStart, err := questService.GetLeaderboardPage(ctx, period, page, questLeaderboardPageSize)
+		if err != nil {
+			slog.Error("Failed to get quest leaderboard",
+				slog.String("period", period),
+				slog.Int("page", page),
+				slog.Any("error", err))
+			return utils.EH.CreateErrorEmbed(e, "Failed to load the quest leaderboard. Please try again.")
+		}
+
+		return e.CreateMessage(discord.MessageCreate{
+			Embeds: []discord.Embed{createQuestLeaderboardEmbed(entries, total, period, periodStart, page)},
+		})
+	}
+}
+
+func createQuestLeaderboardEmbed(entries []*models.QuestLeaderboard, total int, period string, periodStart time.Time, page int) discord.Embed {
+	title := strings.ToUpper(period[:1]) + period[1:]
+	totalPages := (total + questLeaderboardPageSize - 1) / questLeaderboardPageSize
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	embed := discord.NewEmbedBuilder().
+		SetTitle(fmt.Sprintf("🏆 %s Quest Leaderboard", title)).
+		SetColor(getQuestTypeColor(period)).
+		SetFooter(fmt.Sprintf("Page %d/%d • %d ranked • Period started", page, totalPages, total), "").
+		SetTimestamp(periodStart)
+
+	if len(entries) == 0 {
+		if page > 1 {
+			embed.SetDescription(fmt.Sprintf("There are only %d page(s) for this period.", totalPages))
+		} else {
+			embed.SetDescription("Nobody has completed a quest this period yet. Be the first!")
+		}
+		return embed.Build()
+	}
+
+	var sb strings.Builder
+	offset := (page - 1) * questLeaderboardPageSize
+	for i, entry := range entries {
+		rank := offset + i + 1
+		medal := fmt.Sprintf("`#%d`", rank)
+		switch rank {
+		case 1:
+			medal = "🥇"
+		case 2:
+			medal = "🥈"
+		case 3:
+			medal = "🥉"
+		}
+		sb.WriteString(fmt.Sprintf("%s <@%s> — **%d** pts • %d quests\n",
+			medal, entry.UserID, entry.PointsEarned, entry.QuestsCompleted))
+	}
+	embed.SetDescription(sb.String())
+
+	return embed.Build()
+}
@@ -359,6 +359,9 @@ func createQuestEmbed(quests []*models.UserQuestProgress, questType string, user
 					progressBar,
 					quest.CurrentProgress,
 					quest.QuestDefinition.RequirementCount)
+				if steps := services.GetComboSteps(quest); len(steps) > 0 {
+					questLine += fmt.Sprintf("└ %s\n", services.FormatComboSteps(steps))
+				}
 			}
 
 			// Add rewards preview
@@ -440,17 +443,7 @@ func createQuestComponents(activeType string, userID string) []discord.Container
 }
 
 func createProgressBar(quest *models.UserQuestProgress) string {
-	percentage := quest.GetProgressPercentage()
-	filled := int(percentage / 10)
-	empty := 10 - filled
-
-	bar := ""
-	for i := 0; i < filled; i++ {
-		bar += "█"
-	}
-	for i := 0; i < empty; i++ {
-		bar += "░"
-	}
+	bar := formatProgressBar(quest.GetProgressPercentage(), 10)
 
 	// Add milestone indicators
 	if quest.Milestone75 {
@@ -464,6 +457,21 @@ func createProgressBar(quest *models.UserQuestProgress) string {
 	return bar
 }
 
+// formatProgressBar renders a percentage as a fixed-width bar, clamping out-of-range values
+func formatProgressBar(percentage float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if percentage < 0 {
+		percentage = 0
+	} else if percentage > 100 {
+		percentage = 100
+	}
+
+	filled := int(percentage * float64(width) / 100)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
 func getQuestTypeColor(questType string) int {
 	switch questType {
 	case "daily":
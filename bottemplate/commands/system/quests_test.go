@@ -0,0 +1,25 @@
+package system
+
+import "testing"
+
+func TestFormatProgressBar(t *testing.T) {
+	tests := []struct {
+		percentage float64
+		width      int
+		want       string
+	}{
+		{0, 10, "░░░░░░░░░░"},
+		{75, 10, "███████░░░"},
+		{79.9, 10, "███████░░░"},
+		{100, 10, "██████████"},
+		{150, 10, "██████████"},
+		{-20, 10, "░░░░░░░░░░"},
+		{50, 4, "██░░"},
+		{50, 0, ""},
+	}
+	for _, tt := range tests {
+		if got := formatProgressBar(tt.percentage, tt.width); got != tt.want {
+			t.Errorf("formatProgressBar(%v, %d) = %q, want %q", tt.percentage, tt.width, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,11 @@ import (
 	"log/slog"
 	"os"
 
+	"github.com/disgoorg/bot-template/bottemplate/cardleveling"
+	"github.com/disgoorg/bot-template/bottemplate/economy"
+	"github.com/disgoorg/bot-template/bottemplate/economy/forge"
+	"github.com/disgoorg/bot-template/bottemplate/services"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/snowflake/v2"
 	"github.com/pelletier/go-toml/v2"
 )
@@ -19,20 +24,56 @@ func LoadConfig(path string) (*Config, error) {
 	if err = toml.NewDecoder(file).Decode(&cfg); err != nil {
 		return nil, err
 	}
+
+	if len(cfg.Leveling.ExpCurve) > 0 {
+		if err = cardleveling.ValidateExpCurve(cfg.Leveling.ExpCurve); err != nil {
+			return nil, fmt.Errorf("invalid leveling config: %w", err)
+		}
+	}
+
+	cfg.Economy.Rewards = cfg.Economy.Rewards.WithDefaults()
+	if err = cfg.Economy.Rewards.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid economy rewards config: %w", err)
+	}
+	if err = cfg.Economy.Auction.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid auction fees config: %w", err)
+	}
+	if err = cfg.Economy.Bidding.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid bid increment config: %w", err)
+	}
+
+	cfg.Forge = cfg.Forge.WithDefaults()
+	if err = cfg.Forge.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid forge config: %w", err)
+	}
 	return &cfg, nil
 }
 
 type Config struct {
-	Log    LogConfig `toml:"log"`
-	Bot    BotConfig `toml:"bot"`
-	DB     DBConfig  `toml:"db"`
-	Web    WebConfig `toml:"web"`
-	Spaces struct {
+	Log        LogConfig        `toml:"log"`
+	Bot        BotConfig        `toml:"bot"`
+	DB         DBConfig         `toml:"db"`
+	Web        WebConfig        `toml:"web"`
+	Effects    EffectsConfig    `toml:"effects"`
+	Claim      ClaimConfig      `toml:"claim"`
+	Economy    EconomyConfig    `toml:"economy"`
+	Completion CompletionConfig `toml:"completion"`
+	Forge      forge.Config     `toml:"forge"` // recipes, costs and exclusions; unset fields keep the defaults
+	Leveling   LevelingConfig   `toml:"leveling"`
+	Promo      PromoConfig      `toml:"promo"`
+	Premium    PremiumConfig    `toml:"premium"`
+	Search     SearchConfig     `toml:"search"`
+	Vote       VoteConfig       `toml:"vote"`
+	Kofi       KofiConfig       `toml:"kofi"`
+	Spaces     struct {
 		Key      string `toml:"key"`
 		Secret   string `toml:"secret"`
 		Region   string `toml:"region"`
 		Bucket   string `toml:"bucket"`
 		CardRoot string `toml:"cardroot"` // Add this field
+		// Private makes the backend hand out signed image URLs instead of public ones
+		Private             bool `toml:"private"`
+		SignedURLTTLSeconds int  `toml:"signed_url_ttl_seconds"` // 0 uses the default TTL
 	} `toml:"spaces"`
 }
 
@@ -41,6 +82,60 @@ type BotConfig struct {
 	Token     string         `toml:"token"`
 }
 
+type PromoConfig struct {
+	ExpExpiryDays     int `toml:"exp_expiry_days"`     // days promo EXP lasts before it's cleared; 0 keeps it forever
+	DecaySweepMinutes int `toml:"decay_sweep_minutes"` // 0 uses the default interval
+}
+
+type SearchConfig struct {
+	Rarity                  utils.OwnershipRarity `toml:"rarity"`                    // -rare/-common cut-offs
+	OwnershipRefreshMinutes int                   `toml:"ownership_refresh_minutes"` // 0 uses the default interval
+}
+
+// VoteConfig sets up rewards for voting for the bot on a bot list
+type VoteConfig struct {
+	URL           string              `toml:"url"`            // vote page linked by /vote
+	WebhookSecret string              `toml:"webhook_secret"` // Authorization value the bot list sends; empty disables the webhook
+	Reward        services.VoteReward `toml:"reward"`
+}
+
+// KofiConfig sets up rewards for supporters paying through Ko-fi
+type KofiConfig struct {
+	VerificationToken string               `toml:"verification_token"` // from Ko-fi's webhook settings; empty disables the webhook
+	Rewards           services.KofiRewards `toml:"rewards"`
+}
+
+type PremiumConfig struct {
+	Perks              services.PremiumPerks `toml:"perks"`
+	ExpirySweepMinutes int                   `toml:"expiry_sweep_minutes"` // 0 uses the default interval
+}
+
+type EffectsConfig struct {
+	ExpirySweepMinutes int `toml:"expiry_sweep_minutes"` // 0 uses the default interval
+}
+
+type ClaimConfig struct {
+	CooldownSeconds       int `toml:"cooldown_seconds"`        // 0 uses the default cooldown
+	SessionTimeoutSeconds int `toml:"session_timeout_seconds"` // 0 uses the default session TTL
+	OfferSize             int `toml:"offer_size"`              // cards shown by /claim pick; 0 uses the default
+}
+
+type EconomyConfig struct {
+	Thresholds economy.HealthThresholds `toml:"thresholds"` // flags shown by /analyze-economy
+	Rewards    economy.RewardConfig     `toml:"rewards"`    // /daily and /work payouts; unset fields keep the defaults
+	Transfers  economy.TransferLimits   `toml:"transfer_limits"`
+	Auction    economy.AuctionFees      `toml:"auction_fees"`  // listing fee and sale commission
+	Bidding    economy.BidIncrement     `toml:"bid_increment"` // price-scaled minimum bid increment
+}
+
+type LevelingConfig struct {
+	ExpCurve []int64 `toml:"exp_curve"` // EXP to leave levels 1-4; empty uses the built-in curve
+}
+
+type CompletionConfig struct {
+	Rewards services.CompletionRewards `toml:"rewards"` // granted once per user and collection
+}
+
 type LogConfig struct {
 	Level     slog.Level `toml:"level"`
 	Format    string     `toml:"format"`
@@ -56,17 +151,37 @@ type DBConfig struct {
 	PoolSize int    `toml:"pool_size"`
 	SSLMode  string `toml:"sslmode"`
 	FastInit bool   `toml:"fast_init"`
+	// Statements slower than this log at Info, faster ones at Debug; 0 uses the default
+	SlowQueryThresholdMs int `toml:"slow_query_threshold_ms"`
 }
 
 type WebConfig struct {
-	Host         string          `toml:"host"`
-	Port         int             `toml:"port"`
-	OAuth        OAuthConfig     `toml:"oauth"`
-	SessionKey   string          `toml:"session_key"`
-	AdminUsers   []string        `toml:"admin_users"`    // Discord user IDs with admin access
-	AdminRoles   []string        `toml:"admin_roles"`    // Discord role IDs with admin access
-	AdminGuildID string          `toml:"admin_guild_id"` // Guild to check roles in
-	RateLimit    RateLimitConfig `toml:"rate_limit"`
+	Host            string              `toml:"host"`
+	Port            int                 `toml:"port"`
+	Debug           bool                `toml:"debug"` // Development mode: insecure cookies and local CORS origins
+	OAuth           OAuthConfig         `toml:"oauth"`
+	SessionKey      string              `toml:"session_key"`
+	AdminUsers      []string            `toml:"admin_users"`      // Discord user IDs with admin access
+	AdminRoles      []string            `toml:"admin_roles"`      // Discord role IDs with admin access
+	AdminGuildID    string              `toml:"admin_guild_id"`   // Guild to check roles in
+	RolePermissions map[string][]string `toml:"role_permissions"` // Role ID -> scoped web permissions
+	AllowedOrigins  []string            `toml:"allowed_origins"`  // Frontend origins allowed by CORS
+	RateLimit       RateLimitConfig     `toml:"rate_limit"`
+	MaxUploadMB     int                 `toml:"max_upload_mb"`  // Per-file upload limit in megabytes
+	MaxRequestMB    int                 `toml:"max_request_mb"` // Total request body limit in megabytes
+	Images          ImageConfig         `toml:"images"`
+	Metrics         MetricsConfig       `toml:"metrics"`
+}
+
+// MetricsConfig controls the backend's Prometheus endpoint
+type MetricsConfig struct {
+	Enabled bool   `toml:"enabled"`
+	Token   string `toml:"token"` // Bearer token scrapers must send; required when enabled
+}
+
+type ImageConfig struct {
+	WebPQuality int    `toml:"webp_quality"` // 0-100, used when transcoding uploads to WebP
+	CWebPPath   string `toml:"cwebp_path"`   // Path to libwebp's cwebp binary
 }
 
 type OAuthConfig struct {
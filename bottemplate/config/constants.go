@@ -102,6 +102,9 @@ const (
 	WorkMinCooldown = 10 * time.Second
 	DailyCooldown   = 24 * time.Hour
 
+	// Summon system (summoning is free, the cooldown only limits spam)
+	SummonCooldown = 5 * time.Second
+
 	// Claim system
 	ClaimCooldown       = 1 * time.Hour
 	GuaranteedClaim     = 10
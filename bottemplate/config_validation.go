@@ -0,0 +1,155 @@
+package bottemplate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/disgoorg/bot-template/bottemplate/cardleveling"
+)
+
+// configErrors collects every problem found in a config so they can be reported together
+type configErrors []error
+
+func (e *configErrors) add(format string, args ...any) {
+	*e = append(*e, fmt.Errorf(format, args...))
+}
+
+func (e *configErrors) required(value, key string) {
+	if strings.TrimSpace(value) == "" {
+		e.add("%s is required", key)
+	}
+}
+
+func (e *configErrors) port(port int, key string) {
+	if port < 1 || port > 65535 {
+		e.add("%s must be between 1 and 65535, got %d", key, port)
+	}
+}
+
+func (e *configErrors) nonNegative(value int, key string) {
+	if value < 0 {
+		e.add("%s must not be negative, got %d", key, value)
+	}
+}
+
+func (e configErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid config:\n%w", errors.Join(e...))
+}
+
+// Validate checks the settings shared by the bot and the backend: database
+// credentials, Spaces keys and numeric ranges. Every problem is returned at once.
+func (c *Config) Validate() error {
+	var errs configErrors
+	c.validateShared(&errs)
+	return errs.err()
+}
+
+// ValidateBot is Validate plus the settings only the Discord bot needs
+func (c *Config) ValidateBot() error {
+	var errs configErrors
+	c.validateShared(&errs)
+	errs.required(c.Bot.Token, "bot.token")
+	return errs.err()
+}
+
+// ValidateWeb is Validate plus the settings only the web backend needs
+func (c *Config) ValidateWeb() error {
+	var errs configErrors
+	c.validateShared(&errs)
+
+	errs.port(c.Web.Port, "web.port")
+	errs.required(c.Web.SessionKey, "web.session_key")
+	errs.required(c.Web.OAuth.ClientID, "web.oauth.client_id")
+	errs.required(c.Web.OAuth.ClientSecret, "web.oauth.client_secret")
+	errs.required(c.Web.OAuth.RedirectURL, "web.oauth.redirect_url")
+	errs.nonNegative(c.Web.MaxUploadMB, "web.max_upload_mb")
+	errs.nonNegative(c.Web.MaxRequestMB, "web.max_request_mb")
+	if c.Web.RateLimit.Enabled {
+		if c.Web.RateLimit.Requests < 1 {
+			errs.add("web.rate_limit.requests must be at least 1 when rate limiting is enabled, got %d", c.Web.RateLimit.Requests)
+		}
+		if c.Web.RateLimit.Window < 1 {
+			errs.add("web.rate_limit.window must be at least 1 when rate limiting is enabled, got %d", c.Web.RateLimit.Window)
+		}
+	}
+	if q := c.Web.Images.WebPQuality; q < 0 || q > 100 {
+		errs.add("web.images.webp_quality must be between 0 and 100, got %d", q)
+	}
+	// /metrics is served on the public API port, so it must never be left open
+	if c.Web.Metrics.Enabled && strings.TrimSpace(c.Web.Metrics.Token) == "" {
+		errs.add("web.metrics.token is required when metrics are enabled")
+	}
+	return errs.err()
+}
+
+func (c *Config) validateShared(errs *configErrors) {
+	errs.required(c.DB.Host, "db.host")
+	errs.port(c.DB.Port, "db.port")
+	errs.required(c.DB.User, "db.user")
+	errs.required(c.DB.Database, "db.database")
+	if c.DB.PoolSize < 0 || c.DB.PoolSize > 1000 {
+		errs.add("db.pool_size must be between 0 and 1000, got %d", c.DB.PoolSize)
+	}
+	errs.nonNegative(c.DB.SlowQueryThresholdMs, "db.slow_query_threshold_ms")
+
+	errs.required(c.Spaces.Key, "spaces.key")
+	errs.required(c.Spaces.Secret, "spaces.secret")
+	errs.required(c.Spaces.Region, "spaces.region")
+	errs.required(c.Spaces.Bucket, "spaces.bucket")
+	errs.nonNegative(c.Spaces.SignedURLTTLSeconds, "spaces.signed_url_ttl_seconds")
+
+	errs.nonNegative(c.Effects.ExpirySweepMinutes, "effects.expiry_sweep_minutes")
+	errs.nonNegative(c.Claim.CooldownSeconds, "claim.cooldown_seconds")
+	errs.nonNegative(c.Claim.SessionTimeoutSeconds, "claim.session_timeout_seconds")
+	errs.nonNegative(c.Claim.OfferSize, "claim.offer_size")
+	errs.nonNegative(c.Promo.ExpExpiryDays, "promo.exp_expiry_days")
+	errs.nonNegative(c.Promo.DecaySweepMinutes, "promo.decay_sweep_minutes")
+	errs.nonNegative(c.Premium.ExpirySweepMinutes, "premium.expiry_sweep_minutes")
+	errs.nonNegative(c.Search.OwnershipRefreshMinutes, "search.ownership_refresh_minutes")
+	if p := c.Search.Rarity.RarePercentile; p < 0 || p > 100 {
+		errs.add("search.rarity.rare_percentile must be between 0 and 100, got %v", p)
+	}
+	if p := c.Search.Rarity.CommonPercentile; p < 0 || p > 100 {
+		errs.add("search.rarity.common_percentile must be between 0 and 100, got %v", p)
+	}
+	if c.Premium.Perks.DailyMultiplier < 0 {
+		errs.add("premium.perks.daily_multiplier must not be negative, got %v", c.Premium.Perks.DailyMultiplier)
+	}
+	if c.Premium.Perks.WorkMultiplier < 0 {
+		errs.add("premium.perks.work_multiplier must not be negative, got %v", c.Premium.Perks.WorkMultiplier)
+	}
+	if c.Premium.Perks.ClaimCooldownMultiplier < 0 {
+		errs.add("premium.perks.claim_cooldown_multiplier must not be negative, got %v", c.Premium.Perks.ClaimCooldownMultiplier)
+	}
+
+	if len(c.Leveling.ExpCurve) > 0 {
+		if err := cardleveling.ValidateExpCurve(c.Leveling.ExpCurve); err != nil {
+			errs.add("leveling.exp_curve: %w", err)
+		}
+	}
+	if err := c.Economy.Rewards.Validate(); err != nil {
+		errs.add("economy.rewards: %w", err)
+	}
+	if err := c.Economy.Transfers.Validate(); err != nil {
+		errs.add("economy.transfer_limits: %w", err)
+	}
+	if err := c.Economy.Auction.Validate(); err != nil {
+		errs.add("economy.auction_fees: %w", err)
+	}
+	if err := c.Economy.Bidding.Validate(); err != nil {
+		errs.add("economy.bid_increment: %w", err)
+	}
+	if err := c.Vote.Reward.Validate(); err != nil {
+		errs.add("vote.reward: %w", err)
+	}
+	if err := c.Kofi.Rewards.Validate(); err != nil {
+		errs.add("kofi.rewards: %w", err)
+	}
+	if err := c.Forge.Validate(); err != nil {
+		errs.add("forge: %w", err)
+	}
+}
@@ -0,0 +1,102 @@
+package bottemplate
+
+import (
+	"strings"
+	"testing"
+)
+
+// exampleConfig loads config.example.toml, which must always pass validation
+func exampleConfig(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := LoadConfig("../config.example.toml")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	return cfg
+}
+
+func TestExampleConfigIsValid(t *testing.T) {
+	cfg := exampleConfig(t)
+	if err := cfg.ValidateBot(); err != nil {
+		t.Errorf("ValidateBot: %v", err)
+	}
+	if err := cfg.ValidateWeb(); err != nil {
+		t.Errorf("ValidateWeb: %v", err)
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   string
+	}{
+		{"missing db host", func(c *Config) { c.DB.Host = " " }, "db.host is required"},
+		{"db port out of range", func(c *Config) { c.DB.Port = 70000 }, "db.port must be between 1 and 65535, got 70000"},
+		{"pool too large", func(c *Config) { c.DB.PoolSize = 5000 }, "db.pool_size"},
+		{"missing spaces secret", func(c *Config) { c.Spaces.Secret = "" }, "spaces.secret is required"},
+		{"negative claim cooldown", func(c *Config) { c.Claim.CooldownSeconds = -1 }, "claim.cooldown_seconds must not be negative"},
+		{"percentile over 100", func(c *Config) { c.Search.Rarity.RarePercentile = 101 }, "search.rarity.rare_percentile"},
+		{"short exp curve", func(c *Config) { c.Leveling.ExpCurve = []int64{100} }, "leveling.exp_curve"},
+		{"negative forge cost", func(c *Config) { c.Forge.MinCost = -1 }, "forge: min_cost"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := exampleConfig(t)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWebOnlySettings(t *testing.T) {
+	cfg := exampleConfig(t)
+	cfg.Web.Port = 0
+	cfg.Web.OAuth.ClientSecret = ""
+	cfg.Bot.Token = ""
+
+	// The bot doesn't need the web settings, and the backend doesn't need the token
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	err := cfg.ValidateWeb()
+	if err == nil || !strings.Contains(err.Error(), "web.port") || !strings.Contains(err.Error(), "web.oauth.client_secret") {
+		t.Errorf("ValidateWeb = %v, want the port and OAuth secret", err)
+	}
+	if err == nil || strings.Contains(err.Error(), "bot.token") {
+		t.Errorf("ValidateWeb = %v, want no bot token error", err)
+	}
+	if err := cfg.ValidateBot(); err == nil || !strings.Contains(err.Error(), "bot.token is required") {
+		t.Errorf("ValidateBot = %v, want the missing token", err)
+	}
+}
+
+func TestValidateWebRequiresMetricsToken(t *testing.T) {
+	cfg := exampleConfig(t)
+	cfg.Web.Metrics.Enabled = true
+	cfg.Web.Metrics.Token = ""
+	if err := cfg.ValidateWeb(); err == nil || !strings.Contains(err.Error(), "web.metrics.token is required") {
+		t.Errorf("ValidateWeb = %v, want the missing metrics token", err)
+	}
+
+	cfg.Web.Metrics.Token = "scrape-secret"
+	if err := cfg.ValidateWeb(); err != nil {
+		t.Errorf("ValidateWeb with a metrics token: %v", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	var cfg Config
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("empty config is valid")
+	}
+	for _, key := range []string{"db.host", "db.port", "db.user", "db.database", "spaces.key", "spaces.secret", "spaces.region", "spaces.bucket"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error doesn't mention %s:\n%v", key, err)
+		}
+	}
+}
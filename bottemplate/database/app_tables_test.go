@@ -0,0 +1,28 @@
+package database
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+func TestAppTablesCoverSchemaModels(t *testing.T) {
+	// Resolving table names needs no connection
+	bunDB := bun.NewDB(sql.OpenDB(pgdriver.NewConnector()), pgdialect.New())
+	defer bunDB.Close()
+
+	reset := make(map[string]bool, len(appTables))
+	for _, name := range appTables {
+		reset[name] = true
+	}
+	for _, model := range schemaModels {
+		name := bunDB.Table(reflect.TypeOf(model).Elem()).Name
+		if !reset[name] {
+			t.Errorf("table %s is created but never reset", name)
+		}
+	}
+}
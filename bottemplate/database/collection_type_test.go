@@ -0,0 +1,60 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestCollectionTypeBackfillAndCreate(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+
+	// Rows written before collection_type existed are stored with an empty type
+	legacy := []*models.Collection{
+		{ID: "twice", Name: "twice", Tags: []string{"girlgroups"}, UpdatedAt: time.Now()},
+		{ID: "bts", Name: "bts", Tags: []string{"boygroups"}, UpdatedAt: time.Now()},
+		{ID: "promo", Name: "promo", UpdatedAt: time.Now()},
+	}
+	if _, err := db.BunDB().NewInsert().Model(&legacy).Exec(ctx); err != nil {
+		t.Fatalf("insert legacy collections: %v", err)
+	}
+	if err := db.MigrateSchema(ctx); err != nil {
+		t.Fatalf("MigrateSchema: %v", err)
+	}
+
+	collections := repositories.NewCollectionRepository(db.BunDB())
+	want := map[string]string{
+		"twice": models.CollectionTypeGirlGroup,
+		"bts":   models.CollectionTypeBoyGroup,
+		"promo": models.CollectionTypeOther,
+	}
+	for id, typ := range want {
+		collection, err := collections.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetByID(%s): %v", id, err)
+		}
+		if collection.Type != typ {
+			t.Errorf("%s backfilled as %q, want %q", id, collection.Type, typ)
+		}
+	}
+
+	// Create infers a missing type but keeps an explicit one
+	inferred := &models.Collection{ID: "ive", Name: "ive", Tags: []string{"girlgroups"}}
+	explicit := &models.Collection{ID: "misc", Name: "misc", Tags: []string{"girlgroups"}, Type: models.CollectionTypeOther}
+	for _, c := range []*models.Collection{inferred, explicit} {
+		if err := collections.Create(ctx, c); err != nil {
+			t.Fatalf("Create(%s): %v", c.ID, err)
+		}
+	}
+	if got, _ := collections.GetByID(ctx, "ive"); got == nil || got.Type != models.CollectionTypeGirlGroup {
+		t.Errorf("created ive = %+v, want an inferred girl_group", got)
+	}
+	if got, _ := collections.GetByID(ctx, "misc"); got == nil || got.Type != models.CollectionTypeOther {
+		t.Errorf("created misc = %+v, want the explicit other", got)
+	}
+}
@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"reflect"
+	"strings"
 	"time"
 
 	"log/slog"
 
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
 	"github.com/uptrace/bun"
 
 	"github.com/jackc/pgx/v5"
@@ -25,7 +28,12 @@ const (
 	defaultConnTimeout   = 5 * time.Second
 	defaultMaxRetries    = 3
 	defaultRetryInterval = time.Second
-	schemaVersion        = 1 // bump when schema/migrations change
+	schemaVersion        = 19 // bump when schema/migrations change
+
+	userCardsUniqueConstraint = "user_cards_user_card_unique"
+
+	// defaultSlowQueryThreshold applies when db.slow_query_threshold_ms isn't set
+	defaultSlowQueryThreshold = 200 * time.Millisecond
 )
 
 type DBConfig struct {
@@ -37,11 +45,16 @@ type DBConfig struct {
 	PoolSize     int    `toml:"pool_size"`
 	MaxIdleConns int    `toml:"max_idle_conns"`
 	MaxLifetime  int    `toml:"max_lifetime"`
+	// SlowQueryThresholdMs is how long a statement may take before it's logged at Info
+	SlowQueryThresholdMs int `toml:"slow_query_threshold_ms"`
 }
 
 type DB struct {
 	pool  *pgxpool.Pool
 	bunDB *bun.DB
+
+	// slowQueryThreshold splits query logs: slower statements log at Info, the rest at Debug
+	slowQueryThreshold time.Duration
 }
 
 func New(ctx context.Context, cfg DBConfig) (*DB, error) {
@@ -96,7 +109,14 @@ func New(ctx context.Context, cfg DBConfig) (*DB, error) {
 		poolConfig.MaxConnLifetime = time.Duration(cfg.MaxLifetime) * time.Second
 	}
 
-	return createDB(ctx, poolConfig)
+	db, err := createDB(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.SlowQueryThresholdMs > 0 {
+		db.slowQueryThreshold = time.Duration(cfg.SlowQueryThresholdMs) * time.Millisecond
+	}
+	return db, nil
 }
 
 // Helper function to build connection string
@@ -115,7 +135,9 @@ func createDB(ctx context.Context, poolConfig *pgxpool.Config) (*DB, error) {
 	}
 
 	bunDB := newBunDB(pool)
-	return &DB{pool: pool, bunDB: bunDB}, nil
+	db := &DB{pool: pool, bunDB: bunDB, slowQueryThreshold: defaultSlowQueryThreshold}
+	bunDB.AddQueryHook(&queryLogHook{db: db})
+	return db, nil
 }
 
 func (db *DB) GetPool() *pgxpool.Pool {
@@ -147,59 +169,62 @@ func newBunDB(pool *pgxpool.Pool) *bun.DB {
 	return bun.NewDB(sqldb, pgdialect.New())
 }
 
+// appTables are the tables ResetAppTables truncates, children before parents
+var appTables = []string{
+	"auction_watchers",
+	"auction_bids",
+	"auctions",
+	"trades",
+	"user_quest_progress",
+	"quest_leaderboards",
+	"quest_definitions",
+	"quest_chains",
+	"user_effects",
+	"collection_exp_boosts",
+	"shop_stock",
+	"effect_items",
+	"user_inventory",
+	"user_recipes",
+	"user_items",
+	"items",
+	"card_market_history",
+	"collection_resets",
+	"collection_progress",
+	"completion_reward_grants",
+	"transfer_counters",
+	"currency_ledger",
+	"kofi_donations",
+	"admin_audit",
+	"guild_settings",
+	"background_processes",
+	"card_supply",
+	"claims",
+	"claim_stats",
+	"economy_stats",
+	"user_cards",
+	"user_quests",
+	"user_slots",
+	"user_stats",
+	"wishlists",
+	"users",
+	"cards",
+	"collections",
+}
+
 // ResetAppTables truncates application tables for a fresh start (PostgreSQL only)
 func (db *DB) ResetAppTables(ctx context.Context) error {
 	if db.bunDB == nil {
 		return fmt.Errorf("bun DB not initialized")
 	}
 
-	// Candidate tables managed by this application
-	candidates := []string{
-		"auction_bids",
-		"auctions",
-		"trades",
-		"user_quest_progress",
-		"quest_leaderboards",
-		"quest_definitions",
-		"quest_chains",
-		"user_effects",
-		"effect_items",
-		"user_inventory",
-		"user_items",
-		"items",
-		"card_market_history",
-		"collection_resets",
-		"collection_progress",
-		"claims",
-		"claim_stats",
-		"economy_stats",
-		"user_cards",
-		"user_quests",
-		"user_slots",
-		"user_stats",
-		"wishlists",
-		"users",
-		"cards",
-		"collections",
-	}
-
 	// Verify present tables to avoid failures on missing ones
-	rows, err := db.pool.Query(ctx, `SELECT table_name FROM information_schema.tables WHERE table_schema = 'public'`)
+	present, err := db.existingTables(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list tables: %w", err)
 	}
-	defer rows.Close()
-
-	present := map[string]bool{}
-	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err == nil {
-			present[name] = true
-		}
-	}
 
 	var toTruncate []string
-	for _, t := range candidates {
+	for _, t := range appTables {
 		if present[t] {
 			toTruncate = append(toTruncate, t)
 		}
@@ -210,13 +235,61 @@ func (db *DB) ResetAppTables(ctx context.Context) error {
 		return nil
 	}
 
+	if err := db.truncateTables(ctx, toTruncate); err != nil {
+		return err
+	}
+	slog.Info("App tables truncated successfully", "tables", toTruncate)
+	return nil
+}
+
+// ResetTables truncates only the named tables, e.g. user_cards before retrying that step of a
+// migration. Every name must exist in the current schema; nothing is truncated otherwise.
+// CASCADE still empties tables with foreign keys into the named ones.
+func (db *DB) ResetTables(ctx context.Context, names ...string) error {
+	if db.bunDB == nil {
+		return fmt.Errorf("bun DB not initialized")
+	}
+
+	present, err := db.existingTables(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	seen := make(map[string]bool, len(names))
+	var toTruncate, unknown []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		if !present[name] {
+			unknown = append(unknown, name)
+			continue
+		}
+		toTruncate = append(toTruncate, name)
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown tables: %s", strings.Join(unknown, ", "))
+	}
+	if len(toTruncate) == 0 {
+		return fmt.Errorf("no tables given to reset")
+	}
+
+	if err := db.truncateTables(ctx, toTruncate); err != nil {
+		return err
+	}
+	slog.Info("Tables truncated successfully", "tables", toTruncate)
+	return nil
+}
+
+func (db *DB) truncateTables(ctx context.Context, tables []string) error {
 	// Build TRUNCATE statement safely
-	stmt := "TRUNCATE TABLE " + joinIdentifiers(toTruncate) + " RESTART IDENTITY CASCADE;"
+	stmt := "TRUNCATE TABLE " + joinIdentifiers(tables) + " RESTART IDENTITY CASCADE;"
 	if _, err := db.ExecWithLog(ctx, stmt); err != nil {
 		return fmt.Errorf("failed to truncate tables: %w", err)
 	}
-
-	slog.Info("App tables truncated successfully", "tables", toTruncate)
 	return nil
 }
 
@@ -241,25 +314,11 @@ func (db *DB) ExecWithLog(ctx context.Context, sql string, args ...interface{})
 	duration := time.Since(start)
 
 	if err != nil {
-		slog.Error("Query failed",
-			slog.String("type", "db"),
-			slog.String("operation", "exec"),
-			slog.String("query", sql),
-			slog.Any("args", args),
-			slog.Duration("took", duration),
-			slog.Any("error", err),
-		)
+		db.logQueryError(ctx, "exec", sql, args, duration, err)
 		return result, err
 	}
 
-	slog.Info("Query executed",
-		slog.String("type", "db"),
-		slog.String("operation", "exec"),
-		slog.String("query", sql),
-		slog.Any("args", args),
-		slog.Duration("took", duration),
-		slog.Int64("affected_rows", result.RowsAffected()),
-	)
+	db.logQuery(ctx, "exec", sql, args, duration, slog.Int64("affected_rows", result.RowsAffected()))
 	return result, nil
 }
 
@@ -269,24 +328,11 @@ func (db *DB) QueryWithLog(ctx context.Context, sql string, args ...interface{})
 	duration := time.Since(start)
 
 	if err != nil {
-		slog.Error("Query failed",
-			slog.String("type", "db"),
-			slog.String("operation", "query"),
-			slog.String("query", sql),
-			slog.Any("args", args),
-			slog.Duration("took", duration),
-			slog.Any("error", err),
-		)
+		db.logQueryError(ctx, "query", sql, args, duration, err)
 		return rows, err
 	}
 
-	slog.Info("Query executed",
-		slog.String("type", "db"),
-		slog.String("operation", "query"),
-		slog.String("query", sql),
-		slog.Any("args", args),
-		slog.Duration("took", duration),
-	)
+	db.logQuery(ctx, "query", sql, args, duration)
 	return rows, nil
 }
 
@@ -301,54 +347,80 @@ func (db *DB) Close() {
 
 // InitializeSchema creates all required database tables and indexes
 func (db *DB) InitializeSchema(ctx context.Context) error {
-	// Fast init path for development: skip when schema version matches
-	fastInit := os.Getenv("DB_FAST_INIT") == "1"
-	if fastInit {
-		if err := db.ensureAppMeta(ctx); err == nil {
-			if v, _ := db.getAppMeta(ctx, "schema_version"); v == fmt.Sprintf("%d", schemaVersion) {
-				slog.Info("Fast DB init: schema up-to-date, skipping initialization",
-					slog.String("mode", "DB_FAST_INIT"),
-					slog.Int("schema_version", schemaVersion))
-				return nil
-			}
-		}
+	_, err := db.Initialize(ctx, InitAll)
+	return err
+}
+
+// schemaUpToDate reports whether DB_FAST_INIT is set and the stored schema version
+// matches, in which case a full initialization can be skipped
+func (db *DB) schemaUpToDate(ctx context.Context) bool {
+	if os.Getenv("DB_FAST_INIT") != "1" {
+		return false
 	}
+	if err := db.ensureAppMeta(ctx); err != nil {
+		return false
+	}
+	v, _ := db.getAppMeta(ctx, "schema_version")
+	return v == fmt.Sprintf("%d", schemaVersion)
+}
+
+// schemaModels are the models initializeSchema creates tables for, in the correct order
+// to handle foreign key constraints. Every table also belongs in appTables.
+var schemaModels = []interface{}{
+	(*models.Collection)(nil),
+	(*models.Card)(nil),
+	(*models.User)(nil),
+	(*models.UserCard)(nil),
+	(*models.UserQuest)(nil),
+	(*models.UserSlot)(nil),
+	(*models.UserStats)(nil),
+	(*models.UserEffect)(nil),
+	(*models.CollectionExpBoost)(nil),
+	(*models.ShopStock)(nil),
+	(*models.Claim)(nil),
+	(*models.ClaimStats)(nil),
+	(*models.EconomyStats)(nil),
+	(*models.Wishlist)(nil),
+	(*models.UserInventory)(nil),
+	(*models.UserRecipe)(nil),
+	(*models.Auction)(nil),
+	(*models.AuctionBid)(nil),
+	(*models.Trade)(nil),
+	(*models.CardMarketHistory)(nil),
+	(*models.Item)(nil),
+	(*models.UserItem)(nil),
+	// Quest system tables
+	(*models.QuestChain)(nil),
+	(*models.QuestDefinition)(nil),
+	(*models.UserQuestProgress)(nil),
+	(*models.QuestLeaderboard)(nil),
+	(*models.GuildSettings)(nil),
+	(*models.AdminAudit)(nil),
+	(*models.CollectionProgress)(nil),
+	(*models.CompletionRewardGrant)(nil),
+	(*models.CardSupply)(nil),
+	(*models.BackgroundProcess)(nil),
+	(*models.CurrencyLedgerEntry)(nil),
+	(*models.TransferCounter)(nil),
+	(*models.AuctionWatcher)(nil),
+	(*models.KofiDonation)(nil),
+}
+
+// initializeSchema creates missing tables, applies migrations and creates indexes,
+// recording which tables were newly created in summary
+func (db *DB) initializeSchema(ctx context.Context, summary *InitSummary) error {
 	// First, ensure the database is using UTF-8 encoding
 	if err := db.ensureUTF8Encoding(ctx); err != nil {
 		return fmt.Errorf("failed to ensure UTF-8 encoding: %w", err)
 	}
 
-	// Create tables in the correct order to handle foreign key constraints
-	tables := []interface{}{
-		(*models.Collection)(nil),
-		(*models.Card)(nil),
-		(*models.User)(nil),
-		(*models.UserCard)(nil),
-		(*models.UserQuest)(nil),
-		(*models.UserSlot)(nil),
-		(*models.UserStats)(nil),
-		(*models.UserEffect)(nil),
-		(*models.Claim)(nil),
-		(*models.ClaimStats)(nil),
-		(*models.EconomyStats)(nil),
-		(*models.Wishlist)(nil),
-		(*models.UserInventory)(nil),
-		(*models.UserRecipe)(nil),
-		(*models.Auction)(nil),
-		(*models.AuctionBid)(nil),
-		(*models.Trade)(nil),
-		(*models.CardMarketHistory)(nil),
-		(*models.Item)(nil),
-		(*models.UserItem)(nil),
-		// Quest system tables
-		(*models.QuestChain)(nil),
-		(*models.QuestDefinition)(nil),
-		(*models.UserQuestProgress)(nil),
-		(*models.QuestLeaderboard)(nil),
+	existing, err := db.existingTables(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list existing tables: %w", err)
 	}
 
 	// Create tables using Bun
-	for _, model := range tables {
+	for _, model := range schemaModels {
 		query := db.bunDB.NewCreateTable().
 			Model(model).
 			IfNotExists()
@@ -357,6 +429,13 @@ func (db *DB) InitializeSchema(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to create table: %w", err)
 		}
+
+		name := db.bunDB.Table(reflect.TypeOf(model).Elem()).Name
+		if existing[name] {
+			summary.TablesExisting++
+		} else {
+			summary.TablesCreated = append(summary.TablesCreated, name)
+		}
 	}
 
 	// Apply schema migrations for existing tables FIRST
@@ -383,6 +462,8 @@ func (db *DB) InitializeSchema(ctx context.Context) error {
 		"CREATE INDEX IF NOT EXISTS idx_user_cards_compound_search ON user_cards(user_id, card_id, amount) WHERE amount > 0;",
 		"CREATE INDEX IF NOT EXISTS idx_auctions_status_end_time ON auctions(status, end_time);",
 		"CREATE INDEX IF NOT EXISTS idx_auctions_active ON auctions(end_time) WHERE status = 'active';",
+		"CREATE INDEX IF NOT EXISTS idx_auctions_sold ON auctions(card_id, sold_at DESC) WHERE sold_at IS NOT NULL;",
+		"CREATE INDEX IF NOT EXISTS idx_auction_watchers_pending ON auction_watchers(auction_id) WHERE ending_notified = false;",
 		"CREATE INDEX IF NOT EXISTS idx_claims_user_claimed ON claims(user_id, claimed_at);",
 		// Trade system indexes
 		"CREATE INDEX IF NOT EXISTS idx_trades_offerer_id ON trades(offerer_id);",
@@ -398,6 +479,16 @@ func (db *DB) InitializeSchema(ctx context.Context) error {
 		// Item system indexes
 		"CREATE INDEX IF NOT EXISTS idx_user_items_user_id ON user_items(user_id);",
 		"CREATE INDEX IF NOT EXISTS idx_items_type ON items(type);",
+		// Admin audit indexes
+		"CREATE INDEX IF NOT EXISTS idx_admin_audit_actor_created ON admin_audit(actor_id, created_at);",
+		"CREATE INDEX IF NOT EXISTS idx_admin_audit_created ON admin_audit(created_at);",
+		// Currency ledger indexes
+		"CREATE INDEX IF NOT EXISTS idx_currency_ledger_user_created ON currency_ledger(user_id, created_at DESC);",
+		// Collection progress cache indexes
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_collection_progress_user_collection ON collection_progress(user_id, collection_id);",
+		"CREATE INDEX IF NOT EXISTS idx_collection_progress_leaderboard ON collection_progress(collection_id, percentage DESC);",
+		// Completion reward indexes
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_completion_reward_grants_user_collection ON completion_reward_grants(user_id, collection_id);",
 		// Quest system indexes
 		"CREATE INDEX IF NOT EXISTS idx_quest_definitions_type_tier ON quest_definitions(type, tier);",
 		"CREATE INDEX IF NOT EXISTS idx_quest_definitions_quest_id ON quest_definitions(quest_id);",
@@ -415,21 +506,6 @@ func (db *DB) InitializeSchema(ctx context.Context) error {
 		}
 	}
 
-	// Initialize item data
-	if err := db.InitializeItemData(ctx); err != nil {
-		return fmt.Errorf("failed to initialize item data: %w", err)
-	}
-
-	// Initialize quest data
-	if err := db.InitializeQuestData(ctx); err != nil {
-		return fmt.Errorf("failed to initialize quest data: %w", err)
-	}
-
-	// Update schema version marker (safe upsert)
-	if err := db.ensureAppMeta(ctx); err == nil {
-		_ = db.setAppMeta(ctx, "schema_version", fmt.Sprintf("%d", schemaVersion))
-	}
-
 	return nil
 }
 
@@ -455,6 +531,32 @@ func (db *DB) setAppMeta(ctx context.Context, key, value string) error {
 	return err
 }
 
+// ensureUserCardsUnique merges existing duplicate user_cards rows and then adds the
+// (user_id, card_id) unique constraint. It is a no-op once the constraint exists.
+func (db *DB) ensureUserCardsUnique(ctx context.Context) error {
+	var exists bool
+	err := db.bunDB.NewRaw(`SELECT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = ?)`, userCardsUniqueConstraint).
+		Scan(ctx, &exists)
+	if err != nil {
+		return fmt.Errorf("failed to check constraint: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	merges, err := repositories.NewUserCardRepository(db.bunDB).MergeDuplicates(ctx)
+	if err != nil {
+		return err
+	}
+	if len(merges) > 0 {
+		slog.Info("Merged duplicate user cards before adding unique constraint",
+			slog.Int("users", len(merges)))
+	}
+
+	_, err = db.ExecWithLog(ctx, fmt.Sprintf(`ALTER TABLE user_cards ADD CONSTRAINT %s UNIQUE (user_id, card_id);`, userCardsUniqueConstraint))
+	return err
+}
+
 // MigrateSchema applies necessary schema changes to existing tables
 func (db *DB) MigrateSchema(ctx context.Context) error {
 	// Add fragments column to collections table if it doesn't exist
@@ -467,6 +569,85 @@ func (db *DB) MigrateSchema(ctx context.Context) error {
 		return fmt.Errorf("failed to add fragments column: %w", err)
 	}
 
+	// Persist the collection type instead of inferring it from tags on every request
+	if _, err := db.ExecWithLog(ctx, `ALTER TABLE collections ADD COLUMN IF NOT EXISTS collection_type TEXT NOT NULL DEFAULT '';`); err != nil {
+		return fmt.Errorf("failed to add collection_type column: %w", err)
+	}
+	if err := db.backfillCollectionTypes(ctx); err != nil {
+		return fmt.Errorf("failed to backfill collection types: %w", err)
+	}
+
+	// Soft-deleted cards keep their row and references until they are hard-deleted
+	if _, err := db.ExecWithLog(ctx, `ALTER TABLE cards ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;`); err != nil {
+		return fmt.Errorf("failed to add deleted_at column: %w", err)
+	}
+
+	// Track the stored image format of each card (empty means legacy jpg)
+	if _, err := db.ExecWithLog(ctx, `ALTER TABLE cards ADD COLUMN IF NOT EXISTS image_format TEXT NOT NULL DEFAULT '';`); err != nil {
+		return fmt.Errorf("failed to add image_format column: %w", err)
+	}
+
+	// Optional global supply caps for limited cards
+	supplyColumnsSQL := []string{
+		`ALTER TABLE cards ADD COLUMN IF NOT EXISTS max_copies BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE collections ADD COLUMN IF NOT EXISTS max_copies BIGINT NOT NULL DEFAULT 0;`,
+	}
+	for _, sql := range supplyColumnsSQL {
+		if _, err := db.ExecWithLog(ctx, sql); err != nil {
+			return fmt.Errorf("failed to add max_copies column: %w", err)
+		}
+	}
+
+	// Items are tradeable unless explicitly marked otherwise
+	if _, err := db.ExecWithLog(ctx, `ALTER TABLE items ADD COLUMN IF NOT EXISTS tradeable BOOLEAN NOT NULL DEFAULT true;`); err != nil {
+		return fmt.Errorf("failed to add tradeable column: %w", err)
+	}
+
+	// Promo EXP expiry is scheduled per user by the promo-exp decay process
+	if _, err := db.ExecWithLog(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS promo_exp_expires_at TIMESTAMPTZ;`); err != nil {
+		return fmt.Errorf("failed to add promo_exp_expires_at column: %w", err)
+	}
+
+	// Summon cooldown is tracked alongside the other last_* timestamps
+	if _, err := db.ExecWithLog(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS last_summon TIMESTAMPTZ;`); err != nil {
+		return fmt.Errorf("failed to add last_summon column: %w", err)
+	}
+
+	// Inequality of card ownership recorded alongside currency inequality
+	if _, err := db.ExecWithLog(ctx, `ALTER TABLE economy_stats ADD COLUMN IF NOT EXISTS card_ownership_gini DOUBLE PRECISION NOT NULL DEFAULT 0;`); err != nil {
+		return fmt.Errorf("failed to add card_ownership_gini column: %w", err)
+	}
+
+	// Sold auctions keep their final price so closed sales can inform pricing
+	auctionSaleSQL := []string{
+		`ALTER TABLE auctions ADD COLUMN IF NOT EXISTS final_price BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE auctions ADD COLUMN IF NOT EXISTS sold_at TIMESTAMPTZ;`,
+		`UPDATE auctions SET final_price = current_price, sold_at = end_time
+			WHERE status = 'completed' AND COALESCE(top_bidder_id, '') <> '' AND sold_at IS NULL;`,
+	}
+	for _, sql := range auctionSaleSQL {
+		if _, err := db.ExecWithLog(ctx, sql); err != nil {
+			return fmt.Errorf("failed to add auction sale columns: %w", err)
+		}
+	}
+
+	// Auction fees are recorded per auction; the commission rate is fixed at listing
+	auctionFeeSQL := []string{
+		`ALTER TABLE auctions ADD COLUMN IF NOT EXISTS listing_fee BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE auctions ADD COLUMN IF NOT EXISTS commission_bps BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE auctions ADD COLUMN IF NOT EXISTS commission BIGINT NOT NULL DEFAULT 0;`,
+	}
+	for _, sql := range auctionFeeSQL {
+		if _, err := db.ExecWithLog(ctx, sql); err != nil {
+			return fmt.Errorf("failed to add auction fee columns: %w", err)
+		}
+	}
+
+	// Ko-fi payments that couldn't be rewarded are kept with their status
+	if _, err := db.ExecWithLog(ctx, `ALTER TABLE kofi_donations ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'claimed';`); err != nil {
+		return fmt.Errorf("failed to add kofi_donations status column: %w", err)
+	}
+
 	// Add missing columns to user_effects table if they don't exist
 	userEffectsColumnsSQL := []string{
 		`ALTER TABLE user_effects ADD COLUMN IF NOT EXISTS is_recipe BOOLEAN NOT NULL DEFAULT false;`,
@@ -498,6 +679,16 @@ func (db *DB) MigrateSchema(ctx context.Context) error {
 		return fmt.Errorf("failed to add metadata column to user_quest_progress: %w", err)
 	}
 
+	// Record when quest rewards were claimed
+	if _, err := db.ExecWithLog(ctx, `ALTER TABLE user_quest_progress ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMPTZ;`); err != nil {
+		return fmt.Errorf("failed to add claimed_at column to user_quest_progress: %w", err)
+	}
+
+	// Enforce one user_cards row per user and card so concurrent grants upsert instead of duplicating
+	if err := db.ensureUserCardsUnique(ctx); err != nil {
+		return fmt.Errorf("failed to add unique constraint to user_cards: %w", err)
+	}
+
 	// Add unique constraint to quest_leaderboards for upsert operations
 	questLeaderboardConstraintSQL := `
 		DO $$ 
@@ -527,6 +718,41 @@ func (db *DB) MigrateSchema(ctx context.Context) error {
 	return nil
 }
 
+// backfillCollectionTypes infers and stores the type of collections created before collection_type existed
+func (db *DB) backfillCollectionTypes(ctx context.Context) error {
+	var collections []*models.Collection
+	err := db.bunDB.NewSelect().
+		Model(&collections).
+		Column("id", "name", "tags").
+		Where("collection_type = ''").
+		Scan(ctx)
+	if err != nil {
+		return err
+	}
+	if len(collections) == 0 {
+		return nil
+	}
+
+	idsByType := make(map[string][]string)
+	for _, c := range collections {
+		t := models.InferCollectionType(c)
+		idsByType[t] = append(idsByType[t], c.ID)
+	}
+	for t, ids := range idsByType {
+		_, err := db.bunDB.NewUpdate().
+			Model((*models.Collection)(nil)).
+			Set("collection_type = ?", t).
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	slog.Info("Backfilled collection types", slog.Int("collections", len(collections)))
+	return nil
+}
+
 // MigrateUserJSONBFields fixes JSONB fields that might be stored as strings
 func (db *DB) MigrateUserJSONBFields(ctx context.Context) error {
 	// Fix completed_cols field: convert string arrays to proper JSONB objects
@@ -623,18 +849,18 @@ func (db *DB) ensureUTF8Encoding(ctx context.Context) error {
 
 // InitializeItemData inserts the default items into the items table
 func (db *DB) InitializeItemData(ctx context.Context) error {
-	// Check if items already exist
-	var itemCount int
-	err := db.pool.QueryRow(ctx, "SELECT COUNT(*) FROM items WHERE id IN ('broken_disc', 'microphone', 'forgotten_song')").Scan(&itemCount)
-	if err == nil && itemCount >= 3 {
-		slog.Info("Item data already initialized, skipping")
-		return nil
-	}
+	_, err := db.seedItems(ctx)
+	return err
+}
+
+// seedItems upserts the default items and counts which ones were newly inserted
+func (db *DB) seedItems(ctx context.Context) (SeedCount, error) {
+	var count SeedCount
 
 	// Check database encoding to determine if we should use emojis
 	var encoding string
 	useEmojis := true
-	err = db.pool.QueryRow(ctx, "SHOW server_encoding;").Scan(&encoding)
+	err := db.pool.QueryRow(ctx, "SHOW server_encoding;").Scan(&encoding)
 	if err == nil && encoding != "UTF8" {
 		useEmojis = false
 		slog.Info("Database encoding is not UTF8, using text representations instead of emojis", "encoding", encoding)
@@ -684,11 +910,13 @@ func (db *DB) InitializeItemData(ctx context.Context) error {
 	}
 
 	for _, item := range items {
-		// Use parameterized query to handle encoding properly
+		// Use parameterized query to handle encoding properly. xmax is 0 only for
+		// freshly inserted rows, which tells new items apart from existing ones.
 		insertSQL := `
 			INSERT INTO items (id, name, description, emoji, type, rarity, max_stack, created_at, updated_at) 
 			VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-			ON CONFLICT (id) DO UPDATE SET updated_at = CURRENT_TIMESTAMP;
+			ON CONFLICT (id) DO UPDATE SET updated_at = CURRENT_TIMESTAMP
+			RETURNING (xmax = 0);
 		`
 
 		// Use fallback emoji if database doesn't support UTF8
@@ -697,9 +925,10 @@ func (db *DB) InitializeItemData(ctx context.Context) error {
 			emoji = item.FallbackEmoji
 		}
 
-		_, err := db.ExecWithLog(ctx, insertSQL,
+		var inserted bool
+		err := db.pool.QueryRow(ctx, insertSQL,
 			item.ID, item.Name, item.Description, emoji,
-			item.Type, item.Rarity, item.MaxStack)
+			item.Type, item.Rarity, item.MaxStack).Scan(&inserted)
 		if err != nil {
 			// If emoji still fails, use fallback
 			if useEmojis {
@@ -707,24 +936,37 @@ func (db *DB) InitializeItemData(ctx context.Context) error {
 					slog.String("item", item.ID),
 					slog.String("error", err.Error()))
 
-				_, err = db.ExecWithLog(ctx, insertSQL,
+				err = db.pool.QueryRow(ctx, insertSQL,
 					item.ID, item.Name, item.Description, item.FallbackEmoji,
-					item.Type, item.Rarity, item.MaxStack)
+					item.Type, item.Rarity, item.MaxStack).Scan(&inserted)
 				if err != nil {
-					return fmt.Errorf("failed to insert item %s: %w", item.ID, err)
+					return count, fmt.Errorf("failed to insert item %s: %w", item.ID, err)
 				}
 			} else {
-				return fmt.Errorf("failed to insert item %s: %w", item.ID, err)
+				return count, fmt.Errorf("failed to insert item %s: %w", item.ID, err)
 			}
 		}
+		count.add(inserted)
 	}
 
-	slog.Info("Initial item data initialized successfully")
-	return nil
+	slog.Info("Initial item data initialized successfully",
+		slog.Int("created", count.Created),
+		slog.Int("existing", count.Existing))
+	return count, nil
 }
 
 // InitializeQuestData inserts or updates the default quest definitions
 func (db *DB) InitializeQuestData(ctx context.Context) error {
+	_, _, err := db.seedQuests(ctx)
+	return err
+}
+
+// seedQuests upserts the default quest definitions, counting new and existing ones,
+// and removes obsolete quests, returning how many were removed
+func (db *DB) seedQuests(ctx context.Context) (SeedCount, int, error) {
+	var count SeedCount
+	removed := 0
+
 	type questDef struct {
 		ID                string
 		Name              string
@@ -832,7 +1074,8 @@ func (db *DB) InitializeQuestData(ctx context.Context) error {
             reward_snowflakes = EXCLUDED.reward_snowflakes,
             reward_vials = EXCLUDED.reward_vials,
             reward_xp = EXCLUDED.reward_xp,
-            updated_at = CURRENT_TIMESTAMP;
+            updated_at = CURRENT_TIMESTAMP
+        RETURNING (xmax = 0);
     `
 
 	for _, q := range quests {
@@ -842,16 +1085,18 @@ func (db *DB) InitializeQuestData(ctx context.Context) error {
 		}
 		metaBytes, err := json.Marshal(meta)
 		if err != nil {
-			return fmt.Errorf("failed to marshal quest metadata for %s: %w", q.ID, err)
+			return count, removed, fmt.Errorf("failed to marshal quest metadata for %s: %w", q.ID, err)
 		}
 
-		if _, err := db.ExecWithLog(ctx, insertSQL,
+		var inserted bool
+		if err := db.pool.QueryRow(ctx, insertSQL,
 			q.ID, q.Name, q.Description, q.Tier, q.Type, q.Category,
 			q.RequirementType, q.RequirementTarget, q.RequirementCount, string(metaBytes),
 			q.RewardSnowflakes, q.RewardVials, q.RewardXP,
-		); err != nil {
-			return fmt.Errorf("failed to upsert quest %s: %w", q.ID, err)
+		).Scan(&inserted); err != nil {
+			return count, removed, fmt.Errorf("failed to upsert quest %s: %w", q.ID, err)
 		}
+		count.add(inserted)
 	}
 
 	obsoleteQuestIDs := []string{
@@ -859,13 +1104,18 @@ func (db *DB) InitializeQuestData(ctx context.Context) error {
 	}
 	for _, questID := range obsoleteQuestIDs {
 		if _, err := db.ExecWithLog(ctx, "DELETE FROM user_quest_progress WHERE quest_id = $1", questID); err != nil {
-			return fmt.Errorf("failed to delete obsolete quest progress %s: %w", questID, err)
+			return count, removed, fmt.Errorf("failed to delete obsolete quest progress %s: %w", questID, err)
 		}
-		if _, err := db.ExecWithLog(ctx, "DELETE FROM quest_definitions WHERE quest_id = $1", questID); err != nil {
-			return fmt.Errorf("failed to delete obsolete quest definition %s: %w", questID, err)
+		tag, err := db.ExecWithLog(ctx, "DELETE FROM quest_definitions WHERE quest_id = $1", questID)
+		if err != nil {
+			return count, removed, fmt.Errorf("failed to delete obsolete quest definition %s: %w", questID, err)
 		}
+		removed += int(tag.RowsAffected())
 	}
 
-	slog.Info("Quest definitions initialized/updated successfully", slog.Int("count", len(quests)))
-	return nil
+	slog.Info("Quest definitions initialized/updated successfully",
+		slog.Int("count", len(quests)),
+		slog.Int("created", count.Created),
+		slog.Int("removed", removed))
+	return count, removed, nil
 }
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// InitPart selects which part of the database initialization to run
+type InitPart string
+
+const (
+	InitAll    InitPart = "all"
+	InitSchema InitPart = "schema"
+	InitItems  InitPart = "items"
+	InitQuests InitPart = "quests"
+)
+
+// SeedCount tallies seeded rows that were newly inserted vs already present
+type SeedCount struct {
+	Created  int
+	Existing int
+}
+
+func (c *SeedCount) add(inserted bool) {
+	if inserted {
+		c.Created++
+	} else {
+		c.Existing++
+	}
+}
+
+// InitSummary reports what an initialization run changed. Every step is
+// idempotent, so re-running it only creates what is still missing.
+type InitSummary struct {
+	Part InitPart
+	// Skipped is set when DB_FAST_INIT found the schema already up to date
+	Skipped bool
+
+	TablesCreated  []string
+	TablesExisting int
+	Items          SeedCount
+	Quests         SeedCount
+	QuestsRemoved  int
+
+	// Collections and cards aren't seeded here; their counts are reported
+	// so an empty catalog is easy to spot
+	Collections int
+	Cards       int
+}
+
+// Initialize runs the requested part of the database initialization and
+// reports what was created vs already present
+func (db *DB) Initialize(ctx context.Context, part InitPart) (*InitSummary, error) {
+	summary := &InitSummary{Part: part}
+
+	switch part {
+	case InitAll:
+		if db.schemaUpToDate(ctx) {
+			summary.Skipped = true
+			return summary, db.countCatalog(ctx, summary)
+		}
+		if err := db.initializeSchema(ctx, summary); err != nil {
+			return summary, err
+		}
+		if err := db.initializeSeeds(ctx, summary, true, true); err != nil {
+			return summary, err
+		}
+
+		// Update schema version marker (safe upsert)
+		if err := db.ensureAppMeta(ctx); err == nil {
+			_ = db.setAppMeta(ctx, "schema_version", fmt.Sprintf("%d", schemaVersion))
+		}
+	case InitSchema:
+		if err := db.initializeSchema(ctx, summary); err != nil {
+			return summary, err
+		}
+	case InitItems:
+		if err := db.initializeSeeds(ctx, summary, true, false); err != nil {
+			return summary, err
+		}
+	case InitQuests:
+		if err := db.initializeSeeds(ctx, summary, false, true); err != nil {
+			return summary, err
+		}
+	default:
+		return summary, fmt.Errorf("unknown init part %q", part)
+	}
+
+	return summary, db.countCatalog(ctx, summary)
+}
+
+func (db *DB) initializeSeeds(ctx context.Context, summary *InitSummary, items, quests bool) error {
+	if items {
+		count, err := db.seedItems(ctx)
+		summary.Items = count
+		if err != nil {
+			return fmt.Errorf("failed to initialize item data: %w", err)
+		}
+	}
+
+	if quests {
+		count, removed, err := db.seedQuests(ctx)
+		summary.Quests = count
+		summary.QuestsRemoved = removed
+		if err != nil {
+			return fmt.Errorf("failed to initialize quest data: %w", err)
+		}
+	}
+	return nil
+}
+
+// existingTables returns the tables present in the current schema
+func (db *DB) existingTables(ctx context.Context) (map[string]bool, error) {
+	rows, err := db.pool.Query(ctx, "SELECT tablename FROM pg_tables WHERE schemaname = current_schema()")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables[name] = true
+	}
+	return tables, rows.Err()
+}
+
+func (db *DB) countCatalog(ctx context.Context, summary *InitSummary) error {
+	if err := db.pool.QueryRow(ctx, "SELECT COUNT(*) FROM collections").Scan(&summary.Collections); err != nil {
+		return fmt.Errorf("failed to count collections: %w", err)
+	}
+	if err := db.pool.QueryRow(ctx, "SELECT COUNT(*) FROM cards WHERE deleted_at IS NULL").Scan(&summary.Cards); err != nil {
+		return fmt.Errorf("failed to count cards: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database"
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+)
+
+func TestInitializeTwice(t *testing.T) {
+	t.Setenv("DB_FAST_INIT", "")
+	db := dbtest.Open(t)
+	ctx := context.Background()
+
+	if _, err := db.Initialize(ctx, database.InitAll); err != nil {
+		t.Fatalf("first Initialize: %v", err)
+	}
+
+	// Everything exists after the first run, so the second only finds it
+	summary, err := db.Initialize(ctx, database.InitAll)
+	if err != nil {
+		t.Fatalf("second Initialize: %v", err)
+	}
+	if summary.Skipped {
+		t.Fatal("second run was skipped without DB_FAST_INIT")
+	}
+	if len(summary.TablesCreated) != 0 || summary.TablesExisting == 0 {
+		t.Errorf("tables = %v created, %d existing, want none created", summary.TablesCreated, summary.TablesExisting)
+	}
+	if summary.Items.Created != 0 || summary.Items.Existing == 0 {
+		t.Errorf("items = %+v, want all existing", summary.Items)
+	}
+	if summary.Quests.Created != 0 || summary.Quests.Existing == 0 || summary.QuestsRemoved != 0 {
+		t.Errorf("quests = %+v, %d removed, want all existing", summary.Quests, summary.QuestsRemoved)
+	}
+}
+
+func TestInitializePart(t *testing.T) {
+	t.Setenv("DB_FAST_INIT", "")
+	db := dbtest.Open(t)
+	ctx := context.Background()
+
+	// Only the items part runs, so nothing is reported for tables or quests
+	summary, err := db.Initialize(ctx, database.InitItems)
+	if err != nil {
+		t.Fatalf("Initialize items: %v", err)
+	}
+	if summary.Items.Created+summary.Items.Existing == 0 {
+		t.Error("no items seeded")
+	}
+	if summary.TablesExisting != 0 || len(summary.TablesCreated) != 0 || summary.Quests != (database.SeedCount{}) {
+		t.Errorf("items run touched other parts: %+v", summary)
+	}
+
+	if _, err := db.Initialize(ctx, database.InitPart("cards")); err == nil {
+		t.Error("unknown part accepted")
+	}
+}
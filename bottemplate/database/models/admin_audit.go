@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AdminAudit records a single admin command invocation
+type AdminAudit struct {
+	bun.BaseModel `bun:"table:admin_audit,alias:aa"`
+
+	ID         int64                  `bun:"id,pk,autoincrement" json:"id"`
+	ActorID    string                 `bun:"actor_id,notnull" json:"actor_id"`
+	Command    string                 `bun:"command,notnull" json:"command"`
+	Target     string                 `bun:"target" json:"target"`
+	Parameters map[string]interface{} `bun:"parameters,type:jsonb" json:"parameters"`
+	GuildID    string                 `bun:"guild_id" json:"guild_id"`
+	CreatedAt  time.Time              `bun:"created_at,notnull" json:"created_at"`
+}
@@ -34,8 +34,15 @@ type Auction struct {
 	ChannelID         string        `bun:"channel_id"`
 	LastBidTime       time.Time     `bun:"last_bid_time"`
 	BidCount          int           `bun:"bid_count"`
+	FinalPrice        int64         `bun:"final_price,notnull,default:0"`    // winning bid, 0 if unsold
+	SoldAt            time.Time     `bun:"sold_at,nullzero"`                 // when a sold auction closed
+	ListingFee        int64         `bun:"listing_fee,notnull,default:0"`    // charged to the seller on create
+	CommissionBps     int64         `bun:"commission_bps,notnull,default:0"` // sale commission rate fixed at listing
+	Commission        int64         `bun:"commission,notnull,default:0"`     // kept back from the seller on sale
 	CreatedAt         time.Time     `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt         time.Time     `bun:"updated_at,notnull,default:current_timestamp"`
+
+	Card *Card `bun:"rel:belongs-to,join:card_id=id"`
 }
 
 type AuctionBid struct {
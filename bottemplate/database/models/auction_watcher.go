@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AuctionWatcher is a user who asked to be DM'd about an auction's bids and ending
+type AuctionWatcher struct {
+	bun.BaseModel `bun:"table:auction_watchers,alias:aw"`
+
+	AuctionID      int64     `bun:"auction_id,pk"`
+	UserID         string    `bun:"user_id,pk"`
+	EndingNotified bool      `bun:"ending_notified,notnull,default:false"`
+	CreatedAt      time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// BackgroundProcess is the last reported state of one of the bot's background
+// processes, persisted so the web dashboard can inspect and restart them
+type BackgroundProcess struct {
+	bun.BaseModel `bun:"table:background_processes,alias:bp"`
+
+	Name             string     `bun:"name,pk"`
+	Description      string     `bun:"description,notnull,default:''"`
+	Status           string     `bun:"status,notnull"`
+	StartedAt        time.Time  `bun:"started_at,notnull"`
+	Restarts         int        `bun:"restarts,notnull,default:0"`
+	LastRun          *time.Time `bun:"last_run"`
+	LastSuccess      *time.Time `bun:"last_success"`
+	LastError        string     `bun:"last_error,notnull,default:''"`
+	LastErrorAt      *time.Time `bun:"last_error_at"`
+	RestartRequested bool       `bun:"restart_requested,notnull,default:false"`
+	UpdatedAt        time.Time  `bun:"updated_at,notnull,default:current_timestamp"`
+}
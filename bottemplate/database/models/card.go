@@ -9,14 +9,17 @@ import (
 type Card struct {
 	bun.BaseModel `bun:"table:cards,alias:c"`
 
-	ID        int64     `bun:"id,pk"` // Using the ID from JSON as primary key
-	Name      string    `bun:"name,notnull"`
-	Level     int       `bun:"level,notnull"`
-	Animated  bool      `bun:"animated,notnull"`
-	ColID     string    `bun:"col_id,notnull,type:text"`
-	Tags      []string  `bun:"tags,type:jsonb"`
-	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
-	UpdatedAt time.Time `bun:"updated_at,notnull"`
+	ID          int64     `bun:"id,pk"` // Using the ID from JSON as primary key
+	Name        string    `bun:"name,notnull"`
+	Level       int       `bun:"level,notnull"`
+	Animated    bool      `bun:"animated,notnull"`
+	ColID       string    `bun:"col_id,notnull,type:text"`
+	Tags        []string  `bun:"tags,type:jsonb"`
+	ImageFormat string    `bun:"image_format,notnull,default:''"` // Stored image extension, empty means jpg
+	MaxCopies   int64     `bun:"max_copies,notnull,default:0"`    // Global supply cap, 0 falls back to the collection's cap
+	CreatedAt   time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt   time.Time `bun:"updated_at,notnull"`
+	DeletedAt   time.Time `bun:"deleted_at,soft_delete,nullzero"` // Set when the card is soft-deleted; hidden from queries
 
 	// Relations
 	Collection *Collection `bun:"rel:belongs-to,join:col_id=id"`
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// CardSupply counts how many copies of a supply-capped card have been minted
+type CardSupply struct {
+	bun.BaseModel `bun:"table:card_supply,alias:cs"`
+
+	CardID    int64     `bun:"card_id,pk"`
+	Minted    int64     `bun:"minted,notnull,default:0"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -16,10 +17,55 @@ type Collection struct {
 	Promo      bool      `bun:"promo,notnull"`
 	Compressed bool      `bun:"compressed,notnull"`
 	Fragments  bool      `bun:"fragments,notnull,default:false"`
+	MaxCopies  int64     `bun:"max_copies,notnull,default:0"` // Per-card supply cap, 0 means unlimited
 	Tags       []string  `bun:"tags,type:jsonb"`
+	Type       string    `bun:"collection_type,notnull,default:''"` // girl_group, boy_group or other; empty on legacy rows
 	CreatedAt  time.Time `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt  time.Time `bun:"updated_at,notnull"`
 
 	// Relations
 	Cards []*Card `bun:"rel:has-many,join:id=col_id"`
 }
+
+// Collection types stored in collections.collection_type
+const (
+	CollectionTypeGirlGroup = "girl_group"
+	CollectionTypeBoyGroup  = "boy_group"
+	CollectionTypeOther     = "other"
+)
+
+// IsValidCollectionType reports whether t is one of the stored collection types
+func IsValidCollectionType(t string) bool {
+	return t == CollectionTypeGirlGroup || t == CollectionTypeBoyGroup || t == CollectionTypeOther
+}
+
+// ResolvedType returns the stored collection type, inferring it for legacy rows that predate the column
+func (c *Collection) ResolvedType() string {
+	if c.Type != "" {
+		return c.Type
+	}
+	return InferCollectionType(c)
+}
+
+// InferCollectionType guesses the collection type from its tags, then its name
+func InferCollectionType(c *Collection) string {
+	for _, tag := range c.Tags {
+		tag = strings.ToLower(tag)
+		if strings.Contains(tag, "girl") || strings.Contains(tag, "female") {
+			return CollectionTypeGirlGroup
+		}
+		if strings.Contains(tag, "boy") || strings.Contains(tag, "male") {
+			return CollectionTypeBoyGroup
+		}
+	}
+
+	name := strings.ToLower(c.Name)
+	if strings.Contains(name, "girl") || strings.Contains(name, "female") {
+		return CollectionTypeGirlGroup
+	}
+	if strings.Contains(name, "boy") || strings.Contains(name, "male") {
+		return CollectionTypeBoyGroup
+	}
+
+	return CollectionTypeOther
+}
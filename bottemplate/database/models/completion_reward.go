@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// CompletionRewardGrant records the reward handed out for completing a
+// collection. A user is only ever rewarded once per collection.
+type CompletionRewardGrant struct {
+	bun.BaseModel `bun:"table:completion_reward_grants,alias:crg"`
+
+	ID           int64     `bun:"id,pk,autoincrement"`
+	UserID       string    `bun:"user_id,notnull"`
+	CollectionID string    `bun:"collection_id,notnull"`
+	Flakes       int64     `bun:"flakes,notnull,default:0"`
+	Vials        int64     `bun:"vials,notnull,default:0"`
+	ItemID       string    `bun:"item_id,nullzero"`
+	ItemQuantity int       `bun:"item_quantity,notnull,default:0"`
+	GrantedAt    time.Time `bun:"granted_at,notnull,default:current_timestamp"`
+}
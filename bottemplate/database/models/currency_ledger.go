@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Reasons recorded on currency ledger entries
+const (
+	LedgerReasonDaily             = "daily"
+	LedgerReasonWork              = "work"
+	LedgerReasonAuctionBid        = "auction_bid"
+	LedgerReasonAuctionRefund     = "auction_refund"
+	LedgerReasonAuctionSale       = "auction_sale"
+	LedgerReasonAuctionBonus      = "auction_bonus"
+	LedgerReasonAuctionListingFee = "auction_listing_fee"
+	LedgerReasonAuctionCommission = "auction_commission"
+	LedgerReasonShop              = "shop"
+	LedgerReasonVote              = "vote"
+	LedgerReasonKofi              = "kofi"
+)
+
+// CurrencyLedgerEntry records a single change to a user's balance. Amount is
+// negative for spending.
+type CurrencyLedgerEntry struct {
+	bun.BaseModel `bun:"table:currency_ledger,alias:cl"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	UserID    string    `bun:"user_id,notnull"`
+	Amount    int64     `bun:"amount,notnull"`
+	Reason    string    `bun:"reason,notnull"`
+	Reference string    `bun:"reference"`
+	CreatedAt time.Time `bun:"created_at,notnull"`
+}
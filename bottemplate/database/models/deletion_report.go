@@ -3,5 +3,9 @@ package models
 type DeletionReport struct {
 	CardID           int64 `json:"card_id"`
 	UserCardsDeleted int   `json:"user_cards_deleted"`
+	CopiesDeleted    int64 `json:"copies_deleted"`
+	WishlistsDeleted int   `json:"wishlists_deleted"`
 	CardDeleted      bool  `json:"card_deleted"`
+	ImageDeleted     bool  `json:"image_deleted"`
+	SoftDeleted      bool  `json:"soft_deleted"` // Only deleted_at was set; owned copies, wishlists and the image are kept
 }
@@ -20,6 +20,7 @@ type EconomyStats struct {
 	DailyTransactions int       `bun:"daily_transactions,notnull"`
 	InflationRate     float64   `bun:"inflation_rate,notnull"`
 	GiniCoefficient   float64   `bun:"gini_coefficient,notnull"`
+	CardOwnershipGini float64   `bun:"card_ownership_gini,notnull,default:0"`
 
 	// Market health indicators
 	AverageDailyTrades int     `bun:"average_daily_trades,notnull"`
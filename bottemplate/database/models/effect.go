@@ -46,6 +46,30 @@ type UserEffect struct {
 	Progress       int        `bun:"progress,notnull,default:0"` // Progress towards next tier
 }
 
+// CollectionExpBoost is a timed level-up EXP boost for one collection, started by an
+// active effect. A user has at most one boost running.
+type CollectionExpBoost struct {
+	bun.BaseModel `bun:"table:collection_exp_boosts,alias:ceb"`
+
+	UserID       string    `bun:"user_id,pk"`
+	CollectionID string    `bun:"collection_id,notnull"`
+	Multiplier   float64   `bun:"multiplier,notnull"`
+	ExpiresAt    time.Time `bun:"expires_at,notnull"`
+	CreatedAt    time.Time `bun:"created_at,notnull"`
+}
+
+// ShopStock tracks the remaining stock of a limited shop item
+type ShopStock struct {
+	bun.BaseModel `bun:"table:shop_stock,alias:ss"`
+
+	ItemID        string    `bun:"item_id,pk"`
+	Stock         int       `bun:"stock,notnull"`
+	MaxStock      int       `bun:"max_stock,notnull"`
+	RestockHours  int       `bun:"restock_hours,notnull"`
+	NextRestockAt time.Time `bun:"next_restock_at,notnull"`
+	UpdatedAt     time.Time `bun:"updated_at,notnull"`
+}
+
 // EffectTierData represents the tier configuration for an effect
 type EffectTierData struct {
 	Values     []int `json:"values"`     // Values per tier (e.g., bonus amounts)
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// GuildSettings stores per-guild bot configuration. Guilds without a row have every command
+// category enabled.
+type GuildSettings struct {
+	bun.BaseModel `bun:"table:guild_settings,alias:gs"`
+
+	GuildID            string    `bun:"guild_id,pk"`
+	DisabledCategories []string  `bun:"disabled_categories,array"`
+	UpdatedBy          string    `bun:"updated_by"`
+	CreatedAt          time.Time `bun:"created_at,notnull"`
+	UpdatedAt          time.Time `bun:"updated_at,notnull"`
+}
+
+// IsCategoryEnabled reports whether commands in the category may run in the guild. A nil
+// settings row means the guild never changed its configuration.
+func (s *GuildSettings) IsCategoryEnabled(category string) bool {
+	if s == nil {
+		return true
+	}
+	for _, disabled := range s.DisabledCategories {
+		if disabled == category {
+			return false
+		}
+	}
+	return true
+}
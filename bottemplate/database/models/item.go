@@ -16,6 +16,7 @@ type Item struct {
 	Type        string                 `bun:"type,notnull"`
 	Rarity      int                    `bun:"rarity,notnull"`
 	MaxStack    int                    `bun:"max_stack,notnull"`
+	Tradeable   bool                   `bun:"tradeable,notnull,default:true"` // Whether users can gift the item to each other
 	Metadata    map[string]interface{} `bun:"metadata,type:jsonb"`
 	CreatedAt   time.Time              `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt   time.Time              `bun:"updated_at,notnull,default:current_timestamp"`
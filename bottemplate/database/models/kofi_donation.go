@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type KofiDonationStatus string
+
+const (
+	// KofiDonationClaimed payments were rewarded
+	KofiDonationClaimed KofiDonationStatus = "claimed"
+	// KofiDonationUnmatched payments name no Discord user, or one that has no account
+	KofiDonationUnmatched KofiDonationStatus = "unmatched"
+	// KofiDonationPending payments have an amount or currency that couldn't be priced
+	KofiDonationPending KofiDonationStatus = "pending"
+)
+
+// KofiDonation records a Ko-fi payment. Ko-fi's message ID is the key, so a webhook
+// delivered twice is only ever rewarded once. Payments that couldn't be rewarded are
+// kept as unmatched or pending so they can be settled by hand.
+type KofiDonation struct {
+	bun.BaseModel `bun:"table:kofi_donations,alias:kd"`
+
+	MessageID     string    `bun:"message_id,pk"`
+	TransactionID string    `bun:"transaction_id,nullzero"`
+	UserID        string    `bun:"user_id,notnull"`
+	Type          string    `bun:"type,notnull"`       // Donation, Subscription, Commission or Shop Order
+	TierName      string    `bun:"tier_name,nullzero"` // membership tier for subscription payments
+	Amount        string    `bun:"amount,notnull"`     // as Ko-fi sent it, e.g. "3.00"
+	Currency      string    `bun:"currency,notnull"`
+	Flakes        int64     `bun:"flakes,notnull,default:0"`
+	PremiumDays   int       `bun:"premium_days,notnull,default:0"`
+	ReceivedAt    time.Time `bun:"received_at,notnull,default:current_timestamp"`
+
+	Status KofiDonationStatus `bun:"status,notnull,default:'claimed'"`
+}
@@ -64,3 +64,65 @@ func DefaultPreferences() *Preferences {
 		},
 	}
 }
+
+// NotificationSettings returns the user's notification preferences, or the defaults
+// when they never saved any
+func (u *User) NotificationSettings() NotificationPreferences {
+	if u.Preferences == nil {
+		return DefaultPreferences().Notifications
+	}
+	return u.Preferences.Notifications
+}
+
+// NotificationToggle is a notification preference users can switch in /preferences.
+// Key is the preference's JSON key.
+type NotificationToggle struct {
+	Key         string
+	Label       string
+	Description string
+	Field       func(p *NotificationPreferences) *bool
+}
+
+// NotificationToggles lists the notifications the bot sends DMs for, in display order
+var NotificationToggles = []NotificationToggle{
+	{
+		Key:         "aucoutbid",
+		Label:       "Outbid",
+		Description: "When someone outbids you on an auction",
+		Field:       func(p *NotificationPreferences) *bool { return &p.AucOutBid },
+	},
+	{
+		Key:         "aucnewbid",
+		Label:       "Watched Auctions",
+		Description: "New bids on, and the end of, auctions you watch",
+		Field:       func(p *NotificationPreferences) *bool { return &p.AucNewBid },
+	},
+	{
+		Key:         "aucend",
+		Label:       "Auction Results",
+		Description: "When an auction you sold or won ends",
+		Field:       func(p *NotificationPreferences) *bool { return &p.AucEnd },
+	},
+	{
+		Key:         "completed",
+		Label:       "Completions",
+		Description: "When you complete a collection or lose a completion",
+		Field:       func(p *NotificationPreferences) *bool { return &p.Completed },
+	},
+	{
+		Key:         "effectend",
+		Label:       "Effect Expiry",
+		Description: "When one of your passive effects expires",
+		Field:       func(p *NotificationPreferences) *bool { return &p.EffectEnd },
+	},
+}
+
+// FindNotificationToggle returns the toggle with the given key
+func FindNotificationToggle(key string) (NotificationToggle, bool) {
+	for _, toggle := range NotificationToggles {
+		if toggle.Key == key {
+			return toggle, true
+		}
+	}
+	return NotificationToggle{}, false
+}
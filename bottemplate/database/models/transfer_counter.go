@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	TransferKindGift  = "gift"
+	TransferKindTrade = "trade"
+)
+
+// TransferCounter tallies how much a user has sent of one kind within a window.
+// A new window starts a new row, so counters reset without a cleanup pass.
+type TransferCounter struct {
+	bun.BaseModel `bun:"table:transfer_counters,alias:tc"`
+
+	UserID      string    `bun:"user_id,pk"`
+	Kind        string    `bun:"kind,pk"`
+	WindowStart time.Time `bun:"window_start,pk"`
+	Count       int64     `bun:"count,notnull,default:0"`
+	UpdatedAt   time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+}
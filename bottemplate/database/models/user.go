@@ -27,14 +27,16 @@ type CloutedColModel struct {
 type User struct {
 	bun.BaseModel `bun:"table:users,alias:u"`
 
-	ID              int64     `bun:"id,pk,autoincrement"`
-	DiscordID       string    `bun:"discord_id,notnull,unique"`
-	Username        string    `bun:"username,notnull"`
-	Balance         int64     `bun:"balance,notnull,default:0"`
-	PromoExp        int64     `bun:"promo_exp,notnull,default:0"`
-	Joined          time.Time `bun:"joined,notnull"`
-	LastQueriedCard Card      `bun:"last_queried_card,type:jsonb"`
-	LastKofiClaim   time.Time `bun:"last_kofi_claim"`
+	ID        int64  `bun:"id,pk,autoincrement"`
+	DiscordID string `bun:"discord_id,notnull,unique"`
+	Username  string `bun:"username,notnull"`
+	Balance   int64  `bun:"balance,notnull,default:0"`
+	PromoExp  int64  `bun:"promo_exp,notnull,default:0"`
+	// PromoExpExpiresAt is when the current promo EXP lapses; zero when it never does
+	PromoExpExpiresAt time.Time `bun:"promo_exp_expires_at,nullzero"`
+	Joined            time.Time `bun:"joined,notnull"`
+	LastQueriedCard   Card      `bun:"last_queried_card,type:jsonb"`
+	LastKofiClaim     time.Time `bun:"last_kofi_claim"`
 
 	// Stats
 	DailyStats  GameStats   `bun:"daily_stats,type:jsonb"`
@@ -54,6 +56,7 @@ type User struct {
 	LastDaily    time.Time `bun:"last_daily,notnull"`
 	LastTrain    time.Time `bun:"last_train,notnull"`
 	LastWork     time.Time `bun:"last_work,notnull"`
+	LastSummon   time.Time `bun:"last_summon,nullzero"`
 	LastVote     time.Time `bun:"last_vote,notnull"`
 	LastAnnounce time.Time `bun:"last_announce,notnull"`
 	LastMsg      string    `bun:"last_msg"`
@@ -129,6 +132,15 @@ type BanInfo struct {
 	Embargo bool `json:"embargo"`
 	Report  bool `json:"report"`
 	Tags    int  `json:"tags"`
+	// Set by /ban; a zero Until keeps the ban until /unban
+	Reason   string    `json:"reason,omitempty"`
+	Until    time.Time `json:"until,omitempty"`
+	BannedBy string    `json:"banned_by,omitempty"`
+}
+
+// Active reports whether a full ban is in force at now
+func (b BanInfo) Active(now time.Time) bool {
+	return b.Full && (b.Until.IsZero() || b.Until.After(now))
 }
 
 type NotificationPrefs struct {
@@ -232,3 +244,25 @@ func (f *FlexibleCloutedCols) UnmarshalJSON(data []byte) error {
 	*f = FlexibleCloutedCols{}
 	return nil
 }
+
+// PremiumActive reports whether the user's premium is on and hasn't expired at now.
+// A zero PremiumExpires means premium doesn't expire.
+func (u *User) PremiumActive(now time.Time) bool {
+	if u == nil || !u.Premium {
+		return false
+	}
+	return u.PremiumExpires.IsZero() || u.PremiumExpires.After(now)
+}
+
+// ExtendedPremiumExpiry returns premium_expires after granting d more premium at now.
+// Running premium is extended from its expiry, lapsed premium from now, and premium
+// without an expiry stays without one.
+func (u *User) ExtendedPremiumExpiry(d time.Duration, now time.Time) time.Time {
+	if !u.PremiumActive(now) {
+		return now.Add(d)
+	}
+	if u.PremiumExpires.IsZero() {
+		return time.Time{}
+	}
+	return u.PremiumExpires.Add(d)
+}
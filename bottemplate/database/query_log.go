@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// logQuery records a successful statement. Statements slower than the slow query
+// threshold log at Info; everything else logs at Debug so normal load stays quiet.
+func (db *DB) logQuery(ctx context.Context, operation, sql string, args []interface{}, duration time.Duration, extra ...slog.Attr) {
+	level := slog.LevelDebug
+	if duration >= db.slowQueryThreshold {
+		level = slog.LevelInfo
+	}
+
+	logger := slog.Default()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	msg := "Query executed"
+	if level == slog.LevelInfo {
+		msg = "Slow query executed"
+	}
+
+	attrs := append([]slog.Attr{
+		slog.String("type", "db"),
+		slog.String("operation", operation),
+		slog.String("query", sql),
+		slog.Any("args", redactQueryArgs(args)),
+		slog.Duration("took", duration),
+	}, extra...)
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+func (db *DB) logQueryError(ctx context.Context, operation, sql string, args []interface{}, duration time.Duration, err error) {
+	slog.ErrorContext(ctx, "Query failed",
+		slog.String("type", "db"),
+		slog.String("operation", operation),
+		slog.String("query", sql),
+		slog.Any("args", redactQueryArgs(args)),
+		slog.Duration("took", duration),
+		slog.Any("error", err),
+	)
+}
+
+// redactQueryArgs keeps numeric, boolean and time arguments, which help when
+// debugging, and replaces everything else (user text, JSON payloads, tokens)
+// with its type so query logs never carry user data
+func redactQueryArgs(args []interface{}) []interface{} {
+	redacted := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, time.Time, time.Duration:
+			redacted[i] = v
+		default:
+			redacted[i] = fmt.Sprintf("<redacted %T>", arg)
+		}
+	}
+	return redacted
+}
+
+// queryLogHook applies the same level policy to queries made through bun. Only the
+// operation is logged, since bun's formatted SQL has the arguments inlined; the
+// request ID added by the logger's context handler ties each query to its interaction.
+type queryLogHook struct {
+	db *DB
+}
+
+func (h *queryLogHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *queryLogHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	duration := time.Since(event.StartTime)
+
+	level := slog.LevelDebug
+	msg := "Query executed"
+	if duration >= h.db.slowQueryThreshold {
+		level, msg = slog.LevelInfo, "Slow query executed"
+	}
+
+	logger := slog.Default()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("type", "db"),
+		slog.String("operation", event.Operation()),
+		slog.Duration("took", duration),
+	}
+	// Callers handle and log their own query errors; this only keeps them in the trace
+	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) {
+		attrs = append(attrs, slog.Any("error", event.Err))
+	}
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
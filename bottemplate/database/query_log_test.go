@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingHandler keeps every log record at or above its level
+type recordingHandler struct {
+	level   slog.Level
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+func (h *recordingHandler) WithAttrs(attrs []slog.Attr) slog.Handler { return h }
+func (h *recordingHandler) WithGroup(name string) slog.Handler       { return h }
+
+func (h *recordingHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func captureLogs(t *testing.T, level slog.Level) *recordingHandler {
+	t.Helper()
+	h := &recordingHandler{level: level}
+	prev := slog.Default()
+	slog.SetDefault(slog.New(h))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return h
+}
+
+func TestLogQueryLevels(t *testing.T) {
+	logs := captureLogs(t, slog.LevelDebug)
+	db := &DB{slowQueryThreshold: 100 * time.Millisecond}
+	ctx := context.Background()
+
+	db.logQuery(ctx, "exec", "UPDATE users SET balance = $1", []interface{}{int64(5)}, 3*time.Millisecond)
+	db.logQuery(ctx, "query", "SELECT * FROM cards", nil, 250*time.Millisecond)
+	db.logQueryError(ctx, "exec", "DELETE FROM cards", nil, time.Millisecond, errors.New("boom"))
+
+	want := []struct {
+		level slog.Level
+		msg   string
+	}{
+		{slog.LevelDebug, "Query executed"},
+		{slog.LevelInfo, "Slow query executed"},
+		{slog.LevelError, "Query failed"},
+	}
+	if len(logs.records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(logs.records), len(want))
+	}
+	for i, w := range want {
+		if r := logs.records[i]; r.Level != w.level || r.Message != w.msg {
+			t.Errorf("record %d = %v %q, want %v %q", i, r.Level, r.Message, w.level, w.msg)
+		}
+	}
+}
+
+func TestLogQuerySkipsDisabledLevel(t *testing.T) {
+	logs := captureLogs(t, slog.LevelInfo)
+	db := &DB{slowQueryThreshold: 100 * time.Millisecond}
+
+	db.logQuery(context.Background(), "exec", "SELECT 1", nil, time.Millisecond)
+	if len(logs.records) != 0 {
+		t.Errorf("fast query logged %d records at Info, want none", len(logs.records))
+	}
+}
+
+func TestRedactQueryArgs(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := redactQueryArgs([]interface{}{int64(42), true, at, nil, "secret token", []byte(`{"a":1}`)})
+	want := []interface{}{int64(42), true, at, nil, "<redacted string>", "<redacted []uint8>"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("redactQueryArgs = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/uptrace/bun"
+)
+
+// AdminAuditFilter narrows an audit query; zero values are ignored
+type AdminAuditFilter struct {
+	ActorID string
+	Command string
+	From    time.Time
+	To      time.Time
+}
+
+type AdminAuditRepository interface {
+	Create(ctx context.Context, entry *models.AdminAudit) error
+	List(ctx context.Context, filter AdminAuditFilter, offset, limit int) ([]*models.AdminAudit, int, error)
+}
+
+type adminAuditRepository struct {
+	*BaseRepository
+}
+
+func NewAdminAuditRepository(db *bun.DB) AdminAuditRepository {
+	return &adminAuditRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *adminAuditRepository) Create(ctx context.Context, entry *models.AdminAudit) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	_, err := r.ExecWithTimeout(ctx, "create", "admin_audit", func(ctx context.Context) (sql.Result, error) {
+		return r.db.NewInsert().Model(entry).Exec(ctx)
+	})
+	return err
+}
+
+// List returns audit entries newest first along with the total number of matches
+func (r *adminAuditRepository) List(ctx context.Context, filter AdminAuditFilter, offset, limit int) ([]*models.AdminAudit, int, error) {
+	var entries []*models.AdminAudit
+	var total int
+
+	err := r.SelectWithTimeout(ctx, "list", "admin_audit", func(ctx context.Context) error {
+		query := r.db.NewSelect().Model(&entries)
+		if filter.ActorID != "" {
+			query = query.Where("actor_id = ?", filter.ActorID)
+		}
+		if filter.Command != "" {
+			query = query.Where("command = ?", filter.Command)
+		}
+		if !filter.From.IsZero() {
+			query = query.Where("created_at >= ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			query = query.Where("created_at < ?", filter.To)
+		}
+
+		var err error
+		total, err = query.
+			Order("created_at DESC", "id DESC").
+			Offset(offset).
+			Limit(limit).
+			ScanAndCount(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
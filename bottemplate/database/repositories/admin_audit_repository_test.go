@@ -0,0 +1,48 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestAdminAuditList(t *testing.T) {
+	db := dbtest.Open(t)
+	repo := repositories.NewAdminAuditRepository(db.BunDB())
+	ctx := context.Background()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, entry := range []*models.AdminAudit{
+		{ActorID: "admin1", Command: "deletecard", Target: "card:1", CreatedAt: base},
+		{ActorID: "admin1", Command: "gift", Target: "user:9", CreatedAt: base.Add(time.Hour)},
+		{ActorID: "admin2", Command: "deletecard", Target: "card:2", CreatedAt: base.Add(2 * time.Hour)},
+	} {
+		if err := repo.Create(ctx, entry); err != nil {
+			t.Fatalf("Create %d: %v", i, err)
+		}
+	}
+
+	entries, total, err := repo.List(ctx, repositories.AdminAuditFilter{Command: "deletecard"}, 0, 10)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 2 || len(entries) != 2 || entries[0].Target != "card:2" {
+		t.Errorf("deletecard entries = %d of %d, first %+v; want newest first", len(entries), total, entries[0])
+	}
+
+	entries, total, err = repo.List(ctx, repositories.AdminAuditFilter{
+		ActorID: "admin1",
+		From:    base.Add(30 * time.Minute),
+		To:      base.Add(90 * time.Minute),
+	}, 0, 10)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 1 || entries[0].Command != "gift" {
+		t.Errorf("admin1 entries in range = %d, want only the gift", total)
+	}
+}
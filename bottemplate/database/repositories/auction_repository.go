@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/uptrace/bun"
 )
 
@@ -18,6 +20,9 @@ type AuctionRepository interface {
 	GetByID(ctx context.Context, id int64) (*models.Auction, error)
 	GetByAuctionID(ctx context.Context, auctionID string) (*models.Auction, error)
 	GetActive(ctx context.Context) ([]*models.Auction, error)
+	// SearchActive returns running auctions whose card matches filters, ending soonest
+	// first, with Card loaded
+	SearchActive(ctx context.Context, filters utils.SearchFilters) ([]*models.Auction, error)
 	UpdateBid(ctx context.Context, auctionID int64, bidderID string, amount int64) error
 	CompleteAuction(ctx context.Context, auctionID int64) error
 	GetUserBids(ctx context.Context, userID string) ([]*models.AuctionBid, error)
@@ -30,15 +35,36 @@ type AuctionRepository interface {
 	CompleteAuctionWithTransferAndGet(ctx context.Context, auctionID int64) (*models.Auction, error)
 	handleWinningBidTransfer(ctx context.Context, tx bun.Tx, auction *models.Auction) error
 	GetRecentCompletedAuctions(ctx context.Context, cardID int64, limit int) ([]*models.Auction, error)
+	// GetSoldHistory returns the card's auctions that sold since the given time, newest first
+	GetSoldHistory(ctx context.Context, cardID int64, since time.Time) ([]AuctionSale, error)
 	AuctionIDExists(ctx context.Context, auctionID string) (bool, error)
 }
 
+// AuctionSale is the final price of one sold auction
+type AuctionSale struct {
+	AuctionID  string    `bun:"auction_id"`
+	FinalPrice int64     `bun:"final_price"`
+	SoldAt     time.Time `bun:"sold_at"`
+}
+
+// RecordFinalSale adds the final sale price and time to an update that completes an
+// auction. Auctions that closed without a winner keep a zero price and no sale time.
+func RecordFinalSale(q *bun.UpdateQuery, soldAt time.Time) *bun.UpdateQuery {
+	return q.
+		Set("final_price = CASE WHEN COALESCE(top_bidder_id, '') <> '' THEN current_price ELSE 0 END").
+		Set("sold_at = CASE WHEN COALESCE(top_bidder_id, '') <> '' THEN ?::timestamptz ELSE NULL END", soldAt)
+}
+
 type auctionRepository struct {
-	db *bun.DB
+	db        *bun.DB
+	txManager *economicUtils.EconomicTransactionManager
 }
 
 func NewAuctionRepository(db *bun.DB) AuctionRepository {
-	return &auctionRepository{db: db}
+	return &auctionRepository{
+		db:        db,
+		txManager: economicUtils.NewEconomicTransactionManager(db),
+	}
 }
 
 func (r *auctionRepository) DB() *bun.DB {
@@ -101,6 +127,44 @@ func (r *auctionRepository) GetActive(ctx context.Context) ([]*models.Auction, e
 	return auctions, nil
 }
 
+func (r *auctionRepository) SearchActive(ctx context.Context, filters utils.SearchFilters) ([]*models.Auction, error) {
+	var auctions []*models.Auction
+	err := r.db.NewSelect().
+		Model(&auctions).
+		Relation("Card").
+		Where("a.status = ?", models.AuctionStatusActive).
+		Where("a.end_time > ?", time.Now()).
+		Order("a.end_time ASC", "a.id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search active auctions: %w", err)
+	}
+
+	// Card filters follow the same rules as every other search, so match the
+	// auctioned cards in memory rather than re-implementing them in SQL
+	cards := make([]*models.Card, 0, len(auctions))
+	seen := make(map[int64]bool, len(auctions))
+	for _, auction := range auctions {
+		if auction.Card != nil && !seen[auction.CardID] {
+			seen[auction.CardID] = true
+			cards = append(cards, auction.Card)
+		}
+	}
+
+	matched := make(map[int64]bool, len(cards))
+	for _, card := range utils.WeightedSearch(cards, filters) {
+		matched[card.ID] = true
+	}
+
+	results := auctions[:0]
+	for _, auction := range auctions {
+		if matched[auction.CardID] {
+			results = append(results, auction)
+		}
+	}
+	return results, nil
+}
+
 func (r *auctionRepository) UpdateBid(ctx context.Context, auctionID int64, bidderID string, amount int64) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -177,13 +241,14 @@ func (r *auctionRepository) CompleteAuction(ctx context.Context, auctionID int64
 	}
 
 	// Update the auction status with retry logic
-	result, err := tx.NewUpdate().
+	now := time.Now()
+	update := tx.NewUpdate().
 		Model((*models.Auction)(nil)).
 		Set("status = ?", models.AuctionStatusCompleted).
-		Set("updated_at = ?", time.Now()).
+		Set("updated_at = ?", now).
 		Where("id = ?", auctionID).
-		Where("status = ?", models.AuctionStatusActive).
-		Exec(ctx)
+		Where("status = ?", models.AuctionStatusActive)
+	result, err := RecordFinalSale(update, now).Exec(ctx)
 
 	if err != nil {
 		return fmt.Errorf("failed to complete auction: %w", err)
@@ -426,25 +491,21 @@ func (r *auctionRepository) CompleteAuctionWithTransfer(ctx context.Context, auc
 			}
 		}
 
-		// Transfer currency to seller
-		_, err = tx.NewUpdate().
-			Model((*models.User)(nil)).
-			Set("balance = balance + ?", auction.CurrentPrice).
-			Where("id = ?", auction.SellerID).
-			Exec(ctx)
-
-		if err != nil {
-			return fmt.Errorf("failed to transfer balance to seller: %w", err)
+		// Transfer currency to seller less the commission
+		if err := r.paySeller(ctx, tx, auction); err != nil {
+			return err
 		}
 	}
 
 	// Update auction status
-	_, err = tx.NewUpdate().
+	now := time.Now()
+	update := tx.NewUpdate().
 		Model(auction).
 		Set("status = ?", models.AuctionStatusCompleted).
-		Set("updated_at = ?", time.Now()).
-		Where("id = ?", auctionID).
-		Exec(ctx)
+		Set("commission = ?", auction.Commission).
+		Set("updated_at = ?", now).
+		Where("id = ?", auctionID)
+	_, err = RecordFinalSale(update, now).Exec(ctx)
 
 	if err != nil {
 		return fmt.Errorf("failed to update auction status: %w", err)
@@ -536,12 +597,14 @@ func (r *auctionRepository) CompleteAuctionWithTransferAndGet(ctx context.Contex
 	}
 
 	// Update auction status
-	_, err = tx.NewUpdate().
+	now := time.Now()
+	update := tx.NewUpdate().
 		Model(auction).
 		Set("status = ?", models.AuctionStatusCompleted).
-		Set("updated_at = ?", time.Now()).
-		Where("id = ?", auctionID).
-		Exec(ctx)
+		Set("commission = ?", auction.Commission).
+		Set("updated_at = ?", now).
+		Where("id = ?", auctionID)
+	_, err = RecordFinalSale(update, now).Exec(ctx)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to update auction status: %w", err)
@@ -596,15 +659,9 @@ func (r *auctionRepository) handleWinningBidTransfer(ctx context.Context, tx bun
 		}
 	}
 
-	// Transfer currency to seller - Fixed the WHERE clause to use discord_id
-	_, err = tx.NewUpdate().
-		Model((*models.User)(nil)).
-		Set("balance = balance + ?", auction.CurrentPrice).
-		Where("discord_id = ?", auction.SellerID). // Changed from id to discord_id
-		Exec(ctx)
-
-	if err != nil {
-		return fmt.Errorf("failed to transfer balance to seller: %w", err)
+	// Transfer currency to seller less the commission
+	if err := r.paySeller(ctx, tx, auction); err != nil {
+		return err
 	}
 
 	// Verify the balance transfer
@@ -621,11 +678,28 @@ func (r *auctionRepository) handleWinningBidTransfer(ctx context.Context, tx bun
 	slog.Info("Successfully transferred auction proceeds",
 		slog.String("seller_id", auction.SellerID),
 		slog.Int64("amount", auction.CurrentPrice),
+		slog.Int64("commission", auction.Commission),
 		slog.Int64("new_balance", seller.Balance))
 
 	return nil
 }
 
+// paySeller pays the winning bid to the seller less the commission rate the auction
+// was listed with, and records the commission on the auction
+func (r *auctionRepository) paySeller(ctx context.Context, tx bun.Tx, auction *models.Auction) error {
+	commission, err := r.txManager.PaySeller(ctx, tx, economicUtils.SalePayoutOptions{
+		SellerID:      auction.SellerID,
+		Price:         auction.CurrentPrice,
+		CommissionBps: auction.CommissionBps,
+		Reference:     auction.AuctionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to transfer balance to seller: %w", err)
+	}
+	auction.Commission = commission
+	return nil
+}
+
 func (r *auctionRepository) GetRecentCompletedAuctions(ctx context.Context, cardID int64, limit int) ([]*models.Auction, error) {
 	var auctions []*models.Auction
 	err := r.db.NewSelect().
@@ -644,6 +718,23 @@ func (r *auctionRepository) GetRecentCompletedAuctions(ctx context.Context, card
 	return auctions, nil
 }
 
+func (r *auctionRepository) GetSoldHistory(ctx context.Context, cardID int64, since time.Time) ([]AuctionSale, error) {
+	var sales []AuctionSale
+	err := r.db.NewSelect().
+		Model((*models.Auction)(nil)).
+		Column("auction_id", "final_price", "sold_at").
+		Where("card_id = ?", cardID).
+		Where("status = ?", models.AuctionStatusCompleted).
+		Where("sold_at >= ?", since).
+		Where("final_price > 0").
+		Order("sold_at DESC").
+		Scan(ctx, &sales)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auction sale history: %w", err)
+	}
+	return sales, nil
+}
+
 func (r *auctionRepository) AuctionIDExists(ctx context.Context, auctionID string) (bool, error) {
 	exists, err := r.db.NewSelect().
 		Model((*models.Auction)(nil)).
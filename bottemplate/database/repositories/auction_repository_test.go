@@ -0,0 +1,119 @@
+package repositories_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+func TestSearchActiveAuctions(t *testing.T) {
+	db := dbtest.Open(t)
+	auctions := repositories.NewAuctionRepository(db.BunDB())
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestCard(t, db, 1, "momo", "twice", 3)
+	createTestCard(t, db, 2, "sana", "twice", 1)
+	createTestCard(t, db, 3, "wonyoung", "ive", 3)
+
+	create := func(auctionID string, cardID int64, ends time.Duration) *models.Auction {
+		t.Helper()
+		auction := &models.Auction{AuctionID: auctionID, CardID: cardID, SellerID: "seller", StartPrice: 100, CurrentPrice: 100, MinIncrement: 10, StartTime: now, EndTime: now.Add(ends)}
+		if err := auctions.Create(ctx, auction); err != nil {
+			t.Fatalf("Create %s: %v", auctionID, err)
+		}
+		return auction
+	}
+	create("MOMO2", 1, 2*time.Hour)
+	create("MOMO1", 1, time.Hour)
+	create("SANA", 2, 30*time.Minute)
+	create("WONY", 3, 3*time.Hour)
+	create("PAST", 2, -time.Minute)
+	sold := create("SOLD", 3, time.Hour)
+	if _, err := db.BunDB().NewUpdate().Model((*models.Auction)(nil)).
+		Set("status = ?", models.AuctionStatusCompleted).
+		Where("id = ?", sold.ID).
+		Exec(ctx); err != nil {
+		t.Fatalf("complete auction: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		// Ended and closed auctions never show, the rest end soonest first
+		{query: "", want: []string{"SANA", "MOMO1", "MOMO2", "WONY"}},
+		{query: "-3", want: []string{"MOMO1", "MOMO2", "WONY"}},
+		{query: "-twice", want: []string{"SANA", "MOMO1", "MOMO2"}},
+		{query: "momo", want: []string{"MOMO1", "MOMO2"}},
+		{query: "tzuyu", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := auctions.SearchActive(ctx, utils.ParseSearchQuery(tt.query))
+			if err != nil {
+				t.Fatalf("SearchActive: %v", err)
+			}
+			var got []string
+			for _, auction := range results {
+				if auction.Card == nil || auction.Card.ID != auction.CardID {
+					t.Errorf("%s card not loaded", auction.AuctionID)
+				}
+				got = append(got, auction.AuctionID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchActive(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSoldHistory(t *testing.T) {
+	db := dbtest.Open(t)
+	auctions := repositories.NewAuctionRepository(db.BunDB())
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestCard(t, db, 1, "momo", "twice", 3)
+	closeAuction := func(auctionID, topBidder string, price int64, soldAt time.Time) {
+		t.Helper()
+		auction := &models.Auction{AuctionID: auctionID, CardID: 1, SellerID: "seller", StartPrice: 100, CurrentPrice: price, MinIncrement: 10, TopBidderID: topBidder, StartTime: now, EndTime: now}
+		if err := auctions.Create(ctx, auction); err != nil {
+			t.Fatalf("Create %s: %v", auctionID, err)
+		}
+		update := db.BunDB().NewUpdate().
+			Model((*models.Auction)(nil)).
+			Set("status = ?", models.AuctionStatusCompleted).
+			Where("id = ?", auction.ID)
+		if _, err := repositories.RecordFinalSale(update, soldAt).Exec(ctx); err != nil {
+			t.Fatalf("close %s: %v", auctionID, err)
+		}
+	}
+	closeAuction("OLD", "b1", 900, now.Add(-40*24*time.Hour))
+	closeAuction("FIRST", "b1", 300, now.Add(-2*time.Hour))
+	closeAuction("LATEST", "b2", 500, now.Add(-time.Hour))
+	// Auctions without a winner are not sales
+	closeAuction("UNSOLD", "", 100, now)
+
+	sold, err := auctions.GetSoldHistory(ctx, 1, now.Add(-30*24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetSoldHistory: %v", err)
+	}
+	if len(sold) != 2 || sold[0].AuctionID != "LATEST" || sold[0].FinalPrice != 500 || sold[1].AuctionID != "FIRST" {
+		t.Errorf("sold = %+v, want LATEST then FIRST", sold)
+	}
+
+	unsold, err := auctions.GetByAuctionID(ctx, "UNSOLD")
+	if err != nil {
+		t.Fatalf("GetByAuctionID: %v", err)
+	}
+	if unsold.FinalPrice != 0 || !unsold.SoldAt.IsZero() {
+		t.Errorf("unsold auction recorded %d at %v", unsold.FinalPrice, unsold.SoldAt)
+	}
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/uptrace/bun"
+)
+
+type AuctionWatcherRepository interface {
+	// Watch adds the user to the auction's watchers. It reports false if they already were.
+	Watch(ctx context.Context, auctionID int64, userID string) (bool, error)
+	// Unwatch removes the user from the auction's watchers. It reports false if they were not watching.
+	Unwatch(ctx context.Context, auctionID int64, userID string) (bool, error)
+	// GetWatchers returns the IDs of everyone watching the auction
+	GetWatchers(ctx context.Context, auctionID int64) ([]string, error)
+	// ClaimEndingSoon marks and returns the watchers of active auctions ending before the
+	// given time that have not yet been told, so each watcher is only told once
+	ClaimEndingSoon(ctx context.Context, before time.Time) ([]*models.AuctionWatcher, error)
+	// Clear removes all watchers of an auction once it is over
+	Clear(ctx context.Context, auctionID int64) error
+}
+
+type auctionWatcherRepository struct {
+	db *bun.DB
+}
+
+func NewAuctionWatcherRepository(db *bun.DB) AuctionWatcherRepository {
+	return &auctionWatcherRepository{db: db}
+}
+
+func (r *auctionWatcherRepository) Watch(ctx context.Context, auctionID int64, userID string) (bool, error) {
+	watcher := &models.AuctionWatcher{
+		AuctionID: auctionID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+	res, err := r.db.NewInsert().
+		Model(watcher).
+		On("CONFLICT (auction_id, user_id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to watch auction: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to watch auction: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *auctionWatcherRepository) Unwatch(ctx context.Context, auctionID int64, userID string) (bool, error) {
+	res, err := r.db.NewDelete().
+		Model((*models.AuctionWatcher)(nil)).
+		Where("auction_id = ? AND user_id = ?", auctionID, userID).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to unwatch auction: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to unwatch auction: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *auctionWatcherRepository) GetWatchers(ctx context.Context, auctionID int64) ([]string, error) {
+	var userIDs []string
+	err := r.db.NewSelect().
+		Model((*models.AuctionWatcher)(nil)).
+		Column("user_id").
+		Where("auction_id = ?", auctionID).
+		Order("created_at ASC").
+		Scan(ctx, &userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auction watchers: %w", err)
+	}
+	return userIDs, nil
+}
+
+func (r *auctionWatcherRepository) ClaimEndingSoon(ctx context.Context, before time.Time) ([]*models.AuctionWatcher, error) {
+	var watchers []*models.AuctionWatcher
+	err := r.db.NewUpdate().
+		Model((*models.AuctionWatcher)(nil)).
+		Set("ending_notified = true").
+		TableExpr("auctions AS a").
+		Where("a.id = aw.auction_id").
+		Where("a.status = ?", models.AuctionStatusActive).
+		Where("a.end_time <= ?", before).
+		Where("aw.ending_notified = false").
+		Returning("aw.*").
+		Scan(ctx, &watchers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim ending auction watchers: %w", err)
+	}
+	return watchers, nil
+}
+
+func (r *auctionWatcherRepository) Clear(ctx context.Context, auctionID int64) error {
+	_, err := r.db.NewDelete().
+		Model((*models.AuctionWatcher)(nil)).
+		Where("auction_id = ?", auctionID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to clear auction watchers: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package repositories_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestAuctionWatchers(t *testing.T) {
+	db := dbtest.Open(t)
+	auctions := repositories.NewAuctionRepository(db.BunDB())
+	watchers := repositories.NewAuctionWatcherRepository(db.BunDB())
+	ctx := context.Background()
+	now := time.Now()
+
+	createTestCard(t, db, 1, "momo", "twice", 3)
+	endingSoon := &models.Auction{AuctionID: "ENDS", CardID: 1, SellerID: "seller", StartPrice: 100, CurrentPrice: 100, MinIncrement: 10, StartTime: now, EndTime: now.Add(5 * time.Minute)}
+	later := &models.Auction{AuctionID: "LATER", CardID: 1, SellerID: "seller", StartPrice: 100, CurrentPrice: 100, MinIncrement: 10, StartTime: now, EndTime: now.Add(time.Hour)}
+	for _, auction := range []*models.Auction{endingSoon, later} {
+		if err := auctions.Create(ctx, auction); err != nil {
+			t.Fatalf("Create auction: %v", err)
+		}
+	}
+
+	for _, w := range []struct {
+		auctionID int64
+		userID    string
+	}{{endingSoon.ID, "w1"}, {endingSoon.ID, "w2"}, {later.ID, "w1"}} {
+		if added, err := watchers.Watch(ctx, w.auctionID, w.userID); err != nil || !added {
+			t.Fatalf("Watch(%d, %s) = %t, %v", w.auctionID, w.userID, added, err)
+		}
+	}
+	if added, err := watchers.Watch(ctx, endingSoon.ID, "w1"); err != nil || added {
+		t.Errorf("second Watch = %t, %v, want false", added, err)
+	}
+
+	if removed, err := watchers.Unwatch(ctx, endingSoon.ID, "w2"); err != nil || !removed {
+		t.Errorf("Unwatch = %t, %v, want true", removed, err)
+	}
+	if removed, _ := watchers.Unwatch(ctx, endingSoon.ID, "w2"); removed {
+		t.Error("second Unwatch removed a watcher")
+	}
+	if got, err := watchers.GetWatchers(ctx, endingSoon.ID); err != nil || !reflect.DeepEqual(got, []string{"w1"}) {
+		t.Errorf("GetWatchers = %v, %v, want [w1]", got, err)
+	}
+
+	// Only the auction inside the window is claimed, and only once
+	claimed, err := watchers.ClaimEndingSoon(ctx, now.Add(15*time.Minute))
+	if err != nil {
+		t.Fatalf("ClaimEndingSoon: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].AuctionID != endingSoon.ID || claimed[0].UserID != "w1" {
+		t.Errorf("claimed = %+v, want w1 on the ending auction", claimed)
+	}
+	if claimed, err := watchers.ClaimEndingSoon(ctx, now.Add(15*time.Minute)); err != nil || len(claimed) != 0 {
+		t.Errorf("second claim = %d watchers, %v, want none", len(claimed), err)
+	}
+
+	if err := watchers.Clear(ctx, endingSoon.ID); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if got, _ := watchers.GetWatchers(ctx, endingSoon.ID); len(got) != 0 {
+		t.Errorf("watchers after Clear = %v", got)
+	}
+	if got, _ := watchers.GetWatchers(ctx, later.ID); len(got) != 1 {
+		t.Errorf("Clear removed other auctions' watchers: %v", got)
+	}
+}
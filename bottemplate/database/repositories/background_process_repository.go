@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/uptrace/bun"
+)
+
+type BackgroundProcessRepository interface {
+	SaveSnapshots(ctx context.Context, processes []*models.BackgroundProcess) error
+	List(ctx context.Context) ([]*models.BackgroundProcess, error)
+	RequestRestart(ctx context.Context, name string) error
+	TakeRestartRequests(ctx context.Context) ([]string, error)
+}
+
+type backgroundProcessRepository struct {
+	*BaseRepository
+}
+
+func NewBackgroundProcessRepository(db *bun.DB) BackgroundProcessRepository {
+	return &backgroundProcessRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// SaveSnapshots upserts the reported state of each process, leaving pending
+// restart requests untouched
+func (r *backgroundProcessRepository) SaveSnapshots(ctx context.Context, processes []*models.BackgroundProcess) error {
+	if len(processes) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, process := range processes {
+		process.UpdatedAt = now
+	}
+
+	_, err := r.ExecWithTimeout(ctx, "save", "background_processes", func(ctx context.Context) (sql.Result, error) {
+		return r.GetDB().NewInsert().
+			Model(&processes).
+			On("CONFLICT (name) DO UPDATE").
+			Set("description = EXCLUDED.description").
+			Set("status = EXCLUDED.status").
+			Set("started_at = EXCLUDED.started_at").
+			Set("restarts = EXCLUDED.restarts").
+			Set("last_run = EXCLUDED.last_run").
+			Set("last_success = EXCLUDED.last_success").
+			Set("last_error = EXCLUDED.last_error").
+			Set("last_error_at = EXCLUDED.last_error_at").
+			Set("updated_at = EXCLUDED.updated_at").
+			Exec(ctx)
+	})
+	return err
+}
+
+func (r *backgroundProcessRepository) List(ctx context.Context) ([]*models.BackgroundProcess, error) {
+	var processes []*models.BackgroundProcess
+	err := r.SelectWithTimeout(ctx, "list", "background_processes", func(ctx context.Context) error {
+		return r.GetDB().NewSelect().
+			Model(&processes).
+			Order("name ASC").
+			Scan(ctx)
+	})
+	return processes, err
+}
+
+// RequestRestart flags a process for the bot to restart on its next sync
+func (r *backgroundProcessRepository) RequestRestart(ctx context.Context, name string) error {
+	result, err := r.ExecWithTimeout(ctx, "request_restart", "background_processes", func(ctx context.Context) (sql.Result, error) {
+		return r.GetDB().NewUpdate().
+			Model((*models.BackgroundProcess)(nil)).
+			Set("restart_requested = true").
+			Where("name = ?", name).
+			Exec(ctx)
+	})
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return &NotFoundError{Entity: "background_process", ID: name}
+	}
+	return nil
+}
+
+// TakeRestartRequests clears and returns the names of processes flagged for restart
+func (r *backgroundProcessRepository) TakeRestartRequests(ctx context.Context) ([]string, error) {
+	var names []string
+	err := r.SelectWithTimeout(ctx, "take_restart_requests", "background_processes", func(ctx context.Context) error {
+		return r.GetDB().NewUpdate().
+			Model((*models.BackgroundProcess)(nil)).
+			Set("restart_requested = false").
+			Where("restart_requested = true").
+			Returning("name").
+			Scan(ctx, &names)
+	})
+	if err != nil && !IsNotFound(err) {
+		return nil, fmt.Errorf("failed to take restart requests: %w", err)
+	}
+	return names, nil
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -60,6 +61,16 @@ func (ce *ConflictError) Error() string {
 	return fmt.Sprintf("%s with %s %v already exists", ce.Entity, ce.Field, ce.Value)
 }
 
+// ErrStaleUpdate is returned by conditional updates when the row changed since the caller read it
+var ErrStaleUpdate = errors.New("row was modified since it was read")
+
+// whereUnchangedSince limits an update to rows whose updated_at still equals expected.
+// Timestamps are compared at millisecond precision because admin clients round-trip
+// them through JavaScript dates.
+func whereUnchangedSince(q *bun.UpdateQuery, expected time.Time) *bun.UpdateQuery {
+	return q.Where("date_trunc('milliseconds', updated_at) = date_trunc('milliseconds', ?::timestamptz)", expected)
+}
+
 // WithTimeout creates a context with the default timeout
 func (br *BaseRepository) WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(ctx, br.defaultTimeout)
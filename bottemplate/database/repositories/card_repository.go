@@ -21,16 +21,23 @@ const (
 type CardRepository interface {
 	Create(ctx context.Context, card *models.Card) error
 	GetByID(ctx context.Context, id int64) (*models.Card, error)
+	GetByIDWithDeleted(ctx context.Context, id int64) (*models.Card, error)
 	GetByName(ctx context.Context, name string) ([]*models.Card, error)
 	GetAll(ctx context.Context) ([]*models.Card, error)
 	GetByCollectionID(ctx context.Context, colID string) ([]*models.Card, error)
+	GetAllWithDeleted(ctx context.Context) ([]*models.Card, error)
+	GetByCollectionIDWithDeleted(ctx context.Context, colID string) ([]*models.Card, error)
 	Update(ctx context.Context, card *models.Card) error
+	UpdateIfUnchanged(ctx context.Context, card *models.Card, expectedUpdatedAt time.Time) error
 	Delete(ctx context.Context, id int64) error
 	GetByTag(ctx context.Context, tag string) ([]*models.Card, error)
 	BulkCreate(ctx context.Context, cards []*models.Card) (int, error)
 	GetByLevel(ctx context.Context, level int) ([]*models.Card, error)
 	GetAnimated(ctx context.Context) ([]*models.Card, error)
 	SafeDelete(ctx context.Context, cardID int64) (*models.DeletionReport, error)
+	SoftDelete(ctx context.Context, cardID int64) (bool, error)
+	Restore(ctx context.Context, cardID int64) (bool, error)
+	CountCopies(ctx context.Context, cardID int64) (owners int, copies int64, err error)
 	Search(ctx context.Context, filters SearchFilters, offset, limit int) ([]*models.Card, int, error)
 	UpdateUserCard(ctx context.Context, userCard *models.UserCard) error
 	DeleteUserCard(ctx context.Context, id int64) error
@@ -102,6 +109,20 @@ func (r *cardRepository) GetByID(ctx context.Context, id int64) (*models.Card, e
 	return card, err
 }
 
+// GetByIDWithDeleted is GetByID that also finds soft-deleted cards. It bypasses the cache.
+func (r *cardRepository) GetByIDWithDeleted(ctx context.Context, id int64) (*models.Card, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	card := new(models.Card)
+	err := r.db.NewSelect().
+		Model(card).
+		Where("id = ?", id).
+		WhereAllWithDeleted().
+		Scan(ctx)
+	return card, err
+}
+
 func (r *cardRepository) GetByName(ctx context.Context, name string) ([]*models.Card, error) {
 	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
 	defer cancel()
@@ -171,6 +192,36 @@ func (r *cardRepository) GetByCollectionID(ctx context.Context, colID string) ([
 	return cards, err
 }
 
+// GetAllWithDeleted is GetAll that also returns soft-deleted cards. It bypasses the cache.
+func (r *cardRepository) GetAllWithDeleted(ctx context.Context) ([]*models.Card, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	var cards []*models.Card
+	err := r.db.NewSelect().
+		Model(&cards).
+		WhereAllWithDeleted().
+		Order("id ASC").
+		Scan(ctx)
+	return cards, err
+}
+
+// GetByCollectionIDWithDeleted is GetByCollectionID that also returns soft-deleted cards.
+// It bypasses the cache.
+func (r *cardRepository) GetByCollectionIDWithDeleted(ctx context.Context, colID string) ([]*models.Card, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	var cards []*models.Card
+	err := r.db.NewSelect().
+		Model(&cards).
+		Where("col_id = ?", colID).
+		WhereAllWithDeleted().
+		Order("id ASC").
+		Scan(ctx)
+	return cards, err
+}
+
 func (r *cardRepository) Update(ctx context.Context, card *models.Card) error {
 	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
 	defer cancel()
@@ -189,6 +240,27 @@ func (r *cardRepository) Update(ctx context.Context, card *models.Card) error {
 	return err
 }
 
+// UpdateIfUnchanged is Update that fails with ErrStaleUpdate when the stored card's
+// updated_at no longer equals expectedUpdatedAt
+func (r *cardRepository) UpdateIfUnchanged(ctx context.Context, card *models.Card, expectedUpdatedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	card.UpdatedAt = time.Now()
+
+	result, err := whereUnchangedSince(r.db.NewUpdate().Model(card).WherePK(), expectedUpdatedAt).Exec(ctx)
+
+	// The caller may have modified a cached card, so drop it even when nothing was written
+	r.invalidateCache(card.ID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrStaleUpdate
+	}
+	return nil
+}
+
 func (r *cardRepository) Delete(ctx context.Context, id int64) error {
 	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
 	defer cancel()
@@ -196,6 +268,7 @@ func (r *cardRepository) Delete(ctx context.Context, id int64) error {
 	_, err := r.db.NewDelete().
 		Model((*models.Card)(nil)).
 		Where("id = ?", id).
+		ForceDelete().
 		Exec(ctx)
 
 	if err == nil {
@@ -339,34 +412,53 @@ func (r *cardRepository) SafeDelete(ctx context.Context, cardID int64) (*models.
 	}
 	defer tx.Rollback()
 
-	// Get card details before deletion
+	// Get card details before deletion, including soft-deleted cards
 	card := new(models.Card)
 	err = tx.NewSelect().
 		Model(card).
 		Where("id = ?", cardID).
+		WhereAllWithDeleted().
 		Scan(ctx)
 
 	if err != nil {
 		return report, fmt.Errorf("card not found: %w", err)
 	}
 
-	// Delete user_cards entries
-	result, err := tx.NewDelete().
+	// Delete user_cards entries, recording how many copies were removed
+	var removedAmounts []int64
+	err = tx.NewDelete().
 		Model((*models.UserCard)(nil)).
 		Where("card_id = ?", cardID).
-		Exec(ctx)
+		Returning("amount").
+		Scan(ctx, &removedAmounts)
 
 	if err != nil {
 		return report, fmt.Errorf("failed to delete user cards: %w", err)
 	}
 
-	affected, _ := result.RowsAffected()
-	report.UserCardsDeleted = int(affected)
+	report.UserCardsDeleted = len(removedAmounts)
+	for _, amount := range removedAmounts {
+		report.CopiesDeleted += amount
+	}
+
+	// Delete wishlist entries pointing at the card
+	result, err := tx.NewDelete().
+		Model((*models.Wishlist)(nil)).
+		Where("card_id = ?", cardID).
+		Exec(ctx)
+
+	if err != nil {
+		return report, fmt.Errorf("failed to delete wishlist entries: %w", err)
+	}
+
+	wishlistsAffected, _ := result.RowsAffected()
+	report.WishlistsDeleted = int(wishlistsAffected)
 
 	// Delete the card
 	result, err = tx.NewDelete().
 		Model((*models.Card)(nil)).
 		Where("id = ?", cardID).
+		ForceDelete().
 		Exec(ctx)
 
 	if err != nil {
@@ -390,6 +482,74 @@ func (r *cardRepository) SafeDelete(ctx context.Context, cardID int64) (*models.
 	return report, nil
 }
 
+// SoftDelete hides a card from every model query by setting deleted_at. Owned copies and
+// wishlist entries are kept. It reports false when the card is missing or already deleted.
+func (r *cardRepository) SoftDelete(ctx context.Context, cardID int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	result, err := r.db.NewDelete().
+		Model((*models.Card)(nil)).
+		Where("id = ?", cardID).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to soft-delete card: %w", err)
+	}
+
+	affected, _ := result.RowsAffected()
+	if affected > 0 {
+		r.invalidateCache(cardID)
+	}
+	return affected > 0, nil
+}
+
+// Restore clears deleted_at on a soft-deleted card. It reports false when the card is
+// missing or not deleted.
+func (r *cardRepository) Restore(ctx context.Context, cardID int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	result, err := r.db.NewUpdate().
+		Model((*models.Card)(nil)).
+		WhereDeleted().
+		Set("deleted_at = NULL").
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", cardID).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore card: %w", err)
+	}
+
+	affected, _ := result.RowsAffected()
+	if affected > 0 {
+		r.invalidateCache(cardID)
+	}
+	return affected > 0, nil
+}
+
+// CountCopies returns how many users own the card and the total number of copies they hold
+func (r *cardRepository) CountCopies(ctx context.Context, cardID int64) (int, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	var counts struct {
+		Owners int   `bun:"owners"`
+		Copies int64 `bun:"copies"`
+	}
+	err := r.db.NewSelect().
+		Model((*models.UserCard)(nil)).
+		ColumnExpr("COUNT(DISTINCT user_id) AS owners").
+		ColumnExpr("COALESCE(SUM(amount), 0) AS copies").
+		Where("card_id = ?", cardID).
+		Where("amount > 0").
+		Scan(ctx, &counts)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count card copies: %w", err)
+	}
+
+	return counts.Owners, counts.Copies, nil
+}
+
 // First, let's improve the cache key generation
 func generateCacheKey(filters SearchFilters, offset, limit int) string {
 	return fmt.Sprintf("search:name=%s:id=%d:level=%d:col=%s:type=%s:animated=%v:offset=%d:limit=%d",
@@ -585,6 +745,7 @@ func (r *cardRepository) invalidateCache(cardID int64) {
 	r.cache.Range(func(key, _ interface{}) bool {
 		keyStr := key.(string)
 		if strings.HasPrefix(keyStr, "search:") ||
+			strings.HasPrefix(keyStr, "count:") ||
 			strings.HasPrefix(keyStr, "collection:") ||
 			strings.HasPrefix(keyStr, "level:") {
 			r.cache.Delete(key)
@@ -668,11 +829,15 @@ func (r *cardRepository) GetAllByUserID(ctx context.Context, userID string) ([]*
 	return mergeDuplicateUserCards(userCards), nil
 }
 
+// GetByIDs loads many cards in one query; ids that don't exist are simply absent from the result
 func (r *cardRepository) GetByIDs(ctx context.Context, ids []int64) ([]*models.Card, error) {
 	if len(ids) == 0 {
 		return nil, nil
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
 	var cards []*models.Card
 	err := r.db.NewSelect().
 		Model(&cards).
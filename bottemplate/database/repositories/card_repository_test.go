@@ -0,0 +1,162 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestSafeDeleteRemovesCopiesAndWishlists(t *testing.T) {
+	db := dbtest.Open(t)
+	cards := repositories.NewCardRepository(db.BunDB())
+	ctx := context.Background()
+
+	createTestCard(t, db, 1, "nayeon", "twice", 1)
+	createTestCard(t, db, 2, "jeongyeon", "twice", 1)
+	giveTestCard(t, db, "u1", 1, 3)
+	giveTestCard(t, db, "u2", 1, 2)
+	giveTestCard(t, db, "u1", 2, 1)
+	wishlists := repositories.NewWishlistRepository(db.BunDB())
+	for _, userID := range []string{"u3", "u4"} {
+		if err := wishlists.Add(ctx, userID, 1); err != nil {
+			t.Fatalf("wishlist add: %v", err)
+		}
+	}
+
+	owners, copies, err := cards.CountCopies(ctx, 1)
+	if err != nil || owners != 2 || copies != 5 {
+		t.Fatalf("CountCopies = %d, %d, %v, want 2 owners and 5 copies", owners, copies, err)
+	}
+
+	report, err := cards.SafeDelete(ctx, 1)
+	if err != nil {
+		t.Fatalf("SafeDelete: %v", err)
+	}
+	want := models.DeletionReport{CardID: 1, UserCardsDeleted: 2, CopiesDeleted: 5, WishlistsDeleted: 2, CardDeleted: true}
+	if *report != want {
+		t.Errorf("report = %+v, want %+v", *report, want)
+	}
+
+	var orphans int
+	for _, table := range []string{"user_cards", "wishlists"} {
+		count, err := db.BunDB().NewSelect().Table(table).Where("card_id = ?", 1).Count(ctx)
+		if err != nil {
+			t.Fatalf("count %s: %v", table, err)
+		}
+		orphans += count
+	}
+	if orphans != 0 {
+		t.Errorf("%d rows still point at the deleted card", orphans)
+	}
+
+	// Other cards are untouched
+	if owners, _, _ := cards.CountCopies(ctx, 2); owners != 1 {
+		t.Errorf("card 2 owners = %d, want 1", owners)
+	}
+}
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	db := dbtest.Open(t)
+	cards := repositories.NewCardRepository(db.BunDB())
+	ctx := context.Background()
+
+	createTestCard(t, db, 1, "nayeon", "twice", 1)
+	createTestCard(t, db, 2, "momo", "twice", 1)
+	giveTestCard(t, db, "u1", 1, 2)
+	// Warm the cache so soft-deleting has to invalidate it
+	if _, err := cards.GetByID(ctx, 1); err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	deleted, err := cards.SoftDelete(ctx, 1)
+	if err != nil || !deleted {
+		t.Fatalf("SoftDelete = %t, %v, want true", deleted, err)
+	}
+	if _, err := cards.GetByID(ctx, 1); err == nil {
+		t.Error("GetByID still finds the soft-deleted card")
+	}
+	if all, err := cards.GetAll(ctx); err != nil || len(all) != 1 || all[0].ID != 2 {
+		t.Errorf("GetAll = %d cards, %v, want only card 2", len(all), err)
+	}
+	card, err := cards.GetByIDWithDeleted(ctx, 1)
+	if err != nil || card.DeletedAt.IsZero() {
+		t.Errorf("GetByIDWithDeleted = %+v, %v, want the card with deleted_at set", card, err)
+	}
+	if all, err := cards.GetAllWithDeleted(ctx); err != nil || len(all) != 2 {
+		t.Errorf("GetAllWithDeleted = %d cards, %v, want both", len(all), err)
+	}
+	if twice, err := cards.GetByCollectionIDWithDeleted(ctx, "twice"); err != nil || len(twice) != 2 {
+		t.Errorf("GetByCollectionIDWithDeleted = %d cards, %v, want both", len(twice), err)
+	}
+	if owners, copies, _ := cards.CountCopies(ctx, 1); owners != 1 || copies != 2 {
+		t.Errorf("copies after soft delete = %d owners, %d copies, want them kept", owners, copies)
+	}
+
+	// Repeating either step is a no-op
+	if deleted, err := cards.SoftDelete(ctx, 1); err != nil || deleted {
+		t.Errorf("second SoftDelete = %t, %v, want false", deleted, err)
+	}
+	if restored, err := cards.Restore(ctx, 1); err != nil || !restored {
+		t.Fatalf("Restore = %t, %v, want true", restored, err)
+	}
+	if restored, err := cards.Restore(ctx, 1); err != nil || restored {
+		t.Errorf("second Restore = %t, %v, want false", restored, err)
+	}
+	if card, err := cards.GetByID(ctx, 1); err != nil || !card.DeletedAt.IsZero() {
+		t.Errorf("GetByID after restore = %+v, %v", card, err)
+	}
+
+	// A hard delete still reaches a soft-deleted card
+	if _, err := cards.SoftDelete(ctx, 2); err != nil {
+		t.Fatalf("SoftDelete(2): %v", err)
+	}
+	report, err := cards.SafeDelete(ctx, 2)
+	if err != nil || !report.CardDeleted {
+		t.Errorf("SafeDelete of a soft-deleted card = %+v, %v", report, err)
+	}
+	if _, err := cards.GetByIDWithDeleted(ctx, 2); err == nil {
+		t.Error("card 2 still exists after the hard delete")
+	}
+}
+
+func TestUpdateIfUnchangedRejectsStaleWrites(t *testing.T) {
+	db := dbtest.Open(t)
+	cards := repositories.NewCardRepository(db.BunDB())
+	ctx := context.Background()
+
+	createTestCard(t, db, 1, "nayeon", "twice", 1)
+	card, err := cards.GetByIDWithDeleted(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByIDWithDeleted: %v", err)
+	}
+	// Clients send the timestamp back through a JavaScript date, keeping only milliseconds
+	read := card.UpdatedAt.Truncate(time.Millisecond)
+
+	first := *card
+	first.Level = 2
+	if err := cards.UpdateIfUnchanged(ctx, &first, read); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+
+	// A second client still holding the old timestamp loses
+	second := *card
+	second.Level = 3
+	if err := cards.UpdateIfUnchanged(ctx, &second, read); !errors.Is(err, repositories.ErrStaleUpdate) {
+		t.Fatalf("stale update err = %v, want ErrStaleUpdate", err)
+	}
+	stored, err := cards.GetByID(ctx, 1)
+	if err != nil || stored.Level != 2 {
+		t.Errorf("stored level = %d, %v, want the first write's 2", stored.Level, err)
+	}
+
+	// Reading again picks up the new timestamp
+	second.Level = 3
+	if err := cards.UpdateIfUnchanged(ctx, &second, stored.UpdatedAt); err != nil {
+		t.Errorf("update after reload: %v", err)
+	}
+}
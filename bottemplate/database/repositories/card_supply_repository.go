@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+// ErrCardSoldOut is returned when minting would push a card past its supply cap
+var ErrCardSoldOut = errors.New("card supply exhausted")
+
+// SupplyStatus is the effective cap and minted total of a supply-capped card
+type SupplyStatus struct {
+	CardID    int64 `bun:"card_id"`
+	MaxCopies int64 `bun:"max_copies"`
+	Minted    int64 `bun:"minted"`
+}
+
+// Remaining returns how many copies can still be minted
+func (s *SupplyStatus) Remaining() int64 {
+	if s.Minted >= s.MaxCopies {
+		return 0
+	}
+	return s.MaxCopies - s.Minted
+}
+
+type CardSupplyRepository interface {
+	GetStatuses(ctx context.Context, cardIDs []int64) (map[int64]*SupplyStatus, error)
+	Reserve(ctx context.Context, db bun.IDB, cardID int64, amount int64) (*SupplyStatus, error)
+}
+
+type cardSupplyRepository struct {
+	db *bun.DB
+}
+
+func NewCardSupplyRepository(db *bun.DB) CardSupplyRepository {
+	return &cardSupplyRepository{db: db}
+}
+
+// supplyStatusQuery resolves each card's cap, preferring its own max_copies over the collection's
+const supplyStatusQuery = `
+	SELECT
+		c.id AS card_id,
+		CASE WHEN c.max_copies > 0 THEN c.max_copies ELSE col.max_copies END AS max_copies,
+		COALESCE(cs.minted, 0) AS minted
+	FROM cards c
+	JOIN collections col ON col.id = c.col_id
+	LEFT JOIN card_supply cs ON cs.card_id = c.id
+	WHERE (c.max_copies > 0 OR col.max_copies > 0)`
+
+// GetStatuses returns the supply of capped cards, keyed by card ID. Uncapped
+// cards are left out; passing no IDs returns every capped card.
+func (r *cardSupplyRepository) GetStatuses(ctx context.Context, cardIDs []int64) (map[int64]*SupplyStatus, error) {
+	var statuses []*SupplyStatus
+	var err error
+	if len(cardIDs) == 0 {
+		err = r.db.NewRaw(supplyStatusQuery).Scan(ctx, &statuses)
+	} else {
+		err = r.db.NewRaw(supplyStatusQuery+" AND c.id IN (?)", bun.In(cardIDs)).Scan(ctx, &statuses)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card supply: %w", err)
+	}
+
+	result := make(map[int64]*SupplyStatus, len(statuses))
+	for _, status := range statuses {
+		result[status.CardID] = status
+	}
+	return result, nil
+}
+
+// Reserve atomically counts amount new copies of a card against its cap. It
+// returns nil for uncapped cards and ErrCardSoldOut once the cap is reached.
+// Run it on the transaction that grants the cards so a failed claim releases
+// the reservation.
+func (r *cardSupplyRepository) Reserve(ctx context.Context, db bun.IDB, cardID int64, amount int64) (*SupplyStatus, error) {
+	status := new(SupplyStatus)
+	err := db.NewRaw(supplyStatusQuery+" AND c.id = ?", cardID).Scan(ctx, status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card supply: %w", err)
+	}
+
+	// The conditional upsert serialises concurrent claims on the counter row,
+	// so the cap holds without locking the card itself
+	var minted int64
+	err = db.NewRaw(`
+		INSERT INTO card_supply AS cs (card_id, minted, updated_at)
+		SELECT ?, ?, NOW()
+		WHERE ? <= ?
+		ON CONFLICT (card_id) DO UPDATE SET
+			minted = cs.minted + EXCLUDED.minted,
+			updated_at = EXCLUDED.updated_at
+		WHERE cs.minted + EXCLUDED.minted <= ?
+		RETURNING minted
+	`, cardID, amount, amount, status.MaxCopies, status.MaxCopies).Scan(ctx, &minted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return status, ErrCardSoldOut
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve card supply: %w", err)
+	}
+
+	status.Minted = minted
+	return status, nil
+}
@@ -0,0 +1,93 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/uptrace/bun"
+)
+
+func TestSupplyStatusRemaining(t *testing.T) {
+	tests := []struct {
+		status repositories.SupplyStatus
+		want   int64
+	}{
+		{repositories.SupplyStatus{MaxCopies: 10, Minted: 3}, 7},
+		{repositories.SupplyStatus{MaxCopies: 1, Minted: 1}, 0},
+		{repositories.SupplyStatus{MaxCopies: 5, Minted: 8}, 0}, // cap lowered below what was minted
+	}
+	for _, tt := range tests {
+		if got := tt.status.Remaining(); got != tt.want {
+			t.Errorf("Remaining(%d of %d) = %d, want %d", tt.status.Minted, tt.status.MaxCopies, got, tt.want)
+		}
+	}
+}
+
+func TestReserveCardSupplyCapOfOne(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	repo := repositories.NewCardSupplyRepository(db.BunDB())
+
+	createTestCard(t, db, 1, "nayeon", "twice", 3)
+	createTestCard(t, db, 2, "momo", "twice", 3)
+	if _, err := db.BunDB().NewUpdate().Model((*models.Card)(nil)).Set("max_copies = 1").Where("id = 1").Exec(ctx); err != nil {
+		t.Fatalf("cap card: %v", err)
+	}
+
+	// A rolled back claim gives its reservation back
+	err := db.BunDB().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := repo.Reserve(ctx, tx, 1, 1); err != nil {
+			return err
+		}
+		return errors.New("claim failed")
+	})
+	if err == nil || err.Error() != "claim failed" {
+		t.Fatalf("rolled back reserve: %v", err)
+	}
+
+	var reserved, soldOut atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := db.BunDB().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+				_, err := repo.Reserve(ctx, tx, 1, 1)
+				return err
+			})
+			switch {
+			case err == nil:
+				reserved.Add(1)
+			case errors.Is(err, repositories.ErrCardSoldOut):
+				soldOut.Add(1)
+			default:
+				t.Errorf("Reserve: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reserved.Load() != 1 || soldOut.Load() != 19 {
+		t.Errorf("reserved %d, sold out %d; want 1 and 19", reserved.Load(), soldOut.Load())
+	}
+
+	statuses, err := repo.GetStatuses(ctx, nil)
+	if err != nil {
+		t.Fatalf("GetStatuses: %v", err)
+	}
+	if status := statuses[1]; status == nil || status.Minted != 1 || status.Remaining() != 0 {
+		t.Errorf("card 1 supply = %+v, want 1 of 1 minted", status)
+	}
+	if _, capped := statuses[2]; capped {
+		t.Error("uncapped card reported a supply")
+	}
+	if status, err := repo.Reserve(ctx, db.BunDB(), 2, 5); err != nil || status != nil {
+		t.Errorf("uncapped Reserve = %+v, %v, want nil", status, err)
+	}
+}
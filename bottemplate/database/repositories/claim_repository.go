@@ -26,6 +26,7 @@ type ClaimRepository interface {
 	GetClaimInfo(ctx context.Context, userID string) (*ClaimInfo, error)
 	GetBasePrice() int64
 	ResetDailyClaims(ctx context.Context, tx bun.Tx, userID string) error
+	ResetAllDailyClaims(ctx context.Context, tx bun.Tx) error
 }
 
 type claimRepository struct {
@@ -233,3 +234,16 @@ func (r *claimRepository) ResetDailyClaims(ctx context.Context, tx bun.Tx, userI
 	}
 	return nil
 }
+
+func (r *claimRepository) ResetAllDailyClaims(ctx context.Context, tx bun.Tx) error {
+	_, err := tx.NewUpdate().
+		Model(&models.ClaimStats{}).
+		Set("daily_claims = 0").
+		Where("daily_claims <> 0").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to reset all daily claims: %w", err)
+	}
+	return nil
+}
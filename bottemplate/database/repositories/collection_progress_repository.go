@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/config"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/uptrace/bun"
+)
+
+// CollectionProgressRepository persists cached per-user collection progress so
+// leaderboards don't have to re-aggregate user_cards on every request
+type CollectionProgressRepository interface {
+	Get(ctx context.Context, userID, collectionID string) (*models.CollectionProgress, error)
+	Upsert(ctx context.Context, progress *models.CollectionProgress) error
+	IsFresh(ctx context.Context, collectionID string, totalCards int) (bool, error)
+	Rebuild(ctx context.Context, collectionID string, cardIDs []int64, isFragment bool) error
+	GetLeaderboard(ctx context.Context, collectionID string, limit int) ([]*models.CollectionProgressResult, error)
+}
+
+type collectionProgressRepository struct {
+	db *bun.DB
+}
+
+func NewCollectionProgressRepository(db *bun.DB) CollectionProgressRepository {
+	return &collectionProgressRepository{db: db}
+}
+
+// Get returns the cached progress for a user, or nil when nothing is cached yet
+func (r *collectionProgressRepository) Get(ctx context.Context, userID, collectionID string) (*models.CollectionProgress, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	progress := new(models.CollectionProgress)
+	err := r.db.NewSelect().
+		Model(progress).
+		Where("user_id = ? AND collection_id = ?", userID, collectionID).
+		Limit(1).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection progress: %w", err)
+	}
+	return progress, nil
+}
+
+func (r *collectionProgressRepository) Upsert(ctx context.Context, progress *models.CollectionProgress) error {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	if progress.LastUpdated.IsZero() {
+		progress.LastUpdated = now
+	}
+	progress.UpdatedAt = now
+
+	_, err := r.db.NewInsert().
+		Model(progress).
+		On("CONFLICT (user_id, collection_id) DO UPDATE").
+		Set("total_cards = EXCLUDED.total_cards").
+		Set("owned_cards = EXCLUDED.owned_cards").
+		Set("percentage = EXCLUDED.percentage").
+		Set("is_completed = EXCLUDED.is_completed").
+		Set("is_fragment = EXCLUDED.is_fragment").
+		Set("last_updated = EXCLUDED.last_updated").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to upsert collection progress: %w", err)
+	}
+	return nil
+}
+
+// IsFresh reports whether the collection has cached rows that were computed
+// against the current number of eligible cards
+func (r *collectionProgressRepository) IsFresh(ctx context.Context, collectionID string, totalCards int) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	var stats struct {
+		Total int `bun:"total"`
+		Stale int `bun:"stale"`
+	}
+	err := r.db.NewSelect().
+		Model((*models.CollectionProgress)(nil)).
+		ColumnExpr("COUNT(*) AS total").
+		ColumnExpr("COUNT(*) FILTER (WHERE total_cards <> ?) AS stale", totalCards).
+		Where("collection_id = ?", collectionID).
+		Scan(ctx, &stats)
+	if err != nil {
+		return false, fmt.Errorf("failed to check collection progress cache: %w", err)
+	}
+	return stats.Total > 0 && stats.Stale == 0, nil
+}
+
+// Rebuild recomputes cached progress for every owner of the given cards in a
+// single statement, replacing whatever was cached for the collection before
+func (r *collectionProgressRepository) Rebuild(ctx context.Context, collectionID string, cardIDs []int64, isFragment bool) error {
+	if len(cardIDs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	totalCards := len(cardIDs)
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Model((*models.CollectionProgress)(nil)).
+			Where("collection_id = ?", collectionID).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("failed to clear collection progress: %w", err)
+		}
+
+		_, err := tx.NewRaw(`
+			INSERT INTO collection_progress
+				(user_id, collection_id, total_cards, owned_cards, percentage, is_completed, is_fragment, last_updated, created_at, updated_at)
+			SELECT
+				uc.user_id,
+				?,
+				?,
+				COUNT(DISTINCT uc.card_id),
+				COUNT(DISTINCT uc.card_id)::float8 * 100 / ?,
+				COUNT(DISTINCT uc.card_id) >= ?,
+				?,
+				NOW(), NOW(), NOW()
+			FROM user_cards uc
+			WHERE uc.card_id IN (?) AND uc.amount > 0
+			GROUP BY uc.user_id
+			ON CONFLICT (user_id, collection_id) DO UPDATE SET
+				total_cards = EXCLUDED.total_cards,
+				owned_cards = EXCLUDED.owned_cards,
+				percentage = EXCLUDED.percentage,
+				is_completed = EXCLUDED.is_completed,
+				is_fragment = EXCLUDED.is_fragment,
+				last_updated = EXCLUDED.last_updated,
+				updated_at = EXCLUDED.updated_at
+		`, collectionID, totalCards, totalCards, totalCards, isFragment, bun.In(cardIDs)).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild collection progress: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetLeaderboard reads the top cached entries for a collection
+func (r *collectionProgressRepository) GetLeaderboard(ctx context.Context, collectionID string, limit int) ([]*models.CollectionProgressResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultQueryTimeout)
+	defer cancel()
+
+	var results []*models.CollectionProgressResult
+	err := r.db.NewRaw(`
+		SELECT
+			u.discord_id,
+			u.username,
+			cp.owned_cards,
+			ROUND(cp.percentage::numeric, 2) AS progress
+		FROM collection_progress cp
+		JOIN users u ON u.discord_id = cp.user_id
+		WHERE cp.collection_id = ? AND cp.owned_cards > 0
+		ORDER BY cp.percentage DESC, cp.owned_cards DESC
+		LIMIT ?
+	`, collectionID, limit).Scan(ctx, &results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached collection leaderboard: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,60 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestCollectionProgressCache(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	repo := repositories.NewCollectionProgressRepository(db.BunDB())
+
+	createTestUser(t, db, "u1", 0)
+	createTestUser(t, db, "u2", 0)
+	var cardIDs []int64
+	for i, name := range []string{"nayeon", "jeongyeon", "momo", "sana"} {
+		cardIDs = append(cardIDs, createTestCard(t, db, int64(i+1), name, "twice", 1).ID)
+	}
+	giveTestCard(t, db, "u1", 1, 1)
+	giveTestCard(t, db, "u2", 1, 2)
+	giveTestCard(t, db, "u2", 2, 1)
+
+	if fresh, err := repo.IsFresh(ctx, "twice", len(cardIDs)); err != nil || fresh {
+		t.Fatalf("empty cache IsFresh = %v, %v, want false", fresh, err)
+	}
+	if err := repo.Rebuild(ctx, "twice", cardIDs, false); err != nil {
+		t.Fatalf("Rebuild: %v", err)
+	}
+	if fresh, err := repo.IsFresh(ctx, "twice", len(cardIDs)); err != nil || !fresh {
+		t.Errorf("IsFresh after rebuild = %v, %v, want true", fresh, err)
+	}
+	if fresh, _ := repo.IsFresh(ctx, "twice", len(cardIDs)+1); fresh {
+		t.Error("cache stayed fresh after a card was added to the collection")
+	}
+
+	// A claim refreshes one user's row in place
+	if err := repo.Upsert(ctx, &models.CollectionProgress{UserID: "u1", CollectionID: "twice", TotalCards: 4, OwnedCards: 3, Percentage: 75}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	cached, err := repo.Get(ctx, "u1", "twice")
+	if err != nil || cached == nil || cached.Percentage != 75 || cached.OwnedCards != 3 {
+		t.Fatalf("Get = %+v, %v, want 75%%", cached, err)
+	}
+
+	board, err := repo.GetLeaderboard(ctx, "twice", 10)
+	if err != nil {
+		t.Fatalf("GetLeaderboard: %v", err)
+	}
+	if len(board) != 2 || board[0].DiscordID != "u1" || board[0].Progress != 75 || board[1].Progress != 50 {
+		t.Errorf("leaderboard = %+v, want u1 at 75 then u2 at 50", board)
+	}
+
+	if missing, err := repo.Get(ctx, "u3", "twice"); err != nil || missing != nil {
+		t.Errorf("Get for an uncached user = %+v, %v, want nil", missing, err)
+	}
+}
@@ -19,6 +19,7 @@ type CollectionRepository interface {
 	GetAllWithCardCounts(ctx context.Context) ([]*CollectionWithCardCount, error)
 	GetCollectionCount(ctx context.Context) (int64, error)
 	Update(ctx context.Context, collection *models.Collection) error
+	UpdateIfUnchanged(ctx context.Context, collection *models.Collection, expectedUpdatedAt time.Time) error
 	Delete(ctx context.Context, id string) error
 	BulkCreate(ctx context.Context, collections []*models.Collection) error
 	SearchCollections(ctx context.Context, search string) ([]*models.Collection, error)
@@ -43,6 +44,9 @@ func NewCollectionRepository(db *bun.DB) CollectionRepository {
 func (r *collectionRepository) Create(ctx context.Context, collection *models.Collection) error {
 	collection.CreatedAt = time.Now()
 	collection.UpdatedAt = time.Now()
+	if collection.Type == "" {
+		collection.Type = models.InferCollectionType(collection)
+	}
 	_, err := r.db.NewInsert().Model(collection).Exec(ctx)
 	return err
 }
@@ -85,7 +89,7 @@ func (r *collectionRepository) GetAllWithCardCounts(ctx context.Context) ([]*Col
 		Model((*models.Collection)(nil)).
 		ColumnExpr("col.*").
 		ColumnExpr("COALESCE(COUNT(cards.id), 0) AS card_count").
-		Join("LEFT JOIN cards ON cards.col_id = col.id").
+		Join("LEFT JOIN cards ON cards.col_id = col.id AND cards.deleted_at IS NULL").
 		Group("col.id").
 		Order("col.name ASC").
 		Scan(ctx, &results)
@@ -107,10 +111,31 @@ func (r *collectionRepository) GetCollectionCount(ctx context.Context) (int64, e
 
 func (r *collectionRepository) Update(ctx context.Context, collection *models.Collection) error {
 	collection.UpdatedAt = time.Now()
+	if collection.Type == "" {
+		collection.Type = models.InferCollectionType(collection)
+	}
 	_, err := r.db.NewUpdate().Model(collection).WherePK().Exec(ctx)
 	return err
 }
 
+// UpdateIfUnchanged is Update that fails with ErrStaleUpdate when the stored collection's
+// updated_at no longer equals expectedUpdatedAt
+func (r *collectionRepository) UpdateIfUnchanged(ctx context.Context, collection *models.Collection, expectedUpdatedAt time.Time) error {
+	collection.UpdatedAt = time.Now()
+	if collection.Type == "" {
+		collection.Type = models.InferCollectionType(collection)
+	}
+
+	result, err := whereUnchangedSince(r.db.NewUpdate().Model(collection).WherePK(), expectedUpdatedAt).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ErrStaleUpdate
+	}
+	return nil
+}
+
 func (r *collectionRepository) Delete(ctx context.Context, id string) error {
 	_, err := r.db.NewDelete().Model((*models.Collection)(nil)).Where("id = ?", id).Exec(ctx)
 	return err
@@ -125,6 +150,9 @@ func (r *collectionRepository) BulkCreate(ctx context.Context, collections []*mo
 	for _, collection := range collections {
 		collection.CreatedAt = now
 		collection.UpdatedAt = now
+		if collection.Type == "" {
+			collection.Type = models.InferCollectionType(collection)
+		}
 	}
 
 	_, err := r.db.NewInsert().
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/uptrace/bun"
+)
+
+type CompletionRewardRepository interface {
+	Grant(ctx context.Context, grant *models.CompletionRewardGrant) (bool, error)
+}
+
+type completionRewardRepository struct {
+	db *bun.DB
+}
+
+func NewCompletionRewardRepository(db *bun.DB) CompletionRewardRepository {
+	return &completionRewardRepository{db: db}
+}
+
+// Grant records the grant and credits the user in one transaction. It returns
+// false without touching the user when the collection was already rewarded.
+func (r *completionRewardRepository) Grant(ctx context.Context, grant *models.CompletionRewardGrant) (bool, error) {
+	granted := false
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewInsert().
+			Model(grant).
+			On("CONFLICT (user_id, collection_id) DO NOTHING").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to record completion reward: %w", err)
+		}
+		if affected, _ := res.RowsAffected(); affected == 0 {
+			return nil
+		}
+
+		if grant.Flakes != 0 || grant.Vials != 0 {
+			_, err = tx.NewUpdate().
+				Model((*models.User)(nil)).
+				Set("balance = balance + ?", grant.Flakes).
+				Set("user_stats = jsonb_set(COALESCE(user_stats, '{}'::jsonb), '{vials}', (COALESCE((user_stats->>'vials')::bigint, 0) + ?)::text::jsonb)", grant.Vials).
+				Set("updated_at = ?", time.Now()).
+				Where("discord_id = ?", grant.UserID).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to credit completion reward: %w", err)
+			}
+		}
+
+		if grant.ItemID != "" && grant.ItemQuantity > 0 {
+			_, err = tx.NewInsert().
+				Model(&models.UserItem{
+					UserID:   grant.UserID,
+					ItemID:   grant.ItemID,
+					Quantity: grant.ItemQuantity,
+				}).
+				On("CONFLICT (user_id, item_id) DO UPDATE").
+				Set("quantity = ui.quantity + EXCLUDED.quantity").
+				Set("updated_at = CURRENT_TIMESTAMP").
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to add completion reward item: %w", err)
+			}
+		}
+
+		granted = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return granted, nil
+}
@@ -0,0 +1,47 @@
+package repositories_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestCompletionRewardGrantedOnce(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	repo := repositories.NewCompletionRewardRepository(db.BunDB())
+	createTestUser(t, db, "u1", 100)
+
+	var granted atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.Grant(ctx, &models.CompletionRewardGrant{UserID: "u1", CollectionID: "twice", Flakes: 1000, Vials: 20})
+			if err != nil {
+				t.Errorf("Grant: %v", err)
+			}
+			if ok {
+				granted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := granted.Load(); n != 1 {
+		t.Errorf("granted %d times, want once", n)
+	}
+	user, err := repositories.NewUserRepository(db.BunDB()).GetByDiscordID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if user.Balance != 1100 || user.UserStats.Vials != 20 {
+		t.Errorf("balance %d, vials %d; want 1100 and 20", user.Balance, user.UserStats.Vials)
+	}
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/uptrace/bun"
+)
+
+type CurrencyLedgerRepository interface {
+	// Record appends an entry using db, which may be a transaction so the entry
+	// commits together with the balance change it describes
+	Record(ctx context.Context, db bun.IDB, entry *models.CurrencyLedgerEntry) error
+	GetRecent(ctx context.Context, userID string, limit int) ([]*models.CurrencyLedgerEntry, error)
+}
+
+type currencyLedgerRepository struct {
+	db *bun.DB
+}
+
+func NewCurrencyLedgerRepository(db *bun.DB) CurrencyLedgerRepository {
+	return &currencyLedgerRepository{db: db}
+}
+
+func (r *currencyLedgerRepository) Record(ctx context.Context, db bun.IDB, entry *models.CurrencyLedgerEntry) error {
+	if db == nil {
+		db = r.db
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if _, err := db.NewInsert().Model(entry).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record currency change: %w", err)
+	}
+	return nil
+}
+
+// GetRecent returns the user's latest ledger entries, newest first
+func (r *currencyLedgerRepository) GetRecent(ctx context.Context, userID string, limit int) ([]*models.CurrencyLedgerEntry, error) {
+	var entries []*models.CurrencyLedgerEntry
+	err := r.db.NewSelect().
+		Model(&entries).
+		Where("user_id = ?", userID).
+		Order("created_at DESC", "id DESC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get currency history: %w", err)
+	}
+	return entries, nil
+}
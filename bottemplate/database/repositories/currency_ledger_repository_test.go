@@ -0,0 +1,80 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestCurrencyLedgerRecordsWithTransaction(t *testing.T) {
+	db := dbtest.Open(t)
+	ledger := repositories.NewCurrencyLedgerRepository(db.BunDB())
+	ctx := context.Background()
+
+	// A daily claim that rolls back leaves no entry behind
+	tx, err := db.BunDB().BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	if err := ledger.Record(ctx, tx, &models.CurrencyLedgerEntry{UserID: "u1", Amount: 500, Reason: models.LedgerReasonDaily}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if entries, err := ledger.GetRecent(ctx, "u1", 5); err != nil || len(entries) != 0 {
+		t.Fatalf("entries after rollback = %d, %v, want none", len(entries), err)
+	}
+
+	tx, err = db.BunDB().BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	if err := ledger.Record(ctx, tx, &models.CurrencyLedgerEntry{UserID: "u1", Amount: 500, Reason: models.LedgerReasonDaily}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	entries, err := ledger.GetRecent(ctx, "u1", 5)
+	if err != nil {
+		t.Fatalf("GetRecent: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Amount != 500 || entries[0].Reason != models.LedgerReasonDaily || entries[0].CreatedAt.IsZero() {
+		t.Errorf("entries = %+v, want one daily reward of 500", entries)
+	}
+}
+
+func TestCurrencyLedgerGetRecent(t *testing.T) {
+	db := dbtest.Open(t)
+	ledger := repositories.NewCurrencyLedgerRepository(db.BunDB())
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, amount := range []int64{100, -40, 250, -10} {
+		entry := &models.CurrencyLedgerEntry{UserID: "u1", Amount: amount, Reason: models.LedgerReasonWork, CreatedAt: start.Add(time.Duration(i) * time.Hour)}
+		if err := ledger.Record(ctx, nil, entry); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	if err := ledger.Record(ctx, nil, &models.CurrencyLedgerEntry{UserID: "u2", Amount: 999, Reason: models.LedgerReasonShop}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	entries, err := ledger.GetRecent(ctx, "u1", 3)
+	if err != nil {
+		t.Fatalf("GetRecent: %v", err)
+	}
+	var amounts []int64
+	for _, entry := range entries {
+		amounts = append(amounts, entry.Amount)
+	}
+	if len(amounts) != 3 || amounts[0] != -10 || amounts[1] != 250 || amounts[2] != -40 {
+		t.Errorf("amounts = %v, want the latest three newest first", amounts)
+	}
+}
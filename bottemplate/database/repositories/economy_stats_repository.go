@@ -2,7 +2,10 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
@@ -15,6 +18,8 @@ type EconomyStatsRepository interface {
 	GetHistorical(ctx context.Context, start, end time.Time) ([]*models.EconomyStats, error)
 	UpdateEconomicHealth(ctx context.Context) error
 	GetTrends(ctx context.Context) (map[string]float64, error)
+	CardOwnershipGini(ctx context.Context) (float64, error)
+	InflationSince(ctx context.Context, totalWealth int64, since time.Time) (float64, error)
 }
 
 type economyStatsRepository struct {
@@ -83,6 +88,74 @@ func (r *economyStatsRepository) GetTrends(ctx context.Context) (map[string]floa
 	return trends, nil
 }
 
+// CardOwnershipGini returns the Gini coefficient of owned card copies across all card owners
+func (r *economyStatsRepository) CardOwnershipGini(ctx context.Context) (float64, error) {
+	var copies []int64
+	err := r.db.NewSelect().
+		Model((*models.UserCard)(nil)).
+		ColumnExpr("SUM(amount)").
+		Where("amount > 0").
+		Group("user_id").
+		Scan(ctx, &copies)
+	if err != nil {
+		return 0, err
+	}
+	return GiniCoefficient(copies), nil
+}
+
+// InflationSince returns the percentage change from the total wealth of the newest stats
+// recorded at or before since to totalWealth. It returns 0 when there is no earlier snapshot.
+func (r *economyStatsRepository) InflationSince(ctx context.Context, totalWealth int64, since time.Time) (float64, error) {
+	previous := new(models.EconomyStats)
+	err := r.db.NewSelect().
+		Model(previous).
+		Where("timestamp <= ?", since).
+		Order("timestamp DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return InflationRate(previous.TotalWealth, totalWealth), nil
+}
+
+// GiniCoefficient measures inequality of non-negative values, from 0 (equal) to nearly 1
+func GiniCoefficient(values []int64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	var total float64
+	for i, v := range values {
+		if v < 0 {
+			v = 0
+		}
+		sorted[i] = float64(v)
+		total += float64(v)
+	}
+	if total == 0 {
+		return 0
+	}
+
+	sort.Float64s(sorted)
+
+	n := float64(len(sorted))
+	var numerator float64
+	for i, v := range sorted {
+		numerator += (2*float64(i) + 1 - n) * v
+	}
+	return numerator / (n * total)
+}
+
+// InflationRate returns the percentage growth of the currency supply from previous to current
+func InflationRate(previous, current int64) float64 {
+	return calculatePercentageChange(float64(previous), float64(current))
+}
+
 func calculateHealthScore(stats *models.EconomyStats) float64 {
 	weights := map[string]float64{
 		"wealth_distribution": 0.30,
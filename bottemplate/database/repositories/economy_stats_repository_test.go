@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGiniCoefficient(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"all zero", []int64{0, 0, 0}, 0},
+		{"perfect equality", []int64{50, 50, 50, 50}, 0},
+		{"one holds everything", []int64{0, 0, 0, 100}, 0.75},
+		{"order doesn't matter", []int64{100, 0, 0, 0}, 0.75},
+		{"two way split", []int64{25, 75}, 0.25},
+		{"negative balances count as zero", []int64{-40, 0, 0, 100}, 0.75},
+		{"mixed", []int64{1, 2, 3, 4}, 0.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GiniCoefficient(tt.values); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("GiniCoefficient(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInflationRate(t *testing.T) {
+	tests := []struct {
+		previous, current int64
+		want              float64
+	}{
+		{1000, 1100, 10},
+		{1000, 900, -10},
+		{1000, 1000, 0},
+		{0, 500, 0}, // no baseline
+		{200, 500, 150},
+	}
+	for _, tt := range tests {
+		if got := InflationRate(tt.previous, tt.current); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("InflationRate(%d, %d) = %v, want %v", tt.previous, tt.current, got, tt.want)
+		}
+	}
+}
@@ -24,6 +24,20 @@ type EffectRepository interface {
 	GetActiveUserEffects(ctx context.Context, userID string) ([]*models.UserEffect, error)
 	UpdateUserEffect(ctx context.Context, effect *models.UserEffect) error
 	DeactivateExpiredEffects(ctx context.Context) error
+	GetExpiredActiveEffects(ctx context.Context, limit int) ([]*models.UserEffect, error)
+	ExpireUserEffect(ctx context.Context, id int64) (bool, error)
+	CountActiveUserEffects(ctx context.Context, expiringWithin time.Duration) (active int, expiring int, err error)
+
+	// Collection EXP boosts
+	SetCollectionExpBoost(ctx context.Context, boost *models.CollectionExpBoost) error
+	GetActiveCollectionExpBoost(ctx context.Context, userID string) (*models.CollectionExpBoost, error)
+
+	// Shop stock
+	EnsureShopStock(ctx context.Context, stock *models.ShopStock) error
+	GetShopStock(ctx context.Context) ([]*models.ShopStock, error)
+	DecrementShopStock(ctx context.Context, itemID string) (bool, error)
+	IncrementShopStock(ctx context.Context, itemID string) error
+	RestockDueShopItems(ctx context.Context) (int, error)
 
 	// Inventory
 	AddToInventory(ctx context.Context, userID string, itemID string, amount int) error
@@ -175,6 +189,187 @@ func (r *effectRepository) DeactivateExpiredEffects(ctx context.Context) error {
 	return err
 }
 
+// GetExpiredActiveEffects returns effects still marked active whose expiry has passed, oldest first
+func (r *effectRepository) GetExpiredActiveEffects(ctx context.Context, limit int) ([]*models.UserEffect, error) {
+	var effects []*models.UserEffect
+	err := r.SelectWithTimeout(ctx, "get_expired_active", "user_effects", func(ctx context.Context) error {
+		return r.GetDB().NewSelect().
+			Model(&effects).
+			Where("active = true AND expires_at <= ?", time.Now()).
+			Order("expires_at ASC").
+			Limit(limit).
+			Scan(ctx)
+	})
+	return effects, err
+}
+
+// CountActiveUserEffects counts active user effects and how many of them expire
+// within the given window
+func (r *effectRepository) CountActiveUserEffects(ctx context.Context, expiringWithin time.Duration) (int, int, error) {
+	var counts struct {
+		Active   int `bun:"active"`
+		Expiring int `bun:"expiring"`
+	}
+	now := time.Now()
+	err := r.SelectWithTimeout(ctx, "count_active", "user_effects", func(ctx context.Context) error {
+		return r.GetDB().NewSelect().
+			Model((*models.UserEffect)(nil)).
+			ColumnExpr("COUNT(*) AS active").
+			ColumnExpr("COUNT(*) FILTER (WHERE expires_at IS NOT NULL AND expires_at <= ?) AS expiring", now.Add(expiringWithin)).
+			Where("active = true").
+			Where("expires_at IS NULL OR expires_at > ?", now).
+			Scan(ctx, &counts)
+	})
+	return counts.Active, counts.Expiring, err
+}
+
+// ExpireUserEffect deactivates an effect and marks it notified. It reports whether this
+// call performed the deactivation, so concurrent sweeps only notify once.
+func (r *effectRepository) ExpireUserEffect(ctx context.Context, id int64) (bool, error) {
+	result, err := r.ExecWithTimeout(ctx, "expire", "user_effect", func(ctx context.Context) (sql.Result, error) {
+		return r.GetDB().NewUpdate().
+			Model((*models.UserEffect)(nil)).
+			Set("active = false").
+			Set("notified = true").
+			Set("updated_at = ?", time.Now()).
+			Where("id = ? AND active = true", id).
+			Exec(ctx)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// SetCollectionExpBoost starts a collection EXP boost, replacing the user's current one
+func (r *effectRepository) SetCollectionExpBoost(ctx context.Context, boost *models.CollectionExpBoost) error {
+	if err := r.ValidateRequired(map[string]interface{}{
+		"user_id":       boost.UserID,
+		"collection_id": boost.CollectionID,
+	}); err != nil {
+		return err
+	}
+
+	boost.CreatedAt = time.Now()
+	_, err := r.ExecWithTimeout(ctx, "upsert", "collection_exp_boost", func(ctx context.Context) (sql.Result, error) {
+		return r.GetDB().NewInsert().
+			Model(boost).
+			On("CONFLICT (user_id) DO UPDATE").
+			Set("collection_id = EXCLUDED.collection_id").
+			Set("multiplier = EXCLUDED.multiplier").
+			Set("expires_at = EXCLUDED.expires_at").
+			Set("created_at = EXCLUDED.created_at").
+			Exec(ctx)
+	})
+	return err
+}
+
+// GetActiveCollectionExpBoost returns the user's running collection EXP boost, or nil if none
+func (r *effectRepository) GetActiveCollectionExpBoost(ctx context.Context, userID string) (*models.CollectionExpBoost, error) {
+	boost := new(models.CollectionExpBoost)
+	err := r.SelectWithTimeout(ctx, "get_active", "collection_exp_boost", func(ctx context.Context) error {
+		return r.GetDB().NewSelect().
+			Model(boost).
+			Where("user_id = ? AND expires_at > ?", userID, time.Now()).
+			Scan(ctx)
+	})
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return boost, nil
+}
+
+// EnsureShopStock creates the stock row for a limited item, or updates its limits while
+// keeping the current stock
+func (r *effectRepository) EnsureShopStock(ctx context.Context, stock *models.ShopStock) error {
+	stock.UpdatedAt = time.Now()
+	_, err := r.ExecWithTimeout(ctx, "ensure", "shop_stock", func(ctx context.Context) (sql.Result, error) {
+		return r.GetDB().NewInsert().
+			Model(stock).
+			On("CONFLICT (item_id) DO UPDATE").
+			Set("max_stock = EXCLUDED.max_stock").
+			Set("restock_hours = EXCLUDED.restock_hours").
+			Set("stock = LEAST(ss.stock, EXCLUDED.max_stock)").
+			Set("updated_at = EXCLUDED.updated_at").
+			Exec(ctx)
+	})
+	return err
+}
+
+func (r *effectRepository) GetShopStock(ctx context.Context) ([]*models.ShopStock, error) {
+	var stock []*models.ShopStock
+	err := r.SelectWithTimeout(ctx, "list", "shop_stock", func(ctx context.Context) error {
+		return r.GetDB().NewSelect().Model(&stock).Scan(ctx)
+	})
+	return stock, err
+}
+
+// DecrementShopStock takes one unit of stock in a single conditional update, so concurrent
+// purchases can never oversell. It reports false when the item is sold out.
+func (r *effectRepository) DecrementShopStock(ctx context.Context, itemID string) (bool, error) {
+	result, err := r.ExecWithTimeout(ctx, "decrement", "shop_stock", func(ctx context.Context) (sql.Result, error) {
+		return r.GetDB().NewUpdate().
+			Model((*models.ShopStock)(nil)).
+			Set("stock = stock - 1").
+			Set("updated_at = ?", time.Now()).
+			Where("item_id = ? AND stock > 0", itemID).
+			Exec(ctx)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// IncrementShopStock returns one unit of stock, e.g. when a purchase fails after reserving it
+func (r *effectRepository) IncrementShopStock(ctx context.Context, itemID string) error {
+	_, err := r.ExecWithTimeout(ctx, "increment", "shop_stock", func(ctx context.Context) (sql.Result, error) {
+		return r.GetDB().NewUpdate().
+			Model((*models.ShopStock)(nil)).
+			Set("stock = LEAST(stock + 1, max_stock)").
+			Set("updated_at = ?", time.Now()).
+			Where("item_id = ?", itemID).
+			Exec(ctx)
+	})
+	return err
+}
+
+// RestockDueShopItems refills every item whose restock time has passed and schedules its next restock
+func (r *effectRepository) RestockDueShopItems(ctx context.Context) (int, error) {
+	now := time.Now()
+	result, err := r.ExecWithTimeout(ctx, "restock", "shop_stock", func(ctx context.Context) (sql.Result, error) {
+		return r.GetDB().NewUpdate().
+			Model((*models.ShopStock)(nil)).
+			Set("stock = max_stock").
+			Set("next_restock_at = ?::timestamptz + make_interval(hours => restock_hours)", now).
+			Set("updated_at = ?", now).
+			Where("next_restock_at <= ?", now).
+			Exec(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
 func (r *effectRepository) AddToInventory(ctx context.Context, userID string, itemID string, amount int) error {
 	// Validate required fields
 	if err := r.ValidateRequired(map[string]interface{}{
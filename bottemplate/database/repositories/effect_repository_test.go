@@ -0,0 +1,42 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestCountActiveUserEffects(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	repo := repositories.NewEffectRepository(db.BunDB())
+
+	at := func(d time.Duration) *time.Time {
+		t := time.Now().Add(d)
+		return &t
+	}
+	effects := []*models.UserEffect{
+		{UserID: "u1", EffectID: "cakeday", Active: true},                                    // never expires
+		{UserID: "u1", EffectID: "tohrugift", Active: true, ExpiresAt: at(30 * time.Minute)}, // expiring soon
+		{UserID: "u2", EffectID: "holygrail", Active: true, ExpiresAt: at(48 * time.Hour)},
+		{UserID: "u2", EffectID: "lambhinder", Active: true, ExpiresAt: at(-time.Minute)}, // past, awaiting the sweeper
+		{UserID: "u3", EffectID: "cakeday"},                                               // in inventory
+	}
+	for _, effect := range effects {
+		if err := repo.AddUserEffect(ctx, effect); err != nil {
+			t.Fatalf("add %s: %v", effect.EffectID, err)
+		}
+	}
+
+	active, expiring, err := repo.CountActiveUserEffects(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("CountActiveUserEffects: %v", err)
+	}
+	if active != 3 || expiring != 1 {
+		t.Errorf("active %d, expiring %d; want 3 and 1", active, expiring)
+	}
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+type GuildSettingsRepository interface {
+	// Get returns the guild's settings, or nil when the guild has none stored
+	Get(ctx context.Context, guildID string) (*models.GuildSettings, error)
+	SetCategoryEnabled(ctx context.Context, guildID, category string, enabled bool, updatedBy string) (*models.GuildSettings, error)
+}
+
+type guildSettingsRepository struct {
+	*BaseRepository
+}
+
+func NewGuildSettingsRepository(db *bun.DB) GuildSettingsRepository {
+	return &guildSettingsRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *guildSettingsRepository) Get(ctx context.Context, guildID string) (*models.GuildSettings, error) {
+	settings := new(models.GuildSettings)
+	err := r.SelectWithTimeout(ctx, "get", "guild_settings", func(ctx context.Context) error {
+		return r.db.NewSelect().
+			Model(settings).
+			Where("guild_id = ?", guildID).
+			Scan(ctx)
+	})
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SetCategoryEnabled enables or disables a command category for a guild, creating the
+// settings row on first use. The array update is done in SQL so concurrent toggles of
+// different categories don't overwrite each other.
+func (r *guildSettingsRepository) SetCategoryEnabled(ctx context.Context, guildID, category string, enabled bool, updatedBy string) (*models.GuildSettings, error) {
+	disabled := []string{}
+	update := "array_remove(gs.disabled_categories, ?)"
+	args := []interface{}{category}
+	if !enabled {
+		disabled = []string{category}
+		update = "array_append(array_remove(gs.disabled_categories, ?), ?)"
+		args = append(args, category)
+	}
+
+	now := time.Now()
+	query := `
+		INSERT INTO guild_settings AS gs (guild_id, disabled_categories, updated_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (guild_id) DO UPDATE
+		SET disabled_categories = ` + update + `,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING *`
+	queryArgs := append([]interface{}{guildID, pgdialect.Array(disabled), updatedBy, now, now}, args...)
+
+	settings := new(models.GuildSettings)
+	err := r.SelectWithTimeout(ctx, "set_category", "guild_settings", func(ctx context.Context) error {
+		return r.db.NewRaw(query, queryArgs...).Scan(ctx, settings)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
@@ -0,0 +1,57 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// createTestUser inserts a user with the given balance and zeroed stats
+func createTestUser(t *testing.T, db *database.DB, discordID string, balance int64) {
+	t.Helper()
+	now := time.Now()
+	user := &models.User{
+		DiscordID: discordID,
+		Username:  discordID,
+		Balance:   balance,
+		Joined:    now,
+		LastDaily: now,
+		LastTrain: now,
+		LastWork:  now,
+		LastVote:  now,
+	}
+	if err := repositories.NewUserRepository(db.BunDB()).Create(context.Background(), user); err != nil {
+		t.Fatalf("create user %s: %v", discordID, err)
+	}
+}
+
+// createTestCard inserts a card, creating its collection on first use
+func createTestCard(t *testing.T, db *database.DB, id int64, name, colID string, level int) *models.Card {
+	t.Helper()
+	ctx := context.Background()
+
+	collection := &models.Collection{ID: colID, Name: colID, Origin: "test", UpdatedAt: time.Now()}
+	if _, err := db.BunDB().NewInsert().Model(collection).On("CONFLICT (id) DO NOTHING").Exec(ctx); err != nil {
+		t.Fatalf("create collection %s: %v", colID, err)
+	}
+
+	card := &models.Card{ID: id, Name: name, Level: level, ColID: colID, Tags: []string{"girlgroups"}, UpdatedAt: time.Now()}
+	if err := repositories.NewCardRepository(db.BunDB()).Create(ctx, card); err != nil {
+		t.Fatalf("create card %d: %v", id, err)
+	}
+	return card
+}
+
+// giveTestCard inserts amount copies of a card for a user
+func giveTestCard(t *testing.T, db *database.DB, userID string, cardID, amount int64) {
+	t.Helper()
+	now := time.Now()
+	userCard := &models.UserCard{UserID: userID, CardID: cardID, Level: 1, Amount: amount, Obtained: now, CreatedAt: now, UpdatedAt: now}
+	if err := repositories.NewUserCardRepository(db.BunDB()).Create(context.Background(), userCard); err != nil {
+		t.Fatalf("give card %d to %s: %v", cardID, userID, err)
+	}
+}
@@ -3,12 +3,20 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	economyutils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
 	"github.com/uptrace/bun"
 )
 
+var (
+	ErrInsufficientItems = errors.New("insufficient item quantity")
+	ErrItemStackFull     = errors.New("item stack is full")
+	ErrItemNotTradeable  = errors.New("item cannot be traded")
+)
+
 type ItemRepository interface {
 	// Item operations
 	GetByID(ctx context.Context, id string) (*models.Item, error)
@@ -23,6 +31,7 @@ type ItemRepository interface {
 	RemoveUserItem(ctx context.Context, userID, itemID string, quantity int) error
 	HasRequiredItems(ctx context.Context, userID string, requirements map[string]int) (bool, error)
 	ConsumeItems(ctx context.Context, userID string, requirements map[string]int) error
+	TransferUserItem(ctx context.Context, fromUserID, toUserID, itemID string, quantity int) error
 }
 
 type itemRepository struct {
@@ -200,3 +209,103 @@ func (r *itemRepository) ConsumeItems(ctx context.Context, userID string, requir
 		return nil
 	})
 }
+
+// TransferUserItem atomically moves quantity of an item from one user to another. It fails
+// with ErrItemNotTradeable, ErrInsufficientItems or ErrItemStackFull without moving anything.
+func (r *itemRepository) TransferUserItem(ctx context.Context, fromUserID, toUserID, itemID string, quantity int) error {
+	if quantity <= 0 {
+		return fmt.Errorf("quantity must be positive")
+	}
+	if fromUserID == toUserID {
+		return fmt.Errorf("cannot transfer items to yourself")
+	}
+
+	txManager := economyutils.NewEconomicTransactionManager(r.db)
+	return txManager.WithTransaction(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var item models.Item
+		if err := tx.NewSelect().Model(&item).Where("id = ?", itemID).Scan(ctx); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("item %s not found", itemID)
+			}
+			return fmt.Errorf("failed to get item: %w", err)
+		}
+
+		// Lock both stacks in a fixed order so opposing transfers can't deadlock
+		var stacks []models.UserItem
+		err := tx.NewSelect().
+			Model(&stacks).
+			Where("item_id = ? AND user_id IN (?)", itemID, bun.In([]string{fromUserID, toUserID})).
+			Order("user_id").
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to lock item stacks: %w", err)
+		}
+
+		var senderQty, receiverQty int
+		for _, stack := range stacks {
+			if stack.UserID == fromUserID {
+				senderQty = stack.Quantity
+			} else {
+				receiverQty = stack.Quantity
+			}
+		}
+
+		if err := checkItemTransfer(&item, senderQty, receiverQty, quantity); err != nil {
+			return err
+		}
+
+		// Debit the sender, dropping the stack when it empties
+		if senderQty == quantity {
+			_, err = tx.NewDelete().
+				Model((*models.UserItem)(nil)).
+				Where("user_id = ? AND item_id = ?", fromUserID, itemID).
+				Exec(ctx)
+		} else {
+			_, err = tx.NewUpdate().
+				Model((*models.UserItem)(nil)).
+				Set("quantity = quantity - ?", quantity).
+				Set("updated_at = CURRENT_TIMESTAMP").
+				Where("user_id = ? AND item_id = ?", fromUserID, itemID).
+				Exec(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to remove item from sender: %w", err)
+		}
+
+		// Credit the receiver; the conflict guard re-checks the cap in case their stack
+		// was created after we locked
+		receiver := &models.UserItem{UserID: toUserID, ItemID: itemID, Quantity: quantity}
+		query := tx.NewInsert().
+			Model(receiver).
+			On("CONFLICT (user_id, item_id) DO UPDATE").
+			Set("quantity = ui.quantity + EXCLUDED.quantity").
+			Set("updated_at = CURRENT_TIMESTAMP")
+		if item.MaxStack > 0 {
+			query = query.Where("ui.quantity + EXCLUDED.quantity <= ?", item.MaxStack)
+		}
+		result, err := query.Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to add item to receiver: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+			return ErrItemStackFull
+		}
+
+		return nil
+	})
+}
+
+// checkItemTransfer validates moving quantity of item between stacks of the given sizes
+func checkItemTransfer(item *models.Item, senderQty, receiverQty, quantity int) error {
+	if !item.Tradeable {
+		return ErrItemNotTradeable
+	}
+	if senderQty < quantity {
+		return fmt.Errorf("%w: has %d, trying to give %d", ErrInsufficientItems, senderQty, quantity)
+	}
+	if item.MaxStack > 0 && receiverQty+quantity > item.MaxStack {
+		return fmt.Errorf("%w: receiver has %d of %d", ErrItemStackFull, receiverQty, item.MaxStack)
+	}
+	return nil
+}
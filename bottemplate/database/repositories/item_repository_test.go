@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestCheckItemTransfer(t *testing.T) {
+	tradeable := &models.Item{ID: "broken_disc", Tradeable: true, MaxStack: 10}
+	uncapped := &models.Item{ID: "microphone", Tradeable: true}
+	bound := &models.Item{ID: "forgotten_song", MaxStack: 10}
+
+	tests := []struct {
+		name        string
+		item        *models.Item
+		senderQty   int
+		receiverQty int
+		quantity    int
+		wantErr     error
+	}{
+		{name: "whole stack", item: tradeable, senderQty: 3, receiverQty: 0, quantity: 3},
+		{name: "fills the receiver to the cap", item: tradeable, senderQty: 5, receiverQty: 7, quantity: 3},
+		{name: "more than the sender has", item: tradeable, senderQty: 2, receiverQty: 0, quantity: 3, wantErr: ErrInsufficientItems},
+		{name: "sender has none", item: tradeable, senderQty: 0, receiverQty: 0, quantity: 1, wantErr: ErrInsufficientItems},
+		{name: "overflows the receiver's stack", item: tradeable, senderQty: 5, receiverQty: 8, quantity: 3, wantErr: ErrItemStackFull},
+		{name: "receiver already at the cap", item: tradeable, senderQty: 1, receiverQty: 10, quantity: 1, wantErr: ErrItemStackFull},
+		{name: "no cap", item: uncapped, senderQty: 500, receiverQty: 900, quantity: 500},
+		{name: "untradeable item", item: bound, senderQty: 5, receiverQty: 0, quantity: 1, wantErr: ErrItemNotTradeable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkItemTransfer(tt.item, tt.senderQty, tt.receiverQty, tt.quantity)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("checkItemTransfer: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkItemTransfer error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
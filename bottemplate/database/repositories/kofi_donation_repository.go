@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
+	"github.com/uptrace/bun"
+)
+
+type KofiDonationRepository interface {
+	Claim(ctx context.Context, donation *models.KofiDonation) (bool, error)
+	Record(ctx context.Context, donation *models.KofiDonation) (bool, error)
+}
+
+type kofiDonationRepository struct {
+	db        *bun.DB
+	txManager *economicUtils.EconomicTransactionManager
+}
+
+func NewKofiDonationRepository(db *bun.DB) KofiDonationRepository {
+	return &kofiDonationRepository{
+		db:        db,
+		txManager: economicUtils.NewEconomicTransactionManager(db),
+	}
+}
+
+// Claim records the donation, pays its flakes, extends the user's premium by its
+// premium days and sets last_kofi_claim, all in one transaction. It returns false
+// without touching the user when the donation's message ID was already claimed.
+// An earlier unmatched or pending record of the same payment is claimed over, and a
+// donation for a user without an account fails with ErrUserNotFound.
+func (r *kofiDonationRepository) Claim(ctx context.Context, donation *models.KofiDonation) (bool, error) {
+	if donation.ReceivedAt.IsZero() {
+		donation.ReceivedAt = time.Now()
+	}
+	now := donation.ReceivedAt
+	donation.Status = models.KofiDonationClaimed
+
+	claimed := false
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewInsert().
+			Model(donation).
+			On("CONFLICT (message_id) DO UPDATE").
+			Set("user_id = EXCLUDED.user_id").
+			Set("tier_name = EXCLUDED.tier_name").
+			Set("flakes = EXCLUDED.flakes").
+			Set("premium_days = EXCLUDED.premium_days").
+			Set("status = EXCLUDED.status").
+			Where("?TableAlias.status <> ?", models.KofiDonationClaimed).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to record ko-fi donation: %w", err)
+		}
+		if affected, _ := res.RowsAffected(); affected == 0 {
+			return nil
+		}
+
+		user := new(models.User)
+		err = tx.NewSelect().
+			Model(user).
+			Column("discord_id", "premium", "premium_expires").
+			Where("discord_id = ?", donation.UserID).
+			For("UPDATE").
+			Scan(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s", ErrUserNotFound, donation.UserID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		update := tx.NewUpdate().
+			Model((*models.User)(nil)).
+			Set("last_kofi_claim = ?", now).
+			Set("updated_at = ?", now).
+			Where("discord_id = ?", donation.UserID)
+		if donation.PremiumDays > 0 {
+			expires := user.ExtendedPremiumExpiry(time.Duration(donation.PremiumDays)*24*time.Hour, now)
+			update = update.
+				Set("premium = true").
+				Set("premium_expires = ?", expires)
+		}
+		if _, err := update.Exec(ctx); err != nil {
+			return fmt.Errorf("failed to apply ko-fi donation: %w", err)
+		}
+
+		if donation.Flakes > 0 {
+			if err := r.txManager.ValidateAndUpdateBalance(ctx, tx, economicUtils.BalanceOperationOptions{
+				UserID:    donation.UserID,
+				Amount:    donation.Flakes,
+				Reason:    models.LedgerReasonKofi,
+				Reference: donation.MessageID,
+			}); err != nil {
+				return fmt.Errorf("failed to credit ko-fi donation: %w", err)
+			}
+		}
+
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
+
+// Record stores a donation that was not rewarded, with its status set by the caller.
+// It returns false when the message ID is already stored, so a claimed payment is
+// never overwritten.
+func (r *kofiDonationRepository) Record(ctx context.Context, donation *models.KofiDonation) (bool, error) {
+	if donation.ReceivedAt.IsZero() {
+		donation.ReceivedAt = time.Now()
+	}
+	res, err := r.db.NewInsert().
+		Model(donation).
+		On("CONFLICT (message_id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to record ko-fi donation: %w", err)
+	}
+	affected, _ := res.RowsAffected()
+	return affected > 0, nil
+}
@@ -0,0 +1,89 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestKofiClaimIgnoresReplays(t *testing.T) {
+	db := dbtest.Open(t)
+	donations := repositories.NewKofiDonationRepository(db.BunDB())
+	users := repositories.NewUserRepository(db.BunDB())
+	ctx := context.Background()
+	createTestUser(t, db, "u1", 100)
+
+	receivedAt := time.Now().Truncate(time.Second)
+	donation := func() *models.KofiDonation {
+		return &models.KofiDonation{MessageID: "msg-1", UserID: "u1", Type: "Subscription", Amount: "5.00", Currency: "USD", Flakes: 500, PremiumDays: 30, ReceivedAt: receivedAt}
+	}
+
+	if claimed, err := donations.Claim(ctx, donation()); err != nil || !claimed {
+		t.Fatalf("Claim = %t, %v, want claimed", claimed, err)
+	}
+	// The same message delivered again pays nothing
+	if claimed, err := donations.Claim(ctx, donation()); err != nil || claimed {
+		t.Fatalf("replayed Claim = %t, %v, want not claimed", claimed, err)
+	}
+
+	user, err := users.GetByDiscordID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByDiscordID: %v", err)
+	}
+	if user.Balance != 600 {
+		t.Errorf("balance = %d, want 600", user.Balance)
+	}
+	if want := receivedAt.Add(30 * 24 * time.Hour); !user.Premium || !user.PremiumExpires.Equal(want) {
+		t.Errorf("premium = %t until %v, want until %v", user.Premium, user.PremiumExpires, want)
+	}
+
+	// A payment for an unknown user is rolled back so it can be claimed later
+	orphan := &models.KofiDonation{MessageID: "msg-2", UserID: "missing", Type: "Donation", Amount: "3.00", Currency: "USD", Flakes: 100}
+	if _, err := donations.Claim(ctx, orphan); !errors.Is(err, repositories.ErrUserNotFound) {
+		t.Fatalf("Claim for an unknown user = %v, want ErrUserNotFound", err)
+	}
+	if exists, err := db.BunDB().NewSelect().Model((*models.KofiDonation)(nil)).Where("message_id = ?", "msg-2").Exists(ctx); err != nil || exists {
+		t.Errorf("orphan donation recorded = %t, %v", exists, err)
+	}
+}
+
+func TestKofiRecordThenClaim(t *testing.T) {
+	db := dbtest.Open(t)
+	donations := repositories.NewKofiDonationRepository(db.BunDB())
+	ctx := context.Background()
+
+	unmatched := &models.KofiDonation{MessageID: "msg-1", UserID: "u1", Type: "Donation", Amount: "3.00", Currency: "USD", Flakes: 300, Status: models.KofiDonationUnmatched}
+	if recorded, err := donations.Record(ctx, unmatched); err != nil || !recorded {
+		t.Fatalf("Record = %t, %v, want recorded", recorded, err)
+	}
+	if recorded, err := donations.Record(ctx, unmatched); err != nil || recorded {
+		t.Errorf("second Record = %t, %v, want already stored", recorded, err)
+	}
+
+	// Once the user exists, a redelivery claims the stored payment
+	createTestUser(t, db, "u1", 0)
+	claim := &models.KofiDonation{MessageID: "msg-1", UserID: "u1", Type: "Donation", Amount: "3.00", Currency: "USD", Flakes: 300}
+	if claimed, err := donations.Claim(ctx, claim); err != nil || !claimed {
+		t.Fatalf("Claim = %t, %v, want claimed", claimed, err)
+	}
+	stored := new(models.KofiDonation)
+	if err := db.BunDB().NewSelect().Model(stored).Where("message_id = ?", "msg-1").Scan(ctx); err != nil {
+		t.Fatalf("select donation: %v", err)
+	}
+	if stored.Status != models.KofiDonationClaimed {
+		t.Errorf("status = %s, want claimed", stored.Status)
+	}
+
+	// A claimed payment is never downgraded or paid twice
+	if recorded, err := donations.Record(ctx, unmatched); err != nil || recorded {
+		t.Errorf("Record over a claim = %t, %v, want nothing stored", recorded, err)
+	}
+	if claimed, err := donations.Claim(ctx, claim); err != nil || claimed {
+		t.Errorf("second Claim = %t, %v, want not claimed", claimed, err)
+	}
+}
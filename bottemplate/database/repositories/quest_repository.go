@@ -22,16 +22,20 @@ type QuestRepository interface {
 
 	// User progress
 	GetActiveQuests(ctx context.Context, userID string) ([]*models.UserQuestProgress, error)
+	GetUserQuestOverview(ctx context.Context, userID string) ([]*models.UserQuestProgress, error)
 	GetQuestProgress(ctx context.Context, userID string, questID string) (*models.UserQuestProgress, error)
 	CreateQuestProgress(ctx context.Context, progress *models.UserQuestProgress) error
 	UpdateQuestProgress(ctx context.Context, progress *models.UserQuestProgress) error
 	GetUnclaimedQuests(ctx context.Context, userID string) ([]*models.UserQuestProgress, error)
+	ClaimCompletedQuests(ctx context.Context, userID string) ([]*models.UserQuestProgress, error)
 	GetCompletedQuestCount(ctx context.Context, userID string, questType string, since time.Time) (int, error)
 	DeleteExpiredQuests(ctx context.Context) error
 
 	// Leaderboards
 	GetLeaderboard(ctx context.Context, periodType string, periodStart time.Time, limit int) ([]*models.QuestLeaderboard, error)
 	UpdateLeaderboard(ctx context.Context, entry *models.QuestLeaderboard) error
+	AggregateLeaderboard(ctx context.Context, periodType string, periodStart, periodEnd time.Time) (int64, error)
+	GetLeaderboardPage(ctx context.Context, periodType string, periodStart time.Time, offset, limit int) ([]*models.QuestLeaderboard, int, error)
 	GetUserLeaderboardEntry(ctx context.Context, userID string, periodType string, periodStart time.Time) (*models.QuestLeaderboard, error)
 
 	// Quest chains
@@ -128,6 +132,28 @@ func (r *questRepository) GetActiveQuests(ctx context.Context, userID string) ([
 	return progress, nil
 }
 
+// GetUserQuestOverview returns all of a user's unexpired quests, claimed ones included, joined
+// with their definitions in a single query and ordered by type and tier
+func (r *questRepository) GetUserQuestOverview(ctx context.Context, userID string) ([]*models.UserQuestProgress, error) {
+	var progress []*models.UserQuestProgress
+	err := r.db.NewSelect().
+		Model(&progress).
+		Relation("QuestDefinition").
+		Where("uqp.user_id = ?", userID).
+		Where("uqp.expires_at > ?", time.Now()).
+		OrderExpr("quest_definition.type ASC, quest_definition.tier ASC, uqp.quest_id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		slog.Error("Failed to get quest overview",
+			slog.String("user_id", userID),
+			slog.Any("error", err))
+		return nil, err
+	}
+
+	return progress, nil
+}
+
 func (r *questRepository) GetQuestProgress(ctx context.Context, userID string, questID string) (*models.UserQuestProgress, error) {
 	progress := new(models.UserQuestProgress)
 	err := r.db.NewSelect().
@@ -191,6 +217,79 @@ func (r *questRepository) GetUnclaimedQuests(ctx context.Context, userID string)
 	return progress, err
 }
 
+// ClaimCompletedQuests marks every completed, unclaimed quest of a user as claimed and credits
+// the summed snowflake, vial and XP rewards in a single transaction. Concurrent claims are
+// serialized by the row locks taken by the update, so a quest is only ever rewarded once;
+// the returned slice is empty when nothing was left to claim.
+func (r *questRepository) ClaimCompletedQuests(ctx context.Context, userID string) ([]*models.UserQuestProgress, error) {
+	var claimed []*models.UserQuestProgress
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		now := time.Now()
+
+		var ids []int64
+		err := tx.NewUpdate().
+			Model((*models.UserQuestProgress)(nil)).
+			Set("claimed = ?", true).
+			Set("claimed_at = ?", now).
+			Set("updated_at = ?", now).
+			Where("user_id = ?", userID).
+			Where("completed = ?", true).
+			Where("claimed = ?", false).
+			Where("expires_at > ?", now).
+			Returning("id").
+			Scan(ctx, &ids)
+		if err != nil {
+			return fmt.Errorf("failed to mark quests claimed: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		err = tx.NewSelect().
+			Model(&claimed).
+			Relation("QuestDefinition").
+			Where("uqp.id IN (?)", bun.In(ids)).
+			Order("uqp.completed_at ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load claimed quests: %w", err)
+		}
+
+		var snowflakes, vials, xp int64
+		for _, quest := range claimed {
+			if quest.QuestDefinition == nil {
+				continue
+			}
+			snowflakes += quest.QuestDefinition.RewardSnowflakes
+			vials += int64(quest.QuestDefinition.RewardVials)
+			xp += int64(quest.QuestDefinition.RewardXP)
+		}
+
+		if snowflakes == 0 && vials == 0 && xp == 0 {
+			return nil
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*models.User)(nil)).
+			Set("balance = balance + ?", snowflakes).
+			Set("user_stats = jsonb_set(jsonb_set(COALESCE(user_stats, '{}'::jsonb), '{vials}', (COALESCE((user_stats->>'vials')::bigint, 0) + ?)::text::jsonb), '{xp}', (COALESCE((user_stats->>'xp')::bigint, 0) + ?)::text::jsonb)", vials, xp).
+			Set("updated_at = ?", now).
+			Where("discord_id = ?", userID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to credit quest rewards: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
 func (r *questRepository) GetCompletedQuestCount(ctx context.Context, userID string, questType string, since time.Time) (int, error) {
 	count, err := r.db.NewSelect().
 		Model((*models.UserQuestProgress)(nil)).
@@ -243,6 +342,53 @@ func (r *questRepository) UpdateLeaderboard(ctx context.Context, entry *models.Q
 	return err
 }
 
+// AggregateLeaderboard recomputes every user's completed-quest count and points for one period
+// from user_quest_progress and upserts them through the (period_type, period_start, user_id)
+// unique constraint. Points follow updateLeaderboard: 100 per quest tier.
+func (r *questRepository) AggregateLeaderboard(ctx context.Context, periodType string, periodStart, periodEnd time.Time) (int64, error) {
+	now := time.Now()
+	res, err := r.db.NewRaw(`
+		INSERT INTO quest_leaderboards (period_type, period_start, user_id, quests_completed, points_earned, created_at, updated_at)
+		SELECT ?, ?, uqp.user_id, COUNT(*), SUM(qd.tier * 100), ?, ?
+		FROM user_quest_progress uqp
+		JOIN quest_definitions qd ON qd.quest_id = uqp.quest_id
+		WHERE qd.type = ?
+			AND uqp.completed = true
+			AND uqp.completed_at >= ?
+			AND uqp.completed_at < ?
+		GROUP BY uqp.user_id
+		ON CONFLICT (period_type, period_start, user_id) DO UPDATE
+		SET quests_completed = EXCLUDED.quests_completed,
+			points_earned = EXCLUDED.points_earned,
+			updated_at = EXCLUDED.updated_at`,
+		periodType, periodStart, now, now, periodType, periodStart, periodEnd).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to aggregate %s leaderboard: %w", periodType, err)
+	}
+
+	rows, _ := res.RowsAffected()
+	return rows, nil
+}
+
+// GetLeaderboardPage returns one page of a period's leaderboard along with the total entry count
+func (r *questRepository) GetLeaderboardPage(ctx context.Context, periodType string, periodStart time.Time, offset, limit int) ([]*models.QuestLeaderboard, int, error) {
+	var entries []*models.QuestLeaderboard
+	total, err := r.db.NewSelect().
+		Model(&entries).
+		Where("period_type = ?", periodType).
+		Where("period_start = ?", periodStart).
+		Order("points_earned DESC", "quests_completed DESC", "user_id ASC").
+		Offset(offset).
+		Limit(limit).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
 func (r *questRepository) GetUserLeaderboardEntry(ctx context.Context, userID string, periodType string, periodStart time.Time) (*models.QuestLeaderboard, error) {
 	entry := new(models.QuestLeaderboard)
 	err := r.db.NewSelect().
@@ -0,0 +1,136 @@
+package repositories_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestClaimCompletedQuestsPaysOnce(t *testing.T) {
+	db := dbtest.Open(t)
+	repo := repositories.NewQuestRepository(db.BunDB())
+	ctx := context.Background()
+
+	createTestUser(t, db, "claimer", 0)
+	if err := repo.CreateQuestDefinition(ctx, &models.QuestDefinition{
+		QuestID:          "daily_test",
+		Name:             "Test",
+		Description:      "Test quest",
+		Tier:             1,
+		Type:             models.QuestTypeDaily,
+		Category:         "trainee",
+		RequirementType:  "claim",
+		RequirementCount: 1,
+		RewardSnowflakes: 500,
+		RewardVials:      20,
+		RewardXP:         10,
+	}); err != nil {
+		t.Fatalf("CreateQuestDefinition: %v", err)
+	}
+	completedAt := time.Now()
+	if err := repo.CreateQuestProgress(ctx, &models.UserQuestProgress{
+		UserID:          "claimer",
+		QuestID:         "daily_test",
+		CurrentProgress: 1,
+		Completed:       true,
+		CompletedAt:     &completedAt,
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("CreateQuestProgress: %v", err)
+	}
+
+	const claims = 10
+	var mu sync.Mutex
+	var paid int
+	var wg sync.WaitGroup
+	for i := 0; i < claims; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := repo.ClaimCompletedQuests(ctx, "claimer")
+			if err != nil {
+				t.Errorf("ClaimCompletedQuests: %v", err)
+				return
+			}
+			mu.Lock()
+			paid += len(claimed)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if paid != 1 {
+		t.Fatalf("quest was paid out %d times, want 1", paid)
+	}
+
+	user, err := repositories.NewUserRepository(db.BunDB()).GetByDiscordID(ctx, "claimer")
+	if err != nil {
+		t.Fatalf("GetByDiscordID: %v", err)
+	}
+	if user.Balance != 500 || user.UserStats.Vials != 20 {
+		t.Errorf("balance = %d, vials = %d, want 500 and 20", user.Balance, user.UserStats.Vials)
+	}
+}
+
+func TestAggregateLeaderboardUpserts(t *testing.T) {
+	db := dbtest.Open(t)
+	repo := repositories.NewQuestRepository(db.BunDB())
+	ctx := context.Background()
+
+	for _, def := range []*models.QuestDefinition{
+		{QuestID: "weekly_t1", Name: "T1", Description: "Tier 1", Tier: 1, Type: models.QuestTypeWeekly, Category: "trainee", RequirementType: "claim", RequirementCount: 1},
+		{QuestID: "weekly_t3", Name: "T3", Description: "Tier 3", Tier: 3, Type: models.QuestTypeWeekly, Category: "idol", RequirementType: "claim", RequirementCount: 1},
+	} {
+		if err := repo.CreateQuestDefinition(ctx, def); err != nil {
+			t.Fatalf("CreateQuestDefinition %s: %v", def.QuestID, err)
+		}
+	}
+
+	periodStart := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 0, 7)
+	complete := func(userID, questID string, at time.Time) {
+		t.Helper()
+		if err := repo.CreateQuestProgress(ctx, &models.UserQuestProgress{
+			UserID:      userID,
+			QuestID:     questID,
+			Completed:   true,
+			CompletedAt: &at,
+			ExpiresAt:   periodEnd,
+		}); err != nil {
+			t.Fatalf("CreateQuestProgress: %v", err)
+		}
+	}
+
+	complete("alice", "weekly_t1", periodStart.Add(time.Hour))
+	complete("bob", "weekly_t3", periodStart.Add(2*time.Hour))
+	complete("carol", "weekly_t3", periodStart.Add(-time.Hour)) // previous week
+
+	if _, err := repo.AggregateLeaderboard(ctx, models.QuestTypeWeekly, periodStart, periodEnd); err != nil {
+		t.Fatalf("first AggregateLeaderboard: %v", err)
+	}
+
+	// A later run updates the existing rows rather than inserting duplicates
+	complete("alice", "weekly_t3", periodStart.Add(3*time.Hour))
+	if _, err := repo.AggregateLeaderboard(ctx, models.QuestTypeWeekly, periodStart, periodEnd); err != nil {
+		t.Fatalf("second AggregateLeaderboard: %v", err)
+	}
+
+	entries, total, err := repo.GetLeaderboardPage(ctx, models.QuestTypeWeekly, periodStart, 0, 10)
+	if err != nil {
+		t.Fatalf("GetLeaderboardPage: %v", err)
+	}
+	if total != 2 || len(entries) != 2 {
+		t.Fatalf("got %d of %d entries, want 2 of 2", len(entries), total)
+	}
+	if entries[0].UserID != "alice" || entries[0].QuestsCompleted != 2 || entries[0].PointsEarned != 400 {
+		t.Errorf("first = %+v, want alice with 2 quests and 400 points", entries[0])
+	}
+	if entries[1].UserID != "bob" || entries[1].PointsEarned != 300 {
+		t.Errorf("second = %+v, want bob with 300 points", entries[1])
+	}
+}
@@ -0,0 +1,111 @@
+package repositories_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestDecrementShopStockConcurrent(t *testing.T) {
+	db := dbtest.Open(t)
+	repo := repositories.NewEffectRepository(db.BunDB())
+	ctx := context.Background()
+
+	if err := repo.EnsureShopStock(ctx, &models.ShopStock{
+		ItemID:        "judgeday",
+		Stock:         1,
+		MaxStock:      1,
+		RestockHours:  24,
+		NextRestockAt: time.Now().Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("EnsureShopStock: %v", err)
+	}
+
+	const buyers = 20
+	var sold atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reserved, err := repo.DecrementShopStock(ctx, "judgeday")
+			if err != nil {
+				t.Errorf("DecrementShopStock: %v", err)
+				return
+			}
+			if reserved {
+				sold.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := sold.Load(); got != 1 {
+		t.Fatalf("%d buyers got the last unit, want 1", got)
+	}
+
+	stock, err := repo.GetShopStock(ctx)
+	if err != nil {
+		t.Fatalf("GetShopStock: %v", err)
+	}
+	if len(stock) != 1 || stock[0].Stock != 0 {
+		t.Fatalf("stock = %+v, want one sold-out row", stock)
+	}
+
+	// A refund never pushes stock past its maximum
+	for i := 0; i < 2; i++ {
+		if err := repo.IncrementShopStock(ctx, "judgeday"); err != nil {
+			t.Fatalf("IncrementShopStock: %v", err)
+		}
+	}
+	stock, _ = repo.GetShopStock(ctx)
+	if stock[0].Stock != 1 {
+		t.Errorf("stock after refunds = %d, want 1", stock[0].Stock)
+	}
+}
+
+func TestRestockDueShopItems(t *testing.T) {
+	db := dbtest.Open(t)
+	repo := repositories.NewEffectRepository(db.BunDB())
+	ctx := context.Background()
+
+	for _, s := range []*models.ShopStock{
+		{ItemID: "judgeday", MaxStock: 5, RestockHours: 24, NextRestockAt: time.Now().Add(-time.Minute)},
+		{ItemID: "fandomfever", MaxStock: 10, RestockHours: 12, NextRestockAt: time.Now().Add(time.Hour)},
+	} {
+		if err := repo.EnsureShopStock(ctx, s); err != nil {
+			t.Fatalf("EnsureShopStock %s: %v", s.ItemID, err)
+		}
+	}
+
+	restocked, err := repo.RestockDueShopItems(ctx)
+	if err != nil {
+		t.Fatalf("RestockDueShopItems: %v", err)
+	}
+	if restocked != 1 {
+		t.Errorf("restocked %d items, want 1", restocked)
+	}
+
+	stock, err := repo.GetShopStock(ctx)
+	if err != nil {
+		t.Fatalf("GetShopStock: %v", err)
+	}
+	for _, s := range stock {
+		switch s.ItemID {
+		case "judgeday":
+			if s.Stock != 5 || !s.NextRestockAt.After(time.Now().Add(23*time.Hour)) {
+				t.Errorf("judgeday = %+v, want full stock and the next restock in a day", s)
+			}
+		case "fandomfever":
+			if s.Stock != 0 {
+				t.Errorf("fandomfever restocked early: %+v", s)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/uptrace/bun"
+)
+
+// ErrTransferLimitReached is returned when consuming would push a counter past its limit
+var ErrTransferLimitReached = errors.New("transfer limit reached")
+
+type TransferCounterRepository interface {
+	// Consume adds amount to the user's counter for the window containing now. It fails
+	// with ErrTransferLimitReached, leaving the counter unchanged, when the total would
+	// exceed limit. A limit of 0 or less is unlimited. It returns the new total.
+	Consume(ctx context.Context, userID, kind string, window time.Duration, amount, limit int64) (int64, error)
+	// Release gives back amount consumed in the current window, e.g. when the transfer failed
+	Release(ctx context.Context, userID, kind string, window time.Duration, amount int64) error
+	// Used returns the user's total for the window containing now
+	Used(ctx context.Context, userID, kind string, window time.Duration) (int64, error)
+}
+
+type transferCounterRepository struct {
+	db *bun.DB
+}
+
+func NewTransferCounterRepository(db *bun.DB) TransferCounterRepository {
+	return &transferCounterRepository{db: db}
+}
+
+// TransferWindowStart returns the start of the window containing t. Windows are aligned
+// to UTC, so a daily window resets at midnight UTC and an hourly one on the hour.
+func TransferWindowStart(t time.Time, window time.Duration) time.Time {
+	return t.UTC().Truncate(window)
+}
+
+// TransferWindowEnd returns when the window containing t resets
+func TransferWindowEnd(t time.Time, window time.Duration) time.Time {
+	return TransferWindowStart(t, window).Add(window)
+}
+
+func (r *transferCounterRepository) Consume(ctx context.Context, userID, kind string, window time.Duration, amount, limit int64) (int64, error) {
+	if limit > 0 && amount > limit {
+		return 0, ErrTransferLimitReached
+	}
+
+	now := time.Now()
+	counter := &models.TransferCounter{
+		UserID:      userID,
+		Kind:        kind,
+		WindowStart: TransferWindowStart(now, window),
+		Count:       amount,
+		UpdatedAt:   now,
+	}
+
+	query := r.db.NewInsert().
+		Model(counter).
+		On("CONFLICT (user_id, kind, window_start) DO UPDATE").
+		Set("count = tc.count + EXCLUDED.count").
+		Set("updated_at = EXCLUDED.updated_at")
+	if limit > 0 {
+		query = query.Where("tc.count + EXCLUDED.count <= ?", limit)
+	}
+
+	var total int64
+	if err := query.Returning("count").Scan(ctx, &total); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrTransferLimitReached
+		}
+		return 0, fmt.Errorf("failed to update %s counter: %w", kind, err)
+	}
+	return total, nil
+}
+
+func (r *transferCounterRepository) Release(ctx context.Context, userID, kind string, window time.Duration, amount int64) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.TransferCounter)(nil)).
+		Set("count = GREATEST(count - ?, 0)", amount).
+		Set("updated_at = ?", time.Now()).
+		Where("user_id = ? AND kind = ? AND window_start = ?", userID, kind, TransferWindowStart(time.Now(), window)).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to release %s counter: %w", kind, err)
+	}
+	return nil
+}
+
+func (r *transferCounterRepository) Used(ctx context.Context, userID, kind string, window time.Duration) (int64, error) {
+	var counter models.TransferCounter
+	err := r.db.NewSelect().
+		Model(&counter).
+		Where("user_id = ? AND kind = ? AND window_start = ?", userID, kind, TransferWindowStart(time.Now(), window)).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s counter: %w", kind, err)
+	}
+	return counter.Count, nil
+}
@@ -0,0 +1,63 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestTransferWindowBoundaries(t *testing.T) {
+	at := time.Date(2024, 3, 9, 23, 45, 0, 0, time.FixedZone("KST", 9*60*60))
+
+	// Daily windows follow UTC midnight, not the caller's zone
+	if got, want := repositories.TransferWindowStart(at, 24*time.Hour), time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("daily start = %s, want %s", got, want)
+	}
+	if got, want := repositories.TransferWindowEnd(at, time.Hour), time.Date(2024, 3, 9, 15, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("hourly end = %s, want %s", got, want)
+	}
+}
+
+func TestTransferCounterDailyCap(t *testing.T) {
+	db := dbtest.Open(t)
+	counters := repositories.NewTransferCounterRepository(db.BunDB())
+	ctx := context.Background()
+	const day = 24 * time.Hour
+
+	if total, err := counters.Consume(ctx, "u1", models.TransferKindGift, day, 3, 5); err != nil || total != 3 {
+		t.Fatalf("first Consume = %d, %v, want 3", total, err)
+	}
+	if total, err := counters.Consume(ctx, "u1", models.TransferKindGift, day, 2, 5); err != nil || total != 5 {
+		t.Fatalf("second Consume = %d, %v, want 5", total, err)
+	}
+	// Going past the cap fails and leaves the counter alone
+	if _, err := counters.Consume(ctx, "u1", models.TransferKindGift, day, 1, 5); !errors.Is(err, repositories.ErrTransferLimitReached) {
+		t.Fatalf("Consume past the cap = %v, want ErrTransferLimitReached", err)
+	}
+	if used, err := counters.Used(ctx, "u1", models.TransferKindGift, day); err != nil || used != 5 {
+		t.Errorf("Used = %d, %v, want 5", used, err)
+	}
+
+	// Trades and other users have their own counters
+	if _, err := counters.Consume(ctx, "u1", models.TransferKindTrade, time.Hour, 1, 5); err != nil {
+		t.Errorf("trade Consume: %v", err)
+	}
+	if used, _ := counters.Used(ctx, "u2", models.TransferKindGift, day); used != 0 {
+		t.Errorf("u2 used %d, want 0", used)
+	}
+
+	if err := counters.Release(ctx, "u1", models.TransferKindGift, day, 2); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if total, err := counters.Consume(ctx, "u1", models.TransferKindGift, day, 2, 5); err != nil || total != 5 {
+		t.Errorf("Consume after Release = %d, %v, want 5", total, err)
+	}
+	if _, err := counters.Consume(ctx, "u1", models.TransferKindGift, day, 6, 5); !errors.Is(err, repositories.ErrTransferLimitReached) {
+		t.Errorf("Consume above the whole cap = %v, want ErrTransferLimitReached", err)
+	}
+}
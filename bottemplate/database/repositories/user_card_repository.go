@@ -27,7 +27,62 @@ type UserCardRepository interface {
 	CleanupZeroAmountCards(ctx context.Context) error
 	GetUserCardsByName(ctx context.Context, userID string, cardName string) ([]*models.UserCard, error)
 	GetTotalOwnersCount(ctx context.Context, cardID int64) (int64, error)
+	GetAllOwnershipStats(ctx context.Context) (map[int64]CardOwnershipStats, error)
 	ToggleFavorite(ctx context.Context, userID string, cardID int64) (bool, error)
+	MergeDuplicates(ctx context.Context) ([]DuplicateMerge, error)
+	GetCardStats(ctx context.Context, userID string) (*UserCardStats, error)
+	GetLevelCounts(ctx context.Context, userID string) ([]LevelCount, error)
+	GetCollectionCompletion(ctx context.Context, userID string) ([]CollectionCompletion, error)
+}
+
+// UserCardStats is a user's card totals, counting copies and distinct cards
+type UserCardStats struct {
+	TotalCards    int64 `bun:"total_cards"`
+	DistinctCards int   `bun:"distinct_cards"`
+}
+
+// CardOwnershipStats is how widely one card is held across all players
+type CardOwnershipStats struct {
+	CardID int64 `bun:"card_id"`
+	Owners int64 `bun:"owners"` // distinct players holding at least one copy
+	Copies int64 `bun:"copies"` // copies held in total
+}
+
+// LevelCount is how many cards of one level a user owns
+type LevelCount struct {
+	Level         int   `bun:"level"`
+	DistinctCards int   `bun:"distinct_cards"`
+	Copies        int64 `bun:"copies"`
+}
+
+// CollectionCompletion is a user's progress in one collection. Only cards that
+// count towards completion are included: level 1 cards in fragment collections
+// and cards below level 5 elsewhere, matching CollectionService.
+type CollectionCompletion struct {
+	CollectionID string `bun:"collection_id"`
+	Name         string `bun:"name"`
+	TotalCards   int    `bun:"total_cards"`
+	OwnedCards   int    `bun:"owned_cards"`
+}
+
+// Percentage returns the completion in the range 0-100
+func (c CollectionCompletion) Percentage() float64 {
+	if c.TotalCards == 0 {
+		return 0
+	}
+	return float64(c.OwnedCards) * 100 / float64(c.TotalCards)
+}
+
+// IsCompleted reports whether every eligible card of the collection is owned
+func (c CollectionCompletion) IsCompleted() bool {
+	return c.TotalCards > 0 && c.OwnedCards >= c.TotalCards
+}
+
+// DuplicateMerge summarises the duplicate user_cards rows merged for a single user
+type DuplicateMerge struct {
+	UserID      string `bun:"user_id"`
+	CardsMerged int    `bun:"cards_merged"`
+	RowsRemoved int    `bun:"rows_removed"`
 }
 
 type userCardRepository struct {
@@ -294,6 +349,100 @@ func (r *userCardRepository) GetTotalOwnersCount(ctx context.Context, cardID int
 	return count, nil
 }
 
+// GetAllOwnershipStats returns the distinct owners and total copies of each card,
+// including zeroes for unowned cards, in a single grouped query
+func (r *userCardRepository) GetAllOwnershipStats(ctx context.Context) (map[int64]CardOwnershipStats, error) {
+	var rows []CardOwnershipStats
+	err := r.db.NewSelect().
+		TableExpr("cards AS c").
+		Join("LEFT JOIN user_cards AS uc ON uc.card_id = c.id AND uc.amount > 0").
+		ColumnExpr("c.id AS card_id").
+		ColumnExpr("COUNT(DISTINCT uc.user_id) AS owners").
+		ColumnExpr("COALESCE(SUM(uc.amount), 0) AS copies").
+		Where("c.deleted_at IS NULL").
+		GroupExpr("c.id").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card ownership stats: %w", err)
+	}
+
+	stats := make(map[int64]CardOwnershipStats, len(rows))
+	for _, row := range rows {
+		stats[row.CardID] = row
+	}
+	return stats, nil
+}
+
+// GetCardStats totals a user's cards without loading them
+func (r *userCardRepository) GetCardStats(ctx context.Context, userID string) (*UserCardStats, error) {
+	stats := new(UserCardStats)
+	err := r.db.NewSelect().
+		Model((*models.UserCard)(nil)).
+		ColumnExpr("COALESCE(SUM(amount), 0) AS total_cards").
+		ColumnExpr("COUNT(DISTINCT card_id) AS distinct_cards").
+		Where("user_id = ? AND amount > 0", userID).
+		Scan(ctx, stats)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card stats: %w", err)
+	}
+	return stats, nil
+}
+
+// GetLevelCounts groups a user's cards by card level, lowest level first
+func (r *userCardRepository) GetLevelCounts(ctx context.Context, userID string) ([]LevelCount, error) {
+	var counts []LevelCount
+	err := r.db.NewSelect().
+		TableExpr("user_cards AS uc").
+		Join("JOIN cards AS c ON c.id = uc.card_id").
+		ColumnExpr("c.level AS level").
+		ColumnExpr("COUNT(DISTINCT uc.card_id) AS distinct_cards").
+		ColumnExpr("SUM(uc.amount) AS copies").
+		Where("uc.user_id = ? AND uc.amount > 0", userID).
+		GroupExpr("c.level").
+		OrderExpr("c.level ASC").
+		Scan(ctx, &counts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get level counts: %w", err)
+	}
+	return counts, nil
+}
+
+// GetCollectionCompletion returns the user's progress in every collection they
+// own an eligible card of, most complete first
+func (r *userCardRepository) GetCollectionCompletion(ctx context.Context, userID string) ([]CollectionCompletion, error) {
+	var completions []CollectionCompletion
+	err := r.db.NewRaw(`
+		WITH eligible AS (
+			SELECT c.id, c.col_id
+			FROM cards c
+			JOIN collections col ON col.id = c.col_id
+			WHERE c.deleted_at IS NULL
+				AND ((col.fragments AND c.level = 1) OR (NOT col.fragments AND c.level < 5))
+		),
+		totals AS (
+			SELECT col_id, COUNT(*) AS total_cards
+			FROM eligible
+			GROUP BY col_id
+		),
+		owned AS (
+			SELECT e.col_id, COUNT(DISTINCT e.id) AS owned_cards
+			FROM eligible e
+			JOIN user_cards uc ON uc.card_id = e.id
+			WHERE uc.user_id = ? AND uc.amount > 0
+			GROUP BY e.col_id
+		)
+		SELECT o.col_id AS collection_id, col.name, t.total_cards, o.owned_cards
+		FROM owned o
+		JOIN totals t ON t.col_id = o.col_id
+		JOIN collections col ON col.id = o.col_id
+		ORDER BY o.owned_cards::float8 / t.total_cards DESC, o.owned_cards DESC, o.col_id
+	`, userID).Scan(ctx, &completions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection completion: %w", err)
+	}
+	return completions, nil
+}
+
 func (r *userCardRepository) ToggleFavorite(ctx context.Context, userID string, cardID int64) (bool, error) {
 	// First get the current favorite status
 	userCard, err := r.GetUserCard(ctx, userID, cardID)
@@ -318,3 +467,61 @@ func (r *userCardRepository) ToggleFavorite(ctx context.Context, userID string,
 
 	return newFavoriteStatus, nil
 }
+
+// MergeDuplicates collapses every set of user_cards rows sharing a user_id and card_id into
+// the oldest row: amount and exp are summed, the earliest obtained date is kept and the
+// favorite/locked flags are OR-ed. The update and delete run as one statement so either
+// every group is merged or none is.
+func (r *userCardRepository) MergeDuplicates(ctx context.Context) ([]DuplicateMerge, error) {
+	var merges []DuplicateMerge
+	err := r.db.NewRaw(`
+		WITH groups AS (
+			SELECT user_id,
+			       card_id,
+			       MIN(id) AS keep_id,
+			       COUNT(*) AS row_count,
+			       SUM(amount) AS total_amount,
+			       SUM(exp) AS total_exp,
+			       MAX(level) AS max_level,
+			       MAX(rating) AS max_rating,
+			       bool_or(favorite) AS is_favorite,
+			       bool_or(locked) AS is_locked,
+			       MIN(obtained) AS first_obtained,
+			       string_agg(DISTINCT NULLIF(mark, ''), ', ') AS combined_marks
+			FROM user_cards
+			GROUP BY user_id, card_id
+			HAVING COUNT(*) > 1
+		), merged AS (
+			UPDATE user_cards AS uc
+			SET amount = g.total_amount,
+			    exp = g.total_exp,
+			    level = g.max_level,
+			    rating = g.max_rating,
+			    favorite = g.is_favorite,
+			    locked = g.is_locked,
+			    obtained = g.first_obtained,
+			    mark = COALESCE(g.combined_marks, uc.mark),
+			    updated_at = ?
+			FROM groups AS g
+			WHERE uc.id = g.keep_id
+			RETURNING uc.id
+		), removed AS (
+			DELETE FROM user_cards AS uc
+			USING groups AS g
+			WHERE uc.user_id = g.user_id AND uc.card_id = g.card_id AND uc.id <> g.keep_id
+			RETURNING uc.id
+		)
+		SELECT g.user_id,
+		       COUNT(*) AS cards_merged,
+		       SUM(g.row_count - 1) AS rows_removed
+		FROM groups AS g
+		JOIN merged AS m ON m.id = g.keep_id
+		GROUP BY g.user_id
+		ORDER BY rows_removed DESC, g.user_id`, time.Now()).
+		Scan(ctx, &merges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge duplicate user cards: %w", err)
+	}
+
+	return merges, nil
+}
@@ -0,0 +1,103 @@
+package repositories_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestMergeDuplicates(t *testing.T) {
+	db := dbtest.Open(t)
+	repo := repositories.NewUserCardRepository(db.BunDB())
+	ctx := context.Background()
+
+	// Duplicates predate the unique constraint; the next schema init merges and restores it
+	if _, err := db.BunDB().ExecContext(ctx, `ALTER TABLE user_cards DROP CONSTRAINT IF EXISTS user_cards_user_card_unique`); err != nil {
+		t.Fatalf("drop constraint: %v", err)
+	}
+
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := []*models.UserCard{
+		{UserID: "u1", CardID: 1, Level: 1, Amount: 1, Exp: 10, Obtained: first.Add(time.Hour)},
+		{UserID: "u1", CardID: 1, Level: 3, Amount: 2, Exp: 5, Favorite: true, Obtained: first},
+		{UserID: "u1", CardID: 1, Level: 2, Amount: 1, Locked: true, Mark: "gift", Obtained: first.Add(2 * time.Hour)},
+		{UserID: "u1", CardID: 2, Level: 1, Amount: 1, Obtained: first},
+		{UserID: "u1", CardID: 2, Level: 1, Amount: 1, Obtained: first},
+		{UserID: "u2", CardID: 1, Level: 1, Amount: 1, Obtained: first},
+		{UserID: "u2", CardID: 1, Level: 1, Amount: 4, Obtained: first},
+		{UserID: "u3", CardID: 1, Level: 1, Amount: 1, Obtained: first},
+	}
+	for _, row := range rows {
+		if err := repo.Create(ctx, row); err != nil {
+			t.Fatalf("create user card: %v", err)
+		}
+	}
+
+	merges, err := repo.MergeDuplicates(ctx)
+	if err != nil {
+		t.Fatalf("MergeDuplicates: %v", err)
+	}
+	want := []repositories.DuplicateMerge{
+		{UserID: "u1", CardsMerged: 2, RowsRemoved: 3},
+		{UserID: "u2", CardsMerged: 1, RowsRemoved: 1},
+	}
+	if !reflect.DeepEqual(merges, want) {
+		t.Errorf("merges = %+v, want %+v", merges, want)
+	}
+
+	count, err := db.BunDB().NewSelect().Model((*models.UserCard)(nil)).Count(ctx)
+	if err != nil || count != 4 {
+		t.Fatalf("%d user cards left (%v), want one per user and card", count, err)
+	}
+
+	merged, err := repo.GetByUserIDAndCardID(ctx, "u1", 1)
+	if err != nil {
+		t.Fatalf("GetByUserIDAndCardID: %v", err)
+	}
+	if merged.ID != rows[0].ID || merged.Amount != 4 || merged.Exp != 15 || merged.Level != 3 ||
+		!merged.Favorite || !merged.Locked || merged.Mark != "gift" || !merged.Obtained.Equal(first) {
+		t.Errorf("merged row = %+v", merged)
+	}
+
+	// Nothing is left to merge on a second run
+	if merges, err := repo.MergeDuplicates(ctx); err != nil || len(merges) != 0 {
+		t.Errorf("second MergeDuplicates = %+v, %v, want no merges", merges, err)
+	}
+}
+
+func TestGetAllOwnershipStats(t *testing.T) {
+	db := dbtest.Open(t)
+	userCards := repositories.NewUserCardRepository(db.BunDB())
+	ctx := context.Background()
+
+	for id := int64(1); id <= 4; id++ {
+		createTestCard(t, db, id, "card", "twice", 1)
+	}
+	giveTestCard(t, db, "u1", 1, 3)
+	giveTestCard(t, db, "u2", 1, 1)
+	giveTestCard(t, db, "u1", 2, 1)
+	// Zero copies and deleted cards don't count
+	giveTestCard(t, db, "u2", 2, 0)
+	giveTestCard(t, db, "u1", 4, 1)
+	if _, err := repositories.NewCardRepository(db.BunDB()).SoftDelete(ctx, 4); err != nil {
+		t.Fatalf("SoftDelete: %v", err)
+	}
+
+	stats, err := userCards.GetAllOwnershipStats(ctx)
+	if err != nil {
+		t.Fatalf("GetAllOwnershipStats: %v", err)
+	}
+	want := map[int64]repositories.CardOwnershipStats{
+		1: {CardID: 1, Owners: 2, Copies: 4},
+		2: {CardID: 2, Owners: 1, Copies: 1},
+		3: {CardID: 3},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("ownership stats = %v, want %v", stats, want)
+	}
+}
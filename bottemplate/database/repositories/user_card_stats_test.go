@@ -0,0 +1,98 @@
+package repositories_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestCollectionCompletionPercentage(t *testing.T) {
+	tests := []struct {
+		completion repositories.CollectionCompletion
+		percentage float64
+		completed  bool
+	}{
+		{repositories.CollectionCompletion{TotalCards: 4, OwnedCards: 1}, 25, false},
+		{repositories.CollectionCompletion{TotalCards: 4, OwnedCards: 4}, 100, true},
+		{repositories.CollectionCompletion{}, 0, false},
+	}
+	for _, tt := range tests {
+		if got := tt.completion.Percentage(); got != tt.percentage {
+			t.Errorf("%+v Percentage = %v, want %v", tt.completion, got, tt.percentage)
+		}
+		if got := tt.completion.IsCompleted(); got != tt.completed {
+			t.Errorf("%+v IsCompleted = %v, want %v", tt.completion, got, tt.completed)
+		}
+	}
+}
+
+func TestUserCardAggregates(t *testing.T) {
+	db := dbtest.Open(t)
+	repo := repositories.NewUserCardRepository(db.BunDB())
+	ctx := context.Background()
+
+	createTestUser(t, db, "u1", 0)
+	createTestUser(t, db, "u2", 0)
+	createTestCard(t, db, 1, "nayeon", "twice", 1)
+	createTestCard(t, db, 2, "momo", "twice", 3)
+	createTestCard(t, db, 3, "sana", "twice", 3)
+	// Level 5 cards don't count towards completion
+	createTestCard(t, db, 4, "mina", "twice", 5)
+	createTestCard(t, db, 5, "wonyoung", "ive", 1)
+	createTestCard(t, db, 6, "yujin", "ive", 2)
+	// Only level 1 cards count in fragment collections
+	createTestCard(t, db, 7, "karina", "aespa", 1)
+	createTestCard(t, db, 8, "winter", "aespa", 2)
+	if _, err := db.BunDB().ExecContext(ctx, `UPDATE collections SET fragments = true WHERE id = 'aespa'`); err != nil {
+		t.Fatalf("mark fragments: %v", err)
+	}
+
+	giveTestCard(t, db, "u1", 1, 2)
+	giveTestCard(t, db, "u1", 2, 1)
+	giveTestCard(t, db, "u1", 3, 3)
+	giveTestCard(t, db, "u1", 4, 1)
+	giveTestCard(t, db, "u1", 5, 1)
+	giveTestCard(t, db, "u1", 7, 1)
+	giveTestCard(t, db, "u2", 6, 5)
+
+	stats, err := repo.GetCardStats(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetCardStats: %v", err)
+	}
+	if stats.TotalCards != 9 || stats.DistinctCards != 6 {
+		t.Errorf("stats = %+v, want 9 copies of 6 cards", stats)
+	}
+	if empty, err := repo.GetCardStats(ctx, "u3"); err != nil || empty.TotalCards != 0 || empty.DistinctCards != 0 {
+		t.Errorf("stats without cards = %+v, %v, want zero", empty, err)
+	}
+
+	levels, err := repo.GetLevelCounts(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetLevelCounts: %v", err)
+	}
+	wantLevels := []repositories.LevelCount{
+		{Level: 1, DistinctCards: 3, Copies: 4},
+		{Level: 3, DistinctCards: 2, Copies: 4},
+		{Level: 5, DistinctCards: 1, Copies: 1},
+	}
+	if !reflect.DeepEqual(levels, wantLevels) {
+		t.Errorf("levels = %+v, want %+v", levels, wantLevels)
+	}
+
+	completions, err := repo.GetCollectionCompletion(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetCollectionCompletion: %v", err)
+	}
+	wantCompletions := []repositories.CollectionCompletion{
+		// Ties on percentage put the bigger collection first
+		{CollectionID: "twice", Name: "twice", TotalCards: 3, OwnedCards: 3},
+		{CollectionID: "aespa", Name: "aespa", TotalCards: 1, OwnedCards: 1},
+		{CollectionID: "ive", Name: "ive", TotalCards: 2, OwnedCards: 1},
+	}
+	if !reflect.DeepEqual(completions, wantCompletions) {
+		t.Errorf("completions = %+v, want %+v", completions, wantCompletions)
+	}
+}
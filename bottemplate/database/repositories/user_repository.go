@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -13,6 +14,9 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// ErrUserNotFound is returned by writes that target a Discord ID with no user row
+var ErrUserNotFound = errors.New("user not found")
+
 type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	GetByDiscordID(ctx context.Context, discordID string) (*models.User, error)
@@ -22,12 +26,25 @@ type UserRepository interface {
 	AddVials(ctx context.Context, discordID string, amount int64) error
 	AddXP(ctx context.Context, discordID string, amount int64) error
 	UpdateLastDaily(ctx context.Context, discordID string) error
+	ResetDaily(ctx context.Context, db bun.IDB, discordID string) error
+	ResetAllDaily(ctx context.Context, db bun.IDB) (int64, error)
 	GetTopUsers(ctx context.Context, limit int) ([]*models.User, error)
 	GetUsers(ctx context.Context) ([]*models.User, error)
 	UpdateLastWork(ctx context.Context, discordID string) error
+	UpdateLastSummon(ctx context.Context, discordID string) error
+	ClaimVote(ctx context.Context, db bun.IDB, discordID string, cooldown time.Duration, now time.Time) (bool, error)
+	DecayPromoExp(ctx context.Context, window time.Duration, now time.Time) (PromoExpDecayResult, error)
+	ExpirePremium(ctx context.Context, now time.Time) (int64, error)
+	UpdateBan(ctx context.Context, discordID string, ban models.BanInfo) error
 	GetBalance(ctx context.Context, userID string) (int64, error)
 	GetUserCount(ctx context.Context) (int64, error)
 	UpdateLastCard(ctx context.Context, discordID string, cardID int64) error
+	// UpdateHero saves the user's hero slots if nobody changed them since previousChange.
+	// It reports false when the heroes were changed in the meantime.
+	UpdateHero(ctx context.Context, user *models.User, previousChange time.Time) (bool, error)
+	// SetNotificationPreference switches one notification preference, keyed by its JSON key.
+	// Users without saved preferences start from the defaults.
+	SetNotificationPreference(ctx context.Context, discordID string, key string, enabled bool) error
 }
 
 type userRepository struct {
@@ -152,6 +169,94 @@ func (r *userRepository) UpdateLastDaily(ctx context.Context, discordID string)
 	return err
 }
 
+// ClaimVote sets last_vote to now unless the user already voted within cooldown, and
+// reports whether it did. The check and the update are one statement, so concurrent
+// deliveries of the same vote cannot both claim it. db may be a transaction; nil uses
+// the repository's DB.
+func (r *userRepository) ClaimVote(ctx context.Context, db bun.IDB, discordID string, cooldown time.Duration, now time.Time) (bool, error) {
+	if db == nil {
+		db = r.db
+	}
+
+	result, err := db.NewUpdate().
+		Model((*models.User)(nil)).
+		Set("last_vote = ?", now).
+		Set("updated_at = ?", now).
+		Where("discord_id = ?", discordID).
+		Where("last_vote <= ?", now.Add(-cooldown)).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim vote: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim vote: %w", err)
+	}
+	if rows > 0 {
+		return true, nil
+	}
+
+	exists, err := db.NewSelect().
+		Model((*models.User)(nil)).
+		Where("discord_id = ?", discordID).
+		Exists(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim vote: %w", err)
+	}
+	if !exists {
+		return false, fmt.Errorf("%w: %s", ErrUserNotFound, discordID)
+	}
+	return false, nil
+}
+
+// dailyResetTime is stored as last_daily when a daily is reset, so the next
+// /daily is always off cooldown
+var dailyResetTime = time.Unix(0, 0)
+
+// ResetDaily clears one user's daily cooldown. db may be a transaction; nil uses the repository's DB.
+func (r *userRepository) ResetDaily(ctx context.Context, db bun.IDB, discordID string) error {
+	if db == nil {
+		db = r.db
+	}
+
+	result, err := db.NewUpdate().
+		Model((*models.User)(nil)).
+		Set("last_daily = ?", dailyResetTime).
+		Set("updated_at = ?", time.Now()).
+		Where("discord_id = ?", discordID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reset daily: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, discordID)
+	}
+	return nil
+}
+
+// ResetAllDaily clears every user's daily cooldown in a single UPDATE and returns
+// the number of users affected. db may be a transaction; nil uses the repository's DB.
+func (r *userRepository) ResetAllDaily(ctx context.Context, db bun.IDB) (int64, error) {
+	if db == nil {
+		db = r.db
+	}
+
+	result, err := db.NewUpdate().
+		Model((*models.User)(nil)).
+		Set("last_daily = ?", dailyResetTime).
+		Set("updated_at = ?", time.Now()).
+		Where("last_daily > ?", dailyResetTime).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset all dailies: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reset dailies: %w", err)
+	}
+	return rows, nil
+}
+
 func (r *userRepository) GetTopUsers(ctx context.Context, limit int) ([]*models.User, error) {
 	var users []*models.User
 	err := r.db.NewSelect().
@@ -202,6 +307,97 @@ func (r *userRepository) UpdateLastWork(ctx context.Context, discordID string) e
 	return nil
 }
 
+func (r *userRepository) UpdateLastSummon(ctx context.Context, discordID string) error {
+	_, err := r.db.NewUpdate().
+		Model((*models.User)(nil)).
+		Set("last_summon = ?", time.Now()).
+		Where("discord_id = ?", discordID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update last_summon: %w", err)
+	}
+	return nil
+}
+
+// PromoExpDecayResult counts the users touched by one promo EXP decay pass
+type PromoExpDecayResult struct {
+	Scheduled int64 // users whose promo EXP got an expiry for the first time
+	Expired   int64 // users whose promo EXP lapsed and was cleared
+}
+
+// DecayPromoExp clears promo EXP whose expiry has passed and gives promo EXP
+// without an expiry one that ends window after now. Running it again at the
+// same time changes nothing, so overlapping passes are safe.
+func (r *userRepository) DecayPromoExp(ctx context.Context, window time.Duration, now time.Time) (PromoExpDecayResult, error) {
+	var result PromoExpDecayResult
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		expired, err := tx.NewUpdate().
+			Model((*models.User)(nil)).
+			Set("promo_exp = 0").
+			Set("promo_exp_expires_at = NULL").
+			Set("updated_at = ?", now).
+			Where("promo_exp_expires_at IS NOT NULL").
+			Where("promo_exp_expires_at <= ?", now).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to expire promo exp: %w", err)
+		}
+		if result.Expired, err = expired.RowsAffected(); err != nil {
+			return fmt.Errorf("failed to count expired promo exp: %w", err)
+		}
+
+		scheduled, err := tx.NewUpdate().
+			Model((*models.User)(nil)).
+			Set("promo_exp_expires_at = ?", now.Add(window)).
+			Where("promo_exp > 0").
+			Where("promo_exp_expires_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to schedule promo exp expiry: %w", err)
+		}
+		if result.Scheduled, err = scheduled.RowsAffected(); err != nil {
+			return fmt.Errorf("failed to count scheduled promo exp: %w", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// ExpirePremium turns premium off for users whose premium_expires has passed and
+// returns how many were changed. A zero expiry means premium never runs out.
+func (r *userRepository) ExpirePremium(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.db.NewUpdate().
+		Model((*models.User)(nil)).
+		Set("premium = false").
+		Set("updated_at = ?", now).
+		Where("premium = true").
+		Where("premium_expires > ?", time.Time{}).
+		Where("premium_expires <= ?", now).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire premium: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// UpdateBan replaces the user's ban state
+func (r *userRepository) UpdateBan(ctx context.Context, discordID string, ban models.BanInfo) error {
+	res, err := r.db.NewUpdate().
+		Model((*models.User)(nil)).
+		Set("ban = ?", ban).
+		Set("updated_at = ?", time.Now()).
+		Where("discord_id = ?", discordID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update ban: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, discordID)
+	}
+	return nil
+}
+
 func (r *userRepository) GetBalance(ctx context.Context, userID string) (int64, error) {
 	var user models.User
 	err := r.db.NewSelect().
@@ -256,3 +452,48 @@ func (r *userRepository) UpdateLastCard(ctx context.Context, discordID string, c
 
 	return nil
 }
+
+func (r *userRepository) UpdateHero(ctx context.Context, user *models.User, previousChange time.Time) (bool, error) {
+	user.UpdatedAt = time.Now()
+	result, err := r.db.NewUpdate().
+		Model(user).
+		Column("hero_slots", "hero_cooldown", "hero", "hero_changed", "updated_at").
+		Where("discord_id = ?", user.DiscordID).
+		Where("hero_changed IS NOT DISTINCT FROM ?", previousChange).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to update hero: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update hero: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *userRepository) SetNotificationPreference(ctx context.Context, discordID string, key string, enabled bool) error {
+	defaults, err := json.Marshal(models.DefaultPreferences())
+	if err != nil {
+		return fmt.Errorf("failed to encode default preferences: %w", err)
+	}
+
+	// jsonb_set changes only this key, so concurrent toggles of different keys don't overwrite each other
+	result, err := r.db.NewUpdate().
+		Model((*models.User)(nil)).
+		Set("preferences = jsonb_set(COALESCE(preferences, ?::jsonb), ?::text[], to_jsonb(?::boolean))",
+			string(defaults), "{notifications,"+key+"}", enabled).
+		Set("updated_at = ?", time.Now()).
+		Where("discord_id = ?", discordID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update notification preference: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update notification preference: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, discordID)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestResetDailySingleAndAll(t *testing.T) {
+	db := dbtest.Open(t)
+	users := repositories.NewUserRepository(db.BunDB())
+	claims := repositories.NewClaimRepository(db.BunDB())
+	ctx := context.Background()
+
+	for _, id := range []string{"u1", "u2", "u3"} {
+		createTestUser(t, db, id, 0)
+		stats := &models.ClaimStats{UserID: id, DailyClaims: 4, UpdatedAt: time.Now()}
+		if _, err := db.BunDB().NewInsert().Model(stats).Exec(ctx); err != nil {
+			t.Fatalf("create claim stats: %v", err)
+		}
+	}
+	onCooldown := func(id string) bool {
+		t.Helper()
+		user, err := users.GetByDiscordID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetByDiscordID(%s): %v", id, err)
+		}
+		return time.Since(user.LastDaily) < 24*time.Hour
+	}
+
+	// A single reset only touches its target
+	if err := users.ResetDaily(ctx, nil, "u1"); err != nil {
+		t.Fatalf("ResetDaily: %v", err)
+	}
+	if onCooldown("u1") || !onCooldown("u2") || !onCooldown("u3") {
+		t.Error("ResetDaily touched more than its target")
+	}
+	if err := users.ResetDaily(ctx, nil, "nobody"); err == nil {
+		t.Error("ResetDaily of an unknown user succeeded")
+	}
+
+	tx, err := db.BunDB().BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	if err := claims.ResetAllDailyClaims(ctx, tx); err != nil {
+		t.Fatalf("ResetAllDailyClaims: %v", err)
+	}
+	count, err := users.ResetAllDaily(ctx, tx)
+	if err != nil {
+		t.Fatalf("ResetAllDaily: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	// u1 was already reset, so only the other two count
+	if count != 2 {
+		t.Errorf("ResetAllDaily reset %d users, want 2", count)
+	}
+	for _, id := range []string{"u1", "u2", "u3"} {
+		if onCooldown(id) {
+			t.Errorf("%s still on cooldown after the global reset", id)
+		}
+	}
+	var dailyClaims int
+	if err := db.BunDB().NewSelect().Model((*models.ClaimStats)(nil)).ColumnExpr("COALESCE(SUM(daily_claims), 0)").Scan(ctx, &dailyClaims); err != nil {
+		t.Fatalf("sum daily claims: %v", err)
+	}
+	if dailyClaims != 0 {
+		t.Errorf("%d daily claims left, want 0", dailyClaims)
+	}
+
+	if count, err := users.ResetAllDaily(ctx, nil); err != nil || count != 0 {
+		t.Errorf("second ResetAllDaily = %d, %v, want 0", count, err)
+	}
+}
+
+func TestDecayPromoExp(t *testing.T) {
+	db := dbtest.Open(t)
+	users := repositories.NewUserRepository(db.BunDB())
+	ctx := context.Background()
+
+	createTestUser(t, db, "holder", 0)
+	createTestUser(t, db, "none", 0)
+	if _, err := db.BunDB().NewUpdate().Model((*models.User)(nil)).Set("promo_exp = 300").Where("discord_id = ?", "holder").Exec(ctx); err != nil {
+		t.Fatalf("give promo exp: %v", err)
+	}
+
+	const window = 7 * 24 * time.Hour
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	promoExp := func() (int64, time.Time) {
+		t.Helper()
+		user, err := users.GetByDiscordID(ctx, "holder")
+		if err != nil {
+			t.Fatalf("GetByDiscordID: %v", err)
+		}
+		return user.PromoExp, user.PromoExpExpiresAt
+	}
+
+	// The first pass only schedules the expiry
+	result, err := users.DecayPromoExp(ctx, window, start)
+	if err != nil {
+		t.Fatalf("DecayPromoExp: %v", err)
+	}
+	if result != (repositories.PromoExpDecayResult{Scheduled: 1}) {
+		t.Errorf("first pass = %+v, want one scheduled", result)
+	}
+	if exp, expiresAt := promoExp(); exp != 300 || !expiresAt.Equal(start.Add(window)) {
+		t.Errorf("promo exp = %d expiring %v, want 300 expiring %v", exp, expiresAt, start.Add(window))
+	}
+
+	// Passes before the expiry change nothing
+	if result, err := users.DecayPromoExp(ctx, window, start.Add(window-time.Minute)); err != nil || result != (repositories.PromoExpDecayResult{}) {
+		t.Errorf("pass before expiry = %+v, %v, want no changes", result, err)
+	}
+
+	result, err = users.DecayPromoExp(ctx, window, start.Add(window))
+	if err != nil {
+		t.Fatalf("DecayPromoExp: %v", err)
+	}
+	if result != (repositories.PromoExpDecayResult{Expired: 1}) {
+		t.Errorf("pass at expiry = %+v, want one expired", result)
+	}
+	if exp, expiresAt := promoExp(); exp != 0 || !expiresAt.IsZero() {
+		t.Errorf("promo exp = %d expiring %v, want it cleared", exp, expiresAt)
+	}
+}
+
+func TestExpirePremium(t *testing.T) {
+	db := dbtest.Open(t)
+	users := repositories.NewUserRepository(db.BunDB())
+	ctx := context.Background()
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	premium := map[string]time.Time{
+		"expired":  now.Add(-time.Minute),
+		"active":   now.Add(time.Hour),
+		"lifetime": {},
+	}
+	for id, expires := range premium {
+		createTestUser(t, db, id, 0)
+		_, err := db.BunDB().NewUpdate().Model((*models.User)(nil)).
+			Set("premium = true").
+			Set("premium_expires = ?", expires).
+			Where("discord_id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			t.Fatalf("give %s premium: %v", id, err)
+		}
+	}
+
+	expired, err := users.ExpirePremium(ctx, now)
+	if err != nil || expired != 1 {
+		t.Fatalf("ExpirePremium = %d, %v, want 1", expired, err)
+	}
+	for id := range premium {
+		user, err := users.GetByDiscordID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetByDiscordID(%s): %v", id, err)
+		}
+		if want := id != "expired"; user.Premium != want {
+			t.Errorf("%s premium = %t, want %t", id, user.Premium, want)
+		}
+	}
+}
+
+func TestUpdateBan(t *testing.T) {
+	db := dbtest.Open(t)
+	users := repositories.NewUserRepository(db.BunDB())
+	ctx := context.Background()
+	createTestUser(t, db, "u1", 0)
+
+	until := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	ban := models.BanInfo{Full: true, Reason: "alts", Until: until, BannedBy: "admin"}
+	if err := users.UpdateBan(ctx, "u1", ban); err != nil {
+		t.Fatalf("UpdateBan: %v", err)
+	}
+	user, err := users.GetByDiscordID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByDiscordID: %v", err)
+	}
+	if got := user.Ban; !got.Full || got.Reason != "alts" || !got.Until.Equal(until) || got.BannedBy != "admin" {
+		t.Errorf("ban = %+v, want %+v", got, ban)
+	}
+
+	if err := users.UpdateBan(ctx, "missing", ban); err == nil {
+		t.Error("UpdateBan of an unknown user succeeded")
+	}
+}
+
+func TestClaimVoteCooldown(t *testing.T) {
+	db := dbtest.Open(t)
+	users := repositories.NewUserRepository(db.BunDB())
+	ctx := context.Background()
+	createTestUser(t, db, "u1", 0)
+
+	const cooldown = 12 * time.Hour
+	now := time.Now().Add(time.Minute) // createTestUser votes at creation time
+
+	if claimed, err := users.ClaimVote(ctx, nil, "u1", cooldown, now.Add(cooldown)); err != nil || !claimed {
+		t.Fatalf("first ClaimVote = %t, %v, want claimed", claimed, err)
+	}
+	// A redelivered vote within the cooldown is not claimed again
+	if claimed, err := users.ClaimVote(ctx, nil, "u1", cooldown, now.Add(cooldown+time.Hour)); err != nil || claimed {
+		t.Errorf("ClaimVote within the cooldown = %t, %v, want not claimed", claimed, err)
+	}
+	if claimed, err := users.ClaimVote(ctx, nil, "u1", cooldown, now.Add(2*cooldown)); err != nil || !claimed {
+		t.Errorf("ClaimVote after the cooldown = %t, %v, want claimed", claimed, err)
+	}
+	if _, err := users.ClaimVote(ctx, nil, "missing", cooldown, now); !errors.Is(err, repositories.ErrUserNotFound) {
+		t.Errorf("ClaimVote for an unknown user = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestUpdateHeroRejectsConcurrentChanges(t *testing.T) {
+	db := dbtest.Open(t)
+	users := repositories.NewUserRepository(db.BunDB())
+	ctx := context.Background()
+	createTestUser(t, db, "u1", 0)
+
+	first, err := users.GetByDiscordID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByDiscordID: %v", err)
+	}
+	second, _ := users.GetByDiscordID(ctx, "u1")
+	previous := first.HeroChanged
+
+	now := time.Now().UTC().Truncate(time.Second)
+	first.HeroSlots, first.Hero, first.HeroChanged = []string{"11"}, "11", now
+	if ok, err := users.UpdateHero(ctx, first, previous); err != nil || !ok {
+		t.Fatalf("UpdateHero = %t, %v, want saved", ok, err)
+	}
+
+	// The second copy was loaded before the first save and must not overwrite it
+	second.HeroSlots, second.Hero, second.HeroChanged = []string{"22"}, "22", now.Add(time.Second)
+	if ok, err := users.UpdateHero(ctx, second, previous); err != nil || ok {
+		t.Errorf("stale UpdateHero = %t, %v, want rejected", ok, err)
+	}
+
+	saved, err := users.GetByDiscordID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByDiscordID: %v", err)
+	}
+	if saved.Hero != "11" || len(saved.HeroSlots) != 1 || saved.HeroSlots[0] != "11" {
+		t.Errorf("hero = %s %v, want the first change", saved.Hero, saved.HeroSlots)
+	}
+}
+
+func TestSetNotificationPreference(t *testing.T) {
+	db := dbtest.Open(t)
+	users := repositories.NewUserRepository(db.BunDB())
+	ctx := context.Background()
+	createTestUser(t, db, "u1", 0)
+
+	settings := func() models.NotificationPreferences {
+		t.Helper()
+		user, err := users.GetByDiscordID(ctx, "u1")
+		if err != nil {
+			t.Fatalf("GetByDiscordID: %v", err)
+		}
+		return user.NotificationSettings()
+	}
+
+	// A user without saved preferences starts from the defaults
+	if err := users.SetNotificationPreference(ctx, "u1", "aucoutbid", false); err != nil {
+		t.Fatalf("SetNotificationPreference: %v", err)
+	}
+	want := models.DefaultPreferences().Notifications
+	want.AucOutBid = false
+	if got := settings(); got != want {
+		t.Errorf("settings = %+v, want %+v", got, want)
+	}
+
+	if err := users.SetNotificationPreference(ctx, "u1", "aucoutbid", true); err != nil {
+		t.Fatalf("SetNotificationPreference: %v", err)
+	}
+	if got := settings(); got != models.DefaultPreferences().Notifications {
+		t.Errorf("settings = %+v, want the defaults back", got)
+	}
+
+	if err := users.SetNotificationPreference(ctx, "missing", "aucoutbid", false); err == nil {
+		t.Error("SetNotificationPreference for an unknown user succeeded")
+	}
+}
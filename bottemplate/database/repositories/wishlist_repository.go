@@ -14,6 +14,7 @@ type WishlistRepository interface {
 	GetByUserID(ctx context.Context, userID string) ([]*models.Wishlist, error)
 	RemoveMany(ctx context.Context, userID string, cardIDs []int64) error
 	AddMany(ctx context.Context, userID string, cardIDs []int64) error
+	BulkAdd(ctx context.Context, userID string, cardIDs []int64) (added int, existing int, err error)
 	Exists(ctx context.Context, userID string, cardID int64) (bool, error)
 }
 
@@ -77,6 +78,68 @@ func (r *wishlistRepository) AddMany(ctx context.Context, userID string, cardIDs
 	return err
 }
 
+// BulkAdd adds every card not already on the user's wishlist in a single
+// transaction. Duplicate IDs in cardIDs are collapsed first. It returns how many
+// cards were inserted and how many were already wishlisted.
+func (r *wishlistRepository) BulkAdd(ctx context.Context, userID string, cardIDs []int64) (int, int, error) {
+	unique := make([]int64, 0, len(cardIDs))
+	seen := make(map[int64]bool, len(cardIDs))
+	for _, cardID := range cardIDs {
+		if !seen[cardID] {
+			seen[cardID] = true
+			unique = append(unique, cardID)
+		}
+	}
+	if len(unique) == 0 {
+		return 0, 0, nil
+	}
+
+	added := 0
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var existingIDs []int64
+		err := tx.NewSelect().
+			Model((*models.Wishlist)(nil)).
+			Column("card_id").
+			Where("user_id = ? AND card_id IN (?)", userID, bun.In(unique)).
+			Scan(ctx, &existingIDs)
+		if err != nil {
+			return err
+		}
+
+		existing := make(map[int64]bool, len(existingIDs))
+		for _, cardID := range existingIDs {
+			existing[cardID] = true
+		}
+
+		now := time.Now()
+		wishlists := make([]*models.Wishlist, 0, len(unique))
+		for _, cardID := range unique {
+			if existing[cardID] {
+				continue
+			}
+			wishlists = append(wishlists, &models.Wishlist{
+				UserID:    userID,
+				CardID:    cardID,
+				CreatedAt: now,
+				UpdatedAt: now,
+			})
+		}
+		if len(wishlists) == 0 {
+			return nil
+		}
+
+		if _, err := tx.NewInsert().Model(&wishlists).Exec(ctx); err != nil {
+			return err
+		}
+		added = len(wishlists)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return added, len(unique) - added, nil
+}
+
 func (r *wishlistRepository) Exists(ctx context.Context, userID string, cardID int64) (bool, error) {
 	exists, err := r.db.NewSelect().
 		Model((*models.Wishlist)(nil)).
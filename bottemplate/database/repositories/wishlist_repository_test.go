@@ -0,0 +1,54 @@
+package repositories_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+func TestWishlistBulkAddSkipsDuplicates(t *testing.T) {
+	db := dbtest.Open(t)
+	wishlists := repositories.NewWishlistRepository(db.BunDB())
+	ctx := context.Background()
+
+	for id := int64(1); id <= 4; id++ {
+		createTestCard(t, db, id, "card", "twice", 1)
+	}
+	if err := wishlists.Add(ctx, "u1", 2); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	// 2 is already wishlisted and 3 is listed twice
+	added, existing, err := wishlists.BulkAdd(ctx, "u1", []int64{1, 2, 3, 3, 4})
+	if err != nil {
+		t.Fatalf("BulkAdd: %v", err)
+	}
+	if added != 3 || existing != 1 {
+		t.Errorf("BulkAdd = %d added, %d existing, want 3 and 1", added, existing)
+	}
+
+	items, err := wishlists.GetByUserID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByUserID: %v", err)
+	}
+	var ids []int64
+	for _, item := range items {
+		ids = append(ids, item.CardID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) != 4 || ids[0] != 1 || ids[3] != 4 {
+		t.Errorf("wishlist = %v, want cards 1 to 4 once each", ids)
+	}
+
+	// Running it again adds nothing
+	added, existing, err = wishlists.BulkAdd(ctx, "u1", []int64{1, 4})
+	if err != nil || added != 0 || existing != 2 {
+		t.Errorf("second BulkAdd = %d, %d, %v, want 0 added and 2 existing", added, existing, err)
+	}
+	if added, existing, err := wishlists.BulkAdd(ctx, "u1", nil); added != 0 || existing != 0 || err != nil {
+		t.Errorf("empty BulkAdd = %d, %d, %v", added, existing, err)
+	}
+}
@@ -0,0 +1,53 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestResetTablesOnlyNamed(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+
+	collection := &models.Collection{ID: "twice", Name: "twice", Origin: "test", UpdatedAt: time.Now()}
+	if _, err := db.BunDB().NewInsert().Model(collection).Exec(ctx); err != nil {
+		t.Fatalf("create collection: %v", err)
+	}
+	claim := &models.Claim{CardID: 1, UserID: "u1", ClaimedAt: time.Now(), Expires: time.Now()}
+	if _, err := db.BunDB().NewInsert().Model(claim).Exec(ctx); err != nil {
+		t.Fatalf("create claim: %v", err)
+	}
+	count := func(model any) int {
+		t.Helper()
+		n, err := db.BunDB().NewSelect().Model(model).Count(ctx)
+		if err != nil {
+			t.Fatalf("count: %v", err)
+		}
+		return n
+	}
+
+	// One unknown name aborts the whole reset
+	if err := db.ResetTables(ctx, "claims", "no_such_table"); err == nil {
+		t.Error("unknown table accepted")
+	}
+	if n := count((*models.Claim)(nil)); n != 1 {
+		t.Errorf("claims = %d after a rejected reset, want 1", n)
+	}
+	if err := db.ResetTables(ctx, " ", ""); err == nil {
+		t.Error("blank names accepted")
+	}
+
+	if err := db.ResetTables(ctx, " claims", "claims "); err != nil {
+		t.Fatalf("ResetTables: %v", err)
+	}
+	if n := count((*models.Claim)(nil)); n != 0 {
+		t.Errorf("claims = %d, want 0", n)
+	}
+	if n := count((*models.Collection)(nil)); n != 1 {
+		t.Errorf("collections = %d, want the untouched row", n)
+	}
+}
@@ -24,13 +24,13 @@ func NewAuctionHelpers(manager *Manager) *AuctionHelpers {
 
 // getMarketPrice calculates the market price for a card based on auction history
 func (h *AuctionHelpers) getMarketPrice(ctx context.Context, cardID int64) (int64, error) {
-	// Get recent auction history for this card
-	auctions, err := h.manager.repo.GetRecentCompletedAuctions(ctx, cardID, 5)
+	// Get recent auction sales for this card
+	summary, err := h.manager.GetSalesSummary(ctx, cardID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get auction history: %w", err)
 	}
 
-	if len(auctions) == 0 {
+	if summary.AveragedOver == 0 {
 		// If no auction history, use base price calculation
 		card, err := h.manager.cardRepo.GetByID(ctx, cardID)
 		if err != nil {
@@ -41,12 +41,7 @@ func (h *AuctionHelpers) getMarketPrice(ctx context.Context, cardID int64) (int6
 		return basePrice, nil
 	}
 
-	// Calculate average price from recent auctions
-	var totalPrice int64
-	for _, a := range auctions {
-		totalPrice += a.CurrentPrice
-	}
-	avgPrice := totalPrice / int64(len(auctions))
+	avgPrice := summary.MarketPrice
 
 	// Ensure price is within bounds
 	if avgPrice < economicUtils.MinPrice {
@@ -0,0 +1,47 @@
+package auction
+
+import (
+	"context"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
+)
+
+// SalesSummary is what a card's recent auction sales say about its price
+type SalesSummary struct {
+	Sales        []repositories.AuctionSale // newest first, within AuctionSalesLookback
+	MarketPrice  int64                      // moving average of the latest sales, 0 without sales
+	AveragedOver int                        // how many sales MarketPrice averages
+}
+
+// GetSalesSummary loads the card's recent auction sales and their moving average
+func (m *Manager) GetSalesSummary(ctx context.Context, cardID int64) (SalesSummary, error) {
+	sales, err := m.repo.GetSoldHistory(ctx, cardID, time.Now().Add(-economicUtils.AuctionSalesLookback))
+	if err != nil {
+		return SalesSummary{}, err
+	}
+	price, n := MovingAveragePrice(sales, economicUtils.AuctionSalesAverageLen)
+	return SalesSummary{
+		Sales:        sales,
+		MarketPrice:  price,
+		AveragedOver: n,
+	}, nil
+}
+
+// MovingAveragePrice averages the final price of the newest window sales. sales must
+// be ordered newest first. It returns the average and how many sales went into it.
+func MovingAveragePrice(sales []repositories.AuctionSale, window int) (int64, int) {
+	if window <= 0 || len(sales) == 0 {
+		return 0, 0
+	}
+	if len(sales) > window {
+		sales = sales[:window]
+	}
+
+	var total int64
+	for _, sale := range sales {
+		total += sale.FinalPrice
+	}
+	return total / int64(len(sales)), len(sales)
+}
@@ -0,0 +1,76 @@
+package auction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
+)
+
+func sales(prices ...int64) []repositories.AuctionSale {
+	result := make([]repositories.AuctionSale, len(prices))
+	for i, price := range prices {
+		result[i] = repositories.AuctionSale{FinalPrice: price}
+	}
+	return result
+}
+
+func TestMovingAveragePrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		sales     []repositories.AuctionSale
+		window    int
+		wantPrice int64
+		wantN     int
+	}{
+		{name: "no sales", sales: nil, window: 10},
+		{name: "fewer than the window", sales: sales(100, 200), window: 10, wantPrice: 150, wantN: 2},
+		// Only the newest sales count
+		{name: "window", sales: sales(300, 100, 5000), window: 2, wantPrice: 200, wantN: 2},
+		{name: "rounds down", sales: sales(10, 11), window: 5, wantPrice: 10, wantN: 2},
+		{name: "zero window", sales: sales(100), window: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, n := MovingAveragePrice(tt.sales, tt.window)
+			if price != tt.wantPrice || n != tt.wantN {
+				t.Errorf("MovingAveragePrice = %d over %d, want %d over %d", price, n, tt.wantPrice, tt.wantN)
+			}
+		})
+	}
+}
+
+// soldAuctionRepo answers GetSoldHistory with fixed sales and records the cut-off
+type soldAuctionRepo struct {
+	repositories.AuctionRepository
+	sales []repositories.AuctionSale
+	since time.Time
+}
+
+func (r *soldAuctionRepo) GetSoldHistory(ctx context.Context, cardID int64, since time.Time) ([]repositories.AuctionSale, error) {
+	r.since = since
+	return r.sales, nil
+}
+
+func TestGetSalesSummary(t *testing.T) {
+	prices := make([]int64, economicUtils.AuctionSalesAverageLen+2)
+	for i := range prices {
+		prices[i] = 100
+	}
+	prices[len(prices)-1] = 100000 // too old to be averaged
+	repo := &soldAuctionRepo{sales: sales(prices...)}
+	m := &Manager{repo: repo}
+
+	summary, err := m.GetSalesSummary(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetSalesSummary: %v", err)
+	}
+	if summary.MarketPrice != 100 || summary.AveragedOver != economicUtils.AuctionSalesAverageLen || len(summary.Sales) != len(prices) {
+		t.Errorf("summary = %d over %d of %d sales", summary.MarketPrice, summary.AveragedOver, len(summary.Sales))
+	}
+	if lookback := time.Since(repo.since); lookback < economicUtils.AuctionSalesLookback || lookback > economicUtils.AuctionSalesLookback+time.Minute {
+		t.Errorf("sales looked back %s, want %s", lookback, economicUtils.AuctionSalesLookback)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
 	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
 	botutils "github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/uptrace/bun"
@@ -64,19 +65,34 @@ func (l *AuctionLifecycleManager) createAuctionInternal(ctx context.Context, auc
 			slog.String("user_id", sellerID),
 			slog.Int64("card_id", cardID))
 
+		// Charge the listing fee; it is not refunded if the auction does not sell
+		listingFee := l.manager.fees.Listing(startPrice)
+		if listingFee > 0 {
+			if err := l.manager.txManager.ValidateAndUpdateBalance(ctx, tx, economicUtils.BalanceOperationOptions{
+				UserID:    sellerID,
+				Amount:    -listingFee,
+				Reason:    models.LedgerReasonAuctionListingFee,
+				Reference: auctionID,
+			}); err != nil {
+				return fmt.Errorf("failed to charge listing fee of %d: %w", listingFee, err)
+			}
+		}
+
 		// Create auction within same transaction
 		auction = &models.Auction{
-			AuctionID:    auctionID,
-			CardID:       cardID,
-			SellerID:     sellerID,
-			StartPrice:   startPrice,
-			CurrentPrice: startPrice,
-			MinIncrement: economicUtils.MinBidIncrement,
-			Status:       models.AuctionStatusActive,
-			StartTime:    time.Now(),
-			EndTime:      time.Now().Add(duration),
-			CreatedAt:    time.Now(),
-			UpdatedAt:    time.Now(),
+			AuctionID:     auctionID,
+			CardID:        cardID,
+			SellerID:      sellerID,
+			StartPrice:    startPrice,
+			CurrentPrice:  startPrice,
+			MinIncrement:  economicUtils.MinBidIncrement,
+			Status:        models.AuctionStatusActive,
+			StartTime:     time.Now(),
+			EndTime:       time.Now().Add(duration),
+			ListingFee:    listingFee,
+			CommissionBps: l.manager.fees.CommissionBps(),
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
 		}
 
 		if err := l.manager.repo.CreateWithTx(ctx, tx, auction); err != nil {
@@ -191,11 +207,12 @@ func (l *AuctionLifecycleManager) completeAuction(ctx context.Context, auctionID
 	}
 
 	// Update auction status
-	_, err = tx.NewUpdate().
+	update := tx.NewUpdate().
 		Model((*models.Auction)(nil)).
 		Set("status = ?", models.AuctionStatusCompleted).
-		Where("id = ?", auctionID).
-		Exec(ctx)
+		Set("commission = ?", auction.Commission).
+		Where("id = ?", auctionID)
+	_, err = repositories.RecordFinalSale(update, time.Now()).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to update auction status: %w", err)
 	}
@@ -216,6 +233,8 @@ func (l *AuctionLifecycleManager) completeAuction(ctx context.Context, auctionID
 			slog.String("auction_id", auction.AuctionID),
 			slog.String("error", err.Error()))
 	}
+	auction.Status = models.AuctionStatusCompleted
+	go l.manager.closeWatchers(context.Background(), auction, card)
 
 	slog.Info("Auction completed successfully",
 		slog.Int64("auction_id", auctionID),
@@ -261,20 +280,26 @@ func (l *AuctionLifecycleManager) handleWinningBidCompletion(ctx context.Context
 		return fmt.Errorf("failed to transfer card to winner: %w", err)
 	}
 
-	// Transfer winning bid amount to seller using standardized method
-	if err := l.manager.txManager.ValidateAndUpdateBalance(ctx, tx, economicUtils.BalanceOperationOptions{
-		UserID: auction.SellerID,
-		Amount: auction.CurrentPrice,
-	}); err != nil {
+	// Pay the winning bid to the seller less the commission fixed at listing
+	commission, err := l.manager.txManager.PaySeller(ctx, tx, economicUtils.SalePayoutOptions{
+		SellerID:      auction.SellerID,
+		Price:         auction.CurrentPrice,
+		CommissionBps: auction.CommissionBps,
+		Reference:     auction.AuctionID,
+	})
+	if err != nil {
 		return fmt.Errorf("failed to transfer balance to seller: %w", err)
 	}
+	auction.Commission = commission
 
 	if l.manager.auctionSaleBonusFunc != nil {
 		bonus := l.manager.auctionSaleBonusFunc(ctx, auction.SellerID, auction.CurrentPrice)
 		if bonus > 0 {
 			if err := l.manager.txManager.ValidateAndUpdateBalance(ctx, tx, economicUtils.BalanceOperationOptions{
-				UserID: auction.SellerID,
-				Amount: bonus,
+				UserID:    auction.SellerID,
+				Amount:    bonus,
+				Reason:    models.LedgerReasonAuctionBonus,
+				Reference: auction.AuctionID,
 			}); err != nil {
 				return fmt.Errorf("failed to apply auction sale bonus: %w", err)
 			}
@@ -285,8 +310,10 @@ func (l *AuctionLifecycleManager) handleWinningBidCompletion(ctx context.Context
 		cashback := l.manager.auctionWinCashbackFunc(ctx, auction.TopBidderID, auction.CurrentPrice)
 		if cashback > 0 {
 			if err := l.manager.txManager.ValidateAndUpdateBalance(ctx, tx, economicUtils.BalanceOperationOptions{
-				UserID: auction.TopBidderID,
-				Amount: cashback,
+				UserID:    auction.TopBidderID,
+				Amount:    cashback,
+				Reason:    models.LedgerReasonAuctionBonus,
+				Reference: auction.AuctionID,
 			}); err != nil {
 				return fmt.Errorf("failed to apply auction win cashback: %w", err)
 			}
@@ -307,5 +334,8 @@ func (l *AuctionLifecycleManager) cancelAuctionInternal(ctx context.Context, auc
 	l.manager.activeAuctions.Delete(auction.ID)
 	l.manager.activeMu.Unlock()
 
+	auction.Status = models.AuctionStatusCancelled
+	go l.manager.closeWatchers(context.Background(), auction, l.manager.cardForNotification(context.Background(), auction.CardID))
+
 	return nil
 }
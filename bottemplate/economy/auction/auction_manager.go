@@ -9,7 +9,9 @@ import (
 
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
 	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/economy"
 	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
+	botutils "github.com/disgoorg/bot-template/bottemplate/utils"
 	"github.com/disgoorg/disgo/bot"
 	"github.com/uptrace/bun"
 )
@@ -27,6 +29,9 @@ type Manager struct {
 	cardRepo        repositories.CardRepository
 	activeAuctions  sync.Map
 	notifier        *AuctionNotifier
+	watchers        repositories.AuctionWatcherRepository
+	fees            economy.AuctionFees
+	bidIncrement    economy.BidIncrement
 	client          bot.Client
 	minBidIncrement int64
 	maxAuctionTime  time.Duration
@@ -102,6 +107,33 @@ func (m *Manager) SetClient(client bot.Client) {
 	m.notifier.SetClient(client)
 }
 
+// SetFees sets the listing fee and sale commission for auctions created from now on.
+// Running auctions keep the commission rate they were listed with.
+func (m *Manager) SetFees(fees economy.AuctionFees) {
+	m.fees = fees
+}
+
+// Fees returns the fees new auctions are listed with
+func (m *Manager) Fees() economy.AuctionFees {
+	return m.fees
+}
+
+// SetBidIncrement makes the minimum bid increment scale with the current price.
+// It applies to running auctions too.
+func (m *Manager) SetBidIncrement(increment economy.BidIncrement) {
+	m.bidIncrement = increment
+}
+
+// SetUserRepository lets the manager honour users' notification preferences
+func (m *Manager) SetUserRepository(users repositories.UserRepository) {
+	m.notifier.SetUserRepository(users)
+}
+
+// MinimumBid returns the lowest bid the auction currently accepts
+func (m *Manager) MinimumBid(auction *models.Auction) int64 {
+	return m.bidIncrement.MinimumBid(auction.CurrentPrice, auction.MinIncrement)
+}
+
 func (m *Manager) CreateAuction(ctx context.Context, cardID int64, sellerID string, startPrice int64, duration time.Duration) (*models.Auction, error) {
 	if duration < economicUtils.MinAuctionTime || duration > economicUtils.MaxAuctionTime {
 		return nil, fmt.Errorf("auction duration must be between 1 and 24 hours")
@@ -137,7 +169,9 @@ func (m *Manager) CreateAuction(ctx context.Context, cardID int64, sellerID stri
 }
 
 func (m *Manager) PlaceBid(ctx context.Context, auctionID int64, bidderID string, amount int64) error {
-	return m.txManager.WithTransaction(ctx, economicUtils.SerializableTransactionOptions(), func(ctx context.Context, tx bun.Tx) error {
+	// Snapshot of the auction before this bid, for notifications once committed
+	var before models.Auction
+	err := m.txManager.WithTransaction(ctx, economicUtils.SerializableTransactionOptions(), func(ctx context.Context, tx bun.Tx) error {
 		// Lock and get auction details
 		auction := new(models.Auction)
 		err := tx.NewSelect().
@@ -163,15 +197,17 @@ func (m *Manager) PlaceBid(ctx context.Context, auctionID int64, bidderID string
 			return fmt.Errorf("you are already the highest bidder")
 		}
 
-		minValidBid := auction.CurrentPrice + auction.MinIncrement
+		minValidBid := m.MinimumBid(auction)
 		if amount < minValidBid {
 			return fmt.Errorf("bid must be at least %d (current price + minimum increment)", minValidBid)
 		}
 
 		// Validate and deduct bid amount from bidder
 		if err := m.txManager.ValidateAndUpdateBalance(ctx, tx, economicUtils.BalanceOperationOptions{
-			UserID: bidderID,
-			Amount: -amount,
+			UserID:    bidderID,
+			Amount:    -amount,
+			Reason:    models.LedgerReasonAuctionBid,
+			Reference: auction.AuctionID,
 		}); err != nil {
 			return fmt.Errorf("failed to deduct bid amount: %w", err)
 		}
@@ -179,13 +215,16 @@ func (m *Manager) PlaceBid(ctx context.Context, auctionID int64, bidderID string
 		// If there was a previous bidder, refund their bid
 		if auction.TopBidderID != "" {
 			if err := m.txManager.ValidateAndUpdateBalance(ctx, tx, economicUtils.BalanceOperationOptions{
-				UserID: auction.TopBidderID,
-				Amount: auction.CurrentPrice,
+				UserID:    auction.TopBidderID,
+				Amount:    auction.CurrentPrice,
+				Reason:    models.LedgerReasonAuctionRefund,
+				Reference: auction.AuctionID,
 			}); err != nil {
 				return fmt.Errorf("failed to refund previous bidder: %w", err)
 			}
 		}
 
+		before = *auction
 		now := time.Now()
 		timeUntilEnd := auction.EndTime.Sub(now)
 
@@ -214,16 +253,25 @@ func (m *Manager) PlaceBid(ctx context.Context, auctionID int64, bidderID string
 			return fmt.Errorf("failed to update auction: %w", err)
 		}
 
-		// Send notifications after successful commit (moved outside transaction)
-		go func() {
-			m.notifier.NotifyBid(auctionID, bidderID, amount)
-			if auction.TopBidderID != "" {
-				m.notifier.NotifyOutbid(auctionID, auction.TopBidderID, bidderID, amount)
-			}
-		}()
-
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// Send notifications after successful commit
+	go func() {
+		m.notifier.NotifyBid(auctionID, bidderID, amount, m.bidIncrement.MinimumBid(amount, before.MinIncrement))
+		if before.TopBidderID != "" {
+			m.notifier.NotifyOutbid(auctionID, before.TopBidderID, bidderID, amount)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		m.notifyBid(ctx, &before, bidderID, amount)
+	}()
+
+	return nil
 }
 
 func (m *Manager) CancelAuction(ctx context.Context, auctionID int64, requesterID string) error {
@@ -282,6 +330,12 @@ func (m *Manager) GetActiveAuctions(ctx context.Context) ([]*models.Auction, err
 	return auctions, nil
 }
 
+// SearchActiveAuctions returns running auctions whose card matches a search query,
+// ending soonest first. An empty query matches every auction.
+func (m *Manager) SearchActiveAuctions(ctx context.Context, query string) ([]*models.Auction, error) {
+	return m.repo.SearchActive(ctx, botutils.ParseSearchQuery(query))
+}
+
 func (m *Manager) RecoverActiveAuctions(ctx context.Context) error {
 	auctions, err := m.repo.GetActive(ctx)
 	if err != nil {
@@ -0,0 +1,48 @@
+package auction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/economy"
+	botutils "github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// searchAuctionRepo records the filters SearchActive was called with
+type searchAuctionRepo struct {
+	repositories.AuctionRepository
+	filters botutils.SearchFilters
+}
+
+func (r *searchAuctionRepo) SearchActive(ctx context.Context, filters botutils.SearchFilters) ([]*models.Auction, error) {
+	r.filters = filters
+	return nil, nil
+}
+
+func TestSearchActiveAuctionsParsesQuery(t *testing.T) {
+	repo := &searchAuctionRepo{}
+	m := &Manager{repo: repo}
+
+	if _, err := m.SearchActiveAuctions(context.Background(), "momo -3 -twice"); err != nil {
+		t.Fatalf("SearchActiveAuctions: %v", err)
+	}
+	if repo.filters.Name != "momo" || len(repo.filters.Levels) != 1 || repo.filters.Levels[0] != 3 || len(repo.filters.Collections) != 1 {
+		t.Errorf("filters = %+v, want name momo, level 3 and one collection", repo.filters)
+	}
+}
+
+func TestManagerMinimumBid(t *testing.T) {
+	m := &Manager{}
+	auction := &models.Auction{CurrentPrice: 2000, MinIncrement: 10}
+	if got := m.MinimumBid(auction); got != 2010 {
+		t.Errorf("MinimumBid without scaling = %d, want 2010", got)
+	}
+
+	// Running auctions pick up a new increment right away
+	m.SetBidIncrement(economy.BidIncrement{Percent: 10})
+	if got := m.MinimumBid(auction); got != 2200 {
+		t.Errorf("MinimumBid at 10%% = %d, want 2200", got)
+	}
+}
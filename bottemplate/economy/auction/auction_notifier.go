@@ -6,8 +6,11 @@ import (
 	"log/slog"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/snowflake/v2"
@@ -18,6 +21,13 @@ type AuctionNotifier struct {
 	channelID   snowflake.ID
 	mu          sync.RWMutex
 	initialized bool
+
+	// lastBidDM rate limits bid DMs per auction and user, keyed by "auctionID:userID"
+	dmMu      sync.Mutex
+	lastBidDM map[string]time.Time
+
+	// users is consulted for notification preferences; nil sends every DM
+	users repositories.UserRepository
 }
 
 func NewAuctionNotifier(client bot.Client) *AuctionNotifier {
@@ -25,6 +35,7 @@ func NewAuctionNotifier(client bot.Client) *AuctionNotifier {
 		client:      client,
 		channelID:   snowflake.ID(1301232741697851395),
 		initialized: true,
+		lastBidDM:   make(map[string]time.Time),
 	}
 }
 
@@ -35,9 +46,16 @@ func (n *AuctionNotifier) SetClient(client bot.Client) {
 	n.initialized = true
 }
 
-func (n *AuctionNotifier) NotifyBid(auctionID int64, bidderID string, amount int64) {
-	message := fmt.Sprintf("[BID] <@%s> placed a bid of %d 💰 on Auction #%d", bidderID, amount, auctionID)
-	n.logNotification(message)
+// SetUserRepository lets the notifier skip DMs users turned off in /preferences
+func (n *AuctionNotifier) SetUserRepository(users repositories.UserRepository) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.users = users
+}
+
+func (n *AuctionNotifier) NotifyBid(auctionID int64, bidderID string, amount, nextMinimum int64) {
+	message := fmt.Sprintf("[BID] <@%s> placed a bid of %d 💰 on Auction #%d (next minimum bid: %d 💰)", bidderID, amount, auctionID, nextMinimum)
+	n.logNotification(message, WatchActionRow(auctionID))
 }
 
 func (n *AuctionNotifier) NotifyOutbid(auctionID int64, outbidUserID string, newBidderID string, amount int64) {
@@ -56,16 +74,7 @@ func (n *AuctionNotifier) NotifyAuctionEnd(ctx context.Context, auction *models.
 	client := n.client
 	n.mu.RUnlock()
 
-	// Format card name for display
-	cardName := card.Name
-	if card.Level >= 1 && card.Level <= 5 {
-		// Add star rating to card name
-		stars := ""
-		for i := 0; i < card.Level; i++ {
-			stars += "★"
-		}
-		cardName = fmt.Sprintf("%s %s [%s]", stars, card.Name, card.ColID)
-	}
+	cardName := auctionCardName(card)
 
 	// Create DM for seller
 	sellerEmbed := discord.NewEmbedBuilder().
@@ -81,20 +90,12 @@ func (n *AuctionNotifier) NotifyAuctionEnd(ctx context.Context, auction *models.
 			cardName))
 	}
 
-	dmChannel, err := client.Rest().CreateDMChannel(snowflake.MustParse(auction.SellerID))
-	if err != nil {
-		logAuctionDMFailure("seller", auction.SellerID, err)
-	} else {
-		_, err = client.Rest().CreateMessage(dmChannel.ID(), discord.MessageCreate{
-			Embeds: []discord.Embed{sellerEmbed.Build()},
-		})
-		if err != nil {
-			logAuctionDMFailure("seller", auction.SellerID, err)
-		}
+	if n.wantsDM(ctx, auction.SellerID, auctionEndPreference) {
+		sendAuctionDM(client, "seller", auction.SellerID, sellerEmbed.Build())
 	}
 
 	// If there's a winner, notify them too
-	if auction.TopBidderID != "" {
+	if auction.TopBidderID != "" && n.wantsDM(ctx, auction.TopBidderID, auctionEndPreference) {
 		winnerEmbed := discord.NewEmbedBuilder().
 			SetTitle("🏛️ Auction Won!").
 			SetDescription(fmt.Sprintf("You won the auction for **%s** with a final price of %d flakes!",
@@ -102,20 +103,161 @@ func (n *AuctionNotifier) NotifyAuctionEnd(ctx context.Context, auction *models.
 				auction.CurrentPrice)).
 			SetColor(0x2b2d31)
 
-		winnerDMChannel, err := client.Rest().CreateDMChannel(snowflake.MustParse(auction.TopBidderID))
-		if err != nil {
-			logAuctionDMFailure("winner", auction.TopBidderID, err)
-		} else {
-			_, err = client.Rest().CreateMessage(winnerDMChannel.ID(), discord.MessageCreate{
-				Embeds: []discord.Embed{winnerEmbed.Build()},
-			})
-			if err != nil {
-				logAuctionDMFailure("winner", auction.TopBidderID, err)
+		sendAuctionDM(client, "winner", auction.TopBidderID, winnerEmbed.Build())
+	}
+
+	return nil
+}
+
+// NotifyOutbidDM tells the previous top bidder someone beat their bid
+func (n *AuctionNotifier) NotifyOutbidDM(auction *models.Auction, card *models.Card, userID string, amount int64) {
+	if !n.wantsDM(context.Background(), userID, outbidPreference) {
+		return
+	}
+	if !n.allowBidDM(auction.ID, userID, time.Now()) {
+		return
+	}
+	embed := discord.NewEmbedBuilder().
+		SetTitle("🏛️ You've Been Outbid").
+		SetDescription(fmt.Sprintf("Someone bid **%d** flakes on **%s** (auction `%s`). Your bid has been refunded.\nUse `/auction bid` to bid again.",
+			amount, auctionCardName(card), auction.AuctionID)).
+		SetColor(0x2b2d31).
+		Build()
+	n.sendDM("outbid", userID, embed)
+}
+
+// NotifyWatcherBid tells a watcher a new bid was placed
+func (n *AuctionNotifier) NotifyWatcherBid(auction *models.Auction, card *models.Card, userID string, amount int64) {
+	if !n.wantsDM(context.Background(), userID, watcherPreference) {
+		return
+	}
+	if !n.allowBidDM(auction.ID, userID, time.Now()) {
+		return
+	}
+	embed := discord.NewEmbedBuilder().
+		SetTitle("👀 New Bid on a Watched Auction").
+		SetDescription(fmt.Sprintf("**%s** (auction `%s`) is now at **%d** flakes.",
+			auctionCardName(card), auction.AuctionID, amount)).
+		SetColor(0x2b2d31).
+		Build()
+	n.sendDM("watcher", userID, embed)
+}
+
+// NotifyEndingSoon tells a watcher the auction is about to end
+func (n *AuctionNotifier) NotifyEndingSoon(auction *models.Auction, card *models.Card, userID string) {
+	if !n.wantsDM(context.Background(), userID, watcherPreference) {
+		return
+	}
+	embed := discord.NewEmbedBuilder().
+		SetTitle("⏰ Watched Auction Ending Soon").
+		SetDescription(fmt.Sprintf("**%s** (auction `%s`) ends <t:%d:R> at **%d** flakes.",
+			auctionCardName(card), auction.AuctionID, auction.EndTime.Unix(), auction.CurrentPrice)).
+		SetColor(0x2b2d31).
+		Build()
+	n.sendDM("watcher", userID, embed)
+}
+
+// NotifyWatcherClosed tells a watcher the auction ended or was cancelled
+func (n *AuctionNotifier) NotifyWatcherClosed(auction *models.Auction, card *models.Card, userID string) {
+	if !n.wantsDM(context.Background(), userID, watcherPreference) {
+		return
+	}
+	description := fmt.Sprintf("**%s** (auction `%s`) ended with no bids.", auctionCardName(card), auction.AuctionID)
+	switch {
+	case auction.Status == models.AuctionStatusCancelled:
+		description = fmt.Sprintf("**%s** (auction `%s`) was cancelled by the seller.", auctionCardName(card), auction.AuctionID)
+	case auction.TopBidderID != "":
+		description = fmt.Sprintf("**%s** (auction `%s`) sold for **%d** flakes.", auctionCardName(card), auction.AuctionID, auction.CurrentPrice)
+	}
+	embed := discord.NewEmbedBuilder().
+		SetTitle("🏛️ Watched Auction Closed").
+		SetDescription(description).
+		SetColor(0x2b2d31).
+		Build()
+	n.sendDM("watcher", userID, embed)
+}
+
+func outbidPreference(p models.NotificationPreferences) bool     { return p.AucOutBid }
+func watcherPreference(p models.NotificationPreferences) bool    { return p.AucNewBid }
+func auctionEndPreference(p models.NotificationPreferences) bool { return p.AucEnd }
+
+// wantsDM reports whether the user allows the notification picked by pref. When the
+// preferences can't be loaded the DM is sent, as all notifications default to on.
+func (n *AuctionNotifier) wantsDM(ctx context.Context, userID string, pref func(models.NotificationPreferences) bool) bool {
+	n.mu.RLock()
+	users := n.users
+	n.mu.RUnlock()
+	if users == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	user, err := users.GetByDiscordID(ctx, userID)
+	if err != nil {
+		return true
+	}
+	return pref(user.NotificationSettings())
+}
+
+// allowBidDM reports whether userID may get another bid DM about the auction, and
+// records the DM if so. Bid wars would otherwise flood watchers.
+func (n *AuctionNotifier) allowBidDM(auctionID int64, userID string, now time.Time) bool {
+	n.dmMu.Lock()
+	defer n.dmMu.Unlock()
+
+	key := fmt.Sprintf("%d:%s", auctionID, userID)
+	if last, ok := n.lastBidDM[key]; ok && now.Sub(last) < economicUtils.AuctionWatchDMInterval {
+		return false
+	}
+	n.lastBidDM[key] = now
+
+	// Entries past the interval no longer limit anything
+	if len(n.lastBidDM) > 1000 {
+		for k, t := range n.lastBidDM {
+			if now.Sub(t) >= economicUtils.AuctionWatchDMInterval {
+				delete(n.lastBidDM, k)
 			}
 		}
 	}
+	return true
+}
 
-	return nil
+func (n *AuctionNotifier) sendDM(role string, userID string, embed discord.Embed) {
+	n.mu.RLock()
+	client := n.client
+	n.mu.RUnlock()
+	if client == nil {
+		return
+	}
+	sendAuctionDM(client, role, userID, embed)
+}
+
+func sendAuctionDM(client bot.Client, role string, userID string, embed discord.Embed) {
+	id, err := snowflake.Parse(userID)
+	if err != nil {
+		logAuctionDMFailure(role, userID, err)
+		return
+	}
+	dmChannel, err := client.Rest().CreateDMChannel(id)
+	if err != nil {
+		logAuctionDMFailure(role, userID, err)
+		return
+	}
+	_, err = client.Rest().CreateMessage(dmChannel.ID(), discord.MessageCreate{
+		Embeds: []discord.Embed{embed},
+	})
+	if err != nil {
+		logAuctionDMFailure(role, userID, err)
+	}
+}
+
+// auctionCardName formats a card as "★★ Name [collection]" for auction messages
+func auctionCardName(card *models.Card) string {
+	if card.Level < 1 || card.Level > 5 {
+		return card.Name
+	}
+	return fmt.Sprintf("%s %s [%s]", strings.Repeat("★", card.Level), card.Name, card.ColID)
 }
 
 func logAuctionDMFailure(role string, userID string, err error) {
@@ -144,12 +286,13 @@ func isExpectedDMFailure(err error) bool {
 		strings.Contains(message, "50007")
 }
 
-func (n *AuctionNotifier) logNotification(message string) {
+func (n *AuctionNotifier) logNotification(message string, components ...discord.ContainerComponent) {
 	slog.Info(message)
 
 	if n.client != nil {
 		_, err := n.client.Rest().CreateMessage(n.channelID, discord.NewMessageCreateBuilder().
 			SetContent(message).
+			AddContainerComponents(components...).
 			Build())
 		if err != nil {
 			slog.Error("Failed to send to Discord",
@@ -80,6 +80,12 @@ func (s *AuctionScheduler) startCleanupTicker() {
 						slog.String("error", err.Error()))
 				}
 
+				// Let watchers know about auctions that are about to end
+				if err := s.manager.notifyEndingSoon(ctx); err != nil {
+					slog.Error("Failed to notify auction watchers",
+						slog.String("error", err.Error()))
+				}
+
 				// Cleanup zero amount cards
 				if err := s.manager.UserCardRepo.CleanupZeroAmountCards(ctx); err != nil {
 					slog.Error("Failed to cleanup zero amount cards",
@@ -142,6 +148,7 @@ func (s *AuctionScheduler) cleanupExpiredAuctions(ctx context.Context) error {
 				slog.String("auction_id", updatedAuction.AuctionID),
 				slog.String("error", err.Error()))
 		}
+		s.manager.closeWatchers(auctionCtx, updatedAuction, card)
 
 		cancel()
 		time.Sleep(100 * time.Millisecond)
@@ -30,11 +30,11 @@ func NewAuctionUI(client bot.Client, cardRepo repositories.CardRepository, manag
 func (ui *AuctionUI) CreateAuctionEmbed(auction *models.Auction, card *models.Card) discord.Embed {
 	builder := discord.NewEmbedBuilder().
 		SetTitle(fmt.Sprintf("Auction #%d: %s", auction.ID, utils.FormatCardName(card.Name))).
-		SetDescription(fmt.Sprintf("```md\n## Auction Details\n* Auction ID: %s\n* Seller: <@%s>\n* Current Price: %d 💰\n* Min Increment: %d 💰\n* Card Level: %s\n* Collection: %s\n```",
+		SetDescription(fmt.Sprintf("```md\n## Auction Details\n* Auction ID: %s\n* Seller: <@%s>\n* Current Price: %d 💰\n* Next Minimum Bid: %d 💰\n* Card Level: %s\n* Collection: %s\n```",
 			auction.AuctionID,
 			auction.SellerID,
 			auction.CurrentPrice,
-			auction.MinIncrement,
+			ui.manager.MinimumBid(auction),
 			strings.Repeat("⭐", card.Level),
 			card.ColID))
 
@@ -86,6 +86,14 @@ func (ui *AuctionUI) CreateAuctionComponents(auctionID int64, currentPrice int64
 	return []discord.ContainerComponent{actionRow}
 }
 
+// WatchActionRow holds the Watch and Unwatch buttons shown with an auction
+func WatchActionRow(auctionID int64) discord.ActionRowComponent {
+	return discord.NewActionRow(
+		discord.NewSecondaryButton("👀 Watch", fmt.Sprintf("/auction/watch/%d", auctionID)),
+		discord.NewSecondaryButton("Unwatch", fmt.Sprintf("/auction/unwatch/%d", auctionID)),
+	)
+}
+
 // Helper function to format duration in a readable format
 func formatDuration(d time.Duration) string {
 	d = d.Round(time.Second)
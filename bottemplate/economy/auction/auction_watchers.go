@@ -0,0 +1,162 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
+)
+
+// SetWatcherRepository enables auction watching. Without it Watch fails and no
+// watcher DMs are sent; the previous top bidder is still told when outbid.
+func (m *Manager) SetWatcherRepository(repo repositories.AuctionWatcherRepository) {
+	m.watchers = repo
+}
+
+// Watch subscribes the user to DMs about an active auction's bids and ending.
+// It reports false if they were already watching.
+func (m *Manager) Watch(ctx context.Context, auctionID int64, userID string) (bool, error) {
+	if m.watchers == nil {
+		return false, fmt.Errorf("auction watching is not available")
+	}
+	auction, err := m.repo.GetByID(ctx, auctionID)
+	if err != nil {
+		return false, fmt.Errorf("auction not found: %w", err)
+	}
+	if auction.Status != models.AuctionStatusActive {
+		return false, fmt.Errorf("auction is not active")
+	}
+	return m.watchers.Watch(ctx, auctionID, userID)
+}
+
+// Unwatch stops the user's DMs about an auction. It reports false if they were not watching.
+func (m *Manager) Unwatch(ctx context.Context, auctionID int64, userID string) (bool, error) {
+	if m.watchers == nil {
+		return false, fmt.Errorf("auction watching is not available")
+	}
+	return m.watchers.Unwatch(ctx, auctionID, userID)
+}
+
+// bidRecipients splits who to DM about a new bid: the previous top bidder, who was
+// outbid, and the watchers, leaving out the new bidder and anyone already told as
+// the outbid user
+func bidRecipients(previousTopBidder, bidderID string, watchers []string) (outbid string, others []string) {
+	if previousTopBidder != bidderID {
+		outbid = previousTopBidder
+	}
+	for _, userID := range watchers {
+		if userID == bidderID || userID == outbid {
+			continue
+		}
+		others = append(others, userID)
+	}
+	return outbid, others
+}
+
+// notifyBid DMs the outbid user and the watchers after a bid has been committed.
+// auction holds the state from before the bid.
+func (m *Manager) notifyBid(ctx context.Context, auction *models.Auction, bidderID string, amount int64) {
+	var watchers []string
+	if m.watchers != nil {
+		var err error
+		watchers, err = m.watchers.GetWatchers(ctx, auction.ID)
+		if err != nil {
+			slog.Error("Failed to get auction watchers",
+				slog.Int64("auction_id", auction.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	outbid, others := bidRecipients(auction.TopBidderID, bidderID, watchers)
+	if outbid == "" && len(others) == 0 {
+		return
+	}
+
+	card := m.cardForNotification(ctx, auction.CardID)
+	if outbid != "" {
+		m.notifier.NotifyOutbidDM(auction, card, outbid, amount)
+	}
+	for _, userID := range others {
+		m.notifier.NotifyWatcherBid(auction, card, userID, amount)
+		time.Sleep(economicUtils.NotificationDelay)
+	}
+}
+
+// notifyEndingSoon DMs the watchers of auctions about to end, once per watcher
+func (m *Manager) notifyEndingSoon(ctx context.Context) error {
+	if m.watchers == nil {
+		return nil
+	}
+	pending, err := m.watchers.ClaimEndingSoon(ctx, time.Now().Add(economicUtils.AuctionEndingSoonWindow))
+	if err != nil {
+		return err
+	}
+
+	auctions := make(map[int64]*models.Auction)
+	for _, w := range pending {
+		auction, ok := auctions[w.AuctionID]
+		if !ok {
+			auction, err = m.repo.GetByID(ctx, w.AuctionID)
+			if err != nil {
+				slog.Error("Failed to get auction for ending notification",
+					slog.Int64("auction_id", w.AuctionID),
+					slog.String("error", err.Error()))
+				continue
+			}
+			auctions[w.AuctionID] = auction
+		}
+		m.notifier.NotifyEndingSoon(auction, m.cardForNotification(ctx, auction.CardID), w.UserID)
+		time.Sleep(economicUtils.NotificationDelay)
+	}
+	return nil
+}
+
+// closeWatchers DMs the watchers that the auction is over and drops them. The seller
+// and winner are skipped since they already know.
+func (m *Manager) closeWatchers(ctx context.Context, auction *models.Auction, card *models.Card) {
+	if m.watchers == nil {
+		return
+	}
+	watchers, err := m.watchers.GetWatchers(ctx, auction.ID)
+	if err != nil {
+		slog.Error("Failed to get auction watchers",
+			slog.Int64("auction_id", auction.ID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	for _, userID := range watchers {
+		if userID == auction.SellerID {
+			continue
+		}
+		if userID == auction.TopBidderID && auction.Status != models.AuctionStatusCancelled {
+			continue
+		}
+		m.notifier.NotifyWatcherClosed(auction, card, userID)
+		time.Sleep(economicUtils.NotificationDelay)
+	}
+
+	if err := m.watchers.Clear(ctx, auction.ID); err != nil {
+		slog.Error("Failed to clear auction watchers",
+			slog.Int64("auction_id", auction.ID),
+			slog.String("error", err.Error()))
+	}
+}
+
+// cardForNotification loads a card for DMs, falling back to a placeholder
+func (m *Manager) cardForNotification(ctx context.Context, cardID int64) *models.Card {
+	card, err := m.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		return &models.Card{
+			ID:    cardID,
+			Name:  fmt.Sprintf("Card #%d", cardID),
+			ColID: "unknown",
+			Level: 1,
+		}
+	}
+	return card
+}
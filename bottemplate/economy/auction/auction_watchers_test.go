@@ -0,0 +1,108 @@
+package auction
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
+)
+
+func TestBidRecipients(t *testing.T) {
+	tests := []struct {
+		name       string
+		previous   string
+		bidder     string
+		watchers   []string
+		wantOutbid string
+		wantOthers []string
+	}{
+		{name: "first bid", previous: "", bidder: "b1", watchers: []string{"w1"}, wantOthers: []string{"w1"}},
+		{name: "outbid", previous: "b1", bidder: "b2", watchers: []string{"w1"}, wantOutbid: "b1", wantOthers: []string{"w1"}},
+		// Raising your own bid doesn't tell you you're outbid
+		{name: "self raise", previous: "b1", bidder: "b1", watchers: []string{"b1", "w1"}, wantOthers: []string{"w1"}},
+		// A watcher who was outbid only gets the outbid DM
+		{name: "watching outbid user", previous: "b1", bidder: "b2", watchers: []string{"b1", "b2", "w1"}, wantOutbid: "b1", wantOthers: []string{"w1"}},
+		{name: "nobody to tell", previous: "", bidder: "b1", watchers: []string{"b1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbid, others := bidRecipients(tt.previous, tt.bidder, tt.watchers)
+			if outbid != tt.wantOutbid || !reflect.DeepEqual(others, tt.wantOthers) {
+				t.Errorf("bidRecipients = %q, %q, want %q, %q", outbid, others, tt.wantOutbid, tt.wantOthers)
+			}
+		})
+	}
+}
+
+func TestAllowBidDM(t *testing.T) {
+	n := NewAuctionNotifier(nil)
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	if !n.allowBidDM(1, "u1", now) {
+		t.Fatal("first DM refused")
+	}
+	if n.allowBidDM(1, "u1", now.Add(30*time.Second)) {
+		t.Error("second DM within the interval allowed")
+	}
+	if !n.allowBidDM(2, "u1", now) || !n.allowBidDM(1, "u2", now) {
+		t.Error("other auctions and users share the limit")
+	}
+	if !n.allowBidDM(1, "u1", now.Add(economicUtils.AuctionWatchDMInterval)) {
+		t.Error("DM refused after the interval")
+	}
+}
+
+func TestAuctionCardName(t *testing.T) {
+	if got := auctionCardName(&models.Card{Name: "momo", ColID: "twice", Level: 3}); got != "★★★ momo [twice]" {
+		t.Errorf("auctionCardName = %q", got)
+	}
+	if got := auctionCardName(&models.Card{Name: "Card #7", Level: 0}); got != "Card #7" {
+		t.Errorf("auctionCardName without a level = %q", got)
+	}
+}
+
+// preferenceUserRepo answers GetByDiscordID from a fixed set of users
+type preferenceUserRepo struct {
+	repositories.UserRepository
+	users map[string]*models.User
+}
+
+func (r *preferenceUserRepo) GetByDiscordID(ctx context.Context, discordID string) (*models.User, error) {
+	if user, ok := r.users[discordID]; ok {
+		return user, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func TestWantsDM(t *testing.T) {
+	n := NewAuctionNotifier(nil)
+	if !n.wantsDM(context.Background(), "muted", outbidPreference) {
+		t.Error("DM skipped without a user repository")
+	}
+
+	muted := models.DefaultPreferences()
+	muted.Notifications.AucOutBid = false
+	n.SetUserRepository(&preferenceUserRepo{users: map[string]*models.User{
+		"muted":   {DiscordID: "muted", Preferences: muted},
+		"default": {DiscordID: "default"},
+	}})
+
+	if n.wantsDM(context.Background(), "muted", outbidPreference) {
+		t.Error("outbid DM sent to a user who turned it off")
+	}
+	if !n.wantsDM(context.Background(), "muted", watcherPreference) {
+		t.Error("other notifications skipped too")
+	}
+	if !n.wantsDM(context.Background(), "default", auctionEndPreference) {
+		t.Error("DM skipped for a user without saved preferences")
+	}
+	// A failed lookup falls back to sending, as every notification defaults to on
+	if !n.wantsDM(context.Background(), "unknown", outbidPreference) {
+		t.Error("DM skipped when the user lookup failed")
+	}
+}
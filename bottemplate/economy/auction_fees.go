@@ -0,0 +1,43 @@
+package economy
+
+import "fmt"
+
+// AuctionFees are currency sinks on the auction house. The listing fee is charged to
+// the seller when the auction is created and is not refunded; the commission is kept
+// back from the winning bid. Neither is paid to anyone. Zero means no fee.
+type AuctionFees struct {
+	ListingFee        int64   `toml:"listing_fee"`         // flat part of the listing fee
+	ListingFeePercent float64 `toml:"listing_fee_percent"` // of the start price, added to listing_fee
+	CommissionPercent float64 `toml:"commission_percent"`  // of the final price
+}
+
+// Listing returns the fee for listing an auction at the given start price
+func (f AuctionFees) Listing(startPrice int64) int64 {
+	return f.ListingFee + int64(float64(startPrice)*f.ListingFeePercent/100)
+}
+
+// CommissionBps returns the commission rate in basis points, which is what auctions
+// store so a rate change does not affect auctions already running
+func (f AuctionFees) CommissionBps() int64 {
+	return int64(f.CommissionPercent*100 + 0.5)
+}
+
+// Validate reports the first inconsistency in the fees
+func (f AuctionFees) Validate() error {
+	if f.ListingFee < 0 {
+		return fmt.Errorf("listing_fee must not be negative, got %d", f.ListingFee)
+	}
+	percents := []struct {
+		key   string
+		value float64
+	}{
+		{"listing_fee_percent", f.ListingFeePercent},
+		{"commission_percent", f.CommissionPercent},
+	}
+	for _, p := range percents {
+		if p.value < 0 || p.value > 100 {
+			return fmt.Errorf("%s must be between 0 and 100, got %v", p.key, p.value)
+		}
+	}
+	return nil
+}
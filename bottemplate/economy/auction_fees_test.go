@@ -0,0 +1,29 @@
+package economy
+
+import "testing"
+
+func TestAuctionFees(t *testing.T) {
+	fees := AuctionFees{ListingFee: 50, ListingFeePercent: 2, CommissionPercent: 7.5}
+	if got := fees.Listing(1000); got != 70 {
+		t.Errorf("Listing(1000) = %d, want 50 + 2%%", got)
+	}
+	if got := fees.CommissionBps(); got != 750 {
+		t.Errorf("CommissionBps = %d, want 750", got)
+	}
+	// Rates that don't convert exactly round to the nearest basis point
+	if got := (AuctionFees{CommissionPercent: 0.125}).CommissionBps(); got != 13 {
+		t.Errorf("CommissionBps(0.125%%) = %d, want 13", got)
+	}
+	if got := (AuctionFees{}).Listing(1000); got != 0 {
+		t.Errorf("Listing without fees = %d", got)
+	}
+
+	if err := fees.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	for _, invalid := range []AuctionFees{{ListingFee: -1}, {ListingFeePercent: -1}, {CommissionPercent: 101}} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Validate accepted %+v", invalid)
+		}
+	}
+}
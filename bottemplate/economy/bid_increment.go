@@ -0,0 +1,45 @@
+package economy
+
+import "fmt"
+
+// BidIncrement scales the minimum auction bid increment with the current price, so
+// cheap and expensive auctions both move in sensible steps. With Percent at zero each
+// auction's own flat min_increment is used.
+type BidIncrement struct {
+	Percent float64 `toml:"percent"` // of the current price
+	Minimum int64   `toml:"minimum"` // floor on the scaled increment; 0 uses the auction's min_increment
+}
+
+// Enabled reports whether increments scale with the price
+func (b BidIncrement) Enabled() bool {
+	return b.Percent > 0
+}
+
+// Increment returns the smallest raise allowed over currentPrice. flat is the
+// auction's own min_increment, used when scaling is off or as the default floor.
+func (b BidIncrement) Increment(currentPrice, flat int64) int64 {
+	if !b.Enabled() {
+		return flat
+	}
+	floor := b.Minimum
+	if floor == 0 {
+		floor = flat
+	}
+	return max(int64(float64(currentPrice)*b.Percent/100), floor)
+}
+
+// MinimumBid returns the lowest valid next bid on an auction at currentPrice
+func (b BidIncrement) MinimumBid(currentPrice, flat int64) int64 {
+	return currentPrice + b.Increment(currentPrice, flat)
+}
+
+// Validate reports the first inconsistency in the increment settings
+func (b BidIncrement) Validate() error {
+	if b.Percent < 0 || b.Percent > 100 {
+		return fmt.Errorf("percent must be between 0 and 100, got %v", b.Percent)
+	}
+	if b.Minimum < 0 {
+		return fmt.Errorf("minimum must not be negative, got %d", b.Minimum)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package economy
+
+import "testing"
+
+func TestBidIncrementMinimumBid(t *testing.T) {
+	tests := []struct {
+		name      string
+		increment BidIncrement
+		price     int64
+		flat      int64
+		want      int64
+	}{
+		{name: "disabled", increment: BidIncrement{}, price: 10000, flat: 10, want: 10010},
+		{name: "scaled", increment: BidIncrement{Percent: 5}, price: 10000, flat: 10, want: 10500},
+		// Cheap auctions fall back to the flat increment as the floor
+		{name: "flat floor", increment: BidIncrement{Percent: 5}, price: 100, flat: 10, want: 110},
+		{name: "configured floor", increment: BidIncrement{Percent: 5, Minimum: 50}, price: 100, flat: 10, want: 150},
+		{name: "rounds down", increment: BidIncrement{Percent: 2.5}, price: 1030, flat: 1, want: 1055},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.increment.MinimumBid(tt.price, tt.flat); got != tt.want {
+				t.Errorf("MinimumBid(%d, %d) = %d, want %d", tt.price, tt.flat, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBidIncrementValidate(t *testing.T) {
+	if err := (BidIncrement{Percent: 5, Minimum: 10}).Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	for _, invalid := range []BidIncrement{{Percent: -1}, {Percent: 150}, {Minimum: -5}} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Validate accepted %+v", invalid)
+		}
+	}
+}
@@ -21,15 +21,50 @@ type Manager struct {
 	lockDuration    time.Duration // Added as configurable parameter
 	sessionTimeout  time.Duration
 	claimCards      sync.Map // stores messageID -> []models.Card
+	offers          sync.Map // stores userID -> *Offer
+	now             func() time.Time
+	cooldownFor     func(userID string, base time.Duration) time.Duration
 }
 
-func NewManager(cooldownPeriod time.Duration) *Manager {
+const (
+	DefaultCooldownPeriod = 5 * time.Second
+	DefaultSessionTimeout = 30 * time.Second
+)
+
+// NewManager creates a claim manager. Non-positive durations fall back to the defaults; the
+// session timeout bounds both claim locks and pending multi-card offers.
+func NewManager(cooldownPeriod, sessionTimeout time.Duration) *Manager {
+	if cooldownPeriod <= 0 {
+		cooldownPeriod = DefaultCooldownPeriod
+	}
+	if sessionTimeout <= 0 {
+		sessionTimeout = DefaultSessionTimeout
+	}
 	return &Manager{
 		maxClaims:      3,
 		cooldownPeriod: cooldownPeriod,
-		lockDuration:   30 * time.Second,
-		sessionTimeout: 30 * time.Second,
+		lockDuration:   sessionTimeout,
+		sessionTimeout: sessionTimeout,
+		now:            time.Now,
+	}
+}
+
+// SetCooldownModifier lets fn adjust the cooldown per user, e.g. shorter for premium users
+func (m *Manager) SetCooldownModifier(fn func(userID string, base time.Duration) time.Duration) {
+	m.cooldownFor = fn
+}
+
+// cooldown returns the cooldown to apply after userID's claim
+func (m *Manager) cooldown(userID string) time.Duration {
+	if m.cooldownFor == nil {
+		return m.cooldownPeriod
 	}
+	return m.cooldownFor(userID, m.cooldownPeriod)
+}
+
+// SessionTimeout returns how long claim sessions and offers stay valid
+func (m *Manager) SessionTimeout() time.Duration {
+	return m.sessionTimeout
 }
 
 func (m *Manager) CanClaim(userID string) (bool, time.Duration) {
@@ -74,6 +109,7 @@ func (m *Manager) ReleaseClaim(userID string) {
 	m.activeClaimLock.Delete(userID)
 	m.activeUsers.Delete(userID)
 	m.claimOwners.Delete(userID)
+	m.offers.Delete(userID)
 
 	// Clean up message owners - need to iterate to find messages owned by this user
 	var messagesToDelete []string
@@ -92,11 +128,11 @@ func (m *Manager) ReleaseClaim(userID string) {
 	}
 
 	// Set cooldown
-	m.claimCooldowns.Store(userID, time.Now().Add(m.cooldownPeriod))
+	m.claimCooldowns.Store(userID, time.Now().Add(m.cooldown(userID)))
 }
 
 func (m *Manager) SetClaimCooldown(userID string) {
-	m.claimCooldowns.Store(userID, time.Now().Add(m.cooldownPeriod))
+	m.claimCooldowns.Store(userID, time.Now().Add(m.cooldown(userID)))
 }
 
 func (m *Manager) GetClaimStats(userID string) (used int, remaining int, nextReset time.Time) {
@@ -162,28 +198,35 @@ func (m *Manager) cleanupExpiredLocks() {
 		// Use ReleaseClaim to ensure consistent cleanup
 		m.ReleaseClaim(userID)
 	}
+
+	m.cleanupExpiredOffers()
 }
 
+// StartCleanupRoutine runs RunCleanupRoutine in its own goroutine
 func (m *Manager) StartCleanupRoutine(ctx context.Context) {
+	go m.RunCleanupRoutine(ctx)
+}
+
+// RunCleanupRoutine removes expired claim locks every 30 seconds and returns
+// as soon as ctx is cancelled
+func (m *Manager) RunCleanupRoutine(ctx context.Context) {
 	ticker := time.NewTicker(30 * time.Second)
-	go func() {
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				func() {
-					defer func() {
-						if r := recover(); r != nil {
-							slog.Error("Panic in claim cleanup routine", slog.Any("panic", r))
-						}
-					}()
-					m.cleanupExpiredLocks()
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						slog.Error("Panic in claim cleanup routine", slog.Any("panic", r))
+					}
 				}()
-			}
+				m.cleanupExpiredLocks()
+			}()
 		}
-	}()
+	}
 }
 
 func (m *Manager) IsClaimOwner(userID string) bool {
@@ -0,0 +1,97 @@
+package claim
+
+import (
+	"errors"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+var (
+	ErrNoOffer      = errors.New("no pending claim offer")
+	ErrOfferExpired = errors.New("claim offer expired")
+	ErrInvalidPick  = errors.New("invalid card selection")
+)
+
+// Offer is a pending multi-card claim: the user browses the cards and picks exactly one.
+// Nothing is granted or charged until the pick, so unpicked cards simply stay in the pool.
+type Offer struct {
+	UserID    string
+	Cards     []*models.Card
+	Exp       []int64 // initial EXP granted with the card at the same index
+	Cost      int64
+	ExpiresAt time.Time
+}
+
+func (o *Offer) expired(now time.Time) bool {
+	return !now.Before(o.ExpiresAt)
+}
+
+// CreateOffer stores a pending offer for the user, replacing any previous one. The offer
+// lives as long as a claim session.
+func (m *Manager) CreateOffer(userID string, cards []*models.Card, exp []int64, cost int64) *Offer {
+	offer := &Offer{
+		UserID:    userID,
+		Cards:     cards,
+		Exp:       exp,
+		Cost:      cost,
+		ExpiresAt: m.now().Add(m.sessionTimeout),
+	}
+	m.offers.Store(userID, offer)
+	return offer
+}
+
+// GetOffer returns the user's pending offer if it has not expired
+func (m *Manager) GetOffer(userID string) (*Offer, error) {
+	value, ok := m.offers.Load(userID)
+	if !ok {
+		return nil, ErrNoOffer
+	}
+	offer := value.(*Offer)
+	if offer.expired(m.now()) {
+		m.offers.CompareAndDelete(userID, offer)
+		return nil, ErrOfferExpired
+	}
+	return offer, nil
+}
+
+// TakeOffer consumes the user's offer and returns the picked card with its initial EXP and
+// the claim cost. The offer is removed atomically, so concurrent picks resolve to one winner.
+func (m *Manager) TakeOffer(userID string, index int) (*models.Card, int64, int64, error) {
+	value, ok := m.offers.Load(userID)
+	if !ok {
+		return nil, 0, 0, ErrNoOffer
+	}
+	offer := value.(*Offer)
+	if offer.expired(m.now()) {
+		m.offers.CompareAndDelete(userID, offer)
+		return nil, 0, 0, ErrOfferExpired
+	}
+	if index < 0 || index >= len(offer.Cards) {
+		return nil, 0, 0, ErrInvalidPick
+	}
+	if !m.offers.CompareAndDelete(userID, offer) {
+		return nil, 0, 0, ErrNoOffer
+	}
+
+	var exp int64
+	if index < len(offer.Exp) {
+		exp = offer.Exp[index]
+	}
+	return offer.Cards[index], exp, offer.Cost, nil
+}
+
+// DiscardOffer drops the user's pending offer without granting anything
+func (m *Manager) DiscardOffer(userID string) {
+	m.offers.Delete(userID)
+}
+
+func (m *Manager) cleanupExpiredOffers() {
+	now := m.now()
+	m.offers.Range(func(key, value interface{}) bool {
+		if value.(*Offer).expired(now) {
+			m.offers.CompareAndDelete(key, value)
+		}
+		return true
+	})
+}
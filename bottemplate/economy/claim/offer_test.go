@@ -0,0 +1,127 @@
+package claim
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// newTestManager returns a manager whose clock is advanced through the returned pointer
+func newTestManager(sessionTimeout time.Duration) (*Manager, *time.Time) {
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := NewManager(time.Minute, sessionTimeout)
+	m.now = func() time.Time { return clock }
+	return m, &clock
+}
+
+func offerCards() []*models.Card {
+	return []*models.Card{{ID: 1, Name: "nayeon"}, {ID: 2, Name: "jeongyeon"}, {ID: 3, Name: "momo"}}
+}
+
+func TestNewManagerDefaults(t *testing.T) {
+	m := NewManager(0, -time.Second)
+	if m.cooldownPeriod != DefaultCooldownPeriod || m.SessionTimeout() != DefaultSessionTimeout {
+		t.Errorf("cooldown %s, session %s, want the defaults", m.cooldownPeriod, m.SessionTimeout())
+	}
+
+	m = NewManager(time.Second, 2*time.Minute)
+	if m.SessionTimeout() != 2*time.Minute || m.lockDuration != 2*time.Minute {
+		t.Errorf("session %s, lock %s, want 2m for both", m.SessionTimeout(), m.lockDuration)
+	}
+}
+
+func TestTakeOfferSelection(t *testing.T) {
+	m, _ := newTestManager(time.Minute)
+	m.CreateOffer("u1", offerCards(), []int64{10, 20}, 500)
+
+	if _, _, _, err := m.TakeOffer("u1", 3); !errors.Is(err, ErrInvalidPick) {
+		t.Fatalf("pick out of range: err = %v, want ErrInvalidPick", err)
+	}
+	if _, err := m.GetOffer("u1"); err != nil {
+		t.Fatalf("an invalid pick consumed the offer: %v", err)
+	}
+
+	card, exp, cost, err := m.TakeOffer("u1", 1)
+	if err != nil {
+		t.Fatalf("TakeOffer: %v", err)
+	}
+	if card.ID != 2 || exp != 20 || cost != 500 {
+		t.Errorf("took card %d with %d EXP for %d, want card 2 with 20 EXP for 500", card.ID, exp, cost)
+	}
+
+	if _, _, _, err := m.TakeOffer("u1", 0); !errors.Is(err, ErrNoOffer) {
+		t.Errorf("second pick: err = %v, want ErrNoOffer", err)
+	}
+
+	// Cards without a matching EXP entry start at zero
+	m.CreateOffer("u1", offerCards(), []int64{10, 20}, 500)
+	if _, exp, _, err := m.TakeOffer("u1", 2); err != nil || exp != 0 {
+		t.Errorf("third card: exp = %d, err = %v, want 0", exp, err)
+	}
+}
+
+func TestOfferExpiry(t *testing.T) {
+	m, clock := newTestManager(30 * time.Second)
+	offer := m.CreateOffer("u1", offerCards(), nil, 100)
+	if want := clock.Add(30 * time.Second); !offer.ExpiresAt.Equal(want) {
+		t.Errorf("expires at %s, want %s", offer.ExpiresAt, want)
+	}
+
+	*clock = clock.Add(29 * time.Second)
+	if _, err := m.GetOffer("u1"); err != nil {
+		t.Fatalf("offer gone before the session timeout: %v", err)
+	}
+
+	*clock = clock.Add(time.Second)
+	if _, _, _, err := m.TakeOffer("u1", 0); !errors.Is(err, ErrOfferExpired) {
+		t.Fatalf("pick at expiry: err = %v, want ErrOfferExpired", err)
+	}
+	if _, err := m.GetOffer("u1"); !errors.Is(err, ErrNoOffer) {
+		t.Errorf("expired offer still stored: err = %v", err)
+	}
+}
+
+func TestCleanupExpiredOffers(t *testing.T) {
+	m, clock := newTestManager(30 * time.Second)
+	m.CreateOffer("old", offerCards(), nil, 100)
+	*clock = clock.Add(20 * time.Second)
+	m.CreateOffer("new", offerCards(), nil, 100)
+	*clock = clock.Add(15 * time.Second)
+
+	m.cleanupExpiredOffers()
+
+	if _, ok := m.offers.Load("old"); ok {
+		t.Error("expired offer was kept")
+	}
+	if _, ok := m.offers.Load("new"); !ok {
+		t.Error("live offer was removed")
+	}
+}
+
+func TestTakeOfferConcurrentPicks(t *testing.T) {
+	m, _ := newTestManager(time.Minute)
+	m.CreateOffer("u1", offerCards(), nil, 100)
+
+	var mu sync.Mutex
+	var wins int
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			if _, _, _, err := m.TakeOffer("u1", index%3); err == nil {
+				mu.Lock()
+				wins++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if wins != 1 {
+		t.Errorf("%d picks succeeded, want 1", wins)
+	}
+}
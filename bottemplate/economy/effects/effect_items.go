@@ -4,17 +4,19 @@ import "github.com/disgoorg/bot-template/bottemplate/database/models"
 
 // EffectItemData represents the static data for effect items available in the shop
 type EffectItemData struct {
-	ID          string
-	Name        string
-	Description string
-	Type        string
-	Price       int64
-	Currency    string
-	Recipe      []int64
-	Duration    int
-	Passive     bool
-	Cooldown    int
-	TierData    *models.EffectTierData // Tier progression data (nil for non-tiered effects)
+	ID           string
+	Name         string
+	Description  string
+	Type         string
+	Price        int64
+	Currency     string
+	Recipe       []int64
+	Duration     int
+	Passive      bool
+	Cooldown     int
+	TierData     *models.EffectTierData // Tier progression data (nil for non-tiered effects)
+	Stock        int                    // Shop stock per restock; 0 means unlimited
+	RestockHours int                    // Hours between shop restocks of limited items
 }
 
 // StaticEffectItems contains all effect items available for purchase
@@ -167,16 +169,18 @@ var StaticEffectItems = []EffectItemData{
 		Cooldown:    40,
 	},
 	{
-		ID:          "judgeday",
-		Name:        "Judgement Day",
-		Description: "Can be used as any other item",
-		Type:        models.EffectTypeRecipe,
-		Price:       16000,
-		Currency:    models.CurrencyTomato,
-		Recipe:      []int64{2, 2, 3, 3, 3},
-		Duration:    14,
-		Passive:     false,
-		Cooldown:    48,
+		ID:           "judgeday",
+		Name:         "Judgement Day",
+		Description:  "Can be used as any other item",
+		Type:         models.EffectTypeRecipe,
+		Price:        16000,
+		Currency:     models.CurrencyTomato,
+		Recipe:       []int64{2, 2, 3, 3, 3},
+		Duration:     14,
+		Passive:      false,
+		Cooldown:     48,
+		Stock:        5,
+		RestockHours: 24,
 	},
 	{
 		ID:          "walpurgisnight",
@@ -202,6 +206,20 @@ var StaticEffectItems = []EffectItemData{
 		Passive:     false,
 		Cooldown:    15,
 	},
+	{
+		ID:           "fandomfever",
+		Name:         "Fandom Fever",
+		Description:  "Level-ups of cards from a collection of choice gain 50% more EXP for 6 hours",
+		Type:         models.EffectTypeRecipe,
+		Price:        11000,
+		Currency:     models.CurrencyTomato,
+		Recipe:       []int64{1, 2, 2, 3},
+		Duration:     6,
+		Passive:      false,
+		Cooldown:     24,
+		Stock:        10,
+		RestockHours: 12,
+	},
 }
 
 // GetEffectItemByID returns an effect item by its ID
@@ -214,6 +232,17 @@ func GetEffectItemByID(id string) *EffectItemData {
 	return nil
 }
 
+// GetLimitedEffectItems returns the effect items sold in limited stock
+func GetLimitedEffectItems() []EffectItemData {
+	var items []EffectItemData
+	for _, item := range StaticEffectItems {
+		if item.Stock > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // GetPassiveEffectItems returns all passive effect items
 func GetPassiveEffectItems() []EffectItemData {
 	var items []EffectItemData
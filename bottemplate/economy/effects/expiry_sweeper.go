@@ -0,0 +1,119 @@
+package effects
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+const (
+	// DefaultExpirySweepInterval is used when no sweep interval is configured
+	DefaultExpirySweepInterval = 5 * time.Minute
+	expirySweepBatchSize       = 500
+)
+
+// ExpiryNotifier tells a user that one of their effects has expired
+type ExpiryNotifier interface {
+	NotifyEffectExpired(ctx context.Context, userID string, effectName string) error
+}
+
+// ExpirySweeper deactivates effects whose expiry has passed and notifies their owners
+type ExpirySweeper struct {
+	repo     repositories.EffectRepository
+	notifier ExpiryNotifier
+	interval time.Duration
+}
+
+// NewExpirySweeper creates a sweeper; a nil notifier disables notifications
+func NewExpirySweeper(repo repositories.EffectRepository, notifier ExpiryNotifier, interval time.Duration) *ExpirySweeper {
+	if interval <= 0 {
+		interval = DefaultExpirySweepInterval
+	}
+	return &ExpirySweeper{
+		repo:     repo,
+		notifier: notifier,
+		interval: interval,
+	}
+}
+
+// Run sweeps immediately and then on every interval until ctx is cancelled
+func (s *ExpirySweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := s.Sweep(ctx)
+		if err != nil {
+			slog.Error("Failed to sweep expired effects", slog.Any("error", err))
+		}
+		utils.RecordProcessRun(ctx, err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Sweep deactivates all currently expired effects and returns how many it deactivated.
+// Running it again, or concurrently, never deactivates or notifies the same effect twice.
+func (s *ExpirySweeper) Sweep(ctx context.Context) (int, error) {
+	deactivated := 0
+	for {
+		expired, err := s.repo.GetExpiredActiveEffects(ctx, expirySweepBatchSize)
+		if err != nil {
+			return deactivated, err
+		}
+
+		for _, userEffect := range expired {
+			if s.expire(ctx, userEffect) {
+				deactivated++
+			}
+		}
+
+		if len(expired) < expirySweepBatchSize {
+			break
+		}
+	}
+
+	if deactivated > 0 {
+		slog.Info("Deactivated expired effects", slog.Int("count", deactivated))
+	}
+	return deactivated, nil
+}
+
+// expire deactivates a single effect and notifies its owner if they haven't been told yet
+func (s *ExpirySweeper) expire(ctx context.Context, userEffect *models.UserEffect) bool {
+	claimed, err := s.repo.ExpireUserEffect(ctx, userEffect.ID)
+	if err != nil {
+		slog.Warn("Failed to deactivate expired effect",
+			slog.String("user_id", userEffect.UserID),
+			slog.String("effect_id", userEffect.EffectID),
+			slog.Any("error", err))
+		return false
+	}
+	if !claimed {
+		return false // Another sweep got there first
+	}
+
+	if s.notifier != nil && !userEffect.Notified {
+		effectName := userEffect.EffectID
+		if staticEffect := GetEffectItemByID(userEffect.EffectID); staticEffect != nil {
+			effectName = staticEffect.Name
+		}
+
+		if err := s.notifier.NotifyEffectExpired(ctx, userEffect.UserID, effectName); err != nil {
+			slog.Debug("Failed to send effect expiry notification",
+				slog.String("user_id", userEffect.UserID),
+				slog.String("effect_id", userEffect.EffectID),
+				slog.Any("error", err))
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,112 @@
+package effects
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// GetExpiredActiveEffects returns copies, like rows read from the database
+func (r *fakeEffectRepo) GetExpiredActiveEffects(ctx context.Context, limit int) ([]*models.UserEffect, error) {
+	now := time.Now()
+	var expired []*models.UserEffect
+	for _, effect := range r.effects {
+		if effect.Active && !effect.IsRecipe && effect.ExpiresAt != nil && effect.ExpiresAt.Before(now) {
+			row := *effect
+			expired = append(expired, &row)
+			if len(expired) == limit {
+				break
+			}
+		}
+	}
+	return expired, nil
+}
+
+func (r *fakeEffectRepo) ExpireUserEffect(ctx context.Context, id int64) (bool, error) {
+	for _, effect := range r.effects {
+		if effect.ID == id && effect.Active {
+			effect.Active = false
+			effect.Notified = true
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type recordingNotifier struct {
+	notified []string
+}
+
+func (n *recordingNotifier) NotifyEffectExpired(ctx context.Context, userID string, effectName string) error {
+	n.notified = append(n.notified, userID+":"+effectName)
+	return nil
+}
+
+func TestExpirySweeperDeactivatesPastExpiry(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	repo := &fakeEffectRepo{effects: []*models.UserEffect{
+		{ID: 1, UserID: "u1", EffectID: "cakeday", Active: true, ExpiresAt: &past},
+		{ID: 2, UserID: "u2", EffectID: "cakeday", Active: true, ExpiresAt: &future},
+		// Already told about it, e.g. by the legacy bot
+		{ID: 3, UserID: "u3", EffectID: "holygrail", Active: true, ExpiresAt: &past, Notified: true},
+		{ID: 4, UserID: "u4", EffectID: "cakeday", Active: true},
+	}}
+	notifier := &recordingNotifier{}
+	sweeper := NewExpirySweeper(repo, notifier, 0)
+
+	n, err := sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("deactivated %d effects, want 2", n)
+	}
+
+	wantActive := map[int64]bool{1: false, 2: true, 3: false, 4: true}
+	for _, effect := range repo.effects {
+		if effect.Active != wantActive[effect.ID] {
+			t.Errorf("effect %d active = %v, want %v", effect.ID, effect.Active, wantActive[effect.ID])
+		}
+	}
+
+	if len(notifier.notified) != 1 || notifier.notified[0][:3] != "u1:" {
+		t.Errorf("notified %v, want only u1", notifier.notified)
+	}
+}
+
+func TestExpirySweeperIsIdempotent(t *testing.T) {
+	past := time.Now().Add(-time.Second)
+	repo := &fakeEffectRepo{effects: []*models.UserEffect{
+		{ID: 1, UserID: "u1", EffectID: "cakeday", Active: true, ExpiresAt: &past},
+	}}
+	notifier := &recordingNotifier{}
+	sweeper := NewExpirySweeper(repo, notifier, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := sweeper.Sweep(context.Background()); err != nil {
+			t.Fatalf("Sweep %d: %v", i+1, err)
+		}
+	}
+	if len(notifier.notified) != 1 {
+		t.Errorf("notified %d times, want once", len(notifier.notified))
+	}
+}
+
+func TestExpirySweeperBatches(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	repo := &fakeEffectRepo{}
+	for i := 0; i < expirySweepBatchSize+3; i++ {
+		repo.effects = append(repo.effects, &models.UserEffect{ID: int64(i + 1), UserID: "u1", EffectID: "cakeday", Active: true, ExpiresAt: &past})
+	}
+
+	n, err := NewExpirySweeper(repo, nil, 0).Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if n != expirySweepBatchSize+3 {
+		t.Errorf("deactivated %d effects, want %d", n, expirySweepBatchSize+3)
+	}
+}
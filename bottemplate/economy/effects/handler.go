@@ -26,6 +26,18 @@ const (
 	EffectCategoryUtility    EffectCategory = "utility"
 )
 
+// StackPolicy defines how an effect combines with other live effects modifying the same stat
+type StackPolicy string
+
+const (
+	// StackMultiplicative effects are applied one after another, compounding each other (default)
+	StackMultiplicative StackPolicy = "multiplicative"
+	// StackAdditive effects each compute their bonus from the base value and the bonuses are summed
+	StackAdditive StackPolicy = "additive"
+	// StackExclusive effects can't be live alongside any other effect modifying the same stat
+	StackExclusive StackPolicy = "exclusive"
+)
+
 // EffectMetadata contains metadata about an effect
 type EffectMetadata struct {
 	ID          string         `json:"id"`
@@ -38,6 +50,8 @@ type EffectMetadata struct {
 	Animated    bool           `json:"animated"`
 	Tags        []string       `json:"tags"`
 	Version     string         `json:"version"`
+	Stacking    StackPolicy    `json:"stacking"` // How this effect combines with others on the same stat
+	Modifies    []string       `json:"modifies"` // Stats (integrator actions) this effect modifies
 }
 
 // EffectParams contains parameters for effect execution
@@ -243,17 +243,22 @@ func (h *SpaceUnityHandler) Execute(ctx context.Context, params effects.EffectPa
 
 // findCollection finds a collection by ID, alias, or name
 func (h *SpaceUnityHandler) findCollection(ctx context.Context, query string) (*models.Collection, error) {
+	return findCollectionByQuery(ctx, h.db, query)
+}
+
+// findCollectionByQuery looks a collection up by ID, alias or partial name
+func findCollectionByQuery(ctx context.Context, db *bun.DB, query string) (*models.Collection, error) {
 	var collection models.Collection
 
 	// First try exact ID match
-	err := h.db.NewSelect().
+	err := db.NewSelect().
 		Model(&collection).
 		Where("id = ?", query).
 		Scan(ctx)
 
 	// If not found by ID, try aliases
 	if err != nil {
-		err = h.db.NewSelect().
+		err = db.NewSelect().
 			Model(&collection).
 			Where("aliases @> ?", fmt.Sprintf(`["%s"]`, query)).
 			Scan(ctx)
@@ -261,7 +266,7 @@ func (h *SpaceUnityHandler) findCollection(ctx context.Context, query string) (*
 
 	// If still not found, try case-insensitive search
 	if err != nil {
-		err = h.db.NewSelect().
+		err = db.NewSelect().
 			Model(&collection).
 			Where("LOWER(id) = LOWER(?)", query).
 			Scan(ctx)
@@ -269,7 +274,7 @@ func (h *SpaceUnityHandler) findCollection(ctx context.Context, query string) (*
 
 	// If still not found, try partial name matching
 	if err != nil {
-		err = h.db.NewSelect().
+		err = db.NewSelect().
 			Model(&collection).
 			Where("LOWER(name) LIKE LOWER(?)", "%"+query+"%").
 			Limit(1).
@@ -765,3 +770,104 @@ func (h *PbocchiHandler) ConsumeUse(ctx context.Context, userID string) error {
 func (h *PbocchiHandler) GetRemainingUses(ctx context.Context, userID string) (int, error) {
 	return h.GetMetadata().MaxUses, nil
 }
+
+// FandomFeverHandler implements the "Fandom Fever" active effect
+type FandomFeverHandler struct {
+	*effects.BaseEffectHandler
+	effectRepo repositories.EffectRepository
+	db         *bun.DB
+}
+
+// NewFandomFeverHandler creates a new Fandom Fever effect handler
+func NewFandomFeverHandler(deps *effects.EffectDependencies) *FandomFeverHandler {
+	metadata := effects.EffectMetadata{
+		ID:          "fandomfever",
+		Name:        "Fandom Fever",
+		Description: "Level-ups of cards from a collection of choice gain 50% more EXP for 6 hours",
+		Type:        effects.EffectTypeActive,
+		Category:    effects.EffectCategoryCollection,
+		Cooldown:    24 * time.Hour,
+		MaxUses:     6, // Based on static data duration
+		Animated:    false,
+		Tags:        []string{"active", "collection", "levelup", "exp_boost"},
+		Version:     "1.0.0",
+	}
+
+	return &FandomFeverHandler{
+		BaseEffectHandler: effects.NewBaseEffectHandler(metadata, deps),
+		effectRepo:        deps.EffectRepo.(repositories.EffectRepository),
+		db:                deps.Database.(*bun.DB),
+	}
+}
+
+// Execute starts an EXP boost for the chosen collection, replacing any boost already running
+func (h *FandomFeverHandler) Execute(ctx context.Context, params effects.EffectParams) (*effects.EffectResult, error) {
+	if params.Arguments == "" {
+		return &effects.EffectResult{
+			Success:  false,
+			Message:  "Please specify collection ID (e.g., 'twice' or 'blackpink')",
+			Consumed: false,
+		}, nil
+	}
+
+	collection, err := findCollectionByQuery(ctx, h.db, strings.TrimPrefix(params.Arguments, "-"))
+	if err != nil {
+		return &effects.EffectResult{
+			Success:  false,
+			Message:  fmt.Sprintf("Collection '%s' not found", params.Arguments),
+			Consumed: false,
+		}, nil
+	}
+
+	expiresAt := time.Now().Add(effects.CollectionExpBoostDuration)
+	boost := &models.CollectionExpBoost{
+		UserID:       params.UserID,
+		CollectionID: collection.ID,
+		Multiplier:   effects.CollectionExpBoostMultiplier,
+		ExpiresAt:    expiresAt,
+	}
+	if err := h.effectRepo.SetCollectionExpBoost(ctx, boost); err != nil {
+		return nil, fmt.Errorf("failed to start collection EXP boost: %w", err)
+	}
+
+	slog.Info("Fandom Fever effect executed",
+		slog.String("user_id", params.UserID),
+		slog.String("collection_id", collection.ID),
+		slog.Time("expires_at", expiresAt))
+
+	return &effects.EffectResult{
+		Success:  true,
+		Message:  fmt.Sprintf("level-ups in **%s** gain %d%% more EXP until <t:%d:t>", collection.Name, int((effects.CollectionExpBoostMultiplier-1)*100), expiresAt.Unix()),
+		Consumed: true,
+		Data: map[string]interface{}{
+			"collection_id": collection.ID,
+			"multiplier":    effects.CollectionExpBoostMultiplier,
+			"expires_at":    expiresAt,
+		},
+		Events: []effects.EffectEvent{
+			{
+				Type:      "fandomfever_used",
+				Timestamp: time.Now(),
+				Data: map[string]interface{}{
+					"user_id":       params.UserID,
+					"collection_id": collection.ID,
+				},
+			},
+		},
+	}, nil
+}
+
+// GetCooldown returns the cooldown duration
+func (h *FandomFeverHandler) GetCooldown(ctx context.Context, userID string) (time.Duration, error) {
+	return h.GetMetadata().Cooldown, nil
+}
+
+// ConsumeUse decrements the use count
+func (h *FandomFeverHandler) ConsumeUse(ctx context.Context, userID string) error {
+	return nil
+}
+
+// GetRemainingUses returns remaining uses
+func (h *FandomFeverHandler) GetRemainingUses(ctx context.Context, userID string) (int, error) {
+	return h.GetMetadata().MaxUses, nil
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/economy/effects"
+	"github.com/uptrace/bun"
+)
+
+type stubEffectRepo struct {
+	repositories.EffectRepository
+}
+
+func TestFandomFeverMetadataMatchesStaticItem(t *testing.T) {
+	handler := NewFandomFeverHandler(&effects.EffectDependencies{
+		EffectRepo: &stubEffectRepo{},
+		Database:   (*bun.DB)(nil),
+	})
+	metadata := handler.GetMetadata()
+	item := effects.GetEffectItemByID(metadata.ID)
+	if item == nil {
+		t.Fatalf("no static item for %s", metadata.ID)
+	}
+
+	// Like the other active effects, cooldown comes from the item in hours and uses from its duration
+	if want := time.Duration(item.Cooldown) * time.Hour; metadata.Cooldown != want {
+		t.Errorf("cooldown = %s, want %s", metadata.Cooldown, want)
+	}
+	if metadata.MaxUses != item.Duration {
+		t.Errorf("max uses = %d, want %d", metadata.MaxUses, item.Duration)
+	}
+	if metadata.Type != effects.EffectTypeActive {
+		t.Errorf("type = %s, want active", metadata.Type)
+	}
+
+	cooldown, err := handler.GetCooldown(context.Background(), "u1")
+	if err != nil || cooldown != metadata.Cooldown {
+		t.Errorf("GetCooldown = %s, %v", cooldown, err)
+	}
+}
+
+func TestFandomFeverNeedsCollection(t *testing.T) {
+	handler := NewFandomFeverHandler(&effects.EffectDependencies{
+		EffectRepo: &stubEffectRepo{},
+		Database:   (*bun.DB)(nil),
+	})
+
+	result, err := handler.Execute(context.Background(), effects.EffectParams{UserID: "u1"})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if result.Success || result.Consumed {
+		t.Errorf("result = %+v, want an unconsumed failure", result)
+	}
+}
@@ -55,6 +55,8 @@ func NewTohrugiftHandler(deps *effects.EffectDependencies) *TohrugiftHandler {
 		Animated:    false,
 		Tags:        []string{"passive", "claim", "first_daily"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackMultiplicative,
+		Modifies:    []string{"claim_3star_chance"},
 	}
 
 	return &TohrugiftHandler{
@@ -150,6 +152,8 @@ func NewCakedayHandler(deps *effects.EffectDependencies) *CakedayHandler {
 		Animated:    true,
 		Tags:        []string{"passive", "daily", "snowflakes"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackAdditive,
+		Modifies:    []string{"daily_reward"},
 	}
 
 	return &CakedayHandler{
@@ -250,6 +254,8 @@ func NewHolygrailHandler(deps *effects.EffectDependencies) *HolygrailHandler {
 		Animated:    true,
 		Tags:        []string{"passive", "vials", "liquefy"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackAdditive,
+		Modifies:    []string{"vial_reward"},
 	}
 
 	return &HolygrailHandler{
@@ -345,6 +351,8 @@ func NewSkyfriendHandler(deps *effects.EffectDependencies) *SkyfriendHandler {
 		Animated:    false,
 		Tags:        []string{"passive", "auction", "snowflakes"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackExclusive,
+		Modifies:    []string{"auction_win_bonus"},
 	}
 
 	return &SkyfriendHandler{
@@ -427,6 +435,8 @@ func NewCherryblossHandler(deps *effects.EffectDependencies) *CherryblossHandler
 		Animated:    true,
 		Tags:        []string{"passive", "forge", "discount"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackExclusive,
+		Modifies:    []string{"forge_cost"},
 	}
 
 	return &CherryblossHandler{
@@ -508,6 +518,8 @@ func NewRulerjeanneHandler(deps *effects.EffectDependencies) *RulerjeanneHandler
 		Animated:    true,
 		Tags:        []string{"passive", "daily", "cooldown"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackExclusive,
+		Modifies:    []string{"daily_cooldown"},
 	}
 
 	return &RulerjeanneHandler{
@@ -587,6 +599,8 @@ func NewSpellcardHandler(deps *effects.EffectDependencies) *SpellcardHandler {
 		Animated:    true,
 		Tags:        []string{"passive", "cooldown", "effects"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackExclusive,
+		Modifies:    []string{"effect_cooldown_reduction"},
 	}
 
 	return &SpellcardHandler{
@@ -659,6 +673,8 @@ func NewWalpurgisnightHandler(deps *effects.EffectDependencies) *WalpurgisnightH
 		Animated:    true,
 		Tags:        []string{"passive", "daily", "multiple_draws"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackAdditive,
+		Modifies:    []string{"daily_draws"},
 	}
 
 	return &WalpurgisnightHandler{
@@ -728,6 +744,8 @@ func NewLambhyejooHandler(deps *effects.EffectDependencies) *LambhyejooHandler {
 		Animated:    false,
 		Tags:        []string{"passive", "auction", "sales"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackExclusive,
+		Modifies:    []string{"auction_sale_bonus"},
 	}
 
 	return &LambhyejooHandler{
@@ -852,6 +870,8 @@ func NewYouthyouthHandler(deps *effects.EffectDependencies) *YouthyouthHandler {
 		Animated:    false,
 		Tags:        []string{"passive", "work", "rewards"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackAdditive,
+		Modifies:    []string{"work_reward"},
 	}
 
 	return &YouthyouthHandler{
@@ -978,6 +998,8 @@ func NewKisslaterHandler(deps *effects.EffectDependencies) *KisslaterHandler {
 		Animated:    false,
 		Tags:        []string{"passive", "levelup", "xp"},
 		Version:     "1.0.0",
+		Stacking:    effects.StackAdditive,
+		Modifies:    []string{"levelup_xp"},
 	}
 
 	return &KisslaterHandler{
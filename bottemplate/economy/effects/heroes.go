@@ -0,0 +1,134 @@
+package effects
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// Hero slot tuning. Each card placed in a hero slot adds HeroWorkBonusPerStar to
+// /work rewards for every star it has.
+const (
+	HeroBaseSlots        = 2
+	HeroPremiumSlots     = 1
+	HeroChangeCooldown   = 24 * time.Hour
+	HeroWorkBonusPerStar = 0.02
+)
+
+// HeroCooldownError is returned when a hero slot was changed too recently
+type HeroCooldownError struct {
+	Slot    int
+	ReadyAt time.Time
+}
+
+func (e *HeroCooldownError) Error() string {
+	return fmt.Sprintf("hero slot %d can be changed again <t:%d:R>", e.Slot+1, e.ReadyAt.Unix())
+}
+
+// HeroSlotCount returns how many hero slots the user can fill at now
+func HeroSlotCount(user *models.User, now time.Time) int {
+	if user.PremiumActive(now) {
+		return HeroBaseSlots + HeroPremiumSlots
+	}
+	return HeroBaseSlots
+}
+
+// HeroCardID returns the card in a hero slot, or 0 when the slot is empty
+func HeroCardID(user *models.User, slot int) int64 {
+	if slot < 0 || slot >= len(user.HeroSlots) {
+		return 0
+	}
+	id, err := strconv.ParseInt(user.HeroSlots[slot], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// HeroSlotReadyAt returns when a hero slot can next be changed, zero if it never was
+func HeroSlotReadyAt(user *models.User, slot int) time.Time {
+	if slot < 0 || slot >= len(user.HeroCooldown) {
+		return time.Time{}
+	}
+	changed, err := time.Parse(time.RFC3339, user.HeroCooldown[slot])
+	if err != nil {
+		return time.Time{}
+	}
+	return changed.Add(HeroChangeCooldown)
+}
+
+// ActiveHeroCardIDs returns the cards in the hero slots the user can currently use.
+// Slots above the user's slot count, such as premium slots after premium ran out, are skipped.
+func ActiveHeroCardIDs(user *models.User, now time.Time) []int64 {
+	var ids []int64
+	for slot := 0; slot < HeroSlotCount(user, now); slot++ {
+		if id := HeroCardID(user, slot); id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// AssignHero puts a card in one of the user's hero slots (0-based) and starts that
+// slot's cooldown. Ownership of the card is checked by the caller.
+func AssignHero(user *models.User, slot int, cardID int64, now time.Time) error {
+	slots := HeroSlotCount(user, now)
+	if slot < 0 || slot >= slots {
+		return fmt.Errorf("hero slot %d is not available, you have %d hero slots", slot+1, slots)
+	}
+	for i := 0; i < slots; i++ {
+		if HeroCardID(user, i) == cardID {
+			return fmt.Errorf("this card is already your hero in slot %d", i+1)
+		}
+	}
+	if readyAt := HeroSlotReadyAt(user, slot); readyAt.After(now) {
+		return &HeroCooldownError{Slot: slot, ReadyAt: readyAt}
+	}
+
+	for len(user.HeroSlots) < slots {
+		user.HeroSlots = append(user.HeroSlots, "")
+	}
+	for len(user.HeroCooldown) < slots {
+		user.HeroCooldown = append(user.HeroCooldown, "")
+	}
+	id := strconv.FormatInt(cardID, 10)
+	user.HeroSlots[slot] = id
+	user.HeroCooldown[slot] = now.UTC().Format(time.RFC3339)
+	user.Hero = id
+	user.HeroChanged = now
+	return nil
+}
+
+// HeroWorkBonus returns the fraction /work rewards are raised by the user's heroes.
+// Hero cards the user no longer owns give no bonus.
+func (gi *GameIntegrator) HeroWorkBonus(ctx context.Context, userID string) float64 {
+	m := gi.effectManager
+	user, err := m.userRepo.GetByDiscordID(ctx, userID)
+	if err != nil {
+		slog.Warn("Failed to get user for hero bonus", slog.String("user_id", userID), slog.Any("error", err))
+		return 0
+	}
+	ids := ActiveHeroCardIDs(user, time.Now())
+	if len(ids) == 0 {
+		return 0
+	}
+	cards, err := m.cardRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		slog.Warn("Failed to get hero cards", slog.String("user_id", userID), slog.Any("error", err))
+		return 0
+	}
+
+	var bonus float64
+	for _, card := range cards {
+		userCard, err := m.userCardRepo.GetByUserIDAndCardID(ctx, userID, card.ID)
+		if err != nil || userCard == nil || userCard.Amount <= 0 {
+			continue
+		}
+		bonus += float64(card.Level) * HeroWorkBonusPerStar
+	}
+	return bonus
+}
@@ -0,0 +1,86 @@
+package effects
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestAssignHeroSlots(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	user := &models.User{DiscordID: "u1"}
+
+	if err := AssignHero(user, 0, 11, now); err != nil {
+		t.Fatalf("AssignHero slot 1: %v", err)
+	}
+	if err := AssignHero(user, 1, 22, now); err != nil {
+		t.Fatalf("AssignHero slot 2: %v", err)
+	}
+	if !reflect.DeepEqual(user.HeroSlots, []string{"11", "22"}) || user.Hero != "22" || !user.HeroChanged.Equal(now) {
+		t.Errorf("heroes = %v, hero %s changed %v", user.HeroSlots, user.Hero, user.HeroChanged)
+	}
+
+	// The premium slot needs active premium
+	if err := AssignHero(user, 2, 33, now); err == nil {
+		t.Error("third slot assigned without premium")
+	}
+	if err := AssignHero(user, 1, 11, now.Add(48*time.Hour)); err == nil {
+		t.Error("same card assigned to two slots")
+	}
+
+	user.Premium = true
+	if got := HeroSlotCount(user, now); got != HeroBaseSlots+HeroPremiumSlots {
+		t.Fatalf("premium slots = %d", got)
+	}
+	if err := AssignHero(user, 2, 33, now); err != nil {
+		t.Fatalf("AssignHero premium slot: %v", err)
+	}
+	if got := ActiveHeroCardIDs(user, now); !reflect.DeepEqual(got, []int64{11, 22, 33}) {
+		t.Errorf("active heroes = %v", got)
+	}
+
+	// Once premium runs out the premium slot stops counting
+	user.PremiumExpires = now.Add(time.Hour)
+	if got := ActiveHeroCardIDs(user, now.Add(2*time.Hour)); !reflect.DeepEqual(got, []int64{11, 22}) {
+		t.Errorf("heroes after premium expired = %v", got)
+	}
+}
+
+func TestAssignHeroCooldown(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	user := &models.User{DiscordID: "u1"}
+	if err := AssignHero(user, 0, 11, now); err != nil {
+		t.Fatalf("AssignHero: %v", err)
+	}
+
+	err := AssignHero(user, 0, 22, now.Add(time.Hour))
+	var cooldown *HeroCooldownError
+	if !errors.As(err, &cooldown) || cooldown.Slot != 0 || !cooldown.ReadyAt.Equal(now.Add(HeroChangeCooldown)) {
+		t.Fatalf("change within the cooldown = %v, want a HeroCooldownError", err)
+	}
+	// Other slots have their own cooldown
+	if err := AssignHero(user, 1, 22, now.Add(time.Hour)); err != nil {
+		t.Errorf("other slot: %v", err)
+	}
+	if err := AssignHero(user, 0, 33, now.Add(HeroChangeCooldown)); err != nil {
+		t.Errorf("change after the cooldown: %v", err)
+	}
+	if got := HeroCardID(user, 0); got != 33 {
+		t.Errorf("slot 1 = %d, want 33", got)
+	}
+}
+
+func TestHeroCardIDMalformed(t *testing.T) {
+	user := &models.User{HeroSlots: []string{"", "abc", "7"}, HeroCooldown: []string{"yesterday"}}
+	for slot, want := range []int64{0, 0, 7, 0} {
+		if got := HeroCardID(user, slot); got != want {
+			t.Errorf("HeroCardID(%d) = %d, want %d", slot, got, want)
+		}
+	}
+	if got := HeroSlotReadyAt(user, 0); !got.IsZero() {
+		t.Errorf("unparseable cooldown ready at %v, want now", got)
+	}
+}
@@ -140,10 +140,8 @@ func (gi *GameIntegrator) ApplyLiquefyBonus(ctx context.Context, userID string,
 	return modifiedVials
 }
 
-// GetDailyCooldown returns modified daily cooldown in minutes based on passive effects.
-func (gi *GameIntegrator) GetDailyCooldown(ctx context.Context, userID string) int {
-	baseMinutes := 20 * 60
-
+// GetDailyCooldown returns the configured daily cooldown in minutes modified by passive effects.
+func (gi *GameIntegrator) GetDailyCooldown(ctx context.Context, userID string, baseMinutes int) int {
 	result, err := gi.applyPassiveEffect(ctx, userID, "daily_cooldown", baseMinutes)
 	if err != nil {
 		slog.Warn("Failed to apply passive effects to daily cooldown",
@@ -164,18 +162,21 @@ func (gi *GameIntegrator) GetDailyCooldown(ctx context.Context, userID string) i
 	return modifiedMinutes
 }
 
-// ApplyWorkReward applies work reward percentage bonuses to a single reward amount.
+// ApplyWorkReward applies work reward percentage bonuses and the user's hero bonus
+// to a single reward amount.
 func (gi *GameIntegrator) ApplyWorkReward(ctx context.Context, userID string, baseReward int64) int64 {
+	reward := baseReward
 	result, err := gi.applyPassiveEffect(ctx, userID, "work_reward", int(baseReward))
 	if err != nil {
 		slog.Warn("Failed to apply work reward effects", slog.String("user_id", userID), slog.Any("error", err))
-		return baseReward
+	} else if modifiedReward, ok := result.(int); ok {
+		reward = int64(modifiedReward)
 	}
-	modifiedReward, ok := result.(int)
-	if !ok {
-		return baseReward
+
+	if bonus := gi.HeroWorkBonus(ctx, userID); bonus > 0 {
+		reward = int64(float64(reward) * (1 + bonus))
 	}
-	return int64(modifiedReward)
+	return reward
 }
 
 // ApplyLevelupXP applies level-up XP percentage bonuses.
@@ -192,6 +193,36 @@ func (gi *GameIntegrator) ApplyLevelupXP(ctx context.Context, userID string, bas
 	return int64(modifiedXP)
 }
 
+// Collection EXP boost tuning, started by the Fandom Fever active effect
+const (
+	CollectionExpBoostMultiplier = 1.5
+	CollectionExpBoostDuration   = 6 * time.Hour
+)
+
+// ApplyCardLevelupXP applies level-up XP bonuses for a card, including any collection
+// EXP boost the user has running for the card's collection.
+func (gi *GameIntegrator) ApplyCardLevelupXP(ctx context.Context, userID string, collectionID string, baseXP int64) int64 {
+	xp := gi.ApplyLevelupXP(ctx, userID, baseXP)
+
+	boost, err := gi.effectManager.repo.GetActiveCollectionExpBoost(ctx, userID)
+	if err != nil {
+		slog.Warn("Failed to get collection EXP boost", slog.String("user_id", userID), slog.Any("error", err))
+		return xp
+	}
+	if boost == nil || boost.CollectionID != collectionID {
+		return xp
+	}
+	return applyExpMultiplier(xp, boost.Multiplier)
+}
+
+// applyExpMultiplier scales XP by a boost multiplier, never reducing it
+func applyExpMultiplier(xp int64, multiplier float64) int64 {
+	if multiplier <= 1 {
+		return xp
+	}
+	return int64(float64(xp) * multiplier)
+}
+
 // ApplyAuctionWinCashback returns the cashback amount for winning an auction.
 func (gi *GameIntegrator) ApplyAuctionWinCashback(ctx context.Context, userID string, auctionPrice int64) int64 {
 	userEffect, err := gi.effectManager.repo.GetUserEffect(ctx, userID, "wolfofhyejoo")
@@ -451,6 +482,12 @@ func (gi *GameIntegrator) ActivatePassiveEffect(ctx context.Context, userID stri
 	return gi.effectManager.ActivatePassiveEffect(ctx, userID, effectID)
 }
 
+// CheckStackingConflict returns an *EffectConflictError if the effect can't be activated
+// alongside the user's live effects
+func (gi *GameIntegrator) CheckStackingConflict(ctx context.Context, userID string, effectID string) error {
+	return gi.effectManager.CheckStackingConflict(ctx, userID, effectID)
+}
+
 // DeactivatePassiveEffect allows users to deactivate a passive effect
 func (gi *GameIntegrator) DeactivatePassiveEffect(ctx context.Context, userID string, effectID string) error {
 	return gi.effectManager.DeactivatePassiveEffect(ctx, userID, effectID)
@@ -490,15 +527,24 @@ func (gi *GameIntegrator) Shutdown(ctx context.Context) error {
 	return gi.effectManager.Shutdown(ctx)
 }
 
-// applyPassiveEffect applies passive effects to a game action (internal method)
-func (gi *GameIntegrator) applyPassiveEffect(ctx context.Context, userID string, action string, baseValue interface{}) (interface{}, error) {
+// appliedPassiveEffect records a passive effect that changed a value during resolution
+type appliedPassiveEffect struct {
+	effectID string
+	handler  PassiveEffectHandler
+}
+
+// resolvePassiveEffects applies the user's live passive effects to a game action, honoring
+// each effect's stacking policy. Additive effects compute their bonus from the base value
+// and the bonuses are summed; multiplicative effects are then chained on the result; only
+// the first exclusive effect that changes the value is applied.
+func (gi *GameIntegrator) resolvePassiveEffects(ctx context.Context, userID string, action string, baseValue interface{}) (interface{}, []appliedPassiveEffect, error) {
 	// Get all active passive effects for user
 	activeEffects, err := gi.effectManager.GetActiveUserEffects(ctx, userID)
 	if err != nil {
-		return baseValue, err
+		return baseValue, nil, err
 	}
 
-	result := baseValue
+	var additive, sequential []appliedPassiveEffect
 	for _, userEffect := range activeEffects {
 		// Get passive handler
 		handler, err := gi.effectManager.GetRegistry().GetPassiveEffect(userEffect.EffectID)
@@ -511,27 +557,88 @@ func (gi *GameIntegrator) applyPassiveEffect(ctx context.Context, userID string,
 			continue
 		}
 
-		// Apply the effect
-		result, err = handler.ApplyEffect(ctx, userID, action, result)
+		entry := appliedPassiveEffect{effectID: userEffect.EffectID, handler: handler}
+		if handler.GetMetadata().StackingPolicy() == StackAdditive {
+			additive = append(additive, entry)
+		} else {
+			sequential = append(sequential, entry)
+		}
+	}
+
+	result := baseValue
+	var applied []appliedPassiveEffect
+
+	for _, entry := range additive {
+		modified, err := entry.handler.ApplyEffect(ctx, userID, action, baseValue)
 		if err != nil {
-			slog.Warn("Failed to apply passive effect",
-				slog.String("effect_id", userEffect.EffectID),
+			gi.logPassiveEffectFailure(entry.effectID, userID, action, err)
+			continue
+		}
+
+		combined, ok := combineAdditive(result, baseValue, modified)
+		if !ok {
+			// Non-numeric values can't be summed, so chain them instead
+			combined, err = entry.handler.ApplyEffect(ctx, userID, action, result)
+			if err != nil {
+				gi.logPassiveEffectFailure(entry.effectID, userID, action, err)
+				continue
+			}
+		}
+
+		if valueChanged(result, combined) {
+			applied = append(applied, entry)
+		}
+		result = combined
+	}
+
+	exclusiveApplied := false
+	for _, entry := range sequential {
+		exclusive := entry.handler.GetMetadata().StackingPolicy() == StackExclusive
+		if exclusive && exclusiveApplied {
+			slog.Debug("Skipping exclusive passive effect, another exclusive effect already applied",
+				slog.String("effect_id", entry.effectID),
 				slog.String("user_id", userID),
-				slog.String("action", action),
-				slog.Any("error", err))
+				slog.String("action", action))
 			continue
 		}
+
+		modified, err := entry.handler.ApplyEffect(ctx, userID, action, result)
+		if err != nil {
+			gi.logPassiveEffectFailure(entry.effectID, userID, action, err)
+			continue
+		}
+
+		if valueChanged(result, modified) {
+			applied = append(applied, entry)
+			if exclusive {
+				exclusiveApplied = true
+			}
+		}
+		result = modified
 	}
 
-	return result, nil
+	return result, applied, nil
+}
+
+func (gi *GameIntegrator) logPassiveEffectFailure(effectID, userID, action string, err error) {
+	slog.Warn("Failed to apply passive effect",
+		slog.String("effect_id", effectID),
+		slog.String("user_id", userID),
+		slog.String("action", action),
+		slog.Any("error", err))
+}
+
+// applyPassiveEffect applies passive effects to a game action (internal method)
+func (gi *GameIntegrator) applyPassiveEffect(ctx context.Context, userID string, action string, baseValue interface{}) (interface{}, error) {
+	result, _, err := gi.resolvePassiveEffects(ctx, userID, action, baseValue)
+	return result, err
 }
 
 // applyPassiveEffectWithFeedback applies passive effects and returns detailed feedback
 func (gi *GameIntegrator) applyPassiveEffectWithFeedback(ctx context.Context, userID string, action string, baseValue interface{}) *EffectApplicationResult {
 	result := NewEffectApplicationResult(baseValue)
 
-	// Get all active passive effects for user
-	activeEffects, err := gi.effectManager.GetActiveUserEffects(ctx, userID)
+	modifiedValue, applied, err := gi.resolvePassiveEffects(ctx, userID, action, baseValue)
 	if err != nil {
 		slog.Warn("Failed to get active user effects",
 			slog.String("user_id", userID),
@@ -540,58 +647,28 @@ func (gi *GameIntegrator) applyPassiveEffectWithFeedback(ctx context.Context, us
 		return result
 	}
 
-	currentValue := baseValue
-	for _, userEffect := range activeEffects {
-		// Get passive handler
-		handler, err := gi.effectManager.GetRegistry().GetPassiveEffect(userEffect.EffectID)
-		if err != nil {
-			continue // Skip if not a passive effect
-		}
-
-		// Check if effect is still active
-		if active, err := handler.IsActive(ctx, userID); err != nil || !active {
-			continue
-		}
-
+	for _, entry := range applied {
 		// Get effect metadata for feedback
-		metadata := handler.GetMetadata()
-		staticEffect := GetEffectItemByID(userEffect.EffectID)
+		metadata := entry.handler.GetMetadata()
 		effectName := metadata.Name
-		if staticEffect != nil {
+		if staticEffect := GetEffectItemByID(entry.effectID); staticEffect != nil {
 			effectName = staticEffect.Name
 		}
 
-		// Store original value before applying effect
-		originalValue := currentValue
+		// Get modifier for feedback
+		modifier, _ := entry.handler.GetModifier(ctx, userID, action)
 
-		// Apply the effect
-		currentValue, err = handler.ApplyEffect(ctx, userID, action, currentValue)
-		if err != nil {
-			slog.Warn("Failed to apply passive effect",
-				slog.String("effect_id", userEffect.EffectID),
-				slog.String("user_id", userID),
-				slog.String("action", action),
-				slog.Any("error", err))
-			continue
-		}
-
-		// Check if effect actually modified the value
-		if currentValue != originalValue {
-			// Get modifier for feedback
-			modifier, _ := handler.GetModifier(ctx, userID, action)
-
-			// Add to applied effects list
-			result.AddAppliedEffect(
-				userEffect.EffectID,
-				effectName,
-				metadata.Description,
-				action,
-				"🛡️", // Passive effect emoji
-				modifier,
-			)
-		}
+		// Add to applied effects list
+		result.AddAppliedEffect(
+			entry.effectID,
+			effectName,
+			metadata.Description,
+			action,
+			"🛡️", // Passive effect emoji
+			modifier,
+		)
 	}
 
-	result.SetModifiedValue(currentValue)
+	result.SetModifiedValue(modifiedValue)
 	return result
 }
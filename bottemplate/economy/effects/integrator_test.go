@@ -0,0 +1,107 @@
+package effects
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func (r *fakeEffectRepo) GetActiveCollectionExpBoost(ctx context.Context, userID string) (*models.CollectionExpBoost, error) {
+	boost, ok := r.boosts[userID]
+	if !ok || !boost.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+	return boost, nil
+}
+
+func TestApplyCardLevelupXPCollectionBoost(t *testing.T) {
+	now := time.Now()
+	repo := &fakeEffectRepo{boosts: map[string]*models.CollectionExpBoost{
+		"fan": {UserID: "fan", CollectionID: "twice", Multiplier: CollectionExpBoostMultiplier, ExpiresAt: now.Add(CollectionExpBoostDuration)},
+		"old": {UserID: "old", CollectionID: "twice", Multiplier: CollectionExpBoostMultiplier, ExpiresAt: now.Add(-time.Minute)},
+	}}
+	gi := NewGameIntegrator(newTestManager(t, repo))
+
+	tests := []struct {
+		name         string
+		userID       string
+		collectionID string
+		want         int64
+	}{
+		{"boosted collection", "fan", "twice", 150},
+		{"other collection", "fan", "blackpink", 100},
+		{"expired boost", "old", "twice", 100},
+		{"no boost", "nobody", "twice", 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gi.ApplyCardLevelupXP(context.Background(), tt.userID, tt.collectionID, 100); got != tt.want {
+				t.Errorf("ApplyCardLevelupXP = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyCardLevelupXPStacksWithPassiveEffects(t *testing.T) {
+	repo := &fakeEffectRepo{
+		effects: activeEffects("fan", "kisslater"),
+		boosts: map[string]*models.CollectionExpBoost{
+			"fan": {UserID: "fan", CollectionID: "twice", Multiplier: 1.5, ExpiresAt: time.Now().Add(time.Hour)},
+		},
+	}
+	kisslater := &fakeIntPassive{fakePassive: newFakePassive("kisslater", StackAdditive, "levelup_xp", func(v int64) int64 { return v + v/4 })}
+	gi := NewGameIntegrator(newTestManager(t, repo, kisslater))
+
+	// The passive bonus applies first, then the collection boost multiplies the result
+	if got := gi.ApplyCardLevelupXP(context.Background(), "fan", "twice", 100); got != 187 {
+		t.Errorf("ApplyCardLevelupXP = %d, want 187", got)
+	}
+}
+
+func TestApplyExpMultiplier(t *testing.T) {
+	tests := []struct {
+		xp         int64
+		multiplier float64
+		want       int64
+	}{
+		{100, 1.5, 150},
+		{33, 1.5, 49},
+		{100, 1, 100},
+		{100, 0.5, 100}, // a boost never reduces XP
+		{0, 1.5, 0},
+	}
+	for _, tt := range tests {
+		if got := applyExpMultiplier(tt.xp, tt.multiplier); got != tt.want {
+			t.Errorf("applyExpMultiplier(%d, %v) = %d, want %d", tt.xp, tt.multiplier, got, tt.want)
+		}
+	}
+}
+
+func TestFandomFeverItemMatchesBoost(t *testing.T) {
+	item := GetEffectItemByID("fandomfever")
+	if item == nil {
+		t.Fatal("fandomfever is not a static effect item")
+	}
+	if item.Passive {
+		t.Error("fandomfever must be an active effect")
+	}
+	if time.Duration(item.Duration)*time.Hour != CollectionExpBoostDuration {
+		t.Errorf("item duration %dh doesn't match the %s boost", item.Duration, CollectionExpBoostDuration)
+	}
+}
+
+// fakeIntPassive adapts fakePassive to the int values level-up XP is resolved with
+type fakeIntPassive struct {
+	*fakePassive
+}
+
+func (h *fakeIntPassive) ApplyEffect(ctx context.Context, userID string, action string, baseValue interface{}) (interface{}, error) {
+	value, ok := baseValue.(int)
+	if !ok || !h.GetMetadata().ModifiesStat(action) {
+		return baseValue, nil
+	}
+	return int(h.apply(int64(value))), nil
+}
@@ -2,6 +2,7 @@ package effects
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,6 +13,9 @@ import (
 	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
 )
 
+// ErrOutOfStock is returned when buying a limited shop item that is sold out
+var ErrOutOfStock = errors.New("item is out of stock")
+
 // Manager is the modernized effect manager using the registry system
 type Manager struct {
 	registry       *EffectRegistry
@@ -22,6 +26,7 @@ type Manager struct {
 	collectionRepo repositories.CollectionRepository
 	cardRepo       repositories.CardRepository
 	db             *database.DB
+	ledgerRepo     repositories.CurrencyLedgerRepository
 
 	// Metrics and monitoring
 	metricsEnabled bool
@@ -104,6 +109,44 @@ func (m *Manager) PurchaseEffect(ctx context.Context, userID string, effectID st
 		return fmt.Errorf("user not found: %w", err)
 	}
 
+	// Reserve a unit of limited stock before charging; it's returned if the purchase fails
+	if staticItem.Stock > 0 {
+		reserved, err := m.repo.DecrementShopStock(ctx, effectID)
+		if err != nil {
+			return fmt.Errorf("failed to reserve stock: %w", err)
+		}
+		if !reserved {
+			return ErrOutOfStock
+		}
+	}
+
+	if err := m.chargeAndStoreRecipe(ctx, user, staticItem); err != nil {
+		if staticItem.Stock > 0 {
+			if refundErr := m.repo.IncrementShopStock(ctx, effectID); refundErr != nil {
+				slog.Error("Failed to return reserved shop stock",
+					slog.String("effect_id", effectID),
+					slog.Any("error", refundErr))
+			}
+		}
+		return err
+	}
+
+	slog.Info("Effect purchased",
+		slog.String("user_id", userID),
+		slog.String("effect_id", effectID),
+		slog.Int64("price", staticItem.Price),
+		slog.String("currency", staticItem.Currency))
+
+	return nil
+}
+
+// SetCurrencyLedger records shop purchases paid with balance in the currency ledger
+func (m *Manager) SetCurrencyLedger(ledgerRepo repositories.CurrencyLedgerRepository) {
+	m.ledgerRepo = ledgerRepo
+}
+
+// chargeAndStoreRecipe deducts an item's price from the user and stores its recipe
+func (m *Manager) chargeAndStoreRecipe(ctx context.Context, user *models.User, staticItem *EffectItemData) error {
 	// Check and deduct currency
 	switch staticItem.Currency {
 	case models.CurrencyTomato:
@@ -125,20 +168,64 @@ func (m *Manager) PurchaseEffect(ctx context.Context, userID string, effectID st
 		return fmt.Errorf("failed to update user balance: %w", err)
 	}
 
+	if staticItem.Currency == models.CurrencyTomato && m.ledgerRepo != nil {
+		if err := m.ledgerRepo.Record(ctx, nil, &models.CurrencyLedgerEntry{
+			UserID:    user.DiscordID,
+			Amount:    -staticItem.Price,
+			Reason:    models.LedgerReasonShop,
+			Reference: staticItem.ID,
+		}); err != nil {
+			slog.Error("Failed to record shop purchase in currency ledger",
+				slog.String("user_id", user.DiscordID),
+				slog.String("effect_id", staticItem.ID),
+				slog.Any("error", err))
+		}
+	}
+
 	// Store recipe for crafting
-	if err := m.StoreRecipeForUser(ctx, userID, effectID); err != nil {
+	if err := m.StoreRecipeForUser(ctx, user.DiscordID, staticItem.ID); err != nil {
 		return fmt.Errorf("failed to store recipe: %w", err)
 	}
 
-	slog.Info("Effect purchased",
-		slog.String("user_id", userID),
-		slog.String("effect_id", effectID),
-		slog.Int64("price", staticItem.Price),
-		slog.String("currency", staticItem.Currency))
+	return nil
+}
 
+// InitializeShopStock creates stock rows for limited shop items, keeping existing stock levels
+func (m *Manager) InitializeShopStock(ctx context.Context) error {
+	for _, item := range GetLimitedEffectItems() {
+		stock := &models.ShopStock{
+			ItemID:        item.ID,
+			Stock:         item.Stock,
+			MaxStock:      item.Stock,
+			RestockHours:  item.RestockHours,
+			NextRestockAt: time.Now().Add(time.Duration(item.RestockHours) * time.Hour),
+		}
+		if err := m.repo.EnsureShopStock(ctx, stock); err != nil {
+			return fmt.Errorf("failed to initialize stock for %s: %w", item.ID, err)
+		}
+	}
 	return nil
 }
 
+// GetShopStock returns the stock of limited shop items keyed by item ID
+func (m *Manager) GetShopStock(ctx context.Context) (map[string]*models.ShopStock, error) {
+	stock, err := m.repo.GetShopStock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byItem := make(map[string]*models.ShopStock, len(stock))
+	for _, s := range stock {
+		byItem[s.ItemID] = s
+	}
+	return byItem, nil
+}
+
+// RestockShop refills limited shop items that are due for a restock
+func (m *Manager) RestockShop(ctx context.Context) (int, error) {
+	return m.repo.RestockDueShopItems(ctx)
+}
+
 // CraftEffect crafts an effect from recipe cards
 func (m *Manager) CraftEffect(ctx context.Context, userID string, effectID string) error {
 	// Get effect handler
@@ -162,6 +249,13 @@ func (m *Manager) CraftEffect(ctx context.Context, userID string, effectID strin
 		return fmt.Errorf("no recipe found for this effect. Purchase it from the shop first")
 	}
 
+	// Passive effects activate when crafted, so they must not conflict with live ones
+	if staticItem.Passive {
+		if err := m.CheckStackingConflict(ctx, userID, effectID); err != nil {
+			return err
+		}
+	}
+
 	// Verify user has all required cards
 	if err := m.verifyUserHasRecipeCards(ctx, userID, recipe.CardIDs); err != nil {
 		return fmt.Errorf("missing required cards: %w", err)
@@ -309,6 +403,10 @@ func (m *Manager) ActivatePassiveEffect(ctx context.Context, userID string, effe
 		return fmt.Errorf("cannot activate non-passive effect")
 	}
 
+	if err := m.CheckStackingConflict(ctx, userID, effectID); err != nil {
+		return err
+	}
+
 	// Set activation timestamp and expiration
 	now := time.Now()
 	expiry := now.Add(time.Duration(staticEffect.Duration*24) * time.Hour) // Duration is in days for passive effects
@@ -321,6 +419,42 @@ func (m *Manager) ActivatePassiveEffect(ctx context.Context, userID string, effe
 	return m.repo.UpdateUserEffect(ctx, userEffect)
 }
 
+// CheckStackingConflict returns an *EffectConflictError if the effect can't be live alongside
+// the user's currently active effects under their stacking policies
+func (m *Manager) CheckStackingConflict(ctx context.Context, userID string, effectID string) error {
+	handler, err := m.registry.GetEffect(effectID)
+	if err != nil {
+		return fmt.Errorf("effect not found: %w", err)
+	}
+
+	candidate := handler.GetMetadata()
+	if len(candidate.Modifies) == 0 {
+		return nil
+	}
+
+	activeEffects, err := m.repo.GetActiveUserEffects(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get active effects: %w", err)
+	}
+
+	var live []EffectMetadata
+	for _, userEffect := range activeEffects {
+		if userEffect.IsRecipe || userEffect.EffectID == effectID {
+			continue
+		}
+		other, err := m.registry.GetEffect(userEffect.EffectID)
+		if err != nil {
+			continue
+		}
+		live = append(live, other.GetMetadata())
+	}
+
+	if conflict := FindStackingConflict(candidate, live); conflict != nil {
+		return conflict
+	}
+	return nil
+}
+
 // DeactivatePassiveEffect deactivates a passive effect for a user
 func (m *Manager) DeactivatePassiveEffect(ctx context.Context, userID string, effectID string) error {
 	userEffect, err := m.repo.GetUserEffect(ctx, userID, effectID)
@@ -480,6 +614,31 @@ func (m *Manager) GetAllEffectStats() map[string]*EffectExecutionStats {
 	return m.registry.GetAllExecutionStats()
 }
 
+// SystemStats summarises the effect system for health reporting
+type SystemStats struct {
+	RegisteredPassive int
+	RegisteredActive  int
+	ActiveUserEffects int
+	ExpiringSoon      int
+}
+
+// Stats reports how many effects are registered and how many user effects are
+// currently running, counting those that expire within expiringWithin
+func (m *Manager) Stats(ctx context.Context, expiringWithin time.Duration) (*SystemStats, error) {
+	stats := &SystemStats{
+		RegisteredPassive: len(m.registry.ListPassiveEffects()),
+		RegisteredActive:  len(m.registry.ListActiveEffects()),
+	}
+
+	active, expiring, err := m.repo.CountActiveUserEffects(ctx, expiringWithin)
+	if err != nil {
+		return stats, fmt.Errorf("failed to count active user effects: %w", err)
+	}
+	stats.ActiveUserEffects = active
+	stats.ExpiringSoon = expiring
+	return stats, nil
+}
+
 // AddEventHandler adds an event handler for effect events
 func (m *Manager) AddEventHandler(handler func(EffectEvent)) {
 	m.eventHandlers = append(m.eventHandlers, handler)
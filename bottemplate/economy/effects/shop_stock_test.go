@@ -0,0 +1,127 @@
+package effects
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// stockEffectRepo keeps shop stock in memory and hands out recipe cards
+type stockEffectRepo struct {
+	fakeEffectRepo
+	mu      sync.Mutex
+	stock   map[string]int
+	recipes int
+}
+
+func (r *stockEffectRepo) DecrementShopStock(ctx context.Context, itemID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stock[itemID] <= 0 {
+		return false, nil
+	}
+	r.stock[itemID]--
+	return true, nil
+}
+
+func (r *stockEffectRepo) IncrementShopStock(ctx context.Context, itemID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stock[itemID]++
+	return nil
+}
+
+func (r *stockEffectRepo) GetRandomCardForRecipe(ctx context.Context, userID string, stars int64) (*models.Card, error) {
+	return &models.Card{ID: stars, Level: int(stars)}, nil
+}
+
+func (r *stockEffectRepo) StoreUserRecipe(ctx context.Context, userID string, itemID string, cardIDs []int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recipes++
+	return nil
+}
+
+// balanceUserRepo returns a fresh copy of each user on every lookup
+type balanceUserRepo struct {
+	repositories.UserRepository
+	balances map[string]int64
+}
+
+func (r *balanceUserRepo) GetByDiscordID(ctx context.Context, discordID string) (*models.User, error) {
+	balance, ok := r.balances[discordID]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &models.User{DiscordID: discordID, Balance: balance}, nil
+}
+
+func (r *balanceUserRepo) Update(ctx context.Context, user *models.User) error {
+	return nil
+}
+
+func newShopManager(t *testing.T, stock int, balances map[string]int64) (*Manager, *stockEffectRepo) {
+	t.Helper()
+	repo := &stockEffectRepo{stock: map[string]int{"judgeday": stock}}
+	m := newTestManager(t, repo, newFakePassive("judgeday", StackExclusive, "none", func(v int64) int64 { return v }))
+	m.userRepo = &balanceUserRepo{balances: balances}
+	return m, repo
+}
+
+func TestPurchaseEffectLastUnitConcurrent(t *testing.T) {
+	const buyers = 20
+	balances := make(map[string]int64, buyers)
+	for i := 0; i < buyers; i++ {
+		balances[string(rune('a'+i))] = 100000
+	}
+	m, repo := newShopManager(t, 1, balances)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, buyers)
+	for userID := range balances {
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+			errs <- m.PurchaseEffect(context.Background(), userID, "judgeday")
+		}(userID)
+	}
+	wg.Wait()
+	close(errs)
+
+	var sold, soldOut int
+	for err := range errs {
+		switch {
+		case err == nil:
+			sold++
+		case errors.Is(err, ErrOutOfStock):
+			soldOut++
+		default:
+			t.Errorf("PurchaseEffect: %v", err)
+		}
+	}
+	if sold != 1 || soldOut != buyers-1 {
+		t.Errorf("sold %d and refused %d, want 1 and %d", sold, soldOut, buyers-1)
+	}
+	if repo.recipes != 1 || repo.stock["judgeday"] != 0 {
+		t.Errorf("stored %d recipes with %d left in stock, want 1 and 0", repo.recipes, repo.stock["judgeday"])
+	}
+}
+
+func TestPurchaseEffectReturnsStockWhenChargeFails(t *testing.T) {
+	m, repo := newShopManager(t, 1, map[string]int64{"poor": 10})
+
+	err := m.PurchaseEffect(context.Background(), "poor", "judgeday")
+	if err == nil || errors.Is(err, ErrOutOfStock) {
+		t.Fatalf("PurchaseEffect err = %v, want an insufficient balance error", err)
+	}
+	if repo.stock["judgeday"] != 1 {
+		t.Errorf("stock = %d, want the reserved unit back", repo.stock["judgeday"])
+	}
+	if repo.recipes != 0 {
+		t.Errorf("stored %d recipes, want none", repo.recipes)
+	}
+}
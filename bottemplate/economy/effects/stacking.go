@@ -0,0 +1,95 @@
+package effects
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// EffectConflictError reports that an effect can't be activated while a conflicting one is live
+type EffectConflictError struct {
+	EffectID        string
+	EffectName      string
+	ConflictingID   string
+	ConflictingName string
+	Stat            string
+}
+
+func (e *EffectConflictError) Error() string {
+	return fmt.Sprintf("effect %s conflicts with active %s (both modify %s)", e.EffectName, e.ConflictingName, e.Stat)
+}
+
+// StackingPolicy returns the effective stacking policy, defaulting to multiplicative
+func (m EffectMetadata) StackingPolicy() StackPolicy {
+	if m.Stacking == "" {
+		return StackMultiplicative
+	}
+	return m.Stacking
+}
+
+// ModifiesStat reports whether the effect modifies the given stat
+func (m EffectMetadata) ModifiesStat(stat string) bool {
+	for _, s := range m.Modifies {
+		if s == stat {
+			return true
+		}
+	}
+	return false
+}
+
+// FindStackingConflict returns a conflict if the candidate effect can't be live alongside
+// one of the given live effects. Two effects conflict when they modify the same stat and
+// at least one of them is exclusive.
+func FindStackingConflict(candidate EffectMetadata, live []EffectMetadata) *EffectConflictError {
+	for _, other := range live {
+		if other.ID == candidate.ID {
+			continue
+		}
+		if candidate.StackingPolicy() != StackExclusive && other.StackingPolicy() != StackExclusive {
+			continue
+		}
+
+		for _, stat := range candidate.Modifies {
+			if other.ModifiesStat(stat) {
+				return &EffectConflictError{
+					EffectID:        candidate.ID,
+					EffectName:      candidate.Name,
+					ConflictingID:   other.ID,
+					ConflictingName: other.Name,
+					Stat:            stat,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// combineAdditive adds the bonus an additive effect produced (modified - base) to total.
+// It returns false for non-numeric values, which can't be combined additively.
+func combineAdditive(total, base, modified interface{}) (interface{}, bool) {
+	switch b := base.(type) {
+	case int:
+		t, ok1 := total.(int)
+		m, ok2 := modified.(int)
+		if ok1 && ok2 {
+			return t + (m - b), true
+		}
+	case int64:
+		t, ok1 := total.(int64)
+		m, ok2 := modified.(int64)
+		if ok1 && ok2 {
+			return t + (m - b), true
+		}
+	case float64:
+		t, ok1 := total.(float64)
+		m, ok2 := modified.(float64)
+		if ok1 && ok2 {
+			return t + (m - b), true
+		}
+	}
+	return total, false
+}
+
+// valueChanged reports whether an effect changed a value; map values are compared deeply
+func valueChanged(before, after interface{}) bool {
+	return !reflect.DeepEqual(before, after)
+}
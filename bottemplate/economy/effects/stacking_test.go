@@ -0,0 +1,249 @@
+package effects
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// fakeEffectRepo serves a fixed set of user effects; methods it doesn't override panic
+type fakeEffectRepo struct {
+	repositories.EffectRepository
+	effects []*models.UserEffect
+	boosts  map[string]*models.CollectionExpBoost
+}
+
+func (r *fakeEffectRepo) GetActiveUserEffects(ctx context.Context, userID string) ([]*models.UserEffect, error) {
+	var active []*models.UserEffect
+	for _, effect := range r.effects {
+		if effect.UserID == userID && effect.Active {
+			active = append(active, effect)
+		}
+	}
+	return active, nil
+}
+
+// fakePassive modifies int64 values of one stat with apply
+type fakePassive struct {
+	*BaseEffectHandler
+	apply func(int64) int64
+}
+
+func newFakePassive(id string, policy StackPolicy, stat string, apply func(int64) int64) *fakePassive {
+	return &fakePassive{
+		BaseEffectHandler: NewBaseEffectHandler(EffectMetadata{
+			ID:       id,
+			Name:     id,
+			Type:     EffectTypePassive,
+			Stacking: policy,
+			Modifies: []string{stat},
+		}, nil),
+		apply: apply,
+	}
+}
+
+func (h *fakePassive) Execute(ctx context.Context, params EffectParams) (*EffectResult, error) {
+	return &EffectResult{Success: true}, nil
+}
+
+func (h *fakePassive) ApplyEffect(ctx context.Context, userID string, action string, baseValue interface{}) (interface{}, error) {
+	value, ok := baseValue.(int64)
+	if !ok || !h.GetMetadata().ModifiesStat(action) {
+		return baseValue, nil
+	}
+	return h.apply(value), nil
+}
+
+func (h *fakePassive) IsActive(ctx context.Context, userID string) (bool, error) {
+	return true, nil
+}
+
+func (h *fakePassive) GetModifier(ctx context.Context, userID string, action string) (float64, error) {
+	return 0, nil
+}
+
+// newTestManager returns a manager backed by repo with the given handlers registered
+func newTestManager(t *testing.T, repo repositories.EffectRepository, handlers ...EffectHandler) *Manager {
+	t.Helper()
+	m := &Manager{registry: NewEffectRegistry(nil), repo: repo}
+	for _, handler := range handlers {
+		if err := m.RegisterEffect(handler); err != nil {
+			t.Fatalf("register %s: %v", handler.GetMetadata().ID, err)
+		}
+	}
+	return m
+}
+
+func activeEffects(userID string, ids ...string) []*models.UserEffect {
+	effects := make([]*models.UserEffect, 0, len(ids))
+	for _, id := range ids {
+		effects = append(effects, &models.UserEffect{UserID: userID, EffectID: id, Active: true})
+	}
+	return effects
+}
+
+func TestResolvePassiveEffectsStacking(t *testing.T) {
+	const stat = "work_reward"
+	handlers := []EffectHandler{
+		newFakePassive("flat", StackAdditive, stat, func(v int64) int64 { return v + 10 }),
+		newFakePassive("fifth", StackAdditive, stat, func(v int64) int64 { return v + v/5 }),
+		newFakePassive("double", StackExclusive, stat, func(v int64) int64 { return v * 2 }),
+		newFakePassive("jackpot", StackExclusive, stat, func(v int64) int64 { return v + 1000 }),
+	}
+
+	tests := []struct {
+		name        string
+		active      []string
+		want        int64
+		wantApplied []string
+	}{
+		{
+			name:        "additive bonuses come from the base value",
+			active:      []string{"flat", "fifth"},
+			want:        130, // 100 + 10 + 20, not (100+10)*1.2
+			wantApplied: []string{"flat", "fifth"},
+		},
+		{
+			name:        "only the first exclusive effect applies",
+			active:      []string{"double", "jackpot"},
+			want:        200,
+			wantApplied: []string{"double"},
+		},
+		{
+			name:        "additive effects are summed before the exclusive one",
+			active:      []string{"double", "flat", "jackpot", "fifth"},
+			want:        260, // (100 + 10 + 20) * 2
+			wantApplied: []string{"flat", "fifth", "double"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeEffectRepo{effects: activeEffects("u1", tt.active...)}
+			gi := NewGameIntegrator(newTestManager(t, repo, handlers...))
+
+			got, applied, err := gi.resolvePassiveEffects(context.Background(), "u1", stat, int64(100))
+			if err != nil {
+				t.Fatalf("resolvePassiveEffects: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("value = %v, want %d", got, tt.want)
+			}
+
+			var ids []string
+			for _, entry := range applied {
+				ids = append(ids, entry.effectID)
+			}
+			if len(ids) != len(tt.wantApplied) {
+				t.Fatalf("applied = %v, want %v", ids, tt.wantApplied)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantApplied[i] {
+					t.Errorf("applied = %v, want %v", ids, tt.wantApplied)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestResolvePassiveEffectsOtherStat(t *testing.T) {
+	repo := &fakeEffectRepo{effects: activeEffects("u1", "flat")}
+	gi := NewGameIntegrator(newTestManager(t, repo,
+		newFakePassive("flat", StackAdditive, "work_reward", func(v int64) int64 { return v + 10 })))
+
+	got, applied, err := gi.resolvePassiveEffects(context.Background(), "u1", "daily_reward", int64(100))
+	if err != nil {
+		t.Fatalf("resolvePassiveEffects: %v", err)
+	}
+	if got != int64(100) || len(applied) != 0 {
+		t.Errorf("value = %v with %d applied, want 100 untouched", got, len(applied))
+	}
+}
+
+func TestFindStackingConflict(t *testing.T) {
+	meta := func(id string, policy StackPolicy, stats ...string) EffectMetadata {
+		return EffectMetadata{ID: id, Name: id, Stacking: policy, Modifies: stats}
+	}
+
+	tests := []struct {
+		name      string
+		candidate EffectMetadata
+		live      []EffectMetadata
+		wantWith  string
+	}{
+		{
+			name:      "two additive effects stack",
+			candidate: meta("a", StackAdditive, "work_reward"),
+			live:      []EffectMetadata{meta("b", StackAdditive, "work_reward")},
+		},
+		{
+			name:      "two multiplicative effects stack",
+			candidate: meta("a", "", "work_reward"),
+			live:      []EffectMetadata{meta("b", StackMultiplicative, "work_reward")},
+		},
+		{
+			name:      "exclusive candidate conflicts with additive",
+			candidate: meta("a", StackExclusive, "work_reward"),
+			live:      []EffectMetadata{meta("b", StackAdditive, "work_reward")},
+			wantWith:  "b",
+		},
+		{
+			name:      "additive candidate conflicts with live exclusive",
+			candidate: meta("a", StackAdditive, "daily_reward", "work_reward"),
+			live:      []EffectMetadata{meta("b", StackExclusive, "work_reward")},
+			wantWith:  "b",
+		},
+		{
+			name:      "two exclusive effects on different stats",
+			candidate: meta("a", StackExclusive, "forge_cost"),
+			live:      []EffectMetadata{meta("b", StackExclusive, "daily_cooldown")},
+		},
+		{
+			name:      "an effect never conflicts with itself",
+			candidate: meta("a", StackExclusive, "forge_cost"),
+			live:      []EffectMetadata{meta("a", StackExclusive, "forge_cost")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflict := FindStackingConflict(tt.candidate, tt.live)
+			switch {
+			case tt.wantWith == "" && conflict != nil:
+				t.Errorf("unexpected conflict: %v", conflict)
+			case tt.wantWith != "" && conflict == nil:
+				t.Errorf("no conflict, want one with %s", tt.wantWith)
+			case tt.wantWith != "" && conflict.ConflictingID != tt.wantWith:
+				t.Errorf("conflict with %s, want %s", conflict.ConflictingID, tt.wantWith)
+			}
+		})
+	}
+}
+
+func TestCheckStackingConflict(t *testing.T) {
+	repo := &fakeEffectRepo{effects: []*models.UserEffect{
+		{UserID: "u1", EffectID: "double", Active: true},
+		// Recipes aren't live, so they never block activation
+		{UserID: "u1", EffectID: "jackpot", Active: true, IsRecipe: true},
+	}}
+	m := newTestManager(t, repo,
+		newFakePassive("double", StackExclusive, "work_reward", func(v int64) int64 { return v * 2 }),
+		newFakePassive("jackpot", StackExclusive, "work_reward", func(v int64) int64 { return v + 1000 }),
+		newFakePassive("flat", StackAdditive, "daily_reward", func(v int64) int64 { return v + 10 }),
+	)
+
+	var conflict *EffectConflictError
+	if err := m.CheckStackingConflict(context.Background(), "u1", "jackpot"); !errors.As(err, &conflict) || conflict.ConflictingID != "double" {
+		t.Errorf("jackpot: err = %v, want a conflict with double", err)
+	}
+	if err := m.CheckStackingConflict(context.Background(), "u1", "flat"); err != nil {
+		t.Errorf("flat: %v", err)
+	}
+	if err := m.CheckStackingConflict(context.Background(), "u2", "jackpot"); err != nil {
+		t.Errorf("jackpot for another user: %v", err)
+	}
+}
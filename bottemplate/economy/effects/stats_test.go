@@ -0,0 +1,80 @@
+package effects
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingEffectRepo reports fixed user effect counts and the window it was asked about
+type countingEffectRepo struct {
+	fakeEffectRepo
+	active, expiring int
+	err              error
+	window           time.Duration
+}
+
+func (r *countingEffectRepo) CountActiveUserEffects(ctx context.Context, expiringWithin time.Duration) (int, int, error) {
+	r.window = expiringWithin
+	return r.active, r.expiring, r.err
+}
+
+// fakeActive is an active effect that does nothing
+type fakeActive struct {
+	*BaseEffectHandler
+}
+
+func newFakeActive(id string) *fakeActive {
+	return &fakeActive{NewBaseEffectHandler(EffectMetadata{ID: id, Name: id, Type: EffectTypeActive}, nil)}
+}
+
+func (h *fakeActive) Execute(ctx context.Context, params EffectParams) (*EffectResult, error) {
+	return &EffectResult{Success: true}, nil
+}
+
+func (h *fakeActive) GetCooldown(ctx context.Context, userID string) (time.Duration, error) {
+	return 0, nil
+}
+
+func (h *fakeActive) ConsumeUse(ctx context.Context, userID string) error {
+	return nil
+}
+
+func (h *fakeActive) GetRemainingUses(ctx context.Context, userID string) (int, error) {
+	return 1, nil
+}
+
+func TestManagerStats(t *testing.T) {
+	repo := &countingEffectRepo{active: 12, expiring: 3}
+	m := newTestManager(t, repo,
+		newFakePassive("flat", StackAdditive, "work_reward", func(v int64) int64 { return v + 10 }),
+		newFakePassive("double", StackExclusive, "daily_reward", func(v int64) int64 { return v * 2 }),
+		newFakeActive("rulerjeanne"),
+	)
+
+	stats, err := m.Stats(context.Background(), time.Hour)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	want := SystemStats{RegisteredPassive: 2, RegisteredActive: 1, ActiveUserEffects: 12, ExpiringSoon: 3}
+	if *stats != want {
+		t.Errorf("stats = %+v, want %+v", *stats, want)
+	}
+	if repo.window != time.Hour {
+		t.Errorf("expiring window = %s, want 1h", repo.window)
+	}
+}
+
+func TestManagerStatsRepositoryError(t *testing.T) {
+	m := newTestManager(t, &countingEffectRepo{err: errors.New("db down")}, newFakeActive("rulerjeanne"))
+
+	stats, err := m.Stats(context.Background(), time.Hour)
+	if err == nil {
+		t.Fatal("repository error was swallowed")
+	}
+	// Registration counts don't need the database, so they are still reported
+	if stats == nil || stats.RegisteredActive != 1 || stats.ActiveUserEffects != 0 {
+		t.Errorf("stats = %+v, want the registered counts only", stats)
+	}
+}
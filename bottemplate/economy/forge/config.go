@@ -0,0 +1,155 @@
+package forge
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/disgoorg/bot-template/bottemplate/economy/utils"
+	botutils "github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// RecipeOutput is one possible result level of a recipe. Weights are relative
+// to the other outputs of the same recipe.
+type RecipeOutput struct {
+	Level  int     `toml:"level"`
+	Weight float64 `toml:"weight"`
+}
+
+// Recipe maps two input cards of InputLevel to a weighted choice of output levels
+type Recipe struct {
+	InputLevel int            `toml:"input_level"`
+	Outputs    []RecipeOutput `toml:"outputs"`
+}
+
+// Config holds the forge balancing rules. Zero values use DefaultConfig.
+type Config struct {
+	CostMultiplier      float64  `toml:"cost_multiplier"` // share of the average input price charged
+	MinCost             int64    `toml:"min_cost"`
+	ExcludedCollections []string `toml:"excluded_collections"` // never used as inputs or produced, see Apply
+	Recipes             []Recipe `toml:"recipes"`
+}
+
+// DefaultConfig is the forge as it behaved before it was configurable: any two
+// cards of the same level below 5 forge into a random card of that level
+func DefaultConfig() Config {
+	recipes := make([]Recipe, 0, 4)
+	for level := 1; level <= 4; level++ {
+		recipes = append(recipes, Recipe{
+			InputLevel: level,
+			Outputs:    []RecipeOutput{{Level: level, Weight: 1}},
+		})
+	}
+
+	return Config{
+		CostMultiplier:      utils.ForgeCostMultiplier,
+		MinCost:             utils.MinForgeCost,
+		ExcludedCollections: botutils.DefaultForgeExcludedCollections(),
+		Recipes:             recipes,
+	}
+}
+
+// WithDefaults fills unset fields from DefaultConfig. An explicitly empty
+// excluded_collections list is kept, so every collection can be allowed.
+func (c Config) WithDefaults() Config {
+	d := DefaultConfig()
+	if c.CostMultiplier == 0 {
+		c.CostMultiplier = d.CostMultiplier
+	}
+	if c.MinCost == 0 {
+		c.MinCost = d.MinCost
+	}
+	if c.ExcludedCollections == nil {
+		c.ExcludedCollections = d.ExcludedCollections
+	}
+	if len(c.Recipes) == 0 {
+		c.Recipes = d.Recipes
+	}
+	return c
+}
+
+// Validate reports the first inconsistency in the config
+func (c Config) Validate() error {
+	if c.CostMultiplier < 0 {
+		return fmt.Errorf("cost_multiplier must not be negative, got %v", c.CostMultiplier)
+	}
+	if c.MinCost < 0 {
+		return fmt.Errorf("min_cost must not be negative, got %d", c.MinCost)
+	}
+	for _, colID := range c.ExcludedCollections {
+		if strings.TrimSpace(colID) == "" {
+			return fmt.Errorf("excluded_collections must not contain empty collection IDs")
+		}
+	}
+
+	seen := make(map[int]bool, len(c.Recipes))
+	for _, recipe := range c.Recipes {
+		if recipe.InputLevel < 1 || recipe.InputLevel > 4 {
+			return fmt.Errorf("recipe input_level must be between 1 and 4, got %d", recipe.InputLevel)
+		}
+		if seen[recipe.InputLevel] {
+			return fmt.Errorf("duplicate recipe for input_level %d", recipe.InputLevel)
+		}
+		seen[recipe.InputLevel] = true
+
+		if len(recipe.Outputs) == 0 {
+			return fmt.Errorf("recipe for input_level %d has no outputs", recipe.InputLevel)
+		}
+		for _, output := range recipe.Outputs {
+			if output.Level < 1 || output.Level > 5 {
+				return fmt.Errorf("recipe for input_level %d has output level %d, must be between 1 and 5", recipe.InputLevel, output.Level)
+			}
+			if output.Weight <= 0 {
+				return fmt.Errorf("recipe for input_level %d has output level %d with non-positive weight %v", recipe.InputLevel, output.Level, output.Weight)
+			}
+		}
+	}
+	return nil
+}
+
+// Recipe returns the recipe for two input cards of the given level
+func (c Config) Recipe(inputLevel int) (Recipe, bool) {
+	for _, recipe := range c.Recipes {
+		if recipe.InputLevel == inputLevel {
+			return recipe, true
+		}
+	}
+	return Recipe{}, false
+}
+
+// Apply installs the config's collection exclusions for every forge
+// eligibility check. Call it once at startup, before collections are cached.
+func (c Config) Apply() {
+	botutils.SetForgeExcludedCollections(c.ExcludedCollections)
+}
+
+// pickOutputLevel draws an output level by weight
+func (r Recipe) pickOutputLevel() int {
+	var total float64
+	for _, output := range r.Outputs {
+		total += output.Weight
+	}
+
+	roll := rand.Float64() * total
+	for _, output := range r.Outputs {
+		if roll < output.Weight {
+			return output.Level
+		}
+		roll -= output.Weight
+	}
+	return r.Outputs[len(r.Outputs)-1].Level
+}
+
+// Chances returns each output level's probability in the range 0-1
+func (r Recipe) Chances() map[int]float64 {
+	var total float64
+	for _, output := range r.Outputs {
+		total += output.Weight
+	}
+
+	chances := make(map[int]float64, len(r.Outputs))
+	for _, output := range r.Outputs {
+		chances[output.Level] += output.Weight / total
+	}
+	return chances
+}
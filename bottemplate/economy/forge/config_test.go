@@ -0,0 +1,103 @@
+package forge
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+func TestConfigCustomRecipes(t *testing.T) {
+	var cfg Config
+	err := toml.Unmarshal([]byte(`
+cost_multiplier = 0.2
+excluded_collections = []
+
+[[recipes]]
+input_level = 3
+outputs = [{ level = 3, weight = 9 }, { level = 4, weight = 1 }]
+`), &cfg)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	cfg = cfg.WithDefaults()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	if cfg.CostMultiplier != 0.2 || cfg.MinCost != DefaultConfig().MinCost {
+		t.Errorf("costs = %v and %d, want 0.2 and the default minimum", cfg.CostMultiplier, cfg.MinCost)
+	}
+	// An explicitly empty list allows every collection
+	if cfg.ExcludedCollections == nil || len(cfg.ExcludedCollections) != 0 {
+		t.Errorf("excluded = %v, want an empty list", cfg.ExcludedCollections)
+	}
+
+	// Once any recipe is set, unlisted levels can't forge
+	if _, ok := cfg.Recipe(1); ok {
+		t.Error("level 1 has a recipe")
+	}
+	recipe, ok := cfg.Recipe(3)
+	if !ok {
+		t.Fatal("level 3 has no recipe")
+	}
+	chances := recipe.Chances()
+	if math.Abs(chances[3]-0.9) > 1e-9 || math.Abs(chances[4]-0.1) > 1e-9 {
+		t.Errorf("chances = %v, want 90%% level 3 and 10%% level 4", chances)
+	}
+	for i := 0; i < 200; i++ {
+		if level := recipe.pickOutputLevel(); level != 3 && level != 4 {
+			t.Fatalf("picked level %d, want 3 or 4", level)
+		}
+	}
+}
+
+func TestConfigDefaults(t *testing.T) {
+	cfg := Config{}.WithDefaults()
+	if !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Errorf("empty config = %+v, want the defaults", cfg)
+	}
+	for level := 1; level <= 4; level++ {
+		recipe, ok := cfg.Recipe(level)
+		if !ok || !reflect.DeepEqual(recipe.Chances(), map[int]float64{level: 1}) {
+			t.Errorf("level %d recipe = %+v, %v, want the same level out", level, recipe, ok)
+		}
+	}
+	if _, ok := cfg.Recipe(5); ok {
+		t.Error("level 5 cards can be forged by default")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	recipe := func(input int, outputs ...RecipeOutput) Recipe {
+		return Recipe{InputLevel: input, Outputs: outputs}
+	}
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"negative multiplier", Config{CostMultiplier: -1}, "cost_multiplier"},
+		{"negative min cost", Config{MinCost: -5}, "min_cost"},
+		{"blank exclusion", Config{ExcludedCollections: []string{" "}}, "empty collection"},
+		{"input level 5", Config{Recipes: []Recipe{recipe(5, RecipeOutput{Level: 5, Weight: 1})}}, "input_level must be"},
+		{"duplicate input", Config{Recipes: []Recipe{recipe(2, RecipeOutput{Level: 2, Weight: 1}), recipe(2, RecipeOutput{Level: 3, Weight: 1})}}, "duplicate"},
+		{"no outputs", Config{Recipes: []Recipe{recipe(1)}}, "no outputs"},
+		{"output level 6", Config{Recipes: []Recipe{recipe(4, RecipeOutput{Level: 6, Weight: 1})}}, "output level 6"},
+		{"zero weight", Config{Recipes: []Recipe{recipe(1, RecipeOutput{Level: 2, Weight: 0})}}, "non-positive weight"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate = %v, want an error about %q", err, tt.want)
+			}
+		})
+	}
+
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("default config invalid: %v", err)
+	}
+}
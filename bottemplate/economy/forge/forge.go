@@ -23,6 +23,7 @@ type ForgeManager struct {
 	priceCalc *economy.PriceCalculator
 	mu        sync.RWMutex
 	txManager *utils.EconomicTransactionManager
+	cfg       Config
 }
 
 func NewForgeManager(db *database.DB, priceCalc *economy.PriceCalculator) *ForgeManager {
@@ -30,9 +31,21 @@ func NewForgeManager(db *database.DB, priceCalc *economy.PriceCalculator) *Forge
 		db:        db,
 		priceCalc: priceCalc,
 		txManager: utils.NewEconomicTransactionManager(db.BunDB()),
+		cfg:       DefaultConfig(),
 	}
 }
 
+// WithConfig replaces the default forge rules; unset fields keep their defaults
+func (fm *ForgeManager) WithConfig(cfg Config) *ForgeManager {
+	fm.cfg = cfg.WithDefaults()
+	return fm
+}
+
+// Recipe returns the recipe used for two input cards of the given level
+func (fm *ForgeManager) Recipe(inputLevel int) (Recipe, bool) {
+	return fm.cfg.Recipe(inputLevel)
+}
+
 func (fm *ForgeManager) CalculateForgeCost(ctx context.Context, card1, card2 *models.Card) (int64, error) {
 	price1, err := fm.priceCalc.GetLatestPrice(ctx, card1.ID)
 	if err != nil {
@@ -45,7 +58,7 @@ func (fm *ForgeManager) CalculateForgeCost(ctx context.Context, card1, card2 *mo
 	}
 
 	avgPrice := (price1 + price2) / 2
-	forgeCost := int64(math.Max(float64(avgPrice)*utils.ForgeCostMultiplier, float64(utils.MinForgeCost)))
+	forgeCost := int64(math.Max(float64(avgPrice)*fm.cfg.CostMultiplier, float64(fm.cfg.MinCost)))
 
 	return forgeCost, nil
 }
@@ -98,6 +111,10 @@ func (fm *ForgeManager) ForgeCardsWithEffects(ctx context.Context, userID int64,
 		if card1.Level != card2.Level {
 			return fmt.Errorf("cards must be of the same level to forge")
 		}
+		recipe, ok := fm.cfg.Recipe(card1.Level)
+		if !ok {
+			return fmt.Errorf("level %d cards cannot be forged", card1.Level)
+		}
 
 		userCard1, err := getForgeUserCardState(ctx, tx, userIDStr, card1ID)
 		if err != nil {
@@ -146,11 +163,11 @@ func (fm *ForgeManager) ForgeCardsWithEffects(ctx context.Context, userID int64,
 			return fmt.Errorf("failed to remove card 2: %w", err)
 		}
 
-		// Get new card of same level with proper filtering
+		// Get new card of the recipe's output level with proper filtering
 		var possibleCards []*models.Card
 		query := tx.NewSelect().
 			Model((*models.Card)(nil)).
-			Where("level = ?", card1.Level).
+			Where("level = ?", recipe.pickOutputLevel()).
 			Where("id != ? AND id != ?", card1.ID, card2.ID) // Exclude input cards
 
 		err = query.Scan(ctx, &possibleCards)
@@ -148,7 +148,7 @@ func (m *EconomyMonitor) collectCurrentStats(ctx context.Context) (*models.Econo
 
 	stats.WealthyPlayerCount = wealthyCount
 	stats.PoorPlayerCount = poorCount
-	stats.GiniCoefficient = calculateGiniCoefficient(balances)
+	stats.GiniCoefficient = repositories.GiniCoefficient(balances)
 	stats.WealthConcentration = float64(wealthyCount) / math.Max(1.0, float64(len(users)))
 
 	// Count active users
@@ -221,40 +221,6 @@ func (m *EconomyMonitor) applyWealthTax(ctx context.Context) error {
 	return nil
 }
 
-// Helper function to calculate Gini coefficient using optimized O(n log n) algorithm
-func calculateGiniCoefficient(balances []int64) float64 {
-	if len(balances) == 0 {
-		return 0
-	}
-
-	// Convert to float64 slice for calculations
-	sortedBalances := make([]float64, len(balances))
-	var totalSum float64
-
-	for i, balance := range balances {
-		sortedBalances[i] = float64(balance)
-		totalSum += float64(balance)
-	}
-
-	if totalSum == 0 {
-		return 0
-	}
-
-	// Sort balances for O(n log n) algorithm
-	sort.Float64s(sortedBalances)
-
-	n := float64(len(sortedBalances))
-	var numerator float64
-
-	// Optimized calculation: sum of (2*i + 1 - n) * y_i for sorted values
-	for i, balance := range sortedBalances {
-		numerator += (2*float64(i) + 1 - n) * balance
-	}
-
-	// Gini coefficient formula for sorted data
-	return numerator / (n * totalSum)
-}
-
 // RunMonitoringCycle executes a single monitoring cycle and returns any error
 func (m *EconomyMonitor) RunMonitoringCycle(ctx context.Context) error {
 	slog.Info("Starting economy monitoring cycle")
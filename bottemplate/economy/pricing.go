@@ -153,6 +153,11 @@ func (pc *PriceCalculator) GetLastPrice(ctx context.Context, cardID int64) (int6
 	return pc.store.GetLastPrice(ctx, cardID)
 }
 
+// GetLastPrices retrieves the most recent stored price for each card in one query
+func (pc *PriceCalculator) GetLastPrices(ctx context.Context, cardIDs []int64) (map[int64]int64, error) {
+	return pc.store.GetLastPrices(ctx, cardIDs)
+}
+
 // GetLatestPrice retrieves the latest price for a card
 func (pc *PriceCalculator) GetLatestPrice(ctx context.Context, cardID int64) (int64, error) {
 	return pc.store.GetLatestPrice(ctx, cardID, pc.calculator, pc.analyzer)
@@ -202,6 +207,11 @@ func (pc *PriceCalculator) GetActiveCards(ctx context.Context) ([]int64, error)
 	return pc.analyzer.GetActiveCards(ctx)
 }
 
+// SetOwnershipSource lets card stats use cached owner and copy counts
+func (pc *PriceCalculator) SetOwnershipSource(source pricing.OwnershipSource) {
+	pc.analyzer.SetOwnershipSource(source)
+}
+
 // GetCardStats retrieves comprehensive statistics for multiple cards
 func (pc *PriceCalculator) GetCardStats(ctx context.Context, cardIDs []int64) (map[int64]CardStats, error) {
 	return pc.analyzer.GetCardStats(ctx, cardIDs)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/disgoorg/bot-template/bottemplate/database"
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
 	"github.com/disgoorg/bot-template/bottemplate/economy/utils"
 	"github.com/uptrace/bun"
 	"golang.org/x/sync/errgroup"
@@ -29,11 +30,17 @@ type MarketStats struct {
 	PriceChangePercent float64 `bun:"pricechangepercent"`
 }
 
+// OwnershipSource supplies cached per-card owner and copy counts
+type OwnershipSource interface {
+	GetOwnershipStats(cardID int64) (repositories.CardOwnershipStats, bool)
+}
+
 // MarketAnalyzer handles market statistics and analysis
 type MarketAnalyzer struct {
 	db                  *database.DB
 	config              PricingConfig
 	inactivityThreshold time.Duration
+	ownership           OwnershipSource
 }
 
 // NewMarketAnalyzer creates a new market analyzer
@@ -45,6 +52,28 @@ func NewMarketAnalyzer(db *database.DB, config PricingConfig, inactivityThreshol
 	}
 }
 
+// SetOwnershipSource makes stats queries take unique owners and total copies from
+// the cache instead of counting them, whenever every card in a batch is cached
+func (ma *MarketAnalyzer) SetOwnershipSource(source OwnershipSource) {
+	ma.ownership = source
+}
+
+// cachedOwnership returns the cached stats for all cardIDs, or false if any is missing
+func (ma *MarketAnalyzer) cachedOwnership(cardIDs []int64) (map[int64]repositories.CardOwnershipStats, bool) {
+	if ma.ownership == nil {
+		return nil, false
+	}
+	cached := make(map[int64]repositories.CardOwnershipStats, len(cardIDs))
+	for _, id := range cardIDs {
+		s, ok := ma.ownership.GetOwnershipStats(id)
+		if !ok {
+			return nil, false
+		}
+		cached[id] = s
+	}
+	return cached, true
+}
+
 // GetActiveCards returns all cards that have active owners
 func (ma *MarketAnalyzer) GetActiveCards(ctx context.Context) ([]int64, error) {
 	var cardIDs []int64
@@ -176,12 +205,18 @@ func (ma *MarketAnalyzer) GetCardStats(ctx context.Context, cardIDs []int64) (ma
 
 // processStatsBatch processes statistics for a batch of cards
 func (ma *MarketAnalyzer) processStatsBatch(ctx context.Context, cardIDs []int64) (map[int64]CardStats, error) {
+	cached, fromCache := ma.cachedOwnership(cardIDs)
+
 	var stats []CardStats
-	err := ma.db.BunDB().NewSelect().
+	query := ma.db.BunDB().NewSelect().
 		TableExpr("cards c").
-		ColumnExpr("c.id as card_id").
-		ColumnExpr("COALESCE(COUNT(uc.id), 0) as total_copies").
-		ColumnExpr("COALESCE(COUNT(DISTINCT uc.user_id), 0) as unique_owners").
+		ColumnExpr("c.id as card_id")
+	if !fromCache {
+		query = query.
+			ColumnExpr("COALESCE(COUNT(uc.id), 0) as total_copies").
+			ColumnExpr("COALESCE(COUNT(DISTINCT uc.user_id), 0) as unique_owners")
+	}
+	err := query.
 		ColumnExpr(`COALESCE(COUNT(DISTINCT CASE 
 			WHEN u.last_daily > ? THEN uc.user_id 
 			ELSE NULL 
@@ -221,6 +256,10 @@ func (ma *MarketAnalyzer) processStatsBatch(ctx context.Context, cardIDs []int64
 	// Convert to map
 	statsMap := make(map[int64]CardStats, len(stats))
 	for _, stat := range stats {
+		if fromCache {
+			stat.TotalCopies = int(cached[stat.CardID].Copies)
+			stat.UniqueOwners = int(cached[stat.CardID].Owners)
+		}
 		statsMap[stat.CardID] = stat
 	}
 
@@ -0,0 +1,34 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+type fixedOwnership map[int64]repositories.CardOwnershipStats
+
+func (f fixedOwnership) GetOwnershipStats(cardID int64) (repositories.CardOwnershipStats, bool) {
+	s, ok := f[cardID]
+	return s, ok
+}
+
+func TestCachedOwnership(t *testing.T) {
+	ma := &MarketAnalyzer{}
+	if _, ok := ma.cachedOwnership([]int64{1}); ok {
+		t.Error("cache used without an ownership source")
+	}
+
+	ma.SetOwnershipSource(fixedOwnership{
+		1: {CardID: 1, Owners: 2, Copies: 5},
+		2: {CardID: 2},
+	})
+	cached, ok := ma.cachedOwnership([]int64{1, 2})
+	if !ok || cached[1].Copies != 5 || cached[2].Owners != 0 {
+		t.Errorf("cachedOwnership = %v, %t, want both cards", cached, ok)
+	}
+	// One uncached card sends the whole batch to the database
+	if _, ok := ma.cachedOwnership([]int64{1, 3}); ok {
+		t.Error("partial batch served from the cache")
+	}
+}
@@ -77,6 +77,34 @@ func (ps *PriceStore) GetLastPrice(ctx context.Context, cardID int64) (int64, er
 	return history.Price, nil
 }
 
+// GetLastPrices returns the most recent stored price of each card; cards without history are omitted
+func (ps *PriceStore) GetLastPrices(ctx context.Context, cardIDs []int64) (map[int64]int64, error) {
+	prices := make(map[int64]int64, len(cardIDs))
+	if len(cardIDs) == 0 {
+		return prices, nil
+	}
+
+	var rows []struct {
+		CardID int64 `bun:"card_id"`
+		Price  int64 `bun:"price"`
+	}
+	err := ps.db.BunDB().NewSelect().
+		Model((*models.CardMarketHistory)(nil)).
+		DistinctOn("card_id").
+		Column("card_id", "price").
+		Where("card_id IN (?)", bun.In(cardIDs)).
+		OrderExpr("card_id, timestamp DESC").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last prices: %w", err)
+	}
+
+	for _, row := range rows {
+		prices[row.CardID] = row.Price
+	}
+	return prices, nil
+}
+
 // GetLatestPrice retrieves the latest price for a card, calculating if none exists
 func (ps *PriceStore) GetLatestPrice(ctx context.Context, cardID int64, calculator *Calculator, analyzer *MarketAnalyzer) (int64, error) {
 	var history models.CardMarketHistory
@@ -0,0 +1,177 @@
+package economy
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// WorkStarTiers is the number of job rarities /work can roll, 1 to 5 stars
+const WorkStarTiers = 5
+
+// RewardRange rolls a payout in [Min, Max). Max at or below Min always pays Min.
+type RewardRange struct {
+	Min int64 `toml:"min"`
+	Max int64 `toml:"max"`
+}
+
+// Roll returns a random amount within the range
+func (r RewardRange) Roll() int64 {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return r.Min + rand.Int63n(r.Max-r.Min)
+}
+
+func (r RewardRange) isZero() bool {
+	return r.Min == 0 && r.Max == 0
+}
+
+// WorkPayout is what one /work answer pays out before card bonuses and effects
+type WorkPayout struct {
+	Flakes RewardRange `toml:"flakes"`
+	Vials  RewardRange `toml:"vials"`
+	XP     RewardRange `toml:"xp"`
+}
+
+func (p WorkPayout) withDefaults(d WorkPayout) WorkPayout {
+	if p.Flakes.isZero() {
+		p.Flakes = d.Flakes
+	}
+	if p.Vials.isZero() {
+		p.Vials = d.Vials
+	}
+	if p.XP.isZero() {
+		p.XP = d.XP
+	}
+	return p
+}
+
+func (p WorkPayout) validate(key string) error {
+	ranges := []struct {
+		name string
+		r    RewardRange
+	}{{"flakes", p.Flakes}, {"vials", p.Vials}, {"xp", p.XP}}
+	for _, entry := range ranges {
+		name, r := entry.name, entry.r
+		if r.Min < 0 {
+			return fmt.Errorf("%s.%s.min must not be negative, got %d", key, name, r.Min)
+		}
+		if r.Max != 0 && r.Max < r.Min {
+			return fmt.Errorf("%s.%s.max must not be below min, got %d < %d", key, name, r.Max, r.Min)
+		}
+	}
+	return nil
+}
+
+// DailyRewardConfig sets the /daily payout
+type DailyRewardConfig struct {
+	Amount          int64 `toml:"amount"`
+	CooldownMinutes int   `toml:"cooldown_minutes"` // before passive effects such as Ruler Jeanne
+}
+
+// WorkRewardConfig sets the /work payouts. Stars[0] pays 1-star jobs, Stars[4] 5-star jobs.
+type WorkRewardConfig struct {
+	CooldownSeconds int          `toml:"cooldown_seconds"`
+	Failed          WorkPayout   `toml:"failed"` // paid for a wrong answer regardless of rarity
+	Stars           []WorkPayout `toml:"stars"`
+}
+
+// Cooldown is the time a user has to wait between /work answers
+func (c WorkRewardConfig) Cooldown() time.Duration {
+	return time.Duration(c.CooldownSeconds) * time.Second
+}
+
+// Payout returns the payout for a job of the given star rarity
+func (c WorkRewardConfig) Payout(stars int, success bool) WorkPayout {
+	if !success {
+		return c.Failed
+	}
+	if stars < 1 || stars > len(c.Stars) {
+		return WorkPayout{}
+	}
+	return c.Stars[stars-1]
+}
+
+// RewardConfig holds the /daily and /work balancing values. Zero values use DefaultRewardConfig.
+type RewardConfig struct {
+	Daily DailyRewardConfig `toml:"daily"`
+	Work  WorkRewardConfig  `toml:"work"`
+}
+
+// DefaultRewardConfig is /daily and /work as they paid out before they were configurable
+func DefaultRewardConfig() RewardConfig {
+	return RewardConfig{
+		Daily: DailyRewardConfig{
+			Amount:          1000,
+			CooldownMinutes: 20 * 60,
+		},
+		Work: WorkRewardConfig{
+			CooldownSeconds: 10,
+			Failed: WorkPayout{
+				Flakes: RewardRange{Min: 5, Max: 15},
+				Vials:  RewardRange{Min: 2, Max: 7},
+				XP:     RewardRange{Min: 2, Max: 7},
+			},
+			Stars: []WorkPayout{
+				{Flakes: RewardRange{Min: 30, Max: 45}, Vials: RewardRange{Min: 15, Max: 22}, XP: RewardRange{Min: 10, Max: 15}},
+				{Flakes: RewardRange{Min: 60, Max: 90}, Vials: RewardRange{Min: 30, Max: 45}, XP: RewardRange{Min: 20, Max: 30}},
+				{Flakes: RewardRange{Min: 120, Max: 180}, Vials: RewardRange{Min: 60, Max: 90}, XP: RewardRange{Min: 35, Max: 52}},
+				{Flakes: RewardRange{Min: 250, Max: 375}, Vials: RewardRange{Min: 125, Max: 187}, XP: RewardRange{Min: 60, Max: 90}},
+				{Flakes: RewardRange{Min: 500, Max: 750}, Vials: RewardRange{Min: 250, Max: 375}, XP: RewardRange{Min: 100, Max: 150}},
+			},
+		},
+	}
+}
+
+// WithDefaults fills unset values from DefaultRewardConfig. Missing star tiers are
+// taken from the defaults, so a config may override only the first few.
+func (c RewardConfig) WithDefaults() RewardConfig {
+	d := DefaultRewardConfig()
+	if c.Daily.Amount == 0 {
+		c.Daily.Amount = d.Daily.Amount
+	}
+	if c.Daily.CooldownMinutes == 0 {
+		c.Daily.CooldownMinutes = d.Daily.CooldownMinutes
+	}
+	if c.Work.CooldownSeconds == 0 {
+		c.Work.CooldownSeconds = d.Work.CooldownSeconds
+	}
+	c.Work.Failed = c.Work.Failed.withDefaults(d.Work.Failed)
+
+	stars := make([]WorkPayout, WorkStarTiers)
+	for i := range stars {
+		if i < len(c.Work.Stars) {
+			stars[i] = c.Work.Stars[i].withDefaults(d.Work.Stars[i])
+		} else {
+			stars[i] = d.Work.Stars[i]
+		}
+	}
+	c.Work.Stars = stars
+	return c
+}
+
+// Validate reports the first inconsistency in the config
+func (c RewardConfig) Validate() error {
+	if c.Daily.Amount < 0 {
+		return fmt.Errorf("daily.amount must not be negative, got %d", c.Daily.Amount)
+	}
+	if c.Daily.CooldownMinutes < 0 {
+		return fmt.Errorf("daily.cooldown_minutes must not be negative, got %d", c.Daily.CooldownMinutes)
+	}
+	if c.Work.CooldownSeconds < 0 {
+		return fmt.Errorf("work.cooldown_seconds must not be negative, got %d", c.Work.CooldownSeconds)
+	}
+	if len(c.Work.Stars) > WorkStarTiers {
+		return fmt.Errorf("work.stars has %d tiers, at most %d are allowed", len(c.Work.Stars), WorkStarTiers)
+	}
+	if err := c.Work.Failed.validate("work.failed"); err != nil {
+		return err
+	}
+	for i, payout := range c.Work.Stars {
+		if err := payout.validate(fmt.Sprintf("work.stars[%d]", i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
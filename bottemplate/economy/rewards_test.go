@@ -0,0 +1,90 @@
+package economy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRewardConfigWithDefaults(t *testing.T) {
+	d := DefaultRewardConfig()
+	cfg := RewardConfig{
+		Daily: DailyRewardConfig{Amount: 1500},
+		Work: WorkRewardConfig{
+			Failed: WorkPayout{Flakes: RewardRange{Min: 1, Max: 2}},
+			Stars:  []WorkPayout{{XP: RewardRange{Min: 7, Max: 7}}},
+		},
+	}.WithDefaults()
+
+	if cfg.Daily.Amount != 1500 || cfg.Daily.CooldownMinutes != d.Daily.CooldownMinutes {
+		t.Errorf("daily = %+v, want amount 1500 and the default cooldown", cfg.Daily)
+	}
+	if cfg.Work.Cooldown() != 10*time.Second {
+		t.Errorf("work cooldown = %s, want 10s", cfg.Work.Cooldown())
+	}
+	// Unset ranges inside an overridden payout keep their defaults
+	if cfg.Work.Failed.Flakes != (RewardRange{Min: 1, Max: 2}) || cfg.Work.Failed.Vials != d.Work.Failed.Vials {
+		t.Errorf("failed = %+v", cfg.Work.Failed)
+	}
+	if len(cfg.Work.Stars) != WorkStarTiers {
+		t.Fatalf("got %d star tiers, want %d", len(cfg.Work.Stars), WorkStarTiers)
+	}
+	if cfg.Work.Stars[0].XP != (RewardRange{Min: 7, Max: 7}) || cfg.Work.Stars[0].Flakes != d.Work.Stars[0].Flakes {
+		t.Errorf("1-star = %+v", cfg.Work.Stars[0])
+	}
+	if cfg.Work.Stars[4] != d.Work.Stars[4] {
+		t.Errorf("5-star = %+v, want the default", cfg.Work.Stars[4])
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
+
+func TestRewardConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  RewardConfig
+	}{
+		{"negative daily", RewardConfig{Daily: DailyRewardConfig{Amount: -1}}},
+		{"negative cooldown", RewardConfig{Work: WorkRewardConfig{CooldownSeconds: -5}}},
+		{"too many tiers", RewardConfig{Work: WorkRewardConfig{Stars: make([]WorkPayout, WorkStarTiers+1)}}},
+		{"negative min", RewardConfig{Work: WorkRewardConfig{Failed: WorkPayout{Vials: RewardRange{Min: -1}}}}},
+		{"max below min", RewardConfig{Work: WorkRewardConfig{Stars: []WorkPayout{{Flakes: RewardRange{Min: 10, Max: 5}}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); err == nil {
+				t.Error("Validate accepted an invalid config")
+			}
+		})
+	}
+}
+
+func TestWorkRewardConfigPayout(t *testing.T) {
+	cfg := DefaultRewardConfig().Work
+	if got := cfg.Payout(3, false); got != cfg.Failed {
+		t.Errorf("failed 3-star = %+v, want the failed payout", got)
+	}
+	if got := cfg.Payout(5, true); got != cfg.Stars[4] {
+		t.Errorf("5-star = %+v", got)
+	}
+	for _, stars := range []int{0, 6} {
+		if got := cfg.Payout(stars, true); got != (WorkPayout{}) {
+			t.Errorf("Payout(%d) = %+v, want nothing", stars, got)
+		}
+	}
+}
+
+func TestRewardRangeRoll(t *testing.T) {
+	if got := (RewardRange{Min: 4, Max: 4}).Roll(); got != 4 {
+		t.Errorf("fixed range rolled %d", got)
+	}
+	if got := (RewardRange{Min: 9, Max: 3}).Roll(); got != 9 {
+		t.Errorf("inverted range rolled %d, want min", got)
+	}
+	r := RewardRange{Min: 10, Max: 13}
+	for i := 0; i < 100; i++ {
+		if got := r.Roll(); got < r.Min || got >= r.Max {
+			t.Fatalf("Roll = %d, want within [%d, %d)", got, r.Min, r.Max)
+		}
+	}
+}
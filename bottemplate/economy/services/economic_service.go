@@ -66,6 +66,7 @@ type EconomicServiceConfig struct {
 
 	// Claim settings
 	ClaimCooldownPeriod time.Duration
+	ClaimSessionTimeout time.Duration
 
 	// Monitoring settings
 	MonitoringInterval time.Duration
@@ -155,7 +156,7 @@ func (s *EconomicService) initializeManagers() {
 	)
 
 	// Initialize claim manager
-	s.claimManager = claim.NewManager(s.config.ClaimCooldownPeriod)
+	s.claimManager = claim.NewManager(s.config.ClaimCooldownPeriod, s.config.ClaimSessionTimeout)
 
 	// Initialize effects manager
 	s.effectsManager = effects.NewManager(s.effectRepo, s.userRepo, s.userCardRepo, s.cardRepo, s.collectionRepo, s.db)
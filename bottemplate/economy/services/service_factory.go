@@ -124,6 +124,7 @@ func DefaultEconomicServiceConfig() EconomicServiceConfig {
 		DefaultTransactionTimeout: 30 * time.Second,
 		MaxRetries:                3,
 		ClaimCooldownPeriod:       5 * time.Second,
+		ClaimSessionTimeout:       30 * time.Second,
 		MonitoringInterval:        15 * time.Minute,
 		PricingConfig: economy.PricingConfig{
 			BasePrice:           1000,
@@ -0,0 +1,119 @@
+package economy
+
+import "github.com/disgoorg/bot-template/bottemplate/database/models"
+
+// ThresholdStatus is the outcome of checking a metric against its health thresholds
+type ThresholdStatus string
+
+const (
+	ThresholdPass ThresholdStatus = "pass"
+	ThresholdWarn ThresholdStatus = "warn"
+	ThresholdFail ThresholdStatus = "fail"
+)
+
+// HealthThresholds configures when economy metrics are flagged. Zero values use the defaults.
+type HealthThresholds struct {
+	GiniWarn          float64 `toml:"gini_warn"`
+	GiniFail          float64 `toml:"gini_fail"`
+	CardGiniWarn      float64 `toml:"card_gini_warn"`
+	CardGiniFail      float64 `toml:"card_gini_fail"`
+	InflationWarnPct  float64 `toml:"inflation_warn_pct"`
+	InflationFailPct  float64 `toml:"inflation_fail_pct"`
+	HealthScoreWarn   float64 `toml:"health_score_warn"`
+	HealthScoreFail   float64 `toml:"health_score_fail"`
+	ParticipationWarn float64 `toml:"participation_warn"`
+	ParticipationFail float64 `toml:"participation_fail"`
+}
+
+// DefaultHealthThresholds mirrors the limits the monitor uses to decide on corrections
+func DefaultHealthThresholds() HealthThresholds {
+	return HealthThresholds{
+		GiniWarn:          0.5,
+		GiniFail:          0.6,
+		CardGiniWarn:      0.6,
+		CardGiniFail:      0.75,
+		InflationWarnPct:  5,
+		InflationFailPct:  15,
+		HealthScoreWarn:   75,
+		HealthScoreFail:   60,
+		ParticipationWarn: 0.2,
+		ParticipationFail: 0.1,
+	}
+}
+
+// WithDefaults fills unset thresholds from DefaultHealthThresholds
+func (t HealthThresholds) WithDefaults() HealthThresholds {
+	d := DefaultHealthThresholds()
+	fill := func(v *float64, def float64) {
+		if *v == 0 {
+			*v = def
+		}
+	}
+	fill(&t.GiniWarn, d.GiniWarn)
+	fill(&t.GiniFail, d.GiniFail)
+	fill(&t.CardGiniWarn, d.CardGiniWarn)
+	fill(&t.CardGiniFail, d.CardGiniFail)
+	fill(&t.InflationWarnPct, d.InflationWarnPct)
+	fill(&t.InflationFailPct, d.InflationFailPct)
+	fill(&t.HealthScoreWarn, d.HealthScoreWarn)
+	fill(&t.HealthScoreFail, d.HealthScoreFail)
+	fill(&t.ParticipationWarn, d.ParticipationWarn)
+	fill(&t.ParticipationFail, d.ParticipationFail)
+	return t
+}
+
+// ThresholdResult is a single metric checked against its thresholds
+type ThresholdResult struct {
+	Metric string          `json:"metric"`
+	Value  float64         `json:"value"`
+	Warn   float64         `json:"warn"`
+	Fail   float64         `json:"fail"`
+	Status ThresholdStatus `json:"status"`
+}
+
+// Evaluate checks the stats against the thresholds. Inflation is judged by magnitude so
+// sharp deflation is flagged as well.
+func (t HealthThresholds) Evaluate(stats *models.EconomyStats) []ThresholdResult {
+	t = t.WithDefaults()
+
+	participation := 0.0
+	if stats.TotalUsers > 0 {
+		participation = float64(stats.ActiveUsers) / float64(stats.TotalUsers)
+	}
+	inflation := stats.InflationRate
+	if inflation < 0 {
+		inflation = -inflation
+	}
+
+	return []ThresholdResult{
+		above("Currency Gini", stats.GiniCoefficient, stats.GiniCoefficient, t.GiniWarn, t.GiniFail),
+		above("Card Ownership Gini", stats.CardOwnershipGini, stats.CardOwnershipGini, t.CardGiniWarn, t.CardGiniFail),
+		above("Inflation %", stats.InflationRate, inflation, t.InflationWarnPct, t.InflationFailPct),
+		below("Health Score", stats.EconomicHealth, t.HealthScoreWarn, t.HealthScoreFail),
+		below("Participation", participation, t.ParticipationWarn, t.ParticipationFail),
+	}
+}
+
+// above flags metrics that are unhealthy when high; checked may differ from the reported value
+func above(metric string, value, checked, warn, fail float64) ThresholdResult {
+	status := ThresholdPass
+	switch {
+	case checked >= fail:
+		status = ThresholdFail
+	case checked >= warn:
+		status = ThresholdWarn
+	}
+	return ThresholdResult{Metric: metric, Value: value, Warn: warn, Fail: fail, Status: status}
+}
+
+// below flags metrics that are unhealthy when low
+func below(metric string, value, warn, fail float64) ThresholdResult {
+	status := ThresholdPass
+	switch {
+	case value <= fail:
+		status = ThresholdFail
+	case value <= warn:
+		status = ThresholdWarn
+	}
+	return ThresholdResult{Metric: metric, Value: value, Warn: warn, Fail: fail, Status: status}
+}
@@ -0,0 +1,63 @@
+package economy
+
+import (
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestHealthThresholdsEvaluate(t *testing.T) {
+	stats := &models.EconomyStats{
+		GiniCoefficient:   0.55,
+		CardOwnershipGini: 0.8,
+		InflationRate:     -20,
+		EconomicHealth:    90,
+		TotalUsers:        100,
+		ActiveUsers:       15,
+	}
+
+	want := map[string]ThresholdStatus{
+		"Currency Gini":       ThresholdWarn,
+		"Card Ownership Gini": ThresholdFail,
+		"Inflation %":         ThresholdFail, // sharp deflation counts too
+		"Health Score":        ThresholdPass,
+		"Participation":       ThresholdWarn,
+	}
+
+	results := HealthThresholds{}.Evaluate(stats)
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for _, result := range results {
+		if result.Status != want[result.Metric] {
+			t.Errorf("%s = %v: %s, want %s", result.Metric, result.Value, result.Status, want[result.Metric])
+		}
+	}
+	if results[2].Value != -20 {
+		t.Errorf("inflation reported as %v, want the signed -20", results[2].Value)
+	}
+}
+
+func TestHealthThresholdsBoundaries(t *testing.T) {
+	th := HealthThresholds{GiniWarn: 0.4, GiniFail: 0.7}.WithDefaults()
+	if th.GiniWarn != 0.4 || th.GiniFail != 0.7 || th.HealthScoreFail != DefaultHealthThresholds().HealthScoreFail {
+		t.Fatalf("WithDefaults = %+v", th)
+	}
+
+	tests := []struct {
+		gini, health float64
+		wantGini     ThresholdStatus
+		wantHealth   ThresholdStatus
+	}{
+		{0.39, 76, ThresholdPass, ThresholdPass},
+		{0.4, 75, ThresholdWarn, ThresholdWarn},
+		{0.7, 60, ThresholdFail, ThresholdFail},
+	}
+	for _, tt := range tests {
+		results := th.Evaluate(&models.EconomyStats{GiniCoefficient: tt.gini, EconomicHealth: tt.health})
+		if results[0].Status != tt.wantGini || results[3].Status != tt.wantHealth {
+			t.Errorf("gini %v, health %v: got %s and %s, want %s and %s",
+				tt.gini, tt.health, results[0].Status, results[3].Status, tt.wantGini, tt.wantHealth)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package economy
+
+import "fmt"
+
+// TransferLimits caps how much players can move to each other, to make laundering
+// currency and items through alt accounts slow. Zero means unlimited.
+type TransferLimits struct {
+	GiftsPerDay          int64 `toml:"gifts_per_day"`   // units gifted with /gift-item per UTC day
+	TradesPerHour        int64 `toml:"trades_per_hour"` // trade offers created per hour
+	PremiumGiftsPerDay   int64 `toml:"premium_gifts_per_day"`
+	PremiumTradesPerHour int64 `toml:"premium_trades_per_hour"`
+}
+
+// GiftLimit returns the daily gift cap for a regular or premium user. Premium users
+// fall back to the regular cap when no premium cap is set.
+func (l TransferLimits) GiftLimit(premium bool) int64 {
+	if premium && l.PremiumGiftsPerDay != 0 {
+		return l.PremiumGiftsPerDay
+	}
+	return l.GiftsPerDay
+}
+
+// TradeLimit returns the hourly trade cap for a regular or premium user
+func (l TransferLimits) TradeLimit(premium bool) int64 {
+	if premium && l.PremiumTradesPerHour != 0 {
+		return l.PremiumTradesPerHour
+	}
+	return l.TradesPerHour
+}
+
+// Validate reports the first inconsistency in the limits
+func (l TransferLimits) Validate() error {
+	limits := []struct {
+		key   string
+		value int64
+	}{
+		{"gifts_per_day", l.GiftsPerDay},
+		{"trades_per_hour", l.TradesPerHour},
+		{"premium_gifts_per_day", l.PremiumGiftsPerDay},
+		{"premium_trades_per_hour", l.PremiumTradesPerHour},
+	}
+	for _, limit := range limits {
+		if limit.value < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", limit.key, limit.value)
+		}
+	}
+	return nil
+}
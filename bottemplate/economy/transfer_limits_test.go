@@ -0,0 +1,24 @@
+package economy
+
+import "testing"
+
+func TestTransferLimitsPremium(t *testing.T) {
+	limits := TransferLimits{GiftsPerDay: 10, TradesPerHour: 5, PremiumGiftsPerDay: 30}
+
+	if got := limits.GiftLimit(false); got != 10 {
+		t.Errorf("GiftLimit(regular) = %d, want 10", got)
+	}
+	if got := limits.GiftLimit(true); got != 30 {
+		t.Errorf("GiftLimit(premium) = %d, want 30", got)
+	}
+	// No premium trade cap falls back to the regular one
+	if got := limits.TradeLimit(true); got != 5 {
+		t.Errorf("TradeLimit(premium) = %d, want 5", got)
+	}
+	if err := limits.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	if err := (TransferLimits{PremiumTradesPerHour: -1}).Validate(); err == nil {
+		t.Error("Validate accepted a negative limit")
+	}
+}
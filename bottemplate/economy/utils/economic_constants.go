@@ -40,6 +40,12 @@ const (
 	AntiSnipeTime   = 10 * time.Second // Anti-snipe extension time
 	AuctionIDLength = 6                // Length of auction ID
 	MaxRetries      = 5                // Maximum retries for operations
+
+	AuctionEndingSoonWindow = 15 * time.Minute // Watchers are DM'd once an auction is this close to ending
+	AuctionWatchDMInterval  = 1 * time.Minute  // Minimum gap between bid DMs to one user about one auction
+
+	AuctionSalesLookback   = 30 * 24 * time.Hour // Sales older than this no longer inform the auction market price
+	AuctionSalesAverageLen = 10                  // Number of most recent sales averaged into the auction market price
 )
 
 // Card Level Validation
@@ -134,7 +134,7 @@ func (tm *TransactionManager) UpsertUserCard(ctx context.Context, tx bun.Tx, use
 			UpdatedAt: time.Now(),
 		}).
 		On("CONFLICT (user_id, card_id) DO UPDATE").
-		Set("amount = user_cards.amount + ?", amount).
+		Set("amount = uc.amount + EXCLUDED.amount").
 		Set("updated_at = ?", time.Now()).
 		Exec(ctx)
 
@@ -0,0 +1,45 @@
+package utils_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/economy/utils"
+	"github.com/uptrace/bun"
+)
+
+func TestUpsertUserCardConcurrentGrants(t *testing.T) {
+	db := dbtest.Open(t)
+	tm := utils.NewTransactionManager(db.BunDB())
+	ctx := context.Background()
+
+	const grants = 25
+	var wg sync.WaitGroup
+	for i := 0; i < grants; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := db.BunDB().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+				return tm.UpsertUserCard(ctx, tx, "u1", 7, 1)
+			})
+			if err != nil {
+				t.Errorf("UpsertUserCard: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var cards []*models.UserCard
+	if err := db.BunDB().NewSelect().Model(&cards).Where("user_id = ? AND card_id = ?", "u1", 7).Scan(ctx); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if len(cards) != 1 {
+		t.Fatalf("%d rows for one user and card, want 1", len(cards))
+	}
+	if cards[0].Amount != grants {
+		t.Errorf("amount = %d, want %d", cards[0].Amount, grants)
+	}
+}
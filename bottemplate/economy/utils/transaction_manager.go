@@ -86,6 +86,9 @@ type BalanceOperationOptions struct {
 	UserID         string
 	Amount         int64
 	MinimumBalance int64 // Validation threshold
+	// Reason, when set, appends a currency ledger entry in the same transaction
+	Reason    string
+	Reference string
 }
 
 // AddCardToInventory adds cards to user inventory with UPSERT logic
@@ -215,9 +218,66 @@ func (etm *EconomicTransactionManager) ValidateAndUpdateBalance(ctx context.Cont
 		return fmt.Errorf("user not found when updating balance")
 	}
 
+	if opts.Reason != "" {
+		if _, err := tx.NewInsert().
+			Model(&models.CurrencyLedgerEntry{
+				UserID:    opts.UserID,
+				Amount:    opts.Amount,
+				Reason:    opts.Reason,
+				Reference: opts.Reference,
+				CreatedAt: time.Now(),
+			}).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("failed to record currency change: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// SalePayoutOptions configures paying a seller with a commission kept back
+type SalePayoutOptions struct {
+	SellerID      string
+	Price         int64
+	CommissionBps int64 // share of Price kept back, in basis points
+	Reference     string
+}
+
+// CommissionFor returns the commission on price at rate basis points, rounded down
+func CommissionFor(price, bps int64) int64 {
+	if price <= 0 || bps <= 0 {
+		return 0
+	}
+	return price * bps / 10000
+}
+
+// PaySeller credits the seller the full sale price and takes the commission back in
+// the same transaction, so the ledger shows both. The commission is not paid to
+// anyone; it leaves the economy. It returns the commission taken.
+func (etm *EconomicTransactionManager) PaySeller(ctx context.Context, tx bun.Tx, opts SalePayoutOptions) (int64, error) {
+	if err := etm.ValidateAndUpdateBalance(ctx, tx, BalanceOperationOptions{
+		UserID:    opts.SellerID,
+		Amount:    opts.Price,
+		Reason:    models.LedgerReasonAuctionSale,
+		Reference: opts.Reference,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to pay seller: %w", err)
+	}
+
+	commission := CommissionFor(opts.Price, opts.CommissionBps)
+	if commission > 0 {
+		if err := etm.ValidateAndUpdateBalance(ctx, tx, BalanceOperationOptions{
+			UserID:    opts.SellerID,
+			Amount:    -commission,
+			Reason:    models.LedgerReasonAuctionCommission,
+			Reference: opts.Reference,
+		}); err != nil {
+			return 0, fmt.Errorf("failed to take commission: %w", err)
+		}
+	}
+	return commission, nil
+}
+
 // TransferCard transfers a card from one user to another
 func (etm *EconomicTransactionManager) TransferCard(ctx context.Context, tx bun.Tx, fromUserID, toUserID string, cardID int64, amount int64) error {
 	// Remove from source
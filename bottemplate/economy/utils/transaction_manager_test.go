@@ -0,0 +1,99 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/economy/utils"
+	"github.com/uptrace/bun"
+)
+
+func TestCommissionFor(t *testing.T) {
+	tests := []struct {
+		price, bps, want int64
+	}{
+		{1000, 500, 50},
+		{999, 250, 24}, // rounded down
+		{1000, 0, 0},
+		{0, 500, 0},
+		{-100, 500, 0},
+	}
+	for _, tt := range tests {
+		if got := utils.CommissionFor(tt.price, tt.bps); got != tt.want {
+			t.Errorf("CommissionFor(%d, %d) = %d, want %d", tt.price, tt.bps, got, tt.want)
+		}
+	}
+}
+
+func createBalanceUser(t *testing.T, db *bun.DB, discordID string, balance int64) {
+	t.Helper()
+	now := time.Now()
+	user := &models.User{DiscordID: discordID, Username: discordID, Balance: balance, Joined: now, LastDaily: now, LastTrain: now, LastWork: now, LastVote: now}
+	if _, err := db.NewInsert().Model(user).Exec(context.Background()); err != nil {
+		t.Fatalf("create user %s: %v", discordID, err)
+	}
+}
+
+func balanceOf(t *testing.T, db *bun.DB, discordID string) int64 {
+	t.Helper()
+	var user models.User
+	if err := db.NewSelect().Model(&user).Where("discord_id = ?", discordID).Scan(context.Background()); err != nil {
+		t.Fatalf("get user %s: %v", discordID, err)
+	}
+	return user.Balance
+}
+
+func TestPaySellerKeepsCommission(t *testing.T) {
+	db := dbtest.Open(t)
+	tm := utils.NewEconomicTransactionManager(db.BunDB())
+	ctx := context.Background()
+	createBalanceUser(t, db.BunDB(), "seller", 100)
+
+	var commission int64
+	err := db.BunDB().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		commission, err = tm.PaySeller(ctx, tx, utils.SalePayoutOptions{SellerID: "seller", Price: 1000, CommissionBps: 500, Reference: "A1"})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("PaySeller: %v", err)
+	}
+	if commission != 50 {
+		t.Errorf("commission = %d, want 50", commission)
+	}
+	if got := balanceOf(t, db.BunDB(), "seller"); got != 1050 {
+		t.Errorf("seller balance = %d, want 1050", got)
+	}
+
+	var entries []models.CurrencyLedgerEntry
+	if err := db.BunDB().NewSelect().Model(&entries).Where("user_id = ?", "seller").Order("id ASC").Scan(ctx); err != nil {
+		t.Fatalf("select ledger: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Reason != models.LedgerReasonAuctionSale || entries[1].Amount != -50 || entries[1].Reason != models.LedgerReasonAuctionCommission {
+		t.Errorf("ledger = %+v, want the sale then the commission", entries)
+	}
+}
+
+func TestListingFeeNeedsFunds(t *testing.T) {
+	db := dbtest.Open(t)
+	tm := utils.NewEconomicTransactionManager(db.BunDB())
+	ctx := context.Background()
+	createBalanceUser(t, db.BunDB(), "seller", 30)
+
+	err := db.BunDB().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return tm.ValidateAndUpdateBalance(ctx, tx, utils.BalanceOperationOptions{
+			UserID: "seller",
+			Amount: -50,
+			Reason: models.LedgerReasonAuctionListingFee,
+		})
+	})
+	if err == nil {
+		t.Fatal("listing fee charged without enough balance")
+	}
+	if got := balanceOf(t, db.BunDB(), "seller"); got != 30 {
+		t.Errorf("seller balance = %d, want it unchanged", got)
+	}
+}
@@ -0,0 +1,168 @@
+package vials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/economy/utils"
+	"github.com/uptrace/bun"
+)
+
+// LiquefyUndoWindow is how long a liquefy can be undone after it is confirmed
+const LiquefyUndoWindow = 60 * time.Second
+
+// ErrUndoExpired is returned when an undo is requested after the window closed
+// or for a liquefy that was already undone
+var ErrUndoExpired = errors.New("the undo window for this liquefy has expired")
+
+// LiquefyRecord is everything needed to reverse a single liquefy
+type LiquefyRecord struct {
+	UserID    string
+	CardID    int64
+	CardLevel int
+	Vials     int64
+	ExpiresAt time.Time
+}
+
+func (r *LiquefyRecord) expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// UndoStore keeps recent liquefies in memory until their undo window closes
+type UndoStore struct {
+	records sync.Map // token -> *LiquefyRecord
+	nextID  atomic.Uint64
+	window  time.Duration
+	now     func() time.Time
+}
+
+func NewUndoStore() *UndoStore {
+	return &UndoStore{
+		window: LiquefyUndoWindow,
+		now:    time.Now,
+	}
+}
+
+// Remember stores a reversible record of a liquefy and returns the token that undoes it
+func (s *UndoStore) Remember(userID string, cardID int64, cardLevel int, vials int64) string {
+	token := strconv.FormatUint(s.nextID.Add(1), 36)
+	s.records.Store(token, &LiquefyRecord{
+		UserID:    userID,
+		CardID:    cardID,
+		CardLevel: cardLevel,
+		Vials:     vials,
+		ExpiresAt: s.now().Add(s.window),
+	})
+	return token
+}
+
+// Take removes and returns the record for token if it belongs to userID and is
+// still within its window. A record can only be taken once.
+func (s *UndoStore) Take(token, userID string) (*LiquefyRecord, error) {
+	value, ok := s.records.Load(token)
+	if !ok {
+		return nil, ErrUndoExpired
+	}
+	record := value.(*LiquefyRecord)
+	if record.UserID != userID {
+		return nil, fmt.Errorf("only the user who liquefied this card can undo it")
+	}
+	if !s.records.CompareAndDelete(token, value) || record.expired(s.now()) {
+		return nil, ErrUndoExpired
+	}
+	return record, nil
+}
+
+// Restore puts back a record taken with Take, so an undo that failed can be retried
+// while its window is still open
+func (s *UndoStore) Restore(token string, record *LiquefyRecord) {
+	if record.expired(s.now()) {
+		return
+	}
+	s.records.LoadOrStore(token, record)
+}
+
+func (s *UndoStore) cleanupExpired() {
+	now := s.now()
+	s.records.Range(func(key, value interface{}) bool {
+		if value.(*LiquefyRecord).expired(now) {
+			s.records.CompareAndDelete(key, value)
+		}
+		return true
+	})
+}
+
+// RunCleanupRoutine drops expired undo records every 30 seconds until ctx is cancelled
+func (s *UndoStore) RunCleanupRoutine(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						slog.Error("Panic in liquefy undo cleanup routine", slog.Any("panic", r))
+					}
+				}()
+				s.cleanupExpired()
+			}()
+		}
+	}
+}
+
+// UndoLiquefy reverses a liquefy in one transaction: the card goes back into
+// the inventory and the vials it yielded are taken back. It fails without
+// changing anything when the user has already spent those vials.
+func (vm *VialManager) UndoLiquefy(ctx context.Context, record *LiquefyRecord) error {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	return vm.txManager.WithTransaction(ctx, utils.StandardTransactionOptions(), func(ctx context.Context, tx bun.Tx) error {
+		var user models.User
+		err := tx.NewSelect().
+			Model(&user).
+			Column("user_stats").
+			Where("discord_id = ?", record.UserID).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.UserStats.Vials < record.Vials {
+			return fmt.Errorf("you no longer have the %d vials this liquefy gave you", record.Vials)
+		}
+
+		statField := fmt.Sprintf("liquefy%d", record.CardLevel)
+		_, err = tx.NewUpdate().
+			Model((*models.User)(nil)).
+			Set(
+				"user_stats = jsonb_set(jsonb_set(user_stats, '{vials}', (COALESCE((user_stats->>'vials')::bigint, 0) - ?)::text::jsonb), '{"+statField+"}', GREATEST(COALESCE((user_stats->'"+statField+"')::bigint, 0) - 1, 0)::text::jsonb)",
+				record.Vials,
+			).
+			Where("discord_id = ?", record.UserID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to take back vials: %w", err)
+		}
+
+		if err := vm.txManager.AddCardToInventory(ctx, tx, utils.CardOperationOptions{
+			UserID: record.UserID,
+			CardID: record.CardID,
+			Amount: 1,
+		}); err != nil {
+			return fmt.Errorf("failed to restore card: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,139 @@
+package vials
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// newTestUndoStore returns a store whose clock only moves when the test advances it
+func newTestUndoStore() (*UndoStore, *time.Time) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewUndoStore()
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestUndoStoreTakeOnce(t *testing.T) {
+	s, _ := newTestUndoStore()
+	token := s.Remember("u1", 7, 3, 120)
+
+	if _, err := s.Take(token, "u2"); err == nil || errors.Is(err, ErrUndoExpired) {
+		t.Errorf("Take by another user = %v, want an ownership error", err)
+	}
+
+	record, err := s.Take(token, "u1")
+	if err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+	if record.CardID != 7 || record.CardLevel != 3 || record.Vials != 120 {
+		t.Errorf("record = %+v", record)
+	}
+	if _, err := s.Take(token, "u1"); !errors.Is(err, ErrUndoExpired) {
+		t.Errorf("second Take = %v, want ErrUndoExpired", err)
+	}
+	if _, err := s.Take("unknown", "u1"); !errors.Is(err, ErrUndoExpired) {
+		t.Errorf("Take of an unknown token = %v, want ErrUndoExpired", err)
+	}
+}
+
+func TestUndoStoreExpires(t *testing.T) {
+	s, now := newTestUndoStore()
+	token := s.Remember("u1", 7, 3, 120)
+
+	*now = now.Add(LiquefyUndoWindow)
+	if _, err := s.Take(token, "u1"); !errors.Is(err, ErrUndoExpired) {
+		t.Errorf("Take at the end of the window = %v, want ErrUndoExpired", err)
+	}
+
+	kept := s.Remember("u1", 8, 1, 10)
+	*now = now.Add(LiquefyUndoWindow - time.Second)
+	s.cleanupExpired()
+	if _, err := s.Take(kept, "u1"); err != nil {
+		t.Errorf("cleanup dropped a record inside its window: %v", err)
+	}
+}
+
+func TestUndoStoreRestore(t *testing.T) {
+	s, now := newTestUndoStore()
+	token := s.Remember("u1", 7, 3, 120)
+
+	// A failed undo puts the record back so it can be retried
+	record, err := s.Take(token, "u1")
+	if err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+	s.Restore(token, record)
+	if _, err := s.Take(token, "u1"); err != nil {
+		t.Fatalf("Take after Restore: %v", err)
+	}
+
+	// Restoring after the window closed keeps it gone
+	*now = now.Add(LiquefyUndoWindow)
+	s.Restore(token, record)
+	if _, ok := s.records.Load(token); ok {
+		t.Error("expired record was restored")
+	}
+}
+
+func TestUndoLiquefy(t *testing.T) {
+	db := dbtest.Open(t)
+	vm := NewVialManager(db, nil)
+	ctx := context.Background()
+
+	now := time.Now()
+	collection := &models.Collection{ID: "twice", Name: "twice", Origin: "test", UpdatedAt: now}
+	card := &models.Card{ID: 7, Name: "momo", Level: 3, ColID: "twice", Tags: []string{"girlgroups"}, UpdatedAt: now}
+	user := &models.User{DiscordID: "u1", Username: "u1", Joined: now, LastDaily: now, LastTrain: now, LastWork: now, LastVote: now}
+	for _, model := range []interface{}{collection, card, user} {
+		if _, err := db.BunDB().NewInsert().Model(model).Exec(ctx); err != nil {
+			t.Fatalf("insert %T: %v", model, err)
+		}
+	}
+	// The liquefy already paid 120 vials and counted once more toward liquefy3
+	if _, err := db.BunDB().NewUpdate().Model((*models.User)(nil)).
+		Set(`user_stats = '{"vials": 150, "liquefy3": 2}'::jsonb`).
+		Where("discord_id = ?", "u1").
+		Exec(ctx); err != nil {
+		t.Fatalf("set user stats: %v", err)
+	}
+
+	state := func() (vials, liquefied, copies int64) {
+		t.Helper()
+		err := db.BunDB().NewSelect().Model((*models.User)(nil)).
+			ColumnExpr("(user_stats->>'vials')::bigint, (user_stats->>'liquefy3')::bigint").
+			Where("discord_id = ?", "u1").
+			Scan(ctx, &vials, &liquefied)
+		if err != nil {
+			t.Fatalf("get user stats: %v", err)
+		}
+		err = db.BunDB().NewSelect().Model((*models.UserCard)(nil)).
+			ColumnExpr("COALESCE(SUM(amount), 0)").
+			Where("user_id = ? AND card_id = ?", "u1", 7).
+			Scan(ctx, &copies)
+		if err != nil {
+			t.Fatalf("count copies: %v", err)
+		}
+		return vials, liquefied, copies
+	}
+
+	record := &LiquefyRecord{UserID: "u1", CardID: 7, CardLevel: 3, Vials: 120}
+	if err := vm.UndoLiquefy(ctx, record); err != nil {
+		t.Fatalf("UndoLiquefy: %v", err)
+	}
+	if vials, liquefied, copies := state(); vials != 30 || liquefied != 1 || copies != 1 {
+		t.Errorf("after undo: %d vials, liquefy3 %d, %d copies, want 30, 1, 1", vials, liquefied, copies)
+	}
+
+	// The vials from a second liquefy were already spent, so undoing it changes nothing
+	if err := vm.UndoLiquefy(ctx, record); err == nil {
+		t.Fatal("UndoLiquefy with the vials already spent succeeded")
+	}
+	if vials, liquefied, copies := state(); vials != 30 || liquefied != 1 || copies != 1 {
+		t.Errorf("after the refused undo: %d vials, liquefy3 %d, %d copies, want 30, 1, 1", vials, liquefied, copies)
+	}
+}
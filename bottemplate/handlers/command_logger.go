@@ -7,8 +7,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/disgoorg/bot-template/bottemplate/logger"
 	"github.com/disgoorg/bot-template/bottemplate/services"
 	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
 )
 
@@ -30,14 +32,104 @@ func getQuestTracker() *services.QuestTracker {
 	return questTrackerStore.tracker
 }
 
+var commandGateStore struct {
+	sync.RWMutex
+	gate *services.GuildCommandGate
+}
+
+// SetCommandGate enables per-guild command enablement checks for wrapped commands.
+func SetCommandGate(gate *services.GuildCommandGate) {
+	commandGateStore.Lock()
+	defer commandGateStore.Unlock()
+	commandGateStore.gate = gate
+}
+
+func getCommandGate() *services.GuildCommandGate {
+	commandGateStore.RLock()
+	defer commandGateStore.RUnlock()
+	return commandGateStore.gate
+}
+
+// commandAllowed reports whether the command's category is enabled in the guild it was
+// invoked from. DMs and unconfigured bots always allow.
+func commandAllowed(e *handler.CommandEvent) bool {
+	gate := getCommandGate()
+	guildID := e.GuildID()
+	if gate == nil || guildID == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return gate.CommandAllowed(ctx, guildID.String(), e.Data.CommandName())
+}
+
+var banCheckerStore struct {
+	sync.RWMutex
+	checker *services.BanChecker
+}
+
+// SetBanChecker makes wrapped commands and components refuse banned users.
+func SetBanChecker(checker *services.BanChecker) {
+	banCheckerStore.Lock()
+	defer banCheckerStore.Unlock()
+	banCheckerStore.checker = checker
+}
+
+func getBanChecker() *services.BanChecker {
+	banCheckerStore.RLock()
+	defer banCheckerStore.RUnlock()
+	return banCheckerStore.checker
+}
+
+// banNotice returns the message shown to a banned user, or "" when the user may interact
+func banNotice(userID string) string {
+	checker := getBanChecker()
+	if checker == nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ban, banned := checker.Banned(ctx, userID)
+	if !banned {
+		return ""
+	}
+
+	notice := "🚫 You are banned from using this bot."
+	if ban.Reason != "" {
+		notice += fmt.Sprintf("\n**Reason:** %s", ban.Reason)
+	}
+	if !ban.Until.IsZero() {
+		notice += fmt.Sprintf("\n**Expires:** <t:%d:R>", ban.Until.Unix())
+	}
+	return notice
+}
+
 // WrapWithLogging wraps a command handler with logging functionality
 func WrapWithLogging(name string, h handler.CommandHandler) handler.CommandHandler {
 	return func(e *handler.CommandEvent) error {
 		start := time.Now()
+		e.Ctx = logger.WithRequestID(e.Ctx, e.ID().String())
+		ctx := e.Ctx
+
+		if notice := banNotice(e.User().ID.String()); notice != "" {
+			return e.CreateMessage(discord.MessageCreate{
+				Content: notice,
+				Flags:   discord.MessageFlagEphemeral,
+			})
+		}
+
+		if !commandAllowed(e) {
+			return e.CreateMessage(discord.MessageCreate{
+				Content: "🚫 This command is disabled here.",
+				Flags:   discord.MessageFlagEphemeral,
+			})
+		}
 
 		// Log command start only for debug level
-		if slog.Default().Enabled(nil, slog.LevelDebug) {
-			slog.Debug("Command started",
+		if slog.Default().Enabled(ctx, slog.LevelDebug) {
+			slog.DebugContext(ctx, "Command started",
 				slog.String("type", "cmd"),
 				slog.String("name", name),
 				slog.String("user_id", e.User().ID.String()),
@@ -53,7 +145,7 @@ func WrapWithLogging(name string, h handler.CommandHandler) handler.CommandHandl
 		// Log command completion with optimized level checking
 		if err != nil {
 			// Always log errors
-			slog.Error("Command failed",
+			slog.ErrorContext(ctx, "Command failed",
 				slog.String("type", "cmd"),
 				slog.String("name", name),
 				slog.String("user_id", e.User().ID.String()),
@@ -64,7 +156,7 @@ func WrapWithLogging(name string, h handler.CommandHandler) handler.CommandHandl
 			)
 		} else if duration > 2*time.Second {
 			// Always log slow commands
-			slog.Warn("Command executed slowly",
+			slog.WarnContext(ctx, "Command executed slowly",
 				slog.String("type", "cmd"),
 				slog.String("name", name),
 				slog.String("user_id", e.User().ID.String()),
@@ -72,9 +164,9 @@ func WrapWithLogging(name string, h handler.CommandHandler) handler.CommandHandl
 				slog.Duration("took", duration),
 				slog.String("status", "slow"),
 			)
-		} else if slog.Default().Enabled(nil, slog.LevelDebug) {
+		} else if slog.Default().Enabled(ctx, slog.LevelDebug) {
 			// Only log successful completions at debug level
-			slog.Debug("Command completed",
+			slog.DebugContext(ctx, "Command completed",
 				slog.String("type", "cmd"),
 				slog.String("name", name),
 				slog.String("user_id", e.User().ID.String()),
@@ -111,10 +203,19 @@ func WrapWithLoggingAndQuests(name string, h handler.CommandHandler, b interface
 func WrapComponentWithLogging(name string, h handler.ComponentHandler) handler.ComponentHandler {
 	return func(e *handler.ComponentEvent) error {
 		start := time.Now()
+		e.Ctx = logger.WithRequestID(e.Ctx, e.ID().String())
+		ctx := e.Ctx
+
+		if notice := banNotice(e.User().ID.String()); notice != "" {
+			return e.CreateMessage(discord.MessageCreate{
+				Content: notice,
+				Flags:   discord.MessageFlagEphemeral,
+			})
+		}
 
 		// Log component interaction start only for debug level
-		if slog.Default().Enabled(nil, slog.LevelDebug) {
-			slog.Debug("Component interaction started",
+		if slog.Default().Enabled(ctx, slog.LevelDebug) {
+			slog.DebugContext(ctx, "Component interaction started",
 				slog.String("type", "component"),
 				slog.String("name", name),
 				slog.String("user_id", e.User().ID.String()),
@@ -130,7 +231,7 @@ func WrapComponentWithLogging(name string, h handler.ComponentHandler) handler.C
 		// Log component completion with optimized level checking
 		if err != nil {
 			// Always log errors
-			slog.Error("Component interaction failed",
+			slog.ErrorContext(ctx, "Component interaction failed",
 				slog.String("type", "component"),
 				slog.String("name", name),
 				slog.String("user_id", e.User().ID.String()),
@@ -141,7 +242,7 @@ func WrapComponentWithLogging(name string, h handler.ComponentHandler) handler.C
 			)
 		} else if duration > 2*time.Second {
 			// Always log slow interactions
-			slog.Warn("Component interaction executed slowly",
+			slog.WarnContext(ctx, "Component interaction executed slowly",
 				slog.String("type", "component"),
 				slog.String("name", name),
 				slog.String("user_id", e.User().ID.String()),
@@ -149,9 +250,9 @@ func WrapComponentWithLogging(name string, h handler.ComponentHandler) handler.C
 				slog.Duration("took", duration),
 				slog.String("status", "slow"),
 			)
-		} else if slog.Default().Enabled(nil, slog.LevelDebug) {
+		} else if slog.Default().Enabled(ctx, slog.LevelDebug) {
 			// Only log successful completions at debug level
-			slog.Debug("Component interaction completed",
+			slog.DebugContext(ctx, "Component interaction completed",
 				slog.String("type", "component"),
 				slog.String("name", name),
 				slog.String("user_id", e.User().ID.String()),
@@ -167,7 +268,7 @@ func WrapComponentWithLogging(name string, h handler.ComponentHandler) handler.C
 func runComponentHandler(h handler.ComponentHandler, e *handler.ComponentEvent, name string) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			slog.Error("Component panic recovered",
+			slog.ErrorContext(e.Ctx, "Component panic recovered",
 				slog.String("type", "component"),
 				slog.String("name", name),
 				slog.String("user_id", e.User().ID.String()),
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// RequestIDKey is the attribute key used for per-interaction correlation IDs
+const RequestIDKey = "request_id"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the given correlation ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the correlation ID stored in ctx, or "" if there is none
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// NewRequestID generates a random correlation ID for work that doesn't come
+// with one, such as HTTP requests without an X-Request-ID header
+func NewRequestID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// ContextHandler adds the request ID from the record's context to every log
+// record, so any slog call made with a request context can be correlated
+type ContextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler wraps next so records logged with a request context carry its ID
+func NewContextHandler(next slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: next}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestContextHandlerAddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(NewContextHandler(slog.NewTextHandler(&buf, nil))).With(slog.String("type", "cmd"))
+
+	ctx := WithRequestID(context.Background(), "1234567890")
+	log.InfoContext(ctx, "Command failed")
+	log.Info("Startup")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2", len(lines))
+	}
+	// Attributes added with With keep the request ID handler in the chain
+	if !strings.Contains(lines[0], "request_id=1234567890") || !strings.Contains(lines[0], "type=cmd") {
+		t.Errorf("request log = %q, want the request ID and type", lines[0])
+	}
+	if strings.Contains(lines[1], RequestIDKey) {
+		t.Errorf("log without a request context = %q, want no request ID", lines[1])
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	if got := RequestID(context.Background()); got != "" {
+		t.Errorf("RequestID of a bare context = %q", got)
+	}
+	if got := RequestID(WithRequestID(context.Background(), "abc")); got != "abc" {
+		t.Errorf("RequestID = %q, want abc", got)
+	}
+
+	a, b := NewRequestID(), NewRequestID()
+	if len(a) != 16 || a == b {
+		t.Errorf("NewRequestID = %q and %q, want two distinct 16 character IDs", a, b)
+	}
+}
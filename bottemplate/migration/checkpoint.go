@@ -0,0 +1,71 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const defaultCheckpointPath = "migration_checkpoint.json"
+
+// ErrInterrupted is returned once the migration context is cancelled, e.g. by Ctrl-C.
+// The batch in flight is allowed to finish; no further batches are started.
+var ErrInterrupted = errors.New("migration interrupted")
+
+// Checkpoint records how far an interrupted migration got
+type Checkpoint struct {
+	InterruptedAt   time.Time              `json:"interrupted_at"`
+	Source          string                 `json:"source"`
+	CompletedSteps  []string               `json:"completed_steps"`
+	InterruptedStep string                 `json:"interrupted_step"`
+	Tables          map[string]*TableStats `json:"tables"`
+}
+
+// SetCheckpointPath sets where the checkpoint of an interrupted migration is written
+// (default migration_checkpoint.json)
+func (m *Migrator) SetCheckpointPath(path string) { m.checkpointPath = path }
+
+// batchContext stops the migration between batches once ctx is cancelled. Otherwise it returns
+// a context that lets the batch about to start finish even if cancellation arrives mid-write.
+func batchContext(ctx context.Context) (context.Context, error) {
+	if err := interrupted(ctx); err != nil {
+		return nil, err
+	}
+	return context.WithoutCancel(ctx), nil
+}
+
+// interrupted returns ErrInterrupted once ctx is cancelled
+func interrupted(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInterrupted, err)
+	}
+	return nil
+}
+
+// writeCheckpoint saves the completed steps and per-table stats of an interrupted migration
+func (m *Migrator) writeCheckpoint(source string, completed []string, current string) error {
+	path := m.checkpointPath
+	if path == "" {
+		path = defaultCheckpointPath
+	}
+
+	data, err := json.MarshalIndent(Checkpoint{
+		InterruptedAt:   time.Now(),
+		Source:          source,
+		CompletedSteps:  completed,
+		InterruptedStep: current,
+		Tables:          m.stats.Tables,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	logProgress(fmt.Sprintf("Migration interrupted during %s; checkpoint written to %s", current, path))
+	return nil
+}
@@ -0,0 +1,73 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestCancelStopsBeforeNextBatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := batchContext(ctx); err != nil {
+		t.Fatalf("batchContext before cancel: %v", err)
+	}
+	cancel()
+
+	// No database is configured, so reaching the insert would panic
+	m := NewMigrator(nil, t.TempDir())
+	err := m.batchInsertClaims(ctx, []*models.Claim{{CardID: 1, UserID: "u1"}})
+	if !errors.Is(err, ErrInterrupted) || !errors.Is(err, context.Canceled) {
+		t.Errorf("batchInsertClaims after cancel = %v, want ErrInterrupted", err)
+	}
+}
+
+func TestCancelWritesCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	m := NewMigrator(nil, t.TempDir())
+	m.SetCheckpointPath(path)
+	m.beginTable("users")
+	m.currentTable.Successful = 42
+	m.endTable()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := m.stepFailed(ctx, "mongo", []string{"collections", "cards"}, "users", errors.New("cursor closed"))
+	if !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("stepFailed = %v, want ErrInterrupted", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read checkpoint: %v", err)
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		t.Fatalf("decode checkpoint: %v", err)
+	}
+	if cp.Source != "mongo" || cp.InterruptedStep != "users" || !reflect.DeepEqual(cp.CompletedSteps, []string{"collections", "cards"}) {
+		t.Errorf("checkpoint = %+v", cp)
+	}
+	if users := cp.Tables["users"]; users == nil || users.Successful != 42 {
+		t.Errorf("checkpoint users stats = %+v, want 42 successful", users)
+	}
+}
+
+func TestFailureWithoutCancelWritesNoCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	m := NewMigrator(nil, t.TempDir())
+	m.SetCheckpointPath(path)
+
+	err := m.stepFailed(context.Background(), "bson", nil, "cards", errors.New("duplicate key"))
+	if err == nil || errors.Is(err, ErrInterrupted) {
+		t.Errorf("stepFailed = %v, want a plain failure", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("checkpoint written for an ordinary failure: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package migration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DeadLetterEntry is one JSON line in the dead-letter file: a row that could not be
+// inserted, with the error that rejected it
+type DeadLetterEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Table     string    `json:"table"`
+	Error     string    `json:"error"`
+	Row       any       `json:"row"`
+}
+
+// SetDeadLetterPath makes rows that fail to insert individually get appended to path as
+// JSON lines so the migration can continue. An empty path (the default) keeps aborting.
+func (m *Migrator) SetDeadLetterPath(path string) { m.deadLetterPath = path }
+
+func (m *Migrator) deadLetterEnabled() bool { return m.deadLetterPath != "" }
+
+// deadLetter records a rejected row. The file is opened per entry since dead letters are
+// rare and the migrator has no close hook.
+func (m *Migrator) deadLetter(table string, row any, cause error) error {
+	line, err := json.Marshal(DeadLetterEntry{
+		Timestamp: time.Now(),
+		Table:     table,
+		Error:     cause.Error(),
+		Row:       row,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter: %w", err)
+	}
+
+	if dir := filepath.Dir(m.deadLetterPath); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create dead-letter directory: %w", err)
+		}
+	}
+	f, err := os.OpenFile(m.deadLetterPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open dead-letter file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write dead letter: %w", err)
+	}
+
+	if t := m.currentTable; t != nil {
+		t.DeadLettered++
+		// trackBatch later counts the whole batch as successful
+		t.Successful--
+	}
+	logProgress(fmt.Sprintf("Dead-lettered %s row: %v", table, cause))
+	return nil
+}
@@ -0,0 +1,58 @@
+package migration
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestDeadLetterAppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "failed", "dead_letter.jsonl")
+	m := NewMigrator(nil, t.TempDir())
+	if m.deadLetterEnabled() {
+		t.Fatal("dead letters enabled without a path")
+	}
+	m.SetDeadLetterPath(path)
+
+	m.beginTable("user_cards")
+	m.currentTable.Successful = 10
+	rows := []*models.UserCard{{UserID: "u1", CardID: 1}, {UserID: "u2", CardID: 2}}
+	for _, row := range rows {
+		if err := m.deadLetter("user_cards", row, errors.New("value too long")); err != nil {
+			t.Fatalf("deadLetter: %v", err)
+		}
+	}
+	if got := m.currentTable; got.DeadLettered != 2 || got.Successful != 8 {
+		t.Errorf("stats = %d dead-lettered, %d successful, want 2 and 8", got.DeadLettered, got.Successful)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open dead-letter file: %v", err)
+	}
+	defer f.Close()
+
+	var users []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry struct {
+			DeadLetterEntry
+			Row models.UserCard `json:"row"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		if entry.Table != "user_cards" || entry.Error != "value too long" || entry.Timestamp.IsZero() {
+			t.Errorf("entry = %+v", entry.DeadLetterEntry)
+		}
+		users = append(users, entry.Row.UserID)
+	}
+	if len(users) != 2 || users[0] != "u1" || users[1] != "u2" {
+		t.Errorf("dead-lettered users = %v, want u1 then u2", users)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -35,11 +36,16 @@ type Migrator struct {
 	stats MigrationStats
 	// Optional direct Mongo access
 	mongoDB *mongo.Database
+	// Optional stand-in for Mongo finds; tests use it to serve documents from memory
+	mongoFind func(ctx context.Context, collection string, filter interface{}, opts *options.FindOptions) (*mongo.Cursor, error)
 	// Tuning
 	sleepBetween time.Duration
 	insertSingle bool
 	// Mongo collection names (overrideable)
 	collNames map[string]string
+	// Optional per-kind Mongo filters and projections for partial/incremental migrations
+	mongoFilters     map[string]bson.D
+	mongoProjections map[string]bson.D
 	// JSON caches
 	jsonCardsByID       map[int64]JSONCard
 	jsonCollectionsByID map[string]JSONCollection
@@ -50,6 +56,15 @@ type Migrator struct {
 	// Optional: use pgx CopyFrom for fastest bulk inserts
 	useCopy bool
 	pool    *pgxpool.Pool
+	// Where skipped user cards are recorded, and whether as JSON lines instead of CSV
+	skippedLogPath string
+	skippedLogJSON bool
+	// Optional file receiving rows that fail to insert instead of aborting the migration
+	deadLetterPath string
+	// Where an interrupted migration records how far it got
+	checkpointPath string
+	// Stats of the migration step currently running, so batch inserts can record timing
+	currentTable *TableStats
 }
 
 func NewMigrator(pgDB *bun.DB, dataDir string) *Migrator {
@@ -120,6 +135,13 @@ func (m *Migrator) SetUseCopy(v bool) { m.useCopy = v }
 // UsePool sets the pgx pool for COPY operations
 func (m *Migrator) UsePool(pool *pgxpool.Pool) { m.pool = pool }
 
+// SetSkippedLogPath sets where skipped user cards are recorded (default skipped_cards.log).
+// Missing parent directories are created when the migration starts.
+func (m *Migrator) SetSkippedLogPath(path string) { m.skippedLogPath = path }
+
+// SetSkippedLogJSON writes the skipped-cards log as JSON lines instead of CSV
+func (m *Migrator) SetSkippedLogJSON(v bool) { m.skippedLogJSON = v }
+
 // loadJSONCaches loads cards.json and collections.json into memory maps (lazy)
 func (m *Migrator) loadJSONCaches() error {
 	if m.jsonCardsByID != nil && m.jsonCollectionsByID != nil {
@@ -231,6 +253,54 @@ func (m *Migrator) SetMongoCollectionName(kind, name string) {
 	}
 }
 
+// SetMongoFilter restricts which documents of a kind (e.g., "cards") are migrated.
+// The filter is combined with any paging filter the migration applies itself.
+func (m *Migrator) SetMongoFilter(kind string, filter bson.D) {
+	if m.mongoFilters == nil {
+		m.mongoFilters = map[string]bson.D{}
+	}
+	if kind != "" {
+		m.mongoFilters[kind] = filter
+	}
+}
+
+// SetMongoProjection limits the fields fetched for a kind to reduce transfer.
+// Fields left out of the projection decode as zero values.
+func (m *Migrator) SetMongoProjection(kind string, projection bson.D) {
+	if m.mongoProjections == nil {
+		m.mongoProjections = map[string]bson.D{}
+	}
+	if kind != "" {
+		m.mongoProjections[kind] = projection
+	}
+}
+
+// findQuery returns the filter and find options configured for a kind
+func (m *Migrator) findQuery(kind string) (bson.D, *options.FindOptions) {
+	filter := bson.D{}
+	if f, ok := m.mongoFilters[kind]; ok && f != nil {
+		filter = f
+	}
+	opts := options.Find()
+	if p, ok := m.mongoProjections[kind]; ok && len(p) > 0 {
+		opts.SetProjection(p)
+	}
+	return filter, opts
+}
+
+// streamsFromMongo reports whether migrations read from Mongo instead of BSON files
+func (m *Migrator) streamsFromMongo() bool {
+	return m.mongoDB != nil || m.mongoFind != nil
+}
+
+// find queries a Mongo collection, going through mongoFind when it is set
+func (m *Migrator) find(ctx context.Context, collection string, filter interface{}, opts *options.FindOptions) (*mongo.Cursor, error) {
+	if m.mongoFind != nil {
+		return m.mongoFind(ctx, collection, filter, opts)
+	}
+	return m.mongoDB.Collection(collection).Find(ctx, filter, opts)
+}
+
 func (m *Migrator) getColl(kind, defaultName string) *mongo.Collection {
 	if m.mongoDB == nil {
 		return nil
@@ -270,13 +340,21 @@ func (m *Migrator) MigrateAll(ctx context.Context) error {
 		{"user_recipes", "userinventories.bson", m.MigrateUserInventories},
 	}
 
+	var completed []string
 	for _, step := range migrationSteps {
 		logProgress(fmt.Sprintf("Starting migration step: %s", step.name))
 
-		if err := step.migrate(ctx); err != nil {
-			return fmt.Errorf("migration failed at step %s: %w", step.name, err)
+		m.beginTable(step.name)
+		err := step.migrate(ctx)
+		m.endTable()
+		if err == nil {
+			err = interrupted(ctx)
+		}
+		if err != nil {
+			return m.stepFailed(ctx, "bson", completed, step.name, err)
 		}
 
+		completed = append(completed, step.name)
 		logProgress(fmt.Sprintf("Completed migration step: %s", step.name))
 	}
 
@@ -320,11 +398,19 @@ func (m *Migrator) MigrateAllFromMongo(ctx context.Context) error {
 		{"user_inventories_mongo", m.MigrateUserInventoriesFromMongo},
 	}
 
+	var completed []string
 	for _, s := range steps {
 		logProgress(fmt.Sprintf("Starting migration step: %s", s.name))
-		if err := s.fn(ctx); err != nil {
-			return fmt.Errorf("migration failed at step %s: %w", s.name, err)
+		m.beginTable(s.name)
+		err := s.fn(ctx)
+		m.endTable()
+		if err == nil {
+			err = interrupted(ctx)
+		}
+		if err != nil {
+			return m.stepFailed(ctx, "mongo", completed, s.name, err)
 		}
+		completed = append(completed, s.name)
 		logProgress(fmt.Sprintf("Completed migration step: %s", s.name))
 	}
 
@@ -351,7 +437,8 @@ func (m *Migrator) ImportCollectionsFromMongo(ctx context.Context) error {
 		return nil
 	}
 	col := m.getColl("collections", "collections")
-	cur, err := col.Find(ctx, bson.D{})
+	filter, opts := m.findQuery("collections")
+	cur, err := col.Find(ctx, filter, opts)
 	if err != nil {
 		logProgress("collections collection not found or query failed; skipping")
 		return nil
@@ -389,7 +476,8 @@ func (m *Migrator) ImportCardsFromMongo(ctx context.Context) error {
 		return nil
 	}
 	col := m.getColl("cards", "cards")
-	cur, err := col.Find(ctx, bson.D{})
+	filter, opts := m.findQuery("cards")
+	cur, err := col.Find(ctx, filter, opts)
 	if err != nil {
 		logProgress("cards collection not found or query failed; skipping")
 		return nil
@@ -423,24 +511,33 @@ func (m *Migrator) ImportCardsFromMongo(ctx context.Context) error {
 
 // MigrateUsersFromMongo migrates users from live Mongo
 func (m *Migrator) MigrateUsersFromMongo(ctx context.Context) error {
-	if m.mongoDB == nil {
+	if !m.streamsFromMongo() {
 		return nil
 	}
-	col := m.mongoDB.Collection("users")
 
-	var users []MongoUser
+	batch := make([]MongoUser, 0, m.batchSize)
+	stats := userImportStats{}
+	decoded := 0
 	var lastID primitive.ObjectID
 	const pageSize int64 = 250
 	retryCount := 0
 
 	for {
-		filter := bson.D{}
+		if err := interrupted(ctx); err != nil {
+			return err
+		}
+		filter, opts := m.findQuery("users")
 		if !lastID.IsZero() {
-			filter = bson.D{{Key: "_id", Value: bson.D{{Key: "$gt", Value: lastID}}}}
+			page := bson.D{{Key: "_id", Value: bson.D{{Key: "$gt", Value: lastID}}}}
+			if len(filter) > 0 {
+				filter = bson.D{{Key: "$and", Value: bson.A{filter, page}}}
+			} else {
+				filter = page
+			}
 		}
 
 		pageCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
-		cur, err := col.Find(pageCtx, filter, options.Find().
+		cur, err := m.find(pageCtx, "users", filter, opts.
 			SetSort(bson.D{{Key: "_id", Value: 1}}).
 			SetLimit(pageSize).
 			SetBatchSize(25).
@@ -449,7 +546,7 @@ func (m *Migrator) MigrateUsersFromMongo(ctx context.Context) error {
 			cancel()
 			retryCount++
 			if retryCount <= 5 {
-				logProgress(fmt.Sprintf("Users query interrupted after %d decoded users; retrying (%d/5): %v", len(users), retryCount, err))
+				logProgress(fmt.Sprintf("Users query interrupted after %d decoded users; retrying (%d/5): %v", decoded, retryCount, err))
 				time.Sleep(time.Duration(retryCount) * 2 * time.Second)
 				continue
 			}
@@ -459,11 +556,21 @@ func (m *Migrator) MigrateUsersFromMongo(ctx context.Context) error {
 		pageCount := 0
 		for cur.Next(pageCtx) {
 			var mu MongoUser
-			if err := cur.Decode(&mu); err == nil {
-				users = append(users, mu)
-				lastID = mu.ID
-				pageCount++
-				retryCount = 0
+			if err := cur.Decode(&mu); err != nil {
+				continue
+			}
+			batch = append(batch, mu)
+			lastID = mu.ID
+			decoded++
+			pageCount++
+			retryCount = 0
+			if len(batch) >= m.batchSize {
+				if err := m.insertUserBatch(ctx, batch, &stats); err != nil {
+					cur.Close(pageCtx)
+					cancel()
+					return err
+				}
+				batch = batch[:0]
 			}
 		}
 
@@ -474,7 +581,7 @@ func (m *Migrator) MigrateUsersFromMongo(ctx context.Context) error {
 			retryCount++
 			if retryCount <= 5 {
 				logProgress(fmt.Sprintf("Users cursor interrupted after %d decoded users; retrying from _id %s (%d/5): %v",
-					len(users), lastID.Hex(), retryCount, err))
+					decoded, lastID.Hex(), retryCount, err))
 				time.Sleep(time.Duration(retryCount) * 2 * time.Second)
 				continue
 			}
@@ -484,38 +591,60 @@ func (m *Migrator) MigrateUsersFromMongo(ctx context.Context) error {
 		if pageCount == 0 {
 			break
 		}
-		logProgress(fmt.Sprintf("Read %d users from Mongo so far", len(users)))
+		logProgress(fmt.Sprintf("Read %d users from Mongo so far", decoded))
 		if int64(pageCount) < pageSize {
 			break
 		}
 	}
 
-	return m.processUsers(ctx, users)
+	if len(batch) > 0 {
+		if err := m.insertUserBatch(ctx, batch, &stats); err != nil {
+			return err
+		}
+	}
+	stats.log()
+	return nil
 }
 
 // MigrateUserCardsFromMongo migrates user cards from live Mongo
 func (m *Migrator) MigrateUserCardsFromMongo(ctx context.Context) error {
-	if m.mongoDB == nil {
+	if !m.streamsFromMongo() {
 		return nil
 	}
-	col := m.mongoDB.Collection("usercards")
-	cur, err := col.Find(ctx, bson.D{})
+
+	// Pre-flight: report referential gaps before any user card is written
+	gaps, err := m.AnalyzeUserCardGaps(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to analyze user card gaps: %w", err)
+	}
+	m.logUserCardGaps(gaps)
+
+	filter, opts := m.findQuery("usercards")
+	cur, err := m.find(ctx, "usercards", filter, opts.SetBatchSize(int32(m.batchSize)))
 	if err != nil {
 		return fmt.Errorf("failed to query usercards: %w", err)
 	}
 	defer cur.Close(ctx)
 
-	var cards []MongoUserCard
+	imp, err := m.newUserCardImporter(ctx)
+	if err != nil {
+		return err
+	}
+	defer imp.close()
+
 	for cur.Next(ctx) {
 		var mc MongoUserCard
-		if err := cur.Decode(&mc); err == nil {
-			cards = append(cards, mc)
+		if err := cur.Decode(&mc); err != nil {
+			continue
+		}
+		if err := imp.add(ctx, mc); err != nil {
+			return err
 		}
 	}
 	if err := cur.Err(); err != nil {
 		return err
 	}
-	return m.processUserCards(ctx, cards)
+	return imp.finish(ctx)
 }
 
 // MigrateClaimsFromMongo migrates claims from live Mongo
@@ -524,7 +653,8 @@ func (m *Migrator) MigrateClaimsFromMongo(ctx context.Context) error {
 		return nil
 	}
 	col := m.mongoDB.Collection("claims")
-	cur, err := col.Find(ctx, bson.D{})
+	filter, opts := m.findQuery("claims")
+	cur, err := col.Find(ctx, filter, opts)
 	if err != nil {
 		logProgress("claims collection not found; skipping")
 		return nil
@@ -562,7 +692,8 @@ func (m *Migrator) MigrateAuctionsFromMongo(ctx context.Context) error {
 		return nil
 	}
 	col := m.mongoDB.Collection("auctions")
-	cur, err := col.Find(ctx, bson.D{})
+	filter, opts := m.findQuery("auctions")
+	cur, err := col.Find(ctx, filter, opts)
 	if err != nil {
 		logProgress("auctions collection not found; skipping")
 		return nil
@@ -610,7 +741,8 @@ func (m *Migrator) MigrateUserEffectsFromMongo(ctx context.Context) error {
 		return nil
 	}
 	col := m.mongoDB.Collection("usereffects")
-	cur, err := col.Find(ctx, bson.D{})
+	filter, opts := m.findQuery("usereffects")
+	cur, err := col.Find(ctx, filter, opts)
 	if err != nil {
 		logProgress("usereffects collection not found; skipping")
 		return nil
@@ -648,7 +780,8 @@ func (m *Migrator) MigrateUserQuestsFromMongo(ctx context.Context) error {
 		return nil
 	}
 	col := m.mongoDB.Collection("userquests")
-	cur, err := col.Find(ctx, bson.D{})
+	filter, opts := m.findQuery("userquests")
+	cur, err := col.Find(ctx, filter, opts)
 	if err != nil {
 		logProgress("userquests collection not found; skipping")
 		return nil
@@ -686,7 +819,8 @@ func (m *Migrator) MigrateUserInventoriesFromMongo(ctx context.Context) error {
 		return nil
 	}
 	col := m.mongoDB.Collection("userinventories")
-	cur, err := col.Find(ctx, bson.D{})
+	filter, opts := m.findQuery("userinventories")
+	cur, err := col.Find(ctx, filter, opts)
 	if err != nil {
 		logProgress("userinventories collection not found; skipping")
 		return nil
@@ -1086,57 +1220,70 @@ func (m *Migrator) MigrateUsers(ctx context.Context) error {
 }
 
 func (m *Migrator) processUsers(ctx context.Context, mongoUsers []MongoUser) error {
-	batchSize := m.batchSize
-	discordIDUserMap := make(map[string]*models.User)
+	stats := userImportStats{}
+	for i := 0; i < len(mongoUsers); i += m.batchSize {
+		end := i + m.batchSize
+		if end > len(mongoUsers) {
+			end = len(mongoUsers)
+		}
+		if err := m.insertUserBatch(ctx, mongoUsers[i:end], &stats); err != nil {
+			return err
+		}
+	}
+	stats.log()
+	return nil
+}
+
+// userImportStats tracks totals across user batches so memory stays bounded by the batch size
+type userImportStats struct {
+	input      int
+	imported   int
+	duplicates int
+}
 
-	// Build a map of unique discord_id to User
-	duplicateUserCount := 0
+func (s userImportStats) log() {
+	logProgress(fmt.Sprintf("User migration completed: %d total input records, %d users upserted, %d duplicate Discord IDs handled",
+		s.input, s.imported, s.duplicates))
+}
+
+// insertUserBatch converts and upserts one batch of users. Duplicates inside the batch are
+// collapsed here, since one INSERT ... ON CONFLICT cannot update the same row twice;
+// duplicates across batches are resolved by the discord_id upsert, keeping the latest record.
+func (m *Migrator) insertUserBatch(ctx context.Context, mongoUsers []MongoUser, stats *userImportStats) error {
+	stats.input += len(mongoUsers)
+
+	index := make(map[string]int, len(mongoUsers))
+	users := make([]*models.User, 0, len(mongoUsers))
 	for _, mongoUser := range mongoUsers {
 		pgUser := m.convertUser(mongoUser)
-
 		if pgUser.DiscordID == "" {
 			continue // Skip if discord_id is empty
 		}
 
-		// Check for duplicates and handle them
-		if _, exists := discordIDUserMap[pgUser.DiscordID]; exists {
-			duplicateUserCount++
+		if i, exists := index[pgUser.DiscordID]; exists {
+			stats.duplicates++
 			logProgress(fmt.Sprintf("Duplicate Discord ID found: %s (keeping latest record)", pgUser.DiscordID))
+			users[i] = pgUser
+			continue
 		}
-
-		// Keep the latest occurrence (this is expected behavior for data deduplication)
-		discordIDUserMap[pgUser.DiscordID] = pgUser
+		index[pgUser.DiscordID] = len(users)
+		users = append(users, pgUser)
 	}
-
-	// Convert the map to a slice
-	var users []*models.User
-	for _, user := range discordIDUserMap {
-		users = append(users, user)
+	if len(users) == 0 {
+		return nil
 	}
 
-	// Process users in batches
-	totalUsers := len(users)
-	for i := 0; i < totalUsers; i += batchSize {
-		end := i + batchSize
-		if end > totalUsers {
-			end = totalUsers
-		}
-		batch := users[i:end]
-
-		slog.Info("Inserting batch of users",
-			"batchSize", len(batch),
-			"progress", fmt.Sprintf("%d/%d", end, totalUsers))
+	slog.Info("Inserting batch of users",
+		"batchSize", len(users),
+		"progress", stats.input)
 
-		if err := m.batchInsertUsers(ctx, batch); err != nil {
-			slog.Error("Failed to insert user batch",
-				"error", err,
-				"batchSize", len(batch))
-			return err
-		}
+	if err := m.batchInsertUsers(ctx, users); err != nil {
+		slog.Error("Failed to insert user batch",
+			"error", err,
+			"batchSize", len(users))
+		return err
 	}
-
-	logProgress(fmt.Sprintf("User migration completed: %d total input records, %d unique users imported, %d duplicate Discord IDs handled",
-		len(mongoUsers), len(users), duplicateUserCount))
+	stats.imported += len(users)
 	return nil
 }
 
@@ -1197,21 +1344,53 @@ func (m *Migrator) MigrateUserCards(ctx context.Context) error {
 }
 
 func (m *Migrator) processUserCards(ctx context.Context, mongoCards []MongoUserCard) error {
+	scanner, err := m.newUserCardGapScanner(ctx)
+	if err != nil {
+		return err
+	}
+	for _, mongoCard := range mongoCards {
+		scanner.add(mongoCard)
+	}
+	m.logUserCardGaps(scanner.result())
+
+	imp, err := m.newUserCardImporter(ctx)
+	if err != nil {
+		return err
+	}
+	defer imp.close()
+
+	for _, mongoCard := range mongoCards {
+		if err := imp.add(ctx, mongoCard); err != nil {
+			return err
+		}
+	}
+	return imp.finish(ctx)
+}
+
+// userCardImporter validates user cards against the cards table and inserts them in
+// batches of m.batchSize, so callers can feed it one document at a time
+type userCardImporter struct {
+	m               *Migrator
+	validCardIDsMap map[int64]bool
+	skipped         *skippedCardLog
+	autoFile        *os.File
+	userCards       []*models.UserCard
+	insertedCount   int
+	// runStamp is the updated_at of every row this run writes; upsertUserCards uses it to
+	// tell a pair written by an earlier batch from one left over by a previous run
+	runStamp time.Time
+}
+
+func (m *Migrator) newUserCardImporter(ctx context.Context) (*userCardImporter, error) {
 	// First, get all valid card IDs from the cards table
-	var validCardIDs []int64
-	err := m.pgDB.NewSelect().
-		Model((*models.Card)(nil)).
-		Column("id").
-		Scan(ctx, &validCardIDs)
+	validCardIDsMap, err := m.loadValidCardIDs(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get valid card IDs: %w", err)
+		return nil, err
 	}
 
-	// Create a map for O(1) lookups and calculate stats
-	validCardIDsMap := make(map[int64]bool)
+	// Calculate stats
 	var minCardID, maxCardID int64 = 999999, 0
-	for _, id := range validCardIDs {
-		validCardIDsMap[id] = true
+	for id := range validCardIDsMap {
 		if id < minCardID {
 			minCardID = id
 		}
@@ -1220,124 +1399,125 @@ func (m *Migrator) processUserCards(ctx context.Context, mongoCards []MongoUserC
 		}
 	}
 
-	logProgress(fmt.Sprintf("Cards table stats: total=%d, range=%d-%d", len(validCardIDs), minCardID, maxCardID))
+	logProgress(fmt.Sprintf("Cards table stats: total=%d, range=%d-%d", len(validCardIDsMap), minCardID, maxCardID))
 
-	// Create a file for logging skipped cards
-	skippedFile, err := os.Create("skipped_cards.log")
+	skipped, err := openSkippedCardLog(m.skippedLogPath, m.skippedLogJSON)
 	if err != nil {
-		return fmt.Errorf("failed to create skipped cards log file: %w", err)
+		return nil, err
 	}
-	defer skippedFile.Close()
 
-	// Write header to the file
-	_, err = fmt.Fprintf(skippedFile, "timestamp,user_id,card_id,reason\n")
-	if err != nil {
-		return fmt.Errorf("failed to write header to log file: %w", err)
+	imp := &userCardImporter{
+		m:               m,
+		validCardIDsMap: validCardIDsMap,
+		skipped:         skipped,
+		userCards:       make([]*models.UserCard, 0, m.batchSize),
+		runStamp:        time.Now().Truncate(time.Microsecond),
 	}
 
 	// Optional: log of auto-created cards
-	var autoFile *os.File
 	if m.autoCreateMissingCards {
 		f, ferr := os.Create("cards_autocreated.log")
 		if ferr == nil {
-			autoFile = f
-			_, _ = fmt.Fprintf(autoFile, "timestamp,card_id,action\n")
-			defer autoFile.Close()
+			imp.autoFile = f
+			_, _ = fmt.Fprintf(imp.autoFile, "timestamp,card_id,action\n")
 		}
 	}
+	return imp, nil
+}
 
-	var userCards []*models.UserCard
-	skippedCount := 0
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-
-	for _, mongoCard := range mongoCards {
-		if mongoCard.CardID == nil {
-			skippedCount++
-			_, err = fmt.Fprintf(skippedFile, "%s,%s,null,null_card_id\n",
-				timestamp, mongoCard.UserID)
-			if err != nil {
-				logProgress(fmt.Sprintf("Failed to write to log file: %v", err))
-			}
-			continue
-		}
+// add queues one user card, flushing the pending batch once it reaches the batch size
+func (imp *userCardImporter) add(ctx context.Context, mongoCard MongoUserCard) error {
+	m := imp.m
+	if mongoCard.CardID == nil {
+		return imp.skipped.record(mongoCard.UserID, nil, SkipReasonNullCardID)
+	}
 
-		cardID := int64(*mongoCard.CardID)
+	cardID := int64(*mongoCard.CardID)
 
-		// If card ID missing in cards table, try to fill from JSON; otherwise warn/skip
-		if !validCardIDsMap[cardID] {
-			if m.fillMissingFromJSON {
-				ok, jerr := m.ensureCardFromJSON(ctx, cardID)
-				if jerr != nil {
-					logProgress(fmt.Sprintf("Failed to backfill card %d from JSON: %v", cardID, jerr))
-				}
-				if ok {
-					validCardIDsMap[cardID] = true
-				} else {
-					skippedCount++
-					_, _ = fmt.Fprintf(skippedFile, "%s,%s,%d,missing_from_cards_table_and_json\n",
-						timestamp, mongoCard.UserID, cardID)
-					continue
-				}
-			} else if m.autoCreateMissingCards {
-				// fall back to placeholder mode if explicitly enabled
-				_ = m.ensureCollection(ctx, "unknown", "Unknown")
-				now := time.Now()
-				placeholder := &models.Card{ID: cardID, Name: fmt.Sprintf("Unknown Card %d", cardID), Level: 1, Animated: false, ColID: "unknown", Tags: []string{}, CreatedAt: now, UpdatedAt: now}
-				if _, ierr := m.pgDB.NewInsert().Model(placeholder).On("CONFLICT (id) DO NOTHING").Exec(ctx); ierr == nil {
-					validCardIDsMap[cardID] = true
-				} else {
-					skippedCount++
-					_, _ = fmt.Fprintf(skippedFile, "%s,%s,%d,missing_from_cards_table_autocreate_failed\n", timestamp, mongoCard.UserID, cardID)
-					continue
-				}
+	// If card ID missing in cards table, try to fill from JSON; otherwise warn/skip
+	if !imp.validCardIDsMap[cardID] {
+		if m.fillMissingFromJSON {
+			ok, jerr := m.ensureCardFromJSON(ctx, cardID)
+			if jerr != nil {
+				logProgress(fmt.Sprintf("Failed to backfill card %d from JSON: %v", cardID, jerr))
+			}
+			if ok {
+				imp.validCardIDsMap[cardID] = true
 			} else {
-				skippedCount++
-				_, _ = fmt.Fprintf(skippedFile, "%s,%s,%d,missing_from_cards_table\n", timestamp, mongoCard.UserID, cardID)
-				continue
+				return imp.skipped.record(mongoCard.UserID, &cardID, SkipReasonMissingCardAndJSON)
 			}
+		} else if m.autoCreateMissingCards {
+			// fall back to placeholder mode if explicitly enabled
+			_ = m.ensureCollection(ctx, "unknown", "Unknown")
+			now := time.Now()
+			placeholder := &models.Card{ID: cardID, Name: fmt.Sprintf("Unknown Card %d", cardID), Level: 1, Animated: false, ColID: "unknown", Tags: []string{}, CreatedAt: now, UpdatedAt: now}
+			if _, ierr := m.pgDB.NewInsert().Model(placeholder).On("CONFLICT (id) DO NOTHING").Exec(ctx); ierr == nil {
+				imp.validCardIDsMap[cardID] = true
+			} else {
+				return imp.skipped.record(mongoCard.UserID, &cardID, SkipReasonMissingAutocreateError)
+			}
+		} else {
+			return imp.skipped.record(mongoCard.UserID, &cardID, SkipReasonMissingCard)
 		}
+	}
 
-		userCards = append(userCards, &models.UserCard{
-			UserID:    mongoCard.UserID,
-			CardID:    cardID,
-			Favorite:  mongoCard.Fav,
-			Locked:    mongoCard.Locked,
-			Amount:    int64(mongoCard.Amount),
-			Rating:    int64(mongoCard.Rating),
-			Obtained:  mongoCard.Obtained,
-			Exp:       int64(mongoCard.Exp),
-			Mark:      mongoCard.Mark,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		})
+	imp.userCards = append(imp.userCards, &models.UserCard{
+		UserID:    mongoCard.UserID,
+		CardID:    cardID,
+		Favorite:  mongoCard.Fav,
+		Locked:    mongoCard.Locked,
+		Amount:    int64(mongoCard.Amount),
+		Rating:    int64(mongoCard.Rating),
+		Obtained:  mongoCard.Obtained,
+		Exp:       int64(mongoCard.Exp),
+		Mark:      mongoCard.Mark,
+		CreatedAt: time.Now(),
+		UpdatedAt: imp.runStamp,
+	})
 
-		if len(userCards) >= m.batchSize {
-			if err := m.batchInsertUserCards(ctx, userCards); err != nil {
-				return err
-			}
-			logProgress(fmt.Sprintf("Processed %d user cards, skipped %d so far", len(userCards), skippedCount))
-			userCards = userCards[:0]
-		}
+	if len(imp.userCards) >= m.batchSize {
+		return imp.flush(ctx)
 	}
+	return nil
+}
 
-	// Insert remaining user cards
-	if len(userCards) > 0 {
-		if err := m.batchInsertUserCards(ctx, userCards); err != nil {
-			return err
-		}
+func (imp *userCardImporter) flush(ctx context.Context) error {
+	if len(imp.userCards) == 0 {
+		return nil
+	}
+	if err := imp.m.batchInsertUserCards(ctx, mergeUserCardRows(imp.userCards)); err != nil {
+		return err
 	}
+	imp.insertedCount += len(imp.userCards)
+	logProgress(fmt.Sprintf("Processed %d user cards, skipped %d so far", imp.insertedCount, imp.skipped.count))
+	imp.userCards = imp.userCards[:0]
+	return nil
+}
 
-	// Write summary to log file
-	_, err = fmt.Fprintf(skippedFile, "\nSummary:\nTotal skipped: %d\nTimestamp: %s\n",
-		skippedCount, timestamp)
-	if err != nil {
-		logProgress(fmt.Sprintf("Failed to write summary to log file: %v", err))
+// finish inserts the remaining user cards and writes the skipped-cards summary
+func (imp *userCardImporter) finish(ctx context.Context) error {
+	if err := imp.flush(ctx); err != nil {
+		return err
 	}
 
-	logProgress(fmt.Sprintf("Migration completed. Skipped %d invalid/missing card IDs. Check skipped_cards.log for details", skippedCount))
+	if err := imp.skipped.finish(); err != nil {
+		return err
+	}
+	if t := imp.m.currentTable; t != nil {
+		t.Skipped += imp.skipped.count
+	}
+
+	logProgress(fmt.Sprintf("Migration completed. Skipped %d invalid/missing card IDs. Check %s for details", imp.skipped.count, imp.skipped.path))
 	return nil
 }
 
+func (imp *userCardImporter) close() {
+	_ = imp.skipped.close()
+	if imp.autoFile != nil {
+		imp.autoFile.Close()
+	}
+}
+
 // ensureCollection creates a collection row if it does not exist
 func (m *Migrator) ensureCollection(ctx context.Context, id, name string) error {
 	now := time.Now()
@@ -1356,8 +1536,12 @@ func (m *Migrator) ensureCollection(ctx context.Context, id, name string) error
 	return err
 }
 
-func (m *Migrator) batchInsertUsers(ctx context.Context, users []*models.User) error {
+func (m *Migrator) batchInsertUsers(ctx context.Context, users []*models.User) (err error) {
+	if ctx, err = batchContext(ctx); err != nil {
+		return err
+	}
 	startTime := time.Now()
+	defer m.trackBatch(startTime, len(users), &err)
 	mode := "batch"
 	if m.useCopy && m.pool != nil {
 		mode = "copy-upsert"
@@ -1373,7 +1557,7 @@ func (m *Migrator) batchInsertUsers(ctx context.Context, users []*models.User) e
 		}
 	}
 
-	_, err := m.pgDB.NewInsert().
+	_, err = m.pgDB.NewInsert().
 		Model(&users).
 		On("CONFLICT (discord_id) DO UPDATE").
 		Set("username = EXCLUDED.username").
@@ -1429,8 +1613,17 @@ func (m *Migrator) batchInsertUsers(ctx context.Context, users []*models.User) e
 	return nil
 }
 
-func (m *Migrator) batchInsertUserCards(ctx context.Context, userCards []*models.UserCard) error {
+// batchInsertUserCards upserts a batch of user cards; see upsertUserCards
+func (m *Migrator) batchInsertUserCards(ctx context.Context, userCards []*models.UserCard) (err error) {
+	if len(userCards) == 0 {
+		return nil
+	}
+	if ctx, err = batchContext(ctx); err != nil {
+		return err
+	}
 	startTime := time.Now()
+	userCards = mergeUserCardRows(userCards)
+	defer m.trackBatch(startTime, len(userCards), &err)
 	mode := "batch"
 	if m.insertSingle {
 		mode = "single"
@@ -1455,8 +1648,14 @@ func (m *Migrator) batchInsertUserCards(ctx context.Context, userCards []*models
 
 	if m.insertSingle {
 		for i, uc := range userCards {
-			if _, err := m.pgDB.NewInsert().Model(uc).Exec(ctx); err != nil {
+			if _, err := upsertUserCards(m.pgDB.NewInsert().Model(uc)).Exec(ctx); err != nil {
 				logProgress(fmt.Sprintf("Insert user card %d/%d failed: %v", i+1, len(userCards), err))
+				if m.deadLetterEnabled() && !isTimeoutErr(err) {
+					if dlErr := m.deadLetter("user_cards", uc, err); dlErr != nil {
+						return dlErr
+					}
+					continue
+				}
 				return fmt.Errorf("failed to insert user card: %w", err)
 			}
 			if m.sleepBetween > 0 {
@@ -1474,13 +1673,67 @@ func (m *Migrator) batchInsertUserCards(ctx context.Context, userCards []*models
 	return nil
 }
 
+// upsertUserCards makes a user_cards insert merge into the existing (user_id, card_id) row.
+// Every row of a run shares one updated_at, so a row already carrying it was written by an
+// earlier batch of the same run and is added to the way mergeUserCardRows merges within a
+// batch; the result doesn't depend on where batches split. Rows from a previous run are
+// overwritten, so re-running a migration stays idempotent.
+func upsertUserCards(q *bun.InsertQuery) *bun.InsertQuery {
+	return q.On("CONFLICT (user_id, card_id) DO UPDATE").
+		Set("level = EXCLUDED.level").
+		Set(sameRunUserCard("exp", "?TableAlias.exp + EXCLUDED.exp", "EXCLUDED.exp")).
+		Set(sameRunUserCard("amount", "?TableAlias.amount + EXCLUDED.amount", "EXCLUDED.amount")).
+		Set(sameRunUserCard("favorite", "?TableAlias.favorite OR EXCLUDED.favorite", "EXCLUDED.favorite")).
+		Set(sameRunUserCard("locked", "?TableAlias.locked OR EXCLUDED.locked", "EXCLUDED.locked")).
+		Set(sameRunUserCard("rating", "?TableAlias.rating", "EXCLUDED.rating")).
+		Set(sameRunUserCard("obtained", "LEAST(?TableAlias.obtained, EXCLUDED.obtained)", "EXCLUDED.obtained")).
+		Set(sameRunUserCard("mark", "?TableAlias.mark", "EXCLUDED.mark")).
+		Set("updated_at = EXCLUDED.updated_at")
+}
+
+// sameRunUserCard returns a SET clause for column that takes merged when the existing row
+// was written by the same run and overwrite otherwise
+func sameRunUserCard(column, merged, overwrite string) string {
+	return fmt.Sprintf("%s = CASE WHEN ?TableAlias.updated_at = EXCLUDED.updated_at THEN %s ELSE %s END", column, merged, overwrite)
+}
+
+type userCardKey struct {
+	userID string
+	cardID int64
+}
+
+// mergeUserCardRows collapses rows for the same user and card within a batch, since a
+// single INSERT ... ON CONFLICT cannot update the same row twice
+func mergeUserCardRows(rows []*models.UserCard) []*models.UserCard {
+	seen := make(map[userCardKey]*models.UserCard, len(rows))
+	merged := make([]*models.UserCard, 0, len(rows))
+	for _, r := range rows {
+		k := userCardKey{r.UserID, r.CardID}
+		existing, ok := seen[k]
+		if !ok {
+			seen[k] = r
+			merged = append(merged, r)
+			continue
+		}
+		existing.Amount += r.Amount
+		existing.Exp += r.Exp
+		existing.Favorite = existing.Favorite || r.Favorite
+		existing.Locked = existing.Locked || r.Locked
+		if r.Obtained.Before(existing.Obtained) {
+			existing.Obtained = r.Obtained
+		}
+	}
+	return merged
+}
+
 func (m *Migrator) tryInsertUserCards(ctx context.Context, userCards []*models.UserCard) error {
-	if _, err := m.pgDB.NewInsert().Model(&userCards).Exec(ctx); err != nil {
-		if isTimeoutErr(err) && len(userCards) > 1 {
+	if _, err := upsertUserCards(m.pgDB.NewInsert().Model(&userCards)).Exec(ctx); err != nil {
+		// With a dead-letter file, keep halving failing batches until the bad rows are isolated
+		if (isTimeoutErr(err) || m.deadLetterEnabled()) && len(userCards) > 1 {
 			mid := len(userCards) / 2
 			left := userCards[:mid]
 			right := userCards[mid:]
-			logProgress(fmt.Sprintf("Batch insert timeout. Splitting into %d and %d", len(left), len(right)))
+			logProgress(fmt.Sprintf("Batch insert failed (%v). Splitting into %d and %d", err, len(left), len(right)))
 			if err := m.tryInsertUserCards(ctx, left); err != nil {
 				return err
 			}
@@ -1489,6 +1742,9 @@ func (m *Migrator) tryInsertUserCards(ctx context.Context, userCards []*models.U
 			}
 			return nil
 		}
+		if m.deadLetterEnabled() && !isTimeoutErr(err) && len(userCards) == 1 {
+			return m.deadLetter("user_cards", userCards[0], err)
+		}
 		logProgress(fmt.Sprintf("Batch insert failed: %v", err))
 		return fmt.Errorf("failed to insert user cards batch: %w", err)
 	}
@@ -1524,6 +1780,13 @@ func (m *Migrator) copyUpsertUsers(ctx context.Context, rows []*models.User) err
 	}
 	defer conn.Release()
 
+	// The temp table is dropped on commit, so it must share a transaction with the COPY and upsert
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	createSQL := `CREATE TEMP TABLE tmp_users (
         discord_id TEXT PRIMARY KEY,
         username TEXT,
@@ -1559,7 +1822,7 @@ func (m *Migrator) copyUpsertUsers(ctx context.Context, rows []*models.User) err
         premium_expires TIMESTAMP,
         updated_at TIMESTAMP
     ) ON COMMIT DROP;`
-	if _, err := conn.Exec(ctx, createSQL); err != nil {
+	if _, err := tx.Exec(ctx, createSQL); err != nil {
 		return fmt.Errorf("failed to create temp table: %w", err)
 	}
 
@@ -1590,7 +1853,7 @@ func (m *Migrator) copyUpsertUsers(ctx context.Context, rows []*models.User) err
 		})
 	}
 	cols := []string{"discord_id", "username", "user_stats", "promo_exp", "joined", "last_queried_card", "last_kofi_claim", "daily_stats", "effect_stats", "cards", "inventory", "completed_cols", "clouted_cols", "achievements", "effects", "wishlist", "preferences", "last_daily", "last_train", "last_work", "last_vote", "last_announce", "last_msg", "hero_slots", "hero_cooldown", "hero", "hero_changed", "hero_submits", "roles", "ban", "premium", "premium_expires", "updated_at"}
-	if _, err := conn.Conn().CopyFrom(ctx, pgx.Identifier{"tmp_users"}, cols, pgx.CopyFromRows(data)); err != nil {
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"tmp_users"}, cols, pgx.CopyFromRows(data)); err != nil {
 		return fmt.Errorf("copy to temp failed: %w", err)
 	}
 
@@ -1637,13 +1900,15 @@ func (m *Migrator) copyUpsertUsers(ctx context.Context, rows []*models.User) err
         premium = EXCLUDED.premium,
         premium_expires = EXCLUDED.premium_expires,
         updated_at = EXCLUDED.updated_at;`
-	if _, err := conn.Exec(ctx, upsertSQL); err != nil {
+	if _, err := tx.Exec(ctx, upsertSQL); err != nil {
 		return fmt.Errorf("users upsert from temp failed: %w", err)
 	}
-	return nil
+	return tx.Commit(ctx)
 }
 
-// copyInsertUserCards performs a fast COPY FROM for user_cards
+// copyInsertUserCards performs COPY into a temp table, then upserts into user_cards the
+// way upsertUserCards does. A plain COPY would fail on user_cards_user_card_unique for
+// rows that already exist.
 func (m *Migrator) copyInsertUserCards(ctx context.Context, rows []*models.UserCard) error {
 	if m.pool == nil {
 		return fmt.Errorf("pgx pool not configured for COPY")
@@ -1654,6 +1919,31 @@ func (m *Migrator) copyInsertUserCards(ctx context.Context, rows []*models.UserC
 	}
 	defer conn.Release()
 
+	// The temp table is dropped on commit, so it must share a transaction with the COPY and upsert
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	createSQL := `CREATE TEMP TABLE tmp_user_cards (
+        user_id TEXT,
+        card_id BIGINT,
+        level INT,
+        exp BIGINT,
+        amount BIGINT,
+        favorite BOOLEAN,
+        locked BOOLEAN,
+        rating BIGINT,
+        obtained TIMESTAMP,
+        mark TEXT,
+        created_at TIMESTAMP,
+        updated_at TIMESTAMP
+    ) ON COMMIT DROP;`
+	if _, err := tx.Exec(ctx, createSQL); err != nil {
+		return fmt.Errorf("failed to create temp table: %w", err)
+	}
+
 	// Map rows to [][]any for CopyFromRows
 	data := make([][]any, 0, len(rows))
 	for _, r := range rows {
@@ -1677,12 +1967,29 @@ func (m *Migrator) copyInsertUserCards(ctx context.Context, rows []*models.UserC
 		"user_id", "card_id", "level", "exp", "amount", "favorite", "locked", "rating", "obtained", "mark", "created_at", "updated_at",
 	}
 
-	// COPY FROM user_cards (cols...)
-	_, err = conn.Conn().CopyFrom(ctx, pgx.Identifier{"user_cards"}, columns, pgx.CopyFromRows(data))
-	if err != nil {
-		return fmt.Errorf("copy from failed: %w", err)
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"tmp_user_cards"}, columns, pgx.CopyFromRows(data)); err != nil {
+		return fmt.Errorf("copy to temp failed: %w", err)
 	}
-	return nil
+
+	upsertSQL := `INSERT INTO user_cards (
+        user_id, card_id, level, exp, amount, favorite, locked, rating, obtained, mark, created_at, updated_at
+    )
+    SELECT user_id, card_id, level, exp, amount, favorite, locked, rating, obtained, mark, created_at, updated_at
+    FROM tmp_user_cards
+    ON CONFLICT (user_id, card_id) DO UPDATE SET
+        level = EXCLUDED.level,
+        exp = CASE WHEN user_cards.updated_at = EXCLUDED.updated_at THEN user_cards.exp + EXCLUDED.exp ELSE EXCLUDED.exp END,
+        amount = CASE WHEN user_cards.updated_at = EXCLUDED.updated_at THEN user_cards.amount + EXCLUDED.amount ELSE EXCLUDED.amount END,
+        favorite = CASE WHEN user_cards.updated_at = EXCLUDED.updated_at THEN user_cards.favorite OR EXCLUDED.favorite ELSE EXCLUDED.favorite END,
+        locked = CASE WHEN user_cards.updated_at = EXCLUDED.updated_at THEN user_cards.locked OR EXCLUDED.locked ELSE EXCLUDED.locked END,
+        rating = CASE WHEN user_cards.updated_at = EXCLUDED.updated_at THEN user_cards.rating ELSE EXCLUDED.rating END,
+        obtained = CASE WHEN user_cards.updated_at = EXCLUDED.updated_at THEN LEAST(user_cards.obtained, EXCLUDED.obtained) ELSE EXCLUDED.obtained END,
+        mark = CASE WHEN user_cards.updated_at = EXCLUDED.updated_at THEN user_cards.mark ELSE EXCLUDED.mark END,
+        updated_at = EXCLUDED.updated_at;`
+	if _, err := tx.Exec(ctx, upsertSQL); err != nil {
+		return fmt.Errorf("user_cards upsert from temp failed: %w", err)
+	}
+	return tx.Commit(ctx)
 }
 
 // logProgress logs progress messages following existing pattern
@@ -1750,6 +2057,9 @@ func (m *Migrator) processBSONFile(filePath string, processDoc func([]byte) erro
 		fullDocBytes := append(lengthBytes, docBytes...)
 
 		if err := processDoc(fullDocBytes); err != nil {
+			if errors.Is(err, ErrInterrupted) {
+				return err
+			}
 			logProgress(fmt.Sprintf("Warning: failed to process document %d at byte %d: %v", docCount+1, bytesRead-int64(length), err))
 			// Continue processing instead of failing completely
 			continue
@@ -2120,7 +2430,12 @@ func (m *Migrator) MigrateUserInventories(ctx context.Context) error {
 
 // Batch insert helper functions following existing patterns
 
-func (m *Migrator) batchInsertCollections(ctx context.Context, collections []*models.Collection) error {
+func (m *Migrator) batchInsertCollections(ctx context.Context, collections []*models.Collection) (err error) {
+	if ctx, err = batchContext(ctx); err != nil {
+		return err
+	}
+	defer m.trackBatch(time.Now(), len(collections), &err)
+
 	if m.useCopy && m.pool != nil {
 		conn, err := m.pool.Acquire(ctx)
 		if err == nil {
@@ -2137,11 +2452,16 @@ func (m *Migrator) batchInsertCollections(ctx context.Context, collections []*mo
 			}
 		}
 	}
-	_, err := m.pgDB.NewInsert().Model(&collections).On("CONFLICT (id) DO UPDATE").Set("name = EXCLUDED.name").Set("origin = EXCLUDED.origin").Set("aliases = EXCLUDED.aliases").Set("promo = EXCLUDED.promo").Set("compressed = EXCLUDED.compressed").Set("fragments = EXCLUDED.fragments").Set("tags = EXCLUDED.tags").Set("updated_at = EXCLUDED.updated_at").Exec(ctx)
+	_, err = m.pgDB.NewInsert().Model(&collections).On("CONFLICT (id) DO UPDATE").Set("name = EXCLUDED.name").Set("origin = EXCLUDED.origin").Set("aliases = EXCLUDED.aliases").Set("promo = EXCLUDED.promo").Set("compressed = EXCLUDED.compressed").Set("fragments = EXCLUDED.fragments").Set("tags = EXCLUDED.tags").Set("updated_at = EXCLUDED.updated_at").Exec(ctx)
 	return err
 }
 
-func (m *Migrator) batchInsertCards(ctx context.Context, cards []*models.Card) error {
+func (m *Migrator) batchInsertCards(ctx context.Context, cards []*models.Card) (err error) {
+	if ctx, err = batchContext(ctx); err != nil {
+		return err
+	}
+	defer m.trackBatch(time.Now(), len(cards), &err)
+
 	if m.useCopy && m.pool != nil {
 		conn, err := m.pool.Acquire(ctx)
 		if err == nil {
@@ -2157,49 +2477,159 @@ func (m *Migrator) batchInsertCards(ctx context.Context, cards []*models.Card) e
 			}
 		}
 	}
-	_, err := m.pgDB.NewInsert().Model(&cards).On("CONFLICT (id) DO UPDATE").Set("name = EXCLUDED.name").Set("level = EXCLUDED.level").Set("animated = EXCLUDED.animated").Set("col_id = EXCLUDED.col_id").Set("tags = EXCLUDED.tags").Set("updated_at = EXCLUDED.updated_at").Exec(ctx)
+	_, err = m.pgDB.NewInsert().Model(&cards).On("CONFLICT (id) DO UPDATE").Set("name = EXCLUDED.name").Set("level = EXCLUDED.level").Set("animated = EXCLUDED.animated").Set("col_id = EXCLUDED.col_id").Set("tags = EXCLUDED.tags").Set("updated_at = EXCLUDED.updated_at").Exec(ctx)
 	return err
 }
 
-func (m *Migrator) batchInsertClaims(ctx context.Context, claims []*models.Claim) error {
+func (m *Migrator) batchInsertClaims(ctx context.Context, claims []*models.Claim) (err error) {
+	if ctx, err = batchContext(ctx); err != nil {
+		return err
+	}
+	defer m.trackBatch(time.Now(), len(claims), &err)
+
 	if m.useCopy && m.pool != nil {
-		conn, err := m.pool.Acquire(ctx)
-		if err == nil {
-			defer conn.Release()
-			rows := make([][]any, 0, len(claims))
-			for _, c := range claims {
-				rows = append(rows, []any{c.CardID, c.UserID, c.ClaimedAt, c.Expires})
-			}
-			cols := []string{"card_id", "user_id", "claimed_at", "expires"}
-			if _, err = conn.Conn().CopyFrom(ctx, pgx.Identifier{"claims"}, cols, pgx.CopyFromRows(rows)); err == nil {
-				return nil
-			}
+		rows := make([][]any, 0, len(claims))
+		for _, c := range claims {
+			rows = append(rows, []any{c.CardID, c.UserID, c.ClaimedAt, c.Expires})
+		}
+		cols := []string{"card_id", "user_id", "claimed_at", "expires"}
+		// Claims have no natural key, so a claim already migrated is matched on who claimed which card when
+		upsertSQL := `INSERT INTO claims (card_id, user_id, claimed_at, expires)
+    SELECT DISTINCT ON (t.user_id, t.card_id, t.claimed_at) t.card_id, t.user_id, t.claimed_at, t.expires
+    FROM tmp_claims t
+    WHERE NOT EXISTS (
+        SELECT 1 FROM claims c
+        WHERE c.user_id = t.user_id AND c.card_id = t.card_id AND c.claimed_at = t.claimed_at
+    );`
+		if err := m.copyUpsertViaTemp(ctx, "claims", cols, rows, upsertSQL); err == nil {
+			return nil
+		} else {
+			slog.Warn("Claims COPY path failed; falling back to standard insert", "error", err)
 		}
 	}
-	_, err := m.pgDB.NewInsert().Model(&claims).Exec(ctx)
+	fresh, err := m.claimsNotMigrated(ctx, claims)
+	if err != nil || len(fresh) == 0 {
+		return err
+	}
+	_, err = m.pgDB.NewInsert().Model(&fresh).Exec(ctx)
 	return err
 }
 
-func (m *Migrator) batchInsertAuctions(ctx context.Context, auctions []*models.Auction) error {
+// claimsNotMigrated drops the claims that are already in the claims table or repeated in the
+// batch, matching them on who claimed which card when like the COPY path does
+func (m *Migrator) claimsNotMigrated(ctx context.Context, claims []*models.Claim) ([]*models.Claim, error) {
+	type claimKey struct {
+		userID    string
+		cardID    int64
+		claimedAt int64
+	}
+	keyOf := func(c *models.Claim) claimKey {
+		return claimKey{c.UserID, c.CardID, c.ClaimedAt.Truncate(time.Microsecond).UnixMicro()}
+	}
+
+	userIDs := make([]string, 0, len(claims))
+	for _, c := range claims {
+		userIDs = append(userIDs, c.UserID)
+	}
+	var existing []*models.Claim
+	if err := m.pgDB.NewSelect().
+		Model(&existing).
+		Column("user_id", "card_id", "claimed_at").
+		Where("user_id IN (?)", bun.In(userIDs)).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load existing claims: %w", err)
+	}
+
+	seen := make(map[claimKey]bool, len(existing)+len(claims))
+	for _, c := range existing {
+		seen[keyOf(c)] = true
+	}
+	fresh := make([]*models.Claim, 0, len(claims))
+	for _, c := range claims {
+		if k := keyOf(c); !seen[k] {
+			seen[k] = true
+			fresh = append(fresh, c)
+		}
+	}
+	return fresh, nil
+}
+
+func (m *Migrator) batchInsertAuctions(ctx context.Context, auctions []*models.Auction) (err error) {
+	if ctx, err = batchContext(ctx); err != nil {
+		return err
+	}
+	defer m.trackBatch(time.Now(), len(auctions), &err)
+
 	if m.useCopy && m.pool != nil {
-		conn, err := m.pool.Acquire(ctx)
-		if err == nil {
-			defer conn.Release()
-			rows := make([][]any, 0, len(auctions))
-			for _, a := range auctions {
-				rows = append(rows, []any{a.AuctionID, a.CardID, a.SellerID, a.StartPrice, a.CurrentPrice, a.MinIncrement, a.TopBidderID, a.PreviousBidderID, a.PreviousBidAmount, string(a.Status), a.StartTime, a.EndTime, a.MessageID, a.ChannelID, a.LastBidTime, a.BidCount, a.CreatedAt, a.UpdatedAt})
-			}
-			cols := []string{"auction_id", "card_id", "seller_id", "start_price", "current_price", "min_increment", "top_bidder_id", "previous_bidder_id", "previous_bid_amount", "status", "start_time", "end_time", "message_id", "channel_id", "last_bid_time", "bid_count", "created_at", "updated_at"}
-			if _, err = conn.Conn().CopyFrom(ctx, pgx.Identifier{"auctions"}, cols, pgx.CopyFromRows(rows)); err == nil {
-				return nil
-			}
+		rows := make([][]any, 0, len(auctions))
+		for _, a := range auctions {
+			rows = append(rows, []any{a.AuctionID, a.CardID, a.SellerID, a.StartPrice, a.CurrentPrice, a.MinIncrement, a.TopBidderID, a.PreviousBidderID, a.PreviousBidAmount, string(a.Status), a.StartTime, a.EndTime, a.MessageID, a.ChannelID, a.LastBidTime, a.BidCount, a.CreatedAt, a.UpdatedAt})
+		}
+		cols := []string{"auction_id", "card_id", "seller_id", "start_price", "current_price", "min_increment", "top_bidder_id", "previous_bidder_id", "previous_bid_amount", "status", "start_time", "end_time", "message_id", "channel_id", "last_bid_time", "bid_count", "created_at", "updated_at"}
+		colList := strings.Join(cols, ", ")
+		upsertSQL := `INSERT INTO auctions (` + colList + `)
+    SELECT DISTINCT ON (auction_id) ` + colList + `
+    FROM tmp_auctions
+    ORDER BY auction_id, updated_at DESC
+    ON CONFLICT (auction_id) DO UPDATE SET
+        card_id = EXCLUDED.card_id,
+        seller_id = EXCLUDED.seller_id,
+        start_price = EXCLUDED.start_price,
+        current_price = EXCLUDED.current_price,
+        status = EXCLUDED.status,
+        end_time = EXCLUDED.end_time,
+        updated_at = EXCLUDED.updated_at;`
+		if err := m.copyUpsertViaTemp(ctx, "auctions", cols, rows, upsertSQL); err == nil {
+			return nil
+		} else {
+			slog.Warn("Auctions COPY path failed; falling back to standard upsert", "error", err)
 		}
 	}
-	_, err := m.pgDB.NewInsert().Model(&auctions).On("CONFLICT (auction_id) DO UPDATE").Set("card_id = EXCLUDED.card_id").Set("seller_id = EXCLUDED.seller_id").Set("start_price = EXCLUDED.start_price").Set("current_price = EXCLUDED.current_price").Set("status = EXCLUDED.status").Set("end_time = EXCLUDED.end_time").Set("updated_at = EXCLUDED.updated_at").Exec(ctx)
+	_, err = m.pgDB.NewInsert().Model(&auctions).On("CONFLICT (auction_id) DO UPDATE").Set("card_id = EXCLUDED.card_id").Set("seller_id = EXCLUDED.seller_id").Set("start_price = EXCLUDED.start_price").Set("current_price = EXCLUDED.current_price").Set("status = EXCLUDED.status").Set("end_time = EXCLUDED.end_time").Set("updated_at = EXCLUDED.updated_at").Exec(ctx)
 	return err
 }
 
-func (m *Migrator) batchInsertAuctionBids(ctx context.Context, auctionBids []*models.AuctionBid) error {
+// copyUpsertViaTemp COPYs rows into a temp table with the target's column types and then runs
+// upsertSQL to move them into the real table. Plain COPY cannot express ON CONFLICT, so this keeps
+// re-runs with --use-copy idempotent. Everything runs in one transaction because the temp table is
+// dropped on commit.
+func (m *Migrator) copyUpsertViaTemp(ctx context.Context, table string, cols []string, rows [][]any, upsertSQL string) error {
+	if m.pool == nil {
+		return fmt.Errorf("pgx pool not configured")
+	}
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tmp := "tmp_" + table
+	createSQL := fmt.Sprintf("CREATE TEMP TABLE %s ON COMMIT DROP AS SELECT %s FROM %s WITH NO DATA",
+		tmp, strings.Join(cols, ", "), table)
+	if _, err := tx.Exec(ctx, createSQL); err != nil {
+		return fmt.Errorf("failed to create temp table: %w", err)
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{tmp}, cols, pgx.CopyFromRows(rows)); err != nil {
+		return fmt.Errorf("copy to temp failed: %w", err)
+	}
+	if _, err := tx.Exec(ctx, upsertSQL); err != nil {
+		return fmt.Errorf("%s upsert from temp failed: %w", table, err)
+	}
+	return tx.Commit(ctx)
+}
+
+func (m *Migrator) batchInsertAuctionBids(ctx context.Context, auctionBids []*models.AuctionBid) (err error) {
+	if ctx, err = batchContext(ctx); err != nil {
+		return err
+	}
+	defer m.trackBatch(time.Now(), len(auctionBids), &err)
+
 	if m.useCopy && m.pool != nil {
 		conn, err := m.pool.Acquire(ctx)
 		if err == nil {
@@ -2214,11 +2644,16 @@ func (m *Migrator) batchInsertAuctionBids(ctx context.Context, auctionBids []*mo
 			}
 		}
 	}
-	_, err := m.pgDB.NewInsert().Model(&auctionBids).Exec(ctx)
+	_, err = m.pgDB.NewInsert().Model(&auctionBids).Exec(ctx)
 	return err
 }
 
-func (m *Migrator) batchInsertUserEffects(ctx context.Context, userEffects []*models.UserEffect) error {
+func (m *Migrator) batchInsertUserEffects(ctx context.Context, userEffects []*models.UserEffect) (err error) {
+	if ctx, err = batchContext(ctx); err != nil {
+		return err
+	}
+	defer m.trackBatch(time.Now(), len(userEffects), &err)
+
 	if m.useCopy && m.pool != nil {
 		conn, err := m.pool.Acquire(ctx)
 		if err == nil {
@@ -2235,11 +2670,16 @@ func (m *Migrator) batchInsertUserEffects(ctx context.Context, userEffects []*mo
 			}
 		}
 	}
-	_, err := m.pgDB.NewInsert().Model(&userEffects).Exec(ctx)
+	_, err = m.pgDB.NewInsert().Model(&userEffects).Exec(ctx)
 	return err
 }
 
-func (m *Migrator) batchInsertUserQuests(ctx context.Context, userQuests []*models.UserQuest) error {
+func (m *Migrator) batchInsertUserQuests(ctx context.Context, userQuests []*models.UserQuest) (err error) {
+	if ctx, err = batchContext(ctx); err != nil {
+		return err
+	}
+	defer m.trackBatch(time.Now(), len(userQuests), &err)
+
 	if m.useCopy && m.pool != nil {
 		conn, err := m.pool.Acquire(ctx)
 		if err == nil {
@@ -2254,11 +2694,16 @@ func (m *Migrator) batchInsertUserQuests(ctx context.Context, userQuests []*mode
 			}
 		}
 	}
-	_, err := m.pgDB.NewInsert().Model(&userQuests).Exec(ctx)
+	_, err = m.pgDB.NewInsert().Model(&userQuests).Exec(ctx)
 	return err
 }
 
-func (m *Migrator) batchInsertUserRecipes(ctx context.Context, userRecipes []*models.UserRecipe) error {
+func (m *Migrator) batchInsertUserRecipes(ctx context.Context, userRecipes []*models.UserRecipe) (err error) {
+	if ctx, err = batchContext(ctx); err != nil {
+		return err
+	}
+	defer m.trackBatch(time.Now(), len(userRecipes), &err)
+
 	if m.useCopy && m.pool != nil {
 		conn, err := m.pool.Acquire(ctx)
 		if err == nil {
@@ -2273,10 +2718,77 @@ func (m *Migrator) batchInsertUserRecipes(ctx context.Context, userRecipes []*mo
 			}
 		}
 	}
-	_, err := m.pgDB.NewInsert().Model(&userRecipes).Exec(ctx)
+	_, err = m.pgDB.NewInsert().Model(&userRecipes).Exec(ctx)
 	return err
 }
 
+// stepFailed wraps a step error, writing a checkpoint first when the migration was interrupted
+func (m *Migrator) stepFailed(ctx context.Context, source string, completed []string, step string, err error) error {
+	if ctx.Err() == nil && !errors.Is(err, ErrInterrupted) {
+		return fmt.Errorf("migration failed at step %s: %w", step, err)
+	}
+	if cerr := m.writeCheckpoint(source, completed, step); cerr != nil {
+		slog.Error("Failed to write migration checkpoint", "error", cerr)
+	}
+	if !errors.Is(err, ErrInterrupted) {
+		// e.g. a Mongo cursor failing with the cancelled context
+		err = fmt.Errorf("%w: %w", ErrInterrupted, err)
+	}
+	return fmt.Errorf("migration stopped at step %s: %w", step, err)
+}
+
+// beginTable starts timing a migration step; batch inserts made until endTable are attributed to it
+func (m *Migrator) beginTable(name string) {
+	if m.stats.Tables == nil {
+		m.stats.Tables = make(map[string]*TableStats)
+	}
+	t := &TableStats{TableName: name, StartTime: time.Now()}
+	m.stats.Tables[name] = t
+	m.currentTable = t
+}
+
+// endTable stops timing the current step and derives its throughput
+func (m *Migrator) endTable() {
+	t := m.currentTable
+	if t == nil {
+		return
+	}
+	m.currentTable = nil
+
+	t.EndTime = time.Now()
+	elapsed := t.EndTime.Sub(t.StartTime)
+	t.DurationMs = elapsed.Milliseconds()
+	if elapsed > 0 {
+		t.RowsPerSecond = float64(t.Successful) / elapsed.Seconds()
+	}
+}
+
+// trackBatch records a finished batch insert against the current step. It is meant to be
+// deferred with the batch start time and a pointer to the insert's named error result.
+func (m *Migrator) trackBatch(start time.Time, rows int, err *error) {
+	t := m.currentTable
+	if t == nil {
+		return
+	}
+
+	t.Processed += rows
+	if *err != nil {
+		t.Errors++
+		return
+	}
+	t.Successful += rows
+	t.Batches++
+
+	took := time.Since(start).Milliseconds()
+	if took > t.PeakBatchMs {
+		t.PeakBatchMs = took
+	}
+	if took > m.stats.PeakBatchMs {
+		m.stats.PeakBatchMs = took
+		m.stats.PeakBatchTable = t.TableName
+	}
+}
+
 // generateMigrationReport creates a detailed JSON report of the migration
 func (m *Migrator) generateMigrationReport() error {
 	timestamp := time.Now().Format("20060102_150405")
@@ -2289,14 +2801,17 @@ func (m *Migrator) generateMigrationReport() error {
 	defer file.Close()
 
 	// Calculate final totals
+	m.stats.WallClockMs = m.stats.EndTime.Sub(m.stats.StartTime).Milliseconds()
 	m.stats.TotalProcessed = 0
 	m.stats.TotalSkipped = 0
 	m.stats.TotalErrors = 0
+	m.stats.TotalDeadLettered = 0
 
 	for _, tableStats := range m.stats.Tables {
 		m.stats.TotalProcessed += tableStats.Processed
 		m.stats.TotalSkipped += tableStats.Skipped
 		m.stats.TotalErrors += tableStats.Errors
+		m.stats.TotalDeadLettered += tableStats.DeadLettered
 	}
 
 	// Write JSON report
@@ -2316,9 +2831,15 @@ func (m *Migrator) logFinalStats() {
 
 	slog.Info("Migration completed",
 		"duration", duration,
+		"peak_batch_ms", m.stats.PeakBatchMs,
+		"peak_batch_table", m.stats.PeakBatchTable,
 		"total_processed", m.stats.TotalProcessed,
 		"total_skipped", m.stats.TotalSkipped,
-		"total_errors", m.stats.TotalErrors)
+		"total_errors", m.stats.TotalErrors,
+		"total_dead_lettered", m.stats.TotalDeadLettered)
+	if m.stats.TotalDeadLettered > 0 {
+		logProgress(fmt.Sprintf("%d rows were dead-lettered to %s; fix and re-import them separately", m.stats.TotalDeadLettered, m.deadLetterPath))
+	}
 
 	// Log table-specific stats
 	for tableName, stats := range m.stats.Tables {
@@ -2327,6 +2848,11 @@ func (m *Migrator) logFinalStats() {
 			"processed", stats.Processed,
 			"successful", stats.Successful,
 			"skipped", stats.Skipped,
-			"errors", stats.Errors)
+			"errors", stats.Errors,
+			"dead_lettered", stats.DeadLettered,
+			"duration_ms", stats.DurationMs,
+			"rows_per_second", fmt.Sprintf("%.1f", stats.RowsPerSecond),
+			"batches", stats.Batches,
+			"peak_batch_ms", stats.PeakBatchMs)
 	}
 }
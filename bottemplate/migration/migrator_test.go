@@ -0,0 +1,380 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database"
+	"github.com/disgoorg/bot-template/bottemplate/database/dbtest"
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMergeUserCardRows(t *testing.T) {
+	early := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(48 * time.Hour)
+	rows := []*models.UserCard{
+		{UserID: "u1", CardID: 1, Amount: 1, Exp: 10, Obtained: late},
+		{UserID: "u2", CardID: 1, Amount: 1, Obtained: late},
+		{UserID: "u1", CardID: 1, Amount: 2, Exp: 5, Favorite: true, Obtained: early},
+		{UserID: "u1", CardID: 2, Amount: 1, Obtained: late},
+		{UserID: "u1", CardID: 1, Amount: 1, Locked: true, Obtained: late},
+	}
+
+	merged := mergeUserCardRows(rows)
+	if len(merged) != 3 {
+		t.Fatalf("merged into %d rows, want 3", len(merged))
+	}
+
+	got := merged[0]
+	if got.UserID != "u1" || got.CardID != 1 {
+		t.Fatalf("first row = %s/%d, want the first occurrence u1/1", got.UserID, got.CardID)
+	}
+	if got.Amount != 4 || got.Exp != 15 || !got.Favorite || !got.Locked || !got.Obtained.Equal(early) {
+		t.Errorf("merged u1/1 = %+v", got)
+	}
+	if merged[1].UserID != "u2" || merged[2].CardID != 2 {
+		t.Errorf("order not preserved: %s/%d then %s/%d", merged[1].UserID, merged[1].CardID, merged[2].UserID, merged[2].CardID)
+	}
+}
+
+func TestUpsertUserCardsIsIdempotent(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	m := &Migrator{pgDB: db.BunDB()}
+
+	batch := func(amount int64) []*models.UserCard {
+		now := time.Now()
+		return mergeUserCardRows([]*models.UserCard{
+			{UserID: "u1", CardID: 1, Level: 1, Amount: amount, Obtained: now, CreatedAt: now, UpdatedAt: now},
+			{UserID: "u1", CardID: 1, Level: 1, Amount: 1, Obtained: now, CreatedAt: now, UpdatedAt: now},
+			{UserID: "u2", CardID: 1, Level: 1, Amount: 1, Obtained: now, CreatedAt: now, UpdatedAt: now},
+		})
+	}
+
+	for run := 1; run <= 2; run++ {
+		if err := m.tryInsertUserCards(ctx, batch(2)); err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+	}
+
+	var cards []*models.UserCard
+	if err := db.BunDB().NewSelect().Model(&cards).Order("user_id").Scan(ctx); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if len(cards) != 2 {
+		t.Fatalf("%d rows after re-running the migration, want 2", len(cards))
+	}
+	// The second run overwrites instead of adding to the first
+	if cards[0].Amount != 3 || cards[1].Amount != 1 {
+		t.Errorf("amounts = %d, %d, want 3 and 1", cards[0].Amount, cards[1].Amount)
+	}
+}
+
+// batchMigrator returns a migrator writing to the test database in batches of two
+func batchMigrator(t *testing.T, db *database.DB) *Migrator {
+	t.Helper()
+	dir := t.TempDir()
+	m := NewMigrator(db.BunDB(), dir)
+	m.SetBatchSize(2)
+	m.fillMissingFromJSON = false
+	m.SetSkippedLogPath(filepath.Join(dir, "skipped_cards.log"))
+	return m
+}
+
+func TestProcessUsersAcrossBatches(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	m := batchMigrator(t, db)
+
+	// Five records in three batches: u1 repeats inside the first batch and u2 across batches
+	users := []MongoUser{
+		{DiscordID: "u1", Username: "first"},
+		{DiscordID: "u1", Username: "second"},
+		{DiscordID: "u2", Username: "old"},
+		{DiscordID: "u3", Username: "three"},
+		{DiscordID: "u2", Username: "new"},
+		{DiscordID: "", Username: "no id"},
+	}
+	if err := m.processUsers(ctx, users); err != nil {
+		t.Fatalf("processUsers: %v", err)
+	}
+
+	var got []*models.User
+	if err := db.BunDB().NewSelect().Model(&got).Order("discord_id").Scan(ctx); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	names := make(map[string]string, len(got))
+	for _, user := range got {
+		names[user.DiscordID] = user.Username
+	}
+	want := map[string]string{"u1": "second", "u2": "new", "u3": "three"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("users = %v, want the latest record per Discord ID %v", names, want)
+	}
+}
+
+func TestProcessUserCardsAcrossBatches(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	m := batchMigrator(t, db)
+
+	collection := &models.Collection{ID: "twice", Name: "twice", Origin: "test", UpdatedAt: time.Now()}
+	if _, err := db.BunDB().NewInsert().Model(collection).Exec(ctx); err != nil {
+		t.Fatalf("create collection: %v", err)
+	}
+	for _, id := range []int64{1, 2, 3} {
+		card := &models.Card{ID: id, Name: "card", Level: 1, ColID: "twice", Tags: []string{}}
+		if _, err := db.BunDB().NewInsert().Model(card).Exec(ctx); err != nil {
+			t.Fatalf("create card %d: %v", id, err)
+		}
+	}
+
+	cardID := func(id int32) *int32 { return &id }
+	cards := []MongoUserCard{
+		{UserID: "u1", CardID: cardID(1), Amount: 1},
+		{UserID: "u1", CardID: cardID(2), Amount: 2},
+		{UserID: "u1", CardID: nil, Amount: 1},
+		{UserID: "u2", CardID: cardID(1), Amount: 1},
+		{UserID: "u2", CardID: cardID(99), Amount: 1},
+		{UserID: "u2", CardID: cardID(3), Amount: 4},
+		{UserID: "u3", CardID: cardID(2), Amount: 1},
+	}
+	if err := m.processUserCards(ctx, cards); err != nil {
+		t.Fatalf("processUserCards: %v", err)
+	}
+
+	var amount int64
+	count, err := db.BunDB().NewSelect().Model((*models.UserCard)(nil)).Count(ctx)
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if err := db.BunDB().NewSelect().Model((*models.UserCard)(nil)).ColumnExpr("SUM(amount)").Scan(ctx, &amount); err != nil {
+		t.Fatalf("sum: %v", err)
+	}
+	// The null and unknown card IDs are skipped; the other five land over three batches
+	if count != 5 || amount != 9 {
+		t.Errorf("%d rows with %d copies, want 5 rows with 9 copies", count, amount)
+	}
+}
+
+// serveMongo makes m read collections from memory. Every find returns a cursor over all of
+// a collection's documents, so fixtures must stay under one users page.
+func serveMongo(m *Migrator, docs map[string][]interface{}) {
+	m.mongoFind = func(ctx context.Context, collection string, filter interface{}, opts *options.FindOptions) (*mongo.Cursor, error) {
+		return mongo.NewCursorFromDocuments(docs[collection], nil, nil)
+	}
+}
+
+func TestMigrateUsersFromMongoAcrossBatches(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	m := batchMigrator(t, db)
+
+	// Five records in three batches: u1 repeats inside the first batch and u2 across batches
+	serveMongo(m, map[string][]interface{}{"users": {
+		MongoUser{ID: primitive.NewObjectID(), DiscordID: "u1", Username: "first"},
+		MongoUser{ID: primitive.NewObjectID(), DiscordID: "u1", Username: "second"},
+		MongoUser{ID: primitive.NewObjectID(), DiscordID: "u2", Username: "old"},
+		MongoUser{ID: primitive.NewObjectID(), DiscordID: "u3", Username: "three"},
+		MongoUser{ID: primitive.NewObjectID(), DiscordID: "u2", Username: "new"},
+	}})
+	m.beginTable("users")
+	if err := m.MigrateUsersFromMongo(ctx); err != nil {
+		t.Fatalf("MigrateUsersFromMongo: %v", err)
+	}
+	m.endTable()
+	if batches := m.stats.Tables["users"].Batches; batches != 3 {
+		t.Errorf("inserted users in %d batches, want 3", batches)
+	}
+
+	var got []*models.User
+	if err := db.BunDB().NewSelect().Model(&got).Order("discord_id").Scan(ctx); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	names := make(map[string]string, len(got))
+	for _, user := range got {
+		names[user.DiscordID] = user.Username
+	}
+	want := map[string]string{"u1": "second", "u2": "new", "u3": "three"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("users = %v, want the latest record per Discord ID %v", names, want)
+	}
+}
+
+func TestMigrateUserCardsFromMongoAcrossBatches(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+
+	collection := &models.Collection{ID: "twice", Name: "twice", Origin: "test", UpdatedAt: time.Now()}
+	if _, err := db.BunDB().NewInsert().Model(collection).Exec(ctx); err != nil {
+		t.Fatalf("create collection: %v", err)
+	}
+	for _, id := range []int64{1, 2} {
+		card := &models.Card{ID: id, Name: "card", Level: 1, ColID: "twice", Tags: []string{}}
+		if _, err := db.BunDB().NewInsert().Model(card).Exec(ctx); err != nil {
+			t.Fatalf("create card %d: %v", id, err)
+		}
+	}
+
+	cardID := func(id int32) *int32 { return &id }
+	// u1's card 1 is split over both batches; card 99 is unknown and skipped
+	docs := map[string][]interface{}{"usercards": {
+		MongoUserCard{UserID: "u1", CardID: cardID(1), Amount: 2},
+		MongoUserCard{UserID: "u1", CardID: cardID(2), Amount: 1},
+		MongoUserCard{UserID: "u2", CardID: cardID(1), Amount: 1},
+		MongoUserCard{UserID: "u2", CardID: cardID(99), Amount: 1},
+		MongoUserCard{UserID: "u1", CardID: cardID(1), Amount: 3},
+	}}
+
+	// A re-run gives the same rows instead of adding to the first run
+	for run := 1; run <= 2; run++ {
+		m := batchMigrator(t, db)
+		serveMongo(m, docs)
+		m.beginTable("user_cards")
+		if err := m.MigrateUserCardsFromMongo(ctx); err != nil {
+			t.Fatalf("run %d: MigrateUserCardsFromMongo: %v", run, err)
+		}
+		m.endTable()
+		if batches := m.stats.Tables["user_cards"].Batches; batches != 2 {
+			t.Errorf("run %d: inserted user cards in %d batches, want 2", run, batches)
+		}
+
+		var cards []*models.UserCard
+		if err := db.BunDB().NewSelect().Model(&cards).Order("user_id", "card_id").Scan(ctx); err != nil {
+			t.Fatalf("select: %v", err)
+		}
+		amounts := make(map[string]int64, len(cards))
+		for _, card := range cards {
+			amounts[fmt.Sprintf("%s/%d", card.UserID, card.CardID)] = card.Amount
+		}
+		want := map[string]int64{"u1/1": 5, "u1/2": 1, "u2/1": 1}
+		if !reflect.DeepEqual(amounts, want) {
+			t.Errorf("run %d: amounts = %v, want %v", run, amounts, want)
+		}
+	}
+}
+
+func TestCopyClaimsAndAuctionsTwice(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	m := &Migrator{pgDB: db.BunDB()}
+	m.SetUseCopy(true)
+	m.UsePool(db.GetPool())
+
+	claimed := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	claims := func() []*models.Claim {
+		return []*models.Claim{
+			{CardID: 1, UserID: "u1", ClaimedAt: claimed, Expires: claimed.Add(time.Hour)},
+			{CardID: 2, UserID: "u1", ClaimedAt: claimed, Expires: claimed.Add(time.Hour)},
+			{CardID: 1, UserID: "u2", ClaimedAt: claimed, Expires: claimed.Add(time.Hour)},
+		}
+	}
+	auction := func(price int64, updated time.Time) *models.Auction {
+		return &models.Auction{
+			AuctionID: "a1", CardID: 1, SellerID: "u1", StartPrice: 100, CurrentPrice: price,
+			MinIncrement: 10, Status: models.AuctionStatusActive, StartTime: claimed,
+			EndTime: claimed.Add(time.Hour), CreatedAt: claimed, UpdatedAt: updated,
+		}
+	}
+
+	// The second run resends the same rows; the auction now also appears twice in one batch
+	for run := 1; run <= 2; run++ {
+		if err := m.batchInsertClaims(ctx, claims()); err != nil {
+			t.Fatalf("run %d claims: %v", run, err)
+		}
+		auctions := []*models.Auction{auction(150, claimed.Add(time.Minute))}
+		if run == 2 {
+			auctions = append(auctions, auction(200, claimed.Add(2*time.Minute)))
+		}
+		if err := m.batchInsertAuctions(ctx, auctions); err != nil {
+			t.Fatalf("run %d auctions: %v", run, err)
+		}
+	}
+
+	if count, err := db.BunDB().NewSelect().Model((*models.Claim)(nil)).Count(ctx); err != nil || count != 3 {
+		t.Errorf("claims = %d, %v, want 3 after two runs", count, err)
+	}
+	var got []*models.Auction
+	if err := db.BunDB().NewSelect().Model(&got).Scan(ctx); err != nil {
+		t.Fatalf("select auctions: %v", err)
+	}
+	if len(got) != 1 || got[0].CurrentPrice != 200 {
+		t.Errorf("auctions = %d rows, want one at the newest price 200", len(got))
+	}
+}
+
+func TestInsertClaimsTwiceWithoutCopy(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	// --use-copy without a pool takes the fallback insert, like a failed COPY does
+	m := &Migrator{pgDB: db.BunDB()}
+	m.SetUseCopy(true)
+
+	claimed := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	claims := func() []*models.Claim {
+		return []*models.Claim{
+			{CardID: 1, UserID: "u1", ClaimedAt: claimed, Expires: claimed.Add(time.Hour)},
+			{CardID: 1, UserID: "u1", ClaimedAt: claimed, Expires: claimed.Add(time.Hour)},
+			{CardID: 1, UserID: "u2", ClaimedAt: claimed, Expires: claimed.Add(time.Hour)},
+		}
+	}
+	for run := 1; run <= 2; run++ {
+		if err := m.batchInsertClaims(ctx, claims()); err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+	}
+
+	if count, err := db.BunDB().NewSelect().Model((*models.Claim)(nil)).Count(ctx); err != nil || count != 2 {
+		t.Errorf("claims = %d, %v, want 2 after two runs", count, err)
+	}
+}
+
+func TestProcessUserCardsSumsPairsSplitAcrossBatches(t *testing.T) {
+	db := dbtest.Open(t)
+	ctx := context.Background()
+
+	collection := &models.Collection{ID: "twice", Name: "twice", Origin: "test", UpdatedAt: time.Now()}
+	if _, err := db.BunDB().NewInsert().Model(collection).Exec(ctx); err != nil {
+		t.Fatalf("create collection: %v", err)
+	}
+	for _, id := range []int64{1, 2} {
+		card := &models.Card{ID: id, Name: "card", Level: 1, ColID: "twice", Tags: []string{}}
+		if _, err := db.BunDB().NewInsert().Model(card).Exec(ctx); err != nil {
+			t.Fatalf("create card %d: %v", id, err)
+		}
+	}
+
+	early := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	cardID := func(id int32) *int32 { return &id }
+	// With batches of two, u1's card 1 lands in the first and the second batch
+	cards := []MongoUserCard{
+		{UserID: "u1", CardID: cardID(1), Amount: 2, Exp: 10, Obtained: early.Add(time.Hour)},
+		{UserID: "u1", CardID: cardID(2), Amount: 1},
+		{UserID: "u1", CardID: cardID(1), Amount: 3, Exp: 5, Fav: true, Obtained: early},
+	}
+
+	// The same documents give the same result in any batch size and on a re-run
+	for _, size := range []int{2, 10, 2} {
+		m := batchMigrator(t, db)
+		m.SetBatchSize(size)
+		if err := m.processUserCards(ctx, cards); err != nil {
+			t.Fatalf("processUserCards with batches of %d: %v", size, err)
+		}
+
+		card := new(models.UserCard)
+		if err := db.BunDB().NewSelect().Model(card).Where("user_id = ? AND card_id = ?", "u1", 1).Scan(ctx); err != nil {
+			t.Fatalf("select: %v", err)
+		}
+		if card.Amount != 5 || card.Exp != 15 || !card.Favorite || !card.Obtained.Equal(early) {
+			t.Errorf("batches of %d: u1/1 = %d copies, %d exp, favorite %t, obtained %v, want 5, 15, true, %v",
+				size, card.Amount, card.Exp, card.Favorite, card.Obtained, early)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFindQueryPerKind(t *testing.T) {
+	m := NewMigrator(nil, t.TempDir())
+	twice := bson.D{{Key: "col", Value: "twice"}}
+	m.SetMongoFilter("cards", twice)
+	m.SetMongoProjection("cards", bson.D{{Key: "name", Value: 1}})
+	m.SetMongoFilter("", bson.D{{Key: "ignored", Value: true}})
+
+	filter, opts := m.findQuery("cards")
+	if !reflect.DeepEqual(filter, twice) {
+		t.Errorf("cards filter = %v, want %v", filter, twice)
+	}
+	if !reflect.DeepEqual(opts.Projection, bson.D{{Key: "name", Value: 1}}) {
+		t.Errorf("cards projection = %v", opts.Projection)
+	}
+
+	// Other kinds keep matching everything with every field
+	filter, opts = m.findQuery("users")
+	if filter == nil || len(filter) != 0 {
+		t.Errorf("users filter = %#v, want an empty document", filter)
+	}
+	if opts.Projection != nil {
+		t.Errorf("users projection = %v, want none", opts.Projection)
+	}
+	if len(m.mongoFilters) != 1 {
+		t.Errorf("filters = %v, want only cards", m.mongoFilters)
+	}
+}
@@ -0,0 +1,118 @@
+package migration
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const defaultSkippedLogPath = "skipped_cards.log"
+
+// Reason codes written to the skipped-cards log
+const (
+	SkipReasonNullCardID             = "null_card_id"
+	SkipReasonMissingCard            = "missing_from_cards_table"
+	SkipReasonMissingCardAndJSON     = "missing_from_cards_table_and_json"
+	SkipReasonMissingAutocreateError = "missing_from_cards_table_autocreate_failed"
+)
+
+// SkippedCardEntry is one JSON line in the skipped-cards log
+type SkippedCardEntry struct {
+	Timestamp string `json:"timestamp"`
+	UserID    string `json:"user_id"`
+	CardID    *int64 `json:"card_id"`
+	Reason    string `json:"reason"`
+}
+
+// skippedCardLog records user cards that could not be imported, as CSV or JSON lines
+type skippedCardLog struct {
+	path      string
+	jsonLines bool
+	file      *os.File
+	w         *bufio.Writer
+	timestamp string
+	count     int
+}
+
+func openSkippedCardLog(path string, jsonLines bool) (*skippedCardLog, error) {
+	if path == "" {
+		path = defaultSkippedLogPath
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create skipped cards log directory: %w", err)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create skipped cards log file: %w", err)
+	}
+
+	l := &skippedCardLog{
+		path:      path,
+		jsonLines: jsonLines,
+		file:      file,
+		w:         bufio.NewWriter(file),
+		timestamp: time.Now().Format("2006-01-02 15:04:05"),
+	}
+	if !jsonLines {
+		if _, err := l.w.WriteString("timestamp,user_id,card_id,reason\n"); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to write header to skipped cards log: %w", err)
+		}
+	}
+	return l, nil
+}
+
+// record writes one skipped user card. cardID is nil when the source row had no card ID.
+func (l *skippedCardLog) record(userID string, cardID *int64, reason string) error {
+	l.count++
+
+	if l.jsonLines {
+		line, err := json.Marshal(SkippedCardEntry{
+			Timestamp: l.timestamp,
+			UserID:    userID,
+			CardID:    cardID,
+			Reason:    reason,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode skipped card: %w", err)
+		}
+		line = append(line, '\n')
+		if _, err := l.w.Write(line); err != nil {
+			return fmt.Errorf("failed to write to skipped cards log: %w", err)
+		}
+		return nil
+	}
+
+	id := "null"
+	if cardID != nil {
+		id = strconv.FormatInt(*cardID, 10)
+	}
+	if _, err := fmt.Fprintf(l.w, "%s,%s,%s,%s\n", l.timestamp, userID, id, reason); err != nil {
+		return fmt.Errorf("failed to write to skipped cards log: %w", err)
+	}
+	return nil
+}
+
+// finish appends the CSV summary (JSON lines logs stay one record per line) and flushes
+func (l *skippedCardLog) finish() error {
+	if !l.jsonLines {
+		if _, err := fmt.Fprintf(l.w, "\nSummary:\nTotal skipped: %d\nTimestamp: %s\n", l.count, l.timestamp); err != nil {
+			return fmt.Errorf("failed to write summary to skipped cards log: %w", err)
+		}
+	}
+	if err := l.w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush skipped cards log: %w", err)
+	}
+	return nil
+}
+
+func (l *skippedCardLog) close() error {
+	return l.file.Close()
+}
@@ -0,0 +1,93 @@
+package migration
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSkippedLog(t *testing.T, path string, jsonLines bool) {
+	t.Helper()
+	l, err := openSkippedCardLog(path, jsonLines)
+	if err != nil {
+		t.Fatalf("openSkippedCardLog: %v", err)
+	}
+	defer l.close()
+
+	missing := int64(99)
+	entries := []struct {
+		userID string
+		cardID *int64
+		reason string
+	}{
+		{"u1", nil, SkipReasonNullCardID},
+		{"u2", &missing, SkipReasonMissingCard},
+		{"u3", &missing, SkipReasonMissingCardAndJSON},
+	}
+	for _, e := range entries {
+		if err := l.record(e.userID, e.cardID, e.reason); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	if l.count != 3 {
+		t.Errorf("count = %d, want 3", l.count)
+	}
+	if err := l.finish(); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+}
+
+func TestSkippedCardLogJSONLines(t *testing.T) {
+	// Missing directories are created
+	path := filepath.Join(t.TempDir(), "logs", "skipped.jsonl")
+	writeSkippedLog(t, path, true)
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer file.Close()
+
+	var reasons []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry SkippedCardEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("line %q isn't JSON: %v", scanner.Text(), err)
+		}
+		if entry.Reason == SkipReasonNullCardID && entry.CardID != nil {
+			t.Errorf("null card ID written as %d", *entry.CardID)
+		}
+		if entry.Reason == SkipReasonMissingCard && (entry.CardID == nil || *entry.CardID != 99 || entry.UserID != "u2") {
+			t.Errorf("missing card entry = %+v", entry)
+		}
+		reasons = append(reasons, entry.Reason)
+	}
+	want := []string{SkipReasonNullCardID, SkipReasonMissingCard, SkipReasonMissingCardAndJSON}
+	if strings.Join(reasons, ",") != strings.Join(want, ",") {
+		t.Errorf("reasons = %v, want %v", reasons, want)
+	}
+}
+
+func TestSkippedCardLogCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skipped.log")
+	writeSkippedLog(t, path, false)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	lines := strings.Split(string(data), "\n")
+	if lines[0] != "timestamp,user_id,card_id,reason" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",u1,null,"+SkipReasonNullCardID) || !strings.HasSuffix(lines[2], ",u2,99,"+SkipReasonMissingCard) {
+		t.Errorf("rows = %q", lines[1:3])
+	}
+	if !strings.Contains(string(data), "Total skipped: 3") {
+		t.Errorf("summary missing from %q", data)
+	}
+}
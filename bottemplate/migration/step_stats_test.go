@@ -0,0 +1,55 @@
+package migration
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrackBatchAttributesToCurrentStep(t *testing.T) {
+	m := NewMigrator(nil, t.TempDir())
+
+	// Batches outside a step are not recorded anywhere
+	var err error
+	m.trackBatch(time.Now(), 10, &err)
+	if len(m.stats.Tables) != 0 {
+		t.Fatalf("untracked batch created tables %v", m.stats.Tables)
+	}
+
+	m.beginTable("cards")
+	m.trackBatch(time.Now().Add(-50*time.Millisecond), 100, &err)
+	m.trackBatch(time.Now(), 20, &err)
+	failed := errors.New("insert failed")
+	m.trackBatch(time.Now(), 5, &failed)
+	m.stats.Tables["cards"].StartTime = time.Now().Add(-2 * time.Second)
+	m.endTable()
+
+	cards := m.stats.Tables["cards"]
+	if cards.Processed != 125 || cards.Successful != 120 || cards.Errors != 1 || cards.Batches != 2 {
+		t.Errorf("cards = %d processed, %d successful, %d errors, %d batches, want 125, 120, 1, 2",
+			cards.Processed, cards.Successful, cards.Errors, cards.Batches)
+	}
+	if cards.PeakBatchMs < 50 {
+		t.Errorf("PeakBatchMs = %d, want at least 50", cards.PeakBatchMs)
+	}
+	if cards.DurationMs < 2000 {
+		t.Errorf("DurationMs = %d, want at least 2000", cards.DurationMs)
+	}
+	// 120 rows over a little more than two seconds
+	if cards.RowsPerSecond <= 0 || cards.RowsPerSecond > 60 {
+		t.Errorf("RowsPerSecond = %.1f, want between 0 and 60", cards.RowsPerSecond)
+	}
+
+	m.beginTable("users")
+	m.trackBatch(time.Now(), 3, &err)
+	m.endTable()
+	if m.currentTable != nil {
+		t.Error("step still current after endTable")
+	}
+	if m.stats.PeakBatchTable != "cards" || m.stats.PeakBatchMs != cards.PeakBatchMs {
+		t.Errorf("peak = %s %dms, want the cards step", m.stats.PeakBatchTable, m.stats.PeakBatchMs)
+	}
+	if users := m.stats.Tables["users"]; users.Successful != 3 || users.Batches != 1 {
+		t.Errorf("users = %+v, want one batch of 3", users)
+	}
+}
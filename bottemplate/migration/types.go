@@ -276,21 +276,32 @@ type MongoUserInventory struct {
 
 // MigrationStats tracks migration progress and issues
 type MigrationStats struct {
-	Tables         map[string]*TableStats `json:"tables"`
-	StartTime      time.Time              `json:"start_time"`
-	EndTime        time.Time              `json:"end_time"`
-	TotalErrors    int                    `json:"total_errors"`
-	TotalSkipped   int                    `json:"total_skipped"`
-	TotalProcessed int                    `json:"total_processed"`
+	Tables            map[string]*TableStats `json:"tables"`
+	StartTime         time.Time              `json:"start_time"`
+	EndTime           time.Time              `json:"end_time"`
+	WallClockMs       int64                  `json:"wall_clock_ms"`
+	PeakBatchMs       int64                  `json:"peak_batch_ms"`
+	PeakBatchTable    string                 `json:"peak_batch_table,omitempty"`
+	TotalErrors       int                    `json:"total_errors"`
+	TotalSkipped      int                    `json:"total_skipped"`
+	TotalProcessed    int                    `json:"total_processed"`
+	TotalDeadLettered int                    `json:"total_dead_lettered"`
 }
 
 // TableStats tracks stats for individual tables
 type TableStats struct {
 	TableName      string          `json:"table_name"`
+	StartTime      time.Time       `json:"start_time"`
+	EndTime        time.Time       `json:"end_time"`
+	DurationMs     int64           `json:"duration_ms"`
+	RowsPerSecond  float64         `json:"rows_per_second"`
+	Batches        int             `json:"batches"`
+	PeakBatchMs    int64           `json:"peak_batch_ms"`
 	Processed      int             `json:"processed"`
 	Successful     int             `json:"successful"`
 	Skipped        int             `json:"skipped"`
 	Errors         int             `json:"errors"`
+	DeadLettered   int             `json:"dead_lettered"`
 	SkippedRecords []SkippedRecord `json:"skipped_records"`
 	ErrorRecords   []ErrorRecord   `json:"error_records"`
 }
@@ -0,0 +1,153 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// GapCount counts distinct missing card IDs and the user card rows referencing them
+type GapCount struct {
+	CardIDs int `json:"card_ids"`
+	Rows    int `json:"rows"`
+}
+
+// UserCardGapReport is a pre-flight summary of user cards whose card is missing from the
+// cards table, split by how the migration will handle them with the current settings
+type UserCardGapReport struct {
+	Rows        int      `json:"rows"`
+	NullCardIDs int      `json:"null_card_ids"`
+	Backfill    GapCount `json:"backfill_from_json"`
+	AutoCreate  GapCount `json:"auto_create"`
+	Skip        GapCount `json:"skip"`
+}
+
+// userCardGapScanner collects the card IDs referenced by user cards without writing anything
+type userCardGapScanner struct {
+	m       *Migrator
+	known   map[int64]bool
+	missing map[int64]int
+	report  UserCardGapReport
+}
+
+func (m *Migrator) newUserCardGapScanner(ctx context.Context) (*userCardGapScanner, error) {
+	known, err := m.loadValidCardIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if m.fillMissingFromJSON {
+		if err := m.loadJSONCaches(); err != nil {
+			// ensureCardFromJSON fails the same way, so those cards will be skipped
+			logProgress(fmt.Sprintf("JSON card definitions unavailable for gap report: %v", err))
+		}
+	}
+	return &userCardGapScanner{m: m, known: known, missing: make(map[int64]int)}, nil
+}
+
+func (s *userCardGapScanner) add(mc MongoUserCard) {
+	s.report.Rows++
+	if mc.CardID == nil {
+		s.report.NullCardIDs++
+		return
+	}
+	if id := int64(*mc.CardID); !s.known[id] {
+		s.missing[id]++
+	}
+}
+
+// result classifies missing cards the same way userCardImporter.add will handle them
+func (s *userCardGapScanner) result() UserCardGapReport {
+	r := s.report
+	for id, rows := range s.missing {
+		var bucket *GapCount
+		switch {
+		case s.m.fillMissingFromJSON:
+			if _, ok := s.m.jsonCardsByID[id]; ok {
+				bucket = &r.Backfill
+			} else {
+				bucket = &r.Skip
+			}
+		case s.m.autoCreateMissingCards:
+			bucket = &r.AutoCreate
+		default:
+			bucket = &r.Skip
+		}
+		bucket.CardIDs++
+		bucket.Rows += rows
+	}
+	return r
+}
+
+// AnalyzeUserCardGaps scans every user card in Mongo (or usercards.bson without Mongo) against
+// the cards table and JSON definitions and reports what will be backfilled, auto-created or
+// skipped. Nothing is written.
+func (m *Migrator) AnalyzeUserCardGaps(ctx context.Context) (UserCardGapReport, error) {
+	scanner, err := m.newUserCardGapScanner(ctx)
+	if err != nil {
+		return UserCardGapReport{}, err
+	}
+
+	if !m.streamsFromMongo() {
+		err := m.processBSONFile(m.cardsPath, func(doc []byte) error {
+			var mc MongoUserCard
+			if err := bson.Unmarshal(doc, &mc); err != nil {
+				return err
+			}
+			scanner.add(mc)
+			return nil
+		})
+		if err != nil {
+			return UserCardGapReport{}, err
+		}
+		return scanner.result(), nil
+	}
+
+	filter, opts := m.findQuery("usercards")
+	opts.SetProjection(bson.D{{Key: "cardid", Value: 1}}).SetBatchSize(int32(m.batchSize))
+	cur, err := m.find(ctx, "usercards", filter, opts)
+	if err != nil {
+		return UserCardGapReport{}, fmt.Errorf("failed to query usercards: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var mc MongoUserCard
+		if err := cur.Decode(&mc); err != nil {
+			continue
+		}
+		scanner.add(mc)
+	}
+	if err := cur.Err(); err != nil {
+		return UserCardGapReport{}, err
+	}
+	return scanner.result(), nil
+}
+
+// logUserCardGaps writes the gap report to the migration log with a hint when rows would be lost
+func (m *Migrator) logUserCardGaps(r UserCardGapReport) {
+	logProgress(fmt.Sprintf("User card gap report: %d rows, %d without a card ID; missing cards: %d backfilled from JSON (%d rows), %d auto-created (%d rows), %d skipped (%d rows)",
+		r.Rows, r.NullCardIDs, r.Backfill.CardIDs, r.Backfill.Rows, r.AutoCreate.CardIDs, r.AutoCreate.Rows, r.Skip.CardIDs, r.Skip.Rows))
+	if r.Skip.Rows > 0 && !m.fillMissingFromJSON && !m.autoCreateMissingCards {
+		logProgress("Re-run with --auto-create-missing-cards to keep skipped user cards as placeholder cards")
+	}
+}
+
+// loadValidCardIDs returns the IDs currently in the cards table
+func (m *Migrator) loadValidCardIDs(ctx context.Context) (map[int64]bool, error) {
+	var ids []int64
+	err := m.pgDB.NewSelect().
+		Model((*models.Card)(nil)).
+		Column("id").
+		Scan(ctx, &ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get valid card IDs: %w", err)
+	}
+
+	valid := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		valid[id] = true
+	}
+	return valid, nil
+}
@@ -0,0 +1,54 @@
+package migration
+
+import "testing"
+
+func TestUserCardGapScannerBuckets(t *testing.T) {
+	id := func(v int32) *int32 { return &v }
+	rows := []MongoUserCard{
+		{UserID: "u1", CardID: id(1)},
+		{UserID: "u1", CardID: nil},
+		{UserID: "u1", CardID: id(7)},
+		{UserID: "u2", CardID: id(7)},
+		{UserID: "u2", CardID: id(8)},
+		{UserID: "u3", CardID: id(9)},
+	}
+
+	tests := []struct {
+		name       string
+		fillJSON   bool
+		autoCreate bool
+		want       UserCardGapReport
+	}{
+		{
+			// 7 has a JSON definition, 8 and 9 do not
+			name:     "backfill from JSON",
+			fillJSON: true,
+			want:     UserCardGapReport{Rows: 6, NullCardIDs: 1, Backfill: GapCount{1, 2}, Skip: GapCount{2, 2}},
+		},
+		{
+			name:       "auto-create",
+			autoCreate: true,
+			want:       UserCardGapReport{Rows: 6, NullCardIDs: 1, AutoCreate: GapCount{3, 4}},
+		},
+		{
+			name: "skip",
+			want: UserCardGapReport{Rows: 6, NullCardIDs: 1, Skip: GapCount{3, 4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Migrator{
+				fillMissingFromJSON:    tt.fillJSON,
+				autoCreateMissingCards: tt.autoCreate,
+				jsonCardsByID:          map[int64]JSONCard{7: {}},
+			}
+			s := &userCardGapScanner{m: m, known: map[int64]bool{1: true}, missing: make(map[int64]int)}
+			for _, row := range rows {
+				s.add(row)
+			}
+			if got := s.result(); got != tt.want {
+				t.Errorf("result = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
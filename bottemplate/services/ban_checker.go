@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// banCacheTTL bounds how long a cached ban state is trusted; /ban and /unban
+// invalidate the cache immediately
+const banCacheTTL = time.Minute
+
+type cachedBan struct {
+	ban      models.BanInfo
+	loadedAt time.Time
+}
+
+// BanChecker answers whether a user is banned from using the bot, caching each
+// user's ban state so the check stays cheap on every interaction
+type BanChecker struct {
+	users repositories.UserRepository
+	now   func() time.Time
+
+	mu    sync.RWMutex
+	cache map[string]cachedBan
+}
+
+// NewBanChecker creates a ban checker backed by the user repository
+func NewBanChecker(users repositories.UserRepository) *BanChecker {
+	return &BanChecker{
+		users: users,
+		now:   time.Now,
+		cache: make(map[string]cachedBan),
+	}
+}
+
+// Banned returns the user's ban when one is in force. Unknown users and lookup
+// failures are treated as not banned so a database hiccup never locks everyone out.
+func (c *BanChecker) Banned(ctx context.Context, userID string) (models.BanInfo, bool) {
+	now := c.now()
+
+	c.mu.RLock()
+	cached, ok := c.cache[userID]
+	c.mu.RUnlock()
+	if ok && now.Sub(cached.loadedAt) < banCacheTTL {
+		return cached.ban, cached.ban.Active(now)
+	}
+
+	user, err := c.users.GetByDiscordID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			slog.Warn("Failed to load ban state, allowing interaction",
+				slog.String("user_id", userID),
+				slog.Any("error", err))
+			return models.BanInfo{}, false
+		}
+		user = &models.User{}
+	}
+
+	c.mu.Lock()
+	c.cache[userID] = cachedBan{ban: user.Ban, loadedAt: now}
+	c.mu.Unlock()
+	return user.Ban, user.Ban.Active(now)
+}
+
+// SetBan stores the user's new ban state and refreshes the cache
+func (c *BanChecker) SetBan(ctx context.Context, userID string, ban models.BanInfo) error {
+	if err := c.users.UpdateBan(ctx, userID, ban); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.cache[userID] = cachedBan{ban: ban, loadedAt: c.now()}
+	c.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// banUserRepo serves ban state from memory and counts the lookups
+type banUserRepo struct {
+	repositories.UserRepository
+	bans    map[string]models.BanInfo
+	err     error
+	lookups int
+}
+
+func (r *banUserRepo) GetByDiscordID(ctx context.Context, discordID string) (*models.User, error) {
+	r.lookups++
+	if r.err != nil {
+		return nil, r.err
+	}
+	ban, ok := r.bans[discordID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &models.User{DiscordID: discordID, Ban: ban}, nil
+}
+
+func (r *banUserRepo) UpdateBan(ctx context.Context, discordID string, ban models.BanInfo) error {
+	r.bans[discordID] = ban
+	return nil
+}
+
+func TestBanInfoActive(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		ban  models.BanInfo
+		want bool
+	}{
+		{"not banned", models.BanInfo{}, false},
+		{"permanent", models.BanInfo{Full: true}, true},
+		{"until later", models.BanInfo{Full: true, Until: now.Add(time.Hour)}, true},
+		{"lapsed", models.BanInfo{Full: true, Until: now}, false},
+		// Embargo and report flags aren't a full ban
+		{"embargo only", models.BanInfo{Embargo: true, Report: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ban.Active(now); got != tt.want {
+				t.Errorf("Active = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBanCheckerCachesAndUpdates(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo := &banUserRepo{bans: map[string]models.BanInfo{
+		"banned": {Full: true, Reason: "alts", Until: now.Add(2 * time.Minute)},
+		"player": {},
+	}}
+	c := NewBanChecker(repo)
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if ban, banned := c.Banned(ctx, "banned"); !banned || ban.Reason != "alts" {
+			t.Fatalf("Banned(banned) = %+v, %t, want the ban", ban, banned)
+		}
+	}
+	if repo.lookups != 1 {
+		t.Errorf("%d lookups, want the cached state reused", repo.lookups)
+	}
+
+	// Unbanning takes effect without waiting for the cache to expire
+	if err := c.SetBan(ctx, "banned", models.BanInfo{}); err != nil {
+		t.Fatalf("SetBan: %v", err)
+	}
+	if _, banned := c.Banned(ctx, "banned"); banned {
+		t.Error("still banned after SetBan")
+	}
+	if err := c.SetBan(ctx, "player", models.BanInfo{Full: true, Until: now.Add(2 * time.Minute)}); err != nil {
+		t.Fatalf("SetBan: %v", err)
+	}
+	if _, banned := c.Banned(ctx, "player"); !banned {
+		t.Error("not banned after SetBan")
+	}
+
+	// A timed ban lapses on its own once the cached entry is refreshed
+	now = now.Add(3 * time.Minute)
+	if _, banned := c.Banned(ctx, "player"); banned {
+		t.Error("ban still active after Until")
+	}
+	if _, banned := c.Banned(ctx, "newcomer"); banned {
+		t.Error("unknown user reported banned")
+	}
+}
+
+func TestBanCheckerAllowsOnLookupFailure(t *testing.T) {
+	repo := &banUserRepo{err: errors.New("database down")}
+	c := NewBanChecker(repo)
+	ctx := context.Background()
+
+	if _, banned := c.Banned(ctx, "u1"); banned {
+		t.Error("lookup failure reported as banned")
+	}
+	// Failures aren't cached, so the next interaction retries
+	c.Banned(ctx, "u1")
+	if repo.lookups != 2 {
+		t.Errorf("%d lookups, want 2", repo.lookups)
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// CardOwnedError is returned when deleting a card that users still own without forcing it
+type CardOwnedError struct {
+	CardID int64
+	Owners int
+	Copies int64
+}
+
+func (e *CardOwnedError) Error() string {
+	return fmt.Sprintf("card %d is owned by %d users (%d copies)", e.CardID, e.Owners, e.Copies)
+}
+
+// CardImageStore is the part of the Spaces service needed to remove card images
+type CardImageStore interface {
+	GetCardStorageKeys(cardName string, colID string, level int, groupType string, animated bool, format string) []string
+	ListObjectKeys(ctx context.Context, prefix string) ([]string, error)
+	DeleteObject(ctx context.Context, path string) error
+}
+
+// DeleteCard removes a card, every owned copy and wishlist entry in one transaction, then
+// deletes its image. Deleting a card that no longer exists is a no-op. Unless force is set,
+// cards that users still own are refused with a *CardOwnedError.
+func DeleteCard(ctx context.Context, cards repositories.CardRepository, images CardImageStore, cardID int64, force bool) (*models.DeletionReport, error) {
+	report := &models.DeletionReport{CardID: cardID}
+
+	card, err := cards.GetByIDWithDeleted(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || repositories.IsNotFound(err) {
+			return report, nil
+		}
+		return report, fmt.Errorf("failed to get card: %w", err)
+	}
+
+	if !force {
+		owners, copies, err := cards.CountCopies(ctx, cardID)
+		if err != nil {
+			return report, err
+		}
+		if owners > 0 {
+			return report, &CardOwnedError{CardID: cardID, Owners: owners, Copies: copies}
+		}
+	}
+
+	report, err = cards.SafeDelete(ctx, cardID)
+	if err != nil {
+		return report, err
+	}
+
+	if report.CardDeleted && images != nil {
+		deleted, err := deleteCardImages(ctx, images, card)
+		if err != nil {
+			slog.Warn("Failed to delete card image",
+				slog.Int64("card_id", cardID),
+				slog.Any("error", err))
+		}
+		report.ImageDeleted = deleted
+	}
+
+	slog.Info("Card deleted",
+		slog.Int64("card_id", cardID),
+		slog.String("name", card.Name),
+		slog.String("collection", card.ColID),
+		slog.Int("user_cards_deleted", report.UserCardsDeleted),
+		slog.Int64("copies_deleted", report.CopiesDeleted),
+		slog.Int("wishlists_deleted", report.WishlistsDeleted),
+		slog.Bool("image_deleted", report.ImageDeleted),
+		slog.Bool("forced", force))
+
+	return report, nil
+}
+
+// SoftDeleteCard hides a card from search and listings while keeping owned copies,
+// wishlist entries and its image so it can be restored. Soft-deleting a card that is
+// missing or already deleted is a no-op.
+func SoftDeleteCard(ctx context.Context, cards repositories.CardRepository, cardID int64) (*models.DeletionReport, error) {
+	report := &models.DeletionReport{CardID: cardID, SoftDeleted: true}
+
+	deleted, err := cards.SoftDelete(ctx, cardID)
+	if err != nil {
+		return report, err
+	}
+	report.CardDeleted = deleted
+
+	if deleted {
+		slog.Info("Card soft-deleted", slog.Int64("card_id", cardID))
+	}
+	return report, nil
+}
+
+// RestoreCard undoes SoftDeleteCard. It reports false when the card is missing or not deleted.
+func RestoreCard(ctx context.Context, cards repositories.CardRepository, cardID int64) (bool, error) {
+	restored, err := cards.Restore(ctx, cardID)
+	if err != nil {
+		return false, err
+	}
+	if restored {
+		slog.Info("Card restored", slog.Int64("card_id", cardID))
+	}
+	return restored, nil
+}
+
+// deleteCardImages removes the card image in every format it is stored in, such as the
+// original kept next to a WebP image, and reports whether a stored image was deleted
+func deleteCardImages(ctx context.Context, images CardImageStore, card *models.Card) (bool, error) {
+	keys := images.GetCardStorageKeys(card.Name, card.ColID, card.Level, utils.GetGroupType(card.Tags), card.Animated, card.ImageFormat)
+
+	// Deleting a missing key succeeds too, so only keys listed as stored count
+	prefix := strings.TrimSuffix(keys[0], path.Ext(keys[0])) + "."
+	stored, err := images.ListObjectKeys(ctx, prefix)
+	if err != nil {
+		return false, err
+	}
+
+	wanted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		wanted[key] = true
+	}
+
+	deleted := false
+	var errs []error
+	for _, key := range stored {
+		if !wanted[key] {
+			continue
+		}
+		if err := images.DeleteObject(ctx, key); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleted = true
+	}
+	return deleted, errors.Join(errs...)
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+type deletionCardRepo struct {
+	repositories.CardRepository
+	cards       map[int64]*models.Card
+	owners      int
+	copies      int64
+	safeDeletes int
+	softDeleted map[int64]bool
+}
+
+func (r *deletionCardRepo) GetByIDWithDeleted(ctx context.Context, id int64) (*models.Card, error) {
+	card, ok := r.cards[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return card, nil
+}
+
+func (r *deletionCardRepo) CountCopies(ctx context.Context, cardID int64) (int, int64, error) {
+	return r.owners, r.copies, nil
+}
+
+func (r *deletionCardRepo) SafeDelete(ctx context.Context, cardID int64) (*models.DeletionReport, error) {
+	r.safeDeletes++
+	delete(r.cards, cardID)
+	return &models.DeletionReport{CardID: cardID, UserCardsDeleted: r.owners, CopiesDeleted: r.copies, CardDeleted: true}, nil
+}
+
+func (r *deletionCardRepo) SoftDelete(ctx context.Context, cardID int64) (bool, error) {
+	if _, ok := r.cards[cardID]; !ok || r.softDeleted[cardID] {
+		return false, nil
+	}
+	r.softDeleted[cardID] = true
+	return true, nil
+}
+
+func (r *deletionCardRepo) Restore(ctx context.Context, cardID int64) (bool, error) {
+	if !r.softDeleted[cardID] {
+		return false, nil
+	}
+	delete(r.softDeleted, cardID)
+	return true, nil
+}
+
+// fakeImageStore holds stored keys, records deleted ones and fails the calls it's told to
+type fakeImageStore struct {
+	stored     map[string]bool
+	deleted    []string
+	failList   bool
+	failDelete map[string]bool
+}
+
+func newFakeImageStore(keys ...string) *fakeImageStore {
+	s := &fakeImageStore{stored: make(map[string]bool), failDelete: make(map[string]bool)}
+	for _, key := range keys {
+		s.stored[key] = true
+	}
+	return s
+}
+
+func (s *fakeImageStore) GetCardStorageKeys(cardName string, colID string, level int, groupType string, animated bool, format string) []string {
+	keys := []string{"cards/promo/" + groupType + "/" + colID + "/" + cardName + "." + format}
+	for _, other := range CardImageFormats {
+		if other != format {
+			keys = append(keys, "cards/promo/"+groupType+"/"+colID+"/"+cardName+"."+other)
+		}
+	}
+	return keys
+}
+
+func (s *fakeImageStore) ListObjectKeys(ctx context.Context, prefix string) ([]string, error) {
+	if s.failList {
+		return nil, errors.New("list failed")
+	}
+	var keys []string
+	for key := range s.stored {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *fakeImageStore) DeleteObject(ctx context.Context, path string) error {
+	if s.failDelete[path] {
+		return errors.New("object delete failed")
+	}
+	s.deleted = append(s.deleted, path)
+	delete(s.stored, path)
+	return nil
+}
+
+func deletionRepo(owners int, copies int64) *deletionCardRepo {
+	return &deletionCardRepo{
+		cards:       map[int64]*models.Card{1: {ID: 1, Name: "1_nayeon", ColID: "twice", Level: 1, Tags: []string{"girlgroups"}, ImageFormat: "webp"}},
+		owners:      owners,
+		copies:      copies,
+		softDeleted: make(map[int64]bool),
+	}
+}
+
+func TestDeleteCardCleansUpImages(t *testing.T) {
+	repo := deletionRepo(0, 0)
+	images := newFakeImageStore(
+		"cards/promo/girlgroups/twice/1_nayeon.webp",
+		// The original kept next to the converted image
+		"cards/promo/girlgroups/twice/1_nayeon.png",
+		// Not a format of this card's image
+		"cards/promo/girlgroups/twice/1_nayeon.webp.bak",
+	)
+
+	report, err := DeleteCard(context.Background(), repo, images, 1, false)
+	if err != nil {
+		t.Fatalf("DeleteCard: %v", err)
+	}
+	if !report.CardDeleted || !report.ImageDeleted {
+		t.Errorf("report = %+v, want card and image deleted", report)
+	}
+	want := []string{"cards/promo/girlgroups/twice/1_nayeon.png", "cards/promo/girlgroups/twice/1_nayeon.webp"}
+	if !reflect.DeepEqual(images.deleted, want) {
+		t.Errorf("deleted %v, want %v", images.deleted, want)
+	}
+}
+
+func TestDeleteCardWithoutStoredImage(t *testing.T) {
+	images := newFakeImageStore("cards/promo/girlgroups/twice/2_jihyo.webp")
+
+	report, err := DeleteCard(context.Background(), deletionRepo(0, 0), images, 1, false)
+	if err != nil {
+		t.Fatalf("DeleteCard: %v", err)
+	}
+	if !report.CardDeleted || report.ImageDeleted || len(images.deleted) != 0 {
+		t.Errorf("report = %+v after deleting %v, want no image deleted", report, images.deleted)
+	}
+}
+
+func TestDeleteCardRefusesOwnedCards(t *testing.T) {
+	repo := deletionRepo(2, 5)
+	images := newFakeImageStore("cards/promo/girlgroups/twice/1_nayeon.webp")
+
+	_, err := DeleteCard(context.Background(), repo, images, 1, false)
+	var owned *CardOwnedError
+	if !errors.As(err, &owned) || owned.Owners != 2 || owned.Copies != 5 {
+		t.Fatalf("err = %v, want a CardOwnedError for 2 owners and 5 copies", err)
+	}
+	if repo.safeDeletes != 0 || len(images.deleted) != 0 {
+		t.Error("a refused delete still removed data")
+	}
+
+	report, err := DeleteCard(context.Background(), repo, images, 1, true)
+	if err != nil || report.CopiesDeleted != 5 {
+		t.Errorf("forced delete = %+v, %v", report, err)
+	}
+}
+
+func TestDeleteCardIsIdempotent(t *testing.T) {
+	repo := deletionRepo(0, 0)
+	images := newFakeImageStore("cards/promo/girlgroups/twice/1_nayeon.webp")
+
+	if _, err := DeleteCard(context.Background(), repo, images, 1, false); err != nil {
+		t.Fatalf("first delete: %v", err)
+	}
+	report, err := DeleteCard(context.Background(), repo, images, 1, false)
+	if err != nil {
+		t.Fatalf("second delete: %v", err)
+	}
+	if report.CardDeleted || repo.safeDeletes != 1 || len(images.deleted) != 1 {
+		t.Errorf("second delete did work: report %+v, %d deletes, images %v", report, repo.safeDeletes, images.deleted)
+	}
+}
+
+func TestDeleteCardImageFailures(t *testing.T) {
+	const webpKey, pngKey = "cards/promo/girlgroups/twice/1_nayeon.webp", "cards/promo/girlgroups/twice/1_nayeon.png"
+	tests := []struct {
+		name             string
+		failList         bool
+		failDelete       []string
+		wantImageDeleted bool
+	}{
+		{"listing fails", true, nil, false},
+		{"one format fails", false, []string{pngKey}, true},
+		{"every format fails", false, []string{webpKey, pngKey}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images := newFakeImageStore(webpKey, pngKey)
+			images.failList = tt.failList
+			for _, key := range tt.failDelete {
+				images.failDelete[key] = true
+			}
+			report, err := DeleteCard(context.Background(), deletionRepo(0, 0), images, 1, false)
+			if err != nil {
+				t.Fatalf("image failures must not fail the delete: %v", err)
+			}
+			if !report.CardDeleted || report.ImageDeleted != tt.wantImageDeleted {
+				t.Errorf("report = %+v, want image deleted %v", report, tt.wantImageDeleted)
+			}
+		})
+	}
+}
+
+func TestSoftDeleteCardKeepsData(t *testing.T) {
+	repo := deletionRepo(2, 5)
+	ctx := context.Background()
+
+	// Owned cards can be soft-deleted without force since nothing is removed
+	report, err := SoftDeleteCard(ctx, repo, 1)
+	if err != nil {
+		t.Fatalf("SoftDeleteCard: %v", err)
+	}
+	if !report.SoftDeleted || !report.CardDeleted || report.CopiesDeleted != 0 || report.ImageDeleted {
+		t.Errorf("report = %+v, want only a soft delete", report)
+	}
+	if repo.safeDeletes != 0 || repo.cards[1] == nil {
+		t.Error("soft delete removed the card")
+	}
+
+	if report, err := SoftDeleteCard(ctx, repo, 1); err != nil || report.CardDeleted {
+		t.Errorf("second soft delete = %+v, %v, want a no-op", report, err)
+	}
+	if restored, err := RestoreCard(ctx, repo, 1); err != nil || !restored {
+		t.Errorf("RestoreCard = %t, %v, want true", restored, err)
+	}
+	if restored, err := RestoreCard(ctx, repo, 1); err != nil || restored {
+		t.Errorf("second RestoreCard = %t, %v, want false", restored, err)
+	}
+}
@@ -147,8 +147,8 @@ func (ucdc *UserCardDisplayWithContext) GetExtraInfo() []string {
 		}
 	case utils.SortByAmount:
 		// Amount is rendered by the shared card entry formatter.
-	case utils.SortByRating:
-		// Show rating when sorting by rating
+	case utils.SortByRating, utils.SortByEval:
+		// Show rating when sorting by rating or evaluation
 		if ucdc.UserCard.Rating > 0 {
 			extras = append(extras, fmt.Sprintf("**★%d**", ucdc.UserCard.Rating))
 		} else {
@@ -333,7 +333,7 @@ func (cds *CardDisplayService) CreateCardsEmbed(
 		SetTitle(title).
 		SetDescription(description).
 		SetColor(color).
-		SetFooter(fmt.Sprintf("Page %d/%d • Total: %d", page+1, totalPages, totalItems), "")
+		SetFooter(utils.FormatPageFooter(page, totalPages, config.CardsPerPage, totalItems), "")
 
 	if query != "" {
 		embed.SetDescription(fmt.Sprintf("`🔍 %s`\n\n%s", query, description))
@@ -386,30 +386,20 @@ func (cds *CardDisplayService) FormatCopyText(ctx context.Context, items []CardD
 
 // ConvertUserCardsToDisplayItems converts UserCard slice to CardDisplayItem slice
 func (cds *CardDisplayService) ConvertUserCardsToDisplayItems(ctx context.Context, userCards []*models.UserCard) ([]CardDisplayItem, error) {
-	items := make([]CardDisplayItem, 0, len(userCards))
-
-	for _, userCard := range userCards {
-		card, err := cds.cardRepo.GetByID(ctx, userCard.CardID)
-		if err != nil {
-			continue // Skip cards we can't fetch
-		}
-
-		items = append(items, &UserCardDisplay{
-			UserCard: userCard,
-			Card:     card,
-		})
-	}
-
-	return items, nil
+	return cds.ConvertUserCardsToDisplayItemsWithUser(ctx, userCards, nil)
 }
 
 // ConvertUserCardsToDisplayItemsWithUser converts UserCard slice to CardDisplayItem slice with User data
 func (cds *CardDisplayService) ConvertUserCardsToDisplayItemsWithUser(ctx context.Context, userCards []*models.UserCard, user *models.User) ([]CardDisplayItem, error) {
-	items := make([]CardDisplayItem, 0, len(userCards))
+	cardByID, err := cds.fetchCardMap(ctx, userCards)
+	if err != nil {
+		return nil, err
+	}
 
+	items := make([]CardDisplayItem, 0, len(userCards))
 	for _, userCard := range userCards {
-		card, err := cds.cardRepo.GetByID(ctx, userCard.CardID)
-		if err != nil {
+		card, ok := cardByID[userCard.CardID]
+		if !ok {
 			continue // Skip cards we can't fetch
 		}
 
@@ -425,23 +415,30 @@ func (cds *CardDisplayService) ConvertUserCardsToDisplayItemsWithUser(ctx contex
 
 // ConvertUserCardsToDisplayItemsWithUserAndContext converts UserCard slice to CardDisplayItem slice with User data and sorting context
 func (cds *CardDisplayService) ConvertUserCardsToDisplayItemsWithUserAndContext(ctx context.Context, userCards []*models.UserCard, user *models.User, filters utils.SearchFilters) ([]CardDisplayItem, error) {
-	items := make([]CardDisplayItem, 0, len(userCards))
+	cardByID, err := cds.fetchCardMap(ctx, userCards)
+	if err != nil {
+		return nil, err
+	}
+	return cds.ConvertUserCardsToDisplayItemsWithUserAndContextFromMap(ctx, userCards, user, filters, cardByID)
+}
 
-	for _, userCard := range userCards {
-		card, err := cds.cardRepo.GetByID(ctx, userCard.CardID)
-		if err != nil {
-			continue // Skip cards we can't fetch
-		}
+// fetchCardMap loads the cards behind userCards with a single batch query
+func (cds *CardDisplayService) fetchCardMap(ctx context.Context, userCards []*models.UserCard) (map[int64]*models.Card, error) {
+	ids := make([]int64, len(userCards))
+	for i, userCard := range userCards {
+		ids[i] = userCard.CardID
+	}
 
-		items = append(items, &UserCardDisplayWithContext{
-			UserCard: userCard,
-			Card:     card,
-			User:     user,
-			Filters:  filters,
-		})
+	cards, err := cds.cardRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cards: %w", err)
 	}
 
-	return items, nil
+	cardByID := make(map[int64]*models.Card, len(cards))
+	for _, card := range cards {
+		cardByID[card.ID] = card
+	}
+	return cardByID, nil
 }
 
 // ConvertUserCardsToDisplayItemsWithUserAndContextFromMap converts without additional DB lookups using a provided card map
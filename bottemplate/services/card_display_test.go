@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+func TestConvertUserCardsToDisplayItemsBatchesLookups(t *testing.T) {
+	repo := &fixtureCardRepo{cards: []*models.Card{
+		{ID: 1, Name: "nayeon", ColID: "twice", Level: 1},
+		{ID: 2, Name: "jeongyeon", ColID: "twice", Level: 2},
+	}}
+	cds := NewCardDisplayService(repo, nil)
+	userCards := []*models.UserCard{
+		{CardID: 2, Amount: 1},
+		{CardID: 99, Amount: 1}, // deleted card, skipped
+		{CardID: 1, Amount: 3},
+	}
+
+	items, err := cds.ConvertUserCardsToDisplayItemsWithUserAndContext(context.Background(), userCards, nil, utils.SearchFilters{})
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+	if repo.batches != 1 {
+		t.Errorf("GetByIDs called %d times, want 1", repo.batches)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+	if first := items[0].(*UserCardDisplayWithContext); first.Card.ID != 2 {
+		t.Errorf("first item is card %d, want the user cards' order", first.Card.ID)
+	}
+}
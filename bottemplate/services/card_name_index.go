@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// MaxAutocompleteChoices is the most choices Discord accepts in an autocomplete response
+const MaxAutocompleteChoices = utils.NameIndexMaxResults
+
+// cardNameIndexTTL bounds how long cards created outside the bot, e.g. from the
+// web dashboard, take to show up in suggestions
+const cardNameIndexTTL = 10 * time.Minute
+
+// CardNameIndex keeps a utils.NameIndex over every card for autocomplete. The
+// index is rebuilt lazily when it expires or after Invalidate, so each
+// keystroke is a trie lookup instead of a database query.
+type CardNameIndex struct {
+	cardRepo repositories.CardRepository
+	ttl      time.Duration
+
+	mu      sync.RWMutex
+	index   *utils.NameIndex
+	builtAt time.Time
+
+	// rebuildMu keeps concurrent keystrokes from rebuilding the index at the same time
+	rebuildMu sync.Mutex
+}
+
+func NewCardNameIndex(cardRepo repositories.CardRepository) *CardNameIndex {
+	return &CardNameIndex{
+		cardRepo: cardRepo,
+		ttl:      cardNameIndexTTL,
+	}
+}
+
+// Suggest returns up to limit cards whose name, or a word in it, starts with
+// query, falling back to close spellings. See utils.NameIndex.Search for ranking.
+func (idx *CardNameIndex) Suggest(ctx context.Context, query string, limit int) ([]*models.Card, error) {
+	index, err := idx.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return index.Search(query, limit), nil
+}
+
+// Invalidate forces the next suggestion to rebuild the index; call it after
+// creating or deleting cards
+func (idx *CardNameIndex) Invalidate() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.builtAt = time.Time{}
+}
+
+func (idx *CardNameIndex) current(ctx context.Context) (*utils.NameIndex, error) {
+	if index, fresh := idx.snapshot(); fresh {
+		return index, nil
+	}
+
+	idx.rebuildMu.Lock()
+	defer idx.rebuildMu.Unlock()
+	index, fresh := idx.snapshot()
+	if fresh {
+		return index, nil
+	}
+
+	cards, err := idx.cardRepo.GetAll(ctx)
+	if err != nil {
+		if index != nil {
+			// Stale suggestions beat none; try again on the next keystroke
+			return index, nil
+		}
+		return nil, fmt.Errorf("failed to load cards for name index: %w", err)
+	}
+
+	index = utils.BuildNameIndex(cards)
+
+	idx.mu.Lock()
+	idx.index = index
+	idx.builtAt = time.Now()
+	idx.mu.Unlock()
+	return index, nil
+}
+
+func (idx *CardNameIndex) snapshot() (*utils.NameIndex, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	fresh := idx.index != nil && !idx.builtAt.IsZero() && time.Since(idx.builtAt) < idx.ttl
+	return idx.index, fresh
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// allCardsRepo serves a fixed card list from GetAll and counts the loads
+type allCardsRepo struct {
+	repositories.CardRepository
+	cards []*models.Card
+	err   error
+	loads int
+}
+
+func (r *allCardsRepo) GetAll(ctx context.Context) ([]*models.Card, error) {
+	r.loads++
+	return r.cards, r.err
+}
+
+func nameIndexCards() []*models.Card {
+	return []*models.Card{
+		{ID: 1, Name: "Momo", ColID: "twice", Level: 1},
+		{ID: 2, Name: "Momo Hirai", ColID: "twice", Level: 3},
+		{ID: 3, Name: "Hirai Momo", ColID: "twice", Level: 5},
+		{ID: 4, Name: "momoland", ColID: "momoland", Level: 2},
+		{ID: 5, Name: "Sana", ColID: "twice", Level: 4},
+	}
+}
+
+func suggestedIDs(cards []*models.Card) []int64 {
+	ids := make([]int64, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	return ids
+}
+
+func TestCardNameIndexSuggestRanking(t *testing.T) {
+	tests := []struct {
+		query string
+		limit int
+		want  []int64
+	}{
+		// Exact name, then full-name prefixes by level, then word prefixes
+		{query: "momo", limit: 25, want: []int64{1, 2, 4, 3}},
+		{query: "MOM", limit: 25, want: []int64{2, 4, 1, 3}},
+		{query: "momo_hirai", limit: 25, want: []int64{2}},
+		{query: "momo", limit: 2, want: []int64{1, 2}},
+		// A typo in a long enough query still finds the card
+		{query: "sanq", limit: 25, want: []int64{5}},
+		{query: "xyz", limit: 25, want: []int64{}},
+		{query: "  ", limit: 25, want: []int64{}},
+	}
+
+	idx := NewCardNameIndex(&allCardsRepo{cards: nameIndexCards()})
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			cards, err := idx.Suggest(context.Background(), tt.query, tt.limit)
+			if err != nil {
+				t.Fatalf("Suggest: %v", err)
+			}
+			if got := suggestedIDs(cards); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardNameIndexRebuilds(t *testing.T) {
+	repo := &allCardsRepo{cards: nameIndexCards()}
+	idx := NewCardNameIndex(repo)
+	ctx := context.Background()
+
+	for _, query := range []string{"m", "mo", "mom"} {
+		if _, err := idx.Suggest(ctx, query, 25); err != nil {
+			t.Fatalf("Suggest: %v", err)
+		}
+	}
+	if repo.loads != 1 {
+		t.Errorf("%d loads for three keystrokes, want 1", repo.loads)
+	}
+
+	repo.cards = append(repo.cards, &models.Card{ID: 6, Name: "Mina", ColID: "twice", Level: 2})
+	idx.Invalidate()
+	cards, err := idx.Suggest(ctx, "mina", 25)
+	if err != nil || !reflect.DeepEqual(suggestedIDs(cards), []int64{6}) {
+		t.Errorf("after Invalidate: %v, %v, want the new card", suggestedIDs(cards), err)
+	}
+
+	// A failed rebuild keeps serving the previous index
+	repo.err = errors.New("database down")
+	idx.Invalidate()
+	if cards, err := idx.Suggest(ctx, "mina", 25); err != nil || len(cards) != 1 {
+		t.Errorf("failed rebuild: %v, %v, want the stale suggestions", suggestedIDs(cards), err)
+	}
+	if repo.loads != 3 {
+		t.Errorf("%d loads, want 3", repo.loads)
+	}
+}
+
+func TestCardNameIndexFirstLoadFails(t *testing.T) {
+	idx := NewCardNameIndex(&allCardsRepo{err: errors.New("database down")})
+	if _, err := idx.Suggest(context.Background(), "momo", 25); err == nil {
+		t.Error("no error without any index to fall back to")
+	}
+}
@@ -17,8 +17,12 @@ import (
 type CardOperationsService struct {
 	cardRepo     interfaces.CardRepositoryInterface
 	userCardRepo interfaces.UserCardRepositoryInterface
+	priceLookup  PriceLookup
 }
 
+// PriceLookup returns the current price of each requested card
+type PriceLookup func(ctx context.Context, cardIDs []int64) (map[int64]int64, error)
+
 // Simple in-memory cache for recent /cards queries to speed up pagination
 type cardsCacheEntry struct {
 	userID    string
@@ -45,6 +49,12 @@ func NewCardOperationsService(cardRepo interfaces.CardRepositoryInterface, userC
 	}
 }
 
+// WithPriceLookup lets evaluation (>eval) and price (>price) sorting use card prices
+func (s *CardOperationsService) WithPriceLookup(lookup PriceLookup) *CardOperationsService {
+	s.priceLookup = lookup
+	return s
+}
+
 // GetUserCardsWithDetails fetches user cards with card details and applies filtering
 func (s *CardOperationsService) GetUserCardsWithDetails(ctx context.Context, userID string, query string) ([]*models.UserCard, []*models.Card, error) {
 	userCards, cards, _, err := s.GetUserCardsWithDetailsAndFilters(ctx, userID, query)
@@ -117,6 +127,17 @@ func (s *CardOperationsService) GetUserCardsWithDetailsAndFiltersWithUser(ctx co
 			filteredUserCards = s.applyNewCardFilter(filteredUserCards, user, filters.NewOnly)
 		}
 
+		// Apply obtained-date range (obtained>7d, obtained<2024-01-01)
+		if filters.HasObtainedRange() {
+			inRange := make([]*models.UserCard, 0, len(filteredUserCards))
+			for _, userCard := range filteredUserCards {
+				if utils.MatchesObtainedRange(userCard, filters) {
+					inRange = append(inRange, userCard)
+				}
+			}
+			filteredUserCards = inRange
+		}
+
 		// Build card mappings for the filtered user cards
 		filteredCardMap := make(map[int64]*models.UserCard)
 		filteredCardIDs := make([]int64, len(filteredUserCards))
@@ -151,7 +172,9 @@ func (s *CardOperationsService) GetUserCardsWithDetailsAndFiltersWithUser(ctx co
 
 		// Apply user-specific sorting after mapping back to UserCards
 		// This is needed for sorts like experience, amount, rating that require UserCard data
-		if needsUserCardSorting(filters.SortBy) {
+		if filters.SortBy == utils.SortByEval || filters.SortBy == utils.SortByPrice {
+			s.sortUserCardsByPrice(ctx, displayCards, cards, filters)
+		} else if needsUserCardSorting(filters.SortBy) {
 			s.sortUserCardsWithFilters(displayCards, cards, filters)
 		}
 	} else {
@@ -213,9 +236,29 @@ func (s *CardOperationsService) GetMissingCards(ctx context.Context, userID stri
 	return missingCards, nil
 }
 
-// GetCardDifferences returns card differences between two users
+// GetCardDifferences returns the cards only userID owns (mode "for") or only targetUserID owns (mode "from")
 func (s *CardOperationsService) GetCardDifferences(ctx context.Context, userID, targetUserID string, mode string) ([]*models.Card, error) {
-	// Get cards for both users
+	diff, err := s.CompareCollections(ctx, userID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mode == "from" {
+		return diff.OnlyTarget, nil
+	}
+	return diff.OnlyUser, nil
+}
+
+// CollectionDiff compares the collections of two users
+type CollectionDiff struct {
+	OnlyUser   []*models.Card // cards the user has that the target doesn't
+	OnlyTarget []*models.Card // cards the target has that the user doesn't
+	Shared     int            // number of cards both own
+}
+
+// CompareCollections computes both directions of a diff from one fetch of each user's cards
+// and a single batch lookup of the differing cards
+func (s *CardOperationsService) CompareCollections(ctx context.Context, userID, targetUserID string) (*CollectionDiff, error) {
 	userCards, err := s.userCardRepo.GetAllByUserID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch user cards: %w", err)
@@ -226,51 +269,71 @@ func (s *CardOperationsService) GetCardDifferences(ctx context.Context, userID,
 		return nil, fmt.Errorf("failed to fetch target user cards: %w", err)
 	}
 
-	// Get all cards for mapping
-	allCards, err := s.cardRepo.GetAll(ctx)
+	onlyUserIDs, onlyTargetIDs, shared := diffOwnedCardIDs(userCards, targetCards)
+
+	ids := make([]int64, 0, len(onlyUserIDs)+len(onlyTargetIDs))
+	ids = append(ids, onlyUserIDs...)
+	ids = append(ids, onlyTargetIDs...)
+	cards, err := s.cardRepo.GetByIDs(ctx, ids)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch cards: %w", err)
 	}
 
-	// Create card mapping
-	cardMap := make(map[int64]*models.Card)
-	for _, card := range allCards {
+	cardMap := make(map[int64]*models.Card, len(cards))
+	for _, card := range cards {
 		cardMap[card.ID] = card
 	}
 
-	var diffCards []*models.Card
+	diff := &CollectionDiff{Shared: shared}
+	for _, id := range onlyUserIDs {
+		if card, ok := cardMap[id]; ok {
+			diff.OnlyUser = append(diff.OnlyUser, card)
+		}
+	}
+	for _, id := range onlyTargetIDs {
+		if card, ok := cardMap[id]; ok {
+			diff.OnlyTarget = append(diff.OnlyTarget, card)
+		}
+	}
+
+	return diff, nil
+}
 
-	if mode == "for" {
-		// Cards user has that target doesn't
-		targetOwned := make(map[int64]bool)
-		for _, tc := range targetCards {
-			targetOwned[tc.CardID] = true
+// diffOwnedCardIDs splits two collections into the card IDs only the user owns, the card IDs
+// only the target owns and the number of cards both own. Rows with no copies left are ignored.
+func diffOwnedCardIDs(userCards, targetCards []*models.UserCard) (onlyUser, onlyTarget []int64, shared int) {
+	userOwned := make(map[int64]bool, len(userCards))
+	for _, uc := range userCards {
+		if uc.Amount > 0 {
+			userOwned[uc.CardID] = true
 		}
+	}
 
-		for _, uc := range userCards {
-			if !targetOwned[uc.CardID] {
-				if card, exists := cardMap[uc.CardID]; exists {
-					diffCards = append(diffCards, card)
-				}
-			}
+	targetOwned := make(map[int64]bool, len(targetCards))
+	for _, tc := range targetCards {
+		if tc.Amount <= 0 || targetOwned[tc.CardID] {
+			continue
 		}
-	} else if mode == "from" {
-		// Cards target has that user doesn't
-		userOwned := make(map[int64]bool)
-		for _, uc := range userCards {
-			userOwned[uc.CardID] = true
+		targetOwned[tc.CardID] = true
+		if userOwned[tc.CardID] {
+			shared++
+		} else {
+			onlyTarget = append(onlyTarget, tc.CardID)
 		}
+	}
 
-		for _, tc := range targetCards {
-			if !userOwned[tc.CardID] {
-				if card, exists := cardMap[tc.CardID]; exists {
-					diffCards = append(diffCards, card)
-				}
-			}
+	seen := make(map[int64]bool, len(userOwned))
+	for _, uc := range userCards {
+		if uc.Amount <= 0 || seen[uc.CardID] {
+			continue
+		}
+		seen[uc.CardID] = true
+		if !targetOwned[uc.CardID] {
+			onlyUser = append(onlyUser, uc.CardID)
 		}
 	}
 
-	return diffCards, nil
+	return onlyUser, onlyTarget, shared
 }
 
 // SearchCardsInCollection searches within a specific collection of cards using unified search
@@ -313,6 +376,37 @@ func needsUserCardSorting(sortBy string) bool {
 	return sortBy == "exp" || sortBy == "amount" || sortBy == "rating" || sortBy == "date"
 }
 
+// sortUserCardsByPrice orders user cards by a price-aware sort (utils.EvalScore or the
+// card price), breaking ties by level then name
+func (s *CardOperationsService) sortUserCardsByPrice(ctx context.Context, userCards []*models.UserCard, cards []*models.Card, filters utils.SearchFilters) {
+	if len(userCards) == 0 {
+		return
+	}
+
+	cardMap := make(map[int64]*models.Card, len(cards))
+	for _, card := range cards {
+		cardMap[card.ID] = card
+	}
+
+	var prices map[int64]int64
+	if s.priceLookup != nil {
+		cardIDs := make([]int64, len(userCards))
+		for i, uc := range userCards {
+			cardIDs[i] = uc.CardID
+		}
+		if p, err := s.priceLookup(ctx, cardIDs); err == nil {
+			prices = p
+		}
+	}
+
+	utils.NewSortBuilder().
+		FirstBy(filters.SortBy, filters.SortDesc).
+		ThenBy(utils.SortByLevel, true).
+		ThenBy(utils.SortByName, false).
+		WithPrices(prices).
+		SortUserCards(userCards, cardMap)
+}
+
 // sortUserCardsWithFilters sorts user cards based on the provided filters
 func (s *CardOperationsService) sortUserCardsWithFilters(userCards []*models.UserCard, cards []*models.Card, filters utils.SearchFilters) {
 	if len(userCards) == 0 {
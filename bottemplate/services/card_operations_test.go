@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// usersCardRepo serves each user's collection
+type usersCardRepo map[string][]*models.UserCard
+
+func (r usersCardRepo) GetAllByUserID(ctx context.Context, userID string) ([]*models.UserCard, error) {
+	return r[userID], nil
+}
+
+func owned(cardIDs ...int64) []*models.UserCard {
+	userCards := make([]*models.UserCard, 0, len(cardIDs))
+	for _, id := range cardIDs {
+		userCards = append(userCards, &models.UserCard{CardID: id, Amount: 1})
+	}
+	return userCards
+}
+
+func TestDiffOwnedCardIDs(t *testing.T) {
+	tests := []struct {
+		name           string
+		user, target   []*models.UserCard
+		wantOnlyUser   []int64
+		wantOnlyTarget []int64
+		wantShared     int
+	}{
+		{
+			name:           "partial overlap",
+			user:           owned(1, 2, 3),
+			target:         owned(3, 4),
+			wantOnlyUser:   []int64{1, 2},
+			wantOnlyTarget: []int64{4},
+			wantShared:     1,
+		},
+		{
+			name:       "identical collections",
+			user:       owned(1, 2),
+			target:     owned(2, 1),
+			wantShared: 2,
+		},
+		{
+			name:         "empty target",
+			user:         owned(5),
+			wantOnlyUser: []int64{5},
+		},
+		{
+			name:           "duplicate rows count once",
+			user:           owned(1, 1, 2),
+			target:         owned(2, 2, 3, 3),
+			wantOnlyUser:   []int64{1},
+			wantOnlyTarget: []int64{3},
+			wantShared:     1,
+		},
+		{
+			name:           "rows with no copies left are ignored",
+			user:           append(owned(1), &models.UserCard{CardID: 2, Amount: 0}),
+			target:         owned(2),
+			wantOnlyUser:   []int64{1},
+			wantOnlyTarget: []int64{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onlyUser, onlyTarget, shared := diffOwnedCardIDs(tt.user, tt.target)
+			if !reflect.DeepEqual(onlyUser, tt.wantOnlyUser) || !reflect.DeepEqual(onlyTarget, tt.wantOnlyTarget) || shared != tt.wantShared {
+				t.Errorf("got %v, %v, %d; want %v, %v, %d",
+					onlyUser, onlyTarget, shared, tt.wantOnlyUser, tt.wantOnlyTarget, tt.wantShared)
+			}
+		})
+	}
+}
+
+func TestCompareCollections(t *testing.T) {
+	cards := &fixtureCardRepo{cards: []*models.Card{
+		{ID: 1, Name: "nayeon"}, {ID: 2, Name: "jeongyeon"}, {ID: 3, Name: "momo"}, {ID: 4, Name: "sana"},
+	}}
+	s := NewCardOperationsService(cards, usersCardRepo{
+		"u1": owned(1, 2, 3),
+		"u2": owned(3, 4),
+	})
+
+	diff, err := s.CompareCollections(context.Background(), "u1", "u2")
+	if err != nil {
+		t.Fatalf("CompareCollections: %v", err)
+	}
+	if len(diff.OnlyUser) != 2 || len(diff.OnlyTarget) != 1 || diff.Shared != 1 {
+		t.Errorf("only user %d, only target %d, shared %d; want 2, 1, 1", len(diff.OnlyUser), len(diff.OnlyTarget), diff.Shared)
+	}
+	if cards.batches != 1 {
+		t.Errorf("GetByIDs called %d times, want 1", cards.batches)
+	}
+
+	from, err := s.GetCardDifferences(context.Background(), "u1", "u2", "from")
+	if err != nil || len(from) != 1 || from[0].ID != 4 {
+		t.Errorf("from = %v, %v; want card 4", from, err)
+	}
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// DefaultOwnershipRefreshInterval is used when no ownership refresh interval is configured
+const DefaultOwnershipRefreshInterval = 30 * time.Minute
+
+// CardOwnershipCache keeps per-card owner and copy counts in memory. It is reloaded
+// periodically with one grouped query and feeds the -rare and -common search filters
+// and the pricing scarcity inputs.
+type CardOwnershipCache struct {
+	userCards repositories.UserCardRepository
+	rarity    utils.OwnershipRarity
+	interval  time.Duration
+
+	mu          sync.RWMutex
+	stats       map[int64]repositories.CardOwnershipStats
+	refreshedAt time.Time
+}
+
+// NewCardOwnershipCache creates a cache that reloads ownership stats every interval
+func NewCardOwnershipCache(userCards repositories.UserCardRepository, rarity utils.OwnershipRarity, interval time.Duration) *CardOwnershipCache {
+	if interval <= 0 {
+		interval = DefaultOwnershipRefreshInterval
+	}
+	return &CardOwnershipCache{
+		userCards: userCards,
+		rarity:    rarity,
+		interval:  interval,
+	}
+}
+
+// Run refreshes immediately and then on every interval until ctx is cancelled
+func (c *CardOwnershipCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		err := c.Refresh(ctx)
+		if err != nil {
+			slog.Error("Failed to refresh card ownership", slog.Any("error", err))
+		}
+		utils.RecordProcessRun(ctx, err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Refresh reloads the ownership stats once. A failed reload keeps the previous stats.
+func (c *CardOwnershipCache) Refresh(ctx context.Context) error {
+	stats, err := c.userCards.GetAllOwnershipStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	owners := make(map[int64]int64, len(stats))
+	for cardID, s := range stats {
+		owners[cardID] = s.Owners
+	}
+	utils.SetCardOwnership(owners, c.rarity)
+
+	c.mu.Lock()
+	c.stats = stats
+	c.refreshedAt = time.Now()
+	c.mu.Unlock()
+
+	slog.Debug("Refreshed card ownership", slog.Int("cards", len(stats)))
+	return nil
+}
+
+// GetOwnershipStats returns the cached stats for a card. ok is false before the first
+// refresh and for cards created since the last one.
+func (c *CardOwnershipCache) GetOwnershipStats(cardID int64) (repositories.CardOwnershipStats, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	s, ok := c.stats[cardID]
+	return s, ok
+}
+
+// RefreshedAt returns when the stats were last reloaded, zero before the first refresh
+func (c *CardOwnershipCache) RefreshedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refreshedAt
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// ownershipUserCardRepo answers GetAllOwnershipStats with fixed stats
+type ownershipUserCardRepo struct {
+	repositories.UserCardRepository
+	stats map[int64]repositories.CardOwnershipStats
+	err   error
+}
+
+func (r *ownershipUserCardRepo) GetAllOwnershipStats(ctx context.Context) (map[int64]repositories.CardOwnershipStats, error) {
+	return r.stats, r.err
+}
+
+func TestCardOwnershipCacheRefresh(t *testing.T) {
+	repo := &ownershipUserCardRepo{stats: map[int64]repositories.CardOwnershipStats{
+		1: {CardID: 1, Owners: 3, Copies: 7},
+		2: {CardID: 2},
+	}}
+	c := NewCardOwnershipCache(repo, utils.OwnershipRarity{}, 0)
+	ctx := context.Background()
+
+	if c.interval != DefaultOwnershipRefreshInterval {
+		t.Errorf("interval = %s, want the default", c.interval)
+	}
+	if _, ok := c.GetOwnershipStats(1); ok || !c.RefreshedAt().IsZero() {
+		t.Error("stats served before the first refresh")
+	}
+
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if s, ok := c.GetOwnershipStats(1); !ok || s.Owners != 3 || s.Copies != 7 {
+		t.Errorf("card 1 = %+v, %t, want 3 owners and 7 copies", s, ok)
+	}
+	if s, ok := c.GetOwnershipStats(2); !ok || s.Owners != 0 {
+		t.Errorf("unowned card = %+v, %t, want cached zeroes", s, ok)
+	}
+	if owners, ok := utils.CardOwnerCount(1); !ok || owners != 3 {
+		t.Errorf("search owner count = %d, %t, want 3", owners, ok)
+	}
+	refreshedAt := c.RefreshedAt()
+	if refreshedAt.IsZero() {
+		t.Error("RefreshedAt not set")
+	}
+
+	// A failed reload keeps serving the previous stats
+	repo.err = errors.New("database down")
+	if err := c.Refresh(ctx); err == nil {
+		t.Error("Refresh hid the repository error")
+	}
+	if _, ok := c.GetOwnershipStats(1); !ok || !c.RefreshedAt().Equal(refreshedAt) {
+		t.Error("failed refresh dropped the cached stats")
+	}
+}
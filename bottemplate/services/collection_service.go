@@ -16,6 +16,7 @@ type CollectionService struct {
 	collectionRepo repositories.CollectionRepository
 	cardRepo       interfaces.CardRepositoryInterface
 	userCardRepo   interfaces.UserCardRepositoryInterface
+	progressRepo   repositories.CollectionProgressRepository
 }
 
 func NewCollectionService(
@@ -30,6 +31,13 @@ func NewCollectionService(
 	}
 }
 
+// WithProgressCache makes progress refreshes and leaderboards go through the
+// collection_progress cache table
+func (s *CollectionService) WithProgressCache(repo repositories.CollectionProgressRepository) *CollectionService {
+	s.progressRepo = repo
+	return s
+}
+
 func (s *CollectionService) IsFragmentCollection(ctx context.Context, collectionID string) (bool, error) {
 	collection, err := s.collectionRepo.GetByID(ctx, collectionID)
 	if err != nil {
@@ -101,6 +109,22 @@ func (s *CollectionService) CalculateProgress(ctx context.Context, userID string
 	}, nil
 }
 
+// RefreshProgress recalculates a user's progress and stores it in the cache.
+// A failed cache write is returned alongside the freshly computed progress.
+func (s *CollectionService) RefreshProgress(ctx context.Context, userID string, collectionID string) (*models.CollectionProgress, error) {
+	progress, err := s.CalculateProgress(ctx, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if s.progressRepo == nil {
+		return progress, nil
+	}
+	if err := s.progressRepo.Upsert(ctx, progress); err != nil {
+		return progress, err
+	}
+	return progress, nil
+}
+
 func (s *CollectionService) CheckCompletion(ctx context.Context, userID string, collectionID string) (bool, error) {
 	progress, err := s.CalculateProgress(ctx, userID, collectionID)
 	if err != nil {
@@ -252,7 +276,41 @@ func (s *CollectionService) GetCollectionLeaderboard(ctx context.Context, collec
 		limit = 25 // Maximum limit to prevent performance issues
 	}
 
-	return s.collectionRepo.GetCollectionProgress(ctx, collectionID, limit)
+	if s.progressRepo == nil {
+		return s.collectionRepo.GetCollectionProgress(ctx, collectionID, limit)
+	}
+
+	// Seed the cache the first time a collection is viewed, and again whenever
+	// its card pool changed since the cached rows were written
+	collection, err := s.collectionRepo.GetByID(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	colCards, err := s.cardRepo.GetByCollectionID(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection cards: %w", err)
+	}
+	var cardIDs []int64
+	for _, card := range colCards {
+		if (collection.Fragments && card.Level == 1) || (!collection.Fragments && card.Level < 5) {
+			cardIDs = append(cardIDs, card.ID)
+		}
+	}
+	if len(cardIDs) == 0 {
+		return nil, fmt.Errorf("no eligible cards found for collection %s", collectionID)
+	}
+
+	fresh, err := s.progressRepo.IsFresh(ctx, collectionID, len(cardIDs))
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		if err := s.progressRepo.Rebuild(ctx, collectionID, cardIDs, collection.Fragments); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.progressRepo.GetLeaderboard(ctx, collectionID, limit)
 }
 
 // GetRandomSampleCard returns a random card from the specified collection
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+type fixtureCollectionRepo struct {
+	repositories.CollectionRepository
+	collections map[string]*models.Collection
+}
+
+func (r *fixtureCollectionRepo) GetByID(ctx context.Context, id string) (*models.Collection, error) {
+	collection, ok := r.collections[id]
+	if !ok {
+		return nil, errors.New("collection not found")
+	}
+	return collection, nil
+}
+
+// fakeProgressCache keeps cached progress in memory and counts rebuilds
+type fakeProgressCache struct {
+	rows     map[string]*models.CollectionProgress
+	fresh    bool
+	rebuilds int
+	err      error
+}
+
+func (c *fakeProgressCache) Get(ctx context.Context, userID, collectionID string) (*models.CollectionProgress, error) {
+	return c.rows[userID+"/"+collectionID], nil
+}
+
+func (c *fakeProgressCache) Upsert(ctx context.Context, progress *models.CollectionProgress) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.rows[progress.UserID+"/"+progress.CollectionID] = progress
+	return nil
+}
+
+func (c *fakeProgressCache) IsFresh(ctx context.Context, collectionID string, totalCards int) (bool, error) {
+	return c.fresh, nil
+}
+
+func (c *fakeProgressCache) Rebuild(ctx context.Context, collectionID string, cardIDs []int64, isFragment bool) error {
+	c.rebuilds++
+	c.fresh = true
+	return nil
+}
+
+func (c *fakeProgressCache) GetLeaderboard(ctx context.Context, collectionID string, limit int) ([]*models.CollectionProgressResult, error) {
+	return []*models.CollectionProgressResult{}, nil
+}
+
+func newProgressTestService(userCards usersCardRepo, cache *fakeProgressCache) *CollectionService {
+	cards := &fixtureCardRepo{cards: []*models.Card{
+		{ID: 1, Name: "nayeon", ColID: "twice", Level: 1},
+		{ID: 2, Name: "jeongyeon", ColID: "twice", Level: 2},
+		{ID: 3, Name: "momo", ColID: "twice", Level: 3},
+		{ID: 4, Name: "sana", ColID: "twice", Level: 4},
+		{ID: 5, Name: "jihyo", ColID: "twice", Level: 5}, // legendaries don't count towards progress
+	}}
+	collections := &fixtureCollectionRepo{collections: map[string]*models.Collection{"twice": {ID: "twice"}}}
+	return NewCollectionService(collections, cards, userCards).WithProgressCache(cache)
+}
+
+func TestRefreshProgressUpdatesCachedPercentage(t *testing.T) {
+	cache := &fakeProgressCache{rows: make(map[string]*models.CollectionProgress)}
+	collections := usersCardRepo{"u1": owned(1, 5)}
+	s := newProgressTestService(collections, cache)
+
+	if _, err := s.RefreshProgress(context.Background(), "u1", "twice"); err != nil {
+		t.Fatalf("RefreshProgress: %v", err)
+	}
+	if got := cache.rows["u1/twice"].Percentage; got != 25 {
+		t.Errorf("cached percentage = %v, want 25", got)
+	}
+
+	// Claiming the last three cards completes the collection
+	collections["u1"] = append(collections["u1"], owned(2, 3, 4)...)
+	if _, err := s.RefreshProgress(context.Background(), "u1", "twice"); err != nil {
+		t.Fatalf("RefreshProgress: %v", err)
+	}
+	cached := cache.rows["u1/twice"]
+	if cached.Percentage != 100 || !cached.IsCompleted || cached.OwnedCards != 4 {
+		t.Errorf("cached = %.0f%% (%d owned, completed %v), want 100%% of 4", cached.Percentage, cached.OwnedCards, cached.IsCompleted)
+	}
+}
+
+func TestRefreshProgressReturnsProgressWhenCacheFails(t *testing.T) {
+	cache := &fakeProgressCache{rows: make(map[string]*models.CollectionProgress), err: errors.New("db down")}
+	s := newProgressTestService(usersCardRepo{"u1": owned(1, 2)}, cache)
+
+	progress, err := s.RefreshProgress(context.Background(), "u1", "twice")
+	if err == nil {
+		t.Error("cache write failure was swallowed")
+	}
+	if progress == nil || progress.Percentage != 50 {
+		t.Errorf("progress = %+v, want 50%%", progress)
+	}
+}
+
+func TestGetCollectionLeaderboardRebuildsStaleCache(t *testing.T) {
+	cache := &fakeProgressCache{rows: make(map[string]*models.CollectionProgress)}
+	s := newProgressTestService(usersCardRepo{}, cache)
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.GetCollectionLeaderboard(context.Background(), "twice", 10); err != nil {
+			t.Fatalf("GetCollectionLeaderboard: %v", err)
+		}
+	}
+	if cache.rebuilds != 1 {
+		t.Errorf("rebuilt %d times, want once", cache.rebuilds)
+	}
+}
@@ -22,6 +22,8 @@ type CompletionCheckerService struct {
 	cardRepo          interfaces.CardRepositoryInterface
 	userCardRepo      interfaces.UserCardRepositoryInterface
 	collectionRepo    repositories.CollectionRepository
+	rewardRepo        repositories.CompletionRewardRepository
+	rewards           CompletionRewards
 }
 
 // NewCompletionCheckerService creates a new completion checker service
@@ -48,7 +50,15 @@ func (s *CompletionCheckerService) SetClient(client bot.Client) {
 	s.client = client
 }
 
-// CheckCompletionForCards checks collection completion for cards that were just added/modified
+// WithRewards enables automatic rewards for newly completed collections
+func (s *CompletionCheckerService) WithRewards(repo repositories.CompletionRewardRepository, rewards CompletionRewards) *CompletionCheckerService {
+	s.rewardRepo = repo
+	s.rewards = rewards
+	return s
+}
+
+// CheckCompletionForCards checks collection completion for cards that were just added/removed
+// and refreshes the cached progress for their collections.
 // This is called asynchronously after card operations
 func (s *CompletionCheckerService) CheckCompletionForCards(ctx context.Context, userID string, cardIDs []int64) {
 	defer func() {
@@ -95,15 +105,21 @@ func (s *CompletionCheckerService) checkSingleCollectionCompletion(ctx context.C
 	// Check if user already has this collection marked as completed
 	alreadyCompleted := s.isCollectionAlreadyCompleted(user, collectionID)
 
-	// Calculate current progress
-	progress, err := s.collectionService.CalculateProgress(ctx, userID, collectionID)
-	if err != nil {
+	// Calculate current progress and refresh the cached copy
+	progress, err := s.collectionService.RefreshProgress(ctx, userID, collectionID)
+	if progress == nil {
 		slog.Error("Failed to calculate collection progress",
 			slog.String("user_id", userID),
 			slog.String("collection_id", collectionID),
 			slog.String("error", err.Error()))
 		return
 	}
+	if err != nil {
+		slog.Warn("Failed to cache collection progress",
+			slog.String("user_id", userID),
+			slog.String("collection_id", collectionID),
+			slog.String("error", err.Error()))
+	}
 
 	// Handle completion state changes
 	if !alreadyCompleted && progress.IsCompleted {
@@ -148,8 +164,10 @@ func (s *CompletionCheckerService) handleNewCompletion(ctx context.Context, user
 		return
 	}
 
+	grant := s.grantCompletionReward(ctx, user.DiscordID, collectionID)
+
 	// Send notification
-	s.sendCompletionNotification(ctx, user, collectionID, true)
+	s.sendCompletionNotification(ctx, user, collectionID, true, grant)
 
 	slog.Info("User completed collection",
 		slog.String("user_id", user.DiscordID),
@@ -157,6 +175,46 @@ func (s *CompletionCheckerService) handleNewCompletion(ctx context.Context, user
 		slog.String("collection_id", collectionID))
 }
 
+// grantCompletionReward hands out the configured reward for a collection. It
+// returns nil when there is nothing to grant or the user was already rewarded.
+func (s *CompletionCheckerService) grantCompletionReward(ctx context.Context, userID string, collectionID string) *models.CompletionRewardGrant {
+	if s.rewardRepo == nil {
+		return nil
+	}
+	reward, ok := s.rewards.For(collectionID)
+	if !ok {
+		return nil
+	}
+
+	grant := &models.CompletionRewardGrant{
+		UserID:       userID,
+		CollectionID: collectionID,
+		Flakes:       reward.Flakes,
+		Vials:        reward.Vials,
+		ItemID:       reward.ItemID,
+		ItemQuantity: reward.ItemQuantity,
+	}
+	granted, err := s.rewardRepo.Grant(ctx, grant)
+	if err != nil {
+		slog.Error("Failed to grant collection completion reward",
+			slog.String("user_id", userID),
+			slog.String("collection_id", collectionID),
+			slog.String("error", err.Error()))
+		return nil
+	}
+	if !granted {
+		return nil
+	}
+
+	slog.Info("Granted collection completion reward",
+		slog.String("user_id", userID),
+		slog.String("collection_id", collectionID),
+		slog.Int64("flakes", grant.Flakes),
+		slog.Int64("vials", grant.Vials),
+		slog.String("item_id", grant.ItemID))
+	return grant
+}
+
 // handleLostCompletion handles when a user loses completion of a collection
 func (s *CompletionCheckerService) handleLostCompletion(ctx context.Context, user *models.User, collectionID string) {
 	// Remove from completed collections
@@ -180,7 +238,7 @@ func (s *CompletionCheckerService) handleLostCompletion(ctx context.Context, use
 	}
 
 	// Send notification
-	s.sendCompletionNotification(ctx, user, collectionID, false)
+	s.sendCompletionNotification(ctx, user, collectionID, false, nil)
 
 	slog.Info("User lost collection completion",
 		slog.String("user_id", user.DiscordID),
@@ -189,15 +247,16 @@ func (s *CompletionCheckerService) handleLostCompletion(ctx context.Context, use
 }
 
 // sendCompletionNotification sends a DM to the user about collection completion changes
-func (s *CompletionCheckerService) sendCompletionNotification(ctx context.Context, user *models.User, collectionID string, isCompleted bool) {
+func (s *CompletionCheckerService) sendCompletionNotification(ctx context.Context, user *models.User, collectionID string, isCompleted bool, grant *models.CompletionRewardGrant) {
 	if s.client == nil || user == nil || user.DiscordID == "" {
 		slog.Warn("Skipping completion notification because Discord client or user is unavailable",
 			slog.String("collection_id", collectionID))
 		return
 	}
 
-	// Check if user has notifications enabled (following JavaScript pattern)
-	// For now, we'll assume notifications are enabled. In the future, this could check user preferences
+	if !user.NotificationSettings().Completed {
+		return
+	}
 
 	// Get collection information
 	collection, err := s.collectionRepo.GetByID(ctx, collectionID)
@@ -214,6 +273,9 @@ func (s *CompletionCheckerService) sendCompletionNotification(ctx context.Contex
 	if isCompleted {
 		message = fmt.Sprintf("🎉 **Collection Completed!**\n\nYou have just completed `%s`!\n\nYou can now decide if you want to reset this collection for a clout star and a legendary card if it contains one!\n\nOne copy of each card below 5 stars will be consumed if the collection has 200 or fewer cards. Otherwise 200 specified cards will be taken based on overall card composition.\n\nTo reset type: `/collection reset collection:%s`",
 			collection.Name, collectionID)
+		if grant != nil {
+			message += fmt.Sprintf("\n\n🎁 **Completion Reward:** %s", formatCompletionReward(grant))
+		}
 		color = 0x00FF00 // Green
 	} else {
 		message = fmt.Sprintf("⚠️ **Collection Completion Lost**\n\nYou no longer have all the cards required for a full completion of `%s`. This collection has been removed from your completed list.",
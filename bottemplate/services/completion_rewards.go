@@ -0,0 +1,51 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// CompletionReward is what a user receives the first time they complete a collection
+type CompletionReward struct {
+	Flakes       int64  `toml:"flakes"`
+	Vials        int64  `toml:"vials"`
+	ItemID       string `toml:"item_id"`
+	ItemQuantity int    `toml:"item_quantity"`
+}
+
+// IsZero reports whether the reward grants nothing
+func (r CompletionReward) IsZero() bool {
+	return r.Flakes == 0 && r.Vials == 0 && (r.ItemID == "" || r.ItemQuantity <= 0)
+}
+
+// CompletionRewards maps collection IDs to their reward, with Default used for
+// collections that have no entry of their own
+type CompletionRewards struct {
+	Default     CompletionReward            `toml:"default"`
+	Collections map[string]CompletionReward `toml:"collections"`
+}
+
+// For returns the reward configured for a collection
+func (r CompletionRewards) For(collectionID string) (CompletionReward, bool) {
+	if reward, ok := r.Collections[collectionID]; ok {
+		return reward, !reward.IsZero()
+	}
+	return r.Default, !r.Default.IsZero()
+}
+
+// formatCompletionReward renders a granted reward for the completion DM
+func formatCompletionReward(grant *models.CompletionRewardGrant) string {
+	var parts []string
+	if grant.Flakes != 0 {
+		parts = append(parts, fmt.Sprintf("❄️ **%d** Flakes", grant.Flakes))
+	}
+	if grant.Vials != 0 {
+		parts = append(parts, fmt.Sprintf("🍷 **%d** Vials", grant.Vials))
+	}
+	if grant.ItemID != "" && grant.ItemQuantity > 0 {
+		parts = append(parts, fmt.Sprintf("**%dx** %s", grant.ItemQuantity, grant.ItemID))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// onceRewardRepo grants each user/collection pair once, like the unique index does
+type onceRewardRepo struct {
+	mu      sync.Mutex
+	granted map[string]*models.CompletionRewardGrant
+}
+
+func (r *onceRewardRepo) Grant(ctx context.Context, grant *models.CompletionRewardGrant) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := grant.UserID + "/" + grant.CollectionID
+	if _, ok := r.granted[key]; ok {
+		return false, nil
+	}
+	r.granted[key] = grant
+	return true, nil
+}
+
+func TestCompletionRewardsFor(t *testing.T) {
+	rewards := CompletionRewards{
+		Default: CompletionReward{Flakes: 1000},
+		Collections: map[string]CompletionReward{
+			"twice":    {Vials: 50, ItemID: "album", ItemQuantity: 1},
+			"promos":   {}, // explicitly unrewarded
+			"itemless": {ItemID: "album"},
+		},
+	}
+
+	tests := []struct {
+		collectionID string
+		want         CompletionReward
+		wantOK       bool
+	}{
+		{"twice", CompletionReward{Vials: 50, ItemID: "album", ItemQuantity: 1}, true},
+		{"aespa", CompletionReward{Flakes: 1000}, true},
+		{"promos", CompletionReward{}, false},
+		{"itemless", CompletionReward{ItemID: "album"}, false},
+	}
+	for _, tt := range tests {
+		got, ok := rewards.For(tt.collectionID)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("For(%q) = %+v, %v, want %+v, %v", tt.collectionID, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if _, ok := (CompletionRewards{}).For("twice"); ok {
+		t.Error("empty config granted a reward")
+	}
+}
+
+func TestGrantCompletionRewardOnce(t *testing.T) {
+	repo := &onceRewardRepo{granted: make(map[string]*models.CompletionRewardGrant)}
+	s := (&CompletionCheckerService{}).WithRewards(repo, CompletionRewards{
+		Default: CompletionReward{Flakes: 1000, Vials: 20},
+	})
+
+	var wg sync.WaitGroup
+	grants := make(chan *models.CompletionRewardGrant, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if grant := s.grantCompletionReward(context.Background(), "u1", "twice"); grant != nil {
+				grants <- grant
+			}
+		}()
+	}
+	wg.Wait()
+	close(grants)
+
+	var n int
+	for grant := range grants {
+		n++
+		if grant.Flakes != 1000 || grant.Vials != 20 {
+			t.Errorf("grant = %+v, want 1000 flakes and 20 vials", grant)
+		}
+	}
+	if n != 1 {
+		t.Errorf("granted %d times, want once", n)
+	}
+
+	if grant := s.grantCompletionReward(context.Background(), "u1", "aespa"); grant == nil {
+		t.Error("another collection was not rewarded")
+	}
+	if grant := (&CompletionCheckerService{}).grantCompletionReward(context.Background(), "u1", "itzy"); grant != nil {
+		t.Error("rewarded without a reward repository")
+	}
+}
+
+func TestFormatCompletionReward(t *testing.T) {
+	got := formatCompletionReward(&models.CompletionRewardGrant{Flakes: 500, ItemID: "album", ItemQuantity: 2})
+	if want := "❄️ **500** Flakes, **2x** album"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
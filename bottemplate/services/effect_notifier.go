@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// EffectNotifier sends effect related DMs to users
+type EffectNotifier struct {
+	client bot.Client
+	users  repositories.UserRepository
+}
+
+// NewEffectNotifier creates a new effect notifier. users is consulted for notification
+// preferences; nil sends every DM.
+func NewEffectNotifier(client bot.Client, users repositories.UserRepository) *EffectNotifier {
+	return &EffectNotifier{client: client, users: users}
+}
+
+// NotifyEffectExpired DMs a user that one of their passive effects has expired,
+// unless they turned effect expiry DMs off
+func (n *EffectNotifier) NotifyEffectExpired(ctx context.Context, userID string, effectName string) error {
+	if n.users != nil {
+		user, err := n.users.GetByDiscordID(ctx, userID)
+		if err == nil && !user.NotificationSettings().EffectEnd {
+			return nil
+		}
+	}
+
+	discordID, err := snowflake.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID %q: %w", userID, err)
+	}
+
+	dmChannel, err := n.client.Rest().CreateDMChannel(discordID)
+	if err != nil {
+		return fmt.Errorf("failed to create DM channel: %w", err)
+	}
+
+	embed := discord.NewEmbedBuilder().
+		SetDescription(fmt.Sprintf("⌛ **Effect Expired**\n\nYour effect `%s` has expired and is no longer active.", effectName)).
+		SetColor(0xFF9900).
+		SetTimestamp(time.Now()).
+		Build()
+
+	if _, err := n.client.Rest().CreateMessage(dmChannel.ID(), discord.MessageCreate{
+		Embeds: []discord.Embed{embed},
+	}); err != nil {
+		return fmt.Errorf("failed to send DM: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// guildSettingsTTL bounds how long cached guild settings are trusted; updates made through
+// the gate invalidate the cache immediately
+const guildSettingsTTL = time.Minute
+
+type cachedGuildSettings struct {
+	settings *models.GuildSettings
+	loadedAt time.Time
+}
+
+// GuildCommandGate decides whether a command may run in a guild based on the guild's
+// enabled command categories
+type GuildCommandGate struct {
+	repo       repositories.GuildSettingsRepository
+	categories map[string]string // command name -> category
+	alwaysOn   map[string]bool   // categories that can never be disabled
+
+	mu    sync.RWMutex
+	cache map[string]cachedGuildSettings
+}
+
+// NewGuildCommandGate creates a gate. categories maps command names to their category and
+// alwaysOn lists categories that stay enabled regardless of guild settings.
+func NewGuildCommandGate(repo repositories.GuildSettingsRepository, categories map[string]string, alwaysOn ...string) *GuildCommandGate {
+	g := &GuildCommandGate{
+		repo:       repo,
+		categories: categories,
+		alwaysOn:   make(map[string]bool, len(alwaysOn)),
+		cache:      make(map[string]cachedGuildSettings),
+	}
+	for _, category := range alwaysOn {
+		g.alwaysOn[category] = true
+	}
+	return g
+}
+
+// CategoryOf returns the category of a command, or "" when it is unknown
+func (g *GuildCommandGate) CategoryOf(command string) string {
+	return g.categories[command]
+}
+
+// IsToggleable reports whether guilds may disable the category
+func (g *GuildCommandGate) IsToggleable(category string) bool {
+	return category != "" && !g.alwaysOn[category]
+}
+
+// CommandAllowed reports whether the command may run in the guild. Lookup failures fail
+// open so a database hiccup never locks everyone out of the bot.
+func (g *GuildCommandGate) CommandAllowed(ctx context.Context, guildID, command string) bool {
+	category := g.CategoryOf(command)
+	if !g.IsToggleable(category) {
+		return true
+	}
+
+	settings, err := g.Settings(ctx, guildID)
+	if err != nil {
+		slog.Warn("Failed to load guild settings, allowing command",
+			slog.String("guild_id", guildID),
+			slog.String("command", command),
+			slog.Any("error", err))
+		return true
+	}
+	return settings.IsCategoryEnabled(category)
+}
+
+// Settings returns the guild's settings, served from cache when fresh
+func (g *GuildCommandGate) Settings(ctx context.Context, guildID string) (*models.GuildSettings, error) {
+	g.mu.RLock()
+	cached, ok := g.cache[guildID]
+	g.mu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < guildSettingsTTL {
+		return cached.settings, nil
+	}
+
+	settings, err := g.repo.Get(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	g.store(guildID, settings)
+	return settings, nil
+}
+
+// SetCategoryEnabled persists a category toggle and refreshes the cached settings
+func (g *GuildCommandGate) SetCategoryEnabled(ctx context.Context, guildID, category string, enabled bool, updatedBy string) (*models.GuildSettings, error) {
+	settings, err := g.repo.SetCategoryEnabled(ctx, guildID, category, enabled, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	g.store(guildID, settings)
+	return settings, nil
+}
+
+func (g *GuildCommandGate) store(guildID string, settings *models.GuildSettings) {
+	g.mu.Lock()
+	g.cache[guildID] = cachedGuildSettings{settings: settings, loadedAt: time.Now()}
+	g.mu.Unlock()
+}
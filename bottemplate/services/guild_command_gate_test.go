@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// fakeGuildSettingsRepo serves settings rows by guild and counts lookups
+type fakeGuildSettingsRepo struct {
+	settings map[string]*models.GuildSettings
+	err      error
+	gets     int
+}
+
+func (r *fakeGuildSettingsRepo) Get(ctx context.Context, guildID string) (*models.GuildSettings, error) {
+	r.gets++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.settings[guildID], nil
+}
+
+func (r *fakeGuildSettingsRepo) SetCategoryEnabled(ctx context.Context, guildID, category string, enabled bool, updatedBy string) (*models.GuildSettings, error) {
+	settings := r.settings[guildID]
+	if settings == nil {
+		settings = &models.GuildSettings{GuildID: guildID}
+		r.settings[guildID] = settings
+	}
+	kept := settings.DisabledCategories[:0]
+	for _, disabled := range settings.DisabledCategories {
+		if disabled != category {
+			kept = append(kept, disabled)
+		}
+	}
+	if !enabled {
+		kept = append(kept, category)
+	}
+	settings.DisabledCategories = kept
+	settings.UpdatedBy = updatedBy
+	return settings, nil
+}
+
+var testCommandCategories = map[string]string{
+	"claim":        "cards",
+	"daily":        "economy",
+	"help":         "system",
+	"guild-config": "admin",
+}
+
+func TestGuildCommandGateCommandAllowed(t *testing.T) {
+	repo := &fakeGuildSettingsRepo{settings: map[string]*models.GuildSettings{
+		"quiet": {GuildID: "quiet", DisabledCategories: []string{"cards", "system", "admin"}},
+	}}
+	gate := NewGuildCommandGate(repo, testCommandCategories, "admin", "system")
+
+	tests := []struct {
+		guildID string
+		command string
+		want    bool
+	}{
+		{"quiet", "claim", false},
+		{"quiet", "daily", true},
+		{"quiet", "help", true},         // system can't be disabled
+		{"quiet", "guild-config", true}, // nor can admin
+		{"quiet", "unknown", true},
+		{"fresh", "claim", true}, // no settings row
+	}
+	for _, tt := range tests {
+		if got := gate.CommandAllowed(context.Background(), tt.guildID, tt.command); got != tt.want {
+			t.Errorf("CommandAllowed(%s, %s) = %v, want %v", tt.guildID, tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestGuildCommandGateCachesSettings(t *testing.T) {
+	repo := &fakeGuildSettingsRepo{settings: map[string]*models.GuildSettings{}}
+	gate := NewGuildCommandGate(repo, testCommandCategories, "admin", "system")
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		gate.CommandAllowed(ctx, "g1", "claim")
+	}
+	if repo.gets != 1 {
+		t.Errorf("loaded settings %d times, want 1", repo.gets)
+	}
+
+	// A toggle through the gate is visible right away
+	if _, err := gate.SetCategoryEnabled(ctx, "g1", "cards", false, "admin1"); err != nil {
+		t.Fatalf("SetCategoryEnabled: %v", err)
+	}
+	if gate.CommandAllowed(ctx, "g1", "claim") {
+		t.Error("claim allowed after disabling cards")
+	}
+	if _, err := gate.SetCategoryEnabled(ctx, "g1", "cards", true, "admin1"); err != nil {
+		t.Fatalf("SetCategoryEnabled: %v", err)
+	}
+	if !gate.CommandAllowed(ctx, "g1", "claim") {
+		t.Error("claim blocked after re-enabling cards")
+	}
+	if repo.gets != 1 {
+		t.Errorf("loaded settings %d times, want the cache to be refreshed by toggles", repo.gets)
+	}
+}
+
+func TestGuildCommandGateFailsOpen(t *testing.T) {
+	repo := &fakeGuildSettingsRepo{err: errors.New("connection refused")}
+	gate := NewGuildCommandGate(repo, testCommandCategories, "admin", "system")
+
+	if !gate.CommandAllowed(context.Background(), "g1", "claim") {
+		t.Error("command blocked when settings couldn't be loaded")
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrKofiCurrency is returned for payments in a currency with no configured rate
+var ErrKofiCurrency = errors.New("no rate configured for currency")
+
+// KofiReward is what a Ko-fi supporter receives for one payment
+type KofiReward struct {
+	Flakes        int64 `toml:"flakes"`          // paid for every payment
+	FlakesPerUnit int64 `toml:"flakes_per_unit"` // paid per whole unit given, after converting with the currency rate
+	PremiumDays   int   `toml:"premium_days"`    // added to the supporter's premium
+}
+
+// FlakesFor returns the flakes paid for a payment of units, already converted with the currency rate
+func (r KofiReward) FlakesFor(units float64) int64 {
+	if units < 0 {
+		units = 0
+	}
+	return r.Flakes + r.FlakesPerUnit*int64(units)
+}
+
+func (r KofiReward) validate(key string) error {
+	if r.Flakes < 0 {
+		return fmt.Errorf("%s.flakes must not be negative, got %d", key, r.Flakes)
+	}
+	if r.FlakesPerUnit < 0 {
+		return fmt.Errorf("%s.flakes_per_unit must not be negative, got %d", key, r.FlakesPerUnit)
+	}
+	if r.PremiumDays < 0 {
+		return fmt.Errorf("%s.premium_days must not be negative, got %d", key, r.PremiumDays)
+	}
+	return nil
+}
+
+// KofiRewards maps Ko-fi membership tier names to their reward, with Default used
+// for one-off donations and tiers that have no entry of their own. CurrencyRates
+// converts one unit of each accepted currency code into the units flakes_per_unit is priced in.
+type KofiRewards struct {
+	Default       KofiReward            `toml:"default"`
+	Tiers         map[string]KofiReward `toml:"tiers"`
+	CurrencyRates map[string]float64    `toml:"currency_rates"`
+}
+
+// For returns the reward for a payment in the given membership tier; "" for donations
+func (r KofiRewards) For(tierName string) KofiReward {
+	if reward, ok := r.Tiers[tierName]; ok && tierName != "" {
+		return reward
+	}
+	return r.Default
+}
+
+// FlakesFor returns the flakes reward pays for a payment of amount in currency.
+// Payments in a currency without a rate are rejected with ErrKofiCurrency when the
+// reward pays per unit, since the amount can't be compared across currencies.
+func (r KofiRewards) FlakesFor(reward KofiReward, amount float64, currency string) (int64, error) {
+	if reward.FlakesPerUnit == 0 {
+		return reward.FlakesFor(0), nil
+	}
+	rate, ok := r.rate(currency)
+	if !ok {
+		return 0, fmt.Errorf("%w %q", ErrKofiCurrency, currency)
+	}
+	return reward.FlakesFor(amount * rate), nil
+}
+
+// rate looks up a currency code case-insensitively
+func (r KofiRewards) rate(currency string) (float64, bool) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	for code, rate := range r.CurrencyRates {
+		if strings.ToUpper(code) == currency {
+			return rate, true
+		}
+	}
+	return 0, false
+}
+
+// Validate reports the first inconsistency in the rewards
+func (r KofiRewards) Validate() error {
+	if err := r.Default.validate("default"); err != nil {
+		return err
+	}
+	perUnit := r.Default.FlakesPerUnit > 0
+	for _, name := range slices.Sorted(maps.Keys(r.Tiers)) {
+		if err := r.Tiers[name].validate(fmt.Sprintf("tiers.%q", name)); err != nil {
+			return err
+		}
+		perUnit = perUnit || r.Tiers[name].FlakesPerUnit > 0
+	}
+	for _, code := range slices.Sorted(maps.Keys(r.CurrencyRates)) {
+		if r.CurrencyRates[code] <= 0 {
+			return fmt.Errorf("currency_rates.%s must be positive, got %g", code, r.CurrencyRates[code])
+		}
+	}
+	if perUnit && len(r.CurrencyRates) == 0 {
+		return fmt.Errorf("currency_rates must list at least one currency when flakes_per_unit is set")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestKofiRewardsFor(t *testing.T) {
+	rewards := KofiRewards{
+		Default: KofiReward{Flakes: 100},
+		Tiers:   map[string]KofiReward{"Gold": {Flakes: 500, PremiumDays: 30}},
+	}
+	if got := rewards.For("Gold"); got.Flakes != 500 || got.PremiumDays != 30 {
+		t.Errorf("For(Gold) = %+v", got)
+	}
+	if got := rewards.For("Silver"); got != rewards.Default {
+		t.Errorf("For(Silver) = %+v, want the default", got)
+	}
+	if got := rewards.For(""); got != rewards.Default {
+		t.Errorf("For(donation) = %+v, want the default", got)
+	}
+}
+
+func TestKofiRewardsFlakesForCurrency(t *testing.T) {
+	rewards := KofiRewards{CurrencyRates: map[string]float64{"USD": 1, "eur": 1.1}}
+	perUnit := KofiReward{Flakes: 50, FlakesPerUnit: 100}
+
+	tests := []struct {
+		name     string
+		reward   KofiReward
+		amount   float64
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{name: "base currency", reward: perUnit, amount: 5, currency: "USD", want: 550},
+		// Codes match case-insensitively and partial units are dropped after converting
+		{name: "converted", reward: perUnit, amount: 5, currency: " EUR", want: 50 + 100*5},
+		{name: "converted up", reward: perUnit, amount: 10, currency: "EUR", want: 50 + 100*11},
+		{name: "unknown currency", reward: perUnit, amount: 5, currency: "JPY", wantErr: true},
+		// A flat reward doesn't depend on the amount, so any currency is fine
+		{name: "flat reward", reward: KofiReward{Flakes: 50}, amount: 500, currency: "JPY", want: 50},
+		{name: "negative amount", reward: perUnit, amount: -5, currency: "USD", want: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rewards.FlakesFor(tt.reward, tt.amount, tt.currency)
+			if tt.wantErr {
+				if !errors.Is(err, ErrKofiCurrency) {
+					t.Errorf("FlakesFor = %d, %v, want ErrKofiCurrency", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("FlakesFor = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestKofiRewardsValidate(t *testing.T) {
+	valid := KofiRewards{Default: KofiReward{FlakesPerUnit: 10}, CurrencyRates: map[string]float64{"USD": 1}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	invalid := []KofiRewards{
+		{Default: KofiReward{Flakes: -1}},
+		{Tiers: map[string]KofiReward{"Gold": {PremiumDays: -1}}},
+		{CurrencyRates: map[string]float64{"USD": 0}},
+		// Per-unit rewards need at least one currency to price them in
+		{Tiers: map[string]KofiReward{"Gold": {FlakesPerUnit: 10}}},
+	}
+	for _, rewards := range invalid {
+		if err := rewards.Validate(); err == nil {
+			t.Errorf("Validate accepted %+v", rewards)
+		}
+	}
+}
+
+func TestExtendedPremiumExpiry(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	const month = 30 * 24 * time.Hour
+
+	running := &models.User{Premium: true, PremiumExpires: now.Add(24 * time.Hour)}
+	if got := running.ExtendedPremiumExpiry(month, now); !got.Equal(now.Add(24*time.Hour + month)) {
+		t.Errorf("running premium extended to %v", got)
+	}
+	lapsed := &models.User{Premium: true, PremiumExpires: now.Add(-24 * time.Hour)}
+	if got := lapsed.ExtendedPremiumExpiry(month, now); !got.Equal(now.Add(month)) {
+		t.Errorf("lapsed premium extended to %v, want from now", got)
+	}
+	if got := (&models.User{}).ExtendedPremiumExpiry(month, now); !got.Equal(now.Add(month)) {
+		t.Errorf("new premium expires %v, want from now", got)
+	}
+	if got := (&models.User{Premium: true}).ExtendedPremiumExpiry(month, now); !got.IsZero() {
+		t.Errorf("lifetime premium expires %v, want never", got)
+	}
+}
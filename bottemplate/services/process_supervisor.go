@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// ProcessSupervisorName is the background process name the supervisor runs under
+const ProcessSupervisorName = "process-supervisor"
+
+// DefaultProcessSyncInterval is how often process state is published and restart requests are picked up
+const DefaultProcessSyncInterval = 30 * time.Second
+
+// ProcessSupervisor mirrors the bot's background processes into the database so
+// the web dashboard can inspect them, and applies restarts requested from there
+type ProcessSupervisor struct {
+	manager  *utils.BackgroundProcessManager
+	repo     repositories.BackgroundProcessRepository
+	interval time.Duration
+}
+
+func NewProcessSupervisor(manager *utils.BackgroundProcessManager, repo repositories.BackgroundProcessRepository, interval time.Duration) *ProcessSupervisor {
+	if interval <= 0 {
+		interval = DefaultProcessSyncInterval
+	}
+	return &ProcessSupervisor{
+		manager:  manager,
+		repo:     repo,
+		interval: interval,
+	}
+}
+
+// Run syncs on every tick until ctx is cancelled
+func (s *ProcessSupervisor) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.Sync(ctx)
+		if err != nil {
+			slog.Error("Failed to sync background processes", slog.Any("error", err))
+		}
+		utils.RecordProcessRun(ctx, err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Sync restarts processes flagged from the dashboard, then publishes the
+// current state of every process
+func (s *ProcessSupervisor) Sync(ctx context.Context) error {
+	names, err := s.repo.TakeRestartRequests(ctx)
+	if err != nil {
+		return err
+	}
+	var restartErr error
+	for _, name := range names {
+		if name == ProcessSupervisorName {
+			// Restarting ourselves from inside Sync would wait on our own exit
+			slog.Warn("Ignoring restart request for the process supervisor")
+			continue
+		}
+		if err := s.manager.RestartProcess(name); err != nil {
+			slog.Error("Failed to restart background process",
+				slog.String("process", name),
+				slog.Any("error", err))
+			restartErr = errors.Join(restartErr, err)
+		}
+	}
+
+	statuses := s.manager.ListProcesses()
+	snapshots := make([]*models.BackgroundProcess, len(statuses))
+	for i, status := range statuses {
+		snapshots[i] = &models.BackgroundProcess{
+			Name:        status.Name,
+			Description: status.Description,
+			Status:      status.Status,
+			StartedAt:   status.StartedAt,
+			Restarts:    status.Restarts,
+			LastRun:     status.LastRun,
+			LastSuccess: status.LastSuccess,
+			LastError:   status.LastError,
+			LastErrorAt: status.LastErrorAt,
+		}
+	}
+	if err := s.repo.SaveSnapshots(ctx, snapshots); err != nil {
+		return errors.Join(restartErr, err)
+	}
+	return restartErr
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// fakeProcessRepo hands out queued restart requests and keeps the last snapshots
+type fakeProcessRepo struct {
+	requests  []string
+	snapshots []*models.BackgroundProcess
+}
+
+func (r *fakeProcessRepo) SaveSnapshots(ctx context.Context, processes []*models.BackgroundProcess) error {
+	r.snapshots = processes
+	return nil
+}
+
+func (r *fakeProcessRepo) List(ctx context.Context) ([]*models.BackgroundProcess, error) {
+	return r.snapshots, nil
+}
+
+func (r *fakeProcessRepo) RequestRestart(ctx context.Context, name string) error {
+	r.requests = append(r.requests, name)
+	return nil
+}
+
+func (r *fakeProcessRepo) TakeRestartRequests(ctx context.Context) ([]string, error) {
+	requests := r.requests
+	r.requests = nil
+	return requests, nil
+}
+
+func TestProcessSupervisorAppliesRestartRequests(t *testing.T) {
+	manager := utils.NewBackgroundProcessManager()
+	t.Cleanup(func() { manager.Shutdown(context.Background()) })
+
+	runs := make(chan struct{}, 2)
+	manager.StartProcess("sweeper", "sweeps things", func(ctx context.Context) {
+		runs <- struct{}{}
+		<-ctx.Done()
+	})
+	<-runs
+
+	repo := &fakeProcessRepo{}
+	supervisor := NewProcessSupervisor(manager, repo, 0)
+	ctx := context.Background()
+
+	// The dashboard asks for a restart of the sweeper, of the supervisor itself and of a process that doesn't exist
+	for _, name := range []string{"sweeper", ProcessSupervisorName, "missing"} {
+		repo.RequestRestart(ctx, name)
+	}
+	err := supervisor.Sync(ctx)
+	if !errors.Is(err, utils.ErrProcessNotFound) {
+		t.Errorf("Sync error = %v, want the unknown process reported", err)
+	}
+	<-runs
+
+	if len(repo.snapshots) != 1 {
+		t.Fatalf("saved %d snapshots, want 1", len(repo.snapshots))
+	}
+	if snapshot := repo.snapshots[0]; snapshot.Name != "sweeper" || snapshot.Restarts != 1 || snapshot.Status != utils.ProcessStatusRunning {
+		t.Errorf("snapshot = %+v, want sweeper running after one restart", snapshot)
+	}
+
+	// Requests are consumed, so the next sync doesn't restart again
+	if err := supervisor.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if restarts := repo.snapshots[0].Restarts; restarts != 1 {
+		t.Errorf("restarts = %d after a second sync, want 1", restarts)
+	}
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// DefaultPromoExpDecayInterval is used when no decay sweep interval is configured
+const DefaultPromoExpDecayInterval = time.Hour
+
+// PromoExpDecayer clears promo EXP once the configured promo period has passed
+type PromoExpDecayer struct {
+	users    repositories.UserRepository
+	window   time.Duration
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewPromoExpDecayer creates a decayer that lets promo EXP last for window
+func NewPromoExpDecayer(users repositories.UserRepository, window, interval time.Duration) *PromoExpDecayer {
+	if interval <= 0 {
+		interval = DefaultPromoExpDecayInterval
+	}
+	return &PromoExpDecayer{
+		users:    users,
+		window:   window,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run decays immediately and then on every interval until ctx is cancelled
+func (d *PromoExpDecayer) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := d.Decay(ctx)
+		if err != nil {
+			slog.Error("Failed to decay promo exp", slog.Any("error", err))
+		}
+		utils.RecordProcessRun(ctx, err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Decay runs one pass: promo EXP past its expiry is cleared and promo EXP without
+// an expiry gets one. A second pass at the same time changes nothing.
+func (d *PromoExpDecayer) Decay(ctx context.Context) (repositories.PromoExpDecayResult, error) {
+	result, err := d.users.DecayPromoExp(ctx, d.window, d.now())
+	if err != nil {
+		return result, err
+	}
+
+	if result.Expired > 0 || result.Scheduled > 0 {
+		slog.Info("Decayed promo exp",
+			slog.Int64("expired_users", result.Expired),
+			slog.Int64("scheduled_users", result.Scheduled),
+			slog.Duration("window", d.window))
+	}
+	return result, nil
+}
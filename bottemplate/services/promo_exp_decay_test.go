@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// decayUserRepo records the DecayPromoExp calls and answers with a fixed result
+type decayUserRepo struct {
+	repositories.UserRepository
+	result  repositories.PromoExpDecayResult
+	err     error
+	windows []time.Duration
+	times   []time.Time
+}
+
+func (r *decayUserRepo) DecayPromoExp(ctx context.Context, window time.Duration, now time.Time) (repositories.PromoExpDecayResult, error) {
+	r.windows = append(r.windows, window)
+	r.times = append(r.times, now)
+	return r.result, r.err
+}
+
+func TestPromoExpDecayerDecay(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo := &decayUserRepo{result: repositories.PromoExpDecayResult{Expired: 2, Scheduled: 1}}
+	d := NewPromoExpDecayer(repo, 7*24*time.Hour, 0)
+	d.now = func() time.Time { return now }
+
+	if d.interval != DefaultPromoExpDecayInterval {
+		t.Errorf("interval = %v, want the default", d.interval)
+	}
+
+	result, err := d.Decay(context.Background())
+	if err != nil || result != repo.result {
+		t.Fatalf("Decay = %+v, %v, want %+v", result, err, repo.result)
+	}
+	if len(repo.windows) != 1 || repo.windows[0] != 7*24*time.Hour || !repo.times[0].Equal(now) {
+		t.Errorf("DecayPromoExp called with %v at %v", repo.windows, repo.times)
+	}
+
+	repo.err = errors.New("database down")
+	if _, err := d.Decay(context.Background()); !errors.Is(err, repo.err) {
+		t.Errorf("Decay = %v, want the repository error", err)
+	}
+}
+
+func TestPromoExpDecayerRunStopsOnCancel(t *testing.T) {
+	repo := &decayUserRepo{}
+	d := NewPromoExpDecayer(repo, time.Hour, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d.Run(ctx)
+
+	// Run decays once right away before it waits for the ticker
+	if len(repo.times) != 1 {
+		t.Errorf("%d decay passes, want 1", len(repo.times))
+	}
+}
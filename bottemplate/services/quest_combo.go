@@ -0,0 +1,141 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// comboProgressKey is the UserQuestProgress.Metadata key holding per-action combo counts
+const comboProgressKey = "combo_progress"
+
+// ComboStep is the progress of a single sub-target of a combo quest
+type ComboStep struct {
+	Action   string
+	Current  int
+	Required int
+}
+
+// Met reports whether the sub-target has been reached
+func (s ComboStep) Met() bool {
+	return s.Current >= s.Required
+}
+
+// comboTargets reads the required count per action from a combo quest's requirement metadata,
+// e.g. {"claim": 8, "work": 3, "levelup": 10, "auction_create": 1}
+func comboTargets(requirements map[string]interface{}) map[string]int {
+	targets := make(map[string]int, len(requirements))
+	for action, raw := range requirements {
+		if count, ok := metadataInt(raw); ok && count > 0 {
+			targets[action] = count
+		}
+	}
+	return targets
+}
+
+// comboCounts reads the recorded per-action counts from quest progress metadata. Values
+// round-trip through JSONB as float64, but may still be ints before the row is reloaded.
+func comboCounts(metadata map[string]interface{}) map[string]int {
+	counts := make(map[string]int)
+	switch data := metadata[comboProgressKey].(type) {
+	case map[string]int:
+		for action, count := range data {
+			counts[action] = count
+		}
+	case map[string]interface{}:
+		for action, raw := range data {
+			if count, ok := metadataInt(raw); ok {
+				counts[action] = count
+			}
+		}
+	}
+	return counts
+}
+
+func metadataInt(raw interface{}) (int, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+// applyComboEvent records one occurrence of action against the combo targets. It returns
+// false when the action is not part of the combo or its sub-target is already met.
+func applyComboEvent(targets, counts map[string]int, action string) bool {
+	required, ok := targets[action]
+	if !ok || counts[action] >= required {
+		return false
+	}
+	counts[action]++
+	return true
+}
+
+// comboStepsMet returns how many sub-targets are met and whether all of them are
+func comboStepsMet(targets, counts map[string]int) (int, bool) {
+	met := 0
+	for action, required := range targets {
+		if counts[action] >= required {
+			met++
+		}
+	}
+	return met, met == len(targets)
+}
+
+// comboQuestProgress maps combo state onto CurrentProgress. Each met sub-target counts as one
+// step, but the quest only reaches its requirement count once every sub-target is met.
+func comboQuestProgress(requirementCount, met int, allMet bool) int {
+	if requirementCount <= 0 {
+		return met
+	}
+	if allMet {
+		return requirementCount
+	}
+	if met >= requirementCount {
+		return requirementCount - 1
+	}
+	return met
+}
+
+// GetComboSteps returns the per-action progress of a combo quest ordered by action name, or
+// nil when the quest is not a combo quest
+func GetComboSteps(quest *models.UserQuestProgress) []ComboStep {
+	if quest == nil || quest.QuestDefinition == nil ||
+		quest.QuestDefinition.RequirementType != models.RequirementTypeCombo {
+		return nil
+	}
+
+	targets := comboTargets(quest.QuestDefinition.RequirementMetadata)
+	counts := comboCounts(quest.Metadata)
+
+	steps := make([]ComboStep, 0, len(targets))
+	for action, required := range targets {
+		current := counts[action]
+		if current > required {
+			current = required
+		}
+		steps = append(steps, ComboStep{Action: action, Current: current, Required: required})
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].Action < steps[j].Action })
+	return steps
+}
+
+// FormatComboSteps renders combo sub-targets as a compact single line
+func FormatComboSteps(steps []ComboStep) string {
+	parts := make([]string, len(steps))
+	for i, step := range steps {
+		mark := ""
+		if step.Met() {
+			mark = " ✓"
+		}
+		parts[i] = fmt.Sprintf("%s %d/%d%s", strings.ReplaceAll(step.Action, "_", " "), step.Current, step.Required, mark)
+	}
+	return strings.Join(parts, " • ")
+}
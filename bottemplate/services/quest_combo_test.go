@@ -0,0 +1,105 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func comboQuest(requirementCount int) *models.UserQuestProgress {
+	return &models.UserQuestProgress{
+		QuestID: "weekly_combo",
+		QuestDefinition: &models.QuestDefinition{
+			QuestID:          "weekly_combo",
+			RequirementType:  models.RequirementTypeCombo,
+			RequirementCount: requirementCount,
+			// Counts come back from JSONB as float64
+			RequirementMetadata: map[string]interface{}{"claim": 2.0, "work": 1.0, "auction_create": 1.0},
+		},
+	}
+}
+
+func TestTrackComboProgress(t *testing.T) {
+	qs := NewQuestService(nil, nil)
+	quest := comboQuest(3)
+
+	steps := []struct {
+		action       string
+		wantUpdated  bool
+		wantProgress int
+	}{
+		{"claim", true, 0},
+		{"levelup", false, 0}, // not part of the combo
+		{"claim", true, 1},
+		{"claim", false, 1}, // sub-target already met
+		{"work", true, 2},
+		{"auction_create", true, 3},
+	}
+
+	for i, step := range steps {
+		updated := qs.trackComboProgress(quest, step.action)
+		if updated != step.wantUpdated || quest.CurrentProgress != step.wantProgress {
+			t.Fatalf("step %d (%s): updated = %v, progress = %d, want %v, %d",
+				i, step.action, updated, quest.CurrentProgress, step.wantUpdated, step.wantProgress)
+		}
+	}
+}
+
+func TestTrackComboProgressWaitsForEverySubTarget(t *testing.T) {
+	qs := NewQuestService(nil, nil)
+	// Fewer steps than sub-targets: two met must not already complete the quest
+	quest := comboQuest(2)
+
+	qs.trackComboProgress(quest, "work")
+	qs.trackComboProgress(quest, "auction_create")
+	if quest.CurrentProgress != 1 {
+		t.Fatalf("progress with two of three sub-targets = %d, want 1", quest.CurrentProgress)
+	}
+
+	qs.trackComboProgress(quest, "claim")
+	qs.trackComboProgress(quest, "claim")
+	if quest.CurrentProgress != 2 {
+		t.Errorf("progress with every sub-target = %d, want 2", quest.CurrentProgress)
+	}
+}
+
+func TestTrackComboProgressResumesFromJSONB(t *testing.T) {
+	qs := NewQuestService(nil, nil)
+	quest := comboQuest(3)
+	quest.Metadata = map[string]interface{}{
+		comboProgressKey: map[string]interface{}{"claim": 2.0, "work": 1.0},
+	}
+
+	if !qs.trackComboProgress(quest, "auction_create") {
+		t.Fatal("auction_create was not recorded")
+	}
+	if quest.CurrentProgress != 3 {
+		t.Errorf("progress = %d, want 3", quest.CurrentProgress)
+	}
+}
+
+func TestGetComboSteps(t *testing.T) {
+	quest := comboQuest(3)
+	quest.Metadata = map[string]interface{}{
+		comboProgressKey: map[string]interface{}{"claim": 5.0, "work": 0.0},
+	}
+
+	want := []ComboStep{
+		{Action: "auction_create", Current: 0, Required: 1},
+		{Action: "claim", Current: 2, Required: 2}, // capped at the requirement
+		{Action: "work", Current: 0, Required: 1},
+	}
+	steps := GetComboSteps(quest)
+	if !reflect.DeepEqual(steps, want) {
+		t.Fatalf("GetComboSteps = %+v, want %+v", steps, want)
+	}
+	if got := FormatComboSteps(steps); got != "auction create 0/1 • claim 2/2 ✓ • work 0/1" {
+		t.Errorf("FormatComboSteps = %q", got)
+	}
+
+	quest.QuestDefinition.RequirementType = models.RequirementTypeCardClaim
+	if steps := GetComboSteps(quest); steps != nil {
+		t.Errorf("GetComboSteps for a claim quest = %+v, want nil", steps)
+	}
+}
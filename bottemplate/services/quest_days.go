@@ -0,0 +1,103 @@
+package services
+
+import (
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// questDayLayout formats the UTC day buckets stored in day-tracking quest metadata
+const questDayLayout = "2006-01-02"
+
+// tracksDays reports whether a quest counts distinct days rather than individual actions
+func tracksDays(def *models.QuestDefinition) bool {
+	if def == nil || def.RequirementMetadata == nil {
+		return false
+	}
+	trackDays, ok := def.RequirementMetadata["track_days"].(bool)
+	return ok && trackDays
+}
+
+// questDayKey buckets a moment into its UTC calendar day, so DST shifts in the server's
+// local zone never split or merge days
+func questDayKey(t time.Time) string {
+	return t.UTC().Format(questDayLayout)
+}
+
+// questPeriodStart derives the start of the period a quest belongs to from its expiry, which
+// is always the next daily/weekly/monthly reset. Calendar arithmetic keeps DST transitions
+// inside the period from shifting the boundary.
+func questPeriodStart(questType string, expiresAt time.Time) time.Time {
+	switch questType {
+	case models.QuestTypeDaily:
+		return expiresAt.AddDate(0, 0, -1)
+	case models.QuestTypeWeekly:
+		return expiresAt.AddDate(0, 0, -7)
+	case models.QuestTypeMonthly:
+		return expiresAt.AddDate(0, -1, 0)
+	default:
+		return expiresAt.Add(-24 * time.Hour)
+	}
+}
+
+// trackedDays reads a day set from quest metadata, dropping days outside [periodStart, periodEnd)
+func trackedDays(raw interface{}, periodStart, periodEnd time.Time) map[string]bool {
+	first, last := questDayKey(periodStart), questDayKey(periodEnd.Add(-time.Nanosecond))
+
+	days := make(map[string]bool)
+	add := func(day string) {
+		if day >= first && day <= last {
+			days[day] = true
+		}
+	}
+
+	switch data := raw.(type) {
+	case map[string]bool:
+		for day, counted := range data {
+			if counted {
+				add(day)
+			}
+		}
+	case map[string]interface{}:
+		for day, v := range data {
+			if counted, ok := v.(bool); ok && counted {
+				add(day)
+			}
+		}
+	}
+	return days
+}
+
+// trackActivityDay records the current UTC day under metaKey in the quest metadata and sets
+// progress to the number of distinct days. Repeated actions on the same day count once, and
+// days outside the quest's period are discarded so a reset always starts from an empty set.
+func (qs *QuestService) trackActivityDay(quest *models.UserQuestProgress, metaKey string) bool {
+	if quest == nil {
+		return false
+	}
+
+	if quest.Metadata == nil {
+		quest.Metadata = make(map[string]interface{})
+	}
+
+	now := qs.now()
+	periodStart := now
+	if quest.QuestDefinition != nil {
+		periodStart = questPeriodStart(quest.QuestDefinition.Type, quest.ExpiresAt)
+	}
+	if !now.Before(quest.ExpiresAt) {
+		return false
+	}
+
+	days := trackedDays(quest.Metadata[metaKey], periodStart, quest.ExpiresAt)
+
+	today := questDayKey(now)
+	if days[today] {
+		return false
+	}
+
+	days[today] = true
+	quest.Metadata[metaKey] = days
+	quest.CurrentProgress = len(days)
+	return true
+}
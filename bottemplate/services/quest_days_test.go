@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func dayQuest(questType string, expiresAt time.Time) *models.UserQuestProgress {
+	return &models.UserQuestProgress{
+		QuestID:   "work_streak",
+		ExpiresAt: expiresAt,
+		QuestDefinition: &models.QuestDefinition{
+			QuestID:             "work_streak",
+			Type:                questType,
+			RequirementType:     models.RequirementTypeWorkDays,
+			RequirementCount:    5,
+			RequirementMetadata: map[string]interface{}{"track_days": true},
+		},
+	}
+}
+
+func TestTrackActivityDayAcrossDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	var clock time.Time
+	qs := NewQuestService(nil, nil)
+	qs.now = func() time.Time { return clock }
+
+	// Clocks in New York spring forward at 02:00 on 2026-03-08
+	quest := dayQuest(models.QuestTypeWeekly, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	steps := []struct {
+		name         string
+		at           time.Time
+		wantUpdated  bool
+		wantProgress int
+	}{
+		{"saturday evening local, sunday UTC", time.Date(2026, 3, 7, 23, 30, 0, 0, newYork), true, 1},
+		{"before the jump", time.Date(2026, 3, 8, 1, 30, 0, 0, newYork), false, 1},
+		{"after the jump", time.Date(2026, 3, 8, 3, 30, 0, 0, newYork), false, 1},
+		{"sunday night local, monday UTC", time.Date(2026, 3, 8, 22, 0, 0, 0, newYork), true, 2},
+		{"monday afternoon", time.Date(2026, 3, 9, 15, 0, 0, 0, newYork), false, 2},
+		{"after the reset", time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), false, 2},
+	}
+
+	for _, step := range steps {
+		clock = step.at
+		updated := qs.trackActivityDay(quest, "work_days")
+		if updated != step.wantUpdated || quest.CurrentProgress != step.wantProgress {
+			t.Fatalf("%s (%s UTC): updated = %v, progress = %d, want %v, %d",
+				step.name, clock.UTC().Format(time.RFC3339), updated, quest.CurrentProgress, step.wantUpdated, step.wantProgress)
+		}
+	}
+}
+
+func TestTrackActivityDayDropsDaysFromEarlierPeriods(t *testing.T) {
+	qs := NewQuestService(nil, nil)
+	qs.now = func() time.Time { return time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC) }
+
+	quest := dayQuest(models.QuestTypeWeekly, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC))
+	// As stored in JSONB: one day from last week and one from this week
+	quest.Metadata = map[string]interface{}{
+		"work_days": map[string]interface{}{"2026-02-27": true, "2026-03-03": true},
+	}
+
+	if !qs.trackActivityDay(quest, "work_days") {
+		t.Fatal("today was not recorded")
+	}
+	if quest.CurrentProgress != 2 {
+		t.Errorf("progress = %d, want 2", quest.CurrentProgress)
+	}
+	days := quest.Metadata["work_days"].(map[string]bool)
+	if days["2026-02-27"] {
+		t.Error("a day from the previous week was kept")
+	}
+}
+
+func TestQuestPeriodStart(t *testing.T) {
+	expires := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		questType string
+		want      time.Time
+	}{
+		{models.QuestTypeDaily, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{models.QuestTypeWeekly, time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{models.QuestTypeMonthly, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := questPeriodStart(tt.questType, expires); !got.Equal(tt.want) {
+			t.Errorf("questPeriodStart(%s) = %s, want %s", tt.questType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+type leaderboardCall struct {
+	periodType             string
+	periodStart, periodEnd time.Time
+	offset, limit          int
+}
+
+// recordingLeaderboardRepo records the periods and pages it is asked for
+type recordingLeaderboardRepo struct {
+	repositories.QuestRepository
+	calls []leaderboardCall
+}
+
+func (r *recordingLeaderboardRepo) AggregateLeaderboard(ctx context.Context, periodType string, periodStart, periodEnd time.Time) (int64, error) {
+	r.calls = append(r.calls, leaderboardCall{periodType: periodType, periodStart: periodStart, periodEnd: periodEnd})
+	return 1, nil
+}
+
+func (r *recordingLeaderboardRepo) GetLeaderboardPage(ctx context.Context, periodType string, periodStart time.Time, offset, limit int) ([]*models.QuestLeaderboard, int, error) {
+	r.calls = append(r.calls, leaderboardCall{periodType: periodType, periodStart: periodStart, offset: offset, limit: limit})
+	return nil, 0, nil
+}
+
+func TestRefreshLeaderboardsPeriods(t *testing.T) {
+	repo := &recordingLeaderboardRepo{}
+	qs := NewQuestService(repo, nil)
+	// A Wednesday afternoon
+	qs.now = func() time.Time { return time.Date(2026, 3, 11, 15, 4, 5, 0, time.UTC) }
+
+	if err := qs.RefreshLeaderboards(context.Background()); err != nil {
+		t.Fatalf("RefreshLeaderboards: %v", err)
+	}
+
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC) }
+	want := []leaderboardCall{
+		{periodType: models.QuestTypeDaily, periodStart: day(3, 11), periodEnd: day(3, 12)},
+		{periodType: models.QuestTypeWeekly, periodStart: day(3, 9), periodEnd: day(3, 16)},
+		{periodType: models.QuestTypeMonthly, periodStart: day(3, 1), periodEnd: day(4, 1)},
+	}
+	if len(repo.calls) != len(want) {
+		t.Fatalf("aggregated %d periods, want %d", len(repo.calls), len(want))
+	}
+	for i, call := range repo.calls {
+		if call.periodType != want[i].periodType || !call.periodStart.Equal(want[i].periodStart) || !call.periodEnd.Equal(want[i].periodEnd) {
+			t.Errorf("call %d = %s [%s, %s), want %s [%s, %s)", i,
+				call.periodType, call.periodStart, call.periodEnd, want[i].periodType, want[i].periodStart, want[i].periodEnd)
+		}
+	}
+}
+
+func TestGetLeaderboardPageOffsets(t *testing.T) {
+	tests := []struct {
+		page, wantOffset int
+	}{
+		{1, 0},
+		{3, 20},
+		{0, 0}, // clamped to the first page
+	}
+	for _, tt := range tests {
+		repo := &recordingLeaderboardRepo{}
+		qs := NewQuestService(repo, nil)
+		if _, _, _, err := qs.GetLeaderboardPage(context.Background(), models.QuestTypeWeekly, tt.page, 10); err != nil {
+			t.Fatalf("GetLeaderboardPage: %v", err)
+		}
+		if call := repo.calls[0]; call.offset != tt.wantOffset || call.limit != 10 {
+			t.Errorf("page %d: offset %d limit %d, want offset %d limit 10", tt.page, call.offset, call.limit, tt.wantOffset)
+		}
+	}
+}
@@ -13,12 +13,14 @@ import (
 type QuestService struct {
 	questRepo repositories.QuestRepository
 	userRepo  repositories.UserRepository
+	now       func() time.Time
 }
 
 func NewQuestService(questRepo repositories.QuestRepository, userRepo repositories.UserRepository) *QuestService {
 	return &QuestService{
 		questRepo: questRepo,
 		userRepo:  userRepo,
+		now:       time.Now,
 	}
 }
 
@@ -249,14 +251,8 @@ func (qs *QuestService) UpdateProgress(ctx context.Context, userID string, actio
 
 			case models.RequirementTypeCardLevelUp:
 				// Check if this quest tracks days instead of total levelups
-				if quest.QuestDefinition.RequirementMetadata != nil {
-					if trackDays, ok := quest.QuestDefinition.RequirementMetadata["track_days"].(bool); ok && trackDays {
-						shouldUpdate = qs.trackLevelUpDay(quest)
-					} else {
-						// Standard levelup tracking
-						quest.CurrentProgress++
-						shouldUpdate = true
-					}
+				if tracksDays(quest.QuestDefinition) {
+					shouldUpdate = qs.trackLevelUpDay(quest)
 				} else {
 					// Standard levelup tracking
 					quest.CurrentProgress++
@@ -292,6 +288,11 @@ func (qs *QuestService) UpdateProgress(ctx context.Context, userID string, actio
 				shouldUpdate = qs.trackComboProgress(quest, action)
 
 			default:
+				if tracksDays(quest.QuestDefinition) {
+					// Count each UTC day at most once
+					shouldUpdate = qs.trackActivityDay(quest, "active_days")
+					break
+				}
 				// Standard increment for other quest types
 				quest.CurrentProgress++
 				shouldUpdate = true
@@ -337,15 +338,15 @@ func (qs *QuestService) UpdateProgress(ctx context.Context, userID string, actio
 	return nil
 }
 
-// ClaimRewards claims rewards for completed quests
+// ClaimRewards claims rewards for completed quests. Marking quests claimed and crediting
+// the rewards happen atomically, so repeated or concurrent claims never pay out twice.
 func (qs *QuestService) ClaimRewards(ctx context.Context, userID string) (*QuestRewardResult, error) {
-	// Get all unclaimed completed quests
-	unclaimedQuests, err := qs.questRepo.GetUnclaimedQuests(ctx, userID)
+	claimedQuests, err := qs.questRepo.ClaimCompletedQuests(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get unclaimed quests: %w", err)
+		return nil, fmt.Errorf("failed to claim quests: %w", err)
 	}
 
-	if len(unclaimedQuests) == 0 {
+	if len(claimedQuests) == 0 {
 		return &QuestRewardResult{
 			Success: false,
 			Message: "No completed quests to claim!",
@@ -354,37 +355,17 @@ func (qs *QuestService) ClaimRewards(ctx context.Context, userID string) (*Quest
 
 	result := &QuestRewardResult{
 		Success:         true,
-		ClaimedQuests:   make([]ClaimedQuest, 0),
+		ClaimedQuests:   make([]ClaimedQuest, 0, len(claimedQuests)),
 		TotalSnowflakes: 0,
 		TotalVials:      0,
 		TotalXP:         0,
 	}
 
-	// Count by type
-	dailyCount := 0
-	weeklyCount := 0
-	monthlyCount := 0
-
-	// Claim each quest
-	for _, quest := range unclaimedQuests {
+	for _, quest := range claimedQuests {
 		if quest.QuestDefinition == nil {
 			continue
 		}
 
-		// Mark as claimed
-		quest.Claimed = true
-		now := time.Now()
-		quest.ClaimedAt = &now
-
-		if err := qs.questRepo.UpdateQuestProgress(ctx, quest); err != nil {
-			slog.Error("Failed to mark quest as claimed",
-				slog.String("user_id", userID),
-				slog.String("quest_id", quest.QuestID),
-				slog.Any("error", err))
-			continue
-		}
-
-		// Add to result
 		claimed := ClaimedQuest{
 			QuestName:        quest.QuestDefinition.Name,
 			Type:             quest.QuestDefinition.Type,
@@ -399,24 +380,18 @@ func (qs *QuestService) ClaimRewards(ctx context.Context, userID string) (*Quest
 		result.TotalVials += quest.QuestDefinition.RewardVials
 		result.TotalXP += quest.QuestDefinition.RewardXP
 
-		// Count by type
 		switch quest.QuestDefinition.Type {
 		case models.QuestTypeDaily:
-			dailyCount++
+			result.DailyCount++
 		case models.QuestTypeWeekly:
-			weeklyCount++
+			result.WeeklyCount++
 		case models.QuestTypeMonthly:
-			monthlyCount++
+			result.MonthlyCount++
 		}
 	}
 
-	// Update snowflakes
+	// Track snowflakes for quest progress
 	if result.TotalSnowflakes > 0 {
-		if err := qs.userRepo.UpdateBalance(ctx, userID, result.TotalSnowflakes); err != nil {
-			return nil, fmt.Errorf("failed to update balance: %w", err)
-		}
-
-		// Track snowflakes for quest progress
 		metadata := map[string]interface{}{
 			"snowflakes_earned": int64(result.TotalSnowflakes),
 			"source":            "quest_claim",
@@ -429,22 +404,6 @@ func (qs *QuestService) ClaimRewards(ctx context.Context, userID string) (*Quest
 		}
 	}
 
-	if result.TotalVials > 0 {
-		if err := qs.userRepo.AddVials(ctx, userID, int64(result.TotalVials)); err != nil {
-			return nil, fmt.Errorf("failed to update vials: %w", err)
-		}
-	}
-
-	if result.TotalXP > 0 {
-		if err := qs.userRepo.AddXP(ctx, userID, int64(result.TotalXP)); err != nil {
-			return nil, fmt.Errorf("failed to update xp: %w", err)
-		}
-	}
-
-	result.DailyCount = dailyCount
-	result.WeeklyCount = weeklyCount
-	result.MonthlyCount = monthlyCount
-
 	return result, nil
 }
 
@@ -606,7 +565,7 @@ func (qs *QuestService) actionMatchesRequirement(action string, quest *models.Qu
 }
 
 func (qs *QuestService) getNextReset(questType string) time.Time {
-	now := time.Now()
+	now := qs.now()
 
 	switch questType {
 	case models.QuestTypeDaily:
@@ -666,80 +625,12 @@ func (qs *QuestService) updateLeaderboard(ctx context.Context, userID string, qu
 
 // trackWorkDay tracks unique days for work command quests
 func (qs *QuestService) trackWorkDay(quest *models.UserQuestProgress) bool {
-	// Defensive check
-	if quest == nil {
-		return false
-	}
-
-	// Initialize metadata if needed
-	if quest.Metadata == nil {
-		quest.Metadata = make(map[string]interface{})
-	}
-
-	// Get or create work days tracking
-	workDaysData, exists := quest.Metadata["work_days"]
-	workDays := make(map[string]bool)
-
-	if exists {
-		// Convert existing data
-		if data, ok := workDaysData.(map[string]interface{}); ok {
-			for k, v := range data {
-				if b, ok := v.(bool); ok {
-					workDays[k] = b
-				}
-			}
-		}
-	}
-
-	// Add today's date
-	today := time.Now().Format("2006-01-02")
-	if !workDays[today] {
-		workDays[today] = true
-		quest.Metadata["work_days"] = workDays
-		quest.CurrentProgress = len(workDays)
-		return true
-	}
-
-	return false
+	return qs.trackActivityDay(quest, "work_days")
 }
 
 // trackLevelUpDay tracks unique days for levelup command quests (like Level Addict)
 func (qs *QuestService) trackLevelUpDay(quest *models.UserQuestProgress) bool {
-	// Defensive check
-	if quest == nil {
-		return false
-	}
-
-	// Initialize metadata if needed
-	if quest.Metadata == nil {
-		quest.Metadata = make(map[string]interface{})
-	}
-
-	// Get or create levelup days tracking
-	levelupDaysData, exists := quest.Metadata["levelup_days"]
-	levelupDays := make(map[string]bool)
-
-	if exists {
-		// Convert existing data
-		if data, ok := levelupDaysData.(map[string]interface{}); ok {
-			for k, v := range data {
-				if b, ok := v.(bool); ok {
-					levelupDays[k] = b
-				}
-			}
-		}
-	}
-
-	// Add today's date
-	today := time.Now().Format("2006-01-02")
-	if !levelupDays[today] {
-		levelupDays[today] = true
-		quest.Metadata["levelup_days"] = levelupDays
-		quest.CurrentProgress = len(levelupDays)
-		return true
-	}
-
-	return false
+	return qs.trackActivityDay(quest, "levelup_days")
 }
 
 // trackUniqueCardDraw tracks distinct card IDs for draw/summon quests.
@@ -830,96 +721,34 @@ func (qs *QuestService) trackUniqueCommand(quest *models.UserQuestProgress, meta
 	return false
 }
 
-// trackComboProgress tracks multiple requirements for combo quests
+// trackComboProgress records one action against a combo quest's sub-targets, keeping the
+// per-action counts in the progress metadata. The quest completes only once every sub-target
+// has been met.
 func (qs *QuestService) trackComboProgress(quest *models.UserQuestProgress, action string) bool {
 	// Defensive check
 	if quest == nil || quest.QuestDefinition == nil {
 		return false
 	}
 
-	// Initialize metadata if needed
-	if quest.Metadata == nil {
-		quest.Metadata = make(map[string]interface{})
-	}
-
-	// Get combo requirements from quest definition metadata
-	// Format is like: {"claim": 8, "work": 3, "levelup": 10, "auction_create": 1}
-	comboReqs := quest.QuestDefinition.RequirementMetadata
-	if comboReqs == nil {
+	targets := comboTargets(quest.QuestDefinition.RequirementMetadata)
+	if len(targets) == 0 {
 		return false
 	}
 
-	// Get or create combo tracking
-	comboData, exists := quest.Metadata["combo_progress"]
-	comboProgress := make(map[string]int)
-
-	if exists {
-		// Convert existing data
-		if data, ok := comboData.(map[string]interface{}); ok {
-			for k, v := range data {
-				if count, ok := v.(float64); ok {
-					comboProgress[k] = int(count)
-				}
-			}
-		}
+	if quest.Metadata == nil {
+		quest.Metadata = make(map[string]interface{})
 	}
 
-	// Check if this action is part of the combo
-	requiredCount := 0
-	if reqCountInterface, ok := comboReqs[action]; ok {
-		// Convert the required count
-		switch v := reqCountInterface.(type) {
-		case float64:
-			requiredCount = int(v)
-		case int:
-			requiredCount = v
-		default:
-			return false
-		}
-	} else {
-		// Action not part of this combo
+	counts := comboCounts(quest.Metadata)
+	if !applyComboEvent(targets, counts, action) {
 		return false
 	}
+	quest.Metadata[comboProgressKey] = counts
 
-	// Update progress for this action
-	current := comboProgress[action]
-	if current < requiredCount {
-		comboProgress[action]++
-		quest.Metadata["combo_progress"] = comboProgress
-
-		// Calculate total progress (sum of all completed requirements)
-		totalCompleted := 0
-		allRequirementsMet := true
-
-		for reqAction, reqCountInterface := range comboReqs {
-			reqCount := 0
-			switch v := reqCountInterface.(type) {
-			case float64:
-				reqCount = int(v)
-			case int:
-				reqCount = v
-			}
-
-			currentCount := comboProgress[reqAction]
-			if currentCount >= reqCount {
-				totalCompleted++
-			} else {
-				allRequirementsMet = false
-			}
-		}
-
-		// For combo quests, progress is the number of requirements completed
-		quest.CurrentProgress = totalCompleted
-
-		// If all requirements are met, mark the quest as complete
-		if allRequirementsMet && quest.QuestDefinition.RequirementCount > 0 {
-			quest.CurrentProgress = quest.QuestDefinition.RequirementCount
-		}
-
-		return true
-	}
+	met, allMet := comboStepsMet(targets, counts)
+	quest.CurrentProgress = comboQuestProgress(quest.QuestDefinition.RequirementCount, met, allMet)
 
-	return false
+	return true
 }
 
 // updateCompletionQuests updates daily/weekly completion quests when other quests are completed
@@ -1016,8 +845,37 @@ func (qs *QuestService) trackCompletionSet(quest *models.UserQuestProgress, meta
 	return true
 }
 
+// RefreshLeaderboards rebuilds the current daily, weekly and monthly leaderboards from quest
+// completions, correcting any drift from the incremental updates made on completion
+func (qs *QuestService) RefreshLeaderboards(ctx context.Context) error {
+	for _, periodType := range []string{models.QuestTypeDaily, models.QuestTypeWeekly, models.QuestTypeMonthly} {
+		rows, err := qs.questRepo.AggregateLeaderboard(ctx, periodType, qs.getPeriodStart(periodType), qs.getNextReset(periodType))
+		if err != nil {
+			return err
+		}
+		slog.Debug("Refreshed quest leaderboard",
+			slog.String("period", periodType),
+			slog.Int64("entries", rows))
+	}
+	return nil
+}
+
+// GetLeaderboardPage returns a page (1-based) of the current period's leaderboard, the total
+// number of ranked users and the period start
+func (qs *QuestService) GetLeaderboardPage(ctx context.Context, periodType string, page, pageSize int) ([]*models.QuestLeaderboard, int, time.Time, error) {
+	if page < 1 {
+		page = 1
+	}
+	periodStart := qs.getPeriodStart(periodType)
+	entries, total, err := qs.questRepo.GetLeaderboardPage(ctx, periodType, periodStart, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, periodStart, fmt.Errorf("failed to get %s leaderboard: %w", periodType, err)
+	}
+	return entries, total, periodStart, nil
+}
+
 func (qs *QuestService) getPeriodStart(periodType string) time.Time {
-	now := time.Now()
+	now := qs.now()
 
 	switch periodType {
 	case models.QuestTypeDaily:
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// claimOnceQuestRepo hands its completed quests to the first claim only, like the
+// conditional update in the real repository
+type claimOnceQuestRepo struct {
+	repositories.QuestRepository
+	mu        sync.Mutex
+	completed []*models.UserQuestProgress
+}
+
+func (r *claimOnceQuestRepo) ClaimCompletedQuests(ctx context.Context, userID string) ([]*models.UserQuestProgress, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	claimed := r.completed
+	r.completed = nil
+	return claimed, nil
+}
+
+func (r *claimOnceQuestRepo) GetActiveQuests(ctx context.Context, userID string) ([]*models.UserQuestProgress, error) {
+	return nil, nil
+}
+
+func completedQuest(questID, questType string, snowflakes int64, vials, xp int) *models.UserQuestProgress {
+	return &models.UserQuestProgress{
+		QuestID:   questID,
+		Completed: true,
+		QuestDefinition: &models.QuestDefinition{
+			QuestID:          questID,
+			Name:             questID,
+			Type:             questType,
+			RewardSnowflakes: snowflakes,
+			RewardVials:      vials,
+			RewardXP:         xp,
+		},
+	}
+}
+
+func TestClaimRewardsTwice(t *testing.T) {
+	repo := &claimOnceQuestRepo{completed: []*models.UserQuestProgress{
+		completedQuest("daily_claims", models.QuestTypeDaily, 300, 10, 5),
+		completedQuest("weekly_levels", models.QuestTypeWeekly, 1200, 40, 20),
+	}}
+	qs := NewQuestService(repo, nil)
+
+	first, err := qs.ClaimRewards(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("first claim: %v", err)
+	}
+	if !first.Success || first.TotalSnowflakes != 1500 || first.TotalVials != 50 || first.TotalXP != 25 {
+		t.Errorf("first claim = %+v, want 1500 snowflakes, 50 vials, 25 XP", first)
+	}
+	if first.DailyCount != 1 || first.WeeklyCount != 1 || len(first.ClaimedQuests) != 2 {
+		t.Errorf("first claim counted %d daily, %d weekly, %d quests", first.DailyCount, first.WeeklyCount, len(first.ClaimedQuests))
+	}
+
+	second, err := qs.ClaimRewards(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("second claim: %v", err)
+	}
+	if second.Success || second.TotalSnowflakes != 0 {
+		t.Errorf("second claim = %+v, want nothing left to claim", second)
+	}
+}
+
+func TestClaimRewardsConcurrent(t *testing.T) {
+	repo := &claimOnceQuestRepo{completed: []*models.UserQuestProgress{
+		completedQuest("daily_claims", models.QuestTypeDaily, 300, 10, 5),
+	}}
+	qs := NewQuestService(repo, nil)
+
+	var mu sync.Mutex
+	var total int64
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := qs.ClaimRewards(context.Background(), "u1")
+			if err != nil {
+				t.Errorf("ClaimRewards: %v", err)
+				return
+			}
+			mu.Lock()
+			total += result.TotalSnowflakes
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if total != 300 {
+		t.Errorf("paid %d snowflakes across concurrent claims, want 300", total)
+	}
+}
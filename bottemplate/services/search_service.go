@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sort"
 	"strings"
 	"time"
@@ -19,6 +20,7 @@ type SearchService struct {
 	userCardRepo interfaces.UserCardRepositoryInterface
 	userRepo     repositories.UserRepository
 	wishlistRepo repositories.WishlistRepository
+	priceLookup  PriceLookup
 }
 
 // NewSearchService creates a new search service
@@ -31,6 +33,12 @@ func NewSearchService(cardRepo interfaces.CardRepositoryInterface, userCardRepo
 	}
 }
 
+// WithPriceLookup lets searches sort by price (>price, >eval) and attach prices to results
+func (ss *SearchService) WithPriceLookup(lookup PriceLookup) *SearchService {
+	ss.priceLookup = lookup
+	return ss
+}
+
 // UserCardSearchResult represents search results for user cards
 type UserCardSearchResult struct {
 	UserCards []*models.UserCard
@@ -65,6 +73,12 @@ func (ss *SearchService) SearchUserCards(ctx context.Context, userID, query stri
 
 	// Parse search filters
 	filters := utils.ParseSearchQuery(query)
+	if err := ss.populateLastDaily(ctx, userID, &filters); err != nil {
+		return nil, err
+	}
+	if err := ss.populateWishlist(ctx, userID, &filters); err != nil {
+		return nil, err
+	}
 
 	// Convert UserCards to Cards for searching in a single bulk call
 	cardIDs := make([]int64, 0, len(userCards))
@@ -408,7 +422,21 @@ func (ss *SearchService) WithCards(ctx context.Context, userID string, filters u
 	}
 
 	// Safe sort with ownership validation
-	if len(enrichedUserCards) > 0 {
+	if usesPrices(filters) {
+		ids := make([]int64, len(enrichedUserCards))
+		for i, uc := range enrichedUserCards {
+			ids[i] = uc.CardID
+		}
+		ss.populatePrices(ctx, ids, &filters)
+	}
+	if filters.SortBy == utils.SortByEval || filters.SortBy == utils.SortByPrice {
+		utils.NewSortBuilder().
+			FirstBy(filters.SortBy, filters.SortDesc).
+			ThenBy(utils.SortByLevel, true).
+			ThenBy(utils.SortByName, false).
+			WithPrices(filters.Prices).
+			SortUserCards(enrichedUserCards, cardMap)
+	} else {
 		ss.sortUserCardsByLevel(ctx, enrichedUserCards)
 	}
 
@@ -506,7 +534,23 @@ func (ss *SearchService) WithGlobalCards(ctx context.Context, userID string, fil
 	}
 
 	// Apply sorting
-	ss.sortCardsByLevel(filteredCards)
+	if usesPrices(filters) {
+		ids := make([]int64, len(filteredCards))
+		for i, card := range filteredCards {
+			ids[i] = card.ID
+		}
+		ss.populatePrices(ctx, ids, &filters)
+	}
+	if filters.SortBy == utils.SortByPrice {
+		utils.NewSortBuilder().
+			FirstBy(utils.SortByPrice, filters.SortDesc).
+			ThenBy(utils.SortByLevel, true).
+			ThenBy(utils.SortByName, false).
+			WithPrices(filters.Prices).
+			SortCards(filteredCards)
+	} else {
+		ss.sortCardsByLevel(filteredCards)
+	}
 
 	return callback(filteredCards, filters)
 }
@@ -772,6 +816,11 @@ func (ss *SearchService) applyCardFilters(cards []*models.Card, filters utils.Se
 			// No exclusions - all cards are searchable
 		}
 
+		// Apply global ownership filters (-rare, -common)
+		if !utils.MatchesOwnershipFilters(card.ID, filters) {
+			continue
+		}
+
 		filtered = append(filtered, card)
 	}
 
@@ -781,13 +830,7 @@ func (ss *SearchService) applyCardFilters(cards []*models.Card, filters utils.Se
 // matchesUserCardFilters checks if a user card matches user-specific filters
 func (ss *SearchService) matchesUserCardFilters(userCard *models.UserCard, filters utils.SearchFilters) bool {
 	// Apply amount filters
-	if filters.AmountFilter.Min > 0 && userCard.Amount < filters.AmountFilter.Min {
-		return false
-	}
-	if filters.AmountFilter.Max > 0 && userCard.Amount > filters.AmountFilter.Max {
-		return false
-	}
-	if filters.AmountFilter.Exact > 0 && userCard.Amount != filters.AmountFilter.Exact {
+	if !filters.AmountFilter.Matches(userCard.Amount) {
 		return false
 	}
 
@@ -823,19 +866,14 @@ func (ss *SearchService) matchesUserCardFilters(userCard *models.UserCard, filte
 		return false
 	}
 
-	// Apply new card filters - placeholder, needs user context for lastDaily comparison
-	// This is handled at the service layer where we have access to user data
-
-	// Apply wishlist filters - now implemented using actual wishlist data
-	if filters.WishOnly {
-		// This card must be in the user's wishlist
-		// We can't check this at the UserCard level since it requires a separate service call
-		// This will be handled at the service layer level
+	// Apply new card filters when LastDaily has been populated
+	if !utils.MatchesNewFilter(userCard, filters) {
+		return false
 	}
 
-	if filters.ExcludeWish {
-		// This card must NOT be in the user's wishlist
-		// This will be handled at the service layer level
+	// Apply wishlist filters when the wishlist has been loaded
+	if !utils.MatchesWishFilter(userCard.CardID, filters) {
+		return false
 	}
 
 	return true
@@ -893,35 +931,58 @@ func (ss *SearchService) applyLastCardFilterGlobal(ctx context.Context, cards []
 	return []*models.Card{}
 }
 
-// applyWishlistFilter filters user cards based on wishlist status
-func (ss *SearchService) applyWishlistFilter(ctx context.Context, userCards []*models.UserCard, userID string, wishOnly bool) []*models.UserCard {
-	// Get user's wishlist
+// populateWishlist loads the user's wishlisted card IDs into the filters, only when the
+// query uses -wish or !wish
+func (ss *SearchService) populateWishlist(ctx context.Context, userID string, filters *utils.SearchFilters) error {
+	if (!filters.WishOnly && !filters.ExcludeWish) || filters.Wishlist != nil {
+		return nil
+	}
+
 	wishlistItems, err := ss.wishlistRepo.GetByUserID(ctx, userID)
 	if err != nil {
-		// If we can't get the wishlist, return empty result for safety
-		return []*models.UserCard{}
+		return fmt.Errorf("failed to get wishlist: %w", err)
 	}
 
-	// Create a map of wishlist card IDs for O(1) lookup
-	wishlistCardIDs := make(map[int64]bool)
+	filters.Wishlist = make(map[int64]bool, len(wishlistItems))
 	for _, item := range wishlistItems {
-		wishlistCardIDs[item.CardID] = true
+		filters.Wishlist[item.CardID] = true
+	}
+	return nil
+}
+
+// usesPrices reports whether a search needs card prices, for sorting or display
+func usesPrices(filters utils.SearchFilters) bool {
+	return filters.WithPrices || filters.SortBy == utils.SortByPrice || filters.SortBy == utils.SortByEval
+}
+
+// populatePrices looks up the prices of the given cards once per search and stores
+// them in the filters, so sorting and the callback share a single lookup. Without a
+// price lookup, or when it fails, cards sort as if unpriced.
+func (ss *SearchService) populatePrices(ctx context.Context, cardIDs []int64, filters *utils.SearchFilters) {
+	if ss.priceLookup == nil || filters.Prices != nil || len(cardIDs) == 0 {
+		return
+	}
+
+	prices, err := ss.priceLookup(ctx, cardIDs)
+	if err != nil {
+		slog.Warn("Failed to look up card prices for search", slog.String("error", err.Error()))
+		return
+	}
+	filters.Prices = prices
+}
+
+// applyWishlistFilter filters user cards based on wishlist status
+func (ss *SearchService) applyWishlistFilter(ctx context.Context, userCards []*models.UserCard, userID string, wishOnly bool) []*models.UserCard {
+	filters := utils.SearchFilters{WishOnly: wishOnly, ExcludeWish: !wishOnly}
+	if err := ss.populateWishlist(ctx, userID, &filters); err != nil {
+		// If we can't get the wishlist, return empty result for safety
+		return []*models.UserCard{}
 	}
 
 	var filtered []*models.UserCard
 	for _, userCard := range userCards {
-		isInWishlist := wishlistCardIDs[userCard.CardID]
-
-		if wishOnly {
-			// Include only cards that are in the wishlist
-			if isInWishlist {
-				filtered = append(filtered, userCard)
-			}
-		} else {
-			// Exclude cards that are in the wishlist
-			if !isInWishlist {
-				filtered = append(filtered, userCard)
-			}
+		if utils.MatchesWishFilter(userCard.CardID, filters) {
+			filtered = append(filtered, userCard)
 		}
 	}
 
@@ -963,41 +1024,33 @@ func (ss *SearchService) applyWishlistFilterGlobal(ctx context.Context, cards []
 	return filtered
 }
 
-// applyNewCardFilter filters user cards based on obtained date vs user's last daily
-func (ss *SearchService) applyNewCardFilter(ctx context.Context, userCards []*models.UserCard, userID string, newOnly bool) []*models.UserCard {
-	// Get user to access their last daily timestamp
+// populateLastDaily loads the user's last daily into the filters. It only queries the user
+// for user-specific queries that actually use -new or !new.
+func (ss *SearchService) populateLastDaily(ctx context.Context, userID string, filters *utils.SearchFilters) error {
+	if !filters.UserQuery || (!filters.NewOnly && !filters.ExcludeNew) || filters.LastDaily != "" {
+		return nil
+	}
+
 	user, err := ss.userRepo.GetByDiscordID(ctx, userID)
 	if err != nil {
-		// If we can't get the user, return empty result for safety
-		return []*models.UserCard{}
+		return fmt.Errorf("failed to get user: %w", err)
 	}
+	filters.SetLastDaily(user.LastDaily)
+	return nil
+}
 
-	lastDaily := user.LastDaily
-	// If lastDaily is zero time, treat as very old date (before any card could be obtained)
-	if lastDaily.IsZero() {
-		if newOnly {
-			// All cards are "new" if no daily has been claimed
-			return userCards
-		} else {
-			// No cards are "old" if no daily has been claimed
-			return []*models.UserCard{}
-		}
+// applyNewCardFilter filters user cards based on obtained date vs user's last daily
+func (ss *SearchService) applyNewCardFilter(ctx context.Context, userCards []*models.UserCard, userID string, newOnly bool) []*models.UserCard {
+	filters := utils.SearchFilters{NewOnly: newOnly, ExcludeNew: !newOnly, UserQuery: true}
+	if err := ss.populateLastDaily(ctx, userID, &filters); err != nil {
+		// If we can't get the user, return empty result for safety
+		return []*models.UserCard{}
 	}
 
 	var filtered []*models.UserCard
 	for _, userCard := range userCards {
-		isNewCard := userCard.Obtained.After(lastDaily)
-
-		if newOnly {
-			// Include only cards obtained after last daily
-			if isNewCard {
-				filtered = append(filtered, userCard)
-			}
-		} else {
-			// Exclude cards obtained after last daily (include old cards)
-			if !isNewCard {
-				filtered = append(filtered, userCard)
-			}
+		if utils.MatchesNewFilter(userCard, filters) {
+			filtered = append(filtered, userCard)
 		}
 	}
 
@@ -1072,7 +1125,7 @@ func (ss *SearchService) applyNewCardFilterGlobal(ctx context.Context, cards []*
 
 // hasUserSpecificFilters checks if the search filters contain any user-specific criteria
 func (ss *SearchService) hasUserSpecificFilters(filters utils.SearchFilters) bool {
-	return filters.AmountFilter.Min > 0 || filters.AmountFilter.Max > 0 || filters.AmountFilter.Exact > 0 ||
+	return filters.AmountFilter.IsSet() ||
 		filters.ExpFilter.Min > 0 || filters.ExpFilter.Max > 0 || filters.ExpFilter.Exact > 0 ||
 		filters.Favorites || filters.ExcludeFavorites ||
 		filters.LockedOnly || filters.ExcludeLocked ||
@@ -1080,5 +1133,6 @@ func (ss *SearchService) hasUserSpecificFilters(filters utils.SearchFilters) boo
 		filters.NewOnly || filters.ExcludeNew ||
 		filters.RatedOnly || filters.ExcludeRated ||
 		filters.WishOnly || filters.ExcludeWish ||
-		filters.LastCard || filters.Diff > 0
+		filters.LastCard || filters.Diff > 0 ||
+		filters.HasObtainedRange()
 }
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/interfaces"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// lastDailyUserRepo serves one user's last daily and counts lookups
+type lastDailyUserRepo struct {
+	repositories.UserRepository
+	lastDaily time.Time
+	err       error
+	gets      int
+}
+
+func (r *lastDailyUserRepo) GetByDiscordID(ctx context.Context, discordID string) (*models.User, error) {
+	r.gets++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &models.User{DiscordID: discordID, LastDaily: r.lastDaily}, nil
+}
+
+func TestPopulateLastDailyOnlyForNewFilters(t *testing.T) {
+	lastDaily := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		query    string
+		wantGets int
+	}{
+		{query: "twice", wantGets: 0},
+		{query: "-new", wantGets: 1},
+		{query: "!new", wantGets: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			repo := &lastDailyUserRepo{lastDaily: lastDaily}
+			ss := &SearchService{userRepo: repo}
+
+			filters := utils.ParseSearchQuery(tt.query)
+			if err := ss.populateLastDaily(context.Background(), "u1", &filters); err != nil {
+				t.Fatalf("populateLastDaily: %v", err)
+			}
+			if repo.gets != tt.wantGets {
+				t.Errorf("user lookups = %d, want %d", repo.gets, tt.wantGets)
+			}
+			if got, ok := filters.LastDailyTime(); tt.wantGets > 0 && (!ok || !got.Equal(lastDaily)) {
+				t.Errorf("LastDaily = %s, %v, want %s", got, ok, lastDaily)
+			}
+		})
+	}
+}
+
+func TestApplyNewCardFilterDailyBoundary(t *testing.T) {
+	lastDaily := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	cards := []*models.UserCard{
+		{CardID: 1, Obtained: lastDaily.Add(-time.Minute)},
+		{CardID: 2, Obtained: lastDaily},
+		{CardID: 3, Obtained: lastDaily.Add(time.Minute)},
+	}
+	ids := func(userCards []*models.UserCard) []int64 {
+		var ids []int64
+		for _, userCard := range userCards {
+			ids = append(ids, userCard.CardID)
+		}
+		return ids
+	}
+
+	ss := &SearchService{userRepo: &lastDailyUserRepo{lastDaily: lastDaily}}
+	if got := ids(ss.applyNewCardFilter(context.Background(), cards, "u1", true)); len(got) != 1 || got[0] != 3 {
+		t.Errorf("-new = %v, want [3]", got)
+	}
+	if got := ids(ss.applyNewCardFilter(context.Background(), cards, "u1", false)); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("!new = %v, want [1 2]", got)
+	}
+
+	ss = &SearchService{userRepo: &lastDailyUserRepo{err: errors.New("db down")}}
+	if got := ss.applyNewCardFilter(context.Background(), cards, "u1", true); len(got) != 0 {
+		t.Errorf("failed user lookup kept %d cards, want none", len(got))
+	}
+}
+
+// fixtureCardRepo serves a fixed card list in batches; single-card lookups panic
+type fixtureCardRepo struct {
+	interfaces.CardRepositoryInterface
+	cards   []*models.Card
+	batches int
+}
+
+func (r *fixtureCardRepo) GetByIDs(ctx context.Context, ids []int64) ([]*models.Card, error) {
+	r.batches++
+	wanted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	var cards []*models.Card
+	for _, card := range r.cards {
+		if wanted[card.ID] {
+			cards = append(cards, card)
+		}
+	}
+	return cards, nil
+}
+
+func (r *fixtureCardRepo) GetAll(ctx context.Context) ([]*models.Card, error) {
+	return r.cards, nil
+}
+
+func (r *fixtureCardRepo) GetByCollectionID(ctx context.Context, colID string) ([]*models.Card, error) {
+	var cards []*models.Card
+	for _, card := range r.cards {
+		if card.ColID == colID {
+			cards = append(cards, card)
+		}
+	}
+	return cards, nil
+}
+
+type fixtureUserCardRepo struct {
+	userCards []*models.UserCard
+}
+
+func (r *fixtureUserCardRepo) GetAllByUserID(ctx context.Context, userID string) ([]*models.UserCard, error) {
+	return r.userCards, nil
+}
+
+// fixtureWishlistRepo serves one user's wishlist and counts lookups
+type fixtureWishlistRepo struct {
+	repositories.WishlistRepository
+	cardIDs []int64
+	gets    int
+}
+
+func (r *fixtureWishlistRepo) GetByUserID(ctx context.Context, userID string) ([]*models.Wishlist, error) {
+	r.gets++
+	items := make([]*models.Wishlist, 0, len(r.cardIDs))
+	for _, id := range r.cardIDs {
+		items = append(items, &models.Wishlist{UserID: userID, CardID: id})
+	}
+	return items, nil
+}
+
+func TestSearchUserCardsWishFilters(t *testing.T) {
+	cards := []*models.Card{
+		{ID: 1, Name: "nayeon", ColID: "twice", Level: 1},
+		{ID: 2, Name: "jeongyeon", ColID: "twice", Level: 2},
+		{ID: 3, Name: "momo", ColID: "twice", Level: 3},
+	}
+	userCards := []*models.UserCard{
+		{UserID: "u1", CardID: 1, Amount: 1},
+		{UserID: "u1", CardID: 2, Amount: 1},
+		{UserID: "u1", CardID: 3, Amount: 1},
+	}
+
+	tests := []struct {
+		query    string
+		want     []int64
+		wantGets int
+	}{
+		{query: "-wish", want: []int64{1, 3}, wantGets: 1},
+		{query: "!wish", want: []int64{2}, wantGets: 1},
+		{query: "momo", want: []int64{3}, wantGets: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			wishlist := &fixtureWishlistRepo{cardIDs: []int64{1, 3}}
+			ss := NewSearchService(&fixtureCardRepo{cards: cards}, &fixtureUserCardRepo{userCards: userCards},
+				&lastDailyUserRepo{}, wishlist)
+
+			result, err := ss.SearchUserCards(context.Background(), "u1", tt.query)
+			if err != nil {
+				t.Fatalf("SearchUserCards: %v", err)
+			}
+			got := make(map[int64]bool)
+			for _, userCard := range result.UserCards {
+				got[userCard.CardID] = true
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("got %d cards %v, want %v", len(got), got, tt.want)
+			}
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Errorf("card %d missing from %v", id, got)
+				}
+			}
+			if wishlist.gets != tt.wantGets {
+				t.Errorf("wishlist lookups = %d, want %d", wishlist.gets, tt.wantGets)
+			}
+		})
+	}
+}
+
+func TestWithGlobalCardsSortsByPrice(t *testing.T) {
+	cards := []*models.Card{
+		{ID: 1, Name: "nayeon", ColID: "twice", Level: 1},
+		{ID: 2, Name: "jeongyeon", ColID: "twice", Level: 2},
+		{ID: 3, Name: "momo", ColID: "twice", Level: 3},
+		{ID: 4, Name: "sana", ColID: "twice", Level: 2},
+	}
+	lookups := 0
+	lookup := func(ctx context.Context, ids []int64) (map[int64]int64, error) {
+		lookups++
+		// 4 has no price and counts as 0; 1 and 2 tie and fall back to level
+		return map[int64]int64{1: 300, 2: 300, 3: 900}, nil
+	}
+
+	for query, want := range map[string][]int64{
+		">price": {3, 2, 1, 4},
+		"<price": {4, 2, 1, 3},
+	} {
+		t.Run(query, func(t *testing.T) {
+			lookups = 0
+			ss := NewSearchService(&fixtureCardRepo{cards: cards}, &fixtureUserCardRepo{}, &lastDailyUserRepo{}, &fixtureWishlistRepo{}).
+				WithPriceLookup(lookup)
+
+			var got []int64
+			err := ss.WithGlobalCards(context.Background(), "u1", utils.ParseSearchQuery(query), func(cards []*models.Card, filters utils.SearchFilters) error {
+				for _, card := range cards {
+					got = append(got, card.ID)
+				}
+				if filters.Prices[3] != 900 {
+					t.Errorf("callback prices = %v, want the looked up prices", filters.Prices)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("WithGlobalCards: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("order = %v, want %v", got, want)
+			}
+			if lookups != 1 {
+				t.Errorf("%d price lookups, want 1", lookups)
+			}
+		})
+	}
+}
+
+func TestPopulatePricesFailureLeavesCardsUnpriced(t *testing.T) {
+	ss := NewSearchService(nil, nil, nil, nil).WithPriceLookup(func(ctx context.Context, ids []int64) (map[int64]int64, error) {
+		return nil, errors.New("market down")
+	})
+	filters := utils.SearchFilters{SortBy: utils.SortByPrice}
+	ss.populatePrices(context.Background(), []int64{1, 2}, &filters)
+	if filters.Prices != nil {
+		t.Errorf("prices = %v, want none", filters.Prices)
+	}
+}
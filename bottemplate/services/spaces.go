@@ -5,7 +5,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -16,13 +20,33 @@ import (
 	"github.com/disgoorg/bot-template/bottemplate/utils"
 )
 
+// DefaultSignedURLTTL is how long signed card image URLs stay valid when no TTL is configured
+const DefaultSignedURLTTL = 15 * time.Minute
+
+// signedURLCacheLimit bounds the signed URL cache before expired entries are swept
+const signedURLCacheLimit = 10000
+
 type SpacesService struct {
 	client       *s3.Client
+	presign      *s3.PresignClient
 	bucket       string
 	region       string
 	CardRoot     string
 	cacheManager *SpacesCacheManager
 	imageManager *SpacesImageManager
+	existence    *existenceCache
+
+	// signedTTL switches card image URLs to signed URLs when non-zero
+	signedTTL  time.Duration
+	signedMu   sync.Mutex
+	signedURLs map[string]signedURL
+}
+
+// signedURL is a cached signature, reused until half its lifetime has passed
+// so a URL handed out is always valid for at least ttl/2
+type signedURL struct {
+	url       string
+	refreshAt time.Time
 }
 
 func NewSpacesService(spacesKey, spacesSecret, region, bucket, cardRoot string) *SpacesService {
@@ -49,6 +73,9 @@ func NewSpacesService(spacesKey, spacesSecret, region, bucket, cardRoot string)
 
 	service := &SpacesService{
 		client:       client,
+		presign:      s3.NewPresignClient(client),
+		signedURLs:   make(map[string]signedURL),
+		existence:    newExistenceCache(DefaultExistenceTTL),
 		bucket:       bucket,
 		region:       region,
 		CardRoot:     cardRoot,
@@ -63,30 +90,110 @@ func NewSpacesService(spacesKey, spacesSecret, region, bucket, cardRoot string)
 }
 
 func (s *SpacesService) GetCardImageURL(cardName string, colID string, level int, groupType string) string {
-	return s.GetCardImageURLWithFormat(cardName, colID, level, groupType, false)
+	return s.GetCardImageURLWithFormat(cardName, colID, level, groupType, false, "")
 }
 
-func (s *SpacesService) GetCardImageURLWithFormat(cardName string, colID string, level int, groupType string, animated bool) string {
-	// Use cache manager to find the correct path
-	baseDir, _ := s.cacheManager.FindPathForCard(context.Background(), cardName, colID, level, groupType)
+// GetCardImageURLWithFormat builds a card image URL. Animated cards always use gif;
+// otherwise the stored format is used, falling back to jpg when it is empty.
+// When signed URLs are enabled the storage key is signed against the bucket instead.
+func (s *SpacesService) GetCardImageURLWithFormat(cardName string, colID string, level int, groupType string, animated bool, format string) string {
+	if s.signedTTL > 0 {
+		storageKey := s.GetCardStorageKey(cardName, colID, level, groupType, animated, format)
+		signed, err := s.GetSignedCardImageURL(storageKey, s.signedTTL)
+		if err == nil {
+			return signed
+		}
+		slog.Warn("Failed to sign card image URL, falling back to public URL",
+			slog.String("key", storageKey),
+			slog.String("error", err.Error()))
+	}
+	return "https://cards.hyejoobot.com/" + s.GetCardKey(cardName, colID, level, groupType, animated, format)
+}
+
+// UseSignedURLs makes card image URLs signed and valid for ttl, for buckets
+// that aren't publicly readable. A zero ttl uses DefaultSignedURLTTL.
+func (s *SpacesService) UseSignedURLs(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultSignedURLTTL
+	}
+	s.signedTTL = ttl
+}
+
+// GetSignedCardImageURL returns a presigned GET URL for key that expires after
+// ttl. Signatures are cached briefly so listing pages don't re-sign every image.
+func (s *SpacesService) GetSignedCardImageURL(key string, ttl time.Duration) (string, error) {
+	cacheKey := fmt.Sprintf("%s|%d", key, ttl)
+	now := time.Now()
+
+	s.signedMu.Lock()
+	cached, ok := s.signedURLs[cacheKey]
+	s.signedMu.Unlock()
+	if ok && now.Before(cached.refreshAt) {
+		return cached.url, nil
+	}
+
+	req, err := s.presign.PresignGetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign %s: %w", key, err)
+	}
 
-	// Determine file extension based on animation status
-	extension := "jpg"
-	if animated {
-		extension = "gif"
+	s.signedMu.Lock()
+	if len(s.signedURLs) >= signedURLCacheLimit {
+		for k, entry := range s.signedURLs {
+			if !now.Before(entry.refreshAt) {
+				delete(s.signedURLs, k)
+			}
+		}
 	}
+	s.signedURLs[cacheKey] = signedURL{url: req.URL, refreshAt: now.Add(ttl / 2)}
+	s.signedMu.Unlock()
 
-	var sb strings.Builder
-	sb.WriteString("https://cards.hyejoobot.com/")
-	sb.WriteString(baseDir)
-	sb.WriteByte('/')
-	sb.WriteString(groupType)
-	sb.WriteByte('/')
-	sb.WriteString(colID)
-	sb.WriteByte('/')
-	sb.WriteString(fmt.Sprintf("%d_%s.%s", level, cardName, extension))
+	return req.URL, nil
+}
+
+// GetCardKey returns the URL-relative key of a card image, resolving its base directory from
+// the path cache. It is only meant for building URLs; objects are stored under GetCardStorageKey.
+func (s *SpacesService) GetCardKey(cardName string, colID string, level int, groupType string, animated bool, format string) string {
+	ref := CardImageRef{
+		GroupType: groupType,
+		ColID:     colID,
+		Name:      cardName,
+		Level:     level,
+		Animated:  animated,
+		Format:    format,
+	}
 
-	return sb.String()
+	// Use cache manager to find the correct path and name form
+	return BuildCardKey(s.cacheManager.FindPathForCard(context.Background(), ref))
+}
+
+// GetCardStorageKey returns the object key a card image is stored under in the bucket,
+// resolved like GetCardKey but below the card root
+func (s *SpacesService) GetCardStorageKey(cardName string, colID string, level int, groupType string, animated bool, format string) string {
+	return s.cacheManager.FindStorageKey(context.Background(), CardImageRef{
+		GroupType: groupType,
+		ColID:     colID,
+		Name:      cardName,
+		Level:     level,
+		Animated:  animated,
+		Format:    format,
+	})
+}
+
+// GetCardStorageKeys returns GetCardStorageKey followed by the same key in every other
+// format a card image may be stored in, such as an original kept next to a WebP image
+func (s *SpacesService) GetCardStorageKeys(cardName string, colID string, level int, groupType string, animated bool, format string) []string {
+	return s.cacheManager.FindStorageKeys(context.Background(), CardImageRef{
+		GroupType: groupType,
+		ColID:     colID,
+		Name:      cardName,
+		Level:     level,
+		Animated:  animated,
+		Format:    format,
+	})
 }
 
 func (s *SpacesService) GetBucket() string {
@@ -102,15 +209,31 @@ func (s *SpacesService) GetCardRoot() string {
 }
 
 func (s *SpacesService) DeleteCardImage(ctx context.Context, colID string, cardName string, level int, tags []string) error {
+	// The image manager resolves the deleted keys itself, so drop every cached entry
+	defer s.existence.forgetAll()
 	return s.imageManager.DeleteCardImage(ctx, colID, cardName, level, tags)
 }
 
 // ManageCardImage handles various image operations for cards
 func (s *SpacesService) ManageCardImage(ctx context.Context, operation ImageOperation, cardID int64, imageData []byte, card *models.Card) (*ImageManagementResult, error) {
-	return s.imageManager.ManageCardImage(ctx, operation, cardID, imageData, card)
+	result, err := s.imageManager.ManageCardImage(ctx, operation, cardID, imageData, card)
+	if result != nil && result.Key != "" {
+		s.existence.forget(result.Key)
+	}
+	return result, err
+}
+
+// UploadCardImage uploads a card image under the standard cards path with the given extension
+func (s *SpacesService) UploadCardImage(ctx context.Context, card *models.Card, imageData []byte, extension string, contentType string) (string, error) {
+	url, err := s.imageManager.UploadCardImage(ctx, card, imageData, extension, contentType)
+	if url != "" {
+		s.existence.forget(strings.TrimPrefix(url, s.GetObjectURL("")))
+	}
+	return url, err
 }
 
 func (s *SpacesService) DeleteObject(ctx context.Context, path string) error {
+	defer s.existence.forget(path)
 	return s.imageManager.DeleteObject(ctx, path)
 }
 
@@ -137,10 +260,34 @@ func (s *SpacesService) UploadFile(ctx context.Context, data []byte, path string
 		ACL:          types.ObjectCannedACLPublicRead,
 	}
 
+	defer s.existence.forget(path)
 	_, err := s.client.PutObject(ctx, input)
 	return err
 }
 
+// UploadStream uploads the contents of r to the specified path without buffering it in memory.
+// size must be the exact number of bytes r will produce.
+func (s *SpacesService) UploadStream(ctx context.Context, r io.Reader, size int64, path string, contentType string) error {
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(path),
+		Body:          r,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String(contentType),
+		CacheControl:  aws.String("public, max-age=31536000"),
+		ACL:           types.ObjectCannedACLPublicRead,
+	}
+
+	defer s.existence.forget(path)
+	_, err := s.client.PutObject(ctx, input)
+	return err
+}
+
+// GetObjectURL returns the public URL of an object stored at path
+func (s *SpacesService) GetObjectURL(path string) string {
+	return fmt.Sprintf("https://%s.%s.digitaloceanspaces.com/%s", s.bucket, s.region, path)
+}
+
 // DeleteFile deletes a file from the specified path in Spaces
 func (s *SpacesService) DeleteFile(ctx context.Context, path string) error {
 	input := &s3.DeleteObjectInput{
@@ -148,6 +295,43 @@ func (s *SpacesService) DeleteFile(ctx context.Context, path string) error {
 		Key:    aws.String(path),
 	}
 
+	defer s.existence.forget(path)
 	_, err := s.client.DeleteObject(ctx, input)
 	return err
 }
+
+// Ping checks that the bucket is reachable with the configured credentials
+func (s *SpacesService) Ping(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(s.bucket),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reach bucket %s: %w", s.bucket, err)
+	}
+	return nil
+}
+
+// ListObjectKeys returns every object key stored under the given prefix
+func (s *SpacesService) ListObjectKeys(ctx context.Context, prefix string) ([]string, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(s.bucket),
+		Prefix:  aws.String(prefix),
+		MaxKeys: aws.Int32(1000),
+	}
+
+	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(s.client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects under %s: %w", prefix, err)
+		}
+		for _, obj := range output.Contents {
+			if obj.Key != nil {
+				keys = append(keys, *obj.Key)
+			}
+		}
+	}
+
+	return keys, nil
+}
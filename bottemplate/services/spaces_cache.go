@@ -26,6 +26,7 @@ type PathInfo struct {
 
 type PathCache struct {
 	paths map[string]PathInfo
+	keys  map[string]struct{} // object keys seen while building the cache
 	mu    sync.RWMutex
 }
 
@@ -45,6 +46,7 @@ func NewSpacesCacheManager(client *s3.Client, bucket, cardRoot string) *SpacesCa
 		cardRoot: cardRoot,
 		cache: &PathCache{
 			paths: make(map[string]PathInfo),
+			keys:  make(map[string]struct{}),
 		},
 	}
 }
@@ -93,6 +95,7 @@ func (c *SpacesCacheManager) InitializePathCache() {
 						if obj.Key == nil {
 							continue
 						}
+						c.addKey(*obj.Key)
 
 						path := strings.TrimPrefix(*obj.Key, c.cardRoot+"/")
 						parts := strings.Split(path, "/")
@@ -153,44 +156,110 @@ func (c *SpacesCacheManager) RemovePathInfo(colID string) {
 	delete(c.cache.paths, colID)
 }
 
-// FindPathForCard attempts to find the correct path for a card by checking existence
-func (c *SpacesCacheManager) FindPathForCard(ctx context.Context, cardName string, colID string, level int, groupType string) (string, PathType) {
-	// Check cache first
-	if pathInfo, exists := c.GetPathInfo(colID); exists {
-		return string(pathInfo.BaseDir), pathInfo.BaseDir
+func (c *SpacesCacheManager) addKey(key string) {
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	c.cache.keys[key] = struct{}{}
+}
+
+func (c *SpacesCacheManager) hasKey(key string) bool {
+	c.cache.mu.RLock()
+	defer c.cache.mu.RUnlock()
+	_, ok := c.cache.keys[key]
+	return ok
+}
+
+// storageKey returns the object key ref is stored under below the card root
+func (c *SpacesCacheManager) storageKey(ref CardImageRef, pathType PathType) string {
+	ref.BaseDir = c.cardRoot
+	if pathType == PathTypePromo {
+		ref.BaseDir = c.cardRoot + "/promo"
 	}
+	return BuildCardKey(ref)
+}
 
-	// If not in cache, check both possible paths
-	paths := []string{
-		fmt.Sprintf("%s/%s/%s/%d_%s.jpg", c.cardRoot, groupType, colID, level, cardName),
-		fmt.Sprintf("%s/promo/%s/%s/%d_%s.jpg", c.cardRoot, groupType, colID, level, cardName),
+// FindPathForCard resolves the base directory and name form a card image is stored under.
+// Images are probed with the card's stored format, so WebP-only collections are found.
+// The slugified name is preferred; images stored under the raw card name before names
+// were slugified stay readable. Nothing found defaults to the promo directory and the slug.
+func (c *SpacesCacheManager) FindPathForCard(ctx context.Context, ref CardImageRef) CardImageRef {
+	pathTypes := []PathType{PathTypeCards, PathTypePromo}
+	pathInfo, cached := c.GetPathInfo(ref.ColID)
+	if cached {
+		pathTypes = []PathType{pathInfo.BaseDir}
+	}
+	variants := cardNameVariants(ref)
+
+	// Keys listed while building the cache need no request
+	for _, pathType := range pathTypes {
+		for _, variant := range variants {
+			if c.hasKey(c.storageKey(variant, pathType)) {
+				variant.BaseDir = string(pathType)
+				return variant
+			}
+		}
+	}
+
+	resolved := variants[0]
+	if cached {
+		resolved.BaseDir = string(pathInfo.BaseDir)
+		return resolved
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancel()
 
-	for _, path := range paths {
-		_, err := c.client.HeadObject(timeoutCtx, &s3.HeadObjectInput{
-			Bucket: &c.bucket,
-			Key:    &path,
-		})
-		if err == nil {
-			baseDir := strings.Split(path, "/")[1]
-			pathType := PathType(baseDir)
+	for _, pathType := range pathTypes {
+		for _, variant := range variants {
+			key := c.storageKey(variant, pathType)
+			_, err := c.client.HeadObject(timeoutCtx, &s3.HeadObjectInput{
+				Bucket: &c.bucket,
+				Key:    &key,
+			})
+			if err != nil {
+				continue
+			}
 
 			// Update cache with found path
-			c.UpdatePathInfo(colID, PathInfo{
+			c.addKey(key)
+			c.UpdatePathInfo(ref.ColID, PathInfo{
 				BaseDir:  pathType,
-				GroupDir: groupType,
-				ColID:    colID,
+				GroupDir: ref.GroupType,
+				ColID:    ref.ColID,
 			})
 
-			return baseDir, pathType
+			variant.BaseDir = string(pathType)
+			return variant
 		}
 	}
 
 	// Default to promo if nothing found
-	return "promo", PathTypePromo
+	resolved.BaseDir = string(PathTypePromo)
+	return resolved
+}
+
+// FindStorageKey resolves a card image like FindPathForCard and returns the object key
+// it is stored under, including the card root
+func (c *SpacesCacheManager) FindStorageKey(ctx context.Context, ref CardImageRef) string {
+	resolved := c.FindPathForCard(ctx, ref)
+	return c.storageKey(resolved, PathType(resolved.BaseDir))
+}
+
+// FindStorageKeys returns FindStorageKey followed by the same key in every other
+// format a card image may be stored in
+func (c *SpacesCacheManager) FindStorageKeys(ctx context.Context, ref CardImageRef) []string {
+	resolved := c.FindPathForCard(ctx, ref)
+	pathType := PathType(resolved.BaseDir)
+	keys := []string{c.storageKey(resolved, pathType)}
+	for _, format := range CardImageFormats {
+		variant := resolved
+		variant.Animated = false
+		variant.Format = format
+		if key := c.storageKey(variant, pathType); key != keys[0] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
 }
 
 // GetCacheSize returns the number of items in the cache
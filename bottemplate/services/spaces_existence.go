@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// DefaultExistenceTTL is how long a key confirmed present is trusted before it is checked again
+const DefaultExistenceTTL = 5 * time.Minute
+
+// existenceCache remembers keys recently confirmed present so repeated sync
+// checks don't HEAD every card image again. Missing keys are never cached, an
+// image uploaded out of band shows up on the next check.
+type existenceCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	present map[string]time.Time
+}
+
+func newExistenceCache(ttl time.Duration) *existenceCache {
+	return &existenceCache{
+		ttl:     ttl,
+		present: make(map[string]time.Time),
+	}
+}
+
+func (c *existenceCache) isPresent(key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	checkedAt, ok := c.present[key]
+	return ok && time.Since(checkedAt) < c.ttl
+}
+
+func (c *existenceCache) markPresent(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.present[key] = time.Now()
+}
+
+func (c *existenceCache) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.present, key)
+}
+
+func (c *existenceCache) forgetAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.present = make(map[string]time.Time)
+}
+
+// ObjectExists reports whether an object is stored at key, using a HEAD request.
+// Keys confirmed present are cached for DefaultExistenceTTL; uploads and deletes
+// made through this service bust the cached entry.
+func (s *SpacesService) ObjectExists(ctx context.Context, key string) (bool, error) {
+	if s.existence.isPresent(key) {
+		return true, nil
+	}
+
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check %s: %w", key, err)
+	}
+
+	s.existence.markPresent(key)
+	return true, nil
+}
+
+// ForgetObject drops key from the existence cache, forcing the next check to hit Spaces
+func (s *SpacesService) ForgetObject(key string) {
+	s.existence.forget(key)
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBucket answers S3 requests for a fixed set of object paths and counts HEAD requests
+type fakeBucket struct {
+	mu      sync.Mutex
+	objects map[string]bool
+	heads   int
+}
+
+func (b *fakeBucket) Do(req *http.Request) (*http.Response, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	path := strings.TrimPrefix(req.URL.Path, "/hyejoo/")
+	status := http.StatusOK
+	switch req.Method {
+	case http.MethodHead:
+		b.heads++
+		if !b.objects[path] {
+			status = http.StatusNotFound
+		}
+	case http.MethodDelete:
+		delete(b.objects, path)
+		status = http.StatusNoContent
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
+
+func (b *fakeBucket) headCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.heads
+}
+
+func TestObjectExistsCachesPresentKeys(t *testing.T) {
+	const present = "cards/girlgroups/twice/1_momo.jpg"
+	const missing = "cards/girlgroups/twice/2_sana.jpg"
+	bucket := &fakeBucket{objects: map[string]bool{present: true}}
+	s := newTestSpacesService(bucket)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		exists, err := s.ObjectExists(ctx, present)
+		if err != nil || !exists {
+			t.Fatalf("ObjectExists(%s) = %t, %v, want true", present, exists, err)
+		}
+	}
+	if heads := bucket.headCount(); heads != 1 {
+		t.Errorf("%d HEAD requests for a present key, want 1", heads)
+	}
+
+	// Missing keys are checked every time so out of band uploads show up
+	for i := 0; i < 2; i++ {
+		exists, err := s.ObjectExists(ctx, missing)
+		if err != nil || exists {
+			t.Fatalf("ObjectExists(%s) = %t, %v, want false", missing, exists, err)
+		}
+	}
+	if heads := bucket.headCount(); heads != 3 {
+		t.Errorf("%d HEAD requests, want 3", heads)
+	}
+}
+
+func TestObjectExistsForgetsDeletedKeys(t *testing.T) {
+	const key = "cards/girlgroups/twice/1_momo.jpg"
+	bucket := &fakeBucket{objects: map[string]bool{key: true}}
+	s := newTestSpacesService(bucket)
+	ctx := context.Background()
+
+	if exists, _ := s.ObjectExists(ctx, key); !exists {
+		t.Fatal("key not found before delete")
+	}
+	if err := s.DeleteFile(ctx, key); err != nil {
+		t.Fatalf("DeleteFile: %v", err)
+	}
+	if exists, err := s.ObjectExists(ctx, key); err != nil || exists {
+		t.Errorf("ObjectExists after delete = %t, %v, want false", exists, err)
+	}
+	if heads := bucket.headCount(); heads != 2 {
+		t.Errorf("%d HEAD requests, want 2", heads)
+	}
+}
+
+func TestExistenceCacheExpires(t *testing.T) {
+	c := newExistenceCache(time.Minute)
+	c.markPresent("a")
+	if !c.isPresent("a") {
+		t.Fatal("fresh key not present")
+	}
+	c.present["a"] = time.Now().Add(-2 * time.Minute)
+	if c.isPresent("a") {
+		t.Error("key still present after the TTL")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
 )
 
 // ImageOperation represents the type of image operation
@@ -34,6 +35,7 @@ type ImageManagementResult struct {
 	CollectionID string
 	Level        int
 	URL          string
+	Key          string // Object key the operation resolved to, e.g. the uploaded image
 	ErrorMessage string
 	Stats        map[string]interface{}
 }
@@ -75,14 +77,13 @@ func (m *SpacesImageManager) ManageCardImage(ctx context.Context, operation Imag
 		}
 	}
 
-	// Try all possible path patterns
-	possiblePaths := []string{
-		// Standard cards path
-		fmt.Sprintf("cards/%s/%s/%d_%s.jpg",
-			groupType, card.ColID, card.Level, card.Name),
-		// Promo path
-		fmt.Sprintf("promo/%s/%s/%d_%s.jpg",
-			groupType, card.ColID, card.Level, card.Name),
+	// Try all possible path patterns. The first is the standard cards path with the
+	// slugified name, which uploads always use.
+	var possiblePaths []string
+	for _, baseDir := range []PathType{PathTypeCards, PathTypePromo} {
+		for _, ref := range cardNameVariants(CardImageRef{BaseDir: string(baseDir), GroupType: groupType, ColID: card.ColID, Name: card.Name, Level: card.Level}) {
+			possiblePaths = append(possiblePaths, BuildCardKey(ref))
+		}
 	}
 
 	var foundPath string
@@ -144,6 +145,7 @@ func (m *SpacesImageManager) ManageCardImage(ctx context.Context, operation Imag
 		}
 
 		targetName := strings.ToLower(strings.TrimSuffix(card.Name, ".jpg"))
+		targetSlug := SlugifyCardName(card.Name)
 
 		for _, obj := range output.Contents {
 			if obj.Key == nil {
@@ -165,7 +167,7 @@ func (m *SpacesImageManager) ManageCardImage(ctx context.Context, operation Imag
 			imageNameLower := strings.ToLower(imageName)
 
 			// First try exact match
-			if imageNameLower == targetName {
+			if imageNameLower == targetName || imageNameLower == targetSlug {
 				exactMatch = *obj.Key
 				break
 			}
@@ -217,11 +219,37 @@ func (m *SpacesImageManager) ManageCardImage(ctx context.Context, operation Imag
 
 	// Set success result
 	result.Success = true
+	result.Key = foundPath
 	result.URL = fmt.Sprintf("https://%s.%s.digitaloceanspaces.com/%s", m.bucket, m.region, foundPath)
 
 	return result, nil
 }
 
+// UploadCardImage uploads a card image under the standard cards path and returns its URL
+func (m *SpacesImageManager) UploadCardImage(ctx context.Context, card *models.Card, imageData []byte, extension string, contentType string) (string, error) {
+	key := BuildCardKey(CardImageRef{
+		GroupType: utils.GetGroupType(card.Tags),
+		ColID:     card.ColID,
+		Name:      card.Name,
+		Level:     card.Level,
+		Format:    extension,
+	})
+	input := &s3.PutObjectInput{
+		Bucket:       aws.String(m.bucket),
+		Key:          aws.String(key),
+		Body:         bytes.NewReader(imageData),
+		ContentType:  aws.String(contentType),
+		CacheControl: aws.String("public, max-age=31536000"),
+		ACL:          types.ObjectCannedACLPublicRead,
+	}
+
+	if _, err := m.client.PutObject(ctx, input); err != nil {
+		return "", fmt.Errorf("failed to upload image %s: %w", key, err)
+	}
+
+	return fmt.Sprintf("https://%s.%s.digitaloceanspaces.com/%s", m.bucket, m.region, key), nil
+}
+
 // DeleteCardImage deletes a card image from the storage
 func (m *SpacesImageManager) DeleteCardImage(ctx context.Context, colID string, cardName string, level int, tags []string) error {
 	// Get group type from tags
@@ -240,31 +268,25 @@ func (m *SpacesImageManager) DeleteCardImage(ctx context.Context, colID string,
 	// Check cache first for the correct path
 	pathInfo, exists := m.cacheManager.GetPathInfo(colID)
 
-	var paths []string
+	// Use the cached base directory, or try both
+	baseDirs := []PathType{PathTypeCards, PathTypePromo}
 	if exists {
-		// Use cached path
-		paths = []string{
-			fmt.Sprintf("%s/%s/%s/%s/%d_%s.jpg",
-				m.cardRoot,
-				pathInfo.BaseDir,
-				groupType,
-				colID,
-				level,
-				cardName,
-			),
-		}
-	} else {
-		// Try both possible paths
-		paths = []string{
-			fmt.Sprintf("%s/cards/%s/%s/%d_%s.jpg", m.cardRoot, groupType, colID, level, cardName),
-			fmt.Sprintf("%s/promo/%s/%s/%d_%s.jpg", m.cardRoot, groupType, colID, level, cardName),
+		baseDirs = []PathType{pathInfo.BaseDir}
+	}
+
+	// Both the slugified and the legacy raw-name key are deleted
+	var paths []string
+	for _, baseDir := range baseDirs {
+		ref := CardImageRef{BaseDir: m.cardRoot + "/" + string(baseDir), GroupType: groupType, ColID: colID, Name: cardName, Level: level}
+		for _, variant := range cardNameVariants(ref) {
+			paths = append(paths, BuildCardKey(variant))
 		}
 	}
 
 	var errors []string
 	deleted := false
 
-	// Try deleting from all possible paths
+	// Delete from all possible paths; deleting a missing key is not an error
 	for _, path := range paths {
 		_, err := m.client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: &m.bucket,
@@ -272,13 +294,14 @@ func (m *SpacesImageManager) DeleteCardImage(ctx context.Context, colID string,
 		})
 		if err == nil {
 			deleted = true
-			// Remove from cache if exists
-			m.cacheManager.RemovePathInfo(colID)
-			break
 		} else {
 			errors = append(errors, fmt.Sprintf("path (%s): %v", path, err))
 		}
 	}
+	if deleted {
+		// Remove from cache if exists
+		m.cacheManager.RemovePathInfo(colID)
+	}
 
 	if !deleted {
 		return fmt.Errorf("failed to delete image from any path: %s", strings.Join(errors, "; "))
@@ -322,15 +345,15 @@ func (m *SpacesImageManager) DeleteObject(ctx context.Context, path string) erro
 }
 
 // Helper function to get image name from path
-func getImageNameFromPath(path string) string {
-	parts := strings.Split(path, "/")
+func getImageNameFromPath(key string) string {
+	parts := strings.Split(key, "/")
 	if len(parts) == 0 {
 		return ""
 	}
 	filename := parts[len(parts)-1]
-	// Remove level number and .jpg extension
+	// Remove level number and extension
 	if idx := strings.Index(filename, "_"); idx != -1 {
-		return strings.TrimSuffix(filename[idx+1:], ".jpg")
+		return strings.TrimSuffix(filename[idx+1:], path.Ext(filename))
 	}
 	return ""
 }
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CardImageRef identifies a card image in Spaces storage
+type CardImageRef struct {
+	BaseDir   string // "cards" or "promo"; defaults to "cards"
+	GroupType string // "girlgroups" or "boygroups"; anything else falls back to "girlgroups"
+	ColID     string
+	Name      string
+	Level     int
+	Animated  bool
+	Format    string // stored image format; empty means jpg
+	// LegacyName keeps the raw card name, as keys were built before names were slugified
+	LegacyName bool
+}
+
+// BuildCardKey returns the object key of a card image, e.g. "cards/girlgroups/twice/1_nayeon.jpg".
+// Animated cards always use gif; other cards use their stored format.
+func BuildCardKey(ref CardImageRef) string {
+	baseDir := ref.BaseDir
+	if baseDir == "" {
+		baseDir = string(PathTypeCards)
+	}
+
+	groupType := ref.GroupType
+	if groupType != "girlgroups" && groupType != "boygroups" {
+		groupType = "girlgroups"
+	}
+
+	name := SlugifyCardName(ref.Name)
+	if ref.LegacyName {
+		name = ref.Name
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%d_%s.%s", baseDir, groupType, ref.ColID, ref.Level, name, CardImageExtension(ref.Animated, ref.Format))
+}
+
+// cardNameVariants returns ref with the slugified name, followed by ref with the
+// legacy raw name when that builds a different key
+func cardNameVariants(ref CardImageRef) []CardImageRef {
+	ref.LegacyName = false
+	legacy := ref
+	legacy.LegacyName = true
+	if BuildCardKey(legacy) == BuildCardKey(ref) {
+		return []CardImageRef{ref}
+	}
+	return []CardImageRef{ref, legacy}
+}
+
+// CardImageFormats lists every extension a card image may be stored with
+var CardImageFormats = []string{"jpg", "png", "webp", "gif"}
+
+// CardImageExtension returns the file extension used for a card image
+func CardImageExtension(animated bool, format string) string {
+	if animated {
+		return "gif"
+	}
+	if format == "" {
+		return "jpg"
+	}
+	return strings.ToLower(format)
+}
+
+// SlugifyCardName normalizes a card name for use in an object key. Letters and digits
+// (including non-Latin scripts) are kept and lowercased, runs of whitespace become a
+// single underscore, and apostrophes and other punctuation are dropped.
+func SlugifyCardName(name string) string {
+	var sb strings.Builder
+	pendingSep := false
+
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-':
+			if pendingSep && sb.Len() > 0 {
+				sb.WriteByte('_')
+			}
+			pendingSep = false
+			sb.WriteRune(unicode.ToLower(r))
+		case unicode.IsSpace(r) || r == '_':
+			pendingSep = true
+		}
+	}
+
+	if sb.Len() == 0 {
+		return "card"
+	}
+	return sb.String()
+}
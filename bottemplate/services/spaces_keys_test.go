@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSlugifyCardName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"lowercases", "Nayeon", "nayeon"},
+		{"spaces become underscores", "Jang Won Young", "jang_won_young"},
+		{"runs of whitespace collapse", "  Jang \t Won   Young ", "jang_won_young"},
+		{"underscores are separators", "jang__won_young", "jang_won_young"},
+		{"apostrophes are dropped", "Na'Yeon", "nayeon"},
+		{"punctuation is dropped", "Yuna (Christmas)!", "yuna_christmas"},
+		{"hyphens are kept", "Sana-Chan", "sana-chan"},
+		{"hangul is kept", "나연", "나연"},
+		{"accents are kept", "Chaé Ryeong", "chaé_ryeong"},
+		{"japanese is kept", "サナ Minatozaki", "サナ_minatozaki"},
+		{"digits are kept", "Jennie 2024", "jennie_2024"},
+		{"only punctuation falls back", "?!'", "card"},
+		{"empty falls back", "", "card"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SlugifyCardName(tt.in); got != tt.want {
+				t.Errorf("SlugifyCardName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCardKey(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  CardImageRef
+		want string
+	}{
+		{
+			name: "defaults to cards and jpg",
+			ref:  CardImageRef{GroupType: "girlgroups", ColID: "twice", Name: "Nayeon", Level: 1},
+			want: "cards/girlgroups/twice/1_nayeon.jpg",
+		},
+		{
+			name: "boygroups is kept",
+			ref:  CardImageRef{GroupType: "boygroups", ColID: "bts", Name: "Jung Kook", Level: 3},
+			want: "cards/boygroups/bts/3_jung_kook.jpg",
+		},
+		{
+			name: "unknown group type falls back to girlgroups",
+			ref:  CardImageRef{GroupType: "soloists", ColID: "iu", Name: "IU", Level: 2},
+			want: "cards/girlgroups/iu/2_iu.jpg",
+		},
+		{
+			name: "empty group type falls back to girlgroups",
+			ref:  CardImageRef{ColID: "iu", Name: "IU", Level: 2},
+			want: "cards/girlgroups/iu/2_iu.jpg",
+		},
+		{
+			name: "promo base directory",
+			ref:  CardImageRef{BaseDir: "promo", GroupType: "girlgroups", ColID: "xmas", Name: "Momo", Level: 5},
+			want: "promo/girlgroups/xmas/5_momo.jpg",
+		},
+		{
+			name: "stored format is used",
+			ref:  CardImageRef{GroupType: "girlgroups", ColID: "twice", Name: "Mina", Level: 4, Format: "WEBP"},
+			want: "cards/girlgroups/twice/4_mina.webp",
+		},
+		{
+			name: "animated always uses gif",
+			ref:  CardImageRef{GroupType: "girlgroups", ColID: "twice", Name: "Mina", Level: 4, Animated: true, Format: "webp"},
+			want: "cards/girlgroups/twice/4_mina.gif",
+		},
+		{
+			name: "unicode name",
+			ref:  CardImageRef{GroupType: "girlgroups", ColID: "twice", Name: "나연 Yoo", Level: 1},
+			want: "cards/girlgroups/twice/1_나연_yoo.jpg",
+		},
+		{
+			name: "legacy name keeps the raw card name",
+			ref:  CardImageRef{GroupType: "girlgroups", ColID: "twice", Name: "Na'Yeon", Level: 1, LegacyName: true},
+			want: "cards/girlgroups/twice/1_Na'Yeon.jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildCardKey(tt.ref); got != tt.want {
+				t.Errorf("BuildCardKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardNameVariants(t *testing.T) {
+	slugOnly := cardNameVariants(CardImageRef{ColID: "twice", Name: "nayeon", Level: 1})
+	if len(slugOnly) != 1 {
+		t.Fatalf("got %d variants for a name that is already a slug, want 1", len(slugOnly))
+	}
+
+	both := cardNameVariants(CardImageRef{ColID: "twice", Name: "Na'Yeon", Level: 1})
+	if len(both) != 2 || both[0].LegacyName || !both[1].LegacyName {
+		t.Fatalf("got %+v, want the slug followed by the legacy name", both)
+	}
+}
+
+func TestFindPathForCardKnownKeys(t *testing.T) {
+	ctx := context.Background()
+	ref := CardImageRef{GroupType: "girlgroups", ColID: "twice", Name: "Na'Yeon", Level: 1}
+
+	t.Run("legacy key stays readable", func(t *testing.T) {
+		c := NewSpacesCacheManager(nil, "bucket", "cards")
+		c.addKey("cards/girlgroups/twice/1_Na'Yeon.jpg")
+
+		got := c.FindPathForCard(ctx, ref)
+		if key := BuildCardKey(got); key != "cards/girlgroups/twice/1_Na'Yeon.jpg" {
+			t.Errorf("resolved key = %q, want the legacy key", key)
+		}
+	})
+
+	t.Run("slug key wins over legacy", func(t *testing.T) {
+		c := NewSpacesCacheManager(nil, "bucket", "cards")
+		c.addKey("cards/girlgroups/twice/1_Na'Yeon.jpg")
+		c.addKey("cards/girlgroups/twice/1_nayeon.jpg")
+
+		got := c.FindPathForCard(ctx, ref)
+		if key := BuildCardKey(got); key != "cards/girlgroups/twice/1_nayeon.jpg" {
+			t.Errorf("resolved key = %q, want the slug key", key)
+		}
+	})
+
+	t.Run("promo key is found", func(t *testing.T) {
+		c := NewSpacesCacheManager(nil, "bucket", "cards")
+		c.addKey("cards/promo/girlgroups/twice/1_nayeon.jpg")
+
+		got := c.FindPathForCard(ctx, ref)
+		if key := BuildCardKey(got); key != "promo/girlgroups/twice/1_nayeon.jpg" {
+			t.Errorf("resolved key = %q, want the promo slug key", key)
+		}
+		if key := c.FindStorageKey(ctx, ref); key != "cards/promo/girlgroups/twice/1_nayeon.jpg" {
+			t.Errorf("storage key = %q, want the stored promo key", key)
+		}
+	})
+
+	t.Run("storage keys cover every format", func(t *testing.T) {
+		c := NewSpacesCacheManager(nil, "bucket", "cards")
+		c.addKey("cards/promo/girlgroups/twice/1_nayeon.webp")
+
+		webp := ref
+		webp.Format = "webp"
+		want := []string{
+			"cards/promo/girlgroups/twice/1_nayeon.webp",
+			"cards/promo/girlgroups/twice/1_nayeon.jpg",
+			"cards/promo/girlgroups/twice/1_nayeon.png",
+			"cards/promo/girlgroups/twice/1_nayeon.gif",
+		}
+		if got := c.FindStorageKeys(ctx, webp); !reflect.DeepEqual(got, want) {
+			t.Errorf("storage keys = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("storage key keeps the card root", func(t *testing.T) {
+		c := NewSpacesCacheManager(nil, "bucket", "images/cards")
+		c.addKey("images/cards/girlgroups/twice/1_nayeon.jpg")
+
+		if key := c.FindStorageKey(ctx, ref); key != "images/cards/girlgroups/twice/1_nayeon.jpg" {
+			t.Errorf("storage key = %q, want the stored key", key)
+		}
+	})
+
+	t.Run("webp image is found by its format", func(t *testing.T) {
+		c := NewSpacesCacheManager(nil, "bucket", "cards")
+		c.addKey("cards/girlgroups/twice/1_nayeon.webp")
+
+		webp := ref
+		webp.Format = "webp"
+		got := c.FindPathForCard(ctx, webp)
+		if got.BaseDir != string(PathTypeCards) {
+			t.Errorf("base dir = %q, want %q", got.BaseDir, PathTypeCards)
+		}
+	})
+
+	t.Run("cached collection without a known key uses the slug", func(t *testing.T) {
+		c := NewSpacesCacheManager(nil, "bucket", "cards")
+		c.UpdatePathInfo("twice", PathInfo{BaseDir: PathTypeCards, GroupDir: "girlgroups", ColID: "twice"})
+
+		got := c.FindPathForCard(ctx, ref)
+		if key := BuildCardKey(got); key != "cards/girlgroups/twice/1_nayeon.jpg" {
+			t.Errorf("resolved key = %q, want the slug key", key)
+		}
+	})
+}
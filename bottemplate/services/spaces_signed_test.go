@@ -0,0 +1,125 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// newTestSpacesService returns a SpacesService whose requests go to httpClient.
+// Signing URLs needs no requests at all, so httpClient may be nil.
+func newTestSpacesService(httpClient s3.HTTPClient) *SpacesService {
+	client := s3.New(s3.Options{
+		Region:       "sgp1",
+		Credentials:  credentials.NewStaticCredentialsProvider("test-key", "test-secret", ""),
+		BaseEndpoint: aws.String("https://sgp1.digitaloceanspaces.com"),
+		UsePathStyle: true,
+		HTTPClient:   httpClient,
+	})
+	return &SpacesService{
+		client:     client,
+		presign:    s3.NewPresignClient(client),
+		bucket:     "hyejoo",
+		region:     "sgp1",
+		existence:  newExistenceCache(DefaultExistenceTTL),
+		signedURLs: make(map[string]signedURL),
+	}
+}
+
+func TestGetSignedCardImageURL(t *testing.T) {
+	s := newTestSpacesService(nil)
+	const key = "cards/girlgroups/twice/1_momo.jpg"
+
+	raw, err := s.GetSignedCardImageURL(key, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("GetSignedCardImageURL: %v", err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse %q: %v", raw, err)
+	}
+
+	if !strings.HasSuffix(u.Path, "/"+key) || !strings.Contains(u.Host+u.Path, "hyejoo") {
+		t.Errorf("URL %s doesn't point at %s in the bucket", raw, key)
+	}
+	query := u.Query()
+	if got := query.Get("X-Amz-Expires"); got != "600" {
+		t.Errorf("X-Amz-Expires = %q, want 600", got)
+	}
+	if !strings.HasPrefix(query.Get("X-Amz-Credential"), "test-key/") {
+		t.Errorf("X-Amz-Credential = %q, want the configured key", query.Get("X-Amz-Credential"))
+	}
+	if query.Get("X-Amz-Signature") == "" {
+		t.Error("URL is not signed")
+	}
+}
+
+func TestGetSignedCardImageURLCache(t *testing.T) {
+	s := newTestSpacesService(nil)
+	const key = "cards/girlgroups/twice/1_momo.jpg"
+	ttl := 10 * time.Minute
+	cacheKey := fmt.Sprintf("%s|%d", key, ttl)
+
+	before := time.Now()
+	if _, err := s.GetSignedCardImageURL(key, ttl); err != nil {
+		t.Fatalf("GetSignedCardImageURL: %v", err)
+	}
+	entry, ok := s.signedURLs[cacheKey]
+	if !ok {
+		t.Fatalf("signature not cached under %q: %v", cacheKey, s.signedURLs)
+	}
+	if entry.refreshAt.Before(before.Add(ttl/2)) || entry.refreshAt.After(time.Now().Add(ttl/2)) {
+		t.Errorf("refreshAt = %s, want half the TTL from now", entry.refreshAt)
+	}
+
+	// A fresh entry is reused as is
+	s.signedURLs[cacheKey] = signedURL{url: "cached", refreshAt: time.Now().Add(time.Minute)}
+	if got, _ := s.GetSignedCardImageURL(key, ttl); got != "cached" {
+		t.Errorf("got %q, want the cached URL", got)
+	}
+
+	// Past the refresh point the URL is signed again
+	s.signedURLs[cacheKey] = signedURL{url: "cached", refreshAt: time.Now().Add(-time.Second)}
+	if got, _ := s.GetSignedCardImageURL(key, ttl); got == "cached" || !strings.Contains(got, "X-Amz-Signature=") {
+		t.Errorf("got %q, want a new signature", got)
+	}
+
+	// A different TTL is signed separately
+	if got, _ := s.GetSignedCardImageURL(key, time.Minute); !strings.Contains(got, "X-Amz-Expires=60") {
+		t.Errorf("got %q, want a one minute signature", got)
+	}
+}
+
+func TestGetCardImageURLSignsStorageKey(t *testing.T) {
+	s := newTestSpacesService(nil)
+	s.cacheManager = NewSpacesCacheManager(nil, "hyejoo", "cards")
+	s.cacheManager.addKey("cards/promo/girlgroups/xmas/5_momo.jpg")
+	s.UseSignedURLs(time.Minute)
+
+	raw := s.GetCardImageURLWithFormat("Momo", "xmas", 5, "girlgroups", false, "")
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse %q: %v", raw, err)
+	}
+	if !strings.HasSuffix(u.Path, "/cards/promo/girlgroups/xmas/5_momo.jpg") {
+		t.Errorf("URL %s doesn't point at the stored promo image", raw)
+	}
+}
+
+func TestUseSignedURLsDefaultTTL(t *testing.T) {
+	s := newTestSpacesService(nil)
+	s.UseSignedURLs(0)
+	if s.signedTTL != DefaultSignedURLTTL {
+		t.Errorf("signedTTL = %s, want %s", s.signedTTL, DefaultSignedURLTTL)
+	}
+	s.UseSignedURLs(time.Hour)
+	if s.signedTTL != time.Hour {
+		t.Errorf("signedTTL = %s, want 1h", s.signedTTL)
+	}
+}
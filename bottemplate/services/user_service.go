@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+)
+
+// DefaultPremiumExpiryInterval is used when no premium expiry sweep interval is configured
+const DefaultPremiumExpiryInterval = time.Hour
+
+// PremiumPerks are the bonuses premium users get. Zero multipliers leave the value unchanged.
+type PremiumPerks struct {
+	DailyMultiplier         float64 `toml:"daily_multiplier"`          // e.g. 1.5 pays 50% more on /daily
+	WorkMultiplier          float64 `toml:"work_multiplier"`           // applied to /work flakes, vials and XP
+	ClaimCooldownMultiplier float64 `toml:"claim_cooldown_multiplier"` // e.g. 0.5 halves the claim cooldown
+}
+
+// UserService applies per-user status such as premium to rewards and cooldowns
+type UserService struct {
+	users    repositories.UserRepository
+	perks    PremiumPerks
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewUserService creates a user service that grants perks to premium users and
+// sweeps expired premium every interval
+func NewUserService(users repositories.UserRepository, perks PremiumPerks, interval time.Duration) *UserService {
+	if interval <= 0 {
+		interval = DefaultPremiumExpiryInterval
+	}
+	return &UserService{
+		users:    users,
+		perks:    perks,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// IsPremium reports whether the user has premium that hasn't expired yet
+func (s *UserService) IsPremium(user *models.User) bool {
+	return user.PremiumActive(s.now())
+}
+
+// DailyReward returns the /daily payout for the user
+func (s *UserService) DailyReward(user *models.User, base int64) int64 {
+	return s.scale(user, base, s.perks.DailyMultiplier)
+}
+
+// WorkReward returns a /work payout amount for the user
+func (s *UserService) WorkReward(user *models.User, base int64) int64 {
+	return s.scale(user, base, s.perks.WorkMultiplier)
+}
+
+// ClaimCooldown returns the claim cooldown for the user
+func (s *UserService) ClaimCooldown(user *models.User, base time.Duration) time.Duration {
+	if s.perks.ClaimCooldownMultiplier <= 0 || !s.IsPremium(user) {
+		return base
+	}
+	return time.Duration(float64(base) * s.perks.ClaimCooldownMultiplier)
+}
+
+// ClaimCooldownByID is ClaimCooldown for callers that only know the user's Discord ID.
+// Lookup failures keep the base cooldown.
+func (s *UserService) ClaimCooldownByID(userID string, base time.Duration) time.Duration {
+	if s.perks.ClaimCooldownMultiplier <= 0 {
+		return base
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	user, err := s.users.GetByDiscordID(ctx, userID)
+	if err != nil {
+		return base
+	}
+	return s.ClaimCooldown(user, base)
+}
+
+func (s *UserService) scale(user *models.User, base int64, multiplier float64) int64 {
+	if multiplier <= 0 || !s.IsPremium(user) {
+		return base
+	}
+	return int64(float64(base) * multiplier)
+}
+
+// Run expires premium immediately and then on every interval until ctx is cancelled
+func (s *UserService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := s.ExpirePremium(ctx)
+		if err != nil {
+			slog.Error("Failed to expire premium", slog.Any("error", err))
+		}
+		utils.RecordProcessRun(ctx, err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ExpirePremium turns premium off for every user whose premium has run out
+func (s *UserService) ExpirePremium(ctx context.Context) (int64, error) {
+	expired, err := s.users.ExpirePremium(ctx, s.now())
+	if err != nil {
+		return 0, err
+	}
+	if expired > 0 {
+		slog.Info("Expired premium", slog.Int64("users", expired))
+	}
+	return expired, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+)
+
+// premiumUserRepo serves fixed users and records the ExpirePremium calls
+type premiumUserRepo struct {
+	repositories.UserRepository
+	users   map[string]*models.User
+	expired []time.Time
+}
+
+func (r *premiumUserRepo) GetByDiscordID(ctx context.Context, discordID string) (*models.User, error) {
+	if user, ok := r.users[discordID]; ok {
+		return user, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *premiumUserRepo) ExpirePremium(ctx context.Context, now time.Time) (int64, error) {
+	r.expired = append(r.expired, now)
+	return 1, nil
+}
+
+func TestUserServicePremiumPerks(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	active := &models.User{DiscordID: "active", Premium: true, PremiumExpires: now.Add(time.Hour)}
+	lifetime := &models.User{DiscordID: "lifetime", Premium: true}
+	expired := &models.User{DiscordID: "expired", Premium: true, PremiumExpires: now}
+	regular := &models.User{DiscordID: "regular"}
+
+	repo := &premiumUserRepo{users: map[string]*models.User{"active": active, "expired": expired}}
+	s := NewUserService(repo, PremiumPerks{DailyMultiplier: 1.5, WorkMultiplier: 2, ClaimCooldownMultiplier: 0.5}, 0)
+	s.now = func() time.Time { return now }
+
+	tests := []struct {
+		name     string
+		user     *models.User
+		daily    int64
+		work     int64
+		cooldown time.Duration
+	}{
+		{"active", active, 1500, 200, 15 * time.Minute},
+		{"no expiry", lifetime, 1500, 200, 15 * time.Minute},
+		// Premium that ran out at now no longer counts, even before the sweep
+		{"expired", expired, 1000, 100, 30 * time.Minute},
+		{"regular", regular, 1000, 100, 30 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.DailyReward(tt.user, 1000); got != tt.daily {
+				t.Errorf("DailyReward = %d, want %d", got, tt.daily)
+			}
+			if got := s.WorkReward(tt.user, 100); got != tt.work {
+				t.Errorf("WorkReward = %d, want %d", got, tt.work)
+			}
+			if got := s.ClaimCooldown(tt.user, 30*time.Minute); got != tt.cooldown {
+				t.Errorf("ClaimCooldown = %s, want %s", got, tt.cooldown)
+			}
+		})
+	}
+
+	if got := s.ClaimCooldownByID("active", time.Hour); got != 30*time.Minute {
+		t.Errorf("ClaimCooldownByID(active) = %s, want 30m", got)
+	}
+	if got := s.ClaimCooldownByID("missing", time.Hour); got != time.Hour {
+		t.Errorf("ClaimCooldownByID(missing) = %s, want the base cooldown", got)
+	}
+}
+
+func TestUserServiceUnsetPerks(t *testing.T) {
+	s := NewUserService(&premiumUserRepo{}, PremiumPerks{}, 0)
+	user := &models.User{Premium: true}
+	if got := s.DailyReward(user, 1000); got != 1000 {
+		t.Errorf("DailyReward = %d, want it unchanged", got)
+	}
+	if got := s.ClaimCooldownByID("anyone", time.Hour); got != time.Hour {
+		t.Errorf("ClaimCooldownByID = %s, want it unchanged", got)
+	}
+	if s.interval != DefaultPremiumExpiryInterval {
+		t.Errorf("interval = %s, want the default", s.interval)
+	}
+}
+
+func TestUserServiceExpirePremium(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo := &premiumUserRepo{}
+	s := NewUserService(repo, PremiumPerks{}, time.Hour)
+	s.now = func() time.Time { return now }
+
+	if expired, err := s.ExpirePremium(context.Background()); err != nil || expired != 1 {
+		t.Fatalf("ExpirePremium = %d, %v, want 1", expired, err)
+	}
+	if len(repo.expired) != 1 || !repo.expired[0].Equal(now) {
+		t.Errorf("repository called with %v, want %v", repo.expired, now)
+	}
+}
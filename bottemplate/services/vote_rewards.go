@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+	"github.com/disgoorg/bot-template/bottemplate/database/repositories"
+	economicUtils "github.com/disgoorg/bot-template/bottemplate/economy/utils"
+	"github.com/disgoorg/bot-template/bottemplate/utils"
+	"github.com/uptrace/bun"
+)
+
+// DefaultVoteCooldown matches how often a bot list lets a user vote
+const DefaultVoteCooldown = 12 * time.Hour
+
+// ErrVoteCooldown is returned when a vote arrives before the previous one's cooldown ran out
+var ErrVoteCooldown = errors.New("vote reward is on cooldown")
+
+// VoteReward is what a user receives for voting for the bot
+type VoteReward struct {
+	Flakes        int64 `toml:"flakes"`
+	CooldownHours int   `toml:"cooldown_hours"` // 0 uses DefaultVoteCooldown
+}
+
+// Cooldown is the time between two rewarded votes
+func (r VoteReward) Cooldown() time.Duration {
+	if r.CooldownHours <= 0 {
+		return DefaultVoteCooldown
+	}
+	return time.Duration(r.CooldownHours) * time.Hour
+}
+
+// Validate reports the first inconsistency in the reward
+func (r VoteReward) Validate() error {
+	if r.Flakes < 0 {
+		return fmt.Errorf("flakes must not be negative, got %d", r.Flakes)
+	}
+	if r.CooldownHours < 0 {
+		return fmt.Errorf("cooldown_hours must not be negative, got %d", r.CooldownHours)
+	}
+	return nil
+}
+
+// VoteRewardService grants the vote reward and records the vote on the user
+type VoteRewardService struct {
+	users     repositories.UserRepository
+	txManager *economicUtils.EconomicTransactionManager
+	reward    VoteReward
+}
+
+// NewVoteRewardService creates a service granting reward for each vote outside the cooldown
+func NewVoteRewardService(db *bun.DB, users repositories.UserRepository, reward VoteReward) *VoteRewardService {
+	return &VoteRewardService{
+		users:     users,
+		txManager: economicUtils.NewEconomicTransactionManager(db),
+		reward:    reward,
+	}
+}
+
+// Reward returns the configured vote reward
+func (s *VoteRewardService) Reward() VoteReward {
+	return s.reward
+}
+
+// Cooldown reports when the user's next vote will be rewarded
+func (s *VoteRewardService) Cooldown(user *models.User) utils.Cooldown {
+	return utils.CheckCooldown(user.LastVote, s.reward.Cooldown())
+}
+
+// Grant records a vote by the user at now and pays the reward. Recording the vote and
+// paying happen in one transaction; a vote within the cooldown returns ErrVoteCooldown
+// and pays nothing.
+func (s *VoteRewardService) Grant(ctx context.Context, userID string, now time.Time) (int64, error) {
+	err := s.txManager.WithTransaction(ctx, economicUtils.StandardTransactionOptions(), func(ctx context.Context, tx bun.Tx) error {
+		claimed, err := s.users.ClaimVote(ctx, tx, userID, s.reward.Cooldown(), now)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return ErrVoteCooldown
+		}
+		if s.reward.Flakes == 0 {
+			return nil
+		}
+		return s.txManager.ValidateAndUpdateBalance(ctx, tx, economicUtils.BalanceOperationOptions{
+			UserID: userID,
+			Amount: s.reward.Flakes,
+			Reason: models.LedgerReasonVote,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return s.reward.Flakes, nil
+}
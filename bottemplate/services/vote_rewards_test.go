@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestVoteRewardCooldown(t *testing.T) {
+	if got := (VoteReward{}).Cooldown(); got != DefaultVoteCooldown {
+		t.Errorf("default cooldown = %s, want %s", got, DefaultVoteCooldown)
+	}
+	if got := (VoteReward{CooldownHours: 24}).Cooldown(); got != 24*time.Hour {
+		t.Errorf("cooldown = %s, want 24h", got)
+	}
+	if err := (VoteReward{Flakes: 500, CooldownHours: 12}).Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	for _, invalid := range []VoteReward{{Flakes: -1}, {CooldownHours: -1}} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Validate accepted %+v", invalid)
+		}
+	}
+}
+
+func TestVoteRewardServiceCooldown(t *testing.T) {
+	s := NewVoteRewardService(nil, nil, VoteReward{Flakes: 500})
+
+	recent := &models.User{LastVote: time.Now().Add(-time.Hour)}
+	if cooldown := s.Cooldown(recent); !cooldown.Active() || cooldown.Remaining < 10*time.Hour {
+		t.Errorf("cooldown an hour after voting = %+v, want about 11h left", cooldown)
+	}
+	if cooldown := s.Cooldown(&models.User{LastVote: time.Now().Add(-13 * time.Hour)}); cooldown.Active() {
+		t.Errorf("cooldown after 13h = %+v, want ready", cooldown)
+	}
+}
@@ -2,11 +2,28 @@ package utils
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"sort"
 	"sync"
 	"time"
 )
 
+// Process statuses reported by ListProcesses
+const (
+	ProcessStatusRunning = "running"
+	ProcessStatusStopped = "stopped"
+	ProcessStatusExited  = "exited"
+	ProcessStatusCrashed = "crashed"
+)
+
+// ErrProcessNotFound is returned when restarting a process that was never started
+var ErrProcessNotFound = errors.New("background process not found")
+
+// processRestartTimeout bounds how long a restart waits for the old run to exit
+const processRestartTimeout = 10 * time.Second
+
 // BackgroundProcessManager manages all background goroutines with proper lifecycle control
 type BackgroundProcessManager struct {
 	ctx       context.Context
@@ -17,11 +34,38 @@ type BackgroundProcessManager struct {
 }
 
 type ProcessInfo struct {
+	manager     *BackgroundProcessManager
 	name        string
 	cancel      context.CancelFunc
 	description string
+	fn          func(ctx context.Context)
+	done        chan struct{}
+	generation  int
+
+	status      string
+	startedAt   time.Time
+	restarts    int
+	lastRun     time.Time
+	lastSuccess time.Time
+	lastError   string
+	lastErrorAt time.Time
+}
+
+// ProcessStatus is a point-in-time snapshot of a background process
+type ProcessStatus struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	StartedAt   time.Time  `json:"started_at"`
+	Restarts    int        `json:"restarts"`
+	LastRun     *time.Time `json:"last_run,omitempty"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
 }
 
+type processInfoKey struct{}
+
 // NewBackgroundProcessManager creates a new process manager
 func NewBackgroundProcessManager() *BackgroundProcessManager {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -32,32 +76,62 @@ func NewBackgroundProcessManager() *BackgroundProcessManager {
 	}
 }
 
-// StartProcess registers and starts a background process
+// StartProcess registers and starts a background process. It does nothing once
+// Shutdown has begun, so no run is added while Shutdown waits for the others.
 func (bpm *BackgroundProcessManager) StartProcess(name, description string, fn func(ctx context.Context)) {
 	bpm.mu.Lock()
 	defer bpm.mu.Unlock()
 
-	if _, exists := bpm.processes[name]; exists {
+	if bpm.ctx.Err() != nil {
+		slog.Warn("Process manager is shutting down, not starting process", slog.String("name", name))
+		return
+	}
+
+	process, exists := bpm.processes[name]
+	if exists && process.status == ProcessStatusRunning {
 		slog.Warn("Process already exists, stopping existing one", slog.String("name", name))
 		bpm.stopProcessLocked(name)
 	}
+	if !exists {
+		process = &ProcessInfo{manager: bpm, name: name}
+		bpm.processes[name] = process
+	}
+	process.description = description
+	process.fn = fn
+
+	bpm.runLocked(process)
+}
 
+// runLocked starts a new run of process. Callers must hold bpm.mu.
+func (bpm *BackgroundProcessManager) runLocked(process *ProcessInfo) {
 	processCtx, processCancel := context.WithCancel(bpm.ctx)
-	bpm.processes[name] = &ProcessInfo{
-		name:        name,
-		cancel:      processCancel,
-		description: description,
-	}
+	done := make(chan struct{})
+
+	process.generation++
+	process.cancel = processCancel
+	process.done = done
+	process.status = ProcessStatusRunning
+	process.startedAt = time.Now()
+
+	generation := process.generation
+	name, description, fn := process.name, process.description, process.fn
+	processCtx = context.WithValue(processCtx, processInfoKey{}, process)
 
 	bpm.wg.Add(1)
 	go func() {
 		defer bpm.wg.Done()
+		defer close(done)
+
+		status := ProcessStatusExited
 		defer func() {
 			if r := recover(); r != nil {
 				slog.Error("Background process panic",
 					slog.String("process", name),
 					slog.Any("panic", r))
+				status = ProcessStatusCrashed
+				bpm.recordRun(process, fmt.Errorf("panic: %v", r))
 			}
+			bpm.finishRun(process, generation, status)
 		}()
 
 		slog.Info("Starting background process",
@@ -71,6 +145,79 @@ func (bpm *BackgroundProcessManager) StartProcess(name, description string, fn f
 	}()
 }
 
+// finishRun marks a run as ended unless a newer run has replaced it
+func (bpm *BackgroundProcessManager) finishRun(process *ProcessInfo, generation int, status string) {
+	bpm.mu.Lock()
+	defer bpm.mu.Unlock()
+
+	if process.generation != generation || process.status != ProcessStatusRunning {
+		return
+	}
+	process.status = status
+}
+
+// RecordProcessRun records the outcome of one iteration of the background
+// process that owns ctx. It is a no-op outside a managed process.
+func RecordProcessRun(ctx context.Context, err error) {
+	if process, ok := ctx.Value(processInfoKey{}).(*ProcessInfo); ok {
+		process.manager.recordRun(process, err)
+	}
+}
+
+func (bpm *BackgroundProcessManager) recordRun(process *ProcessInfo, err error) {
+	bpm.mu.Lock()
+	defer bpm.mu.Unlock()
+
+	now := time.Now()
+	process.lastRun = now
+	if err != nil {
+		process.lastError = err.Error()
+		process.lastErrorAt = now
+		return
+	}
+	process.lastSuccess = now
+}
+
+// RestartProcess stops a process, waits for its current run to exit and starts it again.
+// Concurrent restarts of the same process start a single new run.
+func (bpm *BackgroundProcessManager) RestartProcess(name string) error {
+	bpm.mu.Lock()
+	process, exists := bpm.processes[name]
+	if !exists {
+		bpm.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrProcessNotFound, name)
+	}
+	done := process.done
+	if process.status == ProcessStatusRunning {
+		process.cancel()
+		process.status = ProcessStatusStopped
+	}
+	bpm.mu.Unlock()
+
+	select {
+	case <-done:
+	case <-time.After(processRestartTimeout):
+		return fmt.Errorf("timed out waiting for process %s to stop", name)
+	}
+
+	bpm.mu.Lock()
+	defer bpm.mu.Unlock()
+	if bpm.ctx.Err() != nil {
+		return fmt.Errorf("process manager is shutting down")
+	}
+	if process.done != done || process.status == ProcessStatusRunning {
+		// Another restart or start replaced the run while we waited
+		return nil
+	}
+	process.restarts++
+	bpm.runLocked(process)
+
+	slog.Info("Restarted background process",
+		slog.String("process", name),
+		slog.Int("restarts", process.restarts))
+	return nil
+}
+
 // StopProcess stops a specific background process
 func (bpm *BackgroundProcessManager) StopProcess(name string) {
 	bpm.mu.Lock()
@@ -78,23 +225,25 @@ func (bpm *BackgroundProcessManager) StopProcess(name string) {
 	bpm.stopProcessLocked(name)
 }
 
+// stopProcessLocked cancels a running process. It stays listed so it can be restarted.
 func (bpm *BackgroundProcessManager) stopProcessLocked(name string) {
-	if process, exists := bpm.processes[name]; exists {
+	if process, exists := bpm.processes[name]; exists && process.status == ProcessStatusRunning {
 		process.cancel()
-		delete(bpm.processes, name)
+		process.status = ProcessStatusStopped
 		slog.Info("Stopped background process", slog.String("process", name))
 	}
 }
 
-// Shutdown gracefully stops all background processes
-func (bpm *BackgroundProcessManager) Shutdown(timeout time.Duration) error {
+// Shutdown cancels all background processes and waits for them to return until
+// ctx is done. Processes still running at the deadline are logged by name.
+func (bpm *BackgroundProcessManager) Shutdown(ctx context.Context) error {
 	slog.Info("Shutting down background processes",
-		slog.Int("process_count", len(bpm.processes)))
+		slog.Int("process_count", bpm.GetProcessCount()))
 
 	// Cancel all processes
 	bpm.cancel()
 
-	// Wait for all processes to finish with timeout
+	// Wait for all processes to finish until the deadline
 	done := make(chan struct{})
 	go func() {
 		bpm.wg.Wait()
@@ -105,29 +254,68 @@ func (bpm *BackgroundProcessManager) Shutdown(timeout time.Duration) error {
 	case <-done:
 		slog.Info("All background processes stopped gracefully")
 		return nil
-	case <-time.After(timeout):
+	case <-ctx.Done():
+		pending := bpm.pendingProcesses()
 		slog.Warn("Timeout waiting for background processes to stop",
-			slog.Duration("timeout", timeout))
-		return context.DeadlineExceeded
+			slog.Int("pending_count", len(pending)),
+			slog.Any("pending", pending))
+		return fmt.Errorf("background processes still running: %w", ctx.Err())
+	}
+}
+
+// pendingProcesses returns the names of processes whose latest run hasn't returned yet
+func (bpm *BackgroundProcessManager) pendingProcesses() []string {
+	bpm.mu.RLock()
+	defer bpm.mu.RUnlock()
+
+	var pending []string
+	for name, process := range bpm.processes {
+		select {
+		case <-process.done:
+		default:
+			pending = append(pending, name)
+		}
 	}
+	sort.Strings(pending)
+	return pending
 }
 
-// GetProcessCount returns the number of active processes
+// GetProcessCount returns the number of running processes
 func (bpm *BackgroundProcessManager) GetProcessCount() int {
 	bpm.mu.RLock()
 	defer bpm.mu.RUnlock()
-	return len(bpm.processes)
+
+	count := 0
+	for _, process := range bpm.processes {
+		if process.status == ProcessStatusRunning {
+			count++
+		}
+	}
+	return count
 }
 
-// ListProcesses returns information about all active processes
-func (bpm *BackgroundProcessManager) ListProcesses() []ProcessInfo {
+// ListProcesses returns a snapshot of every registered process, sorted by name
+func (bpm *BackgroundProcessManager) ListProcesses() []ProcessStatus {
 	bpm.mu.RLock()
 	defer bpm.mu.RUnlock()
 
-	processes := make([]ProcessInfo, 0, len(bpm.processes))
+	processes := make([]ProcessStatus, 0, len(bpm.processes))
 	for _, process := range bpm.processes {
-		processes = append(processes, *process)
+		processes = append(processes, ProcessStatus{
+			Name:        process.name,
+			Description: process.description,
+			Status:      process.status,
+			StartedAt:   process.startedAt,
+			Restarts:    process.restarts,
+			LastRun:     timePtr(process.lastRun),
+			LastSuccess: timePtr(process.lastSuccess),
+			LastError:   process.lastError,
+			LastErrorAt: timePtr(process.lastErrorAt),
+		})
 	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].Name < processes[j].Name
+	})
 	return processes
 }
 
@@ -135,3 +323,10 @@ func (bpm *BackgroundProcessManager) ListProcesses() []ProcessInfo {
 func (bpm *BackgroundProcessManager) Context() context.Context {
 	return bpm.ctx
 }
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
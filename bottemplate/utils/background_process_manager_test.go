@@ -0,0 +1,219 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForStatus polls until the named process reports want
+func waitForStatus(t *testing.T, bpm *BackgroundProcessManager, name, want string) ProcessStatus {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		for _, status := range bpm.ListProcesses() {
+			if status.Name == name && status.Status == want {
+				return status
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s never became %s: %+v", name, want, bpm.ListProcesses())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestBackgroundProcessStopAndRestart(t *testing.T) {
+	bpm := NewBackgroundProcessManager()
+	t.Cleanup(func() { bpm.Shutdown(context.Background()) })
+
+	runs := make(chan struct{}, 4)
+	bpm.StartProcess("sweeper", "sweeps things", func(ctx context.Context) {
+		RecordProcessRun(ctx, nil)
+		runs <- struct{}{}
+		<-ctx.Done()
+	})
+	<-runs
+
+	status := waitForStatus(t, bpm, "sweeper", ProcessStatusRunning)
+	if status.LastSuccess == nil || status.Description != "sweeps things" {
+		t.Errorf("status = %+v, want a recorded successful run", status)
+	}
+	if n := bpm.GetProcessCount(); n != 1 {
+		t.Errorf("running processes = %d, want 1", n)
+	}
+
+	bpm.StopProcess("sweeper")
+	waitForStatus(t, bpm, "sweeper", ProcessStatusStopped)
+	if n := bpm.GetProcessCount(); n != 0 {
+		t.Errorf("running processes after stop = %d, want 0", n)
+	}
+
+	if err := bpm.RestartProcess("sweeper"); err != nil {
+		t.Fatalf("RestartProcess: %v", err)
+	}
+	<-runs
+	if status := waitForStatus(t, bpm, "sweeper", ProcessStatusRunning); status.Restarts != 1 {
+		t.Errorf("restarts = %d, want 1", status.Restarts)
+	}
+
+	// Restarting a running process replaces its run
+	if err := bpm.RestartProcess("sweeper"); err != nil {
+		t.Fatalf("RestartProcess: %v", err)
+	}
+	<-runs
+	if status := waitForStatus(t, bpm, "sweeper", ProcessStatusRunning); status.Restarts != 2 {
+		t.Errorf("restarts = %d, want 2", status.Restarts)
+	}
+
+	if err := bpm.RestartProcess("missing"); !errors.Is(err, ErrProcessNotFound) {
+		t.Errorf("restart unknown process: %v, want ErrProcessNotFound", err)
+	}
+}
+
+func TestConcurrentRestartsStartOneRun(t *testing.T) {
+	bpm := NewBackgroundProcessManager()
+	t.Cleanup(func() { bpm.Shutdown(context.Background()) })
+
+	var active atomic.Int32
+	bpm.StartProcess("worker", "slow to stop", func(ctx context.Context) {
+		active.Add(1)
+		defer active.Add(-1)
+		<-ctx.Done()
+		// Both restarts are waiting on this run by the time it exits
+		time.Sleep(50 * time.Millisecond)
+	})
+	waitForStatus(t, bpm, "worker", ProcessStatusRunning)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := bpm.RestartProcess("worker"); err != nil {
+				t.Errorf("RestartProcess: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(100 * time.Millisecond)
+	if n := active.Load(); n != 1 {
+		t.Errorf("%d runs after two concurrent restarts, want 1", n)
+	}
+
+	bpm.StopProcess("worker")
+	deadline := time.Now().Add(2 * time.Second)
+	for active.Load() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("a run kept going after StopProcess")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStartProcessAfterShutdown(t *testing.T) {
+	bpm := NewBackgroundProcessManager()
+	if err := bpm.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	ran := make(chan struct{}, 1)
+	bpm.StartProcess("late", "started after shutdown", func(ctx context.Context) {
+		ran <- struct{}{}
+	})
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case <-ran:
+		t.Error("process started after Shutdown")
+	default:
+	}
+	if n := len(bpm.ListProcesses()); n != 0 {
+		t.Errorf("%d processes registered after Shutdown, want 0", n)
+	}
+}
+
+func TestBackgroundProcessCrashAndExit(t *testing.T) {
+	bpm := NewBackgroundProcessManager()
+	t.Cleanup(func() { bpm.Shutdown(context.Background()) })
+
+	bpm.StartProcess("crasher", "panics", func(ctx context.Context) {
+		panic("boom")
+	})
+	bpm.StartProcess("oneshot", "returns", func(ctx context.Context) {
+		RecordProcessRun(ctx, errors.New("nothing to do"))
+	})
+
+	if status := waitForStatus(t, bpm, "crasher", ProcessStatusCrashed); status.LastError != "panic: boom" {
+		t.Errorf("crash error = %q, want the panic", status.LastError)
+	}
+	if status := waitForStatus(t, bpm, "oneshot", ProcessStatusExited); status.LastError != "nothing to do" || status.LastErrorAt == nil {
+		t.Errorf("exit status = %+v, want the recorded error", status)
+	}
+
+	// A crashed process can be brought back
+	if err := bpm.RestartProcess("crasher"); err != nil {
+		t.Fatalf("RestartProcess: %v", err)
+	}
+	waitForStatus(t, bpm, "crasher", ProcessStatusCrashed)
+}
+
+func TestRecordProcessRunOutsideManager(t *testing.T) {
+	// Must not panic for contexts that don't belong to a managed process
+	RecordProcessRun(context.Background(), errors.New("ignored"))
+}
+
+func TestShutdownWaitsForLongProcess(t *testing.T) {
+	bpm := NewBackgroundProcessManager()
+
+	started := make(chan struct{})
+	finished := make(chan struct{})
+	bpm.StartProcess("flusher", "flushes on cancel", func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		// Cleanup after cancellation still runs before Shutdown returns
+		time.Sleep(50 * time.Millisecond)
+		close(finished)
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := bpm.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Error("Shutdown returned before the process finished")
+	}
+}
+
+func TestShutdownDeadlineReportsPending(t *testing.T) {
+	bpm := NewBackgroundProcessManager()
+
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	bpm.StartProcess("stuck", "ignores cancellation", func(ctx context.Context) {
+		close(started)
+		<-release
+	})
+	bpm.StartProcess("polite", "stops on cancel", func(ctx context.Context) {
+		<-ctx.Done()
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := bpm.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown = %v, want the deadline error", err)
+	}
+	if pending := bpm.pendingProcesses(); len(pending) != 1 || pending[0] != "stuck" {
+		t.Errorf("pending = %v, want only stuck", pending)
+	}
+}
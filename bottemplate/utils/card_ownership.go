@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"sort"
+	"sync"
+)
+
+// Default share of cards, by owner count, that -rare and -common match
+const (
+	DefaultRarePercentile   = 20.0
+	DefaultCommonPercentile = 80.0
+)
+
+// OwnershipRarity classifies cards as rare or common by how many players own them.
+// Cards owned by no more players than the RarePercentile cut-off are rare; cards owned
+// by at least as many as the CommonPercentile cut-off are common.
+type OwnershipRarity struct {
+	RarePercentile   float64 `toml:"rare_percentile"`   // 0 uses DefaultRarePercentile
+	CommonPercentile float64 `toml:"common_percentile"` // 0 uses DefaultCommonPercentile
+}
+
+// WithDefaults fills unset percentiles from the defaults
+func (r OwnershipRarity) WithDefaults() OwnershipRarity {
+	if r.RarePercentile == 0 {
+		r.RarePercentile = DefaultRarePercentile
+	}
+	if r.CommonPercentile == 0 {
+		r.CommonPercentile = DefaultCommonPercentile
+	}
+	return r
+}
+
+type ownershipSnapshot struct {
+	owners       map[int64]int64
+	rareCutoff   int64
+	commonCutoff int64
+}
+
+var (
+	ownershipMu    sync.RWMutex
+	ownershipCache *ownershipSnapshot
+)
+
+// SetCardOwnership replaces the per-card owner counts used by the -rare and -common
+// filters. counts should hold every card, including unowned ones with a count of 0.
+func SetCardOwnership(counts map[int64]int64, rarity OwnershipRarity) {
+	rarity = rarity.WithDefaults()
+
+	sorted := make([]int64, 0, len(counts))
+	for _, owners := range counts {
+		sorted = append(sorted, owners)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	snapshot := &ownershipSnapshot{
+		owners:       counts,
+		rareCutoff:   ownershipPercentile(sorted, rarity.RarePercentile),
+		commonCutoff: ownershipPercentile(sorted, rarity.CommonPercentile),
+	}
+
+	ownershipMu.Lock()
+	ownershipCache = snapshot
+	ownershipMu.Unlock()
+}
+
+// ownershipPercentile returns the owner count at percentile p of the sorted counts
+func ownershipPercentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p / 100 * float64(len(sorted)-1))
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// CardOwnerCount returns how many players own the card according to the last refresh.
+// ok is false before the first refresh.
+func CardOwnerCount(cardID int64) (owners int64, ok bool) {
+	ownershipMu.RLock()
+	defer ownershipMu.RUnlock()
+	if ownershipCache == nil {
+		return 0, false
+	}
+	return ownershipCache.owners[cardID], true
+}
+
+// matchesOwnershipFilters applies -rare/-common. Before the owner counts are loaded the
+// filters match every card rather than none.
+func matchesOwnershipFilters(cardID int64, filters SearchFilters) bool {
+	if !filters.RareOnly && !filters.CommonOnly {
+		return true
+	}
+
+	ownershipMu.RLock()
+	snapshot := ownershipCache
+	ownershipMu.RUnlock()
+	if snapshot == nil {
+		return true
+	}
+
+	owners := snapshot.owners[cardID]
+	if filters.RareOnly && owners > snapshot.rareCutoff {
+		return false
+	}
+	if filters.CommonOnly && owners < snapshot.commonCutoff {
+		return false
+	}
+	return true
+}
+
+// MatchesOwnershipFilters is matchesOwnershipFilters for callers outside utils that
+// filter cards themselves
+func MatchesOwnershipFilters(cardID int64, filters SearchFilters) bool {
+	return matchesOwnershipFilters(cardID, filters)
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestOwnershipPercentile(t *testing.T) {
+	sorted := []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 100}
+	tests := []struct {
+		p    float64
+		want int64
+	}{
+		{0, 0},
+		{20, 1},
+		{50, 4},
+		{80, 7},
+		{100, 100},
+		// Out of range percentiles clamp to the ends
+		{-10, 0},
+		{150, 100},
+	}
+	for _, tt := range tests {
+		if got := ownershipPercentile(sorted, tt.p); got != tt.want {
+			t.Errorf("ownershipPercentile(%v) = %d, want %d", tt.p, got, tt.want)
+		}
+	}
+	if got := ownershipPercentile(nil, 50); got != 0 {
+		t.Errorf("empty percentile = %d, want 0", got)
+	}
+}
+
+func TestRareAndCommonFilters(t *testing.T) {
+	t.Cleanup(func() {
+		ownershipMu.Lock()
+		ownershipCache = nil
+		ownershipMu.Unlock()
+	})
+
+	cards := make([]*models.Card, 0, 10)
+	counts := make(map[int64]int64)
+	for i := int64(1); i <= 10; i++ {
+		cards = append(cards, &models.Card{ID: i, Name: fmt.Sprintf("card %d", i), ColID: "twice", Level: 1})
+		counts[i] = (i - 1) * 10 // card 1 has no owners, card 10 has 90
+	}
+
+	rare := ParseSearchQuery("-rare")
+	common := ParseSearchQuery("-common")
+	if !rare.RareOnly || !common.CommonOnly {
+		t.Fatalf("parsed filters = %+v and %+v", rare, common)
+	}
+	if excluded := ParseSearchQuery("!rare"); excluded.RareOnly {
+		t.Error("!rare parsed as -rare")
+	}
+
+	// Before the first refresh the filters keep every card
+	if got := WeightedSearch(cards, rare); len(got) != len(cards) {
+		t.Errorf("-rare before refresh matched %d cards, want all %d", len(got), len(cards))
+	}
+
+	SetCardOwnership(counts, OwnershipRarity{})
+	ids := func(filters SearchFilters) map[int64]bool {
+		matched := make(map[int64]bool)
+		for _, card := range WeightedSearch(cards, filters) {
+			matched[card.ID] = true
+		}
+		return matched
+	}
+
+	// The 20th percentile is 10 owners and the 80th is 70
+	if got := ids(rare); len(got) != 2 || !got[1] || !got[2] {
+		t.Errorf("-rare matched %v, want cards 1 and 2", got)
+	}
+	if got := ids(common); len(got) != 3 || !got[8] || !got[10] {
+		t.Errorf("-common matched %v, want cards 8 to 10", got)
+	}
+	if owners, ok := CardOwnerCount(10); !ok || owners != 90 {
+		t.Errorf("CardOwnerCount(10) = %d, %t, want 90", owners, ok)
+	}
+
+	SetCardOwnership(counts, OwnershipRarity{RarePercentile: 50})
+	if got := ids(rare); len(got) != 5 {
+		t.Errorf("-rare at the 50th percentile matched %d cards, want 5", len(got))
+	}
+}
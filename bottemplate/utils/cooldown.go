@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// Now is the clock used for cooldown checks; tests can replace it with a fixed time
+var Now = time.Now
+
+// Cooldown describes whether an action tracked by a last_* timestamp can be used again
+type Cooldown struct {
+	ReadyAt   time.Time
+	Remaining time.Duration
+}
+
+// Active reports whether the action is still on cooldown
+func (c Cooldown) Active() bool {
+	return c.Remaining > 0
+}
+
+// RelativeTimestamp renders ReadyAt as a Discord relative timestamp
+func (c Cooldown) RelativeTimestamp() string {
+	return fmt.Sprintf("<t:%d:R>", c.ReadyAt.Unix())
+}
+
+// CheckCooldown returns the cooldown state for an action last used at last.
+// A zero last time or non-positive duration means the action is available.
+func CheckCooldown(last time.Time, duration time.Duration) Cooldown {
+	return CheckCooldownAt(last, duration, Now())
+}
+
+// CheckCooldownAt is CheckCooldown evaluated at the given time
+func CheckCooldownAt(last time.Time, duration time.Duration, now time.Time) Cooldown {
+	if last.IsZero() || duration <= 0 {
+		return Cooldown{ReadyAt: now}
+	}
+
+	readyAt := last.Add(duration)
+	remaining := readyAt.Sub(now)
+	if remaining <= 0 {
+		return Cooldown{ReadyAt: now}
+	}
+
+	// Never report a sub-second wait as zero while still refusing the action
+	rounded := remaining.Round(time.Second)
+	if rounded < time.Second {
+		rounded = time.Second
+	}
+	return Cooldown{ReadyAt: readyAt, Remaining: rounded}
+}
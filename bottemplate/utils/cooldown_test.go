@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckCooldown(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	defer func(prev func() time.Time) { Now = prev }(Now)
+	Now = func() time.Time { return now }
+
+	tests := []struct {
+		name          string
+		last          time.Time
+		duration      time.Duration
+		wantRemaining time.Duration
+	}{
+		{name: "never used", last: time.Time{}, duration: time.Hour},
+		{name: "no cooldown", last: now, duration: 0},
+		{name: "just used", last: now, duration: time.Hour, wantRemaining: time.Hour},
+		{name: "half way", last: now.Add(-30 * time.Minute), duration: time.Hour, wantRemaining: 30 * time.Minute},
+		{name: "rounded to the second", last: now.Add(-1500 * time.Millisecond), duration: 5 * time.Second, wantRemaining: 4 * time.Second},
+		{name: "never below a second", last: now.Add(-4900 * time.Millisecond), duration: 5 * time.Second, wantRemaining: time.Second},
+		{name: "exactly over", last: now.Add(-time.Hour), duration: time.Hour},
+		{name: "long over", last: now.Add(-48 * time.Hour), duration: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cooldown := CheckCooldown(tt.last, tt.duration)
+			if cooldown.Remaining != tt.wantRemaining || cooldown.Active() != (tt.wantRemaining > 0) {
+				t.Errorf("Remaining = %v (active %t), want %v", cooldown.Remaining, cooldown.Active(), tt.wantRemaining)
+			}
+			wantReady := now
+			if tt.wantRemaining > 0 {
+				wantReady = tt.last.Add(tt.duration)
+			}
+			if !cooldown.ReadyAt.Equal(wantReady) {
+				t.Errorf("ReadyAt = %v, want %v", cooldown.ReadyAt, wantReady)
+			}
+		})
+	}
+}
+
+func TestCooldownRelativeTimestamp(t *testing.T) {
+	last := time.Unix(1700000000, 0)
+	cooldown := CheckCooldownAt(last, time.Minute, last.Add(10*time.Second))
+	if got, want := cooldown.RelativeTimestamp(), "<t:1700000060:R>"; got != want {
+		t.Errorf("RelativeTimestamp = %q, want %q", got, want)
+	}
+}
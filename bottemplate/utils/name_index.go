@@ -0,0 +1,274 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+// NameIndexMaxResults is the most cards a single NameIndex search returns,
+// matching Discord's autocomplete choice limit
+const NameIndexMaxResults = 25
+
+// nameRef is a card reachable from a trie node. whole is false when the card was
+// indexed under one of the later words of its name rather than its full name.
+type nameRef struct {
+	card  *models.Card
+	whole bool
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	// terminal holds the cards whose indexed key ends at this node
+	terminal []nameRef
+	// best is the ranked top NameIndexMaxResults cards in this subtree, so a
+	// prefix lookup never has to walk below the node it lands on
+	best []nameRef
+}
+
+// NameIndex is a trie over normalized card names for autocomplete. Each card is
+// indexed under its full name and every later word of it, so "yeon" finds
+// "Na Yeon". Names are normalized like calculateEnhancedWeight: lowercased with
+// '_' and '-' treated as spaces. An index is read-only once built; rebuild it
+// when cards change.
+type NameIndex struct {
+	root *trieNode
+	size int
+}
+
+// BuildNameIndex indexes the given cards
+func BuildNameIndex(cards []*models.Card) *NameIndex {
+	idx := &NameIndex{root: &trieNode{}}
+	for _, card := range cards {
+		key := normalizeQuery(card.Name)
+		if key == "" {
+			continue
+		}
+		idx.insert(key, nameRef{card: card, whole: true})
+
+		words := strings.Fields(key)
+		for i := 1; i < len(words); i++ {
+			idx.insert(strings.Join(words[i:], " "), nameRef{card: card})
+		}
+		idx.size++
+	}
+	idx.root.rank()
+	return idx
+}
+
+// Len returns the number of indexed cards
+func (idx *NameIndex) Len() int {
+	return idx.size
+}
+
+func (idx *NameIndex) insert(key string, ref nameRef) {
+	node := idx.root
+	for _, r := range key {
+		if node.children == nil {
+			node.children = make(map[rune]*trieNode)
+		}
+		child, ok := node.children[r]
+		if !ok {
+			child = &trieNode{}
+			node.children[r] = child
+		}
+		node = child
+	}
+	node.terminal = append(node.terminal, ref)
+}
+
+// rank fills in best for the subtree bottom-up. Nodes that only continue a
+// single name share their child's slice, which keeps long names cheap.
+func (n *trieNode) rank() {
+	if len(n.terminal) == 0 && len(n.children) == 1 {
+		for _, child := range n.children {
+			child.rank()
+			n.best = child.best
+		}
+		return
+	}
+
+	candidates := append([]nameRef(nil), n.terminal...)
+	for _, child := range n.children {
+		child.rank()
+		candidates = append(candidates, child.best...)
+	}
+	n.best = topRefs(candidates, NameIndexMaxResults)
+}
+
+// Search returns up to limit cards (at most NameIndexMaxResults) whose name, or
+// a word in it, starts with prefix. Exact names rank first, then full-name
+// prefixes, then word prefixes, with ties going to the higher level card. When
+// the prefix matches too few cards, names within a small edit distance of it
+// fill the remaining slots so typos still get suggestions.
+func (idx *NameIndex) Search(prefix string, limit int) []*models.Card {
+	q := normalizeQuery(prefix)
+	if q == "" || limit <= 0 {
+		return nil
+	}
+	if limit > NameIndexMaxResults {
+		limit = NameIndexMaxResults
+	}
+
+	seen := make(map[int64]bool)
+	var results []*models.Card
+	add := func(ref nameRef) {
+		if seen[ref.card.ID] || len(results) >= limit {
+			return
+		}
+		seen[ref.card.ID] = true
+		results = append(results, ref.card)
+	}
+
+	// Exact names first, then the subtree's ranked full-name and word prefixes
+	if node := idx.find(q); node != nil {
+		for _, ref := range sortRefs(append([]nameRef(nil), node.terminal...)) {
+			if ref.whole {
+				add(ref)
+			}
+		}
+		for _, ref := range node.best {
+			add(ref)
+		}
+	}
+
+	if len(results) < limit {
+		for _, ref := range idx.fuzzy(q) {
+			add(ref)
+		}
+	}
+
+	return results
+}
+
+// find walks the trie to the node for key, or nil when no name starts with it
+func (idx *NameIndex) find(key string) *trieNode {
+	node := idx.root
+	for _, r := range key {
+		node = node.children[r]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// maxEditsFor allows one typo once a query is long enough to be specific;
+// shorter queries get no fuzzy matching since almost everything is one edit
+// away. A second edit multiplies the trie walk and rarely helps for names.
+func maxEditsFor(query []rune) int {
+	if len(query) < 4 {
+		return 0
+	}
+	return 1
+}
+
+// fuzzy returns the best cards under every trie path within the edit budget of
+// q, closest paths first. It walks the trie carrying a Levenshtein row, pruning
+// any branch whose row minimum already exceeds the budget.
+func (idx *NameIndex) fuzzy(q string) []nameRef {
+	query := []rune(q)
+	maxEdits := maxEditsFor(query)
+	if maxEdits == 0 {
+		return nil
+	}
+
+	type hit struct {
+		node *trieNode
+		dist int
+	}
+	var hits []hit
+
+	// One row buffer per depth; the walk never needs a row after returning
+	// from the child that overwrote it
+	var rows [][]int
+	rowAt := func(depth int) []int {
+		for len(rows) <= depth {
+			rows = append(rows, make([]int, len(query)+1))
+		}
+		return rows[depth]
+	}
+	firstRow := rowAt(0)
+	for i := range firstRow {
+		firstRow[i] = i
+	}
+
+	var walk func(node *trieNode, r rune, depth int)
+	walk = func(node *trieNode, r rune, depth int) {
+		prev, row := rows[depth-1], rowAt(depth)
+		row[0] = prev[0] + 1
+		rowMin := row[0]
+		for i := 1; i <= len(query); i++ {
+			cost := 1
+			if query[i-1] == r {
+				cost = 0
+			}
+			row[i] = min(min(row[i-1]+1, prev[i]+1), prev[i-1]+cost)
+			rowMin = min(rowMin, row[i])
+		}
+
+		// The path so far matches the whole query within budget: everything
+		// below is a fuzzy prefix match, so take the subtree's best and stop
+		if row[len(query)] <= maxEdits {
+			hits = append(hits, hit{node: node, dist: row[len(query)]})
+			return
+		}
+		if rowMin > maxEdits {
+			return
+		}
+		for cr, child := range node.children {
+			walk(child, cr, depth+1)
+		}
+	}
+	for r, child := range idx.root.children {
+		walk(child, r, 1)
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].dist < hits[j].dist
+	})
+
+	var refs []nameRef
+	for _, h := range hits {
+		refs = append(refs, h.node.best...)
+	}
+	return refs
+}
+
+// topRefs keeps the best n refs, one per card
+func topRefs(refs []nameRef, n int) []nameRef {
+	sortRefs(refs)
+	seen := make(map[int64]bool, len(refs))
+	out := make([]nameRef, 0, min(len(refs), n))
+	for _, ref := range refs {
+		if seen[ref.card.ID] {
+			continue
+		}
+		seen[ref.card.ID] = true
+		out = append(out, ref)
+		if len(out) == n {
+			break
+		}
+	}
+	return out
+}
+
+// sortRefs orders full-name matches before word matches, then by level
+// descending, name and collection
+func sortRefs(refs []nameRef) []nameRef {
+	sort.Slice(refs, func(i, j int) bool {
+		a, b := refs[i], refs[j]
+		if a.whole != b.whole {
+			return a.whole
+		}
+		if a.card.Level != b.card.Level {
+			return a.card.Level > b.card.Level
+		}
+		if a.card.Name != b.card.Name {
+			return a.card.Name < b.card.Name
+		}
+		return a.card.ColID < b.card.ColID
+	})
+	return refs
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestNameIndexSearch(t *testing.T) {
+	idx := BuildNameIndex([]*models.Card{
+		{ID: 1, Name: "Na Yeon", ColID: "twice", Level: 2},
+		{ID: 2, Name: "Jeong-Yeon", ColID: "twice", Level: 4},
+		{ID: 3, Name: "Jang_Won_Young", ColID: "ive", Level: 1},
+		{ID: 4, Name: "Nayeon", ColID: "twice", Level: 1},
+	})
+	if idx.Len() != 4 {
+		t.Fatalf("Len = %d, want 4", idx.Len())
+	}
+
+	tests := []struct {
+		query string
+		want  []int64
+	}{
+		// Later words are indexed too; '-' and '_' act as spaces
+		{query: "yeon", want: []int64{2, 1}},
+		{query: "won young", want: []int64{3}},
+		{query: "jeong yeon", want: []int64{2}},
+		{query: "na", want: []int64{1, 4}},
+		// The exact name first, then "na yeon" one edit away
+		{query: "nayeon", want: []int64{4, 1}},
+		// One typo once the query is four runes long
+		{query: "nayeom", want: []int64{4}},
+		{query: "yeo", want: []int64{2, 1}},
+		{query: "yoe", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var got []int64
+			for _, card := range idx.Search(tt.query, NameIndexMaxResults) {
+				got = append(got, card.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameIndexSearchLimit(t *testing.T) {
+	cards := make([]*models.Card, 0, 40)
+	for i := 1; i <= 40; i++ {
+		cards = append(cards, &models.Card{ID: int64(i), Name: fmt.Sprintf("card %d", i), Level: i%5 + 1})
+	}
+	idx := BuildNameIndex(cards)
+
+	if got := idx.Search("card", 100); len(got) != NameIndexMaxResults {
+		t.Errorf("got %d results, want the %d cap", len(got), NameIndexMaxResults)
+	}
+	if got := idx.Search("card", 0); got != nil {
+		t.Errorf("limit 0 returned %d cards", len(got))
+	}
+	// Higher levels rank first among equal prefixes
+	if got := idx.Search("card", 1); len(got) != 1 || got[0].Level != 5 {
+		t.Errorf("top result = %+v, want a level 5 card", got)
+	}
+}
@@ -223,6 +223,16 @@ func (ph *PaginationHandler) CreateInitialPaginationEmbed(
 	return embed, components, nil
 }
 
+// FormatPageFooter renders a footer like "Showing 1–10 of 312 • Page 1/32"
+func FormatPageFooter(page, totalPages, perPage, totalItems int) string {
+	if totalItems == 0 {
+		return fmt.Sprintf("Showing 0 of 0 • Page %d/%d", page+1, max(totalPages, 1))
+	}
+	start := page*perPage + 1
+	end := min(start+perPage-1, totalItems)
+	return fmt.Sprintf("Showing %d–%d of %d • Page %d/%d", start, end, totalItems, page+1, totalPages)
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -26,6 +26,9 @@ type PaginationParams struct {
 	TargetUserID   string
 	SortByProgress bool
 	CompletedOnly  bool
+	// TotalItems is the size of the whole filtered result set. It is not part of the
+	// component ID; the factory fills it in before formatting each page.
+	TotalItems int
 }
 
 // DataFetcher defines the interface for fetching paginated data
@@ -52,6 +55,9 @@ type PaginationFactoryConfig struct {
 	Fetcher      DataFetcher
 	Formatter    ItemFormatter
 	Validator    UserValidator
+	// ExtraButtons, when set, adds a row of command-specific buttons below the
+	// navigation row on every page, even when there is only one page
+	ExtraButtons func(params PaginationParams) []discord.InteractiveComponent
 }
 
 // PaginationFactory creates unified pagination handlers
@@ -172,6 +178,7 @@ func (pf *PaginationFactory) handleNavigation(ctx context.Context, e *handler.Co
 
 	// Update params with new page
 	newParams := params
+	newParams.TotalItems = len(items)
 	if newPage < 0 || newPage >= totalPages {
 		newParams.Page = 0
 	} else {
@@ -233,6 +240,12 @@ func (pf *PaginationFactory) createPaginationComponents(totalPages int, params P
 		components = append(components, discord.NewActionRow(buttons...))
 	}
 
+	if pf.config.ExtraButtons != nil {
+		if extra := pf.config.ExtraButtons(params); len(extra) > 0 {
+			components = append(components, discord.NewActionRow(extra...))
+		}
+	}
+
 	return components
 }
 
@@ -244,11 +257,18 @@ func (pf *PaginationFactory) CreateInitialPaginationEmbed(ctx context.Context, p
 		return discord.Embed{}, nil, err
 	}
 
+	return pf.CreateInitialPaginationEmbedFromItems(items, params)
+}
+
+// CreateInitialPaginationEmbedFromItems creates the first page from items the caller already
+// fetched, so command handlers can report their own errors before paginating
+func (pf *PaginationFactory) CreateInitialPaginationEmbedFromItems(items []interface{}, params PaginationParams) (discord.Embed, []discord.ContainerComponent, error) {
 	if len(items) == 0 {
 		return discord.Embed{}, nil, fmt.Errorf("no items found")
 	}
 
 	totalPages := int(math.Ceil(float64(len(items)) / float64(pf.config.ItemsPerPage)))
+	params.TotalItems = len(items)
 
 	// Get items for first page only
 	pageItems := items
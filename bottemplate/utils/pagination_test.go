@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestFormatPageFooter(t *testing.T) {
+	tests := []struct {
+		page, totalPages, perPage, totalItems int
+		want                                  string
+	}{
+		{0, 32, 10, 312, "Showing 1–10 of 312 • Page 1/32"},
+		{31, 32, 10, 312, "Showing 311–312 of 312 • Page 32/32"},
+		{1, 2, 10, 20, "Showing 11–20 of 20 • Page 2/2"},
+		{0, 1, 10, 3, "Showing 1–3 of 3 • Page 1/1"},
+		{0, 0, 10, 0, "Showing 0 of 0 • Page 1/1"},
+	}
+	for _, tt := range tests {
+		if got := FormatPageFooter(tt.page, tt.totalPages, tt.perPage, tt.totalItems); got != tt.want {
+			t.Errorf("FormatPageFooter(%d, %d, %d, %d) = %q, want %q", tt.page, tt.totalPages, tt.perPage, tt.totalItems, got, tt.want)
+		}
+	}
+}
+
+// recordingFormatter keeps the arguments of the last FormatItems call
+type recordingFormatter struct {
+	items      int
+	totalPages int
+	params     PaginationParams
+}
+
+func (f *recordingFormatter) FormatItems(items []interface{}, page, totalPages int, params PaginationParams) (discord.Embed, error) {
+	f.items, f.totalPages, f.params = len(items), totalPages, params
+	return discord.Embed{}, nil
+}
+
+func (f *recordingFormatter) FormatCopy(items []interface{}, params PaginationParams) string {
+	return ""
+}
+
+func TestInitialPageCountsFilteredItems(t *testing.T) {
+	formatter := &recordingFormatter{}
+	factory := NewPaginationFactory(PaginationFactoryConfig{
+		ItemsPerPage: 10,
+		Prefix:       "cards",
+		Parser:       NewRegularParser("cards"),
+		Formatter:    formatter,
+	})
+
+	items := make([]interface{}, 23)
+	if _, _, err := factory.CreateInitialPaginationEmbedFromItems(items, PaginationParams{UserID: "u1", Query: "-twice"}); err != nil {
+		t.Fatalf("CreateInitialPaginationEmbedFromItems: %v", err)
+	}
+	if formatter.items != 10 || formatter.totalPages != 3 || formatter.params.TotalItems != 23 {
+		t.Errorf("formatted %d items on page 1 of %d with total %d, want 10 of 3 with total 23",
+			formatter.items, formatter.totalPages, formatter.params.TotalItems)
+	}
+
+	if _, _, err := factory.CreateInitialPaginationEmbedFromItems(nil, PaginationParams{UserID: "u1"}); err == nil {
+		t.Error("no error for an empty result")
+	}
+}
@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"cmp"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/disgoorg/bot-template/bottemplate/database/models"
 )
@@ -33,11 +36,46 @@ const (
 	WeightPartialMatch    = 10
 )
 
-// AmountFilter represents amount-based filtering criteria
+// AmountFilter represents amount-based filtering criteria. The Has* flags mark which
+// bounds were given so that 0 is a valid bound (=amount=0 matches unowned cards).
 type AmountFilter struct {
-	Min   int64 // >amount
-	Max   int64 // <amount
-	Exact int64 // =amount
+	Min      int64 // >amount
+	Max      int64 // <amount
+	Exact    int64 // =amount
+	HasMin   bool
+	HasMax   bool
+	HasExact bool
+}
+
+// IsSet reports whether any amount bound was given
+func (f AmountFilter) IsSet() bool {
+	return f.HasMin || f.HasMax || f.HasExact
+}
+
+// Matches reports whether amount satisfies every bound that was given
+func (f AmountFilter) Matches(amount int64) bool {
+	if f.HasMin && amount < f.Min {
+		return false
+	}
+	if f.HasMax && amount > f.Max {
+		return false
+	}
+	if f.HasExact && amount != f.Exact {
+		return false
+	}
+	return true
+}
+
+// setBound records a >, < or = amount bound
+func (f *AmountFilter) setBound(operator byte, amount int64) {
+	switch operator {
+	case '>':
+		f.Min, f.HasMin = amount+1, true
+	case '<':
+		f.Max, f.HasMax = amount-1, true
+	case '=':
+		f.Exact, f.HasExact = amount, true
+	}
 }
 
 // StarFilter represents star rating-based filtering criteria (card property)
@@ -79,6 +117,8 @@ type SearchFilters struct {
 	AmountFilter    AmountFilter // >amount, <amount, =amount
 	StarFilter      StarFilter   // >star, <star, =star (card star rating 1-5)
 	ExpFilter       ExpFilter    // >exp, <exp, =exp (user experience points)
+	ObtainedAfter   time.Time    // obtained>2024-01-01, obtained>7d - obtained after this time
+	ObtainedBefore  time.Time    // obtained<2024-02-01, obtained<7d - obtained before this time
 
 	// Improved terminology for clarity (preferred over Levels/AntiLevels)
 	Stars     []int // Exact star rating matches (1, 2, 3, 4, 5)
@@ -103,6 +143,8 @@ type SearchFilters struct {
 	WishOnly     bool // -wish - only wishlist cards
 	ExcludeWish  bool // !wish - exclude wishlist cards
 	Diff         int  // -diff, !diff - difference mode (0=none, 1=diff, 2=miss)
+	RareOnly     bool // -rare - cards owned by few players
+	CommonOnly   bool // -common - cards owned by many players
 
 	// Query type flags (from legacy system)
 	UserQuery bool // indicates this query requires user-specific data
@@ -112,8 +154,11 @@ type SearchFilters struct {
 	SortChain []SortCriteria // Chain of sort criteria
 
 	// Additional parameters for advanced queries
-	TargetUserID string // for diff queries
-	LastDaily    string // timestamp for "new" filter comparisons
+	TargetUserID string          // for diff queries
+	LastDaily    string          // timestamp for "new" filter comparisons
+	Wishlist     map[int64]bool  // wishlisted card IDs for -wish/!wish, loaded by SearchService
+	WithPrices   bool            // attach current market prices to the results
+	Prices       map[int64]int64 // current price per card for price sorting and display, loaded by SearchService
 
 	// Inventory search flag - when true, shows all cards user owns including excluded collections
 	IsInventorySearch bool
@@ -129,6 +174,7 @@ const (
 	SortByRating = "rating"
 	SortByExp    = "exp"
 	SortByEval   = "eval"
+	SortByPrice  = "price"
 )
 
 // SortCriteria represents a single sorting criterion (for multi-level sorting)
@@ -155,7 +201,7 @@ var (
 	// List of collection IDs that should be excluded from forge operations
 	// Based on legacy system: excluded cards plus fragments, album, liveauction,
 	// jackpot, birthdays, limited, and lottery.
-	forgeExcludedCollections = []string{"fragments", "album", "albums", "ggalbums", "bgalbums", "liveauction", "jackpot", "birthdays", "limited", "lottery", "signed", "removed"}
+	forgeExcludedCollections = DefaultForgeExcludedCollections()
 
 	claimExcludedCollections = []string{"promos", "ggalbums", "bgalbums", "birthdays", "liveauction", "mythical", "mystical", "limited", "special", "lottery", "jackpot", "signed", "removed", "fragments"}
 
@@ -166,6 +212,18 @@ var (
 	auctionExcludedCollections = []string{"signed", "lottery", "jackpot"}
 )
 
+// DefaultForgeExcludedCollections lists the collections kept out of the forge
+// unless the forge config says otherwise
+func DefaultForgeExcludedCollections() []string {
+	return []string{"fragments", "album", "albums", "ggalbums", "bgalbums", "liveauction", "jackpot", "birthdays", "limited", "lottery", "signed", "removed"}
+}
+
+// SetForgeExcludedCollections replaces the forge exclusion list. Collections
+// cached before the call keep their old IsForgeExcluded flag.
+func SetForgeExcludedCollections(colIDs []string) {
+	forgeExcludedCollections = append([]string(nil), colIDs...)
+}
+
 func isForgeExcludedCollectionID(colID string) bool {
 	return collectionIDInList(colID, forgeExcludedCollections)
 }
@@ -521,6 +579,11 @@ func ParseSearchQuery(query string) SearchFilters {
 			}
 		}
 
+		// Handle obtained-date ranges (obtained>2024-01-01, obtained<7d)
+		if strings.HasPrefix(term, "obtained") && parseObtainedFilter(term, &filters, time.Now()) {
+			continue
+		}
+
 		// Handle plain identifiers before defaulting to name search
 		if parseIdentifier(term, &filters) {
 			continue
@@ -537,6 +600,52 @@ func ParseSearchQuery(query string) SearchFilters {
 	return filters
 }
 
+// parseObtainedFilter handles obtained>X and obtained<X where X is a date (2006-01-02) or an
+// age relative to now such as 12h, 7d or 2w. obtained>7d means obtained within the last 7 days.
+func parseObtainedFilter(term string, filters *SearchFilters, now time.Time) bool {
+	rest := strings.TrimPrefix(term, "obtained")
+	if len(rest) < 2 || (rest[0] != '>' && rest[0] != '<') {
+		return false
+	}
+
+	at, ok := parseObtainedTime(rest[1:], now)
+	if !ok {
+		return false
+	}
+
+	filters.UserQuery = true // obtained date lives on the user's card
+	if rest[0] == '>' {
+		filters.ObtainedAfter = at
+	} else {
+		filters.ObtainedBefore = at
+	}
+	return true
+}
+
+// parseObtainedTime resolves an absolute date or a relative age (h, d, w) to a point in time
+func parseObtainedTime(value string, now time.Time) (time.Time, bool) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true
+	}
+
+	if len(value) < 2 {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n <= 0 {
+		return time.Time{}, false
+	}
+	switch value[len(value)-1] {
+	case 'h':
+		return now.Add(-time.Duration(n) * time.Hour), true
+	case 'd':
+		return now.AddDate(0, 0, -n), true
+	case 'w':
+		return now.AddDate(0, 0, -7*n), true
+	}
+	return time.Time{}, false
+}
+
 // parseComparisonOperator handles <, >, = operators for sorting and amount filtering
 func parseComparisonOperator(term string, filters *SearchFilters) bool {
 	operator := term[0]
@@ -601,6 +710,11 @@ func parseComparisonOperator(term string, filters *SearchFilters) bool {
 		filters.UserQuery = true // eval sorting requires user data
 		filters.EvalQuery = true // evaluation requires special processing
 		return true
+	case "price":
+		filters.SortBy = SortByPrice
+		filters.SortDesc = operator == '>'
+		filters.WithPrices = true // price sorting needs the market prices loaded
+		return true
 	}
 
 	// Handle amount filtering (>amount=2, <amount=5, =amount=1)
@@ -608,14 +722,7 @@ func parseComparisonOperator(term string, filters *SearchFilters) bool {
 		amountStr := strings.TrimPrefix(substr, "amount=")
 		if amount, err := strconv.ParseInt(amountStr, 10, 64); err == nil {
 			filters.UserQuery = true // amount filtering requires user data
-			switch operator {
-			case '>':
-				filters.AmountFilter.Min = amount + 1
-			case '<':
-				filters.AmountFilter.Max = amount - 1
-			case '=':
-				filters.AmountFilter.Exact = amount
-			}
+			filters.AmountFilter.setBound(operator, amount)
 			return true
 		}
 	}
@@ -670,14 +777,7 @@ func parseComparisonOperator(term string, filters *SearchFilters) bool {
 	// Handle direct amount comparison (>3, <5, =4) - legacy behavior for amount filtering
 	if amount, err := strconv.ParseInt(substr, 10, 64); err == nil {
 		filters.UserQuery = true // amount filtering requires user data
-		switch operator {
-		case '>':
-			filters.AmountFilter.Min = amount + 1
-		case '<':
-			filters.AmountFilter.Max = amount - 1
-		case '=':
-			filters.AmountFilter.Exact = amount
-		}
+		filters.AmountFilter.setBound(operator, amount)
 		return true
 	}
 
@@ -758,6 +858,18 @@ func parseNegativeFilter(term string, filters *SearchFilters) bool {
 		}
 		filters.UserQuery = true // wishlist filtering requires user data
 		return true
+	case "rare":
+		if isExclude {
+			return false
+		}
+		filters.RareOnly = true
+		return true
+	case "common":
+		if isExclude {
+			return false
+		}
+		filters.CommonOnly = true
+		return true
 	case "diff":
 		if isExclude {
 			filters.Diff = 2 // miss mode
@@ -943,7 +1055,7 @@ func WeightedSearch(cards []*models.Card, filters SearchFilters) []*models.Card
 	}
 
 	// Sort results
-	sortResults(results, filters.SortBy, filters.SortDesc)
+	sortResults(results, BuildSortFromFilters(filters))
 
 	// Convert to card slice
 	sortedCards := make([]*models.Card, len(results))
@@ -1016,42 +1128,16 @@ func normalizeQuery(q string) string {
 	return strings.Join(fields, " ")
 }
 
-func sortResults(results []SearchResult, sortBy string, desc bool) {
-	sort.Slice(results, func(i, j int) bool {
-		var less bool
-
-		// Primary sort by search weight (descending) - most relevant matches first
+// sortResults orders results by relevance, then by the filters' sort chain, then by name and
+// card ID so equal results always come back in the same order
+func sortResults(results []SearchResult, sb *SortBuilder) {
+	criteria := sb.Build()
+	sort.SliceStable(results, func(i, j int) bool {
+		// Most relevant matches first
 		if results[i].Weight != results[j].Weight {
-			less = results[i].Weight < results[j].Weight
-			return !less // Descending order for weights (higher weight = more relevant)
-		}
-
-		// Secondary sort by level (descending) for ties in relevance
-		if results[i].Card.Level != results[j].Card.Level {
-			less = results[i].Card.Level < results[j].Card.Level
-			return !less // Descending order for levels
-		}
-
-		// Tertiary sort by name (ascending) for final ties
-		less = strings.ToLower(results[i].Card.Name) < strings.ToLower(results[j].Card.Name)
-
-		// If explicit sort criteria is provided, use that instead
-		if sortBy != SortByLevel {
-			switch sortBy {
-			case SortByName:
-				less = strings.ToLower(results[i].Card.Name) < strings.ToLower(results[j].Card.Name)
-			case SortByCol:
-				less = strings.ToLower(results[i].Card.ColID) < strings.ToLower(results[j].Card.ColID)
-			default:
-				less = results[i].Weight < results[j].Weight
-			}
-
-			if desc {
-				return !less
-			}
+			return results[i].Weight > results[j].Weight
 		}
-
-		return less
+		return sb.compareCardChain(results[i].Card, results[j].Card, criteria) < 0
 	})
 }
 
@@ -1226,6 +1312,11 @@ func applyCardFilters(card *models.Card, filters SearchFilters) bool {
 		}
 	}
 
+	// Check global ownership filters (-rare, -common)
+	if !matchesOwnershipFilters(card.ID, filters) {
+		return false
+	}
+
 	// No exclusions - all cards are searchable
 
 	return true
@@ -1238,13 +1329,7 @@ func applyUserCardFilters(userCard *models.UserCard, filters SearchFilters) bool
 	}
 
 	// Check amount filters
-	if filters.AmountFilter.Min > 0 && userCard.Amount < filters.AmountFilter.Min {
-		return false
-	}
-	if filters.AmountFilter.Max > 0 && userCard.Amount > filters.AmountFilter.Max {
-		return false
-	}
-	if filters.AmountFilter.Exact > 0 && userCard.Amount != filters.AmountFilter.Exact {
+	if !filters.AmountFilter.Matches(userCard.Amount) {
 		return false
 	}
 
@@ -1272,6 +1357,21 @@ func applyUserCardFilters(userCard *models.UserCard, filters SearchFilters) bool
 		return false
 	}
 
+	// Check new/old against the user's last daily
+	if !MatchesNewFilter(userCard, filters) {
+		return false
+	}
+
+	// Check wishlist membership
+	if !MatchesWishFilter(userCard.CardID, filters) {
+		return false
+	}
+
+	// Check obtained-date range
+	if !MatchesObtainedRange(userCard, filters) {
+		return false
+	}
+
 	// Check experience range filters (>exp, <exp, =exp)
 	if filters.ExpFilter.Min > 0 && userCard.Exp < filters.ExpFilter.Min {
 		return false
@@ -1286,6 +1386,67 @@ func applyUserCardFilters(userCard *models.UserCard, filters SearchFilters) bool
 	return true
 }
 
+// SetLastDaily records the user's last daily claim for -new/!new comparisons
+func (filters *SearchFilters) SetLastDaily(lastDaily time.Time) {
+	filters.LastDaily = lastDaily.UTC().Format(time.RFC3339Nano)
+}
+
+// LastDailyTime parses LastDaily; ok is false when it has not been populated
+func (filters *SearchFilters) LastDailyTime() (time.Time, bool) {
+	if filters.LastDaily == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, filters.LastDaily)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// MatchesNewFilter applies -new (obtained since the last daily) and !new (obtained before it).
+// A user who never claimed a daily has only new cards. Without a populated LastDaily the
+// filter cannot be evaluated and every card matches.
+func MatchesNewFilter(userCard *models.UserCard, filters SearchFilters) bool {
+	if !filters.NewOnly && !filters.ExcludeNew {
+		return true
+	}
+	lastDaily, ok := filters.LastDailyTime()
+	if !ok {
+		return true
+	}
+
+	isNew := lastDaily.IsZero() || userCard.Obtained.After(lastDaily)
+	if filters.NewOnly {
+		return isNew
+	}
+	return !isNew
+}
+
+// MatchesWishFilter applies -wish (wishlisted only) and !wish (not wishlisted). Without a
+// loaded Wishlist the filter cannot be evaluated and every card matches.
+func MatchesWishFilter(cardID int64, filters SearchFilters) bool {
+	if (!filters.WishOnly && !filters.ExcludeWish) || filters.Wishlist == nil {
+		return true
+	}
+	return filters.Wishlist[cardID] == filters.WishOnly
+}
+
+// HasObtainedRange reports whether the filters restrict the obtained date
+func (filters *SearchFilters) HasObtainedRange() bool {
+	return !filters.ObtainedAfter.IsZero() || !filters.ObtainedBefore.IsZero()
+}
+
+// MatchesObtainedRange checks a user card against the obtained>/obtained< bounds
+func MatchesObtainedRange(userCard *models.UserCard, filters SearchFilters) bool {
+	if !filters.ObtainedAfter.IsZero() && !userCard.Obtained.After(filters.ObtainedAfter) {
+		return false
+	}
+	if !filters.ObtainedBefore.IsZero() && !userCard.Obtained.Before(filters.ObtainedBefore) {
+		return false
+	}
+	return true
+}
+
 // WeightedSearchWithMulti performs a search that can filter for cards with multiple copies and user-specific criteria
 func WeightedSearchWithMulti(cards []*models.Card, filters SearchFilters, userCards map[int64]*models.UserCard) []*models.Card {
 	if len(cards) == 0 {
@@ -1304,7 +1465,9 @@ func WeightedSearchWithMulti(cards []*models.Card, filters SearchFilters, userCa
 			if !filters.MultiOnly && !filters.SingleOnly &&
 				!filters.Favorites && !filters.ExcludeFavorites &&
 				!filters.LockedOnly && !filters.ExcludeLocked &&
-				filters.AmountFilter.Min == 0 && filters.AmountFilter.Max == 0 && filters.AmountFilter.Exact == 0 {
+				filters.AmountFilter.Matches(0) && // an unowned card has amount 0
+				!filters.HasObtainedRange() &&
+				MatchesWishFilter(card.ID, filters) {
 				filteredResults = append(filteredResults, card)
 			}
 			continue
@@ -1378,17 +1541,7 @@ func ApplyLegacyUserCardFiltering(userCard *models.UserCard, card *models.Card,
 		return false
 	}
 
-	// Apply new/rated/wish filters
-	if filters.NewOnly {
-		// This would need lastDaily comparison - placeholder for now
-		// if userCard.Obtained <= lastDaily { return false }
-	}
-
-	if filters.ExcludeNew {
-		// This would need lastDaily comparison - placeholder for now
-		// if userCard.Obtained > lastDaily { return false }
-	}
-
+	// Apply rated filters (new/old and wishlist are checked by applyUserCardFilters)
 	if filters.RatedOnly && userCard.Rating == 0 {
 		return false
 	}
@@ -1397,16 +1550,13 @@ func ApplyLegacyUserCardFiltering(userCard *models.UserCard, card *models.Card,
 		return false
 	}
 
-	// Wishlist filtering would need wishlist data
-	// if filters.WishOnly && !isInWishlist(card.ID) { return false }
-	// if filters.ExcludeWish && isInWishlist(card.ID) { return false }
-
 	return true
 }
 
 // SortBuilder implements the legacy firstBy().thenBy() pattern for multi-level sorting
 type SortBuilder struct {
 	criteria []SortCriteria
+	prices   map[int64]int64 // card prices used by SortByEval; missing entries count as 0
 }
 
 // NewSortBuilder creates a new sort builder
@@ -1428,6 +1578,12 @@ func (sb *SortBuilder) ThenBy(field string, desc bool) *SortBuilder {
 	return sb
 }
 
+// WithPrices supplies card prices for evaluation sorting
+func (sb *SortBuilder) WithPrices(prices map[int64]int64) *SortBuilder {
+	sb.prices = prices
+	return sb
+}
+
 // Build returns the final sort criteria chain
 func (sb *SortBuilder) Build() []SortCriteria {
 	if len(sb.criteria) == 0 {
@@ -1469,18 +1625,25 @@ func (sb *SortBuilder) SortCards(cards []*models.Card) {
 		return
 	}
 
-	sort.Slice(cards, func(i, j int) bool {
-		// Apply each sort criterion in order
-		for _, criterion := range sb.criteria {
-			comparison := sb.compareCards(cards[i], cards[j], criterion)
-			if comparison != 0 {
-				return comparison < 0
-			}
-		}
-		return false
+	sort.SliceStable(cards, func(i, j int) bool {
+		return sb.compareCardChain(cards[i], cards[j], sb.criteria) < 0
 	})
 }
 
+// compareCardChain applies each criterion in order, falling back to name ascending and
+// finally card ID so that no two distinct cards compare equal
+func (sb *SortBuilder) compareCardChain(cardA, cardB *models.Card, criteria []SortCriteria) int {
+	for _, criterion := range criteria {
+		if c := sb.compareCards(cardA, cardB, criterion); c != 0 {
+			return c
+		}
+	}
+	if c := strings.Compare(strings.ToLower(cardA.Name), strings.ToLower(cardB.Name)); c != 0 {
+		return c
+	}
+	return cmp.Compare(cardA.ID, cardB.ID)
+}
+
 // compareUserCards compares two user cards based on a single criterion
 func (sb *SortBuilder) compareUserCards(ucA, ucB *models.UserCard, cardA, cardB *models.Card, criterion SortCriteria) int {
 	var result int
@@ -1524,6 +1687,18 @@ func (sb *SortBuilder) compareUserCards(ucA, ucB *models.UserCard, cardA, cardB
 		} else {
 			result = 0
 		}
+	case SortByEval:
+		evalA := EvalScore(ucA, cardA, sb.prices[cardA.ID])
+		evalB := EvalScore(ucB, cardB, sb.prices[cardB.ID])
+		if evalA < evalB {
+			result = -1
+		} else if evalA > evalB {
+			result = 1
+		} else {
+			result = 0
+		}
+	case SortByPrice:
+		result = comparePrices(sb.prices[cardA.ID], sb.prices[cardB.ID])
 	default:
 		result = 0
 	}
@@ -1536,6 +1711,20 @@ func (sb *SortBuilder) compareUserCards(ucA, ucB *models.UserCard, cardA, cardB
 	return result
 }
 
+// EvalScore ranks an owned card for >eval and <eval sorting:
+//
+//	score = 2*rating + level + log10(price+1)
+//
+// The user's own rating dominates; star level and market price break ties between equally
+// rated cards. Price is log-scaled so one expensive card cannot outweigh a rating point
+// difference, and an unknown price (0) contributes nothing.
+func EvalScore(userCard *models.UserCard, card *models.Card, price int64) float64 {
+	if price < 0 {
+		price = 0
+	}
+	return 2*float64(userCard.Rating) + float64(card.Level) + math.Log10(float64(price)+1)
+}
+
 // compareCards compares two cards based on a single criterion
 func (sb *SortBuilder) compareCards(cardA, cardB *models.Card, criterion SortCriteria) int {
 	var result int
@@ -1547,6 +1736,8 @@ func (sb *SortBuilder) compareCards(cardA, cardB *models.Card, criterion SortCri
 		result = strings.Compare(strings.ToLower(cardA.Name), strings.ToLower(cardB.Name))
 	case SortByCol:
 		result = strings.Compare(strings.ToLower(cardA.ColID), strings.ToLower(cardB.ColID))
+	case SortByPrice:
+		result = comparePrices(sb.prices[cardA.ID], sb.prices[cardB.ID])
 	default:
 		result = 0
 	}
@@ -1559,6 +1750,17 @@ func (sb *SortBuilder) compareCards(cardA, cardB *models.Card, criterion SortCri
 	return result
 }
 
+// comparePrices orders two card prices; cards without a known price count as 0
+func comparePrices(a, b int64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
 // Legacy-style sort builders for backward compatibility
 
 // SortByLevelDesc creates a legacy-style level descending sort (equivalent to firstBy((a, b) => b.level - a.level))
@@ -1581,7 +1783,7 @@ func DefaultLegacySort() *SortBuilder {
 
 // BuildSortFromFilters creates a sort builder from search filters (legacy compatibility)
 func BuildSortFromFilters(filters SearchFilters) *SortBuilder {
-	builder := NewSortBuilder()
+	builder := NewSortBuilder().WithPrices(filters.Prices)
 
 	// If sort chain is specified, use it
 	if len(filters.SortChain) > 0 {
@@ -0,0 +1,364 @@
+package utils
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/bot-template/bottemplate/database/models"
+)
+
+func TestParseObtainedFilter(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		term       string
+		wantOK     bool
+		wantAfter  time.Time
+		wantBefore time.Time
+	}{
+		{term: "obtained>2024-01-01", wantOK: true, wantAfter: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{term: "obtained<2024-02-01", wantOK: true, wantBefore: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{term: "obtained>12h", wantOK: true, wantAfter: now.Add(-12 * time.Hour)},
+		{term: "obtained>7d", wantOK: true, wantAfter: time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)},
+		{term: "obtained<2w", wantOK: true, wantBefore: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{term: "obtained=7d"},
+		{term: "obtained>"},
+		{term: "obtained>0d"},
+		{term: "obtained>-3d"},
+		{term: "obtained>7y"},
+		{term: "obtained>2024-13-01"},
+		{term: "obtained"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			var filters SearchFilters
+			ok := parseObtainedFilter(tt.term, &filters, now)
+			if ok != tt.wantOK {
+				t.Fatalf("parseObtainedFilter ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !filters.ObtainedAfter.Equal(tt.wantAfter) || !filters.ObtainedBefore.Equal(tt.wantBefore) {
+				t.Errorf("after %s, before %s; want %s and %s",
+					filters.ObtainedAfter, filters.ObtainedBefore, tt.wantAfter, tt.wantBefore)
+			}
+			if filters.UserQuery != ok {
+				t.Errorf("UserQuery = %v, want %v", filters.UserQuery, ok)
+			}
+		})
+	}
+}
+
+func TestParseSearchQueryObtainedRange(t *testing.T) {
+	filters := ParseSearchQuery("obtained>2024-01-01 obtained<2024-02-01")
+	if !filters.HasObtainedRange() {
+		t.Fatal("no obtained range parsed")
+	}
+	if filters.Name != "" {
+		t.Errorf("obtained terms leaked into the name search: %q", filters.Name)
+	}
+	if want := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC); !filters.ObtainedBefore.Equal(want) {
+		t.Errorf("before = %s, want %s", filters.ObtainedBefore, want)
+	}
+}
+
+func TestMatchesObtainedRange(t *testing.T) {
+	jan := func(day int) time.Time { return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC) }
+	filters := SearchFilters{ObtainedAfter: jan(10), ObtainedBefore: jan(20)}
+
+	tests := []struct {
+		obtained time.Time
+		want     bool
+	}{
+		{jan(5), false},
+		{jan(10), false}, // bounds are exclusive
+		{jan(15), true},
+		{jan(20), false},
+		{jan(25), false},
+	}
+	for _, tt := range tests {
+		card := &models.UserCard{Obtained: tt.obtained}
+		if got := MatchesObtainedRange(card, filters); got != tt.want {
+			t.Errorf("obtained %s: %v, want %v", tt.obtained.Format("2006-01-02"), got, tt.want)
+		}
+	}
+
+	if !MatchesObtainedRange(&models.UserCard{Obtained: jan(1)}, SearchFilters{}) {
+		t.Error("a card was filtered without an obtained range")
+	}
+}
+
+func TestWeightedSearchWithMultiObtainedRange(t *testing.T) {
+	cards := []*models.Card{
+		{ID: 1, Name: "nayeon", Level: 1},
+		{ID: 2, Name: "jeongyeon", Level: 1},
+		{ID: 3, Name: "momo", Level: 1},
+	}
+	userCards := map[int64]*models.UserCard{
+		1: {CardID: 1, Amount: 1, Obtained: time.Now().Add(-2 * time.Hour)},
+		2: {CardID: 2, Amount: 1, Obtained: time.Now().AddDate(0, 0, -30)},
+	}
+
+	results := WeightedSearchWithMulti(cards, ParseSearchQuery("obtained>1d"), userCards)
+	if len(results) != 1 || results[0].ID != 1 {
+		var ids []int64
+		for _, card := range results {
+			ids = append(ids, card.ID)
+		}
+		t.Errorf("results = %v, want only the card obtained today", ids)
+	}
+}
+
+func TestMatchesNewFilter(t *testing.T) {
+	lastDaily := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	before := &models.UserCard{Obtained: lastDaily.Add(-time.Second)}
+	atDaily := &models.UserCard{Obtained: lastDaily}
+	after := &models.UserCard{Obtained: lastDaily.Add(time.Second)}
+
+	newOnly := SearchFilters{NewOnly: true}
+	newOnly.SetLastDaily(lastDaily)
+	excludeNew := SearchFilters{ExcludeNew: true}
+	excludeNew.SetLastDaily(lastDaily)
+	neverClaimed := SearchFilters{NewOnly: true}
+	neverClaimed.SetLastDaily(time.Time{})
+
+	tests := []struct {
+		name    string
+		card    *models.UserCard
+		filters SearchFilters
+		want    bool
+	}{
+		{"-new drops cards from before the daily", before, newOnly, false},
+		{"-new drops a card obtained at the daily", atDaily, newOnly, false},
+		{"-new keeps cards from after the daily", after, newOnly, true},
+		{"!new keeps cards from before the daily", before, excludeNew, true},
+		{"!new keeps a card obtained at the daily", atDaily, excludeNew, true},
+		{"!new drops cards from after the daily", after, excludeNew, false},
+		{"every card is new without a daily", before, neverClaimed, true},
+		{"unpopulated last daily matches", before, SearchFilters{NewOnly: true}, true},
+		{"no filter", after, SearchFilters{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesNewFilter(tt.card, tt.filters); got != tt.want {
+				t.Errorf("MatchesNewFilter = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastDailyRoundTrip(t *testing.T) {
+	var filters SearchFilters
+	if _, ok := filters.LastDailyTime(); ok {
+		t.Error("LastDailyTime ok before SetLastDaily")
+	}
+
+	lastDaily := time.Date(2024, 5, 1, 9, 30, 0, 123456789, time.FixedZone("KST", 9*60*60))
+	filters.SetLastDaily(lastDaily)
+	got, ok := filters.LastDailyTime()
+	if !ok || !got.Equal(lastDaily) {
+		t.Errorf("LastDailyTime = %s, %v, want %s", got, ok, lastDaily)
+	}
+}
+
+func TestMatchesWishFilter(t *testing.T) {
+	wishlist := map[int64]bool{1: true}
+	tests := []struct {
+		name    string
+		cardID  int64
+		filters SearchFilters
+		want    bool
+	}{
+		{"-wish keeps wishlisted", 1, SearchFilters{WishOnly: true, Wishlist: wishlist}, true},
+		{"-wish drops others", 2, SearchFilters{WishOnly: true, Wishlist: wishlist}, false},
+		{"!wish drops wishlisted", 1, SearchFilters{ExcludeWish: true, Wishlist: wishlist}, false},
+		{"!wish keeps others", 2, SearchFilters{ExcludeWish: true, Wishlist: wishlist}, true},
+		{"-wish with an empty wishlist", 1, SearchFilters{WishOnly: true, Wishlist: map[int64]bool{}}, false},
+		{"unloaded wishlist matches", 2, SearchFilters{WishOnly: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesWishFilter(tt.cardID, tt.filters); got != tt.want {
+				t.Errorf("MatchesWishFilter = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		rating int64
+		level  int
+		price  int64
+		want   float64
+	}{
+		{"unrated, no price", 0, 3, 0, 3},
+		{"rating counts double", 4, 1, 0, 9},
+		{"price is log-scaled", 0, 1, 999, 4},
+		{"negative price counts as none", 2, 2, -50, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvalScore(&models.UserCard{Rating: tt.rating}, &models.Card{Level: tt.level}, tt.price)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("EvalScore = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortUserCardsByEval(t *testing.T) {
+	cardMap := map[int64]*models.Card{
+		1: {ID: 1, Name: "nayeon", Level: 5},
+		2: {ID: 2, Name: "jeongyeon", Level: 1},
+		3: {ID: 3, Name: "momo", Level: 3},
+		4: {ID: 4, Name: "sana", Level: 3},
+		5: {ID: 5, Name: "jihyo", Level: 3},
+	}
+	userCards := []*models.UserCard{
+		{CardID: 1, Rating: 0},
+		{CardID: 2, Rating: 5},
+		{CardID: 3, Rating: 2},
+		{CardID: 4, Rating: 2},
+		{CardID: 5, Rating: 2},
+	}
+	// The price lifts 4 above the equally rated 3 and 5, which fall back to level then name
+	prices := map[int64]int64{4: 5000}
+
+	NewSortBuilder().
+		FirstBy(SortByEval, true).
+		ThenBy(SortByLevel, true).
+		ThenBy(SortByName, false).
+		WithPrices(prices).
+		SortUserCards(userCards, cardMap)
+
+	var got []int64
+	for _, userCard := range userCards {
+		got = append(got, userCard.CardID)
+	}
+	if want := []int64{2, 4, 5, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestParseSearchQueryEvalSort(t *testing.T) {
+	for query, wantDesc := range map[string]bool{">eval": true, "<eval": false} {
+		filters := ParseSearchQuery(query)
+		if filters.SortBy != SortByEval || filters.SortDesc != wantDesc || !filters.UserQuery {
+			t.Errorf("%s: sort %q desc %v user %v", query, filters.SortBy, filters.SortDesc, filters.UserQuery)
+		}
+	}
+}
+
+func TestParseSearchQueryPriceSort(t *testing.T) {
+	for query, wantDesc := range map[string]bool{">price": true, "<price": false} {
+		filters := ParseSearchQuery(query)
+		if filters.SortBy != SortByPrice || filters.SortDesc != wantDesc || !filters.WithPrices {
+			t.Errorf("%s: sort %q desc %v with prices %v", query, filters.SortBy, filters.SortDesc, filters.WithPrices)
+		}
+	}
+}
+
+func TestSortUserCardsByPrice(t *testing.T) {
+	cardMap := map[int64]*models.Card{
+		1: {ID: 1, Name: "nayeon", Level: 5},
+		2: {ID: 2, Name: "jeongyeon", Level: 1},
+		3: {ID: 3, Name: "momo", Level: 3},
+		4: {ID: 4, Name: "sana", Level: 3},
+	}
+	userCards := []*models.UserCard{{CardID: 1}, {CardID: 2}, {CardID: 3}, {CardID: 4}}
+	// 1 has no price; 3 and 4 tie and fall back to name
+	prices := map[int64]int64{2: 800, 3: 150, 4: 150}
+
+	NewSortBuilder().
+		FirstBy(SortByPrice, true).
+		ThenBy(SortByLevel, true).
+		ThenBy(SortByName, false).
+		WithPrices(prices).
+		SortUserCards(userCards, cardMap)
+
+	var got []int64
+	for _, userCard := range userCards {
+		got = append(got, userCard.CardID)
+	}
+	if want := []int64{2, 3, 4, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestParseSearchQueryZeroAmount(t *testing.T) {
+	tests := []struct {
+		query string
+		want  AmountFilter
+	}{
+		{query: "=amount=0", want: AmountFilter{Exact: 0, HasExact: true}},
+		{query: ">amount=0", want: AmountFilter{Min: 1, HasMin: true}},
+		{query: "<amount=1", want: AmountFilter{Max: 0, HasMax: true}},
+		{query: "momo", want: AmountFilter{}},
+	}
+	for _, tt := range tests {
+		filters := ParseSearchQuery(tt.query)
+		if filters.AmountFilter != tt.want {
+			t.Errorf("ParseSearchQuery(%q).AmountFilter = %+v, want %+v", tt.query, filters.AmountFilter, tt.want)
+		}
+		if filters.AmountFilter.IsSet() != (tt.want != AmountFilter{}) {
+			t.Errorf("ParseSearchQuery(%q).AmountFilter.IsSet() = %t", tt.query, filters.AmountFilter.IsSet())
+		}
+	}
+}
+
+func TestWeightedSearchWithMultiZeroAmount(t *testing.T) {
+	cards := []*models.Card{
+		{ID: 1, Name: "nayeon", Level: 1},
+		{ID: 2, Name: "jeongyeon", Level: 1},
+		{ID: 3, Name: "momo", Level: 1},
+	}
+	userCards := map[int64]*models.UserCard{
+		1: {CardID: 1, Amount: 1},
+		2: {CardID: 2, Amount: 3},
+	}
+
+	tests := []struct {
+		query string
+		want  []int64
+	}{
+		{query: "=amount=0", want: []int64{3}},
+		{query: ">amount=0", want: []int64{1, 2}},
+		{query: "<amount=2", want: []int64{1, 3}},
+	}
+	for _, tt := range tests {
+		var got []int64
+		for _, card := range WeightedSearchWithMulti(cards, ParseSearchQuery(tt.query), userCards) {
+			got = append(got, card.ID)
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("WeightedSearchWithMulti(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestWeightedSearchDeterministicOrder(t *testing.T) {
+	cards := []*models.Card{
+		{ID: 4, Name: "Momo", ColID: "twice", Level: 3},
+		{ID: 2, Name: "momo", ColID: "twice", Level: 3},
+		{ID: 7, Name: "Sana", ColID: "twice", Level: 3},
+		{ID: 1, Name: "Momo", ColID: "twice", Level: 5},
+		{ID: 3, Name: "momo", ColID: "twice", Level: 3},
+	}
+	// Level first, then name, then card ID for exact duplicates
+	want := []int64{1, 2, 3, 4, 7}
+
+	for i := 0; i < len(cards); i++ {
+		rotated := append(append([]*models.Card{}, cards[i:]...), cards[:i]...)
+		var got []int64
+		for _, card := range WeightedSearch(rotated, ParseSearchQuery("")) {
+			got = append(got, card.ID)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("rotation %d: WeightedSearch = %v, want %v", i, got, want)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/disgoorg/bot-template/bottemplate/economy/claim"
 	"github.com/disgoorg/bot-template/bottemplate/economy/effects"
 	effectsHandlers "github.com/disgoorg/bot-template/bottemplate/economy/effects/handlers"
+	"github.com/disgoorg/bot-template/bottemplate/economy/vials"
 	"github.com/disgoorg/bot-template/bottemplate/handlers"
 	"github.com/disgoorg/bot-template/bottemplate/logger"
 	"github.com/disgoorg/bot-template/bottemplate/services"
@@ -41,7 +42,7 @@ var (
 func main() {
 	// Initialize custom logger with service name
 	customHandler := logger.NewHandler("GoHYE")
-	slog.SetDefault(slog.New(customHandler))
+	slog.SetDefault(slog.New(logger.NewContextHandler(customHandler)))
 
 	slog.Info("Starting Discord Bot",
 		slog.String("version", version),
@@ -58,8 +59,15 @@ func main() {
 		slog.Error("Failed to load configuration", slog.Any("error", err))
 		os.Exit(-1)
 	}
+	if err = cfg.ValidateBot(); err != nil {
+		slog.Error("Configuration is invalid", slog.Any("error", err))
+		os.Exit(-1)
+	}
 	slog.Info("Configuration loaded successfully")
 
+	// Forge exclusions must be in place before collections are cached
+	cfg.Forge.Apply()
+
 	// Apply fast DB init from config (dev convenience)
 	if cfg.DB.FastInit {
 		_ = os.Setenv("DB_FAST_INIT", "1")
@@ -83,6 +91,8 @@ func main() {
 		Password: cfg.DB.Password,
 		Database: cfg.DB.Database,
 		PoolSize: cfg.DB.PoolSize,
+
+		SlowQueryThresholdMs: cfg.DB.SlowQueryThresholdMs,
 	}
 
 	db, err := database.New(ctx, dbConfig)
@@ -130,13 +140,31 @@ func main() {
 
 	// Initialize repositories first
 	b.UserRepository = repositories.NewUserRepository(b.DB.BunDB())
+	b.UserService = services.NewUserService(
+		b.UserRepository,
+		cfg.Premium.Perks,
+		time.Duration(cfg.Premium.ExpirySweepMinutes)*time.Minute,
+	)
+	b.VoteRewards = services.NewVoteRewardService(b.DB.BunDB(), b.UserRepository, cfg.Vote.Reward)
 	b.UserCardRepository = repositories.NewUserCardRepository(b.DB.BunDB())
+	b.CardOwnership = services.NewCardOwnershipCache(
+		b.UserCardRepository,
+		cfg.Search.Rarity,
+		time.Duration(cfg.Search.OwnershipRefreshMinutes)*time.Minute,
+	)
 	b.CardRepository = repositories.NewCardRepository(b.DB.BunDB())
+	b.CardNameIndex = services.NewCardNameIndex(b.CardRepository)
 	b.ClaimRepository = repositories.NewClaimRepository(b.DB.BunDB())
 	b.CollectionRepository = repositories.NewCollectionRepository(b.DB.BunDB())
 	b.EconomyStatsRepository = repositories.NewEconomyStatsRepository(b.DB.BunDB())
 	b.WishlistRepository = repositories.NewWishlistRepository(b.DB.BunDB())
 	b.ItemRepository = repositories.NewItemRepository(b.DB.BunDB())
+	b.AdminAuditRepository = repositories.NewAdminAuditRepository(b.DB.BunDB())
+	b.CollectionProgressRepo = repositories.NewCollectionProgressRepository(b.DB.BunDB())
+	b.CompletionRewardRepo = repositories.NewCompletionRewardRepository(b.DB.BunDB())
+	b.CardSupplyRepository = repositories.NewCardSupplyRepository(b.DB.BunDB())
+	b.CurrencyLedgerRepository = repositories.NewCurrencyLedgerRepository(b.DB.BunDB())
+	b.TransferCounterRepo = repositories.NewTransferCounterRepository(b.DB.BunDB())
 	b.QuestRepository = repositories.NewQuestRepository(b.DB.BunDB())
 	tradeRepository := repositories.NewTradeRepository(b.DB.BunDB())
 
@@ -156,7 +184,7 @@ func main() {
 		b.CollectionRepository,
 		b.CardRepository,
 		b.UserCardRepository,
-	)
+	).WithProgressCache(b.CollectionProgressRepo)
 
 	// Initialize Completion Checker Service
 	b.CompletionChecker = services.NewCompletionCheckerService(
@@ -166,7 +194,7 @@ func main() {
 		b.CardRepository,
 		b.UserCardRepository,
 		b.CollectionRepository,
-	)
+	).WithRewards(b.CompletionRewardRepo, b.Cfg.Completion.Rewards)
 
 	// Initialize Quest Service
 	b.QuestService = services.NewQuestService(
@@ -176,6 +204,19 @@ func main() {
 	b.QuestTracker = services.NewQuestTracker(b.QuestService)
 	handlers.SetQuestTracker(b.QuestTracker)
 
+	// Per-guild command category toggles; admin and system commands can't be disabled
+	b.GuildCommandGate = services.NewGuildCommandGate(
+		repositories.NewGuildSettingsRepository(b.DB.BunDB()),
+		commands.CommandCategories(),
+		commands.CategoryAdmin,
+		commands.CategorySystem,
+	)
+	handlers.SetCommandGate(b.GuildCommandGate)
+
+	// Refuse interactions from users banned with /ban
+	b.BanChecker = services.NewBanChecker(b.UserRepository)
+	handlers.SetBanChecker(b.BanChecker)
+
 	// Then initialize Auction Manager with all required dependencies
 	// auctionRepo := repositories.NewAuctionRepository(b.DB.BunDB())
 	// auctionManager := auction.NewManager(
@@ -209,6 +250,7 @@ func main() {
 		},
 		b.EconomyStatsRepository,
 	)
+	priceCalc.SetOwnershipSource(b.CardOwnership)
 
 	initCtx, initCancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	if err := priceCalc.InitializeCardPrices(initCtx); err != nil {
@@ -228,11 +270,17 @@ func main() {
 			select {
 			case <-ticker.C:
 				updateCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
-				if err := priceCalc.UpdateAllPrices(updateCtx); err != nil {
+				err := priceCalc.UpdateAllPrices(updateCtx)
+				cancel()
+				if ctx.Err() != nil {
+					// Shutting down; the update was interrupted, not broken
+					return
+				}
+				if err != nil {
 					slog.Error("Failed to update prices",
 						slog.String("error", err.Error()))
 				}
-				cancel()
+				utils.RecordProcessRun(ctx, err)
 			case <-ctx.Done():
 				return
 			}
@@ -254,11 +302,20 @@ func main() {
 
 	b.PriceCalculator = priceCalc
 
-	b.ClaimManager = claim.NewManager(time.Second * 5)
+	b.ClaimManager = claim.NewManager(
+		time.Duration(cfg.Claim.CooldownSeconds)*time.Second,
+		time.Duration(cfg.Claim.SessionTimeoutSeconds)*time.Second,
+	)
+	b.ClaimManager.SetCooldownModifier(b.UserService.ClaimCooldownByID)
 
 	// Start claim cleanup process using background process manager
 	b.BackgroundProcessManager.StartProcess("claim-cleanup", "Cleans up expired claim sessions", func(ctx context.Context) {
-		b.ClaimManager.StartCleanupRoutine(ctx)
+		b.ClaimManager.RunCleanupRoutine(ctx)
+	})
+
+	b.LiquefyUndo = vials.NewUndoStore()
+	b.BackgroundProcessManager.StartProcess("liquefy-undo-cleanup", "Expires liquefy undo records", func(ctx context.Context) {
+		b.LiquefyUndo.RunCleanupRoutine(ctx)
 	})
 
 	// Start quest rotation process
@@ -275,6 +332,7 @@ func main() {
 			select {
 			case <-ticker.C:
 				now := time.Now()
+				var runErr error
 
 				// Check for daily reset (midnight)
 				currentDay := now.Truncate(24 * time.Hour)
@@ -283,12 +341,14 @@ func main() {
 
 					// Delete expired daily quests
 					if err := b.QuestRepository.DeleteExpiredQuests(ctx); err != nil {
+						runErr = err
 						slog.Error("Failed to delete expired quests",
 							slog.Any("error", err))
 					}
 
 					// Assign new daily quests to all users
 					if err := assignDailyQuestsToAllUsers(ctx, b); err != nil {
+						runErr = err
 						slog.Error("Failed to assign daily quests",
 							slog.Any("error", err))
 					}
@@ -303,6 +363,7 @@ func main() {
 
 					// Assign new weekly quests to all users
 					if err := assignWeeklyQuestsToAllUsers(ctx, b); err != nil {
+						runErr = err
 						slog.Error("Failed to assign weekly quests",
 							slog.Any("error", err))
 					}
@@ -317,12 +378,14 @@ func main() {
 
 					// Assign new monthly quests to all users
 					if err := assignMonthlyQuestsToAllUsers(ctx, b); err != nil {
+						runErr = err
 						slog.Error("Failed to assign monthly quests",
 							slog.Any("error", err))
 					}
 
 					lastMonthlyAssignment = currentMonth
 				}
+				utils.RecordProcessRun(ctx, runErr)
 
 			case <-ctx.Done():
 				return
@@ -330,6 +393,27 @@ func main() {
 		}
 	})
 
+	// Periodically rebuild quest leaderboards from completed quests
+	b.BackgroundProcessManager.StartProcess("quest-leaderboard", "Aggregates quest completions into the period leaderboards", func(ctx context.Context) {
+		ticker := time.NewTicker(15 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				refreshCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+				err := b.QuestService.RefreshLeaderboards(refreshCtx)
+				if err != nil {
+					slog.Error("Failed to refresh quest leaderboards", slog.Any("error", err))
+				}
+				cancel()
+				utils.RecordProcessRun(ctx, err)
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
 	h := handler.New()
 
 	// System commands
@@ -341,6 +425,13 @@ func main() {
 	h.Command("/init", handlers.WrapWithLogging("init", admin.InitHandler(b)))
 	h.Command("/gift", handlers.WrapWithLogging("gift", admin.GiftHandler(b)))
 	h.Command("/reset-daily", handlers.WrapWithLogging("reset-daily", admin.ResetDailyHandler(b)))
+	h.Command("/ban", handlers.WrapWithLogging("ban", admin.BanHandler(b)))
+	h.Command("/unban", handlers.WrapWithLogging("unban", admin.UnbanHandler(b)))
+	h.Command("/reload-collections", handlers.WrapWithLogging("reload-collections", admin.ReloadCollectionsHandler(b)))
+	h.Component("/reset-daily/", handlers.WrapComponentWithLogging("reset-daily", admin.ResetDailyComponentHandler(b)))
+	h.Command("/guild-config", handlers.WrapWithLogging("guild-config", admin.GuildConfigHandler(b)))
+	h.Command("/processes", handlers.WrapWithLogging("processes", admin.ProcessesHandler(b)))
+	h.Autocomplete("/processes", admin.ProcessesAutocomplete(b))
 
 	// Card-related commands
 	h.Command("/summon", handlers.WrapWithLogging("summon", cards.SummonHandler(b)))
@@ -354,23 +445,32 @@ func main() {
 	h.Command("/fixduplicates", handlers.WrapWithLogging("fixduplicates", admin.FixDuplicatesHandler(b)))
 	h.Command("/levelup", handlers.WrapWithLogging("levelup", cards.LevelUpHandler(b)))
 	h.Command("/analyze-economy", handlers.WrapWithLogging("analyze-economy", admin.AnalyzeEconomyHandler(b)))
+	h.Command("/economy-config", handlers.WrapWithLogging("economy-config", admin.EconomyConfigHandler(b)))
 	h.Command("/manage-images", handlers.WrapWithLogging("manage-images", admin.ManageImagesHandler(b)))
 	h.Autocomplete("/manage-images", admin.ManageImagesAutocomplete(b))
 
 	// User-Related Commands
 	h.Command("/balance", handlers.WrapWithLogging("balance", economyCommands.BalanceHandler(b)))
 	h.Command("/daily", handlers.WrapWithLogging("daily", economyCommands.DailyHandler(b)))
+	h.Command("/gift-item", handlers.WrapWithLogging("gift-item", economyCommands.GiftItemHandler(b)))
+	h.Command("/vote", handlers.WrapWithLogging("vote", economyCommands.VoteHandler(b)))
 	h.Command("/wish", handlers.WrapWithLogging("wish", social.WishHandler(b)))
+	h.Autocomplete("/wish", cards.CardNameAutocomplete(b))
 	h.Command("/has", handlers.WrapWithLogging("has", social.HasHandler(b)))
+	h.Autocomplete("/has", cards.CardNameAutocomplete(b))
 	h.Command("/miss", handlers.WrapWithLogging("miss", social.MissHandler(b)))
 	h.Command("/diff", handlers.WrapWithLogging("diff", social.DiffHandler(b)))
 
 	// Vial Related Commands
 	h.Command("/liquefy", handlers.WrapWithLogging("liquefy", economyCommands.NewLiquefyHandler(b).HandleLiquefy))
+	h.Autocomplete("/liquefy", cards.CardNameAutocomplete(b))
 	h.Component("/liquefy/", handlers.WrapComponentWithLogging("liquefy", economyCommands.NewLiquefyHandler(b).HandleComponent))
 
 	// Forge Related Commands
 	h.Command("/forge", handlers.WrapWithLogging("forge", cards.NewForgeHandler(b).HandleForge))
+	h.Autocomplete("/forge", cards.CardNameAutocomplete(b))
+	h.Command("/hero", handlers.WrapWithLogging("hero", cards.HeroHandler(b)))
+	h.Autocomplete("/hero", cards.CardNameAutocomplete(b))
 	h.Component("/forge/", handlers.WrapComponentWithLogging("forge", cards.NewForgeHandler(b).HandleComponent))
 
 	// Work Related Commands
@@ -384,14 +484,16 @@ func main() {
 	h.Component("/fuse/", handlers.WrapComponentWithLogging("fuse", fuseHandler.HandleComponent))
 
 	// Initialize modern effect system
+	effectRepo := repositories.NewEffectRepository(b.DB.BunDB())
 	effectManager := effects.NewManager(
-		repositories.NewEffectRepository(b.DB.BunDB()),
+		effectRepo,
 		b.UserRepository,
 		b.UserCardRepository,
 		b.CardRepository,
 		b.CollectionRepository,
 		b.DB,
 	)
+	effectManager.SetCurrencyLedger(b.CurrencyLedgerRepository)
 	b.EffectManager = effectManager
 
 	// Initialize modern effect integrator
@@ -426,6 +528,7 @@ func main() {
 		effectsHandlers.NewSpaceUnityHandler(deps),
 		effectsHandlers.NewWalpurgisNightHandler(deps),
 		effectsHandlers.NewJudgeDayHandler(deps, registry),
+		effectsHandlers.NewFandomFeverHandler(deps),
 	}
 
 	for _, effect := range activeEffects {
@@ -440,6 +543,30 @@ func main() {
 		slog.Int("passive_effects", len(passiveEffects)),
 		slog.Int("active_effects", len(activeEffects)))
 
+	// Seed limited shop stock and keep it refilled
+	if err := effectManager.InitializeShopStock(ctx); err != nil {
+		slog.Error("Failed to initialize shop stock", slog.Any("error", err))
+	}
+	b.BackgroundProcessManager.StartProcess("shop-restock", "Refills limited shop items on their restock schedule", func(ctx context.Context) {
+		ticker := time.NewTicker(5 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				restocked, err := effectManager.RestockShop(ctx)
+				if err != nil {
+					slog.Error("Failed to restock shop", slog.Any("error", err))
+				} else if restocked > 0 {
+					slog.Info("Restocked limited shop items", slog.Int("items", restocked))
+				}
+				utils.RecordProcessRun(ctx, err)
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
 	slog.Info("Modern effect system initialized successfully",
 		slog.String("system", "modern_v2"),
 		slog.String("component", "effect_manager"))
@@ -459,6 +586,7 @@ func main() {
 	h.Command("/inventory", handlers.WrapWithLogging("inventory", inventoryHandler.Handle))
 	h.Component("/inventory_category/", handlers.WrapComponentWithLogging("inventory_category", inventoryHandler.HandleComponent))
 	h.Component("/inventory_item/", handlers.WrapComponentWithLogging("inventory_item", inventoryHandler.HandleComponent))
+	h.Component("/inventory_page/", handlers.WrapComponentWithLogging("inventory_page", inventoryHandler.HandleComponent))
 
 	effectInfoHandler := system.NewEffectInfoHandler(b, effectManager)
 	h.Command("/effect", handlers.WrapWithLogging("effect", effectInfoHandler.Handle))
@@ -483,10 +611,13 @@ func main() {
 
 	// Profile command
 	h.Command("/profile", handlers.WrapWithLogging("profile", system.ProfileHandler(b)))
+	h.Command("/preferences", handlers.WrapWithLogging("preferences", system.PreferencesHandler(b)))
+	h.Component("/preferences/", handlers.WrapComponentWithLogging("preferences", system.PreferencesComponentHandler(b)))
 
 	// Quest commands
 	h.Command("/quests", handlers.WrapWithLogging("quests", system.QuestsHandler(b)))
 	h.Command("/questclaim", handlers.WrapWithLogging("questclaim", system.QuestClaimHandler(b)))
+	h.Command("/quest-leaderboard", handlers.WrapWithLogging("quest-leaderboard", system.QuestLeaderboardHandler(b)))
 	h.Component("/quest/", handlers.WrapComponentWithLogging("quest", system.QuestComponentHandler(b)))
 
 	// Claim commands
@@ -495,6 +626,7 @@ func main() {
 	h.Component("/claim/next/", handlers.WrapComponentWithLogging("claim", claimHandler.HandleComponent))
 	h.Component("/claim/prev/", handlers.WrapComponentWithLogging("claim", claimHandler.HandleComponent))
 	h.Component("/claim/favorite/", handlers.WrapComponentWithLogging("claim", claimHandler.HandleComponent))
+	h.Component("/claim/pick/", handlers.WrapComponentWithLogging("claim", claimHandler.HandleComponent))
 
 	// Add this with the other component handlers
 	h.Component("/cards/", handlers.WrapComponentWithLogging("cards", cards.CardsComponentHandler(b)))
@@ -529,6 +661,38 @@ func main() {
 		b.CompletionChecker.SetClient(b.Client)
 	}
 
+	// Start effect expiry sweeper now that DMs can be sent
+	expirySweeper := effects.NewExpirySweeper(
+		effectRepo,
+		services.NewEffectNotifier(b.Client, b.UserRepository),
+		time.Duration(cfg.Effects.ExpirySweepMinutes)*time.Minute,
+	)
+	b.BackgroundProcessManager.StartProcess("effect-expiry-sweeper", "Deactivates expired effects and notifies their owners", expirySweeper.Run)
+
+	// Clear promo EXP once its promo period is over, when an expiry is configured
+	if cfg.Promo.ExpExpiryDays > 0 {
+		promoExpDecayer := services.NewPromoExpDecayer(
+			b.UserRepository,
+			time.Duration(cfg.Promo.ExpExpiryDays)*24*time.Hour,
+			time.Duration(cfg.Promo.DecaySweepMinutes)*time.Minute,
+		)
+		b.BackgroundProcessManager.StartProcess("promo-exp-decay", "Clears promo EXP after the promo period", promoExpDecayer.Run)
+	}
+
+	// Keep owner and copy counts fresh for the rarity search filters and pricing
+	b.BackgroundProcessManager.StartProcess("card-ownership-refresh", "Reloads per-card owner and copy counts", b.CardOwnership.Run)
+
+	// Turn premium off once it runs out so perks stop applying
+	b.BackgroundProcessManager.StartProcess("premium-expiry", "Turns off premium that has expired", b.UserService.Run)
+
+	// Publish process state for the dashboard and pick up restarts requested there
+	processSupervisor := services.NewProcessSupervisor(
+		b.BackgroundProcessManager,
+		repositories.NewBackgroundProcessRepository(b.DB.BunDB()),
+		services.DefaultProcessSyncInterval,
+	)
+	b.BackgroundProcessManager.StartProcess(services.ProcessSupervisorName, "Publishes background process state and applies dashboard restarts", processSupervisor.Run)
+
 	// Initialize auction manager with the now-initialized client
 	auctionManager := auction.NewManager(
 		repositories.NewAuctionRepository(db.BunDB()),
@@ -539,6 +703,10 @@ func main() {
 
 	// Store the auction manager in the bot instance
 	b.AuctionManager = auctionManager
+	auctionManager.SetWatcherRepository(repositories.NewAuctionWatcherRepository(db.BunDB()))
+	auctionManager.SetFees(cfg.Economy.Auction)
+	auctionManager.SetBidIncrement(cfg.Economy.Bidding)
+	auctionManager.SetUserRepository(b.UserRepository)
 
 	// Set quest tracker for auction wins
 	if b.QuestTracker != nil {